@@ -14,6 +14,7 @@ type Clients struct {
 	user        userpb.UserServiceClient
 	auth        authpb.AuthServiceClient
 	security    securitypb.SecurityServiceClient
+	audit       securitypb.AuditServiceClient
 	broker      brokerpb.BrokerServiceClient
 	transaction transactionpb.TransactionServiceClient
 }
@@ -36,6 +37,10 @@ func WithSecurityClient(security securitypb.SecurityServiceClient) ClientOption
 	return func(c *Clients) { c.security = security }
 }
 
+func WithAuditClient(audit securitypb.AuditServiceClient) ClientOption {
+	return func(c *Clients) { c.audit = audit }
+}
+
 func WithBrokerClient(broker brokerpb.BrokerServiceClient) ClientOption {
 	return func(c *Clients) { c.broker = broker }
 }
@@ -68,6 +73,10 @@ func (c Clients) Security() securitypb.SecurityServiceClient {
 	return c.security
 }
 
+func (c Clients) Audit() securitypb.AuditServiceClient {
+	return c.audit
+}
+
 func (c Clients) Broker() brokerpb.BrokerServiceClient {
 	return c.broker
 }
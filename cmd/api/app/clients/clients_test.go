@@ -51,6 +51,13 @@ func TestNewClients_WithServiceOptions(t *testing.T) {
 		assert.Equal(t, mockService, c.Security())
 	})
 
+	t.Run("Audit client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := mocks.NewMockAuditServiceClient(ctrl)
+		c := NewClients(WithAuditClient(mockService))
+		assert.Equal(t, mockService, c.Audit())
+	})
+
 	t.Run("Broker client", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockService := mocks.NewMockBrokerServiceClient(ctrl)
@@ -8,6 +8,8 @@ import (
 	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
 	"github.com/Zapharaos/fihub-backend/internal/models"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"io"
 	"net/http"
 )
@@ -21,18 +23,23 @@ import (
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
+//	@Param			lang	query	string					false	"Language code"
 //	@Param			user	body	models.UserWithPassword	true	"login & user (json)"
 //	@Security		Bearer
 //	@Success		200	{object}	string			"jwt token"
 //	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
 //	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		429	{object}	render.ErrorResponse	"Too many failed attempts"
 //	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
 //	@Router			/api/v1/auth/token [post]
 func GetToken(w http.ResponseWriter, r *http.Request) {
 	var creds models.UserWithPassword
 
 	var (
-		ErrLoginInvalid = errors.New("login-invalid")
+		ErrLoginInvalid       = errors.New("login-invalid")
+		ErrLoginUnverified    = errors.New("email-unverified")
+		ErrLoginPasswordReset = errors.New("password-reset-required")
+		ErrLoginDisabled      = errors.New("account-disabled")
 	)
 
 	// Read the request body
@@ -45,14 +52,123 @@ func GetToken(w http.ResponseWriter, r *http.Request) {
 
 	// Validate the credentials and return the token
 	response, err := clients.C().Auth().GenerateToken(r.Context(), &authpb.GenerateTokenRequest{
-		Email:    creds.Email,
-		Password: creds.Password,
+		Email:     creds.Email,
+		Password:  creds.Password,
+		IpAddress: U().GetClientIP(r),
+		Language:  U().ParseParamLanguage(w, r).String(),
+		UserAgent: r.UserAgent(),
 	})
 	if err != nil {
 		zap.L().Warn("GetToken: failed", zap.Error(err))
+
+		switch status.Code(err) {
+		case codes.ResourceExhausted:
+			// Too many failed attempts
+			render.ErrorCodesCodeToHttpCode(w, r, err)
+			return
+		case codes.FailedPrecondition:
+			// Valid credentials, but the email address is not verified yet or the password must be reset first
+			if status.Convert(err).Message() == ErrLoginPasswordReset.Error() {
+				render.BadRequest(w, r, ErrLoginPasswordReset)
+				return
+			}
+			render.BadRequest(w, r, ErrLoginUnverified)
+			return
+		case codes.PermissionDenied:
+			// Valid credentials, but the account is disabled
+			render.BadRequest(w, r, ErrLoginDisabled)
+			return
+		}
+
 		render.BadRequest(w, r, ErrLoginInvalid)
 		return
 	}
 
 	render.JSON(w, r, response.Token)
 }
+
+// UnlockUser godoc
+//
+//	@Id				UnlockUser
+//
+//	@Summary		Unlock a user account
+//	@Description	Removes the login lockout of a user account after too many failed attempts. (Permission: <b>admin.users.unlock</b>)
+//	@Tags			User
+//	@Produce		json
+//	@Param			id	path	string	true	"user ID"
+//	@Security		Bearer
+//	@Success		200	{string}	string					"status OK"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{string}	string					"User not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/{id}/lock [delete]
+func UnlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// Unlock user
+	_, err := clients.C().Auth().UnlockUser(r.Context(), &authpb.UnlockUserRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		zap.L().Error("Unlock user", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.OK(w, r)
+}
+
+// ImpersonateUser godoc
+//
+//	@Id				ImpersonateUser
+//
+//	@Summary		Impersonate a user
+//	@Description	Issues a short-lived token to act as the user, every action remains attributed to the caller. Administrators and users holding permissions the caller does not have cannot be impersonated. (Permission: <b>admin.users.impersonate</b>)
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path	string						true	"user ID"
+//	@Param			request		body	models.ImpersonationRequest	true	"impersonation (json) : reason"
+//	@Security		Bearer
+//	@Success		200	{object}	models.ImpersonationToken	"impersonation token"
+//	@Failure		400	{object}	render.ErrorResponse		"Bad PasswordRequest"
+//	@Failure		401	{string}	string						"Permission denied"
+//	@Failure		404	{string}	string						"User not found"
+//	@Failure		500	{object}	render.ErrorResponse		"Internal Server Error"
+//	@Router			/api/v1/user/{id}/impersonate [post]
+func ImpersonateUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var request models.ImpersonationRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		zap.L().Warn("Impersonation json decode", zap.Error(err))
+		render.BadRequest(w, r, nil)
+		return
+	}
+
+	// Impersonate user
+	response, err := clients.C().Auth().ImpersonateUser(r.Context(), &authpb.ImpersonateUserRequest{
+		UserId:    userID.String(),
+		Reason:    request.Reason,
+		IpAddress: U().GetClientIP(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		zap.L().Error("Impersonate user", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, models.ImpersonationToken{
+		Token:     response.GetToken(),
+		ExpiresAt: response.GetExpiresAt().AsTime(),
+	})
+}
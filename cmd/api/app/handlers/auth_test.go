@@ -5,17 +5,22 @@ import (
 	"encoding/json"
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
 	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
 	"github.com/Zapharaos/fihub-backend/internal/models"
 	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestGetToken(t *testing.T) {
@@ -36,6 +41,7 @@ func TestGetToken(t *testing.T) {
 		body           []byte
 		mockSetup      func(ctrl *gomock.Controller)
 		expectedStatus int
+		expectedError  string
 	}{
 		{
 			name: "fails to decode",
@@ -53,6 +59,10 @@ func TestGetToken(t *testing.T) {
 			name: "fails to generate token",
 			body: validCredsBody,
 			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetClientIP(gomock.Any()).Return("127.0.0.1")
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
+				handlers.ReplaceGlobals(m)
 				ac := mocks.NewMockAuthServiceClient(ctrl)
 				ac.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unknown, "error"))
 				clients.ReplaceGlobals(clients.NewClients(
@@ -61,10 +71,81 @@ func TestGetToken(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "fails with too many attempts",
+			body: validCredsBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetClientIP(gomock.Any()).Return("127.0.0.1")
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.ResourceExhausted, "login-locked"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name: "fails with unverified email",
+			body: validCredsBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetClientIP(gomock.Any()).Return("127.0.0.1")
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.FailedPrecondition, "email-unverified"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "email-unverified",
+		},
+		{
+			name: "fails with a required password reset",
+			body: validCredsBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetClientIP(gomock.Any()).Return("127.0.0.1")
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.FailedPrecondition, "password-reset-required"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "password-reset-required",
+		},
+		{
+			name: "fails with a disabled account",
+			body: validCredsBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetClientIP(gomock.Any()).Return("127.0.0.1")
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "account-disabled"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "account-disabled",
+		},
 		{
 			name: "succeeded",
 			body: validCredsBody,
 			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetClientIP(gomock.Any()).Return("127.0.0.1")
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
+				handlers.ReplaceGlobals(m)
 				ac := mocks.NewMockAuthServiceClient(ctrl)
 				ac.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return(validResponse, nil)
 				clients.ReplaceGlobals(clients.NewClients(
@@ -90,6 +171,185 @@ func TestGetToken(t *testing.T) {
 			response := w.Result()
 			defer response.Body.Close()
 
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+			if tt.expectedError != "" {
+				var body render.ErrorResponse
+				assert.NoError(t, json.NewDecoder(response.Body).Decode(&body))
+				assert.Equal(t, tt.expectedError, body.Message)
+			}
+		})
+	}
+}
+
+// TestUnlockUser tests the UnlockUser handler
+func TestUnlockUser(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().UnlockUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to unlock the user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().UnlockUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "Permission denied"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().UnlockUser(gomock.Any(), gomock.Any()).Return(&authpb.UnlockUserResponse{Success: true}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", apiBasePath+"/user/{id}/lock", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.UnlockUser(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestImpersonateUser tests the ImpersonateUser handler
+func TestImpersonateUser(t *testing.T) {
+	// Prepare data
+	validBody, _ := json.Marshal(models.ImpersonationRequest{Reason: "Ticket #42"})
+	expiresAt := time.Now().Add(30 * time.Minute)
+
+	// Test cases
+	tests := []struct {
+		name           string
+		body           []byte
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			body: validBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().ImpersonateUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to decode",
+			body: []byte("invalid"),
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().ImpersonateUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fails to impersonate the user",
+			body: validBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				m.EXPECT().GetClientIP(gomock.Any()).Return("127.0.0.1")
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().ImpersonateUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "impersonation-admin"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Succeeded",
+			body: validBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				m.EXPECT().GetClientIP(gomock.Any()).Return("127.0.0.1")
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().ImpersonateUser(gomock.Any(), gomock.Any()).Return(&authpb.ImpersonateUserResponse{
+					Token:     "impersonation-token",
+					ExpiresAt: timestamppb.New(expiresAt),
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", apiBasePath+"/user/{id}/impersonate", bytes.NewBuffer(tt.body))
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.ImpersonateUser(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
 			assert.Equal(t, tt.expectedStatus, response.StatusCode)
 		})
 	}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+// ListOutboxEmails godoc
+//
+//	@Id				ListOutboxEmails
+//
+//	@Summary		List the emails of the outbox
+//	@Description	Lists the emails enqueued for delivery along with their delivery status, from the most recently enqueued one. The contents of the emails are never returned. (Permission: <b>admin.emails.read</b>)
+//	@Tags			Email
+//	@Produce		json
+//	@Param			status		query	string	false	"status (pending, sending, sent, dead)"
+//	@Param			recipient	query	string	false	"recipient address"
+//	@Param			limit		query	int		false	"maximum number of emails"
+//	@Param			offset		query	int		false	"number of emails to skip"
+//	@Security		Bearer
+//	@Success		200	{array}		models.OutboxEmail		"list of emails"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad Request"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/email/outbox [get]
+func ListOutboxEmails(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// Construct the search from the query parameters
+	req := &userpb.ListOutboxEmailsRequest{
+		Status:    query.Get("status"),
+		Recipient: query.Get("recipient"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			zap.L().Warn("Invalid limit parameter", zap.String("limit", limit), zap.Error(err))
+			render.BadRequest(w, r, errors.New("limit-invalid"))
+			return
+		}
+		req.Limit = int32(parsed)
+	}
+	if offset := query.Get("offset"); offset != "" {
+		parsed, err := strconv.ParseInt(offset, 10, 32)
+		if err != nil {
+			zap.L().Warn("Invalid offset parameter", zap.String("offset", offset), zap.Error(err))
+			render.BadRequest(w, r, errors.New("offset-invalid"))
+			return
+		}
+		req.Offset = int32(parsed)
+	}
+
+	// List emails
+	response, err := clients.C().User().ListOutboxEmails(r.Context(), req)
+	if err != nil {
+		zap.L().Error("List outbox emails", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.OutboxEmailsFromProto(response.GetEmails()))
+}
+
+// GetOutboxEmail godoc
+//
+//	@Id				GetOutboxEmail
+//
+//	@Summary		Get an email of the outbox
+//	@Description	Gets the delivery status of an email enqueued for delivery. The contents of the email are never returned. (Permission: <b>admin.emails.read</b>)
+//	@Tags			Email
+//	@Produce		json
+//	@Param			id	path	string	true	"email ID"
+//	@Security		Bearer
+//	@Success		200	{object}	models.OutboxEmail		"email"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad Request"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{string}	string					"Email not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/email/outbox/{id} [get]
+func GetOutboxEmail(w http.ResponseWriter, r *http.Request) {
+	emailID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// Get email
+	response, err := clients.C().User().GetOutboxEmail(r.Context(), &userpb.GetOutboxEmailRequest{
+		Id: emailID.String(),
+	})
+	if err != nil {
+		zap.L().Error("Get outbox email", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.OutboxEmailFromProto(response.GetEmail()))
+}
+
+// RetryOutboxEmail godoc
+//
+//	@Id				RetryOutboxEmail
+//
+//	@Summary		Retry the delivery of a dead email
+//	@Description	Starts over the delivery of an email which exhausted its attempts, with a fresh count of attempts. (Permission: <b>admin.emails.retry</b>)
+//	@Tags			Email
+//	@Produce		json
+//	@Param			id	path	string	true	"email ID"
+//	@Security		Bearer
+//	@Success		200	{object}	models.OutboxEmail		"email"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad Request"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{string}	string					"Email not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/email/outbox/{id}/retry [post]
+func RetryOutboxEmail(w http.ResponseWriter, r *http.Request) {
+	emailID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// Retry email
+	response, err := clients.C().User().RetryOutboxEmail(r.Context(), &userpb.RetryOutboxEmailRequest{
+		Id: emailID.String(),
+	})
+	if err != nil {
+		zap.L().Error("Retry outbox email", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.OutboxEmailFromProto(response.GetEmail()))
+}
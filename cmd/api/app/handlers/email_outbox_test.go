@@ -0,0 +1,269 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestListOutboxEmails tests the ListOutboxEmails handler
+func TestListOutboxEmails(t *testing.T) {
+	emails := []*userpb.OutboxEmail{
+		{Id: uuid.New().String(), To: []string{"jane@example.com"}, Status: models.OutboxStatusDead},
+		{Id: uuid.New().String(), To: []string{"jane@example.com"}, Status: models.OutboxStatusDead},
+	}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+		expectedLength int
+	}{
+		{
+			name:  "Fails to parse the limit",
+			query: "?limit=ten",
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListOutboxEmails(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Fails to parse the offset",
+			query: "?offset=ten",
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListOutboxEmails(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fails to list emails",
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListOutboxEmails(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "forbidden"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "Succeeded",
+			query: "?status=dead&recipient=jane@example.com&limit=10&offset=20",
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListOutboxEmails(gomock.Any(), &userpb.ListOutboxEmailsRequest{
+					Status:    models.OutboxStatusDead,
+					Recipient: "jane@example.com",
+					Limit:     10,
+					Offset:    20,
+				}).Return(&userpb.ListOutboxEmailsResponse{
+					Emails: emails,
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+			expectedLength: 2,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/email/outbox"+tt.query, nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.ListOutboxEmails(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				var result []models.OutboxEmail
+				assert.NoError(t, json.NewDecoder(response.Body).Decode(&result))
+				assert.Len(t, result, tt.expectedLength)
+			}
+		})
+	}
+}
+
+// TestGetOutboxEmail tests the GetOutboxEmail handler
+func TestGetOutboxEmail(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().GetOutboxEmail(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to get email",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().GetOutboxEmail(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "email not found"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				emailID := uuid.New()
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(emailID, true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().GetOutboxEmail(gomock.Any(), &userpb.GetOutboxEmailRequest{Id: emailID.String()}).Return(&userpb.GetOutboxEmailResponse{
+					Email: &userpb.OutboxEmail{Id: emailID.String(), Status: models.OutboxStatusSent},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/email/outbox/{id}", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.GetOutboxEmail(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestRetryOutboxEmail tests the RetryOutboxEmail handler
+func TestRetryOutboxEmail(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().RetryOutboxEmail(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to retry an email which is not dead",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().RetryOutboxEmail(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.FailedPrecondition, "email-not-dead"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				emailID := uuid.New()
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(emailID, true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().RetryOutboxEmail(gomock.Any(), &userpb.RetryOutboxEmailRequest{Id: emailID.String()}).Return(&userpb.RetryOutboxEmailResponse{
+					Email: &userpb.OutboxEmail{Id: emailID.String(), Status: models.OutboxStatusPending},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", apiBasePath+"/email/outbox/{id}/retry", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.RetryOutboxEmail(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
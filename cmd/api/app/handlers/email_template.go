@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// PreviewEmailTemplate godoc
+//
+//	@Id				PreviewEmailTemplate
+//
+//	@Summary		Preview an email template
+//	@Description	Renders an email template of the catalog with sample data, in the requested language. (Permission: <b>admin.emails.preview</b>)
+//	@Tags			Email
+//	@Produce		json
+//	@Param			name	path	string	true	"template name (welcome, email-verification, password-reset, password-changed, email-changed, new-login, account-locked, export-ready, deletion-scheduled, weekly-summary)"
+//	@Param			lang	query	string	false	"Language code"
+//	@Security		Bearer
+//	@Success		200	{object}	models.EmailTemplatePreview	"rendered template"
+//	@Failure		400	{object}	render.ErrorResponse		"Bad Request"
+//	@Failure		401	{string}	string						"Permission denied"
+//	@Failure		404	{string}	string						"Template not found"
+//	@Failure		500	{object}	render.ErrorResponse		"Internal Server Error"
+//	@Router			/api/v1/email/template/{name}/preview [get]
+func PreviewEmailTemplate(w http.ResponseWriter, r *http.Request) {
+	name, ok := U().ParseParamString(w, r, "name")
+	if !ok {
+		return
+	}
+
+	// Preview template
+	response, err := clients.C().User().PreviewEmailTemplate(r.Context(), &userpb.PreviewEmailTemplateRequest{
+		Name:     name,
+		Language: U().ParseParamLanguage(w, r).String(),
+	})
+	if err != nil {
+		zap.L().Error("Preview email template", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.EmailTemplatePreviewFromProto(response))
+}
@@ -0,0 +1,123 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestPreviewEmailTemplate tests the PreviewEmailTemplate handler
+func TestPreviewEmailTemplate(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		expectedStatus  int
+		expectedSubject string
+	}{
+		{
+			name: "Fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamString(gomock.Any(), gomock.Any(), "name").Return("", false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().PreviewEmailTemplate(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to find the template",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamString(gomock.Any(), gomock.Any(), "name").Return("unknown", true)
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().PreviewEmailTemplate(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "template not found"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Fails without permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamString(gomock.Any(), gomock.Any(), "name").Return("welcome", true)
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().PreviewEmailTemplate(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "forbidden"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamString(gomock.Any(), gomock.Any(), "name").Return("welcome", true)
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.French)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().PreviewEmailTemplate(gomock.Any(), &userpb.PreviewEmailTemplateRequest{
+					Name:     "welcome",
+					Language: "fr",
+				}).Return(&userpb.PreviewEmailTemplateResponse{
+					Name:     "welcome",
+					Language: "fr",
+					Subject:  "Bienvenue sur Fihub",
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus:  http.StatusOK,
+			expectedSubject: "Bienvenue sur Fihub",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/email/template/{name}/preview", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.PreviewEmailTemplate(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+			if tt.expectedSubject != "" {
+				var result models.EmailTemplatePreview
+				assert.NoError(t, json.NewDecoder(response.Body).Decode(&result))
+				assert.Equal(t, tt.expectedSubject, result.Subject)
+			}
+		})
+	}
+}
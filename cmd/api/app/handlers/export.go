@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
+	"github.com/Zapharaos/fihub-backend/gen/go/brokerpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/transactionpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
+	"github.com/Zapharaos/fihub-backend/internal/export"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/pkg/email"
+	"github.com/Zapharaos/fihub-backend/pkg/email/templates"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ExportUserSelf godoc
+//
+//	@Id				ExportUserSelf
+//
+//	@Summary		Export the data of the currently authenticated user
+//	@Description	Starts an export of the data held on the currently authenticated user. Once ready, a ZIP archive can be downloaded through the expiring link sent by email.
+//	@Tags			User
+//	@Param			lang	query	string	false	"Language code (defaults to the language of the user)"
+//	@Security		Bearer
+//	@Success		202	{string}	string					"Export started"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad Request"
+//	@Failure		429	{object}	render.ErrorResponse	"Too Many Requests"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/me/export [post]
+func ExportUserSelf(w http.ResponseWriter, r *http.Request) {
+	userID, found := U().GetUserIDFromContext(r)
+	if !found {
+		zap.L().Debug("No context user provided")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// The job outlives the request : keep its values (identity assertion included) but not its cancellation.
+	// The identity assertion is short-lived, hence the job must complete within its lifetime.
+	ctx := context.WithoutCancel(r.Context())
+	lang := r.URL.Query().Get("lang") // Defaults to the language stored in the profile of the user
+
+	err := export.S().Submit(func() {
+		ctx, cancel := context.WithTimeout(ctx, grpcutil.IdentityAssertionTTL)
+		defer cancel()
+		exportUserData(ctx, userID, lang)
+	})
+	if err != nil {
+		zap.L().Warn("Submit export", zap.String("user_id", userID), zap.Error(err))
+		if errors.Is(err, export.ErrTooManyExports) {
+			render.TooManyRequests(w, r, err, time.Minute)
+			return
+		}
+		render.Error(w, r, err, "Submit export")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// DownloadUserExport godoc
+//
+//	@Id				DownloadUserExport
+//
+//	@Summary		Download a data export
+//	@Description	Downloads the ZIP archive of a data export, using the signed link sent by email.
+//	@Tags			User
+//	@Produce		application/zip
+//	@Param			token	path	string	true	"download token"
+//	@Success		200	{file}		file					"ZIP archive"
+//	@Failure		404	{object}	render.ErrorResponse	"Export not found or expired"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/export/{token} [get]
+func DownloadUserExport(w http.ResponseWriter, r *http.Request) {
+	token, ok := U().ParseParamString(w, r, "token")
+	if !ok {
+		return
+	}
+
+	userID, archive, err := export.S().Open(token)
+	if err != nil {
+		if errors.Is(err, export.ErrLinkInvalid) || errors.Is(err, export.ErrArchiveNotFound) {
+			zap.L().Warn("Open export", zap.Error(err))
+			render.NotFound(w, r, err)
+			return
+		}
+		zap.L().Error("Open export", zap.Error(err))
+		render.Error(w, r, err, "Open export")
+		return
+	}
+
+	// Keep track of the download
+	audit.Facade().Record(r.Context(), models.AuditLog{
+		Action:     models.AuditActionUserExportDownload,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetUser,
+		TargetID:   userID.String(),
+		IPAddress:  U().GetClientIP(r),
+	})
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="fihub-export.zip"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(archive)
+}
+
+// exportUserData gathers the data held on the user, stores the archive and sends the download link by email
+func exportUserData(ctx context.Context, userID string, lang string) {
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		zap.L().Error("Invalid user ID", zap.String("user_id", userID), zap.Error(err))
+		return
+	}
+
+	// Gather and pack the data
+	data, err := collectUserExport(ctx, userID)
+	if err != nil {
+		zap.L().Error("Collect user export", zap.String("user_id", userID), zap.Error(err))
+		auditUserExport(ctx, parsedUserID, models.AuditOutcomeFailure, models.AuditDetails{"error": "collect"})
+		return
+	}
+
+	archive, err := export.Archive(data)
+	if err != nil {
+		zap.L().Error("Archive user export", zap.String("user_id", userID), zap.Error(err))
+		auditUserExport(ctx, parsedUserID, models.AuditOutcomeFailure, models.AuditDetails{"error": "archive"})
+		return
+	}
+
+	// Store the archive and sign its download link
+	link, err := export.S().Store(parsedUserID, archive)
+	if err != nil {
+		zap.L().Error("Store user export", zap.String("user_id", userID), zap.Error(err))
+		auditUserExport(ctx, parsedUserID, models.AuditOutcomeFailure, models.AuditDetails{"error": "store"})
+		return
+	}
+
+	// Notify the user
+	err = enqueueExportReadyEmail(data.User.Email, data.User.EmailLanguage(lang), link)
+	if err != nil {
+		zap.L().Error("Enqueue export email", zap.String("user_id", userID), zap.Error(err))
+		auditUserExport(ctx, parsedUserID, models.AuditOutcomeFailure, models.AuditDetails{
+			"export_id": link.ID.String(),
+			"error":     "email",
+		})
+		return
+	}
+
+	auditUserExport(ctx, parsedUserID, models.AuditOutcomeSuccess, models.AuditDetails{
+		"export_id":  link.ID.String(),
+		"expires_at": link.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// discardUserExports removes the export archives of a user scheduled for deletion, the archives are only stored by the gateway
+func discardUserExports(userID string) {
+	exports := export.S()
+	if exports == nil {
+		return
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		zap.L().Error("Invalid user ID", zap.String("user_id", userID), zap.Error(err))
+		return
+	}
+
+	if err = exports.Discard(parsedUserID); err != nil {
+		zap.L().Error("Discard user exports", zap.String("user_id", userID), zap.Error(err))
+	}
+}
+
+// collectUserExport retrieves the data held on the user from every microservice
+func collectUserExport(ctx context.Context, userID string) (models.UserExport, error) {
+	userResponse, err := clients.C().User().GetUser(ctx, &userpb.GetUserRequest{Id: userID})
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("get user: %w", err)
+	}
+
+	brokersResponse, err := clients.C().Broker().ListUserBrokers(ctx, &brokerpb.ListUserBrokersRequest{UserId: userID})
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("list user brokers: %w", err)
+	}
+
+	transactionsResponse, err := clients.C().Transaction().ListTransactions(ctx, &transactionpb.ListTransactionsRequest{UserId: userID})
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("list transactions: %w", err)
+	}
+
+	rolesResponse, err := clients.C().Security().ListRolesForUser(ctx, &securitypb.ListRolesForUserRequest{UserId: userID})
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("list roles: %w", err)
+	}
+
+	grantsResponse, err := clients.C().Security().ListRoleGrantsForUser(ctx, &securitypb.ListRoleGrantsForUserRequest{UserId: userID})
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("list role grants: %w", err)
+	}
+
+	logs, err := collectUserAuditLogs(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("list audit logs: %w", err)
+	}
+
+	return models.UserExport{
+		GeneratedAt:  time.Now(),
+		User:         mappers.UserFromProto(userResponse.GetUser()),
+		Brokers:      mappers.BrokerUsersFromProto(brokersResponse.GetUserBrokers()),
+		Transactions: mappers.TransactionsFromProto(transactionsResponse.GetTransactions()),
+		Roles:        mappers.RolesFromProto(rolesResponse.GetRoles()),
+		RoleGrants:   mappers.RoleGrantsFromProto(grantsResponse.GetGrants()),
+		AuditLogs:    logs,
+	}, nil
+}
+
+// collectUserAuditLogs retrieves the audit log entries performed by or on the user, the most recent first
+func collectUserAuditLogs(ctx context.Context, userID string) (models.AuditLogs, error) {
+	searches := []*securitypb.ListAuditLogsRequest{
+		{ActorId: userID},
+		{TargetType: models.AuditTargetUser, TargetId: userID},
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	logs := models.AuditLogs{}
+	for _, search := range searches {
+		search.Limit = models.AuditLogsMaxLimit
+		for {
+			response, err := clients.C().Audit().ListAuditLogs(ctx, search)
+			if err != nil {
+				return nil, err
+			}
+
+			page := mappers.AuditLogsFromProto(response.GetLogs())
+			for _, log := range page {
+				if !seen[log.ID] {
+					seen[log.ID] = true
+					logs = append(logs, log)
+				}
+			}
+
+			if len(page) < models.AuditLogsMaxLimit {
+				break
+			}
+			search.Offset += models.AuditLogsMaxLimit
+		}
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].OccurredAt.After(logs[j].OccurredAt)
+	})
+	return logs, nil
+}
+
+// enqueueExportReadyEmail enqueues the email sending the download link of the export to the user
+func enqueueExportReadyEmail(emailAddress string, lang language.Tag, link models.ExportLink) error {
+	// Build the download link
+	url := viper.GetString("API_PUBLIC_URL") + viper.GetString("API_BASE_PATH") + "/export/" + link.Token
+
+	// Render email
+	mail, err := templates.ExportReady.Localize(lang, templates.ExportReadyData{
+		Link:     url,
+		Duration: time.Until(link.ExpiresAt),
+	})
+	if err != nil {
+		return err
+	}
+
+	message := email.NewMessage(emailAddress, mail.Subject, mail.PlainTextContent, mail.HTMLContent)
+	_, _, err = outbox.R().Enqueue(models.InitOutboxEmail("export-ready:"+link.ID.String(), message))
+	return err
+}
+
+// auditUserExport records the outcome of the export of the user data
+func auditUserExport(ctx context.Context, userID uuid.UUID, outcome models.AuditOutcome, details models.AuditDetails) {
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionUserExport,
+		Outcome:    outcome,
+		ActorID:    uuid.NullUUID{UUID: userID, Valid: true},
+		TargetType: models.AuditTargetUser,
+		TargetID:   userID.String(),
+		Details:    details,
+	})
+}
@@ -0,0 +1,284 @@
+package handlers_test
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
+	"github.com/Zapharaos/fihub-backend/gen/go/brokerpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/transactionpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
+	"github.com/Zapharaos/fihub-backend/internal/export"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestExportUserSelf tests the ExportUserSelf handler, running the submitted job synchronously
+func TestExportUserSelf(t *testing.T) {
+	userID := uuid.New()
+	user := &userpb.User{Id: userID.String(), Email: "user@fihub.com"}
+
+	// runJob runs the submitted job right away
+	runJob := func(job func()) error {
+		job()
+		return nil
+	}
+
+	// exportClients sets up the microservices clients, user being the one returned by the user microservice
+	exportClients := func(ctrl *gomock.Controller, userErr error) {
+		uc := mocks.NewMockUserServiceClient(ctrl)
+		uc.EXPECT().GetUser(gomock.Any(), &userpb.GetUserRequest{Id: userID.String()}).Return(&userpb.GetUserResponse{User: user}, userErr)
+		bc := mocks.NewMockBrokerServiceClient(ctrl)
+		tc := mocks.NewMockTransactionServiceClient(ctrl)
+		sc := mocks.NewMockSecurityServiceClient(ctrl)
+		ac := mocks.NewMockAuditServiceClient(ctrl)
+		if userErr == nil {
+			bc.EXPECT().ListUserBrokers(gomock.Any(), gomock.Any()).Return(&brokerpb.ListUserBrokersResponse{}, nil)
+			tc.EXPECT().ListTransactions(gomock.Any(), gomock.Any()).Return(&transactionpb.ListTransactionsResponse{}, nil)
+			sc.EXPECT().ListRolesForUser(gomock.Any(), gomock.Any()).Return(&securitypb.ListRolesForUserResponse{}, nil)
+			sc.EXPECT().ListRoleGrantsForUser(gomock.Any(), gomock.Any()).Return(&securitypb.ListRoleGrantsForUserResponse{}, nil)
+			// The same entry performed by and on the user is only exported once
+			log := &securitypb.AuditLog{Id: uuid.New().String(), Action: models.AuditActionLogin, Outcome: models.AuditOutcomeSuccess}
+			ac.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Return(&securitypb.ListAuditLogsResponse{
+				Logs: []*securitypb.AuditLog{log},
+			}, nil).Times(2)
+		}
+		clients.ReplaceGlobals(clients.NewClients(
+			clients.WithUserClient(uc),
+			clients.WithBrokerClient(bc),
+			clients.WithTransactionClient(tc),
+			clients.WithSecurityClient(sc),
+			clients.WithAuditClient(ac),
+		))
+	}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to retrieve user from context",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return("", false)
+				handlers.ReplaceGlobals(m)
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Submit(gomock.Any()).Times(0)
+				export.ReplaceGlobals(e)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fails with too many exports running",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(userID.String(), true)
+				handlers.ReplaceGlobals(m)
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Submit(gomock.Any()).Return(export.ErrTooManyExports)
+				export.ReplaceGlobals(e)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name: "Accepted but fails to collect the data",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(userID.String(), true)
+				handlers.ReplaceGlobals(m)
+				exportClients(ctrl, status.Error(codes.Internal, "error"))
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Submit(gomock.Any()).DoAndReturn(runJob)
+				e.EXPECT().Store(gomock.Any(), gomock.Any()).Times(0)
+				export.ReplaceGlobals(e)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Any()).Times(0)
+				outbox.ReplaceGlobals(or)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name: "Accepted but fails to store the archive",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(userID.String(), true)
+				handlers.ReplaceGlobals(m)
+				exportClients(ctrl, nil)
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Submit(gomock.Any()).DoAndReturn(runJob)
+				e.EXPECT().Store(userID, gomock.Any()).Return(models.ExportLink{}, errors.New("error"))
+				export.ReplaceGlobals(e)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Any()).Times(0)
+				outbox.ReplaceGlobals(or)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name: "Accepted and sends the download link",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(userID.String(), true)
+				handlers.ReplaceGlobals(m)
+				exportClients(ctrl, nil)
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Submit(gomock.Any()).DoAndReturn(runJob)
+				e.EXPECT().Store(userID, gomock.Any()).Return(models.ExportLink{
+					ID:        uuid.New(),
+					Token:     "token",
+					ExpiresAt: time.Now().Add(24 * time.Hour),
+				}, nil)
+				export.ReplaceGlobals(e)
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
+				tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("").AnyTimes()
+				translation.ReplaceGlobals(tr)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Cond(func(outboxEmail models.OutboxEmail) bool {
+					return slices.Equal(outboxEmail.To, []string{user.Email})
+				})).Return(models.OutboxEmail{}, true, nil)
+				outbox.ReplaceGlobals(or)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", apiBasePath+"/user/me/export", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			audit.ReplaceGlobals(nil)
+			defer ctrl.Finish()
+
+			handlers.ExportUserSelf(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestDownloadUserExport tests the DownloadUserExport handler
+func TestDownloadUserExport(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Fails to parse param token",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamString(gomock.Any(), gomock.Any(), "token").Return("", false)
+				handlers.ReplaceGlobals(m)
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Open(gomock.Any()).Times(0)
+				export.ReplaceGlobals(e)
+			},
+			expectedStatus: http.StatusOK, // should be StatusBadRequest, but no with mock
+		},
+		{
+			name: "Fails with an invalid link",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamString(gomock.Any(), gomock.Any(), "token").Return("token", true)
+				handlers.ReplaceGlobals(m)
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Open("token").Return(uuid.Nil, nil, export.ErrLinkInvalid)
+				export.ReplaceGlobals(e)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Fails with a purged archive",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamString(gomock.Any(), gomock.Any(), "token").Return("token", true)
+				handlers.ReplaceGlobals(m)
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Open("token").Return(uuid.Nil, nil, export.ErrArchiveNotFound)
+				export.ReplaceGlobals(e)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Fails to open the archive",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamString(gomock.Any(), gomock.Any(), "token").Return("token", true)
+				handlers.ReplaceGlobals(m)
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Open("token").Return(uuid.Nil, nil, errors.New("error"))
+				export.ReplaceGlobals(e)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamString(gomock.Any(), gomock.Any(), "token").Return("token", true)
+				m.EXPECT().GetClientIP(gomock.Any()).Return("127.0.0.1")
+				handlers.ReplaceGlobals(m)
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Open("token").Return(uuid.New(), []byte("archive"), nil)
+				export.ReplaceGlobals(e)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "archive",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/export/token", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			audit.ReplaceGlobals(nil)
+			defer ctrl.Finish()
+
+			handlers.DownloadUserExport(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+			if tt.expectedBody != "" {
+				body, err := io.ReadAll(response.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBody, string(body))
+				assert.Equal(t, "application/zip", response.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -3,16 +3,18 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
 	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
 	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
 	"github.com/Zapharaos/fihub-backend/internal/password"
 	"github.com/Zapharaos/fihub-backend/pkg/email"
 	"github.com/Zapharaos/fihub-backend/pkg/email/templates"
-	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"github.com/Zapharaos/fihub-backend/pkg/passwordpolicy"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"golang.org/x/text/language"
 	"net/http"
 	"time"
 )
@@ -26,7 +28,7 @@ import (
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
-//	@Param			lang	query	string					false	"Language code"
+//	@Param			lang	query	string					false	"Language code (defaults to the language of the user)"
 //	@Param			request	body	models.PasswordInputRequest	true	"request (json)"
 //	@Success		200	{object}	models.PasswordResponseRequest	"PasswordRequest"
 //	@Failure		400	{object}	render.ErrorResponse		"Bad PasswordRequest"
@@ -91,69 +93,41 @@ func CreatePasswordResetRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Retrieve user language from query parameters
-	userLanguage := U().ParseParamLanguage(w, r)
-
-	// Get localizer
-	loc, err := translation.S().Localizer(userLanguage)
+	// Render email, in the requested language or the one stored in the profile of the user
+	mail, err := templates.PasswordReset.Localize(user.EmailLanguage(r.URL.Query().Get("lang")), templates.PasswordResetData{
+		Otp:      request.Token,
+		Duration: duration,
+	})
 	if err != nil {
-		zap.L().Error("Failed to get localizer", zap.Error(err))
+		// Delete the request since the email will not be sent
+		_ = password.R().Delete(request.ID)
+
+		zap.L().Error("Failed to render OTP email", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	// Prepare email data with translations
-	subject := translation.S().Message(loc, &translation.Message{ID: "EmailOtpTitle"})
-	plainTextContent := translation.S().Message(loc, &translation.Message{
-		ID: "EmailOtpPlainTextContent",
-		Data: map[string]interface{}{
-			"Otp": request.Token,
-		},
-	})
-
-	// Prepare email html template
-	htmlContentTemplate := templates.NewOtpTemplate(templates.OtpData{
-		OTP:      request.Token,
-		Greeting: translation.S().Message(loc, &translation.Message{ID: "EmailGreeting"}),
-		MainContent: translation.S().Message(loc, &translation.Message{
-			ID: "EmailOtpContentForgotPassword",
-			Data: map[string]interface{}{
-				"Duration": fmt.Sprintf("%d", int(duration.Minutes())),
-			},
-		}),
-		DoNotShare: translation.S().Message(loc, &translation.Message{ID: "EmailOtpDoNotShare"}),
-	})
-
-	// Prepare email layout labels
-	labels := templates.LayoutLabels{
-		Help: translation.S().Message(loc, &translation.Message{ID: "EmailFooterHelp"}),
-		Copyrights: translation.S().Message(loc, &translation.Message{
-			ID: "EmailFooterCopyrights",
-			Data: map[string]interface{}{
-				"Year": time.Now().Year(),
-			},
-		}),
-	}
-
-	// Render email html content
-	htmlContent, err := htmlContentTemplate.Build(labels)
+	// Enqueue email, delivered in the background so that a failing transport is retried
+	message := email.NewMessage(user.Email, mail.Subject, mail.PlainTextContent, mail.HTMLContent)
+	_, _, err = outbox.R().Enqueue(models.InitOutboxEmail("password-reset:"+request.ID.String(), message))
 	if err != nil {
-		// Log error and use plain text content instead of HTML
-		zap.L().Error("Render email content", zap.Error(err))
-		htmlContent = plainTextContent
-	}
-
-	// Send email
-	err = email.S().Send(user.Email, subject, plainTextContent, htmlContent)
-	if err != nil {
-		// Delete the request since the email could not be sent
+		// Delete the request since the email will not be sent
 		_ = password.R().Delete(request.ID)
 
-		zap.L().Error("Failed to send OTP email", zap.Error(err))
+		zap.L().Error("Failed to enqueue OTP email", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	// Keep track of the request, anyone knowing the email can make it hence it has no actor
+	audit.Facade().Record(r.Context(), models.AuditLog{
+		Action:     models.AuditActionPasswordResetRequest,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetUser,
+		TargetID:   user.ID.String(),
+		Details:    models.AuditDetails{"request_id": request.ID.String()},
+	})
+
 	// Return user ID and expires_at in JSON response
 	render.JSON(w, r, models.PasswordResponseRequest{
 		ExpiresAt: result.ExpiresAt,
@@ -214,10 +188,12 @@ func GetPasswordResetRequestID(w http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Param			id				path	string					true	"User ID"
 //	@Param			request_id		path	string					true	"Reset token"
+//	@Param			lang			query	string					false	"Language code (defaults to the language of the user)"
 //	@Param			password		body	models.UserInputPassword	true	"password (json)"
 //	@Security		Bearer
 //	@Success		200	{string}	string					"status OK"
 //	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		404	{string}	string					"User not found"
 //	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
 //	@Router			/api/v1/auth/password/{id}/{request_id} [put]
 func ResetPassword(w http.ResponseWriter, r *http.Request) {
@@ -235,6 +211,13 @@ func ResetPassword(w http.ResponseWriter, r *http.Request) {
 	}
 	if !exists {
 		zap.L().Warn("ResetPassword not found", zap.Error(err))
+		audit.Facade().Record(r.Context(), models.AuditLog{
+			Action:     models.AuditActionPasswordReset,
+			Outcome:    models.AuditOutcomeFailure,
+			TargetType: models.AuditTargetUser,
+			TargetID:   userID.String(),
+			Details:    models.AuditDetails{"request_id": requestID.String(), "reason": "request-invalid"},
+		})
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
@@ -255,6 +238,26 @@ func ResetPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get the user for the password policy
+	user, found, err := repositories.R().Get(userID)
+	if err != nil {
+		zap.L().Error("ResetPassword.GetUser", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		zap.L().Warn("ResetPassword user not found", zap.String("uuid", userID.String()))
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Check password against the policy
+	if err := passwordpolicy.S().Check(userPassword.Password, user.Email); err != nil {
+		zap.L().Warn("ResetPassword policy", zap.Error(err))
+		render.BadRequest(w, r, err)
+		return
+	}
+
 	// Convert to UserWithPassword
 	userWithPassword := userPassword.UserWithPassword
 	userWithPassword.ID = userID
@@ -267,6 +270,19 @@ func ResetPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Notify the user, the password being reset whether the notice is enqueued or not
+	enqueuePasswordChangedEmail(user, user.EmailLanguage(r.URL.Query().Get("lang")), "password-changed:"+requestID.String())
+
+	// Keep track of the reset
+	audit.Facade().Record(r.Context(), models.AuditLog{
+		Action:     models.AuditActionPasswordReset,
+		Outcome:    models.AuditOutcomeSuccess,
+		ActorID:    uuid.NullUUID{UUID: userID, Valid: true},
+		TargetType: models.AuditTargetUser,
+		TargetID:   userID.String(),
+		Details:    models.AuditDetails{"request_id": requestID.String()},
+	})
+
 	// Delete request
 	err = password.R().Delete(requestID)
 	if err != nil {
@@ -277,3 +293,22 @@ func ResetPassword(w http.ResponseWriter, r *http.Request) {
 
 	render.OK(w, r)
 }
+
+// enqueuePasswordChangedEmail notifies the user that the password of the account has been changed
+func enqueuePasswordChangedEmail(user models.User, lang language.Tag, key string) {
+	// Render email
+	mail, err := templates.PasswordChanged.Localize(lang, templates.PasswordChangedData{
+		Date: time.Now(),
+	})
+	if err != nil {
+		zap.L().Error("Failed to render password changed email", zap.Error(err))
+		return
+	}
+
+	// Enqueue email
+	message := email.NewMessage(user.Email, mail.Subject, mail.PlainTextContent, mail.HTMLContent)
+	_, _, err = outbox.R().Enqueue(models.InitOutboxEmail(key, message))
+	if err != nil {
+		zap.L().Error("Failed to enqueue password changed email", zap.Error(err))
+	}
+}
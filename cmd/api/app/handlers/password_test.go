@@ -7,6 +7,7 @@ package handlers_test
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
 	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
 	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
 	"github.com/Zapharaos/fihub-backend/internal/password"
 	"github.com/Zapharaos/fihub-backend/pkg/email"
 	"github.com/Zapharaos/fihub-backend/pkg/translation"
@@ -15,9 +16,9 @@ package handlers_test
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
-	"golang.org/x/text/language"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -140,7 +141,6 @@ func TestCreatePasswordResetRequest(t *testing.T) {
 			body: validRequestBody,
 			mockSetup: func(ctrl *gomock.Controller) {
 				m := mocks.NewMockApiUtils(ctrl)
-				h.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
 				handlers.ReplaceGlobals(m)
 				u := mocks.NewUserRepository(ctrl)
 				u.EXPECT().GetByEmail(gomock.Any()).Return(models.User{}, true, nil)
@@ -157,11 +157,10 @@ func TestCreatePasswordResetRequest(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 		},
 		{
-			name: "fails to send email",
+			name: "fails to enqueue email",
 			body: validRequestBody,
 			mockSetup: func(ctrl *gomock.Controller) {
 				m := mocks.NewMockApiUtils(ctrl)
-				h.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
 				handlers.ReplaceGlobals(m)
 				u := mocks.NewUserRepository(ctrl)
 				u.EXPECT().GetByEmail(gomock.Any()).Return(models.User{}, true, nil)
@@ -175,9 +174,9 @@ func TestCreatePasswordResetRequest(t *testing.T) {
 				t.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
 				t.EXPECT().Message(gomock.Any(), gomock.Any()).Return("").AnyTimes()
 				translation.ReplaceGlobals(t)
-				e := email.NewMockService(ctrl)
-				e.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error"))
-				email.ReplaceGlobals(e)
+				o := mocks.NewOutboxRepository(ctrl)
+				o.EXPECT().Enqueue(gomock.Any()).Return(models.OutboxEmail{}, false, errors.New("error"))
+				outbox.ReplaceGlobals(o)
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -186,7 +185,6 @@ func TestCreatePasswordResetRequest(t *testing.T) {
 			body: validRequestBody,
 			mockSetup: func(ctrl *gomock.Controller) {
 				m := mocks.NewMockApiUtils(ctrl)
-				h.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
 				handlers.ReplaceGlobals(m)
 				u := mocks.NewUserRepository(ctrl)
 				u.EXPECT().GetByEmail(gomock.Any()).Return(models.User{}, true, nil)
@@ -200,8 +198,13 @@ func TestCreatePasswordResetRequest(t *testing.T) {
 				t.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
 				t.EXPECT().Message(gomock.Any(), gomock.Any()).Return("").AnyTimes()
 				translation.ReplaceGlobals(t)
+				o := mocks.NewOutboxRepository(ctrl)
+				o.EXPECT().Enqueue(gomock.Cond(func(x any) bool {
+					return strings.HasPrefix(x.(models.OutboxEmail).IdempotencyKey, "password-reset:")
+				})).Return(models.OutboxEmail{}, true, nil)
+				outbox.ReplaceGlobals(o)
 				e := email.NewMockService(ctrl)
-				e.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				e.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 				email.ReplaceGlobals(e)
 			},
 			expectedStatus: http.StatusOK,
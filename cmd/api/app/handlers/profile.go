@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// UpdateUserProfileSelf godoc
+//
+//	@Id				UpdateUserProfileSelf
+//
+//	@Summary		Update the profile of the currently authenticated user
+//	@Description	Updates the display name and preferences (language, timezone, base currency, number and date formats) of the currently authenticated user. The language is used by default for the emails sent to the user.
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			profile	body	models.UserProfile	true	"profile (json)"
+//	@Security		Bearer
+//	@Success		200	{object}	models.User				"user"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/me/profile [put]
+func UpdateUserProfileSelf(w http.ResponseWriter, r *http.Request) {
+	userID, found := U().GetUserIDFromContext(r)
+	if !found {
+		zap.L().Debug("No context user provided")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// Parse request body
+	var profile models.UserProfile
+	err := json.NewDecoder(r.Body).Decode(&profile)
+	if err != nil {
+		zap.L().Warn("User profile json decode", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Update profile
+	response, err := clients.C().User().UpdateUserProfile(r.Context(), &userpb.UpdateUserProfileRequest{
+		Id:      userID,
+		Profile: mappers.UserProfileToProto(profile),
+	})
+	if err != nil {
+		zap.L().Error("Update user profile", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.UserFromProto(response.GetUser()))
+}
+
+// SetUserAvatarSelf godoc
+//
+//	@Id				SetUserAvatarSelf
+//
+//	@Summary		Set the avatar of the currently authenticated user
+//	@Description	Creates or replaces the avatar of the currently authenticated user. The image must not exceed 1 MB.
+//	@Tags			User
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file	true	"image file"
+//	@Security		Bearer
+//	@Success		200	{string}	string					"status OK"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/me/avatar [put]
+func SetUserAvatarSelf(w http.ResponseWriter, r *http.Request) {
+	userID, found := U().GetUserIDFromContext(r)
+	if !found {
+		zap.L().Debug("No context user provided")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// Parse the multipart form
+	data, name, ok := U().ReadImage(w, r)
+	if !ok {
+		return
+	}
+
+	// Validate the avatar before sending it, larger images would exceed the gRPC message size limit
+	if ok, err := (models.UserAvatar{Name: name, Data: data}).IsValid(); !ok {
+		zap.L().Warn("User avatar is not valid", zap.Error(err))
+		render.BadRequest(w, r, err)
+		return
+	}
+
+	// Set the avatar
+	_, err := clients.C().User().SetUserAvatar(r.Context(), &userpb.SetUserAvatarRequest{
+		UserId: userID,
+		Name:   name,
+		Data:   data,
+	})
+	if err != nil {
+		zap.L().Error("Set user avatar", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.OK(w, r)
+}
+
+// GetUserAvatarSelf godoc
+//
+//	@Id				GetUserAvatarSelf
+//
+//	@Summary		Get the avatar of the currently authenticated user
+//	@Description	Retrieves the avatar of the currently authenticated user.
+//	@Tags			User
+//	@Produce		image/jpeg
+//	@Produce		image/png
+//	@Security		Bearer
+//	@Success		200	{file}		file					"image"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{object}	render.ErrorResponse	"Not Found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/me/avatar [get]
+func GetUserAvatarSelf(w http.ResponseWriter, r *http.Request) {
+	userID, found := U().GetUserIDFromContext(r)
+	if !found {
+		zap.L().Debug("No context user provided")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// Get the avatar
+	response, err := clients.C().User().GetUserAvatar(r.Context(), &userpb.GetUserAvatarRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		zap.L().Error("Get user avatar", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	// Set headers
+	w.Header().Set("Content-Disposition", "inline; filename="+response.Name)
+
+	// Write the image
+	_, err = w.Write(response.Data)
+	if err != nil {
+		zap.L().Error("Cannot write image", zap.Error(err))
+		render.Error(w, r, err, "Write image")
+		return
+	}
+}
+
+// DeleteUserAvatarSelf godoc
+//
+//	@Id				DeleteUserAvatarSelf
+//
+//	@Summary		Delete the avatar of the currently authenticated user
+//	@Description	Deletes the avatar of the currently authenticated user.
+//	@Tags			User
+//	@Security		Bearer
+//	@Success		200	{string}	string					"status OK"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{object}	render.ErrorResponse	"Not Found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/me/avatar [delete]
+func DeleteUserAvatarSelf(w http.ResponseWriter, r *http.Request) {
+	userID, found := U().GetUserIDFromContext(r)
+	if !found {
+		zap.L().Debug("No context user provided")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// Delete the avatar
+	_, err := clients.C().User().DeleteUserAvatar(r.Context(), &userpb.DeleteUserAvatarRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		zap.L().Error("Delete user avatar", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.OK(w, r)
+}
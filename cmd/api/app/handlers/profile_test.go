@@ -0,0 +1,379 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestUpdateUserProfileSelf tests the UpdateUserProfileSelf handler
+func TestUpdateUserProfileSelf(t *testing.T) {
+	profile := models.UserProfile{
+		DisplayName: "Jane",
+		Language:    "fr",
+		Timezone:    "Europe/Paris",
+	}
+	validProfile, _ := json.Marshal(profile)
+
+	// Test cases
+	tests := []struct {
+		name           string
+		body           []byte
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to retrieve user from context",
+			body: validProfile,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return("", false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Fails to decode",
+			body: []byte("invalid json"),
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fails to update profile",
+			body: validProfile,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "timezone-invalid"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Succeeded",
+			body: validProfile,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any()).Return(&userpb.UpdateUserProfileResponse{
+					User: &userpb.User{Id: uuid.New().String(), Profile: &userpb.UserProfile{DisplayName: "Jane"}},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", apiBasePath+"/user/me/profile", bytes.NewBuffer(tt.body))
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.UpdateUserProfileSelf(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestSetUserAvatarSelf tests the SetUserAvatarSelf handler
+func TestSetUserAvatarSelf(t *testing.T) {
+	fileName := "avatar.png"
+	fileData := []byte("image data")
+
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to retrieve user from context",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return("", false)
+				m.EXPECT().ReadImage(gomock.Any(), gomock.Any()).Times(0)
+				handlers.ReplaceGlobals(m)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Fails to read image",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				m.EXPECT().ReadImage(gomock.Any(), gomock.Any()).Return(nil, "", false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().SetUserAvatar(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails with a too large avatar",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				m.EXPECT().ReadImage(gomock.Any(), gomock.Any()).Return(make([]byte, models.AvatarMaxSize+1), fileName, true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().SetUserAvatar(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fails to set the avatar",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				m.EXPECT().ReadImage(gomock.Any(), gomock.Any()).Return(fileData, fileName, true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().SetUserAvatar(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unknown, "error"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				userID := uuid.New().String()
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(userID, true)
+				m.EXPECT().ReadImage(gomock.Any(), gomock.Any()).Return(fileData, fileName, true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().SetUserAvatar(gomock.Any(), &userpb.SetUserAvatarRequest{
+					UserId: userID,
+					Name:   fileName,
+					Data:   fileData,
+				}).Return(&userpb.SetUserAvatarResponse{Success: true}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", apiBasePath+"/user/me/avatar", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.SetUserAvatarSelf(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestGetUserAvatarSelf tests the GetUserAvatarSelf handler
+func TestGetUserAvatarSelf(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to retrieve user from context",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return("", false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().GetUserAvatar(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Fails to get the avatar",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().GetUserAvatar(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "User avatar not found"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().GetUserAvatar(gomock.Any(), gomock.Any()).Return(&userpb.GetUserAvatarResponse{
+					Name: "avatar.png",
+					Data: []byte("image data"),
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/user/me/avatar", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.GetUserAvatarSelf(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestDeleteUserAvatarSelf tests the DeleteUserAvatarSelf handler
+func TestDeleteUserAvatarSelf(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to retrieve user from context",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return("", false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().DeleteUserAvatar(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Fails to delete the avatar",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().DeleteUserAvatar(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "User avatar not found"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().DeleteUserAvatar(gomock.Any(), gomock.Any()).Return(&userpb.DeleteUserAvatarResponse{Success: true}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", apiBasePath+"/user/me/avatar", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.DeleteUserAvatarSelf(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
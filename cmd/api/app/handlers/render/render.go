@@ -2,16 +2,22 @@ package render
 
 import (
 	"encoding/json"
+	"errors"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 var (
 	TitleInternalServerError = "Internal Server Error - Please contact the administrator."
 	TitleBadRequest          = "Bad Request - Please check your request."
 	TitleNotFound            = "Not Found - The requested resource was not found."
+	TitleTooManyRequests     = "Too Many Requests - Please try again later."
 )
 
 type ErrorResponse struct {
@@ -83,6 +89,21 @@ func NotFound(w http.ResponseWriter, r *http.Request, err error) {
 	JSON(w, r, resp)
 }
 
+// TooManyRequests returns an HTTP status 429 with a specific error message.
+// If retryAfter is set, the Retry-After header indicates the number of seconds to wait before retrying.
+func TooManyRequests(w http.ResponseWriter, r *http.Request, err error, retryAfter time.Duration) {
+	resp := ErrorResponse{Title: TitleTooManyRequests}
+	if err != nil {
+		zap.L().Debug("Too Many Requests", zap.Error(err))
+		resp.Message = err.Error()
+	}
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+	w.WriteHeader(http.StatusTooManyRequests)
+	JSON(w, r, resp)
+}
+
 // Count returns an HTTP status 200 with a JSON object containing the count (CountResponse)
 func Count(w http.ResponseWriter, r *http.Request, count int64) {
 	JSON(w, r, CountResponse{Count: count})
@@ -107,6 +128,9 @@ func ErrorCodesCodeToHttpCode(w http.ResponseWriter, r *http.Request, err error)
 		case codes.PermissionDenied:
 			w.WriteHeader(http.StatusUnauthorized)
 			return
+		case codes.ResourceExhausted:
+			TooManyRequests(w, r, errors.New(s.Message()), retryDelay(s))
+			return
 		case codes.Internal:
 			w.WriteHeader(http.StatusInternalServerError)
 			return
@@ -114,3 +138,13 @@ func ErrorCodesCodeToHttpCode(w http.ResponseWriter, r *http.Request, err error)
 	}
 	w.WriteHeader(http.StatusInternalServerError)
 }
+
+// retryDelay extracts the retry delay from the status details, zero if none
+func retryDelay(s *status.Status) time.Duration {
+	for _, detail := range s.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			return retryInfo.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
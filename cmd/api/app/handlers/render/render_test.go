@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 // TestError tests the Error function
@@ -234,6 +235,56 @@ func TestNotFound(t *testing.T) {
 	}
 }
 
+// TestTooManyRequests tests the TooManyRequests function
+func TestTooManyRequests(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name             string
+		err              error
+		retryAfter       time.Duration
+		expectMessage    string
+		expectRetryAfter string
+	}{
+		{
+			name:             "err is nil",
+			err:              nil,
+			expectMessage:    "",
+			expectRetryAfter: "",
+		},
+		{
+			name:             "err and retry delay are set",
+			err:              errors.New("login-locked"),
+			retryAfter:       1500 * time.Millisecond,
+			expectMessage:    "login-locked",
+			expectRetryAfter: "2",
+		},
+	}
+
+	// Run the tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new recorder
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/", nil)
+
+			// Call the function
+			TooManyRequests(w, r, tt.err, tt.retryAfter)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			// Check the response
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			assert.Equal(t, tt.expectRetryAfter, resp.Header.Get("Retry-After"))
+
+			var response ErrorResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			assert.NoError(t, err)
+			assert.Equal(t, TitleTooManyRequests, response.Title)
+			assert.Equal(t, tt.expectMessage, response.Message)
+		})
+	}
+}
+
 // TestCount tests the Count function
 func TestCount(t *testing.T) {
 	// Create a new recorder
@@ -291,6 +342,11 @@ func TestErrorCodesCodeToHttpCode(t *testing.T) {
 			statusCode:     codes.PermissionDenied,
 			expectedStatus: http.StatusUnauthorized,
 		},
+		{
+			name:           "ResourceExhausted",
+			statusCode:     codes.ResourceExhausted,
+			expectedStatus: http.StatusTooManyRequests,
+		},
 		{
 			name:           "Internal",
 			statusCode:     codes.Internal,
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ListAuditLogs godoc
+//
+//	@Id				ListAuditLogs
+//
+//	@Summary		Search the audit log
+//	@Description	Search the authentication and authorization events, the most recent first. (Permission: <b>admin.audit.list</b>)
+//	@Tags			Security, Audit
+//	@Produce		json
+//	@Param			action		query	string	false	"action (i.e. auth.login)"
+//	@Param			outcome		query	string	false	"outcome (success, failure, denied)"
+//	@Param			actor_id	query	string	false	"actor user ID"
+//	@Param			target_type	query	string	false	"target type (user, role, permission)"
+//	@Param			target_id	query	string	false	"target ID"
+//	@Param			request_id	query	string	false	"request ID"
+//	@Param			from		query	string	false	"lower bound of the occurrence date (RFC 3339)"
+//	@Param			to			query	string	false	"upper bound of the occurrence date (RFC 3339)"
+//	@Param			limit		query	int		false	"maximum number of entries"
+//	@Param			offset		query	int		false	"number of entries to skip"
+//	@Security		Bearer
+//	@Success		200	{array}		models.AuditLog			"list of audit log entries"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad Request"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/security/audit [get]
+func ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// Construct the search from the query parameters
+	req := &securitypb.ListAuditLogsRequest{
+		Action:     query.Get("action"),
+		Outcome:    query.Get("outcome"),
+		ActorId:    query.Get("actor_id"),
+		TargetType: query.Get("target_type"),
+		TargetId:   query.Get("target_id"),
+		RequestId:  query.Get("request_id"),
+	}
+
+	if from := query.Get("from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			zap.L().Warn("Invalid from parameter", zap.String("from", from), zap.Error(err))
+			render.BadRequest(w, r, errors.New("from-invalid"))
+			return
+		}
+		req.From = timestamppb.New(parsed)
+	}
+	if to := query.Get("to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			zap.L().Warn("Invalid to parameter", zap.String("to", to), zap.Error(err))
+			render.BadRequest(w, r, errors.New("to-invalid"))
+			return
+		}
+		req.To = timestamppb.New(parsed)
+	}
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			zap.L().Warn("Invalid limit parameter", zap.String("limit", limit), zap.Error(err))
+			render.BadRequest(w, r, errors.New("limit-invalid"))
+			return
+		}
+		req.Limit = int32(parsed)
+	}
+	if offset := query.Get("offset"); offset != "" {
+		parsed, err := strconv.ParseInt(offset, 10, 32)
+		if err != nil {
+			zap.L().Warn("Invalid offset parameter", zap.String("offset", offset), zap.Error(err))
+			render.BadRequest(w, r, errors.New("offset-invalid"))
+			return
+		}
+		req.Offset = int32(parsed)
+	}
+
+	// Search the audit log
+	response, err := clients.C().Audit().ListAuditLogs(r.Context(), req)
+	if err != nil {
+		zap.L().Error("List Audit Logs", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.AuditLogsFromProto(response.GetLogs()))
+}
@@ -0,0 +1,132 @@
+package handlers_test
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestListAuditLogs tests the ListAuditLogs handler
+func TestListAuditLogs(t *testing.T) {
+	actorID := uuid.New().String()
+
+	// Define the test cases
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name:  "fails to parse from",
+			query: "?from=yesterday",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ac := mocks.NewMockAuditServiceClient(ctrl)
+				ac.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuditClient(ac),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "fails to parse limit",
+			query: "?limit=many",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ac := mocks.NewMockAuditServiceClient(ctrl)
+				ac.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuditClient(ac),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "fails with permission denied",
+			query: "",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ac := mocks.NewMockAuditServiceClient(ctrl)
+				ac.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "Permission denied"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuditClient(ac),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "fails to list audit logs",
+			query: "",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ac := mocks.NewMockAuditServiceClient(ctrl)
+				ac.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuditClient(ac),
+				))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:  "succeeded",
+			query: "?action=auth.login&outcome=failure&actor_id=" + actorID + "&from=2025-05-01T00:00:00Z&to=2025-05-09T00:00:00Z&limit=20&offset=40",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ac := mocks.NewMockAuditServiceClient(ctrl)
+				ac.EXPECT().ListAuditLogs(gomock.Any(), &securitypb.ListAuditLogsRequest{
+					Action:  "auth.login",
+					Outcome: "failure",
+					ActorId: actorID,
+					From:    timestamppb.New(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)),
+					To:      timestamppb.New(time.Date(2025, 5, 9, 0, 0, 0, 0, time.UTC)),
+					Limit:   20,
+					Offset:  40,
+				}).Return(&securitypb.ListAuditLogsResponse{
+					Logs: []*securitypb.AuditLog{
+						{
+							Id:         uuid.New().String(),
+							OccurredAt: timestamppb.Now(),
+							Action:     "auth.login",
+							Outcome:    "failure",
+							ActorId:    actorID,
+						},
+					},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuditClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/security/audit"+tt.query, nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.ListAuditLogs(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
@@ -207,3 +207,40 @@ func ListPermissions(w http.ResponseWriter, r *http.Request) {
 
 	render.JSON(w, r, mappers.PermissionsFromProto(response.Permissions))
 }
+
+// ListUserPermissionsSelf godoc
+//
+//	@Id				ListUserPermissionsSelf
+//
+//	@Summary		Get the effective permissions of the currently authenticated user
+//	@Description	Gets the permissions granted to the currently authenticated user, wildcards being expanded. Can be filtered by scope.
+//	@Tags			User, Security, Permission
+//	@Produce		json
+//	@Param			scope	query	string	false	"permission scope (admin, front, all)"
+//	@Security		Bearer
+//	@Success		200	{array}		models.Permission		"list of permissions"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad Request"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/me/permissions [get]
+func ListUserPermissionsSelf(w http.ResponseWriter, r *http.Request) {
+	userID, found := U().GetUserIDFromContext(r)
+	if !found {
+		zap.L().Debug("No context user provided")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// List the effective permissions
+	response, err := clients.C().Security().ListEffectivePermissionsForUser(r.Context(), &securitypb.ListEffectivePermissionsForUserRequest{
+		UserId: userID,
+		Scope:  r.URL.Query().Get("scope"),
+	})
+	if err != nil {
+		zap.L().Error("List effective permissions", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.PermissionsFromProto(response.GetPermissions()))
+}
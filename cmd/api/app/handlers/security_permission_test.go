@@ -423,3 +423,85 @@ func TestListPermissions(t *testing.T) {
 		})
 	}
 }
+
+// TestListUserPermissionsSelf tests the ListUserPermissionsSelf function
+func TestListUserPermissionsSelf(t *testing.T) {
+	userID := uuid.New().String()
+
+	// Define the test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to retrieve user from context",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return("", false)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().ListEffectivePermissionsForUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "With invalid scope",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(userID, true)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().ListEffectivePermissionsForUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "error"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "With success",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(userID, true)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().ListEffectivePermissionsForUser(gomock.Any(), &securitypb.ListEffectivePermissionsForUserRequest{
+					UserId: userID,
+					Scope:  "front",
+				}).Return(&securitypb.ListEffectivePermissionsForUserResponse{
+					Permissions: []*securitypb.Permission{},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run the test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new recorder and request
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/user/me/permissions?scope=front", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call the function
+			handlers.ListUserPermissionsSelf(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
@@ -9,6 +10,7 @@ import (
 	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
 	"github.com/Zapharaos/fihub-backend/internal/mappers"
 	"github.com/Zapharaos/fihub-backend/internal/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"net/http"
 
 	"go.uber.org/zap"
@@ -288,6 +290,52 @@ func SetRolePermissions(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, mappers.PermissionsFromProto(response.GetPermissions()))
 }
 
+// SetRoleParents godoc
+//
+//	@Id				SetRoleParents
+//
+//	@Summary		Set parent roles for a given role
+//	@Description	Updates the role hierarchy. The role inherits the permissions of its parents. (Permission: <b>admin.roles.update</b>)
+//	@Tags			Security, Role
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string		true	"role ID"
+//	@Param			parents	body	[]string	true	"List of parent role UUIDs (json)"
+//	@Security		Bearer
+//	@Success		200	{object}	models.RoleWithPermissions	"role"
+//	@Failure		400	{object}	render.ErrorResponse		"Bad PasswordRequest"
+//	@Failure		401	{string}	string						"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse		"Internal Server Error"
+//	@Router			/api/v1/security/role/{id}/parent [put]
+func SetRoleParents(w http.ResponseWriter, r *http.Request) {
+	roleId, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var parentUUIDs []string
+	err := json.NewDecoder(r.Body).Decode(&parentUUIDs)
+	if err != nil {
+		zap.L().Warn("Parent UUIDs json decode", zap.Error(err))
+		render.BadRequest(w, r, nil)
+		return
+	}
+
+	// Set the role parents
+	response, err := clients.C().Security().SetRoleParents(r.Context(), &securitypb.SetRoleParentsRequest{
+		Id:      roleId.String(),
+		Parents: parentUUIDs,
+	})
+	if err != nil {
+		zap.L().Error("Set Role Parents", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	// Map the response to the RoleWithPermissions model
+	render.JSON(w, r, mappers.RoleWithPermissionsFromProto(response.GetRole()))
+}
+
 // AddUsersToRole godoc
 //
 //	@Id				AddUsersToRole
@@ -492,62 +540,191 @@ func ListRolesWithPermissionsForUser(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, mappers.RolesWithPermissionsFromProto(response.GetRoles()))
 }
 
+// GrantRoleToUser godoc
+//
+//	@Id				GrantRoleToUser
+//
+//	@Summary		Grant a role to a user
+//	@Description	Grant a role to a user, optionally within a time window. Replaces any previous grant of the role. (Permission: <b>admin.users.roles.update</b>)
+//	@Tags			Security, Role, User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string				true	"user ID"
+//	@Param			grant	body	models.RoleGrant	true	"role grant (json) : role_id, valid_from, valid_until and reason"
+//	@Security		Bearer
+//	@Success		200	{object}	models.RoleGrant		"role grant"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{object}	render.ErrorResponse	"Role not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/security/role/user/{id}/grant [post]
+func GrantRoleToUser(w http.ResponseWriter, r *http.Request) {
+	userId, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var grant models.RoleGrant
+	err := json.NewDecoder(r.Body).Decode(&grant)
+	if err != nil {
+		zap.L().Warn("Role grant json decode", zap.Error(err))
+		render.BadRequest(w, r, nil)
+		return
+	}
+
+	// Create gRPC gen.GrantRoleToUserRequest : the grant starts right away without valid_from
+	grantRequest := &securitypb.GrantRoleToUserRequest{
+		UserId: userId.String(),
+		RoleId: grant.RoleID.String(),
+		Reason: grant.Reason,
+	}
+	if !grant.ValidFrom.IsZero() {
+		grantRequest.ValidFrom = timestamppb.New(grant.ValidFrom)
+	}
+	if grant.ValidUntil != nil {
+		grantRequest.ValidUntil = timestamppb.New(*grant.ValidUntil)
+	}
+
+	// Grant the role to the user
+	response, err := clients.C().Security().GrantRoleToUser(r.Context(), grantRequest)
+	if err != nil {
+		zap.L().Error("Grant Role to User", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	// Map the response to the RoleGrant model
+	render.JSON(w, r, mappers.RoleGrantFromProto(response.GetGrant()))
+}
+
+// ListRoleGrantsForUser godoc
+//
+//	@Id				ListRoleGrantsForUser
+//
+//	@Summary		List the role grants of a user
+//	@Description	List of all role grants of a user, the upcoming ones included. (Permission: <b>admin.users.roles.list</b>)
+//	@Tags			Security, Role, User
+//	@Produce		json
+//	@Param			id	path	string	true	"user ID"
+//	@Security		Bearer
+//	@Success		200	{array}		models.RoleGrant		"list of role grants"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/security/role/user/{id}/grant [get]
+func ListRoleGrantsForUser(w http.ResponseWriter, r *http.Request) {
+	userId, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// List role grants for the user
+	response, err := clients.C().Security().ListRoleGrantsForUser(r.Context(), &securitypb.ListRoleGrantsForUserRequest{
+		UserId: userId.String(),
+	})
+	if err != nil {
+		zap.L().Error("List Role Grants for User", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	// Map the response to the RoleGrants model
+	render.JSON(w, r, mappers.RoleGrantsFromProto(response.GetGrants()))
+}
+
 // ListUsersWithRoles godoc
 //
 //	@Id				ListUsersWithRoles
 //
 //	@Summary		List users with their roles
-//	@Description	List of all users with their roles. (Permission: <b>admin.users.list</b>)
+//	@Description	Search the users with their roles, sorted and paginated. The link to the next page, if any, is returned in the Link header. (Permission: <b>admin.users.list</b>)
 //	@Tags			Security, Role, User
 //	@Produce		json
+//	@Param			email			query	string	false	"beginning of the email address (case insensitive)"
+//	@Param			role_id			query	string	false	"role held by the users"
+//	@Param			created_from	query	string	false	"created at or after (RFC3339)"
+//	@Param			created_to		query	string	false	"created before (RFC3339)"
+//	@Param			status			query	string	false	"status (active, unverified, disabled, pending_deletion)"
+//	@Param			sort			query	string	false	"sort (created_at, -created_at, email, -email)"
+//	@Param			limit			query	int		false	"maximum number of users"
+//	@Param			after			query	string	false	"cursor of the page, as given by the Link header of the previous page"
 //	@Security		Bearer
 //	@Success		200	{array}		apimodels.UserWithRoles "list of users with roles"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad Request"
 //	@Failure		401	{string}	string					"Permission denied"
 //	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
 //	@Router			/api/v1/security/role/user [get]
 func ListUsersWithRoles(w http.ResponseWriter, r *http.Request) {
-	// List roles for the users
-	respSecurityUsers, err := clients.C().Security().ListUsersFull(r.Context(), &securitypb.ListUsersFullRequest{})
+	req, ok := parseUserSearch(w, r)
+	if !ok {
+		return
+	}
+
+	users, next, err := searchUsersWithRoles(r.Context(), req, r.URL.Query().Get("role_id"))
 	if err != nil {
-		zap.L().Error("List Security Users", zap.Error(err))
+		zap.L().Error("List users with roles", zap.Error(err))
 		render.ErrorCodesCodeToHttpCode(w, r, err)
 		return
 	}
 
-	// Create a map of user IDs to roles (for faster lookup)
-	userRolesMap := make(map[string]models.Roles)
-	for _, user := range respSecurityUsers.GetUsers() {
-		userID := user.GetUserId()
-		userRoles := mappers.RolesFromProto(user.GetRoles())
-		userRolesMap[userID] = userRoles
+	setNextPageLink(w, r, next)
+	render.JSON(w, r, users)
+}
+
+// searchUsersWithRoles retrieves a page of the users matching the search, along with their roles.
+// The security service pages the users, restricted to the holders of the role if any, and the details
+// of that page are then retrieved from the user service. Returns the cursor of the next page.
+func searchUsersWithRoles(ctx context.Context, req *userpb.ListUsersRequest, roleID string) ([]apimodels.UserWithRoles, string, error) {
+	// List roles for the page of users
+	respSecurityUsers, err := clients.C().Security().ListUsersFull(ctx, &securitypb.ListUsersFullRequest{
+		Email:       req.GetEmail(),
+		Sort:        req.GetSort(),
+		Limit:       req.GetLimit(),
+		After:       req.GetAfter(),
+		RoleId:      roleID,
+		CreatedFrom: req.GetCreatedFrom(),
+		CreatedTo:   req.GetCreatedTo(),
+		Status:      req.GetStatus(),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(respSecurityUsers.GetUsers()) == 0 {
+		return []apimodels.UserWithRoles{}, "", nil
 	}
 
-	// Retrieve all app users
-	respUserUsers, err := clients.C().User().ListUsers(r.Context(), &userpb.ListUsersRequest{})
+	// Retrieve the details of the same page of users
+	userIDs := make([]string, len(respSecurityUsers.GetUsers()))
+	for i, user := range respSecurityUsers.GetUsers() {
+		userIDs[i] = user.GetUserId()
+	}
+	respUserUsers, err := clients.C().User().ListUsers(ctx, &userpb.ListUsersRequest{
+		UserIds: userIDs,
+		Sort:    req.GetSort(),
+		Limit:   int32(len(userIDs)),
+	})
 	if err != nil {
-		zap.L().Error("List User Users", zap.Error(err))
-		render.ErrorCodesCodeToHttpCode(w, r, err)
-		return
+		return nil, "", err
 	}
 
-	// Link the users with their roles
-	users := make([]apimodels.UserWithRoles, len(respUserUsers.GetUsers()))
-	for i, u := range respUserUsers.GetUsers() {
-		// Map the user to the UserWithRoles model
-		user := mappers.UserFromProto(u)
+	// Create a map of user IDs to users (for faster lookup)
+	usersMap := make(map[string]models.User, len(respUserUsers.GetUsers()))
+	for _, u := range respUserUsers.GetUsers() {
+		usersMap[u.GetId()] = mappers.UserFromProto(u)
+	}
 
-		// Retrieve user roles from userRolesMap
-		userRoles, ok := userRolesMap[user.ID.String()]
+	// Link the users with their roles, in the order of the page, skipping the users deleted meanwhile
+	users := make([]apimodels.UserWithRoles, 0, len(userIDs))
+	for _, u := range respSecurityUsers.GetUsers() {
+		user, ok := usersMap[u.GetUserId()]
 		if !ok {
-			userRoles = models.Roles{}
+			continue
 		}
-
-		// Link user to his roles
-		users[i] = apimodels.UserWithRoles{
+		users = append(users, apimodels.UserWithRoles{
 			User:  user,
-			Roles: userRoles,
-		}
+			Roles: mappers.RolesFromProto(u.GetRoles()),
+		})
 	}
 
-	render.JSON(w, r, users)
+	return users, respSecurityUsers.GetNextCursor(), nil
 }
@@ -14,9 +14,12 @@ import (
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 // TestCreateRole tests the CreateRole handler
@@ -611,6 +614,104 @@ func TestSetRolePermissions(t *testing.T) {
 	}
 }
 
+// TestSetRoleParents tests the SetRoleParents handler
+func TestSetRoleParents(t *testing.T) {
+	// Declare the data
+	validUUIDs := []uuid.UUID{uuid.New()}
+	validUUIDsBody, _ := json.Marshal(validUUIDs)
+
+	// Define the test cases
+	tests := []struct {
+		name           string
+		body           []byte
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().SetRoleParents(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be StatusBadRequest, but not with mock
+		},
+		{
+			name: "fails to decode",
+			body: []byte(`invalid json`),
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().SetRoleParents(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fails with a cycle",
+			body: validUUIDsBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().SetRoleParents(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, models.ErrRoleHierarchyCycle.Error()))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Succeeded",
+			body: validUUIDsBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().SetRoleParents(gomock.Any(), gomock.Any()).Return(&securitypb.SetRoleParentsResponse{
+					Role: &securitypb.RoleWithPermissions{
+						Role: &securitypb.Role{Id: uuid.New().String()},
+					},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", apiBasePath+"/security/role/"+uuid.New().String()+"/parent", bytes.NewBuffer(tt.body))
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tt.mockSetup(ctrl)
+
+			handlers.SetRoleParents(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
 // TestAddUsersToRole tests the AddUsersToRole handler
 func TestAddUsersToRole(t *testing.T) {
 	// Declare the data
@@ -1054,6 +1155,190 @@ func TestListRolesWithPermissionsForUser(t *testing.T) {
 	}
 }
 
+// TestGrantRoleToUser tests the GrantRoleToUser handler
+func TestGrantRoleToUser(t *testing.T) {
+	// Declare the data
+	validUntil := time.Now().Add(48 * time.Hour)
+	validGrantBody, _ := json.Marshal(models.RoleGrant{
+		RoleID:     uuid.New(),
+		ValidUntil: &validUntil,
+		Reason:     "support ticket",
+	})
+
+	// Define the test cases
+	tests := []struct {
+		name           string
+		body           []byte
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().GrantRoleToUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be StatusBadRequest, but not with mock
+		},
+		{
+			name: "fails to decode",
+			body: []byte(`invalid json`),
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().GrantRoleToUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fails to grant role",
+			body: validGrantBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().GrantRoleToUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, models.ErrRoleGrantWindowInvalid.Error()))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "succeeded",
+			body: validGrantBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().GrantRoleToUser(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, req *securitypb.GrantRoleToUserRequest, _ ...interface{}) (*securitypb.GrantRoleToUserResponse, error) {
+						assert.Nil(t, req.GetValidFrom())
+						assert.NotNil(t, req.GetValidUntil())
+						return &securitypb.GrantRoleToUserResponse{
+							Grant: &securitypb.RoleGrant{
+								UserId: req.GetUserId(),
+								RoleId: req.GetRoleId(),
+							},
+						}, nil
+					})
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", apiBasePath+"/security/role/user/"+uuid.New().String()+"/grant", bytes.NewBuffer(tt.body))
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.GrantRoleToUser(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestListRoleGrantsForUser tests the ListRoleGrantsForUser handler
+func TestListRoleGrantsForUser(t *testing.T) {
+	// Define the test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().ListRoleGrantsForUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be StatusBadRequest, but not with mock
+		},
+		{
+			name: "fails to list role grants",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().ListRoleGrantsForUser(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().ListRoleGrantsForUser(gomock.Any(), gomock.Any()).Return(&securitypb.ListRoleGrantsForUserResponse{
+					Grants: []*securitypb.RoleGrant{},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/security/role/user/"+uuid.New().String()+"/grant", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.ListRoleGrantsForUser(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
 // TestListUsersWithRoles tests the ListUsersWithRoles handler
 func TestListUsersWithRoles(t *testing.T) {
 	userID := uuid.New().String()
@@ -1093,6 +1378,20 @@ func TestListUsersWithRoles(t *testing.T) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name: "No users",
+			mockSetup: func(ctrl *gomock.Controller) {
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().ListUsersFull(gomock.Any(), gomock.Any()).Return(&securitypb.ListUsersFullResponse{}, nil)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name: "Fails to list users from users",
 			mockSetup: func(ctrl *gomock.Controller) {
@@ -1117,7 +1416,10 @@ func TestListUsersWithRoles(t *testing.T) {
 					Users: validSecurityUsers,
 				}, nil)
 				uc := mocks.NewMockUserServiceClient(ctrl)
-				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Return(&userpb.ListUsersResponse{
+				uc.EXPECT().ListUsers(gomock.Any(), &userpb.ListUsersRequest{
+					UserIds: []string{userID},
+					Limit:   1,
+				}).Return(&userpb.ListUsersResponse{
 					Users: validUserUsers,
 				}, nil)
 				clients.ReplaceGlobals(clients.NewClients(
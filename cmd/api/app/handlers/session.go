@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// ListUserSessionsSelf godoc
+//
+//	@Id				ListUserSessionsSelf
+//
+//	@Summary		List the sessions of the currently authenticated user
+//	@Description	Lists the devices on which the currently authenticated user is logged in. The session of the request is flagged as current.
+//	@Tags			User
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{array}		models.Session			"list of sessions"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/me/sessions [get]
+func ListUserSessionsSelf(w http.ResponseWriter, r *http.Request) {
+	userID, found := U().GetUserIDFromContext(r)
+	if !found {
+		zap.L().Debug("No context user provided")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// List sessions
+	response, err := clients.C().Auth().ListSessions(r.Context(), &authpb.ListSessionsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		zap.L().Error("List sessions", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	// Flag the session of the request
+	sessions := mappers.SessionsFromProto(response.GetSessions())
+	if sessionID, ok := U().GetSessionIDFromContext(r); ok {
+		for i := range sessions {
+			sessions[i].Current = sessions[i].ID.String() == sessionID
+		}
+	}
+
+	render.JSON(w, r, sessions)
+}
+
+// DeleteUserSessionSelf godoc
+//
+//	@Id				DeleteUserSessionSelf
+//
+//	@Summary		Terminate a session of the currently authenticated user
+//	@Description	Logs the currently authenticated user out of a device. The token of the session can no longer be used.
+//	@Tags			User
+//	@Param			session_id	path	string	true	"session ID"
+//	@Security		Bearer
+//	@Success		200	{string}	string					"status OK"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{string}	string					"Session not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/me/sessions/{session_id} [delete]
+func DeleteUserSessionSelf(w http.ResponseWriter, r *http.Request) {
+	userID, found := U().GetUserIDFromContext(r)
+	if !found {
+		zap.L().Debug("No context user provided")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	sessionID, ok := U().ParseParamUUID(w, r, "session_id")
+	if !ok {
+		return
+	}
+
+	// Terminate session
+	_, err := clients.C().Auth().DeleteSession(r.Context(), &authpb.DeleteSessionRequest{
+		UserId:    userID,
+		SessionId: sessionID.String(),
+	})
+	if err != nil {
+		zap.L().Error("Delete session", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.OK(w, r)
+}
+
+// ListUserSessions godoc
+//
+//	@Id				ListUserSessions
+//
+//	@Summary		List the sessions of a user
+//	@Description	Lists the devices on which a user is logged in. (Permission: <b>admin.users.sessions.list</b>)
+//	@Tags			User
+//	@Produce		json
+//	@Param			id	path	string	true	"user ID"
+//	@Security		Bearer
+//	@Success		200	{array}		models.Session			"list of sessions"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/{id}/sessions [get]
+func ListUserSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// List sessions
+	response, err := clients.C().Auth().ListSessions(r.Context(), &authpb.ListSessionsRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		zap.L().Error("List sessions", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.SessionsFromProto(response.GetSessions()))
+}
+
+// DeleteUserSession godoc
+//
+//	@Id				DeleteUserSession
+//
+//	@Summary		Terminate a session of a user
+//	@Description	Logs a user out of a device. The token of the session can no longer be used. (Permission: <b>admin.users.sessions.delete</b>)
+//	@Tags			User
+//	@Param			id			path	string	true	"user ID"
+//	@Param			session_id	path	string	true	"session ID"
+//	@Security		Bearer
+//	@Success		200	{string}	string					"status OK"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{string}	string					"Session not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/{id}/sessions/{session_id} [delete]
+func DeleteUserSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	sessionID, ok := U().ParseParamUUID(w, r, "session_id")
+	if !ok {
+		return
+	}
+
+	// Terminate session
+	_, err := clients.C().Auth().DeleteSession(r.Context(), &authpb.DeleteSessionRequest{
+		UserId:    userID.String(),
+		SessionId: sessionID.String(),
+	})
+	if err != nil {
+		zap.L().Error("Delete session", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.OK(w, r)
+}
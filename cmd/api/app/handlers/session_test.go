@@ -0,0 +1,368 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestListUserSessionsSelf tests the ListUserSessionsSelf handler
+func TestListUserSessionsSelf(t *testing.T) {
+	userID := uuid.New()
+	sessions := []*authpb.Session{
+		{Id: uuid.New().String(), UserId: userID.String()},
+		{Id: uuid.New().String(), UserId: userID.String()},
+	}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+		expectCurrent  []bool
+	}{
+		{
+			name: "Fails to retrieve user from context",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return("", false)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().ListSessions(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Fails to list sessions",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(userID.String(), true)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().ListSessions(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Internal, "error"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "Succeeded and flags the current session",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(userID.String(), true)
+				m.EXPECT().GetSessionIDFromContext(gomock.Any()).Return(sessions[1].Id, true)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().ListSessions(gomock.Any(), &authpb.ListSessionsRequest{UserId: userID.String()}).Return(&authpb.ListSessionsResponse{
+					Sessions: sessions,
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK,
+			expectCurrent:  []bool{false, true},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/user/me/sessions", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.ListUserSessionsSelf(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+			if tt.expectCurrent != nil {
+				var result []models.Session
+				assert.NoError(t, json.NewDecoder(response.Body).Decode(&result))
+				assert.Len(t, result, len(tt.expectCurrent))
+				for i, current := range tt.expectCurrent {
+					assert.Equal(t, current, result[i].Current)
+				}
+			}
+		})
+	}
+}
+
+// TestDeleteUserSessionSelf tests the DeleteUserSessionSelf handler
+func TestDeleteUserSessionSelf(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to retrieve user from context",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return("", false)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "session_id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to delete the session",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "session_id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "Session not found"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "session_id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Return(&authpb.DeleteSessionResponse{Success: true}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", apiBasePath+"/user/me/sessions/{session_id}", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.DeleteUserSessionSelf(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestListUserSessions tests the ListUserSessions handler
+func TestListUserSessions(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().ListSessions(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to list sessions",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().ListSessions(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "Permission denied"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				userID := uuid.New()
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(userID, true)
+				m.EXPECT().GetSessionIDFromContext(gomock.Any()).Times(0)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().ListSessions(gomock.Any(), gomock.Any()).Return(&authpb.ListSessionsResponse{
+					Sessions: []*authpb.Session{{Id: uuid.New().String(), UserId: userID.String()}},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/user/{id}/sessions", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.ListUserSessions(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestDeleteUserSession tests the DeleteUserSession handler
+func TestDeleteUserSession(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse user param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to parse session param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "session_id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to delete the session",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "session_id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "Permission denied"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "session_id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Return(&authpb.DeleteSessionResponse{Success: true}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(ac),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", apiBasePath+"/user/{id}/sessions/{session_id}", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.DeleteUserSession(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
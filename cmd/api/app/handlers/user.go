@@ -16,10 +16,11 @@ import (
 //	@Id				CreateUser
 //
 //	@Summary		Create a new user
-//	@Description	Create a new user.
+//	@Description	Create a new user. A verification code is sent to the email address, which must be verified before logging in.
 //	@Tags			User
 //	@Accept			json
 //	@Produce		json
+//	@Param			lang	query	string					false	"Language code"
 //	@Param			user	body	models.UserInputCreate	true	"user (json)"
 //	@Security		Bearer
 //	@Success		200	{object}	models.User				"user"
@@ -42,6 +43,7 @@ func CreateUser(w http.ResponseWriter, r *http.Request) {
 		Password:     userInputCreate.Password,
 		Confirmation: userInputCreate.Confirmation,
 		Checkbox:     userInputCreate.Checkbox,
+		Language:     U().ParseParamLanguage(w, r).String(),
 	}
 
 	// Create user
@@ -132,10 +134,11 @@ func GetUserSelf(w http.ResponseWriter, r *http.Request) {
 //	@Id				UpdateUserSelf
 //
 //	@Summary		Update the currently authenticated user
-//	@Description	Updates the currently authenticated user.
+//	@Description	Updates the currently authenticated user. A new email is only applied once verified through the code sent to that address.
 //	@Tags			User
 //	@Accept			json
 //	@Produce		json
+//	@Param			lang	query	string		false	"Language code (defaults to the language of the user)"
 //	@Param			user	body	models.User	true	"user (json)"
 //	@Security		Bearer
 //	@Success		200	{object}	models.User				"user"
@@ -162,8 +165,9 @@ func UpdateUserSelf(w http.ResponseWriter, r *http.Request) {
 
 	// Map User to gRPC UpdateUserRequest
 	updateUserRequest := &userpb.UpdateUserRequest{
-		Id:    userID,
-		Email: user.Email,
+		Id:       userID,
+		Email:    user.Email,
+		Language: r.URL.Query().Get("lang"), // Defaults to the language stored in the profile of the user
 	}
 
 	// Update user
@@ -234,8 +238,9 @@ func UpdateUserPassword(w http.ResponseWriter, r *http.Request) {
 //	@Id				DeleteUserSelf
 //
 //	@Summary		Delete the currently authenticated user
-//	@Description	Deletes the currently authenticated user.
+//	@Description	Schedules the deletion of the currently authenticated user, confirmed by email. Logging in before the end of the grace period cancels the deletion.
 //	@Tags			User
+//	@Param			lang	query	string	false	"Language code (defaults to the language of the user)"
 //	@Security		Bearer
 //	@Success		200	{string}	string					"status OK"
 //	@Failure		401	{string}	string					"Permission denied"
@@ -251,7 +256,8 @@ func DeleteUserSelf(w http.ResponseWriter, r *http.Request) {
 
 	// Delete user
 	_, err := clients.C().User().DeleteUser(r.Context(), &userpb.DeleteUserRequest{
-		Id: userID,
+		Id:       userID,
+		Language: r.URL.Query().Get("lang"), // Defaults to the language stored in the profile of the user
 	})
 	if err != nil {
 		zap.L().Error("Delete user", zap.Error(err))
@@ -259,5 +265,7 @@ func DeleteUserSelf(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	discardUserExports(userID)
+
 	render.OK(w, r)
 }
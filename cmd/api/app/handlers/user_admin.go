@@ -0,0 +1,368 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ListUsers godoc
+//
+//	@Id				ListUsers
+//
+//	@Summary		List the users
+//	@Description	Search the users, sorted and paginated. The link to the next page, if any, is returned in the Link header. (Permission: <b>admin.users.list</b>)
+//	@Tags			User
+//	@Produce		json
+//	@Param			email			query	string	false	"beginning of the email address (case insensitive)"
+//	@Param			role_id			query	string	false	"role held by the users"
+//	@Param			created_from	query	string	false	"created at or after (RFC3339)"
+//	@Param			created_to		query	string	false	"created before (RFC3339)"
+//	@Param			status			query	string	false	"status (active, unverified, disabled, pending_deletion)"
+//	@Param			sort			query	string	false	"sort (created_at, -created_at, email, -email)"
+//	@Param			limit			query	int		false	"maximum number of users"
+//	@Param			after			query	string	false	"cursor of the page, as given by the Link header of the previous page"
+//	@Security		Bearer
+//	@Success		200	{array}		models.User				"list of users"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad Request"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user [get]
+func ListUsers(w http.ResponseWriter, r *http.Request) {
+	req, ok := parseUserSearch(w, r)
+	if !ok {
+		return
+	}
+
+	// The holders of a role are only known by the security service
+	if roleID := r.URL.Query().Get("role_id"); roleID != "" {
+		usersWithRoles, next, err := searchUsersWithRoles(r.Context(), req, roleID)
+		if err != nil {
+			zap.L().Error("List users", zap.Error(err))
+			render.ErrorCodesCodeToHttpCode(w, r, err)
+			return
+		}
+		users := make(models.Users, len(usersWithRoles))
+		for i, user := range usersWithRoles {
+			users[i] = user.User
+		}
+		setNextPageLink(w, r, next)
+		render.JSON(w, r, users)
+		return
+	}
+
+	// List users
+	response, err := clients.C().User().ListUsers(r.Context(), req)
+	if err != nil {
+		zap.L().Error("List users", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	setNextPageLink(w, r, response.GetNextCursor())
+	render.JSON(w, r, mappers.UsersFromProto(response.GetUsers()))
+}
+
+// parseUserSearch constructs the search of users from the query parameters, except for the role.
+// Writes a bad request and returns false when a parameter is invalid.
+func parseUserSearch(w http.ResponseWriter, r *http.Request) (*userpb.ListUsersRequest, bool) {
+	query := r.URL.Query()
+
+	req := &userpb.ListUsersRequest{
+		Email:  query.Get("email"),
+		Status: query.Get("status"),
+		Sort:   query.Get("sort"),
+		After:  query.Get("after"),
+	}
+
+	if from := query.Get("created_from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			zap.L().Warn("Invalid created_from parameter", zap.String("created_from", from), zap.Error(err))
+			render.BadRequest(w, r, errors.New("created-from-invalid"))
+			return nil, false
+		}
+		req.CreatedFrom = timestamppb.New(parsed)
+	}
+	if to := query.Get("created_to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			zap.L().Warn("Invalid created_to parameter", zap.String("created_to", to), zap.Error(err))
+			render.BadRequest(w, r, errors.New("created-to-invalid"))
+			return nil, false
+		}
+		req.CreatedTo = timestamppb.New(parsed)
+	}
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			zap.L().Warn("Invalid limit parameter", zap.String("limit", limit), zap.Error(err))
+			render.BadRequest(w, r, errors.New("limit-invalid"))
+			return nil, false
+		}
+		req.Limit = int32(parsed)
+	}
+
+	return req, true
+}
+
+// setNextPageLink sets the Link header to the URL of the next page, the current URL with the given cursor
+func setNextPageLink(w http.ResponseWriter, r *http.Request, cursor string) {
+	if cursor == "" {
+		return
+	}
+	next := *r.URL
+	query := next.Query()
+	query.Set("after", cursor)
+	next.RawQuery = query.Encode()
+	w.Header().Set("Link", "<"+next.String()+`>; rel="next"`)
+}
+
+// UpdateUser godoc
+//
+//	@Id				UpdateUser
+//
+//	@Summary		Update a user
+//	@Description	Updates a user. A new email is only applied once verified through the code sent to that address. (Permission: <b>admin.users.update</b>)
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string		true	"user ID"
+//	@Param			lang	query	string		false	"Language code (defaults to the language of the user)"
+//	@Param			user	body	models.User	true	"user (json)"
+//	@Security		Bearer
+//	@Success		200	{object}	models.User				"user"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{string}	string					"User not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/{id} [put]
+func UpdateUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// Parse request body
+	var user models.User
+	err := json.NewDecoder(r.Body).Decode(&user)
+	if err != nil {
+		zap.L().Warn("User json decode", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Update user
+	response, err := clients.C().User().UpdateUser(r.Context(), &userpb.UpdateUserRequest{
+		Id:       userID.String(),
+		Email:    user.Email,
+		Language: r.URL.Query().Get("lang"), // Defaults to the language stored in the profile of the user
+	})
+	if err != nil {
+		zap.L().Error("Update user", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.UserFromProto(response.GetUser()))
+}
+
+// UpdateUserProfile godoc
+//
+//	@Id				UpdateUserProfile
+//
+//	@Summary		Update the profile of a user
+//	@Description	Updates the display name and preferences of a user. (Permission: <b>admin.users.update</b>)
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string				true	"user ID"
+//	@Param			profile	body	models.UserProfile	true	"profile (json)"
+//	@Security		Bearer
+//	@Success		200	{object}	models.User				"user"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/{id}/profile [put]
+func UpdateUserProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// Parse request body
+	var profile models.UserProfile
+	err := json.NewDecoder(r.Body).Decode(&profile)
+	if err != nil {
+		zap.L().Warn("User profile json decode", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Update profile
+	response, err := clients.C().User().UpdateUserProfile(r.Context(), &userpb.UpdateUserProfileRequest{
+		Id:      userID.String(),
+		Profile: mappers.UserProfileToProto(profile),
+	})
+	if err != nil {
+		zap.L().Error("Update user profile", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.UserFromProto(response.GetUser()))
+}
+
+// DeleteUser godoc
+//
+//	@Id				DeleteUser
+//
+//	@Summary		Delete a user
+//	@Description	Schedules the deletion of a user, confirmed to the user by email. Logging in before the end of the grace period cancels the deletion. (Permission: <b>admin.users.delete</b>)
+//	@Tags			User
+//	@Param			id		path	string	true	"user ID"
+//	@Param			lang	query	string	false	"Language code (defaults to the language of the user)"
+//	@Security		Bearer
+//	@Success		200	{string}	string					"status OK"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{string}	string					"User not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/{id} [delete]
+func DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// Delete user
+	_, err := clients.C().User().DeleteUser(r.Context(), &userpb.DeleteUserRequest{
+		Id:       userID.String(),
+		Language: r.URL.Query().Get("lang"), // Defaults to the language stored in the profile of the user
+	})
+	if err != nil {
+		zap.L().Error("Delete user", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	discardUserExports(userID.String())
+
+	render.OK(w, r)
+}
+
+// DisableUser godoc
+//
+//	@Id				DisableUser
+//
+//	@Summary		Disable a user account
+//	@Description	Disables a user account : the user can no longer log in and its sessions are terminated. (Permission: <b>admin.users.update</b>)
+//	@Tags			User
+//	@Produce		json
+//	@Param			id	path	string	true	"user ID"
+//	@Security		Bearer
+//	@Success		200	{object}	models.User				"user"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{string}	string					"User not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/{id}/disable [put]
+func DisableUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// Disable user
+	response, err := clients.C().User().SetUserDisabled(r.Context(), &userpb.SetUserDisabledRequest{
+		Id:       userID.String(),
+		Disabled: true,
+	})
+	if err != nil {
+		zap.L().Error("Disable user", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.UserFromProto(response.GetUser()))
+}
+
+// EnableUser godoc
+//
+//	@Id				EnableUser
+//
+//	@Summary		Enable a user account
+//	@Description	Enables a disabled user account, the user can log in again. (Permission: <b>admin.users.update</b>)
+//	@Tags			User
+//	@Produce		json
+//	@Param			id	path	string	true	"user ID"
+//	@Security		Bearer
+//	@Success		200	{object}	models.User				"user"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{string}	string					"User not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/{id}/disable [delete]
+func EnableUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// Enable user
+	response, err := clients.C().User().SetUserDisabled(r.Context(), &userpb.SetUserDisabledRequest{
+		Id:       userID.String(),
+		Disabled: false,
+	})
+	if err != nil {
+		zap.L().Error("Enable user", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.UserFromProto(response.GetUser()))
+}
+
+// ForceUserPasswordReset godoc
+//
+//	@Id				ForceUserPasswordReset
+//
+//	@Summary		Force a user to reset its password
+//	@Description	The user can no longer log in until the password is reset through the forgotten password process, its sessions are terminated. (Permission: <b>admin.users.update</b>)
+//	@Tags			User
+//	@Produce		json
+//	@Param			id	path	string	true	"user ID"
+//	@Security		Bearer
+//	@Success		200	{string}	string					"status OK"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		404	{string}	string					"User not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/{id}/password/reset [post]
+func ForceUserPasswordReset(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// Require the reset
+	_, err := clients.C().User().ForcePasswordReset(r.Context(), &userpb.ForcePasswordResetRequest{
+		Id: userID.String(),
+	})
+	if err != nil {
+		zap.L().Error("Force user password reset", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.OK(w, r)
+}
@@ -0,0 +1,674 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/export"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestListUsers tests the ListUsers handler
+func TestListUsers(t *testing.T) {
+	roleID := uuid.New()
+
+	// Test cases
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+		expectedLink   string
+	}{
+		{
+			name:  "Fails with an invalid limit",
+			query: "?limit=abc",
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Fails with an invalid creation date",
+			query: "?created_from=yesterday",
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Fails to list users",
+			query: "",
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "Permission denied"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "Succeeded",
+			query: "?email=jane&created_from=2025-01-01T00:00:00Z&status=active&sort=-email&limit=10&after=cursor",
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListUsers(gomock.Any(), &userpb.ListUsersRequest{
+					Email:       "jane",
+					CreatedFrom: timestamppb.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+					Status:      models.UserStatusActive,
+					Sort:        "-email",
+					Limit:       10,
+					After:       "cursor",
+				}).Return(&userpb.ListUsersResponse{
+					Users:      []*userpb.User{{Id: uuid.New().String()}},
+					NextCursor: "next",
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+			expectedLink:   "after=next",
+		},
+		{
+			name:  "Fails to list the holders of the role",
+			query: "?role_id=" + roleID.String(),
+			mockSetup: func(ctrl *gomock.Controller) {
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().ListUsersFull(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "role-invalid"))
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Succeeded with a role",
+			query: "?role_id=" + roleID.String() + "&sort=-email&limit=10",
+			mockSetup: func(ctrl *gomock.Controller) {
+				userID := uuid.New()
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().ListUsersFull(gomock.Any(), &securitypb.ListUsersFullRequest{
+					RoleId: roleID.String(),
+					Sort:   "-email",
+					Limit:  10,
+				}).Return(&securitypb.ListUsersFullResponse{
+					Users:      []*securitypb.UserWithRoles{{UserId: userID.String()}},
+					NextCursor: "next",
+				}, nil)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ListUsers(gomock.Any(), &userpb.ListUsersRequest{
+					UserIds: []string{userID.String()},
+					Sort:    "-email",
+					Limit:   1,
+				}).Return(&userpb.ListUsersResponse{
+					Users: []*userpb.User{{Id: userID.String()}},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithSecurityClient(sc),
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+			expectedLink:   "after=next",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", apiBasePath+"/user"+tt.query, nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.ListUsers(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+			if tt.expectedLink != "" {
+				assert.Contains(t, response.Header.Get("Link"), tt.expectedLink)
+			} else {
+				assert.Empty(t, response.Header.Get("Link"))
+			}
+		})
+	}
+}
+
+// TestUpdateUser tests the UpdateUser handler
+func TestUpdateUser(t *testing.T) {
+	validUser, _ := json.Marshal(models.User{Email: "jane@example.com"})
+
+	// Test cases
+	tests := []struct {
+		name           string
+		body           []byte
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			body: validUser,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to decode",
+			body: []byte("invalid json"),
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fails to update user",
+			body: validUser,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "User not found"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Succeeded",
+			body: validUser,
+			mockSetup: func(ctrl *gomock.Controller) {
+				userID := uuid.New()
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(userID, true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUser(gomock.Any(), &userpb.UpdateUserRequest{
+					Id:    userID.String(),
+					Email: "jane@example.com",
+				}).Return(&userpb.UpdateUserResponse{User: &userpb.User{Id: userID.String()}}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", apiBasePath+"/user/{id}", bytes.NewBuffer(tt.body))
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.UpdateUser(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestUpdateUserProfile tests the UpdateUserProfile handler
+func TestUpdateUserProfile(t *testing.T) {
+	validProfile, _ := json.Marshal(models.UserProfile{DisplayName: "Jane"})
+
+	// Test cases
+	tests := []struct {
+		name           string
+		body           []byte
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			body: validProfile,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to decode",
+			body: []byte("invalid json"),
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fails to update profile",
+			body: validProfile,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "Permission denied"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Succeeded",
+			body: validProfile,
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any()).Return(&userpb.UpdateUserProfileResponse{
+					User: &userpb.User{Id: uuid.New().String()},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", apiBasePath+"/user/{id}/profile", bytes.NewBuffer(tt.body))
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.UpdateUserProfile(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestDeleteUser tests the DeleteUser handler
+func TestDeleteUser(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to delete user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "User not found"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				userID := uuid.New()
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(userID, true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().DeleteUser(gomock.Any(), &userpb.DeleteUserRequest{
+					Id:       userID.String(),
+					Language: "fr",
+				}).Return(&userpb.DeleteUserResponse{Success: true}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Discard(userID).Return(errors.New("error"))
+				export.ReplaceGlobals(e)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", apiBasePath+"/user/{id}?lang=fr", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.DeleteUser(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestDisableUser tests the DisableUser handler
+func TestDisableUser(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().SetUserDisabled(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to disable user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().SetUserDisabled(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "Permission denied"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				userID := uuid.New()
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(userID, true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().SetUserDisabled(gomock.Any(), &userpb.SetUserDisabledRequest{
+					Id:       userID.String(),
+					Disabled: true,
+				}).Return(&userpb.SetUserDisabledResponse{
+					User: &userpb.User{Id: userID.String()},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", apiBasePath+"/user/{id}/disable", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.DisableUser(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestEnableUser tests the EnableUser handler
+func TestEnableUser(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().SetUserDisabled(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to enable user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().SetUserDisabled(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "User not found"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				userID := uuid.New()
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(userID, true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().SetUserDisabled(gomock.Any(), &userpb.SetUserDisabledRequest{
+					Id:       userID.String(),
+					Disabled: false,
+				}).Return(&userpb.SetUserDisabledResponse{
+					User: &userpb.User{Id: userID.String()},
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", apiBasePath+"/user/{id}/disable", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.EnableUser(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestForceUserPasswordReset tests the ForceUserPasswordReset handler
+func TestForceUserPasswordReset(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to parse param",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.Nil, false)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ForcePasswordReset(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK, // should be http.StatusBadRequest, but not with mock
+		},
+		{
+			name: "Fails to force the password reset",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ForcePasswordReset(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "User not found"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamUUID(gomock.Any(), gomock.Any(), "id").Return(uuid.New(), true)
+				handlers.ReplaceGlobals(m)
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ForcePasswordReset(gomock.Any(), gomock.Any()).Return(&userpb.ForcePasswordResetResponse{Success: true}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", apiBasePath+"/user/{id}/password/reset", nil)
+
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.ForceUserPasswordReset(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
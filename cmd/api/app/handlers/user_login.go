@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+// ListUserLoginsSelf godoc
+//
+//	@Id				ListUserLoginsSelf
+//
+//	@Summary		List the login history of the currently authenticated user
+//	@Description	Lists the latest login attempts on the account of the currently authenticated user, successful or not, from the most recent one.
+//	@Tags			User
+//	@Produce		json
+//	@Param			limit	query	int	false	"maximum number of entries"
+//	@Security		Bearer
+//	@Success		200	{array}		models.UserLogin		"list of login attempts"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad Request"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/me/logins [get]
+func ListUserLoginsSelf(w http.ResponseWriter, r *http.Request) {
+	userID, found := U().GetUserIDFromContext(r)
+	if !found {
+		zap.L().Debug("No context user provided")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	listUserLogins(w, r, userID)
+}
+
+// ListUserLogins godoc
+//
+//	@Id				ListUserLogins
+//
+//	@Summary		List the login history of a user
+//	@Description	Lists the latest login attempts on the account of a user, successful or not, from the most recent one. (Permission: <b>admin.users.read</b>)
+//	@Tags			User
+//	@Produce		json
+//	@Param			id		path	string	true	"user ID"
+//	@Param			limit	query	int		false	"maximum number of entries"
+//	@Security		Bearer
+//	@Success		200	{array}		models.UserLogin		"list of login attempts"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad Request"
+//	@Failure		401	{string}	string					"Permission denied"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/user/{id}/logins [get]
+func ListUserLogins(w http.ResponseWriter, r *http.Request) {
+	userID, ok := U().ParseParamUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	listUserLogins(w, r, userID.String())
+}
+
+// listUserLogins renders the login history of the user, bounded by the optional limit query parameter
+func listUserLogins(w http.ResponseWriter, r *http.Request, userID string) {
+	req := &userpb.ListUserLoginsRequest{
+		UserId: userID,
+	}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		parsed, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			zap.L().Warn("Invalid limit parameter", zap.String("limit", limit), zap.Error(err))
+			render.BadRequest(w, r, errors.New("limit-invalid"))
+			return
+		}
+		req.Limit = int32(parsed)
+	}
+
+	// List logins
+	response, err := clients.C().User().ListUserLogins(r.Context(), req)
+	if err != nil {
+		zap.L().Error("List user logins", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, mappers.UserLoginsFromProto(response.GetLogins()))
+}
@@ -7,12 +7,14 @@ import (
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
 	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/export"
 	"github.com/Zapharaos/fihub-backend/internal/models"
 	"github.com/Zapharaos/fihub-backend/test/mocks"
 	"github.com/google/uuid"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -63,6 +65,9 @@ func TestCreateUser(t *testing.T) {
 			name: "Fail at create",
 			user: validUserBody,
 			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
+				handlers.ReplaceGlobals(m)
 				uc := mocks.NewMockUserServiceClient(ctrl)
 				uc.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
 				clients.ReplaceGlobals(clients.NewClients(
@@ -75,6 +80,9 @@ func TestCreateUser(t *testing.T) {
 			name: "Succeeded",
 			user: validUserBody,
 			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockApiUtils(ctrl)
+				m.EXPECT().ParseParamLanguage(gomock.Any(), gomock.Any()).Return(language.English)
+				handlers.ReplaceGlobals(m)
 				uc := mocks.NewMockUserServiceClient(ctrl)
 				uc.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(validResponse, nil)
 				clients.ReplaceGlobals(clients.NewClients(
@@ -510,7 +518,8 @@ func TestDeleteUserSelf(t *testing.T) {
 			name: "Succeeded",
 			mockSetup: func(ctrl *gomock.Controller) {
 				m := mocks.NewMockApiUtils(ctrl)
-				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(uuid.New().String(), true)
+				userID := uuid.New()
+				m.EXPECT().GetUserIDFromContext(gomock.Any()).Return(userID.String(), true)
 				handlers.ReplaceGlobals(m)
 				uc := mocks.NewMockUserServiceClient(ctrl)
 				uc.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(&userpb.DeleteUserResponse{
@@ -519,6 +528,9 @@ func TestDeleteUserSelf(t *testing.T) {
 				clients.ReplaceGlobals(clients.NewClients(
 					clients.WithUserClient(uc),
 				))
+				e := mocks.NewMockExportService(ctrl)
+				e.EXPECT().Discard(userID).Return(nil)
+				export.ReplaceGlobals(e)
 			},
 			expectedStatus: http.StatusOK,
 		},
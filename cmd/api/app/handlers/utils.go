@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
 	"github.com/Zapharaos/fihub-backend/internal/app"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"golang.org/x/text/language"
 	"io"
+	"net"
 	"net/http"
 	"strconv"
 	"sync"
@@ -19,6 +21,8 @@ import (
 // Utils defines the interface for handler utility functions
 type Utils interface {
 	GetUserIDFromContext(r *http.Request) (string, bool)
+	GetSessionIDFromContext(r *http.Request) (string, bool)
+	GetClientIP(r *http.Request) string
 	ParseParamString(w http.ResponseWriter, r *http.Request, key string) (string, bool)
 	ParseParamUUID(w http.ResponseWriter, r *http.Request, key string) (uuid.UUID, bool)
 	ParseParamLanguage(w http.ResponseWriter, r *http.Request) language.Tag
@@ -74,6 +78,26 @@ func (u *utils) GetUserIDFromContext(r *http.Request) (string, bool) {
 	return userID, true
 }
 
+// GetSessionIDFromContext extract the logged user session ID from the request context
+func (u *utils) GetSessionIDFromContext(r *http.Request) (string, bool) {
+	sessionID, ok := r.Context().Value(app.ContextKeySessionID).(string)
+	if !ok || sessionID == "" {
+		zap.L().Warn("No context sessionID provided")
+		return "", false
+	}
+	return sessionID, true
+}
+
+// GetClientIP extracts the client IP address from the request.
+// The remote address is expected to be set by the RealIP middleware when behind a proxy.
+func (u *utils) GetClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // ParseParamString parses a string from the request parameters (using key parameter)
 func (u *utils) ParseParamString(w http.ResponseWriter, r *http.Request, key string) (string, bool) {
 	value := chi.URLParam(r, key)
@@ -101,15 +125,27 @@ func (u *utils) ParseParamUUID(w http.ResponseWriter, r *http.Request, key strin
 }
 
 // ParseParamLanguage parses a language from the request parameters
+// If none is provided, the loaded language best matching the Accept-Language header is used, otherwise the default one.
+// The emails sent to an existing user are rather localized by the services sending them, from the language stored in the profile of the user.
 func (u *utils) ParseParamLanguage(w http.ResponseWriter, r *http.Request) language.Tag {
 	langParam := r.URL.Query().Get("lang")
 	lang, err := language.Parse(langParam)
-	if langParam == "" || err != nil {
-		// If no language is provided, use the default language
-		defaultLang := viper.GetString("DEFAULT_LANGUAGE")
-		return language.MustParse(defaultLang)
+	if langParam != "" && err == nil {
+		return lang
 	}
-	return lang
+
+	// Negotiate the language from the Accept-Language header
+	if header := r.Header.Get("Accept-Language"); header != "" {
+		tags, _, err := language.ParseAcceptLanguage(header)
+		if err == nil && len(tags) > 0 {
+			return translation.S().Match(tags...)
+		}
+		zap.L().Debug("Parse Accept-Language", zap.String("header", header), zap.Error(err))
+	}
+
+	// If no language is provided, use the default language
+	defaultLang := viper.GetString("DEFAULT_LANGUAGE")
+	return language.MustParse(defaultLang)
 }
 
 // ParseParamBool parses a boolean from the request parameters (using key parameter)
@@ -3,8 +3,10 @@ package handlers_test
 import (
 	"bytes"
 	"context"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
 	"github.com/Zapharaos/fihub-backend/internal/app"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
 	"github.com/Zapharaos/fihub-backend/test/mocks"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -69,6 +71,96 @@ func TestGetUserIDFromContext(t *testing.T) {
 	}
 }
 
+// TestGetSessionIDFromContext tests the GetSessionIDFromContext function
+func TestGetSessionIDFromContext(t *testing.T) {
+	// Define valid session data
+	sessionID := uuid.New().String()
+
+	// Replace the global utils with a new instance
+	handlers.ReplaceGlobals(handlers.NewUtils())
+
+	// Define the test cases
+	tests := []struct {
+		name          string
+		context       context.Context
+		expectOK      bool
+		expectSession string
+	}{
+		{
+			name:     "no context",
+			context:  context.Background(),
+			expectOK: false,
+		},
+		{
+			name:     "empty session in context",
+			context:  context.WithValue(context.Background(), app.ContextKeySessionID, ""),
+			expectOK: false,
+		},
+		{
+			name:          "valid session in context",
+			context:       context.WithValue(context.Background(), app.ContextKeySessionID, sessionID),
+			expectOK:      true,
+			expectSession: sessionID,
+		},
+	}
+
+	// Run the test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new request with the context
+			r := httptest.NewRequest("GET", "/", nil).WithContext(tt.context)
+
+			// Call the function
+			resultSession, ok := handlers.U().GetSessionIDFromContext(r)
+
+			// Check the results
+			assert.Equal(t, tt.expectOK, ok)
+			assert.Equal(t, tt.expectSession, resultSession)
+		})
+	}
+}
+
+// TestGetClientIP tests the GetClientIP function
+func TestGetClientIP(t *testing.T) {
+	// Replace the global utils with a new instance
+	handlers.ReplaceGlobals(handlers.NewUtils())
+
+	// Define the test cases
+	tests := []struct {
+		name       string
+		remoteAddr string
+		expectIP   string
+	}{
+		{
+			name:       "address with port",
+			remoteAddr: "192.0.2.1:1234",
+			expectIP:   "192.0.2.1",
+		},
+		{
+			name:       "address without port",
+			remoteAddr: "192.0.2.1",
+			expectIP:   "192.0.2.1",
+		},
+		{
+			name:       "IPv6 address with port",
+			remoteAddr: "[2001:db8::1]:1234",
+			expectIP:   "2001:db8::1",
+		},
+	}
+
+	// Run the test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new request with the remote address
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+
+			// Call the function and check the result
+			assert.Equal(t, tt.expectIP, handlers.U().GetClientIP(r))
+		})
+	}
+}
+
 // TestParseParamUUID tests the ParseParamUUID function
 func TestParseParamUUID(t *testing.T) {
 	// Define valid data
@@ -196,6 +288,7 @@ func TestParseParamString(t *testing.T) {
 func TestParseParamLanguage(t *testing.T) {
 	// Define data
 	defaultLanguage := language.English
+	userID := uuid.New().String()
 
 	// Replace the global utils with a new instance
 	handlers.ReplaceGlobals(handlers.NewUtils())
@@ -205,11 +298,12 @@ func TestParseParamLanguage(t *testing.T) {
 
 	// Define the test cases
 	tests := []struct {
-		name       string
-		langParam  string
-		expectOK   bool
-		expectCode int
-		expectLang language.Tag
+		name           string
+		langParam      string
+		acceptLanguage string
+		userID         string
+		mockSetup      func(ctrl *gomock.Controller)
+		expectLang     language.Tag
 	}{
 		{
 			name:       "missing language parameter",
@@ -226,14 +320,67 @@ func TestParseParamLanguage(t *testing.T) {
 			langParam:  "fr",
 			expectLang: language.MustParse("fr"),
 		},
+		{
+			name:   "authenticated user without language parameter",
+			userID: userID,
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(clients.WithUserClient(uc)))
+			},
+			expectLang: defaultLanguage,
+		},
+		{
+			name:           "negotiated language from the Accept-Language header",
+			acceptLanguage: "de;q=0.9, fr-CA, en;q=0.5",
+			mockSetup: func(ctrl *gomock.Controller) {
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Match(language.CanadianFrench, language.German, language.English).Return(language.French)
+				translation.ReplaceGlobals(tr)
+			},
+			expectLang: language.French,
+		},
+		{
+			name:           "invalid Accept-Language header",
+			acceptLanguage: "fr;q=x",
+			mockSetup: func(ctrl *gomock.Controller) {
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Match(gomock.Any()).Times(0)
+				translation.ReplaceGlobals(tr)
+			},
+			expectLang: defaultLanguage,
+		},
+		{
+			name:           "language parameter over the Accept-Language header",
+			langParam:      "fr",
+			acceptLanguage: "de",
+			mockSetup: func(ctrl *gomock.Controller) {
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Match(gomock.Any()).Times(0)
+				translation.ReplaceGlobals(tr)
+			},
+			expectLang: language.French,
+		},
 	}
 
 	// Run the test cases
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			if tt.mockSetup != nil {
+				tt.mockSetup(ctrl)
+			}
+
 			// Create a new recorder
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest("GET", "/", nil)
+			if tt.userID != "" {
+				r = r.WithContext(context.WithValue(r.Context(), app.ContextKeyUserID, tt.userID))
+			}
+			if tt.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
 
 			// Add the language parameter to the request
 			q := r.URL.Query()
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers/render"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// VerifyEmail godoc
+//
+//	@Id				VerifyEmail
+//
+//	@Summary		Verify an email address
+//	@Description	Verifies the email address of a user using the code sent to that address. Confirms a pending email change, in which case the previous address is notified.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			lang	query	string						false	"Language code (defaults to the language of the user)"
+//	@Param			request	body	models.EmailVerificationInput	true	"request (json)"
+//	@Success		200	{object}	models.User				"user"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		404	{string}	string					"User not found"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/auth/email/verify [post]
+func VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var input models.EmailVerificationInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		zap.L().Warn("EmailVerificationInput json decode", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Verify email
+	response, err := clients.C().User().VerifyEmail(r.Context(), &userpb.VerifyEmailRequest{
+		UserId:   input.UserID.String(),
+		Token:    input.Token,
+		Language: r.URL.Query().Get("lang"), // Defaults to the language stored in the profile of the user
+	})
+	if err != nil {
+		zap.L().Error("Verify email", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	// Map the response to the models.User struct
+	render.JSON(w, r, mappers.UserFromProto(response.GetUser()))
+}
+
+// ResendEmailVerification godoc
+//
+//	@Id				ResendEmailVerification
+//
+//	@Summary		Resend the email verification
+//	@Description	Sends a new verification code to the email address of an unverified user. The response is the same whether or not the address belongs to an account.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			lang	query	string								false	"Language code (defaults to the language of the user)"
+//	@Param			request	body	models.EmailVerificationInputResend	true	"request (json)"
+//	@Success		202	{string}	string					"status accepted"
+//	@Failure		400	{object}	render.ErrorResponse	"Bad PasswordRequest"
+//	@Failure		500	{object}	render.ErrorResponse	"Internal Server Error"
+//	@Router			/api/v1/auth/email/verify/resend [post]
+func ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var input models.EmailVerificationInputResend
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		zap.L().Warn("EmailVerificationInputResend json decode", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Resend verification
+	_, err = clients.C().User().ResendEmailVerification(r.Context(), &userpb.ResendEmailVerificationRequest{
+		Email:    input.Email,
+		Language: r.URL.Query().Get("lang"), // Defaults to the language stored in the profile of the user
+	})
+	if err != nil {
+		zap.L().Error("Resend email verification", zap.Error(err))
+		render.ErrorCodesCodeToHttpCode(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
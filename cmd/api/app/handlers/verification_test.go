@@ -0,0 +1,181 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/clients"
+	"github.com/Zapharaos/fihub-backend/cmd/api/app/handlers"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestVerifyEmail tests the VerifyEmail handler
+func TestVerifyEmail(t *testing.T) {
+	// Prepare data
+	validInput := models.EmailVerificationInput{
+		UserID: uuid.New(),
+		Token:  "123456",
+	}
+	validInputBody, _ := json.Marshal(validInput)
+	validResponse := &userpb.VerifyEmailResponse{
+		User: &userpb.User{
+			Id:            validInput.UserID.String(),
+			EmailVerified: true,
+		},
+	}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		body           []byte
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to decode",
+			body: []byte(`invalid json`),
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().VerifyEmail(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid token",
+			body: validInputBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().VerifyEmail(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "token-invalid"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Succeeded",
+			body: validInputBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().VerifyEmail(gomock.Any(), &userpb.VerifyEmailRequest{
+					UserId:   validInput.UserID.String(),
+					Token:    validInput.Token,
+					Language: language.French.String(),
+				}).Return(validResponse, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", apiBasePath+"/auth/email/verify?lang=fr", bytes.NewBuffer(tt.body))
+
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.VerifyEmail(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+// TestResendEmailVerification tests the ResendEmailVerification handler
+func TestResendEmailVerification(t *testing.T) {
+	// Prepare data
+	validInput := models.EmailVerificationInputResend{
+		Email: "email@test.ut",
+	}
+	validInputBody, _ := json.Marshal(validInput)
+	validResponse := &userpb.ResendEmailVerificationResponse{
+		Success: true,
+	}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		body           []byte
+		mockSetup      func(ctrl *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "Fails to decode",
+			body: []byte(`invalid json`),
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ResendEmailVerification(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Fails to resend",
+			body: validInputBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ResendEmailVerification(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Internal, "error"))
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "Succeeded",
+			body: validInputBody,
+			mockSetup: func(ctrl *gomock.Controller) {
+				uc := mocks.NewMockUserServiceClient(ctrl)
+				uc.EXPECT().ResendEmailVerification(gomock.Any(), gomock.Any()).Return(validResponse, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithUserClient(uc),
+				))
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiBasePath := viper.GetString("API_BASE_PATH")
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", apiBasePath+"/auth/email/verify/resend", bytes.NewBuffer(tt.body))
+
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			handlers.ResendEmailVerification(w, r)
+			response := w.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
@@ -6,8 +6,9 @@ import (
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/server"
 	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
 	"github.com/Zapharaos/fihub-backend/internal/app"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/metadata"
 	"net/http"
 )
 
@@ -21,6 +22,8 @@ func extractToken(r *http.Request) string {
 
 // AuthMiddleware is a middleware for authenticating requests.
 func AuthMiddleware(config server.Config) func(http.Handler) http.Handler {
+	identity := grpcutil.NewIdentityAuthorityFromConfig()
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// No auth in insecure mode
@@ -31,6 +34,8 @@ func AuthMiddleware(config server.Config) func(http.Handler) http.Handler {
 
 			token := extractToken(r)
 			userID := ""
+			sessionID := ""
+			actorID := ""
 
 			// Gateway mode: skip validation
 			// WARNING: this is a security risk, don't use unless you know what you're doing.
@@ -45,6 +50,8 @@ func AuthMiddleware(config server.Config) func(http.Handler) http.Handler {
 					return
 				}
 				userID = response.GetUserId()
+				sessionID = response.GetSessionId()
+				actorID = response.GetActorId()
 			} else {
 				// Validate token
 				response, err := clients.C().Auth().ValidateToken(r.Context(), &authpb.ValidateTokenRequest{
@@ -56,15 +63,45 @@ func AuthMiddleware(config server.Config) func(http.Handler) http.Handler {
 					return
 				}
 				userID = response.GetUserId()
+				sessionID = response.GetSessionId()
+				actorID = response.GetActorId()
+			}
+
+			// Parse the user ID to make sure the assertion targets a valid user
+			parsedUserID, err := uuid.Parse(userID)
+			if err != nil {
+				zap.L().Error("Invalid user ID", zap.String("user_id", userID), zap.Error(err))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
 			}
 
-			// Setup metadata for gRPC clients as context
-			md := metadata.Pairs("x-user-id", userID)
-			ctx := metadata.NewOutgoingContext(r.Context(), md)
+			// Impersonation tokens also carry the administrator acting on behalf of the user
+			principal := grpcutil.Principal{UserID: parsedUserID}
+			if actorID != "" {
+				parsedActorID, err := uuid.Parse(actorID)
+				if err != nil {
+					zap.L().Error("Invalid actor ID", zap.String("actor_id", actorID), zap.Error(err))
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				principal.ActorID = uuid.NullUUID{UUID: parsedActorID, Valid: true}
+			}
+
+			// Setup signed identity assertion for gRPC clients as context
+			ctx, err := identity.AppendPrincipalToOutgoingContext(r.Context(), principal)
+			if err != nil {
+				zap.L().Error("Sign identity assertion", zap.Error(err))
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
 			r = r.WithContext(ctx)
 
-			// Set user ID in context
+			// Set user ID and session ID in context
 			ctx = context.WithValue(r.Context(), app.ContextKeyUserID, userID)
+			ctx = context.WithValue(ctx, app.ContextKeySessionID, sessionID)
+			if principal.IsImpersonated() {
+				ctx = context.WithValue(ctx, app.ContextKeyActorID, actorID)
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
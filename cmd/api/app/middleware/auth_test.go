@@ -6,10 +6,12 @@ import (
 	"github.com/Zapharaos/fihub-backend/cmd/api/app/server"
 	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
 	"github.com/Zapharaos/fihub-backend/internal/app"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
 	"github.com/Zapharaos/fihub-backend/test/mocks"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/metadata"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -17,14 +19,17 @@ import (
 
 func TestAuthMiddleware(t *testing.T) {
 	inputUserID := uuid.New().String()
+	inputSessionID := uuid.New().String()
+	inputActorID := uuid.New().String()
 
 	// Define test cases
 	tests := []struct {
-		name       string
-		mockSetup  func(ctrl *gomock.Controller)
-		config     server.Config
-		expectCode int
-		expectCtx  bool
+		name        string
+		mockSetup   func(ctrl *gomock.Controller)
+		config      server.Config
+		expectCode  int
+		expectCtx   bool
+		expectActor string
 	}{
 		{
 			name: "no security mode",
@@ -75,13 +80,32 @@ func TestAuthMiddleware(t *testing.T) {
 			expectCode: http.StatusUnauthorized,
 			expectCtx:  false,
 		},
+		{
+			name: "fails with invalid user ID",
+			mockSetup: func(ctrl *gomock.Controller) {
+				authClient := mocks.NewMockAuthServiceClient(ctrl)
+				authClient.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).Return(&authpb.ValidateTokenResponse{
+					UserId: "invalid",
+				}, nil)
+				authClient.EXPECT().ExtractUserID(gomock.Any(), gomock.Any()).Times(0)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(authClient),
+				))
+			},
+			config: server.Config{
+				Security: true,
+			},
+			expectCode: http.StatusUnauthorized,
+			expectCtx:  false,
+		},
 		{
 			name: "success in gateway mode",
 			mockSetup: func(ctrl *gomock.Controller) {
 				authClient := mocks.NewMockAuthServiceClient(ctrl)
 				authClient.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).Times(0)
 				authClient.EXPECT().ExtractUserID(gomock.Any(), gomock.Any()).Return(&authpb.ExtractUserIDResponse{
-					UserId: inputUserID,
+					UserId:    inputUserID,
+					SessionId: inputSessionID,
 				}, nil)
 				clients.ReplaceGlobals(clients.NewClients(
 					clients.WithAuthClient(authClient),
@@ -99,7 +123,8 @@ func TestAuthMiddleware(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) {
 				authClient := mocks.NewMockAuthServiceClient(ctrl)
 				authClient.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).Return(&authpb.ValidateTokenResponse{
-					UserId: inputUserID,
+					UserId:    inputUserID,
+					SessionId: inputSessionID,
 				}, nil)
 				authClient.EXPECT().ExtractUserID(gomock.Any(), gomock.Any()).Times(0)
 				clients.ReplaceGlobals(clients.NewClients(
@@ -112,6 +137,45 @@ func TestAuthMiddleware(t *testing.T) {
 			expectCode: http.StatusOK,
 			expectCtx:  true,
 		},
+		{
+			name: "fails with invalid actor ID",
+			mockSetup: func(ctrl *gomock.Controller) {
+				authClient := mocks.NewMockAuthServiceClient(ctrl)
+				authClient.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).Return(&authpb.ValidateTokenResponse{
+					UserId:    inputUserID,
+					SessionId: inputSessionID,
+					ActorId:   "invalid",
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(authClient),
+				))
+			},
+			config: server.Config{
+				Security: true,
+			},
+			expectCode: http.StatusUnauthorized,
+			expectCtx:  false,
+		},
+		{
+			name: "success with impersonation token",
+			mockSetup: func(ctrl *gomock.Controller) {
+				authClient := mocks.NewMockAuthServiceClient(ctrl)
+				authClient.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).Return(&authpb.ValidateTokenResponse{
+					UserId:    inputUserID,
+					SessionId: inputSessionID,
+					ActorId:   inputActorID,
+				}, nil)
+				clients.ReplaceGlobals(clients.NewClients(
+					clients.WithAuthClient(authClient),
+				))
+			},
+			config: server.Config{
+				Security: true,
+			},
+			expectCode:  http.StatusOK,
+			expectCtx:   true,
+			expectActor: inputActorID,
+		},
 	}
 
 	for _, tt := range tests {
@@ -135,6 +199,25 @@ func TestAuthMiddleware(t *testing.T) {
 				if tt.expectCtx {
 					assert.True(t, ok, "User ID should be set in context")
 					assert.Equal(t, inputUserID, userID, "User ID should match")
+					sessionID, _ := r.Context().Value(app.ContextKeySessionID).(string)
+					assert.Equal(t, inputSessionID, sessionID, "Session ID should match")
+
+					// Verify the identity assertion propagated to gRPC clients
+					md, found := metadata.FromOutgoingContext(r.Context())
+					assert.True(t, found, "Outgoing metadata should be set in context")
+					assertions := md.Get(grpcutil.IdentityMetadataKey)
+					assert.Len(t, assertions, 1, "Identity assertion should be set in metadata")
+					principal, err := grpcutil.NewIdentityAuthorityFromConfig().Verify(assertions[0])
+					assert.NoError(t, err, "Identity assertion should be valid")
+					assert.Equal(t, inputUserID, principal.UserID.String(), "Principal should match")
+
+					// Verify the actor of impersonation tokens
+					actorID, _ := r.Context().Value(app.ContextKeyActorID).(string)
+					assert.Equal(t, tt.expectActor, actorID, "Actor ID should match")
+					assert.Equal(t, tt.expectActor != "", principal.IsImpersonated(), "Principal actor should match")
+					if tt.expectActor != "" {
+						assert.Equal(t, tt.expectActor, principal.ActorID.UUID.String(), "Principal actor should match")
+					}
 				} else {
 					assert.False(t, ok, "User ID should not be set in context")
 				}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	mw "github.com/go-chi/chi/v5/middleware"
+	"net"
+	"net/http"
+)
+
+// RequestInfoMiddleware propagates the request ID and the client IP address to the gRPC calls made while serving the request,
+// so that the microservices can trace them back, i.e. in the audit log.
+// It must be used after the RequestID and RealIP middlewares.
+func RequestInfoMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ipAddress, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ipAddress = r.RemoteAddr
+		}
+
+		ctx := grpcutil.AppendRequestInfoToOutgoingContext(r.Context(), grpcutil.RequestInfo{
+			RequestID: mw.GetReqID(r.Context()),
+			IPAddress: ipAddress,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
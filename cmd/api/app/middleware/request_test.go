@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	mw "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestInfoMiddleware(t *testing.T) {
+	var info grpcutil.RequestInfo
+	handler := mw.RequestID(RequestInfoMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info = grpcutil.RequestInfoFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:54321"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, info.RequestID)
+	assert.Equal(t, "203.0.113.7", info.IPAddress)
+}
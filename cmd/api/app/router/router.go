@@ -22,6 +22,7 @@ func New(config server.Config) *chi.Mux {
 	// Setup router
 	r.Use(mw.RequestID)
 	r.Use(mw.RealIP)
+	r.Use(middleware.RequestInfoMiddleware)
 	r.Use(mw.Logger)
 	r.Use(mw.Recoverer)
 
@@ -83,8 +84,31 @@ func New(config server.Config) *chi.Mux {
 				// Reset password using userID and requestID
 				r.Put("/{id}/{request_id}", handlers.ResetPassword)
 			})
+
+			// Email verification routes
+			r.Route("/email/verify", func(r chi.Router) {
+
+				// Input token using userID
+				inputLimit := viper.GetInt("OTP_MIDDLEWARE_INPUT_LIMIT")
+				inputLength := viper.GetDuration("OTP_MIDDLEWARE_INPUT_WINDOW")
+				if inputLength == 0 {
+					inputLength = 1 * time.Hour
+				}
+				r.With(httprate.LimitByIP(inputLimit, inputLength)).Post("/", handlers.VerifyEmail)
+
+				// Resend verification using email
+				requestLimit := viper.GetInt("OTP_MIDDLEWARE_REQUEST_LIMIT")
+				requestLength := viper.GetDuration("OTP_MIDDLEWARE_REQUEST_LENGTH")
+				if requestLength == 0 {
+					requestLength = 24 * time.Hour
+				}
+				r.With(httprate.LimitByIP(requestLimit, requestLength)).Post("/resend", handlers.ResendEmailVerification)
+			})
 		})
 
+		// Data export download : authenticated through the signed token sent by email
+		r.Get("/export/{token}", handlers.DownloadUserExport)
+
 		// Protected routes
 		r.Group(buildProtectedRoutes(config))
 	})
@@ -110,17 +134,87 @@ func buildProtectedRoutes(config server.Config) func(r chi.Router) {
 
 				// User's password : retrieving userID through context
 				r.Put("/password", handlers.UpdateUserPassword)
+
+				// User's profile and avatar : retrieving userID through context
+				r.Put("/profile", handlers.UpdateUserProfileSelf)
+				r.Route("/avatar", func(r chi.Router) {
+					r.Get("/", handlers.GetUserAvatarSelf)
+					r.Put("/", handlers.SetUserAvatarSelf)
+					r.Delete("/", handlers.DeleteUserAvatarSelf)
+				})
+
+				// User's effective permissions : retrieving userID through context
+				r.Get("/permissions", handlers.ListUserPermissionsSelf)
+
+				// User's sessions : retrieving userID through context
+				r.Route("/sessions", func(r chi.Router) {
+					r.Get("/", handlers.ListUserSessionsSelf)
+					r.Delete("/{session_id}", handlers.DeleteUserSessionSelf)
+				})
+
+				// User's login history : retrieving userID through context
+				r.Get("/logins", handlers.ListUserLoginsSelf)
+
+				// User's data export : retrieving userID through context
+				r.Post("/export", handlers.ExportUserSelf)
 			})
 
+			// Users administration
+			r.Get("/", handlers.ListUsers)
+
 			// User specific
 			r.Route("/{id}", func(r chi.Router) {
 				r.Get("/", handlers.GetUser)
+				r.Put("/", handlers.UpdateUser)
+				r.Delete("/", handlers.DeleteUser)
+				r.Put("/profile", handlers.UpdateUserProfile)
+
+				// User's account state
+				r.Put("/disable", handlers.DisableUser)
+				r.Delete("/disable", handlers.EnableUser)
+				r.Post("/password/reset", handlers.ForceUserPasswordReset)
+
+				// Login lockout
+				r.Delete("/lock", handlers.UnlockUser)
+
+				// Impersonation
+				r.Post("/impersonate", handlers.ImpersonateUser)
+
+				// Sessions
+				r.Route("/sessions", func(r chi.Router) {
+					r.Get("/", handlers.ListUserSessions)
+					r.Delete("/{session_id}", handlers.DeleteUserSession)
+				})
+
+				// Login history
+				r.Get("/logins", handlers.ListUserLogins)
+			})
+		})
+
+		// Email
+		r.Route("/email", func(r chi.Router) {
+
+			// Outbox
+			r.Route("/outbox", func(r chi.Router) {
+				r.Get("/", handlers.ListOutboxEmails)
+
+				// Email specific
+				r.Route("/{id}", func(r chi.Router) {
+					r.Get("/", handlers.GetOutboxEmail)
+					r.Post("/retry", handlers.RetryOutboxEmail)
+				})
 			})
+
+			// Template
+			r.Get("/template/{name}/preview", handlers.PreviewEmailTemplate)
 		})
 
 		// Security
 		r.Route("/security", func(r chi.Router) {
 
+			// Audit
+			r.Get("/audit", handlers.ListAuditLogs)
+
 			// Permission
 			r.Route("/permission", func(r chi.Router) {
 				r.Post("/", handlers.CreatePermission)
@@ -151,6 +245,11 @@ func buildProtectedRoutes(config server.Config) func(r chi.Router) {
 						r.Put("/", handlers.SetRolePermissions)
 					})
 
+					// Parents
+					r.Route("/parent", func(r chi.Router) {
+						r.Put("/", handlers.SetRoleParents)
+					})
+
 					// Users
 					r.Route("/user", func(r chi.Router) {
 						r.Get("/", handlers.ListUsersForRole)
@@ -166,6 +265,12 @@ func buildProtectedRoutes(config server.Config) func(r chi.Router) {
 					r.Route("/{id}", func(r chi.Router) {
 						r.Get("/", handlers.ListRolesWithPermissionsForUser)
 						r.Put("/", handlers.SetRolesForUser)
+
+						// Grants
+						r.Route("/grant", func(r chi.Router) {
+							r.Get("/", handlers.ListRoleGrantsForUser)
+							r.Post("/", handlers.GrantRoleToUser)
+						})
 					})
 				})
 			})
@@ -14,11 +14,15 @@ import (
 	"github.com/Zapharaos/fihub-backend/gen/go/transactionpb"
 	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
 	"github.com/Zapharaos/fihub-backend/internal/app"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
 	"github.com/Zapharaos/fihub-backend/internal/database"
+	"github.com/Zapharaos/fihub-backend/internal/export"
 	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
 	"github.com/Zapharaos/fihub-backend/internal/password"
 	"github.com/Zapharaos/fihub-backend/internal/security"
-	"github.com/Zapharaos/fihub-backend/pkg/email"
+	"github.com/Zapharaos/fihub-backend/pkg/hasher"
+	"github.com/Zapharaos/fihub-backend/pkg/passwordpolicy"
 	"github.com/Zapharaos/fihub-backend/pkg/translation"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
@@ -131,11 +135,13 @@ func initGrpcClients() {
 	authClient := authpb.NewAuthServiceClient(authConn)
 	securityClient := securitypb.NewSecurityServiceClient(securityConn)
 	publicSecurityClient := securitypb.NewPublicSecurityServiceClient(securityConn)
+	auditClient := securitypb.NewAuditServiceClient(securityConn)
 	brokerClient := brokerpb.NewBrokerServiceClient(brokerConn)
 	transactionClient := transactionpb.NewTransactionServiceClient(transactionConn)
 
 	// Setup facades
 	security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+	audit.ReplaceGlobals(audit.NewAuditFacadeWithGrpcClient(auditClient))
 
 	// Initialize the gRPC clients
 	clients.ReplaceGlobals(clients.NewClients(
@@ -143,6 +149,7 @@ func initGrpcClients() {
 		clients.WithUserClient(userClient),
 		clients.WithAuthClient(authClient),
 		clients.WithSecurityClient(securityClient),
+		clients.WithAuditClient(auditClient),
 		clients.WithBrokerClient(brokerClient),
 		clients.WithTransactionClient(transactionClient),
 	))
@@ -174,12 +181,22 @@ func setup() {
 		}
 	})
 
-	// Setup Email
-	email.ReplaceGlobals(email.NewSendgridService())
+	// Setup Data exports
+	exportService, err := export.NewServiceFromConfig()
+	if err != nil {
+		zap.L().Error("Failed to setup data exports", zap.Error(err))
+	} else {
+		export.ReplaceGlobals(exportService)
+	}
 
 	// Setup Translations
 	defaultLang := language.MustParse(viper.GetString("DEFAULT_LANGUAGE"))
 	translation.ReplaceGlobals(translation.NewI18nService(defaultLang))
+
+	// Setup Password hashing and policy
+	// TODO : remove once the password reset is moved to the user microservice
+	hasher.ReplaceGlobals(hasher.NewPasswordServiceFromConfig())
+	passwordpolicy.ReplaceGlobals(passwordpolicy.NewPolicyServiceFromConfig())
 }
 
 // setupPostgresRepositories initializes the Postgres repositories for the microservice.
@@ -187,4 +204,5 @@ func setupPostgresRepositories() {
 	// TODO : remove once auth fully migrated
 	userrepositories.ReplaceGlobals(userrepositories.NewPostgresRepository(database.DB().Postgres().DB))
 	password.ReplaceGlobals(password.NewPostgresRepository(database.DB().Postgres().DB))
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(database.DB().Postgres().DB))
 }
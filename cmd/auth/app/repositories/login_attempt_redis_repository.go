@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const (
+	loginFailuresKeyPrefix = "auth:login:failures:"
+	loginLockKeyPrefix     = "auth:login:lock:"
+)
+
+// LoginAttemptRedisRepository is a repository containing the LoginAttempt definition based on a Redis database and
+// implementing the repository interface
+type LoginAttemptRedisRepository struct {
+	client *redis.Client
+}
+
+// NewLoginAttemptRedisRepository returns a new instance of LoginAttemptRedisRepository
+func NewLoginAttemptRedisRepository(client *redis.Client) LoginAttemptRepository {
+	r := LoginAttemptRedisRepository{
+		client: client,
+	}
+	var repo LoginAttemptRepository = &r
+	return repo
+}
+
+// IncrementFailures increments the failed attempts counter of the key and returns its new value.
+// The counter expires once no failure was registered for the ttl duration.
+func (r *LoginAttemptRedisRepository) IncrementFailures(key string, ttl time.Duration) (int64, error) {
+	ctx := context.Background()
+
+	var incr *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, loginFailuresKeyPrefix+key)
+		pipe.Expire(ctx, loginFailuresKeyPrefix+key, ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+// Lock locks the key for the given duration
+func (r *LoginAttemptRedisRepository) Lock(key string, duration time.Duration) error {
+	return r.client.Set(context.Background(), loginLockKeyPrefix+key, 1, duration).Err()
+}
+
+// LockedFor returns the remaining lock duration of the key, zero if the key is not locked
+func (r *LoginAttemptRedisRepository) LockedFor(key string) (time.Duration, error) {
+	ttl, err := r.client.TTL(context.Background(), loginLockKeyPrefix+key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// Negative values indicate that the lock does not exist or has no expiration
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// Reset removes both the failed attempts counter and the lock of the key
+func (r *LoginAttemptRedisRepository) Reset(key string) error {
+	return r.client.Del(context.Background(), loginFailuresKeyPrefix+key, loginLockKeyPrefix+key).Err()
+}
@@ -0,0 +1,182 @@
+package repositories_test
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/auth/app/repositories"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestLoginAttemptRedisRepository_IncrementFailures test the LoginAttemptRedisRepository.IncrementFailures method
+func TestLoginAttemptRedisRepository_IncrementFailures(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewLoginAttemptRedisRepository(client), nil))
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectErr      bool
+		expectFailures int64
+	}{
+		{
+			name: "Fail to increment failures",
+			mockSetup: func() {
+				mock.ExpectTxPipeline()
+				mock.ExpectIncr("auth:login:failures:key").SetErr(errors.New("error"))
+			},
+			expectErr:      true,
+			expectFailures: 0,
+		},
+		{
+			name: "Increment failures",
+			mockSetup: func() {
+				mock.ExpectTxPipeline()
+				mock.ExpectIncr("auth:login:failures:key").SetVal(3)
+				mock.ExpectExpire("auth:login:failures:key", time.Hour).SetVal(true)
+				mock.ExpectTxPipelineExec()
+			},
+			expectErr:      false,
+			expectFailures: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			failures, err := repositories.R().L().IncrementFailures("key", time.Hour)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("IncrementFailures() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.Equal(t, tt.expectFailures, failures)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestLoginAttemptRedisRepository_Lock test the LoginAttemptRedisRepository.Lock method
+func TestLoginAttemptRedisRepository_Lock(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewLoginAttemptRedisRepository(client), nil))
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail to lock",
+			mockSetup: func() {
+				mock.ExpectSet("auth:login:lock:key", 1, time.Minute).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Lock",
+			mockSetup: func() {
+				mock.ExpectSet("auth:login:lock:key", 1, time.Minute).SetVal("OK")
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().L().Lock("key", time.Minute)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Lock() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestLoginAttemptRedisRepository_LockedFor test the LoginAttemptRedisRepository.LockedFor method
+func TestLoginAttemptRedisRepository_LockedFor(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewLoginAttemptRedisRepository(client), nil))
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectErr      bool
+		expectDuration time.Duration
+	}{
+		{
+			name: "Fail to retrieve lock",
+			mockSetup: func() {
+				mock.ExpectTTL("auth:login:lock:key").SetErr(errors.New("error"))
+			},
+			expectErr:      true,
+			expectDuration: 0,
+		},
+		{
+			name: "Not locked",
+			mockSetup: func() {
+				mock.ExpectTTL("auth:login:lock:key").SetVal(-2)
+			},
+			expectErr:      false,
+			expectDuration: 0,
+		},
+		{
+			name: "Locked",
+			mockSetup: func() {
+				mock.ExpectTTL("auth:login:lock:key").SetVal(time.Minute)
+			},
+			expectErr:      false,
+			expectDuration: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			duration, err := repositories.R().L().LockedFor("key")
+			if (err != nil) != tt.expectErr {
+				t.Errorf("LockedFor() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.Equal(t, tt.expectDuration, duration)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestLoginAttemptRedisRepository_Reset test the LoginAttemptRedisRepository.Reset method
+func TestLoginAttemptRedisRepository_Reset(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewLoginAttemptRedisRepository(client), nil))
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail to reset",
+			mockSetup: func() {
+				mock.ExpectDel("auth:login:failures:key", "auth:login:lock:key").SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Reset",
+			mockSetup: func() {
+				mock.ExpectDel("auth:login:failures:key", "auth:login:lock:key").SetVal(2)
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().L().Reset("key")
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Reset() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"time"
+)
+
+// LoginAttemptRepository is a storage interface which can be implemented by multiple backend
+// (in-memory map, sql database, in-memory cache, file system, ...)
+// It keeps track of the failed login attempts and of the temporary lockouts, identified by a key
+type LoginAttemptRepository interface {
+	IncrementFailures(key string, ttl time.Duration) (int64, error)
+	Lock(key string, duration time.Duration) error
+	LockedFor(key string) (time.Duration, error)
+	Reset(key string) error
+}
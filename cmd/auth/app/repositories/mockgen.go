@@ -0,0 +1,4 @@
+package repositories
+
+//go:generate mockgen -source=login_attempt_repository.go -destination=../../../../test/mocks/auth_repository_login_attempt.go --package=mocks -mock_names=LoginAttemptRepository=AuthLoginAttemptRepository LoginAttemptRepository
+//go:generate mockgen -source=session_repository.go -destination=../../../../test/mocks/auth_repository_session.go --package=mocks -mock_names=SessionRepository=AuthSessionRepository SessionRepository
@@ -0,0 +1,40 @@
+package repositories
+
+// Repository is a struct that contains all the repositories
+type Repository struct {
+	loginAttempt LoginAttemptRepository
+	session      SessionRepository
+}
+
+// NewRepository returns a new instance of Repository
+func NewRepository(loginAttempt LoginAttemptRepository, session SessionRepository) Repository {
+	return Repository{
+		loginAttempt: loginAttempt,
+		session:      session,
+	}
+}
+
+// L is used to access the LoginAttemptRepository singleton
+func (r Repository) L() LoginAttemptRepository {
+	return r.loginAttempt
+}
+
+// S is used to access the SessionRepository singleton
+func (r Repository) S() SessionRepository {
+	return r.session
+}
+
+// R is used to access the global repository singleton
+var _globalRepository Repository
+
+// R is used to access the global repository singleton
+func R() Repository {
+	return _globalRepository
+}
+
+// ReplaceGlobals affect a new repository to the global repository singleton
+func ReplaceGlobals(repository Repository) func() {
+	prev := _globalRepository
+	_globalRepository = repository
+	return func() { ReplaceGlobals(prev) }
+}
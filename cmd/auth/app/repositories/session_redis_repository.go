@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"sort"
+)
+
+const (
+	sessionKeyPrefix      = "auth:session:"
+	userSessionsKeyPrefix = "auth:sessions:"
+)
+
+// SessionRedisRepository is a repository containing the Session definition based on a Redis database and
+// implementing the repository interface.
+// Each session is stored as a JSON value expiring along with its token, and indexed in a set per user.
+type SessionRedisRepository struct {
+	client *redis.Client
+}
+
+// NewSessionRedisRepository returns a new instance of SessionRedisRepository
+func NewSessionRedisRepository(client *redis.Client) SessionRepository {
+	r := SessionRedisRepository{
+		client: client,
+	}
+	var repo SessionRepository = &r
+	return repo
+}
+
+// Create stores the session until its expiration and indexes it for its user.
+// Sessions all share the same lifetime, so the index of the user expires along with its latest session.
+func (r *SessionRedisRepository) Create(session models.Session) error {
+	ctx := context.Background()
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	sessionKey := sessionKeyPrefix + session.ID.String()
+	userKey := userSessionsKeyPrefix + session.UserID.String()
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey, data, 0)
+		pipe.ExpireAt(ctx, sessionKey, session.ExpiresAt)
+		pipe.SAdd(ctx, userKey, session.ID.String())
+		pipe.ExpireAt(ctx, userKey, session.ExpiresAt)
+		return nil
+	})
+	return err
+}
+
+// Get returns the session, if it exists and has not expired
+func (r *SessionRedisRepository) Get(sessionID uuid.UUID) (models.Session, bool, error) {
+	data, err := r.client.Get(context.Background(), sessionKeyPrefix+sessionID.String()).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return models.Session{}, false, nil
+	}
+	if err != nil {
+		return models.Session{}, false, err
+	}
+
+	var session models.Session
+	err = json.Unmarshal(data, &session)
+	if err != nil {
+		return models.Session{}, false, err
+	}
+	return session, true, nil
+}
+
+// Update replaces the stored session while keeping its expiration.
+// Nothing is stored if the session has expired or has been deleted in the meantime.
+func (r *SessionRedisRepository) Update(session models.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	err = r.client.SetArgs(context.Background(), sessionKeyPrefix+session.ID.String(), data, redis.SetArgs{
+		Mode:    "XX",
+		KeepTTL: true,
+	}).Err()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
+
+// List returns the active sessions of the user, most recently used first.
+// Expired sessions are removed from the index of the user.
+func (r *SessionRedisRepository) List(userID uuid.UUID) ([]models.Session, error) {
+	ctx := context.Background()
+	userKey := userSessionsKeyPrefix + userID.String()
+
+	ids, err := r.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.Session, 0, len(ids))
+	if len(ids) == 0 {
+		return sessions, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = sessionKeyPrefix + id
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []interface{}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+
+		var session models.Session
+		err = json.Unmarshal([]byte(data), &session)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(expired) > 0 {
+		err = r.client.SRem(ctx, userKey, expired...).Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// Delete removes the session of the user
+func (r *SessionRedisRepository) Delete(userID uuid.UUID, sessionID uuid.UUID) error {
+	ctx := context.Background()
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKeyPrefix+sessionID.String())
+		pipe.SRem(ctx, userSessionsKeyPrefix+userID.String(), sessionID.String())
+		return nil
+	})
+	return err
+}
+
+// DeleteAll removes every session of the user along with its index
+func (r *SessionRedisRepository) DeleteAll(userID uuid.UUID) error {
+	ctx := context.Background()
+	userKey := userSessionsKeyPrefix + userID.String()
+
+	ids, err := r.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, sessionKeyPrefix+id)
+	}
+	keys = append(keys, userKey)
+
+	return r.client.Del(ctx, keys...).Err()
+}
@@ -0,0 +1,354 @@
+package repositories_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/auth/app/repositories"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/go-redis/redismock/v9"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// newTestSession returns a session along with its Redis keys and JSON value
+func newTestSession() (models.Session, string, string, []byte) {
+	date := time.Date(2025, 5, 4, 10, 0, 0, 0, time.UTC)
+	session := models.Session{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		UserAgent:  "Mozilla/5.0",
+		IPAddress:  "127.0.0.1",
+		CreatedAt:  date,
+		LastSeenAt: date,
+		ExpiresAt:  date.Add(12 * time.Hour),
+	}
+	data, _ := json.Marshal(session)
+	return session, "auth:session:" + session.ID.String(), "auth:sessions:" + session.UserID.String(), data
+}
+
+// TestSessionRedisRepository_Create test the SessionRedisRepository.Create method
+func TestSessionRedisRepository_Create(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewSessionRedisRepository(client)))
+	session, sessionKey, userKey, data := newTestSession()
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail to create",
+			mockSetup: func() {
+				mock.ExpectTxPipeline()
+				mock.ExpectSet(sessionKey, data, 0).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Create",
+			mockSetup: func() {
+				mock.ExpectTxPipeline()
+				mock.ExpectSet(sessionKey, data, 0).SetVal("OK")
+				mock.ExpectExpireAt(sessionKey, session.ExpiresAt).SetVal(true)
+				mock.ExpectSAdd(userKey, session.ID.String()).SetVal(1)
+				mock.ExpectExpireAt(userKey, session.ExpiresAt).SetVal(true)
+				mock.ExpectTxPipelineExec()
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().S().Create(session)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Create() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestSessionRedisRepository_Get test the SessionRedisRepository.Get method
+func TestSessionRedisRepository_Get(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewSessionRedisRepository(client)))
+	session, sessionKey, _, data := newTestSession()
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectErr     bool
+		expectFound   bool
+		expectSession models.Session
+	}{
+		{
+			name: "Fail to retrieve",
+			mockSetup: func() {
+				mock.ExpectGet(sessionKey).SetErr(errors.New("error"))
+			},
+			expectErr:   true,
+			expectFound: false,
+		},
+		{
+			name: "Not found",
+			mockSetup: func() {
+				mock.ExpectGet(sessionKey).RedisNil()
+			},
+			expectErr:   false,
+			expectFound: false,
+		},
+		{
+			name: "Fail to decode",
+			mockSetup: func() {
+				mock.ExpectGet(sessionKey).SetVal("invalid")
+			},
+			expectErr:   true,
+			expectFound: false,
+		},
+		{
+			name: "Found",
+			mockSetup: func() {
+				mock.ExpectGet(sessionKey).SetVal(string(data))
+			},
+			expectErr:     false,
+			expectFound:   true,
+			expectSession: session,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			result, found, err := repositories.R().S().Get(session.ID)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Get() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.Equal(t, tt.expectFound, found)
+			if tt.expectFound {
+				assert.Equal(t, tt.expectSession, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestSessionRedisRepository_Update test the SessionRedisRepository.Update method
+func TestSessionRedisRepository_Update(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewSessionRedisRepository(client)))
+	session, sessionKey, _, data := newTestSession()
+	args := redis.SetArgs{Mode: "XX", KeepTTL: true}
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail to update",
+			mockSetup: func() {
+				mock.ExpectSetArgs(sessionKey, data, args).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Session no longer exists",
+			mockSetup: func() {
+				mock.ExpectSetArgs(sessionKey, data, args).RedisNil()
+			},
+			expectErr: false,
+		},
+		{
+			name: "Update",
+			mockSetup: func() {
+				mock.ExpectSetArgs(sessionKey, data, args).SetVal("OK")
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().S().Update(session)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Update() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestSessionRedisRepository_List test the SessionRedisRepository.List method
+func TestSessionRedisRepository_List(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewSessionRedisRepository(client)))
+	session, sessionKey, userKey, data := newTestSession()
+	expiredID := uuid.New().String()
+
+	// A second session of the same user, used more recently
+	recent := session
+	recent.ID = uuid.New()
+	recent.LastSeenAt = session.LastSeenAt.Add(time.Hour)
+	recentData, _ := json.Marshal(recent)
+	recentKey := "auth:session:" + recent.ID.String()
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectErr      bool
+		expectSessions []models.Session
+	}{
+		{
+			name: "Fail to retrieve the index",
+			mockSetup: func() {
+				mock.ExpectSMembers(userKey).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "No sessions",
+			mockSetup: func() {
+				mock.ExpectSMembers(userKey).SetVal([]string{})
+			},
+			expectErr:      false,
+			expectSessions: []models.Session{},
+		},
+		{
+			name: "Fail to retrieve the sessions",
+			mockSetup: func() {
+				mock.ExpectSMembers(userKey).SetVal([]string{session.ID.String()})
+				mock.ExpectMGet(sessionKey).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fail to remove expired sessions",
+			mockSetup: func() {
+				mock.ExpectSMembers(userKey).SetVal([]string{session.ID.String(), expiredID})
+				mock.ExpectMGet(sessionKey, "auth:session:"+expiredID).SetVal([]interface{}{string(data), nil})
+				mock.ExpectSRem(userKey, expiredID).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "List and remove expired sessions",
+			mockSetup: func() {
+				mock.ExpectSMembers(userKey).SetVal([]string{session.ID.String(), expiredID, recent.ID.String()})
+				mock.ExpectMGet(sessionKey, "auth:session:"+expiredID, recentKey).SetVal([]interface{}{string(data), nil, string(recentData)})
+				mock.ExpectSRem(userKey, expiredID).SetVal(1)
+			},
+			expectErr:      false,
+			expectSessions: []models.Session{recent, session},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			sessions, err := repositories.R().S().List(session.UserID)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("List() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.Equal(t, tt.expectSessions, sessions)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestSessionRedisRepository_Delete test the SessionRedisRepository.Delete method
+func TestSessionRedisRepository_Delete(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewSessionRedisRepository(client)))
+	session, sessionKey, userKey, _ := newTestSession()
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail to delete",
+			mockSetup: func() {
+				mock.ExpectTxPipeline()
+				mock.ExpectDel(sessionKey).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Delete",
+			mockSetup: func() {
+				mock.ExpectTxPipeline()
+				mock.ExpectDel(sessionKey).SetVal(1)
+				mock.ExpectSRem(userKey, session.ID.String()).SetVal(1)
+				mock.ExpectTxPipelineExec()
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().S().Delete(session.UserID, session.ID)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Delete() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestSessionRedisRepository_DeleteAll test the SessionRedisRepository.DeleteAll method
+func TestSessionRedisRepository_DeleteAll(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewSessionRedisRepository(client)))
+	session, sessionKey, userKey, _ := newTestSession()
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail to list the sessions",
+			mockSetup: func() {
+				mock.ExpectSMembers(userKey).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fail to delete",
+			mockSetup: func() {
+				mock.ExpectSMembers(userKey).SetVal([]string{session.ID.String()})
+				mock.ExpectDel(sessionKey, userKey).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Delete",
+			mockSetup: func() {
+				mock.ExpectSMembers(userKey).SetVal([]string{session.ID.String()})
+				mock.ExpectDel(sessionKey, userKey).SetVal(2)
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().S().DeleteAll(session.UserID)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("DeleteAll() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
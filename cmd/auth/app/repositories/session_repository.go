@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// SessionRepository is a storage interface which can be implemented by multiple backend
+// (in-memory map, sql database, in-memory cache, file system, ...)
+// It keeps track of the active sessions of the users, which expire along with their token
+type SessionRepository interface {
+	Create(session models.Session) error
+	Get(sessionID uuid.UUID) (models.Session, bool, error)
+	Update(session models.Session) error
+	List(userID uuid.UUID) ([]models.Session, error)
+	Delete(userID uuid.UUID, sessionID uuid.UUID) error
+	DeleteAll(userID uuid.UUID) error
+}
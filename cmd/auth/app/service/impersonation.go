@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+const (
+	// defaultImpersonationDuration is the lifetime of the impersonation tokens, unless configured otherwise
+	defaultImpersonationDuration = 30 * time.Minute
+)
+
+// impersonationDurationFromConfig returns the lifetime of the impersonation tokens, bounded by the regular tokens lifetime
+func impersonationDurationFromConfig() time.Duration {
+	duration := viper.GetDuration("AUTH_IMPERSONATION_DURATION")
+	if duration <= 0 {
+		return defaultImpersonationDuration
+	}
+	if duration > tokenDuration {
+		return tokenDuration
+	}
+	return duration
+}
+
+// ImpersonateUser issues a short-lived token to act as a user on behalf of the caller.
+// The token carries both the impersonated user and the caller, so that every action remains attributed to the latter.
+func (s *AuthService) ImpersonateUser(ctx context.Context, req *authpb.ImpersonateUserRequest) (*authpb.ImpersonateUserResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &authpb.ImpersonateUserResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Impersonations must be justified
+	if req.GetReason() == "" {
+		return &authpb.ImpersonateUserResponse{}, status.Error(codes.InvalidArgument, "reason-required")
+	}
+
+	// Retrieve the authenticated caller, which becomes the actor of the impersonation
+	principal, ok := grpcutil.PrincipalFromContext(ctx)
+	if !ok {
+		return &authpb.ImpersonateUserResponse{}, status.Error(codes.Unauthenticated, "Missing authenticated caller")
+	}
+
+	// Check the caller may impersonate the user
+	err = security.Facade().CheckImpersonation(ctx, userID)
+	if err != nil {
+		zap.L().Error("CheckImpersonation", zap.Error(err))
+		outcome := models.AuditOutcomeFailure
+		if status.Code(err) == codes.PermissionDenied {
+			outcome = models.AuditOutcomeDenied
+		}
+		auditImpersonation(ctx, req, outcome, principal.UserID, models.AuditDetails{"reason_code": status.Convert(err).Message()})
+		return &authpb.ImpersonateUserResponse{}, err
+	}
+
+	// If any, propagate metadata from the incoming context to the outgoing context
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	// Make sure the user exists
+	_, err = s.userClient.GetUser(ctx, &userpb.GetUserRequest{
+		Id: userID.String(),
+	})
+	if err != nil {
+		zap.L().Error("GetUser", zap.String("uuid", userID.String()), zap.Error(err))
+		return &authpb.ImpersonateUserResponse{}, err
+	}
+
+	// Open a short-lived session for the impersonated user, it can be terminated like any other session
+	session := models.InitSession(userID, req.GetUserAgent(), req.GetIpAddress(), s.impersonationDuration)
+
+	token, err := s.createImpersonationToken(session, principal.UserID)
+	if err != nil {
+		zap.L().Error("failed to create impersonation token", zap.Error(err))
+		return &authpb.ImpersonateUserResponse{}, err
+	}
+
+	sessions, err := sessionRepository()
+	if err != nil {
+		return &authpb.ImpersonateUserResponse{}, err
+	}
+	err = sessions.Create(session)
+	if err != nil {
+		zap.L().Error("failed to create session", zap.Error(err))
+		return &authpb.ImpersonateUserResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	auditImpersonation(ctx, req, models.AuditOutcomeSuccess, principal.UserID, models.AuditDetails{
+		"session_id": session.ID.String(),
+		"expires_at": session.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+
+	return &authpb.ImpersonateUserResponse{
+		Token:     token,
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+	}, nil
+}
+
+// auditImpersonation records the impersonation attempt in the audit log
+func auditImpersonation(ctx context.Context, req *authpb.ImpersonateUserRequest, outcome models.AuditOutcome, actorID uuid.UUID, details models.AuditDetails) {
+	details["reason"] = req.GetReason()
+	details["user_agent"] = req.GetUserAgent()
+
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionImpersonate,
+		Outcome:    outcome,
+		ActorID:    uuid.NullUUID{UUID: actorID, Valid: true},
+		TargetType: models.AuditTargetUser,
+		TargetID:   req.GetUserId(),
+		IPAddress:  req.GetIpAddress(),
+		Details:    details,
+	})
+}
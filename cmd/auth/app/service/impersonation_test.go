@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/auth/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+// TestImpersonationDurationFromConfig tests the impersonationDurationFromConfig function
+func TestImpersonationDurationFromConfig(t *testing.T) {
+	defer viper.Set("AUTH_IMPERSONATION_DURATION", nil)
+
+	viper.Set("AUTH_IMPERSONATION_DURATION", nil)
+	assert.Equal(t, defaultImpersonationDuration, impersonationDurationFromConfig())
+
+	viper.Set("AUTH_IMPERSONATION_DURATION", "10m")
+	assert.Equal(t, 10*time.Minute, impersonationDurationFromConfig())
+
+	viper.Set("AUTH_IMPERSONATION_DURATION", "48h")
+	assert.Equal(t, tokenDuration, impersonationDurationFromConfig())
+}
+
+// TestImpersonateUser tests the AuthService.ImpersonateUser service
+func TestImpersonateUser(t *testing.T) {
+	actorID := uuid.New()
+	userID := uuid.New()
+	validContext := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{UserID: actorID})
+	validRequest := &authpb.ImpersonateUserRequest{
+		UserId:    userID.String(),
+		Reason:    "Ticket #42",
+		IpAddress: "127.0.0.1",
+		UserAgent: "Mozilla/5.0",
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		serviceSetup    func(ctrl *gomock.Controller) *AuthService
+		ctx             context.Context
+		request         *authpb.ImpersonateUserRequest
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckImpersonation(gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				return NewAuthService(userClient)
+			},
+			ctx:             validContext,
+			request:         &authpb.ImpersonateUserRequest{UserId: "bad-uuid", Reason: "Ticket #42"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails without reason",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckImpersonation(gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				return NewAuthService(userClient)
+			},
+			ctx:             validContext,
+			request:         &authpb.ImpersonateUserRequest{UserId: userID.String()},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails without authenticated caller",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckImpersonation(gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				return NewAuthService(userClient)
+			},
+			ctx:             context.Background(),
+			request:         validRequest,
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "is not allowed to impersonate",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckImpersonation(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "impersonation-admin"))
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				ac := mocks.NewMockAuditServiceClient(ctrl)
+				ac.EXPECT().WriteAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *securitypb.WriteAuditLogRequest, opts ...grpc.CallOption) (*securitypb.WriteAuditLogResponse, error) {
+					assert.Equal(t, models.AuditActionImpersonate, req.GetAction())
+					assert.Equal(t, models.AuditOutcomeDenied, req.GetOutcome())
+					assert.Equal(t, actorID.String(), req.GetActorId())
+					assert.Equal(t, userID.String(), req.GetTargetId())
+					assert.Equal(t, "impersonation-admin", req.GetDetails()["reason_code"])
+					return &securitypb.WriteAuditLogResponse{}, nil
+				})
+				audit.ReplaceGlobals(audit.NewAuditFacadeWithGrpcClient(ac))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Create(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				return NewAuthService(userClient)
+			},
+			ctx:             validContext,
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to retrieve the user",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckImpersonation(gomock.Any(), gomock.Any()).Return(&securitypb.CheckImpersonationResponse{Allowed: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Create(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "User not found"))
+				return NewAuthService(userClient)
+			},
+			ctx:             validContext,
+			request:         validRequest,
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "fails to create the session",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckImpersonation(gomock.Any(), gomock.Any()).Return(&securitypb.CheckImpersonationResponse{Allowed: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Create(gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(&userpb.GetUserResponse{User: &userpb.User{Id: userID.String()}}, nil)
+				return NewAuthService(userClient)
+			},
+			ctx:             validContext,
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeds",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckImpersonation(gomock.Any(), gomock.Any()).Return(&securitypb.CheckImpersonationResponse{Allowed: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Create(gomock.Any()).DoAndReturn(func(session models.Session) error {
+					assert.Equal(t, userID, session.UserID)
+					assert.WithinDuration(t, time.Now().Add(defaultImpersonationDuration), session.ExpiresAt, time.Minute)
+					return nil
+				})
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+				ac := mocks.NewMockAuditServiceClient(ctrl)
+				ac.EXPECT().WriteAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *securitypb.WriteAuditLogRequest, opts ...grpc.CallOption) (*securitypb.WriteAuditLogResponse, error) {
+					assert.Equal(t, models.AuditActionImpersonate, req.GetAction())
+					assert.Equal(t, models.AuditOutcomeSuccess, req.GetOutcome())
+					assert.Equal(t, actorID.String(), req.GetActorId())
+					assert.Equal(t, userID.String(), req.GetTargetId())
+					assert.Equal(t, validRequest.Reason, req.GetDetails()["reason"])
+					assert.NotEmpty(t, req.GetDetails()["session_id"])
+					return &securitypb.WriteAuditLogResponse{}, nil
+				})
+				audit.ReplaceGlobals(audit.NewAuditFacadeWithGrpcClient(ac))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(&userpb.GetUserResponse{User: &userpb.User{Id: userID.String()}}, nil)
+				return NewAuthService(userClient)
+			},
+			ctx:             validContext,
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Prepare mocks
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// Only the cases expecting an audit log entry set up the facade
+			audit.ReplaceGlobals(nil)
+
+			// Call service
+			service := tt.serviceSetup(ctrl)
+			response, err := service.ImpersonateUser(tt.ctx, tt.request)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			if tt.expectedErrCode != codes.OK {
+				assert.Empty(t, response.GetToken())
+				return
+			}
+
+			// The token carries both the impersonated user and the actor
+			claims, err := service.parseToken(response.GetToken())
+			assert.NoError(t, err)
+			assert.Equal(t, userID.String(), claims[JwtUserIDKey])
+			assert.Equal(t, actorID.String(), claims[JwtActorIDKey])
+			expiresAt, err := claims.GetExpirationTime()
+			assert.NoError(t, err)
+			assert.Equal(t, jwt.NewNumericDate(response.GetExpiresAt().AsTime()).Unix(), expiresAt.Unix())
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"fmt"
+	"github.com/Zapharaos/fihub-backend/cmd/auth/app/repositories"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/pkg/email"
+	"github.com/Zapharaos/fihub-backend/pkg/email/templates"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"strings"
+	"time"
+)
+
+const (
+	lockoutAccountKeyPrefix = "account:"
+	lockoutIPKeyPrefix      = "ip:"
+)
+
+// LockoutPolicy defines how failed login attempts are throttled.
+// Once a threshold is reached, every new failure locks the account (or IP address) for a duration
+// which doubles at each failure, starting from BaseDuration and capped at MaxDuration.
+type LockoutPolicy struct {
+	AccountThreshold int64
+	IPThreshold      int64
+	Window           time.Duration
+	BaseDuration     time.Duration
+	MaxDuration      time.Duration
+}
+
+// NewLockoutPolicyFromConfig creates a new LockoutPolicy from the configuration
+func NewLockoutPolicyFromConfig() LockoutPolicy {
+	policy := LockoutPolicy{
+		AccountThreshold: viper.GetInt64("LOGIN_LOCKOUT_ACCOUNT_THRESHOLD"),
+		IPThreshold:      viper.GetInt64("LOGIN_LOCKOUT_IP_THRESHOLD"),
+		Window:           viper.GetDuration("LOGIN_LOCKOUT_WINDOW"),
+		BaseDuration:     viper.GetDuration("LOGIN_LOCKOUT_BASE_DURATION"),
+		MaxDuration:      viper.GetDuration("LOGIN_LOCKOUT_MAX_DURATION"),
+	}
+
+	if policy.AccountThreshold == 0 {
+		policy.AccountThreshold = 5
+	}
+	if policy.IPThreshold == 0 {
+		policy.IPThreshold = 20
+	}
+	if policy.Window == 0 {
+		policy.Window = 1 * time.Hour
+	}
+	if policy.BaseDuration == 0 {
+		policy.BaseDuration = 1 * time.Minute
+	}
+	if policy.MaxDuration == 0 {
+		policy.MaxDuration = 30 * time.Minute
+	}
+
+	return policy
+}
+
+// LockDuration returns the lock duration to apply after the given number of failures, zero if the threshold is not reached
+func (p LockoutPolicy) LockDuration(failures, threshold int64) time.Duration {
+	if threshold <= 0 || failures < threshold {
+		return 0
+	}
+
+	duration := p.BaseDuration
+	for i := threshold; i < failures && duration < p.MaxDuration; i++ {
+		duration *= 2
+	}
+	return min(duration, p.MaxDuration)
+}
+
+// counterTTL returns how long the failures counters are kept.
+// Counters outlive the longest lock so that the backoff keeps growing across consecutive locks.
+func (p LockoutPolicy) counterTTL() time.Duration {
+	return p.Window + p.MaxDuration
+}
+
+// lockoutAccountKey returns the key identifying the account in the login attempts repository
+func lockoutAccountKey(emailAddress string) string {
+	return lockoutAccountKeyPrefix + strings.ToLower(strings.TrimSpace(emailAddress))
+}
+
+// lockoutIPKey returns the key identifying the IP address in the login attempts repository
+func lockoutIPKey(ipAddress string) string {
+	return lockoutIPKeyPrefix + ipAddress
+}
+
+// checkLockout returns a ResourceExhausted error if the account or the IP address is currently locked.
+// The lockout is not enforced when the repository is unavailable, so that logins keep working.
+func (s *AuthService) checkLockout(emailAddress, ipAddress string) error {
+	attempts := repositories.R().L()
+	if attempts == nil {
+		zap.L().Warn("Login lockout not enforced, login attempts repository unavailable")
+		return nil
+	}
+
+	keys := []string{lockoutAccountKey(emailAddress)}
+	if ipAddress != "" {
+		keys = append(keys, lockoutIPKey(ipAddress))
+	}
+
+	for _, key := range keys {
+		lockedFor, err := attempts.LockedFor(key)
+		if err != nil {
+			zap.L().Error("Failed to check login lockout", zap.String("key", key), zap.Error(err))
+			continue
+		}
+		if lockedFor > 0 {
+			zap.L().Warn("Login attempt while locked", zap.String("key", key), zap.Duration("locked_for", lockedFor))
+			return lockedError(lockedFor)
+		}
+	}
+
+	return nil
+}
+
+// registerFailedLogin increments the failures counters of the account and of the IP address, and locks them
+// once their threshold is reached. The account owner is notified by email when their account gets locked.
+func (s *AuthService) registerFailedLogin(emailAddress, ipAddress string, lang language.Tag, accountExists bool) {
+	// Account
+	accountKey := lockoutAccountKey(emailAddress)
+	failures, lockedFor := s.registerFailure(accountKey, s.lockout.AccountThreshold)
+	if lockedFor > 0 && accountExists && failures == s.lockout.AccountThreshold {
+		enqueueAccountLockedEmail(emailAddress, lang, lockedFor)
+	}
+
+	// IP address
+	if ipAddress != "" {
+		s.registerFailure(lockoutIPKey(ipAddress), s.lockout.IPThreshold)
+	}
+}
+
+// registerFailure increments the failures counter of the key and locks it if required.
+// It returns the number of failures and the lock duration, if any.
+func (s *AuthService) registerFailure(key string, threshold int64) (int64, time.Duration) {
+	attempts := repositories.R().L()
+	if attempts == nil {
+		zap.L().Warn("Failed login not registered, login attempts repository unavailable", zap.String("key", key))
+		return 0, 0
+	}
+
+	failures, err := attempts.IncrementFailures(key, s.lockout.counterTTL())
+	if err != nil {
+		zap.L().Error("Failed to register failed login", zap.String("key", key), zap.Error(err))
+		return 0, 0
+	}
+
+	lockedFor := s.lockout.LockDuration(failures, threshold)
+	if lockedFor == 0 {
+		return failures, 0
+	}
+
+	err = attempts.Lock(key, lockedFor)
+	if err != nil {
+		zap.L().Error("Failed to lock login", zap.String("key", key), zap.Error(err))
+		return failures, 0
+	}
+
+	zap.L().Warn("Login locked", zap.String("key", key), zap.Int64("failures", failures), zap.Duration("locked_for", lockedFor))
+	return failures, lockedFor
+}
+
+// lockedError returns the error sent back when a login is locked, along with the delay before retrying
+func lockedError(lockedFor time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, "login-locked").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(lockedFor),
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "login-locked")
+	}
+	return st.Err()
+}
+
+// enqueueAccountLockedEmail notifies the account owner that their account has been locked.
+// The email is delivered in the background by the outbox, so that the failed login is not delayed by the transport.
+func enqueueAccountLockedEmail(emailAddress string, lang language.Tag, lockedFor time.Duration) {
+	// Render email
+	mail, err := templates.AccountLocked.Localize(lang, templates.AccountLockedData{
+		Duration: lockedFor,
+	})
+	if err != nil {
+		zap.L().Error("Failed to render account locked email", zap.Error(err))
+		return
+	}
+
+	// Enqueue email, once per lock of the account
+	message := email.NewMessage(emailAddress, mail.Subject, mail.PlainTextContent, mail.HTMLContent)
+	key := fmt.Sprintf("account-locked:%s:%d", emailAddress, time.Now().Add(lockedFor).Unix())
+	_, _, err = outbox.R().Enqueue(models.InitOutboxEmail(key, message))
+	if err != nil {
+		zap.L().Error("Failed to enqueue account locked email", zap.Error(err))
+	}
+}
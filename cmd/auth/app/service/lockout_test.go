@@ -0,0 +1,97 @@
+package service
+
+import (
+	"github.com/Zapharaos/fihub-backend/cmd/auth/app/repositories"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+// TestNewLockoutPolicyFromConfig tests the NewLockoutPolicyFromConfig function
+func TestNewLockoutPolicyFromConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		assert.Equal(t, LockoutPolicy{
+			AccountThreshold: 5,
+			IPThreshold:      20,
+			Window:           time.Hour,
+			BaseDuration:     time.Minute,
+			MaxDuration:      30 * time.Minute,
+		}, NewLockoutPolicyFromConfig())
+	})
+
+	t.Run("From configuration", func(t *testing.T) {
+		viper.Set("LOGIN_LOCKOUT_ACCOUNT_THRESHOLD", "3")
+		viper.Set("LOGIN_LOCKOUT_IP_THRESHOLD", "10")
+		viper.Set("LOGIN_LOCKOUT_WINDOW", "2h")
+		viper.Set("LOGIN_LOCKOUT_BASE_DURATION", "30s")
+		viper.Set("LOGIN_LOCKOUT_MAX_DURATION", "1h")
+		defer viper.Reset()
+
+		assert.Equal(t, LockoutPolicy{
+			AccountThreshold: 3,
+			IPThreshold:      10,
+			Window:           2 * time.Hour,
+			BaseDuration:     30 * time.Second,
+			MaxDuration:      time.Hour,
+		}, NewLockoutPolicyFromConfig())
+	})
+}
+
+// TestLockoutPolicy_LockDuration tests the LockoutPolicy.LockDuration method
+func TestLockoutPolicy_LockDuration(t *testing.T) {
+	policy := LockoutPolicy{
+		BaseDuration: time.Minute,
+		MaxDuration:  10 * time.Minute,
+	}
+
+	tests := []struct {
+		name      string
+		failures  int64
+		threshold int64
+		expected  time.Duration
+	}{
+		{name: "Below threshold", failures: 4, threshold: 5, expected: 0},
+		{name: "Threshold disabled", failures: 4, threshold: 0, expected: 0},
+		{name: "Threshold reached", failures: 5, threshold: 5, expected: time.Minute},
+		{name: "Backoff", failures: 7, threshold: 5, expected: 4 * time.Minute},
+		{name: "Capped", failures: 50, threshold: 5, expected: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.LockDuration(tt.failures, tt.threshold))
+		})
+	}
+}
+
+// TestLockedError tests the lockedError function
+func TestLockedError(t *testing.T) {
+	err := lockedError(90 * time.Second)
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+
+	details := st.Details()
+	assert.Len(t, details, 1)
+	retryInfo, ok := details[0].(*errdetails.RetryInfo)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, retryInfo.GetRetryDelay().AsDuration())
+}
+
+// TestLockout_RepositoryUnavailable tests that the lockout is not enforced while the repository is unavailable
+func TestLockout_RepositoryUnavailable(t *testing.T) {
+	restore := repositories.ReplaceGlobals(repositories.NewRepository(nil, nil))
+	defer restore()
+
+	service := &AuthService{lockout: NewLockoutPolicyFromConfig()}
+	assert.NoError(t, service.checkLockout("user@example.com", "127.0.0.1"))
+
+	failures, lockedFor := service.registerFailure(lockoutAccountKey("user@example.com"), 1)
+	assert.Zero(t, failures)
+	assert.Zero(t, lockedFor)
+}
@@ -2,15 +2,21 @@ package service
 
 import (
 	"context"
+	"github.com/Zapharaos/fihub-backend/cmd/auth/app/repositories"
 	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
 	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
 	"github.com/Zapharaos/fihub-backend/internal/mappers"
 	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
 	"github.com/Zapharaos/fihub-backend/internal/utils"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"golang.org/x/text/language"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"time"
 )
@@ -19,10 +25,18 @@ type AuthService struct {
 	authpb.UnimplementedAuthServiceServer
 	signingKey []byte
 	userClient userpb.UserServiceClient
+	lockout    LockoutPolicy
+
+	impersonationDuration time.Duration
 }
 
 const (
-	JwtUserIDKey = "id"
+	JwtUserIDKey    = "id"
+	JwtSessionIDKey = "sid"
+	JwtActorIDKey   = "act"
+
+	// tokenDuration is the lifetime of both the tokens and their sessions
+	tokenDuration = 12 * time.Hour
 )
 
 // NewAuthService creates a new AuthService instance
@@ -37,33 +51,98 @@ func NewAuthService(userClient userpb.UserServiceClient) *AuthService {
 	return &AuthService{
 		signingKey: signingKey,
 		userClient: userClient,
+		lockout:    NewLockoutPolicyFromConfig(),
+
+		impersonationDuration: impersonationDurationFromConfig(),
 	}
 }
 
 // GenerateToken authenticates a user and generates a JWT token for them
 func (s *AuthService) GenerateToken(ctx context.Context, req *authpb.GenerateTokenRequest) (*authpb.GenerateTokenResponse, error) {
+	// Reject the attempt if the account or the IP address is locked
+	err := s.checkLockout(req.GetEmail(), req.GetIpAddress())
+	if err != nil {
+		auditLogin(ctx, req, models.AuditOutcomeDenied, uuid.Nil, models.AuditDetails{"reason": "locked"})
+		return nil, err
+	}
+
 	// Try to authenticate the user
+	// The context of the attempt is forwarded to be kept in the login history of the user
 	response, err := s.userClient.AuthenticateUser(ctx, &userpb.AuthenticateUserRequest{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		IpAddress: req.GetIpAddress(),
+		UserAgent: req.GetUserAgent(),
+		Method:    models.LoginMethodPassword,
 	})
 	if err != nil {
 		zap.L().Error("failed to authenticate user", zap.Error(err))
+
+		// Only invalid credentials count as failed attempts
+		code := status.Code(err)
+		if code == codes.InvalidArgument || code == codes.NotFound {
+			lang, _ := language.Parse(req.GetLanguage())
+			s.registerFailedLogin(req.GetEmail(), req.GetIpAddress(), lang, code != codes.NotFound)
+		}
+		auditLogin(ctx, req, models.AuditOutcomeFailure, uuid.Nil, models.AuditDetails{"reason": code.String()})
 		return nil, err
 	}
 
-	// Generate a token for the authenticated user
+	// Successful login : forget the previous failures of the account
+	if attempts := repositories.R().L(); attempts != nil {
+		err = attempts.Reset(lockoutAccountKey(req.GetEmail()))
+		if err != nil {
+			zap.L().Error("failed to reset login attempts", zap.Error(err))
+		}
+	}
+
+	// Open a new session for the authenticated user
 	user := mappers.UserFromProto(response.GetUser())
-	token, err := s.createToken(user)
+	session := models.InitSession(user.ID, req.GetUserAgent(), req.GetIpAddress(), tokenDuration)
+
+	// Generate a token bound to the session
+	token, err := s.createToken(user, session)
 	if err != nil {
 		zap.L().Error("failed to create token", zap.Error(err))
 		return nil, err
 	}
 
+	sessions, err := sessionRepository()
+	if err != nil {
+		return nil, err
+	}
+	err = sessions.Create(session)
+	if err != nil {
+		zap.L().Error("failed to create session", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	auditLogin(ctx, req, models.AuditOutcomeSuccess, user.ID, models.AuditDetails{"session_id": session.ID.String()})
+
 	return &authpb.GenerateTokenResponse{Token: token}, nil
 }
 
-// ValidateToken validates the JWT token and extracts the user ID
+// auditLogin records the login attempt in the audit log, the user is only known once authenticated
+func auditLogin(ctx context.Context, req *authpb.GenerateTokenRequest, outcome models.AuditOutcome, userID uuid.UUID, details models.AuditDetails) {
+	details["email"] = req.GetEmail()
+	details["user_agent"] = req.GetUserAgent()
+
+	entry := models.AuditLog{
+		Action:    models.AuditActionLogin,
+		Outcome:   outcome,
+		IPAddress: req.GetIpAddress(),
+		Details:   details,
+	}
+	if userID != uuid.Nil {
+		entry.ActorID = uuid.NullUUID{UUID: userID, Valid: true}
+		entry.TargetType = models.AuditTargetUser
+		entry.TargetID = userID.String()
+	}
+	audit.Facade().Record(ctx, entry)
+}
+
+// ValidateToken validates the JWT token and its session, then extracts the user ID.
+// The session must still be active, so that terminated sessions can no longer be used.
 func (s *AuthService) ValidateToken(ctx context.Context, req *authpb.ValidateTokenRequest) (*authpb.ValidateTokenResponse, error) {
 	claims, err := s.parseToken(req.Token)
 	if err != nil {
@@ -75,7 +154,20 @@ func (s *AuthService) ValidateToken(ctx context.Context, req *authpb.ValidateTok
 		return nil, status.Error(codes.InvalidArgument, "invalid token claims")
 	}
 
-	return &authpb.ValidateTokenResponse{UserId: userID}, nil
+	sessionID, ok := claims[JwtSessionIDKey].(string)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid token claims")
+	}
+
+	err = s.touchSession(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The actor is only set on impersonation tokens
+	actorID, _ := claims[JwtActorIDKey].(string)
+
+	return &authpb.ValidateTokenResponse{UserId: userID, SessionId: sessionID, ActorId: actorID}, nil
 }
 
 // ExtractUserID extracts the user ID from the JWT token without verifying the signature
@@ -96,19 +188,86 @@ func (s *AuthService) ExtractUserID(ctx context.Context, req *authpb.ExtractUser
 		return nil, status.Error(codes.InvalidArgument, "invalid token claims")
 	}
 
-	return &authpb.ExtractUserIDResponse{UserId: userID}, nil
+	// The session ID is optional here since the token is not validated anyway
+	sessionID, _ := claims[JwtSessionIDKey].(string)
+	actorID, _ := claims[JwtActorIDKey].(string)
+
+	return &authpb.ExtractUserIDResponse{UserId: userID, SessionId: sessionID, ActorId: actorID}, nil
 }
 
-func (s *AuthService) createToken(user models.User) (string, error) {
-	if s.signingKey == nil {
-		return "", status.Error(codes.FailedPrecondition, "signing key is nil")
+// UnlockUser removes the login lockout of a user account
+func (s *AuthService) UnlockUser(ctx context.Context, req *authpb.UnlockUserRequest) (*authpb.UnlockUserResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &authpb.UnlockUserResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.unlock")
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &authpb.UnlockUserResponse{}, err
+	}
+
+	// If any, propagate metadata from the incoming context to the outgoing context
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	// Retrieve the user email
+	response, err := s.userClient.GetUser(ctx, &userpb.GetUserRequest{
+		Id: userID.String(),
+	})
+	if err != nil {
+		zap.L().Error("GetUser", zap.String("uuid", userID.String()), zap.Error(err))
+		return &authpb.UnlockUserResponse{}, err
+	}
+
+	// Remove the lock and the failures counter
+	attempts := repositories.R().L()
+	if attempts == nil {
+		zap.L().Error("Login attempts repository unavailable")
+		return &authpb.UnlockUserResponse{}, status.Error(codes.Unavailable, "login-attempts-unavailable")
+	}
+	user := mappers.UserFromProto(response.GetUser())
+	err = attempts.Reset(lockoutAccountKey(user.Email))
+	if err != nil {
+		zap.L().Error("Reset login attempts", zap.String("uuid", userID.String()), zap.Error(err))
+		return &authpb.UnlockUserResponse{}, status.Error(codes.Internal, err.Error())
 	}
 
-	claims := jwt.MapClaims{
-		"exp":        jwt.NewNumericDate(time.Now().Add(time.Hour * 12)),
-		"iat":        jwt.NewNumericDate(time.Now()),
-		"nbf":        jwt.NewNumericDate(time.Now()),
-		JwtUserIDKey: user.ID.String(),
+	return &authpb.UnlockUserResponse{Success: true}, nil
+}
+
+func (s *AuthService) createToken(user models.User, session models.Session) (string, error) {
+	claims := sessionClaims(session)
+	claims[JwtUserIDKey] = user.ID.String()
+	return s.signToken(claims)
+}
+
+// createImpersonationToken creates a token for the user of the session, on behalf of the actor
+func (s *AuthService) createImpersonationToken(session models.Session, actorID uuid.UUID) (string, error) {
+	claims := sessionClaims(session)
+	claims[JwtUserIDKey] = session.UserID.String()
+	claims[JwtActorIDKey] = actorID.String()
+	return s.signToken(claims)
+}
+
+// sessionClaims returns the claims binding a token to its session
+func sessionClaims(session models.Session) jwt.MapClaims {
+	return jwt.MapClaims{
+		"exp":           jwt.NewNumericDate(session.ExpiresAt),
+		"iat":           jwt.NewNumericDate(session.CreatedAt),
+		"nbf":           jwt.NewNumericDate(session.CreatedAt),
+		JwtSessionIDKey: session.ID.String(),
+	}
+}
+
+func (s *AuthService) signToken(claims jwt.MapClaims) (string, error) {
+	if s.signingKey == nil {
+		return "", status.Error(codes.FailedPrecondition, "signing key is nil")
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -2,16 +2,25 @@ package service
 
 import (
 	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/auth/app/repositories"
 	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
 	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
 	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
 	"github.com/Zapharaos/fihub-backend/test/mocks"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"slices"
 	"testing"
 	"time"
 )
@@ -19,9 +28,14 @@ import (
 // TestGenerateToken tests the AuthService.GenerateToken service
 func TestGenerateToken(t *testing.T) {
 	validRequest := &authpb.GenerateTokenRequest{
-		Email:    "email",
-		Password: "password",
+		Email:     "email",
+		Password:  "password",
+		IpAddress: "127.0.0.1",
+		Language:  "en",
+		UserAgent: "Mozilla/5.0",
 	}
+	accountKey := lockoutAccountKey(validRequest.Email)
+	ipKey := lockoutIPKey(validRequest.IpAddress)
 
 	// Define tests
 	tests := []struct {
@@ -31,10 +45,53 @@ func TestGenerateToken(t *testing.T) {
 		expectToken     bool
 		expectedErrCode codes.Code
 	}{
+		{
+			name: "fails with locked account",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().LockedFor(accountKey).Return(time.Minute, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(la, nil))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().AuthenticateUser(gomock.Any(), gomock.Any()).Times(0)
+				ac := mocks.NewMockAuditServiceClient(ctrl)
+				ac.EXPECT().WriteAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *securitypb.WriteAuditLogRequest, opts ...grpc.CallOption) (*securitypb.WriteAuditLogResponse, error) {
+					assert.Equal(t, models.AuditActionLogin, req.GetAction())
+					assert.Equal(t, models.AuditOutcomeDenied, req.GetOutcome())
+					assert.Empty(t, req.GetActorId())
+					assert.Equal(t, validRequest.IpAddress, req.GetIpAddress())
+					assert.Equal(t, validRequest.Email, req.GetDetails()["email"])
+					return &securitypb.WriteAuditLogResponse{}, nil
+				})
+				audit.ReplaceGlobals(audit.NewAuditFacadeWithGrpcClient(ac))
+				return NewAuthService(userClient)
+			},
+			request:         validRequest,
+			expectToken:     false,
+			expectedErrCode: codes.ResourceExhausted,
+		},
+		{
+			name: "fails with locked IP address",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().LockedFor(accountKey).Return(time.Duration(0), nil)
+				la.EXPECT().LockedFor(ipKey).Return(time.Minute, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(la, nil))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().AuthenticateUser(gomock.Any(), gomock.Any()).Times(0)
+				return NewAuthService(userClient)
+			},
+			request:         validRequest,
+			expectToken:     false,
+			expectedErrCode: codes.ResourceExhausted,
+		},
 		{
 			name: "fails to authenticate user",
 			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
-				userClient := mocks.NewMockUserServiceClient(gomock.NewController(t))
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().LockedFor(gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				la.EXPECT().IncrementFailures(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(la, nil))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
 				userClient.EXPECT().AuthenticateUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Internal, "internal error"))
 				return NewAuthService(userClient)
 			},
@@ -42,10 +99,77 @@ func TestGenerateToken(t *testing.T) {
 			expectToken:     false,
 			expectedErrCode: codes.Internal,
 		},
+		{
+			name: "fails with invalid credentials",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().LockedFor(gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				la.EXPECT().IncrementFailures(accountKey, gomock.Any()).Return(int64(1), nil)
+				la.EXPECT().IncrementFailures(ipKey, gomock.Any()).Return(int64(1), nil)
+				la.EXPECT().Lock(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(la, nil))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().AuthenticateUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "invalid-credentials"))
+				return NewAuthService(userClient)
+			},
+			request:         validRequest,
+			expectToken:     false,
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails with invalid credentials and locks the account",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().LockedFor(gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				la.EXPECT().IncrementFailures(accountKey, gomock.Any()).Return(int64(5), nil)
+				la.EXPECT().Lock(accountKey, time.Minute).Return(nil)
+				la.EXPECT().IncrementFailures(ipKey, gomock.Any()).Return(int64(5), nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(la, nil))
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
+				tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("message").AnyTimes()
+				translation.ReplaceGlobals(tr)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Cond(func(outboxEmail models.OutboxEmail) bool {
+					return slices.Equal(outboxEmail.To, []string{validRequest.Email})
+				})).Return(models.OutboxEmail{}, true, nil)
+				outbox.ReplaceGlobals(or)
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().AuthenticateUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "invalid-credentials"))
+				return NewAuthService(userClient)
+			},
+			request:         validRequest,
+			expectToken:     false,
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails with unknown account and locks it without notification",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().LockedFor(gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				la.EXPECT().IncrementFailures(accountKey, gomock.Any()).Return(int64(5), nil)
+				la.EXPECT().Lock(accountKey, time.Minute).Return(nil)
+				la.EXPECT().IncrementFailures(ipKey, gomock.Any()).Return(int64(5), nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(la, nil))
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Any()).Times(0)
+				outbox.ReplaceGlobals(or)
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().AuthenticateUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "invalid-credentials"))
+				return NewAuthService(userClient)
+			},
+			request:         validRequest,
+			expectToken:     false,
+			expectedErrCode: codes.NotFound,
+		},
 		{
 			name: "fails to create token",
 			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
-				userClient := mocks.NewMockUserServiceClient(gomock.NewController(t))
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().LockedFor(gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				la.EXPECT().Reset(accountKey).Return(nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(la, nil))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
 				userClient.EXPECT().AuthenticateUser(gomock.Any(), gomock.Any()).Return(&userpb.AuthenticateUserResponse{
 					User: &userpb.User{Id: uuid.New().String()},
 				}, nil)
@@ -58,18 +182,64 @@ func TestGenerateToken(t *testing.T) {
 			expectedErrCode: codes.FailedPrecondition,
 		},
 		{
-			name: "succeeds",
+			name: "fails to create session",
 			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
-				userClient := mocks.NewMockUserServiceClient(gomock.NewController(t))
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().LockedFor(gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				la.EXPECT().Reset(accountKey).Return(nil)
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Create(gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(la, sr))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
 				userClient.EXPECT().AuthenticateUser(gomock.Any(), gomock.Any()).Return(&userpb.AuthenticateUserResponse{
 					User: &userpb.User{Id: uuid.New().String()},
 				}, nil)
 				return NewAuthService(userClient)
 			},
 			request:         validRequest,
-			expectToken:     true,
+			expectToken:     false,
 			expectedErrCode: codes.Internal,
 		},
+		{
+			name: "succeeds",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().LockedFor(gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				la.EXPECT().Reset(accountKey).Return(nil)
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Create(gomock.Any()).DoAndReturn(func(session models.Session) error {
+					assert.Equal(t, validRequest.UserAgent, session.UserAgent)
+					assert.Equal(t, validRequest.IpAddress, session.IPAddress)
+					return nil
+				})
+				repositories.ReplaceGlobals(repositories.NewRepository(la, sr))
+				userID := uuid.New().String()
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().AuthenticateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *userpb.AuthenticateUserRequest, opts ...grpc.CallOption) (*userpb.AuthenticateUserResponse, error) {
+					assert.Equal(t, validRequest.IpAddress, req.GetIpAddress())
+					assert.Equal(t, validRequest.UserAgent, req.GetUserAgent())
+					assert.Equal(t, models.LoginMethodPassword, req.GetMethod())
+					return &userpb.AuthenticateUserResponse{
+						User: &userpb.User{Id: userID},
+					}, nil
+				})
+				ac := mocks.NewMockAuditServiceClient(ctrl)
+				ac.EXPECT().WriteAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *securitypb.WriteAuditLogRequest, opts ...grpc.CallOption) (*securitypb.WriteAuditLogResponse, error) {
+					assert.Equal(t, models.AuditActionLogin, req.GetAction())
+					assert.Equal(t, models.AuditOutcomeSuccess, req.GetOutcome())
+					assert.Equal(t, userID, req.GetActorId())
+					assert.Equal(t, userID, req.GetTargetId())
+					assert.Equal(t, validRequest.IpAddress, req.GetIpAddress())
+					assert.NotEmpty(t, req.GetDetails()["session_id"])
+					return &securitypb.WriteAuditLogResponse{}, nil
+				})
+				audit.ReplaceGlobals(audit.NewAuditFacadeWithGrpcClient(ac))
+				return NewAuthService(userClient)
+			},
+			request:         validRequest,
+			expectToken:     true,
+			expectedErrCode: codes.OK,
+		},
 	}
 
 	// Run tests
@@ -79,6 +249,9 @@ func TestGenerateToken(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			// Only the cases expecting an audit log entry set up the facade
+			audit.ReplaceGlobals(nil)
+
 			// Call service
 			service := tt.serviceSetup(ctrl)
 			response, err := service.GenerateToken(context.Background(), tt.request)
@@ -105,58 +278,214 @@ func TestGenerateToken(t *testing.T) {
 	}
 }
 
+// TestUnlockUser tests the AuthService.UnlockUser service
+func TestUnlockUser(t *testing.T) {
+	validRequest := &authpb.UnlockUserRequest{
+		UserId: uuid.New().String(),
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		serviceSetup    func(ctrl *gomock.Controller) *AuthService
+		request         *authpb.UnlockUserRequest
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				return NewAuthService(mocks.NewMockUserServiceClient(ctrl))
+			},
+			request:         &authpb.UnlockUserRequest{UserId: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				return NewAuthService(userClient)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to retrieve the user",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "User not found"))
+				return NewAuthService(userClient)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "fails to reset login attempts",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(&userpb.GetUserResponse{
+					User: &userpb.User{Id: validRequest.UserId, Email: "Email@Example.com"},
+				}, nil)
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().Reset(gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(la, nil))
+				return NewAuthService(userClient)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeds",
+			serviceSetup: func(ctrl *gomock.Controller) *AuthService {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				userClient := mocks.NewMockUserServiceClient(ctrl)
+				userClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(&userpb.GetUserResponse{
+					User: &userpb.User{Id: validRequest.UserId, Email: "Email@Example.com"},
+				}, nil)
+				la := mocks.NewAuthLoginAttemptRepository(ctrl)
+				la.EXPECT().Reset("account:email@example.com").Return(nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(la, nil))
+				return NewAuthService(userClient)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Prepare mocks
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Call service
+			service := tt.serviceSetup(ctrl)
+			response, err := service.UnlockUser(context.Background(), tt.request)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			if tt.expectedErrCode == codes.OK {
+				assert.True(t, response.GetSuccess())
+			}
+		})
+	}
+}
+
 // TestValidateToken tests the AuthService.ValidateToken service
 func TestValidateToken(t *testing.T) {
 	// Data
-	userID := uuid.New()
+	signingKey := []byte("test-signing-key")
+	user := models.User{ID: uuid.New()}
+	session := models.InitSession(user.ID, "Mozilla/5.0", "127.0.0.1", time.Hour)
+	signToken := func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		signedToken, _ := token.SignedString(signingKey)
+		return signedToken
+	}
+	validToken := func() string {
+		service := &AuthService{signingKey: signingKey}
+		token, _ := service.createToken(user, session)
+		return token
+	}()
+	actorID := uuid.New()
+	impersonationToken := func() string {
+		service := &AuthService{signingKey: signingKey}
+		token, _ := service.createImpersonationToken(session, actorID)
+		return token
+	}()
 
 	tests := []struct {
-		name           string
-		signingKey     []byte
-		token          string
-		expectedUserID string
-		expectError    bool
+		name              string
+		token             string
+		mockSetup         func(ctrl *gomock.Controller)
+		expectedUserID    string
+		expectedSessionID string
+		expectedActorID   string
+		expectError       bool
 	}{
 		{
 			name:        "fails with invalid token",
-			signingKey:  []byte("test-signing-key"),
 			token:       "invalid-token",
+			mockSetup:   func(ctrl *gomock.Controller) {},
 			expectError: true,
 		},
 		{
-			name:       "fails with missing user ID claim",
-			signingKey: []byte("test-signing-key"),
-			token: func() string {
-				service := &AuthService{signingKey: []byte("test-signing-key")}
-				claims := jwt.MapClaims{
-					"exp": jwt.NewNumericDate(time.Now().Add(time.Hour)),
-				}
-				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-				signedToken, _ := token.SignedString(service.signingKey)
-				return signedToken
-			}(),
+			name: "fails with missing user ID claim",
+			token: signToken(jwt.MapClaims{
+				"exp": jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			}),
+			mockSetup:   func(ctrl *gomock.Controller) {},
 			expectError: true,
 		},
 		{
-			name:       "successfully validates token",
-			signingKey: []byte("test-signing-key"),
-			token: func() string {
-				service := &AuthService{signingKey: []byte("test-signing-key")}
-				user := models.User{ID: userID}
-				token, _ := service.createToken(user)
-				return token
-			}(),
-			expectedUserID: func() string {
-				return userID.String()
-			}(),
-			expectError: false,
+			name: "fails with missing session ID claim",
+			token: signToken(jwt.MapClaims{
+				"exp":        jwt.NewNumericDate(time.Now().Add(time.Hour)),
+				JwtUserIDKey: user.ID.String(),
+			}),
+			mockSetup:   func(ctrl *gomock.Controller) {},
+			expectError: true,
+		},
+		{
+			name:  "fails with terminated session",
+			token: validToken,
+			mockSetup: func(ctrl *gomock.Controller) {
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(models.Session{}, false, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			expectError: true,
+		},
+		{
+			name:  "successfully validates token",
+			token: validToken,
+			mockSetup: func(ctrl *gomock.Controller) {
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(session, true, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			expectedUserID:    user.ID.String(),
+			expectedSessionID: session.ID.String(),
+			expectError:       false,
+		},
+		{
+			name:  "successfully validates impersonation token",
+			token: impersonationToken,
+			mockSetup: func(ctrl *gomock.Controller) {
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(session, true, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			expectedUserID:    user.ID.String(),
+			expectedSessionID: session.ID.String(),
+			expectedActorID:   actorID.String(),
+			expectError:       false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tt.mockSetup(ctrl)
+
 			service := &AuthService{
-				signingKey: tt.signingKey,
+				signingKey: signingKey,
 			}
 
 			response, err := service.ValidateToken(context.Background(), &authpb.ValidateTokenRequest{
@@ -170,6 +499,8 @@ func TestValidateToken(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, response)
 				assert.Equal(t, tt.expectedUserID, response.UserId)
+				assert.Equal(t, tt.expectedSessionID, response.SessionId)
+				assert.Equal(t, tt.expectedActorID, response.ActorId)
 			}
 		})
 	}
@@ -270,7 +601,7 @@ func TestCreateToken(t *testing.T) {
 				signingKey: tt.signingKey,
 			}
 
-			token, err := service.createToken(tt.user)
+			token, err := service.createToken(tt.user, models.InitSession(tt.user.ID, "", "", time.Hour))
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -308,7 +639,7 @@ func TestParseToken(t *testing.T) {
 			token: func() string {
 				service := &AuthService{signingKey: []byte("test-signing-key")}
 				user := models.User{ID: uuid.New()}
-				token, _ := service.createToken(user)
+				token, _ := service.createToken(user, models.InitSession(user.ID, "", "", time.Hour))
 				return token
 			}(),
 			expectError: false,
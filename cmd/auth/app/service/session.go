@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"github.com/Zapharaos/fihub-backend/cmd/auth/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+const (
+	// sessionTouchInterval limits how often the last seen date of a session is written
+	sessionTouchInterval = time.Minute
+)
+
+// sessionRepository returns the session repository, or an Unavailable error while it is not set up
+func sessionRepository() (repositories.SessionRepository, error) {
+	sessions := repositories.R().S()
+	if sessions == nil {
+		zap.L().Error("Session repository unavailable")
+		return nil, status.Error(codes.Unavailable, "sessions-unavailable")
+	}
+	return sessions, nil
+}
+
+// touchSession makes sure the session of a token is still active and refreshes its last seen date
+func (s *AuthService) touchSession(userID, sessionID string) error {
+	parsedSessionID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid token claims")
+	}
+
+	sessions, err := sessionRepository()
+	if err != nil {
+		return err
+	}
+
+	session, found, err := sessions.Get(parsedSessionID)
+	if err != nil {
+		zap.L().Error("Get session", zap.String("session_id", sessionID), zap.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	if !found || session.UserID.String() != userID {
+		return status.Error(codes.Unauthenticated, "session-terminated")
+	}
+
+	// Writing on every request is not worth it, the date only needs to be roughly accurate
+	if time.Since(session.LastSeenAt) < sessionTouchInterval {
+		return nil
+	}
+
+	session.LastSeenAt = time.Now()
+	err = sessions.Update(session)
+	if err != nil {
+		// The session is valid anyway, only its last seen date is outdated
+		zap.L().Error("Update session", zap.String("session_id", sessionID), zap.Error(err))
+	}
+
+	return nil
+}
+
+// ListSessions lists the active sessions of a user
+func (s *AuthService) ListSessions(ctx context.Context, req *authpb.ListSessionsRequest) (*authpb.ListSessionsResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &authpb.ListSessionsResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.sessions.list", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &authpb.ListSessionsResponse{}, err
+	}
+
+	// List the sessions
+	repository, err := sessionRepository()
+	if err != nil {
+		return &authpb.ListSessionsResponse{}, err
+	}
+	sessions, err := repository.List(userID)
+	if err != nil {
+		zap.L().Error("List sessions", zap.String("uuid", userID.String()), zap.Error(err))
+		return &authpb.ListSessionsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &authpb.ListSessionsResponse{Sessions: mappers.SessionsToProto(sessions)}, nil
+}
+
+// DeleteSession terminates a session of a user, its token can no longer be used afterward
+func (s *AuthService) DeleteSession(ctx context.Context, req *authpb.DeleteSessionRequest) (*authpb.DeleteSessionResponse, error) {
+	// Parse the IDs from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &authpb.DeleteSessionResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+	sessionID, err := uuid.Parse(req.GetSessionId())
+	if err != nil {
+		zap.L().Error("Invalid session ID", zap.String("session_id", req.GetSessionId()), zap.Error(err))
+		return &authpb.DeleteSessionResponse{}, status.Error(codes.InvalidArgument, "Invalid session ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.sessions.delete", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &authpb.DeleteSessionResponse{}, err
+	}
+
+	// Make sure the session belongs to the user
+	sessions, err := sessionRepository()
+	if err != nil {
+		return &authpb.DeleteSessionResponse{}, err
+	}
+	session, found, err := sessions.Get(sessionID)
+	if err != nil {
+		zap.L().Error("Get session", zap.String("session_id", sessionID.String()), zap.Error(err))
+		return &authpb.DeleteSessionResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found || session.UserID != userID {
+		zap.L().Warn("Session not found", zap.String("session_id", sessionID.String()))
+		return &authpb.DeleteSessionResponse{}, status.Error(codes.NotFound, "Session not found")
+	}
+
+	// Terminate the session
+	err = sessions.Delete(userID, sessionID)
+	if err != nil {
+		zap.L().Error("Delete session", zap.String("session_id", sessionID.String()), zap.Error(err))
+		return &authpb.DeleteSessionResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &authpb.DeleteSessionResponse{Success: true}, nil
+}
+
+// DeleteUserSessions terminates every session of a user, none of its tokens can be used afterward
+func (s *AuthService) DeleteUserSessions(ctx context.Context, req *authpb.DeleteUserSessionsRequest) (*authpb.DeleteUserSessionsResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &authpb.DeleteUserSessionsResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.sessions.delete", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &authpb.DeleteUserSessionsResponse{}, err
+	}
+
+	// Terminate the sessions
+	sessions, err := sessionRepository()
+	if err != nil {
+		return &authpb.DeleteUserSessionsResponse{}, err
+	}
+	err = sessions.DeleteAll(userID)
+	if err != nil {
+		zap.L().Error("Delete sessions", zap.String("uuid", userID.String()), zap.Error(err))
+		return &authpb.DeleteUserSessionsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Report what is left, so that the caller can verify the deletion
+	remaining, err := sessions.List(userID)
+	if err != nil {
+		zap.L().Error("List sessions", zap.String("uuid", userID.String()), zap.Error(err))
+		return &authpb.DeleteUserSessionsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &authpb.DeleteUserSessionsResponse{Remaining: int64(len(remaining))}, nil
+}
@@ -0,0 +1,481 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/auth/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+// TestTouchSession tests the AuthService.touchSession method
+func TestTouchSession(t *testing.T) {
+	session := models.InitSession(uuid.New(), "Mozilla/5.0", "127.0.0.1", time.Hour)
+	staleSession := session
+	staleSession.LastSeenAt = time.Now().Add(-time.Hour)
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		userID          string
+		sessionID       string
+		expectedErrCode codes.Code
+	}{
+		{
+			name:            "fails to parse session ID",
+			mockSetup:       func(ctrl *gomock.Controller) {},
+			userID:          session.UserID.String(),
+			sessionID:       "bad-uuid",
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails while the session repository is unavailable",
+			mockSetup: func(ctrl *gomock.Controller) {
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil))
+			},
+			userID:          session.UserID.String(),
+			sessionID:       session.ID.String(),
+			expectedErrCode: codes.Unavailable,
+		},
+		{
+			name: "fails to retrieve session",
+			mockSetup: func(ctrl *gomock.Controller) {
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(models.Session{}, false, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			userID:          session.UserID.String(),
+			sessionID:       session.ID.String(),
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "fails with terminated session",
+			mockSetup: func(ctrl *gomock.Controller) {
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(models.Session{}, false, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			userID:          session.UserID.String(),
+			sessionID:       session.ID.String(),
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "fails with session of another user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(session, true, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			userID:          uuid.New().String(),
+			sessionID:       session.ID.String(),
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "succeeds without refreshing a recent session",
+			mockSetup: func(ctrl *gomock.Controller) {
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(session, true, nil)
+				sr.EXPECT().Update(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			userID:          session.UserID.String(),
+			sessionID:       session.ID.String(),
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "succeeds even if the refresh fails",
+			mockSetup: func(ctrl *gomock.Controller) {
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(staleSession, true, nil)
+				sr.EXPECT().Update(gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			userID:          session.UserID.String(),
+			sessionID:       session.ID.String(),
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "succeeds and refreshes a stale session",
+			mockSetup: func(ctrl *gomock.Controller) {
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(staleSession, true, nil)
+				sr.EXPECT().Update(gomock.Any()).DoAndReturn(func(updated models.Session) error {
+					assert.WithinDuration(t, time.Now(), updated.LastSeenAt, time.Second)
+					return nil
+				})
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			userID:          session.UserID.String(),
+			sessionID:       session.ID.String(),
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Prepare mocks
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tt.mockSetup(ctrl)
+
+			// Call service
+			service := &AuthService{}
+			err := service.touchSession(tt.userID, tt.sessionID)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+		})
+	}
+}
+
+// TestListSessions tests the AuthService.ListSessions service
+func TestListSessions(t *testing.T) {
+	userID := uuid.New()
+	validRequest := &authpb.ListSessionsRequest{
+		UserId: userID.String(),
+	}
+	sessions := []models.Session{
+		models.InitSession(userID, "Mozilla/5.0", "127.0.0.1", time.Hour),
+		models.InitSession(userID, "curl/8.0", "10.0.0.1", time.Hour),
+	}
+
+	// Define tests
+	tests := []struct {
+		name             string
+		mockSetup        func(ctrl *gomock.Controller)
+		request          *authpb.ListSessionsRequest
+		expectedErrCode  codes.Code
+		expectedSessions int
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &authpb.ListSessionsRequest{UserId: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().List(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to list sessions",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().List(userID).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().List(userID).Return(sessions, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:          validRequest,
+			expectedErrCode:  codes.OK,
+			expectedSessions: len(sessions),
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Prepare mocks
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tt.mockSetup(ctrl)
+
+			// Call service
+			service := &AuthService{}
+			response, err := service.ListSessions(context.Background(), tt.request)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Len(t, response.GetSessions(), tt.expectedSessions)
+		})
+	}
+}
+
+// TestDeleteSession tests the AuthService.DeleteSession service
+func TestDeleteSession(t *testing.T) {
+	session := models.InitSession(uuid.New(), "Mozilla/5.0", "127.0.0.1", time.Hour)
+	validRequest := &authpb.DeleteSessionRequest{
+		UserId:    session.UserID.String(),
+		SessionId: session.ID.String(),
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *authpb.DeleteSessionRequest
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse user ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &authpb.DeleteSessionRequest{UserId: "bad-uuid", SessionId: session.ID.String()},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to parse session ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &authpb.DeleteSessionRequest{UserId: session.UserID.String(), SessionId: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to retrieve session",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(models.Session{}, false, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "fails with session not found",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(models.Session{}, false, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "fails with session of another user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(session, true, nil)
+				sr.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         &authpb.DeleteSessionRequest{UserId: uuid.New().String(), SessionId: session.ID.String()},
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "fails to delete session",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(session, true, nil)
+				sr.EXPECT().Delete(session.UserID, session.ID).Return(errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().Get(session.ID).Return(session, true, nil)
+				sr.EXPECT().Delete(session.UserID, session.ID).Return(nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Prepare mocks
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tt.mockSetup(ctrl)
+
+			// Call service
+			service := &AuthService{}
+			response, err := service.DeleteSession(context.Background(), tt.request)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			if tt.expectedErrCode == codes.OK {
+				assert.True(t, response.GetSuccess())
+			}
+		})
+	}
+}
+
+// TestDeleteUserSessions tests the AuthService.DeleteUserSessions service
+func TestDeleteUserSessions(t *testing.T) {
+	userID := uuid.New()
+	validRequest := &authpb.DeleteUserSessionsRequest{
+		UserId: userID.String(),
+	}
+
+	// Define tests
+	tests := []struct {
+		name              string
+		mockSetup         func(ctrl *gomock.Controller)
+		request           *authpb.DeleteUserSessionsRequest
+		expectedErrCode   codes.Code
+		expectedRemaining int64
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &authpb.DeleteUserSessionsRequest{UserId: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().DeleteAll(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails with sessions unavailable",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Unavailable,
+		},
+		{
+			name: "fails to delete sessions",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().DeleteAll(userID).Return(errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "fails to list remaining sessions",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().DeleteAll(userID).Return(nil)
+				sr.EXPECT().List(userID).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				sr := mocks.NewAuthSessionRepository(ctrl)
+				sr.EXPECT().DeleteAll(userID).Return(nil)
+				sr.EXPECT().List(userID).Return([]models.Session{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, sr))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Prepare mocks
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tt.mockSetup(ctrl)
+
+			// Call service
+			service := &AuthService{}
+			response, err := service.DeleteUserSessions(context.Background(), tt.request)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Equal(t, tt.expectedRemaining, response.GetRemaining())
+		})
+	}
+}
@@ -1,16 +1,23 @@
 package main
 
 import (
+	"github.com/Zapharaos/fihub-backend/cmd/auth/app/repositories"
 	"github.com/Zapharaos/fihub-backend/cmd/auth/app/service"
 	userrepositories "github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
 	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
 	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
 	"github.com/Zapharaos/fihub-backend/internal/app"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
 	"github.com/Zapharaos/fihub-backend/internal/database"
 	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
 	"github.com/Zapharaos/fihub-backend/internal/password"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
+	"golang.org/x/text/language"
 	"time"
 )
 
@@ -38,13 +45,24 @@ func main() {
 	// Setup gRPC clients
 	userConn := grpcutil.ConnectToClient("USER")
 	userClient := userpb.NewUserServiceClient(userConn)
+	securityConn := grpcutil.ConnectToClient("SECURITY")
+	publicSecurityClient := securitypb.NewPublicSecurityServiceClient(securityConn)
+	security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+	auditClient := securitypb.NewAuditServiceClient(securityConn)
+	audit.ReplaceGlobals(audit.NewAuditFacadeWithGrpcClient(auditClient))
+
+	// Setup Translations
+	defaultLang := language.MustParse(viper.GetString("DEFAULT_LANGUAGE"))
+	translation.ReplaceGlobals(translation.NewI18nService(defaultLang))
 
 	// Register gRPC service
-	s := grpc.NewServer()
+	s := grpcutil.NewServer(serviceName)
 	authpb.RegisterAuthServiceServer(s, service.NewAuthService(userClient))
 
 	// Setup Database
-	app.InitRedis()
+	if app.InitRedis() {
+		setupRedisRepositories()
+	}
 
 	// TODO : remove once auth fully migrated to redis
 	if app.InitPostgres() {
@@ -60,7 +78,9 @@ func main() {
 		}
 	})
 	healthMonitor.AddTarget("Redis", database.DB().Redis(), func() {
-		app.InitRedis()
+		if app.InitRedis() {
+			setupRedisRepositories()
+		}
 	})
 	healthMonitor.Start()
 	// TODO : uncomment once auth fully migrated to redis
@@ -80,11 +100,20 @@ func main() {
 	s.GracefulStop() // Stop server cleanly
 }
 
+// setupRedisRepositories initializes the Redis repositories for the microservice.
+func setupRedisRepositories() {
+	repositories.ReplaceGlobals(repositories.NewRepository(
+		repositories.NewLoginAttemptRedisRepository(database.DB().Redis().Client),
+		repositories.NewSessionRedisRepository(database.DB().Redis().Client),
+	))
+}
+
 // setupPostgresRepositories initializes the Postgres repositories for the microservice.
 func setupPostgresRepositories() {
 	// TODO : remove once auth fully migrated to redis
 	userrepositories.ReplaceGlobals(userrepositories.NewPostgresRepository(database.DB().Postgres().DB))
 	password.ReplaceGlobals(password.NewPostgresRepository(database.DB().Postgres().DB))
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(database.DB().Postgres().DB))
 }
 
 // serverHealthStatusIsHealthy indicates whether the server is healthy.
@@ -122,3 +122,17 @@ func (r *UserPostgresRepository) GetAll(userID uuid.UUID) ([]models.BrokerUser,
 
 	return utils.ScanAllStruct[models.BrokerUser](rows)
 }
+
+// DeleteAll use to delete every BrokerUser of a user
+func (r *UserPostgresRepository) DeleteAll(userID uuid.UUID) error {
+	// Prepare query
+	query := `DELETE FROM user_brokers as ub
+			  WHERE ub.user_id = :user_id`
+	params := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	// Execute query
+	_, err := r.conn.NamedExec(query, params)
+	return err
+}
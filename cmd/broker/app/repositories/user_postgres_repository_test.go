@@ -259,3 +259,43 @@ func TestUserPostgresRepository_GetAll(t *testing.T) {
 		})
 	}
 }
+
+// TestUserPostgresRepository_DeleteAll tests the DeleteAll method
+func TestUserPostgresRepository_DeleteAll(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewUserPostgresRepository(sqlxMock.DB), nil))
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail user brokers delete",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM user_brokers").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Delete user brokers",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM user_brokers").WillReturnResult(sqlxmock.NewResult(1, 3))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().U().DeleteAll(uuid.New())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("DeleteAll() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
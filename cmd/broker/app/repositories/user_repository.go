@@ -14,4 +14,5 @@ type UserRepository interface {
 	Delete(userBroker models.BrokerUser) error
 	Exists(userBroker models.BrokerUser) (bool, error)
 	GetAll(userID uuid.UUID) ([]models.BrokerUser, error)
+	DeleteAll(userID uuid.UUID) error
 }
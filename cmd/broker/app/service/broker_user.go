@@ -103,13 +103,6 @@ func (h *Service) CreateBrokerUser(ctx context.Context, req *brokerpb.CreateBrok
 
 // GetBrokerUser implements the GetBrokerUser RPC method.
 func (h *Service) GetBrokerUser(ctx context.Context, req *brokerpb.GetBrokerUserRequest) (*brokerpb.GetBrokerUserResponse, error) {
-	// Check user permissions
-	err := security.Facade().CheckPermission(ctx, "admin.users.brokers.get")
-	if err != nil {
-		zap.L().Error("CheckPermission", zap.Error(err))
-		return &brokerpb.GetBrokerUserResponse{}, err
-	}
-
 	// Parse the user ID from the request
 	userID, err := uuid.Parse(req.GetUserId())
 	if err != nil {
@@ -126,6 +119,16 @@ func (h *Service) GetBrokerUser(ctx context.Context, req *brokerpb.GetBrokerUser
 		return &brokerpb.GetBrokerUserResponse{}, status.Error(codes.InvalidArgument, "Invalid broker ID")
 	}
 
+	// Verify that the user can access the user broker
+	err = security.Facade().CheckResourceAccess(ctx, security.ActionRead, security.Resource{
+		Type:    security.ResourceBrokerUser,
+		OwnerID: userID,
+	})
+	if err != nil {
+		zap.L().Error("CheckResourceAccess", zap.Error(err))
+		return &brokerpb.GetBrokerUserResponse{}, err
+	}
+
 	// Retrieve the BrokerUser from the database
 	brokerUser, exists, err := repositories.R().U().Get(models.BrokerUser{
 		UserID: userID,
@@ -149,13 +152,6 @@ func (h *Service) GetBrokerUser(ctx context.Context, req *brokerpb.GetBrokerUser
 
 // DeleteBrokerUser implements the DeleteBrokerUser RPC method.
 func (h *Service) DeleteBrokerUser(ctx context.Context, req *brokerpb.DeleteBrokerUserRequest) (*brokerpb.DeleteBrokerUserResponse, error) {
-	// Check user permissions
-	err := security.Facade().CheckPermission(ctx, "admin.users.brokers.delete")
-	if err != nil {
-		zap.L().Error("CheckPermission", zap.Error(err))
-		return &brokerpb.DeleteBrokerUserResponse{}, err
-	}
-
 	// Parse the user ID from the request
 	userID, err := uuid.Parse(req.GetUserId())
 	if err != nil {
@@ -176,6 +172,18 @@ func (h *Service) DeleteBrokerUser(ctx context.Context, req *brokerpb.DeleteBrok
 		}, status.Error(codes.InvalidArgument, "Invalid broker ID")
 	}
 
+	// Verify that the user can delete the user broker
+	err = security.Facade().CheckResourceAccess(ctx, security.ActionDelete, security.Resource{
+		Type:    security.ResourceBrokerUser,
+		OwnerID: userID,
+	})
+	if err != nil {
+		zap.L().Error("CheckResourceAccess", zap.Error(err))
+		return &brokerpb.DeleteBrokerUserResponse{
+			Success: false,
+		}, err
+	}
+
 	// Construct the BrokerUser object from the request
 	brokerUser := models.BrokerUser{
 		UserID: userID,
@@ -242,3 +250,40 @@ func (h *Service) ListUserBrokers(ctx context.Context, req *brokerpb.ListUserBro
 		UserBrokers: mappers.BrokerUsersToProto(userBrokers),
 	}, nil
 }
+
+// DeleteUserBrokers implements the DeleteUserBrokers RPC method.
+// Called by the user microservice once the deletion of an account is due, it reports how many user brokers remain.
+func (h *Service) DeleteUserBrokers(ctx context.Context, req *brokerpb.DeleteUserBrokersRequest) (*brokerpb.DeleteUserBrokersResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &brokerpb.DeleteUserBrokersResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.delete", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &brokerpb.DeleteUserBrokersResponse{}, err
+	}
+
+	// Remove all the brokers of the user
+	err = repositories.R().U().DeleteAll(userID)
+	if err != nil {
+		zap.L().Error("Cannot delete user brokers", zap.String("uuid", userID.String()), zap.Error(err))
+		return &brokerpb.DeleteUserBrokersResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Report what is left, so that the caller can verify the deletion
+	remaining, err := repositories.R().U().GetAll(userID)
+	if err != nil {
+		zap.L().Error("Cannot get user brokers", zap.String("uuid", userID.String()), zap.Error(err))
+		return &brokerpb.DeleteUserBrokersResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &brokerpb.DeleteUserBrokersResponse{
+		Remaining: int64(len(remaining)),
+	}, nil
+}
@@ -267,24 +267,12 @@ func TestGetUserBroker(t *testing.T) {
 		expected        *brokerpb.GetBrokerUserResponse
 		expectedErrCode codes.Code
 	}{
-		{
-			name: "does not have permission",
-			mockSetup: func(ctrl *gomock.Controller) {
-				// Mock the public security facade
-				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
-				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
-			},
-			request:         validRequest,
-			expected:        &brokerpb.GetBrokerUserResponse{},
-			expectedErrCode: codes.PermissionDenied,
-		},
 		{
 			name: "fails to parse ID from request",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the broker repository
 				bu := mocks.NewBrokerUserRepository(ctrl)
@@ -297,12 +285,24 @@ func TestGetUserBroker(t *testing.T) {
 			expected:        &brokerpb.GetBrokerUserResponse{},
 			expectedErrCode: codes.InvalidArgument,
 		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         validRequest,
+			expected:        &brokerpb.GetBrokerUserResponse{},
+			expectedErrCode: codes.PermissionDenied,
+		},
 		{
 			name: "Fails to retrieve the user broker",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the broker repository
 				bu := mocks.NewBrokerUserRepository(ctrl)
@@ -318,7 +318,7 @@ func TestGetUserBroker(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the broker repository
 				bu := mocks.NewBrokerUserRepository(ctrl)
@@ -334,7 +334,7 @@ func TestGetUserBroker(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the broker repository
 				bu := mocks.NewBrokerUserRepository(ctrl)
@@ -400,24 +400,12 @@ func TestDeleteUserBroker(t *testing.T) {
 		expected        *brokerpb.DeleteBrokerUserResponse
 		expectedErrCode codes.Code
 	}{
-		{
-			name: "does not have permission",
-			mockSetup: func(ctrl *gomock.Controller) {
-				// Mock the public security facade
-				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
-				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
-			},
-			request:         request,
-			expected:        &brokerpb.DeleteBrokerUserResponse{},
-			expectedErrCode: codes.PermissionDenied,
-		},
 		{
 			name: "fails to parse ID from request",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the broker repository
 				bu := mocks.NewBrokerUserRepository(ctrl)
@@ -430,12 +418,24 @@ func TestDeleteUserBroker(t *testing.T) {
 			expected:        &brokerpb.DeleteBrokerUserResponse{},
 			expectedErrCode: codes.InvalidArgument,
 		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         request,
+			expected:        &brokerpb.DeleteBrokerUserResponse{},
+			expectedErrCode: codes.PermissionDenied,
+		},
 		{
 			name: "Fails to verify the user broker existence",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the broker repository
 				bu := mocks.NewBrokerUserRepository(ctrl)
@@ -452,7 +452,7 @@ func TestDeleteUserBroker(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the broker repository
 				bu := mocks.NewBrokerUserRepository(ctrl)
@@ -469,7 +469,7 @@ func TestDeleteUserBroker(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the broker repository
 				bu := mocks.NewBrokerUserRepository(ctrl)
@@ -486,7 +486,7 @@ func TestDeleteUserBroker(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the broker repository
 				bu := mocks.NewBrokerUserRepository(ctrl)
@@ -646,3 +646,133 @@ func TestListUserBrokers(t *testing.T) {
 		})
 	}
 }
+
+// TestDeleteUserBrokers tests the DeleteUserBrokers handler
+func TestDeleteUserBrokers(t *testing.T) {
+	service := &Service{}
+	validRequest := &brokerpb.DeleteUserBrokersRequest{
+		UserId: uuid.New().String(),
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *brokerpb.DeleteUserBrokersRequest
+		expected        *brokerpb.DeleteUserBrokersResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the broker repository
+				bu := mocks.NewBrokerUserRepository(ctrl)
+				bu.EXPECT().DeleteAll(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, bu, nil))
+			},
+			request: &brokerpb.DeleteUserBrokersRequest{
+				UserId: "bad-uuid",
+			},
+			expected:        &brokerpb.DeleteUserBrokersResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the broker repository
+				bu := mocks.NewBrokerUserRepository(ctrl)
+				bu.EXPECT().DeleteAll(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, bu, nil))
+			},
+			request:         validRequest,
+			expected:        &brokerpb.DeleteUserBrokersResponse{},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to delete",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the broker repository
+				bu := mocks.NewBrokerUserRepository(ctrl)
+				bu.EXPECT().DeleteAll(gomock.Any()).Return(errors.New("error"))
+				bu.EXPECT().GetAll(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, bu, nil))
+			},
+			request:         validRequest,
+			expected:        &brokerpb.DeleteUserBrokersResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "fails to count the remaining",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the broker repository
+				bu := mocks.NewBrokerUserRepository(ctrl)
+				bu.EXPECT().DeleteAll(gomock.Any()).Return(nil)
+				bu.EXPECT().GetAll(gomock.Any()).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, bu, nil))
+			},
+			request:         validRequest,
+			expected:        &brokerpb.DeleteUserBrokersResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the broker repository
+				bu := mocks.NewBrokerUserRepository(ctrl)
+				bu.EXPECT().DeleteAll(gomock.Any()).Return(nil)
+				bu.EXPECT().GetAll(gomock.Any()).Return(nil, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, bu, nil))
+			},
+			request:         validRequest,
+			expected:        &brokerpb.DeleteUserBrokersResponse{Remaining: 0},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.DeleteUserBrokers(context.Background(), tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+}
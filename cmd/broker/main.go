@@ -10,7 +10,6 @@ import (
 	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
 	"github.com/Zapharaos/fihub-backend/internal/security"
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
 	"time"
 )
 
@@ -41,7 +40,7 @@ func main() {
 	security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 
 	// Register gRPC service
-	s := grpc.NewServer()
+	s := grpcutil.NewServer(serviceName)
 	brokerpb.RegisterBrokerServiceServer(s, &service.Service{})
 
 	// Setup Database
@@ -6,7 +6,6 @@ import (
 	"github.com/Zapharaos/fihub-backend/gen/go/healthpb"
 	"github.com/Zapharaos/fihub-backend/internal/app"
 	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
-	"google.golang.org/grpc"
 )
 
 func main() {
@@ -31,7 +30,7 @@ func main() {
 	registerGrpcConnections()
 
 	// Register gRPC service
-	s := grpc.NewServer()
+	s := grpcutil.NewServer(serviceName)
 	healthpb.RegisterHealthServiceServer(s, &service.Service{})
 
 	// Start gRPC server
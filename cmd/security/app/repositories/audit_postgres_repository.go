@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+)
+
+// AuditPostgresRepository is a repository containing the audit log based on a PSQL database and
+// implementing the repository interface
+type AuditPostgresRepository struct {
+	conn *sqlx.DB
+}
+
+// NewAuditPostgresRepository returns a new instance of AuditRepository
+func NewAuditPostgresRepository(dbClient *sqlx.DB) AuditRepository {
+	r := AuditPostgresRepository{
+		conn: dbClient,
+	}
+	var ra AuditRepository = &r
+	return ra
+}
+
+// Create appends a new entry to the audit log
+func (r *AuditPostgresRepository) Create(log models.AuditLog) (uuid.UUID, error) {
+
+	newUUID := uuid.New()
+
+	// Prepare query
+	query := `INSERT INTO audit_logs (id, occurred_at, action, outcome, actor_id, target_type, target_id, request_id, ip_address, details)
+				VALUES (:id, NOW(), :action, :outcome, :actor_id, :target_type, :target_id, :request_id, :ip_address, :details)`
+	params := map[string]interface{}{
+		"id":          newUUID,
+		"action":      log.Action,
+		"outcome":     log.Outcome,
+		"actor_id":    log.ActorID,
+		"target_type": log.TargetType,
+		"target_id":   log.TargetID,
+		"request_id":  log.RequestID,
+		"ip_address":  log.IPAddress,
+		"details":     log.Details,
+	}
+
+	// Execute query
+	_, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	return newUUID, nil
+}
+
+// Search returns the entries of the audit log matching the filter, the most recent first
+func (r *AuditPostgresRepository) Search(filter models.AuditLogFilter) (models.AuditLogs, error) {
+	filter = filter.Normalize()
+
+	// Only filter on the given criteria
+	conditions := make([]string, 0)
+	params := map[string]interface{}{
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "a.action = :action")
+		params["action"] = filter.Action
+	}
+	if filter.Outcome != "" {
+		conditions = append(conditions, "a.outcome = :outcome")
+		params["outcome"] = filter.Outcome
+	}
+	if filter.ActorID.Valid {
+		conditions = append(conditions, "a.actor_id = :actor_id")
+		params["actor_id"] = filter.ActorID.UUID
+	}
+	if filter.TargetType != "" {
+		conditions = append(conditions, "a.target_type = :target_type")
+		params["target_type"] = filter.TargetType
+	}
+	if filter.TargetID != "" {
+		conditions = append(conditions, "a.target_id = :target_id")
+		params["target_id"] = filter.TargetID
+	}
+	if filter.RequestID != "" {
+		conditions = append(conditions, "a.request_id = :request_id")
+		params["request_id"] = filter.RequestID
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "a.occurred_at >= :from")
+		params["from"] = *filter.From
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "a.occurred_at < :to")
+		params["to"] = *filter.To
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Prepare query
+	query := `SELECT a.id, a.occurred_at, a.action, a.outcome, a.actor_id, a.target_type, a.target_id, a.request_id, a.ip_address, a.details
+			  FROM audit_logs as a
+			  ` + where + `
+			  ORDER BY a.occurred_at DESC, a.id
+			  LIMIT :limit OFFSET :offset`
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return utils.ScanAllStruct[models.AuditLog](rows)
+}
+
+// DeleteOlderThan removes the entries which occurred before the given time and returns how many were removed
+func (r *AuditPostgresRepository) DeleteOlderThan(before time.Time) (int64, error) {
+	// Prepare query
+	query := `DELETE FROM audit_logs as a
+			  WHERE a.occurred_at < :before`
+	params := map[string]interface{}{
+		"before": before,
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
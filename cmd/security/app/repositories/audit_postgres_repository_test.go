@@ -0,0 +1,183 @@
+package repositories_test
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/test"
+	"github.com/google/uuid"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+	"testing"
+	"time"
+)
+
+// TestAuditPostgresRepository_Create test the AuditPostgresRepository.Create method
+func TestAuditPostgresRepository_Create(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, repositories.NewAuditPostgresRepository(sqlxMock.DB)))
+
+	tests := []struct {
+		name      string
+		log       models.AuditLog
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail audit log creation",
+			log:  models.AuditLog{Action: models.AuditActionLogin, Outcome: models.AuditOutcomeSuccess},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("INSERT INTO audit_logs").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Create audit log",
+			log: models.AuditLog{
+				Action:  models.AuditActionLogin,
+				Outcome: models.AuditOutcomeSuccess,
+				ActorID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				Details: models.AuditDetails{"session_id": uuid.New().String()},
+			},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("INSERT INTO audit_logs").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			_, err := repositories.R().A().Create(tt.log)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Create() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestAuditPostgresRepository_Search test the AuditPostgresRepository.Search method
+func TestAuditPostgresRepository_Search(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, repositories.NewAuditPostgresRepository(sqlxMock.DB)))
+
+	columns := []string{"id", "occurred_at", "action", "outcome", "actor_id", "target_type", "target_id", "request_id", "ip_address", "details"}
+	from := time.Now().Add(-time.Hour)
+	to := time.Now()
+
+	tests := []struct {
+		name        string
+		filter      models.AuditLogFilter
+		mockSetup   func()
+		expectErr   bool
+		expectCount int
+	}{
+		{
+			name:   "Fail audit logs search",
+			filter: models.AuditLogFilter{},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
+			},
+			expectErr:   true,
+			expectCount: 0,
+		},
+		{
+			name:   "Search without filter",
+			filter: models.AuditLogFilter{},
+			mockSetup: func() {
+				rows := sqlxmock.NewRows(columns).
+					AddRow(uuid.New(), time.Now(), models.AuditActionLogin, models.AuditOutcomeSuccess, uuid.New(), "", "", "", "127.0.0.1", []byte(`{}`)).
+					AddRow(uuid.New(), time.Now(), models.AuditActionRoleDelete, models.AuditOutcomeSuccess, nil, models.AuditTargetRole, uuid.New().String(), "req-1", "", []byte(`{"name":"admin"}`))
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			expectErr:   false,
+			expectCount: 2,
+		},
+		{
+			name: "Search with every filter",
+			filter: models.AuditLogFilter{
+				Action:     models.AuditActionPermissionDenied,
+				Outcome:    models.AuditOutcomeDenied,
+				ActorID:    uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				TargetType: models.AuditTargetUser,
+				TargetID:   uuid.New().String(),
+				RequestID:  "req-1",
+				From:       &from,
+				To:         &to,
+			},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("WHERE a.action = (.+) AND a.outcome = (.+) AND a.actor_id = (.+) AND a.target_type = (.+) AND a.target_id = (.+) AND a.request_id = (.+) AND a.occurred_at >= (.+) AND a.occurred_at < (.+)").
+					WillReturnRows(sqlxmock.NewRows(columns))
+			},
+			expectErr:   false,
+			expectCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			logs, err := repositories.R().A().Search(tt.filter)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Search() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if len(logs) != tt.expectCount {
+				t.Errorf("Search() count = %v, expectCount %v", len(logs), tt.expectCount)
+			}
+		})
+	}
+}
+
+// TestAuditPostgresRepository_DeleteOlderThan test the AuditPostgresRepository.DeleteOlderThan method
+func TestAuditPostgresRepository_DeleteOlderThan(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, repositories.NewAuditPostgresRepository(sqlxMock.DB)))
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectErr     bool
+		expectDeleted int64
+	}{
+		{
+			name: "Fail audit logs delete",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM audit_logs").WillReturnError(errors.New("error"))
+			},
+			expectErr:     true,
+			expectDeleted: 0,
+		},
+		{
+			name: "Delete audit logs",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM audit_logs").WillReturnResult(sqlxmock.NewResult(0, 3))
+			},
+			expectErr:     false,
+			expectDeleted: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			deleted, err := repositories.R().A().DeleteOlderThan(time.Now())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("DeleteOlderThan() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if deleted != tt.expectDeleted {
+				t.Errorf("DeleteOlderThan() deleted = %v, expectDeleted %v", deleted, tt.expectDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+// AuditRepository is an append-only storage interface for the audit log.
+// Entries are never updated, they are only removed once the retention period has elapsed.
+type AuditRepository interface {
+	Create(log models.AuditLog) (uuid.UUID, error)
+	Search(filter models.AuditLogFilter) (models.AuditLogs, error)
+	DeleteOlderThan(before time.Time) (int64, error)
+}
@@ -2,3 +2,5 @@ package repositories
 
 //go:generate mockgen -source=role_repository.go -destination=../../../../test/mocks/security_repository_role.go --package=mocks -mock_names=RoleRepository=SecurityRoleRepository RoleRepository
 //go:generate mockgen -source=permission_repository.go -destination=../../../../test/mocks/security_repository_permission.go --package=mocks -mock_names=PermissionRepository=SecurityPermissionRepository PermissionRepository
+//go:generate mockgen -source=permission_cache_repository.go -destination=../../../../test/mocks/security_repository_permission_cache.go --package=mocks -mock_names=PermissionCacheRepository=SecurityPermissionCacheRepository PermissionCacheRepository
+//go:generate mockgen -source=audit_repository.go -destination=../../../../test/mocks/security_repository_audit.go --package=mocks -mock_names=AuditRepository=SecurityAuditRepository AuditRepository
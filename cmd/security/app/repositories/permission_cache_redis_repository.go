@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	permissionCacheKeyPrefix = "security:access:"
+	// PermissionCacheChannel is the channel the permission cache invalidations are published on
+	PermissionCacheChannel = "security:permissions-invalidated"
+	// permissionCacheScanCount is the number of keys scanned per iteration when invalidating every user
+	permissionCacheScanCount = 100
+)
+
+// PermissionCacheRedisRepository is a repository caching the roles and effective permissions of the users in a Redis database
+// and implementing the repository interface.
+// Each user access is stored as a JSON object expiring after the TTL.
+// Invalidations are published on PermissionCacheChannel as a JSON list of user IDs, an empty list meaning every user.
+type PermissionCacheRedisRepository struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewPermissionCacheRedisRepository returns a new instance of PermissionCacheRedisRepository
+func NewPermissionCacheRedisRepository(client *redis.Client, ttl time.Duration) PermissionCacheRepository {
+	r := PermissionCacheRedisRepository{
+		client: client,
+		ttl:    ttl,
+	}
+	var repo PermissionCacheRepository = &r
+	return repo
+}
+
+// Get returns the cached access of the user, if any
+func (r *PermissionCacheRedisRepository) Get(userUUID uuid.UUID) (models.UserAccess, bool, error) {
+	data, err := r.client.Get(context.Background(), permissionCacheKeyPrefix+userUUID.String()).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return models.UserAccess{}, false, nil
+	}
+	if err != nil {
+		return models.UserAccess{}, false, err
+	}
+
+	var access models.UserAccess
+	err = json.Unmarshal(data, &access)
+	if err != nil {
+		return models.UserAccess{}, false, err
+	}
+	return access, true, nil
+}
+
+// Set caches the access of the user until the TTL expires, or until the given date when earlier and not zero.
+// Nothing is cached when the date has already passed.
+func (r *PermissionCacheRedisRepository) Set(userUUID uuid.UUID, access models.UserAccess, until time.Time) error {
+	ttl := r.ttl
+	if !until.IsZero() {
+		ttl = min(ttl, time.Until(until))
+		if ttl <= 0 {
+			return nil
+		}
+	}
+
+	if access.Roles == nil {
+		access.Roles = []string{}
+	}
+	if access.Permissions == nil {
+		access.Permissions = []string{}
+	}
+	data, err := json.Marshal(access)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(context.Background(), permissionCacheKeyPrefix+userUUID.String(), data, ttl).Err()
+}
+
+// Invalidate removes the cached permissions of the users and publishes the invalidation
+func (r *PermissionCacheRedisRepository) Invalidate(userUUIDs []uuid.UUID) error {
+	if len(userUUIDs) == 0 {
+		return nil
+	}
+	ctx := context.Background()
+
+	keys := make([]string, len(userUUIDs))
+	ids := make([]string, len(userUUIDs))
+	for i, userUUID := range userUUIDs {
+		keys[i] = permissionCacheKeyPrefix + userUUID.String()
+		ids[i] = userUUID.String()
+	}
+
+	err := r.client.Del(ctx, keys...).Err()
+	if err != nil {
+		return err
+	}
+	return r.publish(ctx, ids)
+}
+
+// InvalidateAll removes the cached permissions of every user and publishes the invalidation
+func (r *PermissionCacheRedisRepository) InvalidateAll() error {
+	ctx := context.Background()
+
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, permissionCacheKeyPrefix+"*", permissionCacheScanCount).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			err = r.client.Del(ctx, keys...).Err()
+			if err != nil {
+				return err
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return r.publish(ctx, []string{})
+}
+
+// publish publishes an invalidation of the users
+func (r *PermissionCacheRedisRepository) publish(ctx context.Context, ids []string) error {
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	return r.client.Publish(ctx, PermissionCacheChannel, data).Err()
+}
+
+// Subscribe listens to the invalidations published on PermissionCacheChannel
+func (r *PermissionCacheRedisRepository) Subscribe(onInvalidate func(userUUIDs []uuid.UUID)) (func() error, error) {
+	ctx := context.Background()
+
+	pubsub := r.client.Subscribe(ctx, PermissionCacheChannel)
+	// Wait for the subscription to be confirmed
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	go func() {
+		for msg := range pubsub.Channel() {
+			userUUIDs, err := parsePermissionCacheInvalidation(msg.Payload)
+			if err != nil {
+				zap.L().Error("Invalid permission cache invalidation", zap.String("payload", msg.Payload), zap.Error(err))
+				continue
+			}
+			onInvalidate(userUUIDs)
+		}
+	}()
+
+	return pubsub.Close, nil
+}
+
+// parsePermissionCacheInvalidation parses the users of an invalidation, nil meaning every user
+func parsePermissionCacheInvalidation(payload string) ([]uuid.UUID, error) {
+	var ids []string
+	err := json.Unmarshal([]byte(payload), &ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	userUUIDs := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		userUUIDs[i], err = uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return userUUIDs, nil
+}
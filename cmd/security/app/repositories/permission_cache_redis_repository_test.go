@@ -0,0 +1,272 @@
+package repositories_test
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/go-redis/redismock/v9"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestPermissionCacheRedisRepository_Get test the PermissionCacheRedisRepository.Get method
+func TestPermissionCacheRedisRepository_Get(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, repositories.NewPermissionCacheRedisRepository(client, time.Minute), nil))
+	userID := uuid.New()
+	key := "security:access:" + userID.String()
+
+	tests := []struct {
+		name         string
+		mockSetup    func()
+		expectErr    bool
+		expectFound  bool
+		expectResult models.UserAccess
+	}{
+		{
+			name: "Fail to get",
+			mockSetup: func() {
+				mock.ExpectGet(key).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Not cached",
+			mockSetup: func() {
+				mock.ExpectGet(key).RedisNil()
+			},
+			expectFound: false,
+		},
+		{
+			name: "Invalid cached value",
+			mockSetup: func() {
+				mock.ExpectGet(key).SetVal("invalid")
+			},
+			expectErr: true,
+		},
+		{
+			name: "Cached without roles",
+			mockSetup: func() {
+				mock.ExpectGet(key).SetVal(`{"roles":[],"permissions":[]}`)
+			},
+			expectFound:  true,
+			expectResult: models.UserAccess{Roles: []string{}, Permissions: []string{}},
+		},
+		{
+			name: "Cached",
+			mockSetup: func() {
+				mock.ExpectGet(key).SetVal(`{"roles":["admin"],"permissions":["admin.users.read","admin.roles.*"]}`)
+			},
+			expectFound:  true,
+			expectResult: models.UserAccess{Roles: []string{"admin"}, Permissions: []string{"admin.users.read", "admin.roles.*"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			result, found, err := repositories.R().C().Get(userID)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Get() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.Equal(t, tt.expectFound, found)
+			assert.Equal(t, tt.expectResult, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestPermissionCacheRedisRepository_Set test the PermissionCacheRedisRepository.Set method
+func TestPermissionCacheRedisRepository_Set(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, repositories.NewPermissionCacheRedisRepository(client, time.Minute), nil))
+	userID := uuid.New()
+	key := "security:access:" + userID.String()
+
+	access := models.UserAccess{Roles: []string{"admin"}, Permissions: []string{"admin.users.read"}}
+	accessJSON := []byte(`{"roles":["admin"],"permissions":["admin.users.read"]}`)
+
+	tests := []struct {
+		name      string
+		access    models.UserAccess
+		until     time.Time
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:   "Fail to set",
+			access: access,
+			mockSetup: func() {
+				mock.ExpectSet(key, accessJSON, time.Minute).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:   "Set without roles",
+			access: models.UserAccess{},
+			mockSetup: func() {
+				mock.ExpectSet(key, []byte(`{"roles":[],"permissions":[]}`), time.Minute).SetVal("OK")
+			},
+			expectErr: false,
+		},
+		{
+			name:   "Set",
+			access: access,
+			mockSetup: func() {
+				mock.ExpectSet(key, accessJSON, time.Minute).SetVal("OK")
+			},
+			expectErr: false,
+		},
+		{
+			name:   "Set until a date after the TTL",
+			access: access,
+			until:  time.Now().Add(time.Hour),
+			mockSetup: func() {
+				mock.ExpectSet(key, accessJSON, time.Minute).SetVal("OK")
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Set until a passed date",
+			access:    access,
+			until:     time.Now().Add(-time.Second),
+			mockSetup: func() {},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().C().Set(userID, tt.access, tt.until)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Set() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestPermissionCacheRedisRepository_Invalidate test the PermissionCacheRedisRepository.Invalidate method
+func TestPermissionCacheRedisRepository_Invalidate(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, repositories.NewPermissionCacheRedisRepository(client, time.Minute), nil))
+	userID1 := uuid.New()
+	userID2 := uuid.New()
+	keys := []string{"security:access:" + userID1.String(), "security:access:" + userID2.String()}
+	event := []byte(`["` + userID1.String() + `","` + userID2.String() + `"]`)
+
+	tests := []struct {
+		name      string
+		userIDs   []uuid.UUID
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:      "No users",
+			userIDs:   nil,
+			mockSetup: func() {},
+			expectErr: false,
+		},
+		{
+			name:    "Fail to delete",
+			userIDs: []uuid.UUID{userID1, userID2},
+			mockSetup: func() {
+				mock.ExpectDel(keys...).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:    "Fail to publish",
+			userIDs: []uuid.UUID{userID1, userID2},
+			mockSetup: func() {
+				mock.ExpectDel(keys...).SetVal(2)
+				mock.ExpectPublish(repositories.PermissionCacheChannel, event).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:    "Invalidate",
+			userIDs: []uuid.UUID{userID1, userID2},
+			mockSetup: func() {
+				mock.ExpectDel(keys...).SetVal(2)
+				mock.ExpectPublish(repositories.PermissionCacheChannel, event).SetVal(1)
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().C().Invalidate(tt.userIDs)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Invalidate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestPermissionCacheRedisRepository_InvalidateAll test the PermissionCacheRedisRepository.InvalidateAll method
+func TestPermissionCacheRedisRepository_InvalidateAll(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, repositories.NewPermissionCacheRedisRepository(client, time.Minute), nil))
+	pattern := "security:access:*"
+	key1 := "security:access:" + uuid.New().String()
+	key2 := "security:access:" + uuid.New().String()
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail to scan",
+			mockSetup: func() {
+				mock.ExpectScan(0, pattern, 100).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fail to delete",
+			mockSetup: func() {
+				mock.ExpectScan(0, pattern, 100).SetVal([]string{key1}, 0)
+				mock.ExpectDel(key1).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fail to publish",
+			mockSetup: func() {
+				mock.ExpectScan(0, pattern, 100).SetVal([]string{}, 0)
+				mock.ExpectPublish(repositories.PermissionCacheChannel, []byte(`[]`)).SetErr(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Invalidate every user",
+			mockSetup: func() {
+				mock.ExpectScan(0, pattern, 100).SetVal([]string{key1}, 42)
+				mock.ExpectDel(key1).SetVal(1)
+				mock.ExpectScan(42, pattern, 100).SetVal([]string{key2}, 0)
+				mock.ExpectDel(key2).SetVal(1)
+				mock.ExpectPublish(repositories.PermissionCacheChannel, []byte(`[]`)).SetVal(1)
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().C().InvalidateAll()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("InvalidateAll() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
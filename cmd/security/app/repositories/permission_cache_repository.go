@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+// PermissionCacheRepository is a storage interface caching the roles and effective permissions of the users.
+// Invalidations are also broadcast, so that other layers caching permission decisions can drop them.
+type PermissionCacheRepository interface {
+	Get(userUUID uuid.UUID) (models.UserAccess, bool, error)
+	// Set caches the access until the TTL expires, or until the given date when earlier and not zero
+	Set(userUUID uuid.UUID, access models.UserAccess, until time.Time) error
+	Invalidate(userUUIDs []uuid.UUID) error
+	InvalidateAll() error
+	// Subscribe calls onInvalidate for each invalidation, with the invalidated users or nil for every user.
+	// The returned function stops the subscription.
+	Subscribe(onInvalidate func(userUUIDs []uuid.UUID)) (func() error, error)
+}
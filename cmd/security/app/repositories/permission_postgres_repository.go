@@ -38,10 +38,11 @@ func (r *PermissionPostgresRepository) Create(permission models.Permission) (uui
 	}
 
 	// Execute query
-	_, err := r.conn.NamedQuery(query, params)
+	rows, err := r.conn.NamedQuery(query, params)
 	if err != nil {
 		return uuid.UUID{}, err
 	}
+	defer rows.Close()
 
 	return newUUID, nil
 }
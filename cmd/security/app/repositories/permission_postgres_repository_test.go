@@ -16,7 +16,7 @@ func TestPermissionPostgresRepository_Create(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewPermissionPostgresRepository(sqlxMock.DB)))
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewPermissionPostgresRepository(sqlxMock.DB), nil, nil))
 
 	tests := []struct {
 		name       string
@@ -59,7 +59,7 @@ func TestPermissionPostgresRepository_Get(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewPermissionPostgresRepository(sqlxMock.DB)))
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewPermissionPostgresRepository(sqlxMock.DB), nil, nil))
 
 	tests := []struct {
 		name         string
@@ -111,7 +111,7 @@ func TestPermissionPostgresRepository_Update(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewPermissionPostgresRepository(sqlxMock.DB)))
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewPermissionPostgresRepository(sqlxMock.DB), nil, nil))
 
 	tests := []struct {
 		name       string
@@ -152,7 +152,7 @@ func TestPermissionPostgresRepository_Delete(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewPermissionPostgresRepository(sqlxMock.DB)))
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewPermissionPostgresRepository(sqlxMock.DB), nil, nil))
 
 	tests := []struct {
 		name         string
@@ -195,7 +195,7 @@ func TestPermissionPostgresRepository_List(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewPermissionPostgresRepository(sqlxMock.DB)))
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, repositories.NewPermissionPostgresRepository(sqlxMock.DB), nil, nil))
 
 	tests := []struct {
 		name        string
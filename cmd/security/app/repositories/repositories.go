@@ -4,13 +4,18 @@ package repositories
 type Repository struct {
 	role       RoleRepository
 	permission PermissionRepository
+	cache      PermissionCacheRepository
+	audit      AuditRepository
 }
 
-// NewRepository returns a new instance of Repository
-func NewRepository(role RoleRepository, permission PermissionRepository) Repository {
+// NewRepository returns a new instance of Repository.
+// The permission cache is optional : permissions are always read from the role repository without it.
+func NewRepository(role RoleRepository, permission PermissionRepository, cache PermissionCacheRepository, audit AuditRepository) Repository {
 	return Repository{
 		role:       role,
 		permission: permission,
+		cache:      cache,
+		audit:      audit,
 	}
 }
 
@@ -24,6 +29,16 @@ func (r Repository) P() PermissionRepository {
 	return r.permission
 }
 
+// C is used to access the PermissionCacheRepository singleton, nil when the cache is disabled
+func (r Repository) C() PermissionCacheRepository {
+	return r.cache
+}
+
+// A is used to access the AuditRepository singleton
+func (r Repository) A() AuditRepository {
+	return r.audit
+}
+
 // R is used to access the global repository singleton
 var _globalRepository Repository
 
@@ -14,13 +14,17 @@ func TestNewRepository(t *testing.T) {
 	// Replace with mocks repositories
 	mockRoleRepository := &mocks.SecurityRoleRepository{}
 	mockPermissionRepository := &mocks.SecurityPermissionRepository{}
+	mockPermissionCacheRepository := &mocks.SecurityPermissionCacheRepository{}
+	mockAuditRepository := &mocks.SecurityAuditRepository{}
 
 	// Create a new repository
-	repo := repositories.NewRepository(mockRoleRepository, mockPermissionRepository)
+	repo := repositories.NewRepository(mockRoleRepository, mockPermissionRepository, mockPermissionCacheRepository, mockAuditRepository)
 
 	// Verify that the repositories are correctly assigned
 	assert.Equal(t, mockRoleRepository, repo.R())
 	assert.Equal(t, mockPermissionRepository, repo.P())
+	assert.Equal(t, mockPermissionCacheRepository, repo.C())
+	assert.Equal(t, mockAuditRepository, repo.A())
 }
 
 // TestReplaceGlobals tests the ReplaceGlobals function
@@ -29,7 +33,7 @@ func TestReplaceGlobals(t *testing.T) {
 	// Replace with mocks repositories
 	mockRoleRepository := &mocks.SecurityRoleRepository{}
 	mockPermissionRepository := &mocks.SecurityPermissionRepository{}
-	mockRepository := repositories.NewRepository(mockRoleRepository, mockPermissionRepository)
+	mockRepository := repositories.NewRepository(mockRoleRepository, mockPermissionRepository, nil, nil)
 
 	// Replace the global repository with a mocks repository
 	restore := repositories.ReplaceGlobals(mockRepository)
@@ -13,6 +13,9 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// activeGrantCondition restricts the user_roles (ur) rows to the grants effective at the time of the query
+const activeGrantCondition = `ur.valid_from <= NOW() AND (ur.valid_until IS NULL OR ur.valid_until > NOW())`
+
 // RolePostgresRepository is a repository containing the user roles data based on a PSQL database and
 // implementing the repository interface
 type RolePostgresRepository struct {
@@ -264,7 +267,7 @@ func (r *RolePostgresRepository) ListByUserId(userUUID uuid.UUID) (models.Roles,
 	query := `SELECT r.id, r.name
 			  FROM roles as r
 			  INNER JOIN user_roles as ur on r.id = ur.role_id
-			  WHERE ur.user_id = :id`
+			  WHERE ur.user_id = :id AND ` + activeGrantCondition
 	params := map[string]interface{}{
 		"id": userUUID,
 	}
@@ -298,15 +301,25 @@ func (r *RolePostgresRepository) ListWithPermissions() (models.RolesWithPermissi
 	return r.ScanAllWithPermissions(rows)
 }
 
-// ListWithPermissionsByUserId returns all the roles with permissions for a user in the repository
+// ListWithPermissionsByUserId returns all the roles with permissions for a user in the repository.
+// The permissions of the ancestors of each role are returned as inherited permissions.
 func (r *RolePostgresRepository) ListWithPermissionsByUserId(userUUID uuid.UUID) (models.RolesWithPermissions, error) {
-	// Prepare query
-	query := `SELECT r.id, r.name, p.id, p.value, p.scope, p.description
-			  FROM roles as r
-			  LEFT JOIN role_permissions as rp on r.id = rp.role_id
+	// Prepare query : UNION discards the already visited pairs, hence cycles end the recursion
+	query := `WITH RECURSIVE role_tree (role_id, ancestor_id) AS (
+				  SELECT ur.role_id, ur.role_id
+				  FROM user_roles as ur
+				  WHERE ur.user_id = :id AND ` + activeGrantCondition + `
+				  UNION
+				  SELECT rt.role_id, rpa.parent_id
+				  FROM role_tree as rt
+				  INNER JOIN role_parents as rpa on rt.ancestor_id = rpa.role_id
+			  )
+			  SELECT r.id, r.name, rt.ancestor_id, p.id, p.value, p.scope, p.description
+			  FROM role_tree as rt
+			  INNER JOIN roles as r on rt.role_id = r.id
+			  LEFT JOIN role_permissions as rp on rt.ancestor_id = rp.role_id
 			  LEFT JOIN permissions as p on rp.permission_id = p.id
-			  INNER JOIN user_roles as ur on r.id = ur.role_id
-			  WHERE ur.user_id = :id`
+			  ORDER BY rt.ancestor_id <> rt.role_id`
 	params := map[string]interface{}{
 		"id": userUUID,
 	}
@@ -318,7 +331,7 @@ func (r *RolePostgresRepository) ListWithPermissionsByUserId(userUUID uuid.UUID)
 	}
 	defer rows.Close()
 
-	return r.ScanAllWithPermissions(rows)
+	return r.ScanAllWithInheritedPermissions(rows)
 }
 
 // SetForUser sets the roles of a User in the repository
@@ -462,6 +475,72 @@ func (r *RolePostgresRepository) RemoveFromUsers(userUUIDs []uuid.UUID, roleUUID
 	return nil
 }
 
+// GrantToUser assigns a role to a User in the repository, replacing any previous grant of the same role
+func (r *RolePostgresRepository) GrantToUser(grant models.RoleGrant) error {
+	// Prepare query
+	query := `INSERT INTO user_roles (user_id, role_id, valid_from, valid_until, reason, granted_by)
+			  VALUES (:user_id, :role_id, :valid_from, :valid_until, :reason, :granted_by)
+			  ON CONFLICT (user_id, role_id) DO UPDATE
+			  SET valid_from = EXCLUDED.valid_from,
+			      valid_until = EXCLUDED.valid_until,
+			      reason = EXCLUDED.reason,
+			      granted_by = EXCLUDED.granted_by`
+	params := map[string]interface{}{
+		"user_id":     grant.UserID,
+		"role_id":     grant.RoleID,
+		"valid_from":  grant.ValidFrom,
+		"valid_until": grant.ValidUntil,
+		"reason":      grant.Reason,
+		"granted_by":  grant.GrantedBy,
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// ListGrantsByUserId returns all the role grants of a User, the expired and upcoming ones included
+func (r *RolePostgresRepository) ListGrantsByUserId(userUUID uuid.UUID) (models.RoleGrants, error) {
+	// Prepare query
+	query := `SELECT ur.user_id, ur.role_id, ur.valid_from, ur.valid_until, ur.reason, ur.granted_by
+			  FROM user_roles as ur
+			  WHERE ur.user_id = :id
+			  ORDER BY ur.valid_from`
+	params := map[string]interface{}{
+		"id": userUUID,
+	}
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return utils.ScanAllStruct[models.RoleGrant](rows)
+}
+
+// DeleteExpiredGrants removes the role grants that have ended from the repository and returns them
+func (r *RolePostgresRepository) DeleteExpiredGrants() (models.RoleGrants, error) {
+	// Prepare query
+	query := `DELETE FROM user_roles as ur
+			  WHERE ur.valid_until IS NOT NULL AND ur.valid_until <= NOW()
+			  RETURNING ur.user_id, ur.role_id, ur.valid_from, ur.valid_until, ur.reason, ur.granted_by`
+
+	// Execute query
+	rows, err := r.conn.Queryx(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return utils.ScanAllStruct[models.RoleGrant](rows)
+}
+
 // ListUsersByRoleId returns all Users for a given Role
 func (r *RolePostgresRepository) ListUsersByRoleId(roleUUID uuid.UUID) ([]string, error) {
 	// Prepare query
@@ -482,20 +561,63 @@ func (r *RolePostgresRepository) ListUsersByRoleId(roleUUID uuid.UUID) ([]string
 	return utils.ScanAll(rows, utils.ScanString)
 }
 
-// ListUsers returns all User id's
-func (r *RolePostgresRepository) ListUsers() ([]string, error) {
+// ListActiveUsersByRoleId returns the Users currently holding a given Role
+func (r *RolePostgresRepository) ListActiveUsersByRoleId(roleUUID uuid.UUID) ([]uuid.UUID, error) {
 	// Prepare query
 	query := `SELECT DISTINCT ur.user_id
-			  FROM user_roles as ur`
+			  FROM user_roles as ur
+			  WHERE ur.role_id = :ID AND ` + activeGrantCondition
+	params := map[string]interface{}{
+		"ID": roleUUID,
+	}
 
 	// Execute query
-	rows, err := r.conn.Queryx(query)
+	rows, err := r.conn.NamedQuery(query, params)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return utils.ScanAll(rows, utils.ScanString)
+	return utils.ScanAll(rows, func(rows *sqlx.Rows) (uuid.UUID, error) {
+		var userID uuid.UUID
+		err := rows.Scan(&userID)
+		return userID, err
+	})
+}
+
+// ListByUserIds returns the roles of each of the given users in the repository.
+// Users without any role are missing from the map.
+func (r *RolePostgresRepository) ListByUserIds(userUUIDs []uuid.UUID) (map[uuid.UUID]models.Roles, error) {
+	// Prepare query
+	query := `SELECT ur.user_id, r.id, r.name
+			  FROM roles as r
+			  INNER JOIN user_roles as ur on r.id = ur.role_id
+			  WHERE ur.user_id = ANY(:ids) AND ` + activeGrantCondition + `
+			  ORDER BY r.name`
+	params := map[string]interface{}{
+		"ids": pq.Array(userUUIDs),
+	}
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userRoles, err := utils.ScanAllStruct[struct {
+		UserID uuid.UUID `db:"user_id"`
+		models.Role
+	}](rows)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[uuid.UUID]models.Roles)
+	for _, userRole := range userRoles {
+		roles[userRole.UserID] = append(roles[userRole.UserID], userRole.Role)
+	}
+	return roles, nil
 }
 
 // SetPermissionsByRoleId sets the permissions of a Role in the repository
@@ -581,14 +703,22 @@ func (r *RolePostgresRepository) ListPermissionsByRoleId(roleUUID uuid.UUID) (mo
 	return utils.ScanAllStruct[models.Permission](rows)
 }
 
-// ListPermissionsByUserId returns all Permissions for a given User
+// ListPermissionsByUserId returns all Permissions for a given User, inherited ones included
 func (r *RolePostgresRepository) ListPermissionsByUserId(userUUID uuid.UUID) (models.Permissions, error) {
 	// Prepare query
-	query := `SELECT p.id, p.value, p.scope, p.description
+	query := `WITH RECURSIVE role_tree (role_id) AS (
+				  SELECT ur.role_id
+				  FROM user_roles as ur
+				  WHERE ur.user_id = :id AND ` + activeGrantCondition + `
+				  UNION
+				  SELECT rpa.parent_id
+				  FROM role_tree as rt
+				  INNER JOIN role_parents as rpa on rt.role_id = rpa.role_id
+			  )
+			  SELECT DISTINCT p.id, p.value, p.scope, p.description
 			  FROM permissions as p
 			  INNER JOIN role_permissions as rp on p.id = rp.permission_id
-			  INNER JOIN user_roles as ur on rp.role_id = ur.role_id
-			  WHERE ur.user_id = :id`
+			  INNER JOIN role_tree as rt on rp.role_id = rt.role_id`
 	params := map[string]interface{}{
 		"id": userUUID,
 	}
@@ -603,6 +733,129 @@ func (r *RolePostgresRepository) ListPermissionsByUserId(userUUID uuid.UUID) (mo
 	return utils.ScanAllStruct[models.Permission](rows)
 }
 
+// SetParentsByRoleId sets the parent roles of a Role in the repository.
+// Returns models.ErrRoleHierarchyCycle if the parents would create a cycle in the hierarchy.
+func (r *RolePostgresRepository) SetParentsByRoleId(roleUUID uuid.UUID, parentUUIDs []uuid.UUID) error {
+
+	// Start transaction
+	ctx := context.Background()
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		zap.L().Error("Cannot start transaction", zap.Error(err))
+		return err
+	}
+
+	// Lock the hierarchy until the transaction ends, so that concurrent updates cannot create a cycle together
+	query := `LOCK TABLE role_parents IN SHARE ROW EXCLUSIVE MODE`
+	_, err = tx.ExecContext(ctx, query)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("main error: %v, rollback error: %v", err, rollbackErr)
+		}
+		return err
+	}
+
+	// Make sure the new parents do not create a cycle in the hierarchy
+	rows, err := tx.QueryContext(ctx, hierarchyQuery)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("main error: %v, rollback error: %v", err, rollbackErr)
+		}
+		return err
+	}
+	hierarchy, err := scanHierarchy(rows)
+	if err == nil && hierarchy.CreatesCycle(roleUUID, parentUUIDs) {
+		err = models.ErrRoleHierarchyCycle
+	}
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("main error: %w, rollback error: %v", err, rollbackErr)
+		}
+		return err
+	}
+
+	// Query to reset parents
+	query = `DELETE FROM role_parents WHERE role_id = $1`
+	_, err = tx.ExecContext(ctx, query, roleUUID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("main error: %v, rollback error: %v", err, rollbackErr)
+		}
+		return err
+	}
+
+	// If no parents are provided, we can commit and return
+	if len(parentUUIDs) == 0 {
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	// Prepare query to set new parents
+	query = `INSERT INTO role_parents (role_id, parent_id) VALUES `
+	var values []interface{}
+	for i, parentUUID := range parentUUIDs {
+		query += fmt.Sprintf("($%d, $%d),", i*2+1, i*2+2)
+		values = append(values, roleUUID, parentUUID)
+	}
+	query = query[:len(query)-1] // Remove the trailing comma
+
+	// Execute query
+	result, err := tx.ExecContext(ctx, query, values...)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("main error: %v, rollback error: %v", err, rollbackErr)
+		}
+		return err
+	}
+
+	// Check if all parents were set
+	if err = utils.CheckRowAffected(result, int64(len(parentUUIDs))); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("main error: %v, rollback error: %v", err, rollbackErr)
+		}
+		return err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListHierarchy returns the parent roles of every Role in the repository
+func (r *RolePostgresRepository) ListHierarchy() (models.RoleHierarchy, error) {
+	// Execute query
+	rows, err := r.conn.Query(hierarchyQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanHierarchy(rows)
+}
+
+// hierarchyQuery retrieves every parent of every role
+const hierarchyQuery = `SELECT rpa.role_id, rpa.parent_id
+			  FROM role_parents as rpa`
+
+// scanHierarchy scans the rows of hierarchyQuery into a RoleHierarchy, closing the rows
+func scanHierarchy(rows *sql.Rows) (models.RoleHierarchy, error) {
+	defer rows.Close()
+
+	hierarchy := make(models.RoleHierarchy)
+	for rows.Next() {
+		var roleUUID, parentUUID uuid.UUID
+		if err := rows.Scan(&roleUUID, &parentUUID); err != nil {
+			return nil, err
+		}
+		hierarchy[roleUUID] = append(hierarchy[roleUUID], parentUUID)
+	}
+
+	return hierarchy, rows.Err()
+}
+
 // ScanWithPermissions scans the current row of the given rows and returns a RoleWithPermissions
 func (r *RolePostgresRepository) ScanWithPermissions(rows *sqlx.Rows) (models.RoleWithPermissions, error) {
 
@@ -671,3 +924,64 @@ func (r *RolePostgresRepository) ScanAllWithPermissions(rows *sqlx.Rows) (models
 
 	return roles, nil
 }
+
+// ScanAllWithInheritedPermissions scans all rows of the given rows and returns a list of RoleWithPermissions.
+// Each row is a role, one of its ancestors (possibly itself) and one permission of the ancestor.
+// Rows of the role itself are expected first, so that a permission both direct and inherited is kept as direct.
+func (r *RolePostgresRepository) ScanAllWithInheritedPermissions(rows *sqlx.Rows) (models.RolesWithPermissions, error) {
+	roles := make(models.RolesWithPermissions, 0)
+	rolesMap := make(map[uuid.UUID]int)
+	seen := make(map[uuid.UUID]map[uuid.UUID]bool)
+
+	for rows.Next() {
+		var role models.Role
+		var ancestorUUID uuid.UUID
+		var permission models.Permission
+		var pValue sql.NullString
+		var pScope sql.NullString
+		var pDescription sql.NullString
+
+		err := rows.Scan(
+			&role.Id,
+			&role.Name,
+			&ancestorUUID,
+			&permission.Id,
+			&pValue,
+			&pScope,
+			&pDescription,
+		)
+		if err != nil {
+			return models.RolesWithPermissions{}, err
+		}
+
+		// Retrieve role from map if exists, add it otherwise
+		index, exists := rolesMap[role.Id]
+		if !exists {
+			index = len(roles)
+			rolesMap[role.Id] = index
+			seen[role.Id] = make(map[uuid.UUID]bool)
+			roles = append(roles, models.RoleWithPermissions{
+				Role:                 role,
+				Permissions:          models.Permissions{},
+				InheritedPermissions: models.Permissions{},
+			})
+		}
+
+		// Skip the rows without permission, and the permissions already granted to the role
+		if permission.Id == uuid.Nil || seen[role.Id][permission.Id] {
+			continue
+		}
+		seen[role.Id][permission.Id] = true
+
+		permission.Value = pValue.String
+		permission.Scope = pScope.String
+		permission.Description = pDescription.String
+		if ancestorUUID == role.Id {
+			roles[index].Permissions = append(roles[index].Permissions, permission)
+		} else {
+			roles[index].InheritedPermissions = append(roles[index].InheritedPermissions, permission)
+		}
+	}
+
+	return roles, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	sqlxmock "github.com/zhashkevych/go-sqlxmock"
 	"testing"
+	"time"
 )
 
 // TestRolePostgresRepository_Create test the RolePostgresRepository.Create method
@@ -16,7 +17,7 @@ func TestRolePostgresRepository_Create(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name          string
@@ -100,7 +101,7 @@ func TestRolePostgresRepository_Get(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name        string
@@ -152,7 +153,7 @@ func TestRolePostgresRepository_GetByName(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name        string
@@ -204,7 +205,7 @@ func TestRolePostgresRepository_GetWithPermissions(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name        string
@@ -256,7 +257,7 @@ func TestRolePostgresRepository_Update(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name          string
@@ -344,7 +345,7 @@ func TestRolePostgresRepository_Delete(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name      string
@@ -387,7 +388,7 @@ func TestRolePostgresRepository_List(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name        string
@@ -437,7 +438,7 @@ func TestRolePostgresRepository_ListByUserId(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name        string
@@ -490,7 +491,7 @@ func TestRolePostgresRepository_ListWithPermissions(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name        string
@@ -546,7 +547,7 @@ func TestRolePostgresRepository_ListWithPermissionsByUserId(t *testing.T) {
 	permissionID1 := uuid.New()
 	permissionID2 := uuid.New()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name         string
@@ -568,11 +569,11 @@ func TestRolePostgresRepository_ListWithPermissionsByUserId(t *testing.T) {
 			name:   "Retrieve role with permissions for user",
 			userID: uuid.New(),
 			mockSetup: func() {
-				rows := sqlxmock.NewRows([]string{"r.id", "r.name", "p.id", "p.value", "p.scope", "p.description"}).
-					AddRow(roleID1, "name1", permissionID1, "value1", "scope1", "description1").
-					AddRow(roleID1, "name1", permissionID2, "value2", "scope2", "description2").
-					AddRow(roleID2, "name2", permissionID2, "value2", "scope2", "description2")
-				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+				rows := sqlxmock.NewRows([]string{"r.id", "r.name", "rt.ancestor_id", "p.id", "p.value", "p.scope", "p.description"}).
+					AddRow(roleID1, "name1", roleID1, permissionID1, "value1", "scope1", "description1").
+					AddRow(roleID1, "name1", roleID1, permissionID2, "value2", "scope2", "description2").
+					AddRow(roleID2, "name2", roleID2, permissionID2, "value2", "scope2", "description2")
+				sqlxMock.Mock.ExpectQuery("WITH RECURSIVE").WillReturnRows(rows)
 			},
 			expectErr: false,
 			expectResult: models.RolesWithPermissions{
@@ -586,6 +587,31 @@ func TestRolePostgresRepository_ListWithPermissionsByUserId(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "Retrieve role with inherited permissions for user",
+			userID: uuid.New(),
+			mockSetup: func() {
+				rows := sqlxmock.NewRows([]string{"r.id", "r.name", "rt.ancestor_id", "p.id", "p.value", "p.scope", "p.description"}).
+					AddRow(roleID1, "name1", roleID1, permissionID1, "value1", "scope1", "description1").
+					AddRow(roleID2, "name2", roleID2, nil, nil, nil, nil).
+					AddRow(roleID1, "name1", roleID2, permissionID1, "value1", "scope1", "description1").
+					AddRow(roleID1, "name1", roleID2, permissionID2, "value2", "scope2", "description2")
+				sqlxMock.Mock.ExpectQuery("WITH RECURSIVE").WillReturnRows(rows)
+			},
+			expectErr: false,
+			expectResult: models.RolesWithPermissions{
+				{
+					Role:                 models.Role{Id: roleID1},
+					Permissions:          []models.Permission{{Id: permissionID1}},
+					InheritedPermissions: []models.Permission{{Id: permissionID2}},
+				},
+				{
+					Role:                 models.Role{Id: roleID2},
+					Permissions:          []models.Permission{},
+					InheritedPermissions: []models.Permission{},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -611,6 +637,14 @@ func TestRolePostgresRepository_ListWithPermissionsByUserId(t *testing.T) {
 						t.Errorf("ListWithPermissionsByUserId() permission ID = %v, expectResultPermissionID %v", permission.Id, tt.expectResult[i].Permissions[j].Id)
 					}
 				}
+				if len(role.InheritedPermissions) != len(tt.expectResult[i].InheritedPermissions) {
+					t.Errorf("ListWithPermissionsByUserId() inherited permissions length = %v, expectResultInheritedPermissionsLength %v", len(role.InheritedPermissions), len(tt.expectResult[i].InheritedPermissions))
+				}
+				for j, permission := range role.InheritedPermissions {
+					if permission.Id != tt.expectResult[i].InheritedPermissions[j].Id {
+						t.Errorf("ListWithPermissionsByUserId() inherited permission ID = %v, expectResultInheritedPermissionID %v", permission.Id, tt.expectResult[i].InheritedPermissions[j].Id)
+					}
+				}
 			}
 		})
 	}
@@ -622,7 +656,7 @@ func TestRolePostgresRepository_SetForUser(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name      string
@@ -673,7 +707,7 @@ func TestRolePostgresRepository_AddUsersRole(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name      string
@@ -723,7 +757,7 @@ func TestRolePostgresRepository_RemoveUsersRole(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name      string
@@ -767,13 +801,189 @@ func TestRolePostgresRepository_RemoveUsersRole(t *testing.T) {
 	}
 }
 
+// TestRolePostgresRepository_GrantToUser test the RolePostgresRepository.GrantToUser method
+func TestRolePostgresRepository_GrantToUser(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
+
+	validUntil := time.Now().Add(48 * time.Hour)
+	grant := models.RoleGrant{
+		UserID:     uuid.New(),
+		RoleID:     uuid.New(),
+		ValidFrom:  time.Now(),
+		ValidUntil: &validUntil,
+		Reason:     "support ticket",
+		GrantedBy:  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+	}
+
+	tests := []struct {
+		name      string
+		grant     models.RoleGrant
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:  "Fail to grant role",
+			grant: grant,
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("INSERT INTO user_roles").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:  "No row affected",
+			grant: grant,
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("INSERT INTO user_roles").WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			expectErr: true,
+		},
+		{
+			name:  "Grant role",
+			grant: grant,
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("INSERT INTO user_roles").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().R().GrantToUser(tt.grant)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("GrantToUser() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestRolePostgresRepository_ListGrantsByUserId test the RolePostgresRepository.ListGrantsByUserId method
+func TestRolePostgresRepository_ListGrantsByUserId(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
+
+	columns := []string{"user_id", "role_id", "valid_from", "valid_until", "reason", "granted_by"}
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		userID      uuid.UUID
+		mockSetup   func()
+		expectErr   bool
+		expectCount int
+	}{
+		{
+			name:   "Fail grants retrieval",
+			userID: userID,
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
+			},
+			expectErr:   true,
+			expectCount: 0,
+		},
+		{
+			name:   "Retrieve grants",
+			userID: userID,
+			mockSetup: func() {
+				rows := sqlxmock.NewRows(columns).
+					AddRow(userID, uuid.New(), time.Now(), nil, "", nil).
+					AddRow(userID, uuid.New(), time.Now(), time.Now().Add(time.Hour), "support ticket", uuid.New())
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			expectErr:   false,
+			expectCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			grants, err := repositories.R().R().ListGrantsByUserId(tt.userID)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ListGrantsByUserId() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if len(grants) != tt.expectCount {
+				t.Errorf("ListGrantsByUserId() count = %v, expectCount %v", len(grants), tt.expectCount)
+			}
+		})
+	}
+}
+
+// TestRolePostgresRepository_DeleteExpiredGrants test the RolePostgresRepository.DeleteExpiredGrants method
+func TestRolePostgresRepository_DeleteExpiredGrants(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
+
+	columns := []string{"user_id", "role_id", "valid_from", "valid_until", "reason", "granted_by"}
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectErr   bool
+		expectCount int
+	}{
+		{
+			name: "Fail to delete expired grants",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("DELETE FROM user_roles").WillReturnError(errors.New("error"))
+			},
+			expectErr:   true,
+			expectCount: 0,
+		},
+		{
+			name: "No expired grants",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("DELETE FROM user_roles").WillReturnRows(sqlxmock.NewRows(columns))
+			},
+			expectErr:   false,
+			expectCount: 0,
+		},
+		{
+			name: "Delete expired grants",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows(columns).
+					AddRow(uuid.New(), uuid.New(), time.Now().Add(-time.Hour), time.Now(), "support ticket", uuid.New())
+				sqlxMock.Mock.ExpectQuery("DELETE FROM user_roles").WillReturnRows(rows)
+			},
+			expectErr:   false,
+			expectCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			grants, err := repositories.R().R().DeleteExpiredGrants()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("DeleteExpiredGrants() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if len(grants) != tt.expectCount {
+				t.Errorf("DeleteExpiredGrants() count = %v, expectCount %v", len(grants), tt.expectCount)
+			}
+		})
+	}
+}
+
 // TestRolePostgresRepository_ListUsersByRoleId test the RolePostgresRepository.ListUsersByRoleId method
 func TestRolePostgresRepository_ListUsersByRoleId(t *testing.T) {
 	var sqlxMock test.Sqlx
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name        string
@@ -820,24 +1030,22 @@ func TestRolePostgresRepository_ListUsersByRoleId(t *testing.T) {
 	}
 }
 
-// TestRolePostgresRepository_ListUsers test the RolePostgresRepository.ListUsers method
-func TestRolePostgresRepository_ListUsers(t *testing.T) {
+// TestRolePostgresRepository_ListActiveUsersByRoleId test the RolePostgresRepository.ListActiveUsersByRoleId method
+func TestRolePostgresRepository_ListActiveUsersByRoleId(t *testing.T) {
 	var sqlxMock test.Sqlx
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name        string
-		roleID      uuid.UUID
 		mockSetup   func()
 		expectErr   bool
 		expectCount int
 	}{
 		{
-			name:   "Fail users retrieval",
-			roleID: uuid.New(),
+			name: "Fail users retrieval",
 			mockSetup: func() {
 				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
 			},
@@ -845,10 +1053,9 @@ func TestRolePostgresRepository_ListUsers(t *testing.T) {
 			expectCount: 0,
 		},
 		{
-			name:   "Retrieve users",
-			roleID: uuid.New(),
+			name: "Retrieve users",
 			mockSetup: func() {
-				rows := sqlxmock.NewRows([]string{"id"}).
+				rows := sqlxmock.NewRows([]string{"user_id"}).
 					AddRow(uuid.New()).
 					AddRow(uuid.New())
 				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
@@ -861,13 +1068,72 @@ func TestRolePostgresRepository_ListUsers(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			p, err := repositories.R().R().ListUsers()
+			users, err := repositories.R().R().ListActiveUsersByRoleId(uuid.New())
 			if (err != nil) != tt.expectErr {
-				t.Errorf("GetAllByRoleId() error = %v, expectErr %v", err, tt.expectErr)
+				t.Errorf("ListActiveUsersByRoleId() error = %v, expectErr %v", err, tt.expectErr)
 				return
 			}
-			if len(p) != tt.expectCount {
-				t.Errorf("GetAllByRoleId() count = %v, expectCount %v", len(p), tt.expectCount)
+			if len(users) != tt.expectCount {
+				t.Errorf("ListActiveUsersByRoleId() count = %v, expectCount %v", len(users), tt.expectCount)
+			}
+		})
+	}
+}
+
+// TestRolePostgresRepository_ListByUserIds test the RolePostgresRepository.ListByUserIds method
+func TestRolePostgresRepository_ListByUserIds(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
+
+	userID := uuid.New()
+	otherUserID := uuid.New()
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectErr   bool
+		expectRoles map[uuid.UUID]int
+	}{
+		{
+			name: "Fail roles retrieval",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
+			},
+			expectErr:   true,
+			expectRoles: map[uuid.UUID]int{},
+		},
+		{
+			name: "Retrieve roles",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows([]string{"user_id", "id", "name"}).
+					AddRow(userID, uuid.New(), "admin").
+					AddRow(userID, uuid.New(), "support").
+					AddRow(otherUserID, uuid.New(), "admin")
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			expectErr:   false,
+			expectRoles: map[uuid.UUID]int{userID: 2, otherUserID: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			roles, err := repositories.R().R().ListByUserIds([]uuid.UUID{userID, otherUserID})
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ListByUserIds() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if len(roles) != len(tt.expectRoles) {
+				t.Errorf("ListByUserIds() users = %v, expectRoles %v", len(roles), len(tt.expectRoles))
+			}
+			for id, count := range tt.expectRoles {
+				if len(roles[id]) != count {
+					t.Errorf("ListByUserIds() roles = %v, expectRoles %v", len(roles[id]), count)
+				}
 			}
 		})
 	}
@@ -879,7 +1145,7 @@ func TestRolePostgresRepository_SetPermissionsByRoleId(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name          string
@@ -976,7 +1242,7 @@ func TestRolePostgresRepository_ListPermissionsByRoleId(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name        string
@@ -1026,7 +1292,7 @@ func TestRolePostgresRepository_ListPermissionsByUserId(t *testing.T) {
 	sqlxMock.CreateFullTestSqlx(t)
 	defer sqlxMock.CleanTestSqlx()
 
-	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil))
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
 
 	tests := []struct {
 		name        string
@@ -1069,3 +1335,212 @@ func TestRolePostgresRepository_ListPermissionsByUserId(t *testing.T) {
 		})
 	}
 }
+
+// TestRolePostgresRepository_SetParentsByRoleId tests the RolePostgresRepository.SetParentsByRoleId method
+func TestRolePostgresRepository_SetParentsByRoleId(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
+
+	roleID := uuid.New()
+	parentID := uuid.New()
+
+	tests := []struct {
+		name      string
+		parentIDs []uuid.UUID
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:      "Fail to lock the hierarchy",
+			parentIDs: []uuid.UUID{parentID},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("LOCK TABLE role_parents").WillReturnError(errors.New("error"))
+				sqlxMock.Mock.ExpectRollback()
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Fail to retrieve the hierarchy",
+			parentIDs: []uuid.UUID{parentID},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("LOCK TABLE role_parents").WillReturnResult(sqlxmock.NewResult(0, 0))
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
+				sqlxMock.Mock.ExpectRollback()
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Parents create a cycle",
+			parentIDs: []uuid.UUID{parentID},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("LOCK TABLE role_parents").WillReturnResult(sqlxmock.NewResult(0, 0))
+				rows := sqlxmock.NewRows([]string{"role_id", "parent_id"}).AddRow(parentID, roleID)
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+				sqlxMock.Mock.ExpectRollback()
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Fail to delete existing parents",
+			parentIDs: []uuid.UUID{uuid.New(), uuid.New()},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("LOCK TABLE role_parents").WillReturnResult(sqlxmock.NewResult(0, 0))
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(sqlxmock.NewRows([]string{"role_id", "parent_id"}))
+				sqlxMock.Mock.ExpectExec("DELETE FROM role_parents").WillReturnError(errors.New("error"))
+				sqlxMock.Mock.ExpectRollback()
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Set role parents with empty parents",
+			parentIDs: []uuid.UUID{},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("LOCK TABLE role_parents").WillReturnResult(sqlxmock.NewResult(0, 0))
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(sqlxmock.NewRows([]string{"role_id", "parent_id"}))
+				sqlxMock.Mock.ExpectExec("DELETE FROM role_parents").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectCommit()
+			},
+			expectErr: false,
+		},
+		{
+			name:      "Fail to insert new parents",
+			parentIDs: []uuid.UUID{uuid.New(), uuid.New()},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("LOCK TABLE role_parents").WillReturnResult(sqlxmock.NewResult(0, 0))
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(sqlxmock.NewRows([]string{"role_id", "parent_id"}))
+				sqlxMock.Mock.ExpectExec("DELETE FROM role_parents").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectExec("INSERT INTO role_parents").WillReturnError(errors.New("error"))
+				sqlxMock.Mock.ExpectRollback()
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Fail to set every parent",
+			parentIDs: []uuid.UUID{uuid.New(), uuid.New()},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("LOCK TABLE role_parents").WillReturnResult(sqlxmock.NewResult(0, 0))
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(sqlxmock.NewRows([]string{"role_id", "parent_id"}))
+				sqlxMock.Mock.ExpectExec("DELETE FROM role_parents").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectExec("INSERT INTO role_parents").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectRollback()
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Fail to commit transaction",
+			parentIDs: []uuid.UUID{uuid.New(), uuid.New()},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("LOCK TABLE role_parents").WillReturnResult(sqlxmock.NewResult(0, 0))
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(sqlxmock.NewRows([]string{"role_id", "parent_id"}))
+				sqlxMock.Mock.ExpectExec("DELETE FROM role_parents").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectExec("INSERT INTO role_parents").WillReturnResult(sqlxmock.NewResult(1, 2))
+				sqlxMock.Mock.ExpectCommit().WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Set role parents",
+			parentIDs: []uuid.UUID{uuid.New(), uuid.New()},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("LOCK TABLE role_parents").WillReturnResult(sqlxmock.NewResult(0, 0))
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(sqlxmock.NewRows([]string{"role_id", "parent_id"}))
+				sqlxMock.Mock.ExpectExec("DELETE FROM role_parents").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectExec("INSERT INTO role_parents").WillReturnResult(sqlxmock.NewResult(1, 2))
+				sqlxMock.Mock.ExpectCommit()
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().R().SetParentsByRoleId(roleID, tt.parentIDs)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("SetParentsByRoleId() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestRolePostgresRepository_ListHierarchy tests the RolePostgresRepository.ListHierarchy method
+func TestRolePostgresRepository_ListHierarchy(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	// Mock data
+	roleID := uuid.New()
+	parentID1 := uuid.New()
+	parentID2 := uuid.New()
+
+	repositories.ReplaceGlobals(repositories.NewRepository(repositories.NewRolePostgresRepository(sqlxMock.DB), nil, nil, nil))
+
+	tests := []struct {
+		name         string
+		mockSetup    func()
+		expectErr    bool
+		expectResult models.RoleHierarchy
+	}{
+		{
+			name: "Fail hierarchy retrieval",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
+			},
+			expectErr:    true,
+			expectResult: nil,
+		},
+		{
+			name: "Retrieve hierarchy",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows([]string{"role_id", "parent_id"}).
+					AddRow(roleID, parentID1).
+					AddRow(roleID, parentID2).
+					AddRow(parentID1, parentID2)
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			expectErr: false,
+			expectResult: models.RoleHierarchy{
+				roleID:    {parentID1, parentID2},
+				parentID1: {parentID2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			h, err := repositories.R().R().ListHierarchy()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ListHierarchy() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if len(h) != len(tt.expectResult) {
+				t.Errorf("ListHierarchy() length = %v, expectResultLength %v", len(h), len(tt.expectResult))
+			}
+			for roleID, parentIDs := range tt.expectResult {
+				if len(h[roleID]) != len(parentIDs) {
+					t.Errorf("ListHierarchy() parents length = %v, expectResultParentsLength %v", len(h[roleID]), len(parentIDs))
+					continue
+				}
+				for i, parentID := range parentIDs {
+					if h[roleID][i] != parentID {
+						t.Errorf("ListHierarchy() parent ID = %v, expectResultParentID %v", h[roleID][i], parentID)
+					}
+				}
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ type RoleRepository interface {
 	Delete(uuid uuid.UUID) error
 	List() (models.Roles, error)
 	ListByUserId(userUUID uuid.UUID) (models.Roles, error)
+	ListByUserIds(userUUIDs []uuid.UUID) (map[uuid.UUID]models.Roles, error)
 	ListWithPermissions() (models.RolesWithPermissions, error)
 	ListWithPermissionsByUserId(userUUID uuid.UUID) (models.RolesWithPermissions, error)
 
@@ -24,10 +25,17 @@ type RoleRepository interface {
 	AddToUsers(userUUIDs []uuid.UUID, id uuid.UUID) error
 	RemoveFromUsers(userUUIDs []uuid.UUID, roleUUID uuid.UUID) error
 
+	GrantToUser(grant models.RoleGrant) error
+	ListGrantsByUserId(userUUID uuid.UUID) (models.RoleGrants, error)
+	DeleteExpiredGrants() (models.RoleGrants, error)
+
 	ListUsersByRoleId(roleUUID uuid.UUID) ([]string, error)
-	ListUsers() ([]string, error)
+	ListActiveUsersByRoleId(roleUUID uuid.UUID) ([]uuid.UUID, error)
 
 	SetPermissionsByRoleId(roleUUID uuid.UUID, permissionUUIDs []uuid.UUID) error
 	ListPermissionsByRoleId(roleUUID uuid.UUID) (models.Permissions, error)
 	ListPermissionsByUserId(userUUID uuid.UUID) (models.Permissions, error)
+
+	SetParentsByRoleId(roleUUID uuid.UUID, parentUUIDs []uuid.UUID) error
+	ListHierarchy() (models.RoleHierarchy, error)
 }
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// AuditService is the implementation of the AuditService interface.
+type AuditService struct {
+	securitypb.UnimplementedAuditServiceServer
+}
+
+// WriteAuditLog implements the WriteAuditLog RPC method.
+// Entries are written by the microservices themselves, hence no permission is required.
+func (s *AuditService) WriteAuditLog(ctx context.Context, req *securitypb.WriteAuditLogRequest) (*securitypb.WriteAuditLogResponse, error) {
+	// Construct the AuditLog object from the request
+	entry := models.AuditLog{
+		Action:     req.GetAction(),
+		Outcome:    req.GetOutcome(),
+		TargetType: req.GetTargetType(),
+		TargetID:   req.GetTargetId(),
+		RequestID:  req.GetRequestId(),
+		IPAddress:  req.GetIpAddress(),
+		Details:    req.GetDetails(),
+	}
+
+	// Parse the actor ID from the request, if any
+	if req.GetActorId() != "" {
+		actorID, err := uuid.Parse(req.GetActorId())
+		if err != nil {
+			zap.L().Error("Invalid actor ID", zap.String("actor_id", req.GetActorId()), zap.Error(err))
+			return &securitypb.WriteAuditLogResponse{}, status.Error(codes.InvalidArgument, "Invalid actor ID")
+		}
+		entry.ActorID = uuid.NullUUID{UUID: actorID, Valid: true}
+	}
+
+	if ok, err := entry.IsValid(); !ok {
+		zap.L().Warn("Audit log is not valid", zap.Error(err))
+		return &securitypb.WriteAuditLogResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Append the entry to the audit log
+	id, err := repositories.R().A().Create(entry)
+	if err != nil {
+		zap.L().Error("Cannot write audit log", zap.String("action", entry.Action), zap.Error(err))
+		return &securitypb.WriteAuditLogResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &securitypb.WriteAuditLogResponse{
+		Id: id.String(),
+	}, nil
+}
+
+// ListAuditLogs implements the ListAuditLogs RPC method.
+func (s *AuditService) ListAuditLogs(ctx context.Context, req *securitypb.ListAuditLogsRequest) (*securitypb.ListAuditLogsResponse, error) {
+	// Users can list the entries they are the actor or the target of, any other search requires the permission
+	var subjects []uuid.UUID
+	if actorID, err := uuid.Parse(req.GetActorId()); err == nil {
+		subjects = append(subjects, actorID)
+	} else if targetID, err := uuid.Parse(req.GetTargetId()); err == nil && req.GetTargetType() == models.AuditTargetUser {
+		subjects = append(subjects, targetID)
+	}
+
+	// Check user permissions
+	err := security.Facade().CheckPermission(ctx, "admin.audit.list", subjects...)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &securitypb.ListAuditLogsResponse{}, err
+	}
+
+	// Construct the filter from the request
+	filter := models.AuditLogFilter{
+		Action:     req.GetAction(),
+		Outcome:    req.GetOutcome(),
+		TargetType: req.GetTargetType(),
+		TargetID:   req.GetTargetId(),
+		RequestID:  req.GetRequestId(),
+		Limit:      int(req.GetLimit()),
+		Offset:     int(req.GetOffset()),
+	}
+	if req.GetActorId() != "" {
+		actorID, err := uuid.Parse(req.GetActorId())
+		if err != nil {
+			zap.L().Error("Invalid actor ID", zap.String("actor_id", req.GetActorId()), zap.Error(err))
+			return &securitypb.ListAuditLogsResponse{}, status.Error(codes.InvalidArgument, "Invalid actor ID")
+		}
+		filter.ActorID = uuid.NullUUID{UUID: actorID, Valid: true}
+	}
+	if req.GetFrom() != nil {
+		from := req.GetFrom().AsTime()
+		filter.From = &from
+	}
+	if req.GetTo() != nil {
+		to := req.GetTo().AsTime()
+		filter.To = &to
+	}
+
+	if ok, err := filter.IsValid(); !ok {
+		zap.L().Warn("Audit log filter is not valid", zap.Error(err))
+		return &securitypb.ListAuditLogsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Search the audit log
+	logs, err := repositories.R().A().Search(filter)
+	if err != nil {
+		zap.L().Error("Cannot search audit logs", zap.Error(err))
+		return &securitypb.ListAuditLogsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &securitypb.ListAuditLogsResponse{
+		Logs: mappers.AuditLogsToProto(logs),
+	}, nil
+}
+
+// SweepAuditLogs removes the audit log entries older than the retention period
+func SweepAuditLogs(retention time.Duration) {
+	deleted, err := repositories.R().A().DeleteOlderThan(time.Now().Add(-retention))
+	if err != nil {
+		zap.L().Error("Cannot delete expired audit logs", zap.Error(err))
+		return
+	}
+	if deleted > 0 {
+		zap.L().Info("Audit logs expired", zap.Int64("count", deleted), zap.Duration("retention", retention))
+	}
+}
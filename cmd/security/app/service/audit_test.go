@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestAuditService_WriteAuditLog(t *testing.T) {
+	service := &AuditService{}
+	actorID := uuid.New()
+	validRequest := &securitypb.WriteAuditLogRequest{
+		Action:     models.AuditActionLogin,
+		Outcome:    models.AuditOutcomeSuccess,
+		ActorId:    actorID.String(),
+		TargetType: models.AuditTargetUser,
+		TargetId:   actorID.String(),
+		RequestId:  "req-1",
+		IpAddress:  "203.0.113.7",
+		Details:    map[string]string{"email": "user@example.com"},
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *securitypb.WriteAuditLogRequest
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse actor ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ar := mocks.NewSecurityAuditRepository(ctrl)
+				ar.EXPECT().Create(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+			},
+			request: &securitypb.WriteAuditLogRequest{
+				Action:  models.AuditActionLogin,
+				Outcome: models.AuditOutcomeSuccess,
+				ActorId: "bad-uuid",
+			},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to validate entry",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ar := mocks.NewSecurityAuditRepository(ctrl)
+				ar.EXPECT().Create(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+			},
+			request: &securitypb.WriteAuditLogRequest{
+				Action:  models.AuditActionLogin,
+				Outcome: "unknown",
+			},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to write entry",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ar := mocks.NewSecurityAuditRepository(ctrl)
+				ar.EXPECT().Create(gomock.Any()).Return(uuid.Nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ar := mocks.NewSecurityAuditRepository(ctrl)
+				ar.EXPECT().Create(models.AuditLog{
+					Action:     models.AuditActionLogin,
+					Outcome:    models.AuditOutcomeSuccess,
+					ActorID:    uuid.NullUUID{UUID: actorID, Valid: true},
+					TargetType: models.AuditTargetUser,
+					TargetID:   actorID.String(),
+					RequestID:  "req-1",
+					IPAddress:  "203.0.113.7",
+					Details:    models.AuditDetails{"email": "user@example.com"},
+				}).Return(uuid.New(), nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.WriteAuditLog(context.Background(), tt.request)
+
+			// Handle errors
+			if tt.expectedErrCode == codes.OK {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, response.GetId())
+				return
+			}
+			s, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expectedErrCode, s.Code())
+		})
+	}
+}
+
+func TestAuditService_ListAuditLogs(t *testing.T) {
+	service := &AuditService{}
+	actorID := uuid.New()
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 9, 0, 0, 0, 0, time.UTC)
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *securitypb.ListAuditLogsRequest
+		expectedCount   int
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				ar := mocks.NewSecurityAuditRepository(ctrl)
+				ar.EXPECT().Search(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+			},
+			request:         &securitypb.ListAuditLogsRequest{},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to parse actor ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				ar := mocks.NewSecurityAuditRepository(ctrl)
+				ar.EXPECT().Search(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+			},
+			request:         &securitypb.ListAuditLogsRequest{ActorId: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to validate filter",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				ar := mocks.NewSecurityAuditRepository(ctrl)
+				ar.EXPECT().Search(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+			},
+			request:         &securitypb.ListAuditLogsRequest{From: timestamppb.New(to), To: timestamppb.New(from)},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to search audit logs",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				ar := mocks.NewSecurityAuditRepository(ctrl)
+				ar.EXPECT().Search(gomock.Any()).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+			},
+			request:         &securitypb.ListAuditLogsRequest{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				ar := mocks.NewSecurityAuditRepository(ctrl)
+				ar.EXPECT().Search(models.AuditLogFilter{
+					Action:  models.AuditActionPermissionDenied,
+					ActorID: uuid.NullUUID{UUID: actorID, Valid: true},
+					From:    &from,
+					To:      &to,
+					Limit:   10,
+				}).Return(models.AuditLogs{
+					{ID: uuid.New(), Action: models.AuditActionPermissionDenied, Outcome: models.AuditOutcomeDenied},
+					{ID: uuid.New(), Action: models.AuditActionPermissionDenied, Outcome: models.AuditOutcomeDenied},
+				}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+			},
+			request: &securitypb.ListAuditLogsRequest{
+				Action:  models.AuditActionPermissionDenied,
+				ActorId: actorID.String(),
+				From:    timestamppb.New(from),
+				To:      timestamppb.New(to),
+				Limit:   10,
+			},
+			expectedCount:   2,
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "checks the permission against the targeted user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), &securitypb.CheckPermissionRequest{
+					Permission: "admin.audit.list",
+					UserId:     actorID.String(),
+				}).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				ar := mocks.NewSecurityAuditRepository(ctrl)
+				ar.EXPECT().Search(gomock.Any()).Return(models.AuditLogs{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+			},
+			request: &securitypb.ListAuditLogsRequest{
+				TargetType: models.AuditTargetUser,
+				TargetId:   actorID.String(),
+			},
+			expectedCount:   0,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			ctx := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{UserID: uuid.New()})
+			response, err := service.ListAuditLogs(ctx, tt.request)
+
+			// Handle errors
+			if tt.expectedErrCode == codes.OK {
+				assert.NoError(t, err)
+				assert.Len(t, response.GetLogs(), tt.expectedCount)
+				return
+			}
+			s, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expectedErrCode, s.Code())
+		})
+	}
+}
+
+func TestSweepAuditLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ar := mocks.NewSecurityAuditRepository(ctrl)
+	repositories.ReplaceGlobals(repositories.NewRepository(nil, nil, nil, ar))
+
+	// Failures are only logged
+	ar.EXPECT().DeleteOlderThan(gomock.Any()).Return(int64(0), errors.New("error"))
+	SweepAuditLogs(time.Hour)
+
+	// Entries older than the retention are removed
+	before := time.Now().Add(-24 * time.Hour)
+	ar.EXPECT().DeleteOlderThan(gomock.Any()).DoAndReturn(func(threshold time.Time) (int64, error) {
+		assert.WithinDuration(t, before, threshold, time.Minute)
+		return 3, nil
+	})
+	SweepAuditLogs(24 * time.Hour)
+}
+
+// TestAuditedEvents tests that the security events are recorded in the audit log, with the caller and request information
+func TestAuditedEvents(t *testing.T) {
+	callerID := uuid.New()
+	roleID := uuid.New()
+	info := grpcutil.RequestInfo{RequestID: "req-1", IPAddress: "203.0.113.7"}
+	outgoing, _ := metadata.FromOutgoingContext(grpcutil.AppendRequestInfoToOutgoingContext(context.Background(), info))
+	ctx := grpcutil.ContextWithPrincipal(metadata.NewIncomingContext(context.Background(), outgoing), grpcutil.Principal{UserID: callerID})
+
+	audit.ReplaceGlobals(audit.NewAuditFacade(&AuditService{}))
+	defer audit.ReplaceGlobals(nil)
+
+	t.Run("role deletion", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+		publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+		security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+		rr := mocks.NewSecurityRoleRepository(ctrl)
+		rr.EXPECT().Delete(roleID).Return(nil)
+		ar := mocks.NewSecurityAuditRepository(ctrl)
+		ar.EXPECT().Create(models.AuditLog{
+			Action:     models.AuditActionRoleDelete,
+			Outcome:    models.AuditOutcomeSuccess,
+			ActorID:    uuid.NullUUID{UUID: callerID, Valid: true},
+			TargetType: models.AuditTargetRole,
+			TargetID:   roleID.String(),
+			RequestID:  info.RequestID,
+			IPAddress:  info.IPAddress,
+		}).Return(uuid.New(), nil)
+		repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, ar))
+
+		_, err := (&Service{}).DeleteRole(ctx, &securitypb.DeleteRoleRequest{Id: roleID.String()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("denied permission check", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		rr := mocks.NewSecurityRoleRepository(ctrl)
+		rr.EXPECT().ListWithPermissionsByUserId(callerID).Return(models.RolesWithPermissions{}, nil)
+		ar := mocks.NewSecurityAuditRepository(ctrl)
+		ar.EXPECT().Create(models.AuditLog{
+			Action:    models.AuditActionPermissionDenied,
+			Outcome:   models.AuditOutcomeDenied,
+			ActorID:   uuid.NullUUID{UUID: callerID, Valid: true},
+			RequestID: info.RequestID,
+			IPAddress: info.IPAddress,
+			Details:   models.AuditDetails{"permission": "admin.roles.delete"},
+		}).Return(uuid.New(), nil)
+		repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, ar))
+
+		_, err := (&PublicService{}).CheckPermission(ctx, &securitypb.CheckPermissionRequest{Permission: "admin.roles.delete"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("audit failure does not break the operation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+		publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+		security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+		rr := mocks.NewSecurityRoleRepository(ctrl)
+		rr.EXPECT().Delete(roleID).Return(nil)
+		ar := mocks.NewSecurityAuditRepository(ctrl)
+		ar.EXPECT().Create(gomock.Any()).Return(uuid.Nil, errors.New("error"))
+		repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, ar))
+
+		_, err := (&Service{}).DeleteRole(ctx, &securitypb.DeleteRoleRequest{Id: roleID.String()})
+		assert.NoError(t, err)
+	})
+}
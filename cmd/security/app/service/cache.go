@@ -0,0 +1,113 @@
+package service
+
+import (
+	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+// userSubject returns the user as a policy subject : the names of its roles and its effective permissions.
+// They are read from the permission cache when enabled, and cached after being read from the database.
+// Cache failures are logged but never fail the request, the database stays the source of truth.
+func userSubject(userID uuid.UUID) (security.Subject, error) {
+	cache := repositories.R().C()
+
+	if cache != nil {
+		access, found, err := cache.Get(userID)
+		if err != nil {
+			zap.L().Error("Cannot get cached permissions", zap.String("uuid", userID.String()), zap.Error(err))
+		} else if found {
+			return security.Subject{
+				ID:          userID,
+				Roles:       access.Roles,
+				Permissions: models.PermissionsFromValues(access.Permissions),
+			}, nil
+		}
+	}
+
+	roles, err := repositories.R().R().ListWithPermissionsByUserId(userID)
+	if err != nil {
+		return security.Subject{}, err
+	}
+	subject, err := subjectFromRoles(userID, roles)
+	if err != nil {
+		return security.Subject{}, err
+	}
+
+	if cache != nil {
+		// The access changes as soon as one of the grants starts or ends, it must not be cached past that date
+		grants, err := repositories.R().R().ListGrantsByUserId(userID)
+		if err != nil {
+			zap.L().Error("Cannot list grants", zap.String("uuid", userID.String()), zap.Error(err))
+			return subject, nil
+		}
+
+		err = cache.Set(userID, models.UserAccess{
+			Roles:       subject.Roles,
+			Permissions: subject.Permissions.GetValues(),
+		}, grants.NextBoundary(time.Now()))
+		if err != nil {
+			zap.L().Error("Cannot cache permissions", zap.String("uuid", userID.String()), zap.Error(err))
+		}
+	}
+
+	return subject, nil
+}
+
+// subjectFromRoles builds the policy subject of the user, including the roles inherited from the hierarchy
+func subjectFromRoles(userID uuid.UUID, roles models.RolesWithPermissions) (security.Subject, error) {
+	// Without any role there is nothing to inherit
+	if len(roles) == 0 {
+		return security.SubjectFromRoles(userID, roles, nil, nil), nil
+	}
+
+	hierarchy, err := repositories.R().R().ListHierarchy()
+	if err != nil {
+		return security.Subject{}, err
+	}
+	all, err := repositories.R().R().List()
+	if err != nil {
+		return security.Subject{}, err
+	}
+
+	return security.SubjectFromRoles(userID, roles, hierarchy, all), nil
+}
+
+// listUserPermissions returns the effective permissions of the user, from the cache when available
+func listUserPermissions(userID uuid.UUID) (models.Permissions, error) {
+	subject, err := userSubject(userID)
+	if err != nil {
+		return nil, err
+	}
+	return subject.Permissions, nil
+}
+
+// invalidateUsersPermissions invalidates the cached permissions of the users whose roles changed
+func invalidateUsersPermissions(userIDs []uuid.UUID) {
+	cache := repositories.R().C()
+	if cache == nil {
+		return
+	}
+
+	err := cache.Invalidate(userIDs)
+	if err != nil {
+		zap.L().Error("Cannot invalidate cached permissions", zap.Error(err))
+	}
+}
+
+// invalidateAllPermissions invalidates the cached permissions of every user.
+// Used when roles or permissions themselves change, which is rare enough not to track the affected users.
+func invalidateAllPermissions() {
+	cache := repositories.R().C()
+	if cache == nil {
+		return
+	}
+
+	err := cache.InvalidateAll()
+	if err != nil {
+		zap.L().Error("Cannot invalidate all cached permissions", zap.Error(err))
+	}
+}
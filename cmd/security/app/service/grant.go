@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// GrantRoleToUser implements the GrantRoleToUser RPC method.
+// The grant replaces any previous grant of the same role to the user.
+func (s *Service) GrantRoleToUser(ctx context.Context, req *securitypb.GrantRoleToUserRequest) (*securitypb.GrantRoleToUserResponse, error) {
+	// Check user permissions
+	err := security.Facade().CheckPermission(ctx, "admin.users.roles.update")
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &securitypb.GrantRoleToUserResponse{}, err
+	}
+
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &securitypb.GrantRoleToUserResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Parse the role ID from the request
+	roleID, err := uuid.Parse(req.GetRoleId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid role ID", zap.String("role_id", req.GetRoleId()), zap.Error(err))
+		return &securitypb.GrantRoleToUserResponse{}, status.Error(codes.InvalidArgument, "Invalid role ID")
+	}
+
+	// Construct the grant from the request : it starts right away unless told otherwise
+	grant := models.RoleGrant{
+		UserID:    userID,
+		RoleID:    roleID,
+		ValidFrom: time.Now(),
+		Reason:    req.GetReason(),
+	}
+	if req.GetValidFrom() != nil {
+		grant.ValidFrom = req.GetValidFrom().AsTime()
+	}
+	if req.GetValidUntil() != nil {
+		validUntil := req.GetValidUntil().AsTime()
+		grant.ValidUntil = &validUntil
+	}
+
+	// Keep track of the granting user
+	if principal, ok := grpcutil.PrincipalFromContext(ctx); ok {
+		grant.GrantedBy = uuid.NullUUID{UUID: principal.UserID, Valid: true}
+	}
+
+	if ok, err := grant.IsValid(); !ok {
+		zap.L().Warn("Role grant is not valid", zap.Error(err))
+		return &securitypb.GrantRoleToUserResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Check the role exists
+	_, found, err := repositories.R().R().Get(roleID)
+	if err != nil {
+		zap.L().Error("Cannot get role", zap.String("uuid", roleID.String()), zap.Error(err))
+		return &securitypb.GrantRoleToUserResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Debug("Role not found", zap.String("uuid", roleID.String()))
+		return &securitypb.GrantRoleToUserResponse{}, status.Error(codes.NotFound, "Role not found")
+	}
+
+	// Grant the role in the database
+	err = repositories.R().R().GrantToUser(grant)
+	if err != nil {
+		zap.L().Error("GrantRoleToUser", zap.Error(err))
+		return &securitypb.GrantRoleToUserResponse{}, status.Error(codes.Internal, "Failed to grant role to user")
+	}
+
+	// Invalidate the cached permissions
+	invalidateUsersPermissions([]uuid.UUID{userID})
+
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionUserRoleGrant,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetUser,
+		TargetID:   userID.String(),
+		Details:    models.AuditDetails{"role_id": roleID.String(), "reason": grant.Reason},
+	})
+
+	zap.L().Info("Role granted",
+		zap.String("user_id", userID.String()),
+		zap.String("role_id", roleID.String()),
+		zap.Time("valid_from", grant.ValidFrom),
+		zap.Timep("valid_until", grant.ValidUntil),
+		zap.String("reason", grant.Reason),
+		zap.String("granted_by", grant.GrantedBy.UUID.String()))
+
+	return &securitypb.GrantRoleToUserResponse{
+		Grant: mappers.RoleGrantToProto(grant),
+	}, nil
+}
+
+// ListRoleGrantsForUser implements the ListRoleGrantsForUser RPC method.
+func (s *Service) ListRoleGrantsForUser(ctx context.Context, req *securitypb.ListRoleGrantsForUserRequest) (*securitypb.ListRoleGrantsForUserResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &securitypb.ListRoleGrantsForUserResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.roles.list", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &securitypb.ListRoleGrantsForUserResponse{}, err
+	}
+
+	// Get all role grants for user from the database
+	grants, err := repositories.R().R().ListGrantsByUserId(userID)
+	if err != nil {
+		zap.L().Error("Cannot list role grants", zap.Error(err))
+		return &securitypb.ListRoleGrantsForUserResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &securitypb.ListRoleGrantsForUserResponse{
+		Grants: mappers.RoleGrantsToProto(grants),
+	}, nil
+}
+
+// SweepExpiredRoleGrants removes the role grants that have ended and invalidates the permissions of their users.
+// Expired grants are already ignored when computing permissions, the sweep keeps the assignments clean and traced.
+func SweepExpiredRoleGrants() {
+	grants, err := repositories.R().R().DeleteExpiredGrants()
+	if err != nil {
+		zap.L().Error("Cannot delete expired role grants", zap.Error(err))
+		return
+	}
+	if len(grants) == 0 {
+		return
+	}
+
+	for _, grant := range grants {
+		zap.L().Info("Role grant expired",
+			zap.String("user_id", grant.UserID.String()),
+			zap.String("role_id", grant.RoleID.String()),
+			zap.Time("valid_from", grant.ValidFrom),
+			zap.Timep("valid_until", grant.ValidUntil),
+			zap.String("reason", grant.Reason),
+			zap.String("granted_by", grant.GrantedBy.UUID.String()))
+	}
+
+	// Invalidate the cached permissions
+	invalidateUsersPermissions(grants.GetUserUUIDs())
+}
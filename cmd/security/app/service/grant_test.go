@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestService_GrantRoleToUser(t *testing.T) {
+	service := &Service{}
+	granterID := uuid.New()
+	userID := uuid.New()
+	roleID := uuid.New()
+	now := time.Now()
+	validRequest := &securitypb.GrantRoleToUserRequest{
+		UserId:     userID.String(),
+		RoleId:     roleID.String(),
+		ValidUntil: timestamppb.New(now.Add(48 * time.Hour)),
+		Reason:     "support ticket",
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *securitypb.GrantRoleToUserRequest
+		expected        *securitypb.GrantRoleToUserResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().GrantToUser(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.GrantRoleToUserResponse{},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to parse user ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().GrantToUser(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request: &securitypb.GrantRoleToUserRequest{
+				UserId: "bad-uuid",
+				RoleId: roleID.String(),
+			},
+			expected:        &securitypb.GrantRoleToUserResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to parse role ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().GrantToUser(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request: &securitypb.GrantRoleToUserRequest{
+				UserId: userID.String(),
+				RoleId: "bad-uuid",
+			},
+			expected:        &securitypb.GrantRoleToUserResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "grant ends before it starts",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().GrantToUser(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request: &securitypb.GrantRoleToUserRequest{
+				UserId:     userID.String(),
+				RoleId:     roleID.String(),
+				ValidFrom:  timestamppb.New(now.Add(time.Hour)),
+				ValidUntil: timestamppb.New(now),
+			},
+			expected:        &securitypb.GrantRoleToUserResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to get role",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().Get(roleID).Return(models.Role{}, false, errors.New("some error"))
+				rr.EXPECT().GrantToUser(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.GrantRoleToUserResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "role not found",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().Get(roleID).Return(models.Role{}, false, nil)
+				rr.EXPECT().GrantToUser(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.GrantRoleToUserResponse{},
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "fails to grant role",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().Get(roleID).Return(models.Role{Id: roleID}, true, nil)
+				rr.EXPECT().GrantToUser(gomock.Any()).Return(errors.New("some error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.GrantRoleToUserResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "success",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the repositories
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().Get(roleID).Return(models.Role{Id: roleID}, true, nil)
+				rr.EXPECT().GrantToUser(gomock.Any()).DoAndReturn(func(grant models.RoleGrant) error {
+					assert.Equal(t, userID, grant.UserID)
+					assert.Equal(t, roleID, grant.RoleID)
+					assert.Equal(t, "support ticket", grant.Reason)
+					assert.Equal(t, uuid.NullUUID{UUID: granterID, Valid: true}, grant.GrantedBy)
+					assert.NotNil(t, grant.ValidUntil)
+					return nil
+				})
+				c := mocks.NewSecurityPermissionCacheRepository(ctrl)
+				c.EXPECT().Invalidate([]uuid.UUID{userID}).Return(nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, c, nil))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			ctx := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{UserID: granterID})
+			response, err := service.GrantRoleToUser(ctx, tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			if tt.expectedErrCode == codes.OK {
+				assert.Equal(t, roleID.String(), response.GetGrant().GetRoleId())
+				assert.Equal(t, granterID.String(), response.GetGrant().GetGrantedBy())
+			} else {
+				assert.Equal(t, tt.expected, response)
+			}
+		})
+	}
+}
+
+func TestService_ListRoleGrantsForUser(t *testing.T) {
+	service := &Service{}
+	validRequest := &securitypb.ListRoleGrantsForUserRequest{
+		UserId: uuid.New().String(),
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *securitypb.ListRoleGrantsForUserRequest
+		expected        *securitypb.ListRoleGrantsForUserResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse user ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListGrantsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request: &securitypb.ListRoleGrantsForUserRequest{
+				UserId: "bad-uuid",
+			},
+			expected:        &securitypb.ListRoleGrantsForUserResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListGrantsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.ListRoleGrantsForUserResponse{},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to list role grants",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListGrantsByUserId(gomock.Any()).Return(nil, errors.New("some error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.ListRoleGrantsForUserResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "success",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListGrantsByUserId(gomock.Any()).Return(models.RoleGrants{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request: validRequest,
+			expected: &securitypb.ListRoleGrantsForUserResponse{
+				Grants: []*securitypb.RoleGrant{},
+			},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.ListRoleGrantsForUser(context.Background(), tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+}
+
+// TestSweepExpiredRoleGrants tests the SweepExpiredRoleGrants function
+func TestSweepExpiredRoleGrants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userA := uuid.New()
+	userB := uuid.New()
+	validUntil := time.Now()
+
+	// Failures are logged, nothing gets invalidated
+	rr := mocks.NewSecurityRoleRepository(ctrl)
+	rr.EXPECT().DeleteExpiredGrants().Return(nil, errors.New("error"))
+	c := mocks.NewSecurityPermissionCacheRepository(ctrl)
+	c.EXPECT().Invalidate(gomock.Any()).Times(0)
+	repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, c, nil))
+	SweepExpiredRoleGrants()
+
+	// Nothing expired, nothing gets invalidated
+	rr.EXPECT().DeleteExpiredGrants().Return(models.RoleGrants{}, nil)
+	SweepExpiredRoleGrants()
+
+	// The permissions of the users whose grants expired are invalidated
+	rr.EXPECT().DeleteExpiredGrants().Return(models.RoleGrants{
+		{UserID: userA, RoleID: uuid.New(), ValidUntil: &validUntil},
+		{UserID: userB, RoleID: uuid.New(), ValidUntil: &validUntil},
+		{UserID: userA, RoleID: uuid.New(), ValidUntil: &validUntil},
+	}, nil)
+	c = mocks.NewSecurityPermissionCacheRepository(ctrl)
+	c.EXPECT().Invalidate([]uuid.UUID{userA, userB}).Return(nil)
+	repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, c, nil))
+	SweepExpiredRoleGrants()
+}
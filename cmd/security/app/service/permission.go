@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
 	"github.com/Zapharaos/fihub-backend/internal/mappers"
 	"github.com/Zapharaos/fihub-backend/internal/models"
 	"github.com/Zapharaos/fihub-backend/internal/security"
@@ -43,6 +44,15 @@ func (s *Service) CreatePermission(ctx context.Context, req *securitypb.CreatePe
 		return &securitypb.CreatePermissionResponse{}, status.Error(codes.Internal, err.Error())
 	}
 
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionPermissionCreate,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetPermission,
+		TargetID:   permissionID.String(),
+		Details:    models.AuditDetails{"value": permission.Value, "scope": permission.Scope},
+	})
+
 	// Get the permission from the database
 	permission, found, err := repositories.R().P().Get(permissionID)
 	if err != nil {
@@ -130,6 +140,18 @@ func (s *Service) UpdatePermission(ctx context.Context, req *securitypb.UpdatePe
 		return &securitypb.UpdatePermissionResponse{}, status.Error(codes.Internal, err.Error())
 	}
 
+	// Invalidate the cached permissions
+	invalidateAllPermissions()
+
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionPermissionUpdate,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetPermission,
+		TargetID:   permissionID.String(),
+		Details:    models.AuditDetails{"value": permission.Value, "scope": permission.Scope},
+	})
+
 	// Get the permission from the database
 	permission, found, err := repositories.R().P().Get(permissionID)
 	if err != nil {
@@ -174,6 +196,17 @@ func (s *Service) DeletePermission(ctx context.Context, req *securitypb.DeletePe
 		}, status.Error(codes.Internal, err.Error())
 	}
 
+	// Invalidate the cached permissions
+	invalidateAllPermissions()
+
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionPermissionDelete,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetPermission,
+		TargetID:   permissionID.String(),
+	})
+
 	return &securitypb.DeletePermissionResponse{
 		Success: true,
 	}, nil
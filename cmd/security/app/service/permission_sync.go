@@ -0,0 +1,73 @@
+package service
+
+import (
+	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"go.uber.org/zap"
+)
+
+// PermissionSyncReport describes the changes applied by SyncPermissions
+type PermissionSyncReport struct {
+	Created []string
+	Updated []string
+	// Orphans are stored but not declared by any microservice, they are kept untouched
+	Orphans []string
+}
+
+// SyncPermissions upserts the declared permissions into the database, matching them by value.
+// The stored permissions which are not declared anymore are reported as orphans but never deleted,
+// since they might still be granted to roles.
+func SyncPermissions(declared models.Permissions) (PermissionSyncReport, error) {
+	report := PermissionSyncReport{
+		Created: make([]string, 0),
+		Updated: make([]string, 0),
+		Orphans: make([]string, 0),
+	}
+
+	stored, err := repositories.R().P().List()
+	if err != nil {
+		return report, err
+	}
+
+	storedByValue := make(map[string]models.Permission, len(stored))
+	for _, p := range stored {
+		storedByValue[p.Value] = p
+	}
+
+	declaredValues := make(map[string]bool, len(declared))
+	for _, p := range declared {
+		declaredValues[p.Value] = true
+
+		existing, found := storedByValue[p.Value]
+		if !found {
+			if _, err = repositories.R().P().Create(p); err != nil {
+				return report, err
+			}
+			report.Created = append(report.Created, p.Value)
+			continue
+		}
+
+		if existing.Scope != p.Scope || existing.Description != p.Description {
+			p.Id = existing.Id
+			if err = repositories.R().P().Update(p); err != nil {
+				return report, err
+			}
+			report.Updated = append(report.Updated, p.Value)
+		}
+	}
+
+	for _, p := range stored {
+		if !declaredValues[p.Value] {
+			report.Orphans = append(report.Orphans, p.Value)
+		}
+	}
+
+	zap.L().Info("Permissions synchronised",
+		zap.Strings("created", report.Created),
+		zap.Strings("updated", report.Updated))
+	if len(report.Orphans) > 0 {
+		zap.L().Warn("Stored permissions are not declared by any service", zap.Strings("orphans", report.Orphans))
+	}
+
+	return report, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+// TestSyncPermissions tests the SyncPermissions function
+func TestSyncPermissions(t *testing.T) {
+	existingID := uuid.New()
+	declared := models.Permissions{
+		{Value: "admin.brokers.create", Scope: models.AdminScope, Description: "Create broker"},
+		{Value: "admin.brokers.update", Scope: models.AdminScope, Description: "Update broker"},
+		{Value: "admin.brokers.delete", Scope: models.AdminScope, Description: "Delete broker"},
+	}
+	stored := models.Permissions{
+		{Id: uuid.New(), Value: "admin.brokers.create", Scope: models.AdminScope, Description: "Create broker"},
+		{Id: existingID, Value: "admin.brokers.update", Scope: models.AdminScope, Description: "Outdated description"},
+		{Id: uuid.New(), Value: "admin.brokers.list", Scope: models.AdminScope, Description: "List brokers"},
+	}
+
+	// Define tests
+	tests := []struct {
+		name      string
+		mockSetup func(ctrl *gomock.Controller)
+		expected  PermissionSyncReport
+		expectErr bool
+	}{
+		{
+			name: "fails to list permissions",
+			mockSetup: func(ctrl *gomock.Controller) {
+				pr := mocks.NewSecurityPermissionRepository(ctrl)
+				pr.EXPECT().List().Return(nil, errors.New("error"))
+				pr.EXPECT().Create(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
+			},
+			expectErr: true,
+		},
+		{
+			name: "fails to create permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				pr := mocks.NewSecurityPermissionRepository(ctrl)
+				pr.EXPECT().List().Return(models.Permissions{}, nil)
+				pr.EXPECT().Create(gomock.Any()).Return(uuid.Nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
+			},
+			expectErr: true,
+		},
+		{
+			name: "fails to update permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				pr := mocks.NewSecurityPermissionRepository(ctrl)
+				pr.EXPECT().List().Return(stored, nil)
+				pr.EXPECT().Update(gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
+			},
+			expectErr: true,
+		},
+		{
+			name: "creates, updates and reports orphans",
+			mockSetup: func(ctrl *gomock.Controller) {
+				pr := mocks.NewSecurityPermissionRepository(ctrl)
+				pr.EXPECT().List().Return(stored, nil)
+				pr.EXPECT().Update(models.Permission{
+					Id:          existingID,
+					Value:       "admin.brokers.update",
+					Scope:       models.AdminScope,
+					Description: "Update broker",
+				}).Return(nil)
+				pr.EXPECT().Create(declared[2]).Return(uuid.New(), nil)
+				pr.EXPECT().Delete(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
+			},
+			expected: PermissionSyncReport{
+				Created: []string{"admin.brokers.delete"},
+				Updated: []string{"admin.brokers.update"},
+				Orphans: []string{"admin.brokers.list"},
+			},
+			expectErr: false,
+		},
+		{
+			name: "already in sync",
+			mockSetup: func(ctrl *gomock.Controller) {
+				pr := mocks.NewSecurityPermissionRepository(ctrl)
+				pr.EXPECT().List().Return(models.Permissions{
+					{Id: uuid.New(), Value: "admin.brokers.create", Scope: models.AdminScope, Description: "Create broker"},
+					{Id: uuid.New(), Value: "admin.brokers.update", Scope: models.AdminScope, Description: "Update broker"},
+					{Id: uuid.New(), Value: "admin.brokers.delete", Scope: models.AdminScope, Description: "Delete broker"},
+				}, nil)
+				pr.EXPECT().Create(gomock.Any()).Times(0)
+				pr.EXPECT().Update(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
+			},
+			expected: PermissionSyncReport{
+				Created: []string{},
+				Updated: []string{},
+				Orphans: []string{},
+			},
+			expectErr: false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			report, err := SyncPermissions(declared)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, report)
+		})
+	}
+}
@@ -42,7 +42,7 @@ func TestService_CreatePermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Create(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         &securitypb.CreatePermissionRequest{},
 			expected:        &securitypb.CreatePermissionResponse{},
@@ -58,7 +58,7 @@ func TestService_CreatePermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Create(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request: &securitypb.CreatePermissionRequest{
 				Value: "",
@@ -77,7 +77,7 @@ func TestService_CreatePermission(t *testing.T) {
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Create(gomock.Any()).Return(uuid.Nil, errors.New("error"))
 				pr.EXPECT().Get(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.CreatePermissionResponse{},
@@ -94,7 +94,7 @@ func TestService_CreatePermission(t *testing.T) {
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Create(gomock.Any()).Return(uuid.New(), nil)
 				pr.EXPECT().Get(gomock.Any()).Return(models.Permission{}, false, errors.New("error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.CreatePermissionResponse{},
@@ -111,7 +111,7 @@ func TestService_CreatePermission(t *testing.T) {
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Create(gomock.Any()).Return(uuid.New(), nil)
 				pr.EXPECT().Get(gomock.Any()).Return(models.Permission{}, false, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.CreatePermissionResponse{},
@@ -128,7 +128,7 @@ func TestService_CreatePermission(t *testing.T) {
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Create(gomock.Any()).Return(uuid.New(), nil)
 				pr.EXPECT().Get(gomock.Any()).Return(models.Permission{}, true, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request: validRequest,
 			expected: &securitypb.CreatePermissionResponse{
@@ -194,7 +194,7 @@ func TestService_GetPermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Get(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         &securitypb.GetPermissionRequest{},
 			expected:        &securitypb.GetPermissionResponse{},
@@ -210,7 +210,7 @@ func TestService_GetPermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Get(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request: &securitypb.GetPermissionRequest{
 				Id: "bad-uuid",
@@ -228,7 +228,7 @@ func TestService_GetPermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Get(gomock.Any()).Return(models.Permission{}, false, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.GetPermissionResponse{},
@@ -244,7 +244,7 @@ func TestService_GetPermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Get(gomock.Any()).Return(models.Permission{}, false, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.GetPermissionResponse{},
@@ -260,7 +260,7 @@ func TestService_GetPermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Get(gomock.Any()).Return(models.Permission{}, true, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request: validRequest,
 			expected: &securitypb.GetPermissionResponse{
@@ -329,7 +329,7 @@ func TestService_UpdatePermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Update(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         &securitypb.UpdatePermissionRequest{},
 			expected:        &securitypb.UpdatePermissionResponse{},
@@ -345,7 +345,7 @@ func TestService_UpdatePermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Update(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request: &securitypb.UpdatePermissionRequest{
 				Id: "bad-uuid",
@@ -363,7 +363,7 @@ func TestService_UpdatePermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Update(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request: &securitypb.UpdatePermissionRequest{
 				Id:    uuid.New().String(),
@@ -383,7 +383,7 @@ func TestService_UpdatePermission(t *testing.T) {
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Update(gomock.Any()).Return(errors.New("error"))
 				pr.EXPECT().Get(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.UpdatePermissionResponse{},
@@ -400,7 +400,7 @@ func TestService_UpdatePermission(t *testing.T) {
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Update(gomock.Any()).Return(nil)
 				pr.EXPECT().Get(gomock.Any()).Return(models.Permission{}, false, errors.New("error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.UpdatePermissionResponse{},
@@ -417,7 +417,7 @@ func TestService_UpdatePermission(t *testing.T) {
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Update(gomock.Any()).Return(nil)
 				pr.EXPECT().Get(gomock.Any()).Return(models.Permission{}, false, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.UpdatePermissionResponse{},
@@ -434,7 +434,7 @@ func TestService_UpdatePermission(t *testing.T) {
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Update(gomock.Any()).Return(nil)
 				pr.EXPECT().Get(gomock.Any()).Return(models.Permission{}, true, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request: validRequest,
 			expected: &securitypb.UpdatePermissionResponse{
@@ -500,7 +500,7 @@ func TestService_DeletePermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Delete(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         &securitypb.DeletePermissionRequest{},
 			expected:        &securitypb.DeletePermissionResponse{},
@@ -516,7 +516,7 @@ func TestService_DeletePermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Delete(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request: &securitypb.DeletePermissionRequest{
 				Id: "bad-uuid",
@@ -534,7 +534,7 @@ func TestService_DeletePermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Delete(gomock.Any()).Return(errors.New("error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.DeletePermissionResponse{},
@@ -550,7 +550,7 @@ func TestService_DeletePermission(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().Delete(gomock.Any()).Return(nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.DeletePermissionResponse{},
@@ -611,7 +611,7 @@ func TestService_ListPermissions(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().List().Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         &securitypb.ListPermissionsRequest{},
 			expected:        &securitypb.ListPermissionsResponse{},
@@ -627,7 +627,7 @@ func TestService_ListPermissions(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().List().Return(models.Permissions{}, errors.New("error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request:         &securitypb.ListPermissionsRequest{},
 			expected:        &securitypb.ListPermissionsResponse{},
@@ -643,7 +643,7 @@ func TestService_ListPermissions(t *testing.T) {
 				// Mock the role repository
 				pr := mocks.NewSecurityPermissionRepository(ctrl)
 				pr.EXPECT().List().Return(models.Permissions{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr))
+				repositories.ReplaceGlobals(repositories.NewRepository(nil, pr, nil, nil))
 			},
 			request: &securitypb.ListPermissionsRequest{},
 			expected: &securitypb.ListPermissionsResponse{
@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
 	"github.com/Zapharaos/fihub-backend/internal/mappers"
 	"github.com/Zapharaos/fihub-backend/internal/models"
 	"github.com/Zapharaos/fihub-backend/internal/security"
@@ -11,6 +13,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"strings"
 )
 
 // CreateRole implements the CreateRole RPC method.
@@ -57,6 +60,15 @@ func (s *Service) CreateRole(ctx context.Context, req *securitypb.CreateRoleRequ
 		return &securitypb.CreateRoleResponse{}, status.Error(codes.Internal, err.Error())
 	}
 
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionRoleCreate,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetRole,
+		TargetID:   roleID.String(),
+		Details:    models.AuditDetails{"name": role.Name},
+	})
+
 	// Get the role + permissions from the database
 	result, found, err := repositories.R().R().GetWithPermissions(roleID)
 	if err != nil {
@@ -91,12 +103,13 @@ func (s *Service) GetRole(ctx context.Context, req *securitypb.GetRoleRequest) (
 		return &securitypb.GetRoleResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
 	}
 
-	// Get the role from the database
-	role, found, err := repositories.R().R().GetWithPermissions(roleID)
+	// Get the role, with its inherited permissions, from the database
+	roles, err := listRolesWithInheritance()
 	if err != nil {
 		zap.L().Error("Cannot load role", zap.String("uuid", roleID.String()), zap.Error(err))
 		return &securitypb.GetRoleResponse{}, status.Error(codes.Internal, err.Error())
 	}
+	role, found := findRole(roles, roleID)
 	if !found {
 		zap.L().Debug("Role not found", zap.String("uuid", roleID.String()))
 		return &securitypb.GetRoleResponse{}, status.Error(codes.NotFound, "Role not found")
@@ -160,6 +173,18 @@ func (s *Service) UpdateRole(ctx context.Context, req *securitypb.UpdateRoleRequ
 		return &securitypb.UpdateRoleResponse{}, status.Error(codes.Internal, err.Error())
 	}
 
+	// Invalidate the cached permissions
+	invalidateAllPermissions()
+
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionRoleUpdate,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetRole,
+		TargetID:   roleID.String(),
+		Details:    models.AuditDetails{"name": role.Name},
+	})
+
 	// Get the role + permissions from the database
 	result, found, err := repositories.R().R().GetWithPermissions(roleID)
 	if err != nil {
@@ -202,6 +227,17 @@ func (s *Service) DeleteRole(ctx context.Context, req *securitypb.DeleteRoleRequ
 		return &securitypb.DeleteRoleResponse{}, status.Error(codes.Internal, err.Error())
 	}
 
+	// Invalidate the cached permissions
+	invalidateAllPermissions()
+
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionRoleDelete,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetRole,
+		TargetID:   roleID.String(),
+	})
+
 	return &securitypb.DeleteRoleResponse{
 		Success: true,
 	}, nil
@@ -216,8 +252,8 @@ func (s *Service) ListRoles(ctx context.Context, req *securitypb.ListRolesReques
 		return &securitypb.ListRolesResponse{}, err
 	}
 
-	// Get all roles from the database
-	result, err := repositories.R().R().ListWithPermissions()
+	// Get all roles, with their inherited permissions, from the database
+	result, err := listRolesWithInheritance()
 	if err != nil {
 		zap.L().Error("Cannot list roles", zap.Error(err))
 		return &securitypb.ListRolesResponse{}, status.Error(codes.Internal, err.Error())
@@ -288,6 +324,18 @@ func (s *Service) SetRolePermissions(ctx context.Context, req *securitypb.SetRol
 		return &securitypb.SetRolePermissionsResponse{}, status.Error(codes.Internal, err.Error())
 	}
 
+	// Invalidate the cached permissions
+	invalidateAllPermissions()
+
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionRolePermissionsSet,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetRole,
+		TargetID:   roleID.String(),
+		Details:    models.AuditDetails{"permissions": strings.Join(req.GetPermissions(), ",")},
+	})
+
 	// List all role permissions from the database
 	permissions, err := repositories.R().R().ListPermissionsByRoleId(roleID)
 	if err != nil {
@@ -299,3 +347,96 @@ func (s *Service) SetRolePermissions(ctx context.Context, req *securitypb.SetRol
 		Permissions: mappers.PermissionsToProto(permissions),
 	}, nil
 }
+
+// SetRoleParents implements the SetRoleParents RPC method.
+func (s *Service) SetRoleParents(ctx context.Context, req *securitypb.SetRoleParentsRequest) (*securitypb.SetRoleParentsResponse, error) {
+	// Check user permissions
+	err := security.Facade().CheckPermission(ctx, "admin.roles.update")
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &securitypb.SetRoleParentsResponse{}, err
+	}
+
+	// Parse the role ID from the request
+	roleID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid role ID", zap.String("role_id", req.GetId()), zap.Error(err))
+		return &securitypb.SetRoleParentsResponse{}, status.Error(codes.InvalidArgument, "Invalid role ID")
+	}
+
+	// Parse the parent role IDs from the request
+	parentIDs := make([]uuid.UUID, 0, len(req.GetParents()))
+	for _, parent := range req.GetParents() {
+		parentID, err := uuid.Parse(parent)
+		if err != nil {
+			zap.L().Error("Invalid parent role ID", zap.String("role_id", parent), zap.Error(err))
+			return &securitypb.SetRoleParentsResponse{}, status.Error(codes.InvalidArgument, "Invalid parent role ID")
+		}
+		parentIDs = append(parentIDs, parentID)
+	}
+
+	// Set the role parents in the database, unless they create a cycle in the hierarchy
+	err = repositories.R().R().SetParentsByRoleId(roleID, parentIDs)
+	if errors.Is(err, models.ErrRoleHierarchyCycle) {
+		zap.L().Warn("Role parents create a cycle", zap.String("uuid", roleID.String()))
+		return &securitypb.SetRoleParentsResponse{}, status.Error(codes.InvalidArgument, models.ErrRoleHierarchyCycle.Error())
+	}
+	if err != nil {
+		zap.L().Error("Failed to set parents", zap.Error(err))
+		return &securitypb.SetRoleParentsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Invalidate the cached permissions
+	invalidateAllPermissions()
+
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionRoleParentsSet,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetRole,
+		TargetID:   roleID.String(),
+		Details:    models.AuditDetails{"parents": strings.Join(req.GetParents(), ",")},
+	})
+
+	// Get the role, with its inherited permissions, from the database
+	roles, err := listRolesWithInheritance()
+	if err != nil {
+		zap.L().Error("Cannot get role", zap.String("uuid", roleID.String()), zap.Error(err))
+		return &securitypb.SetRoleParentsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	role, found := findRole(roles, roleID)
+	if !found {
+		zap.L().Error("Role not found after update", zap.String("uuid", roleID.String()))
+		return &securitypb.SetRoleParentsResponse{}, status.Error(codes.Internal, "Role not found after update")
+	}
+
+	return &securitypb.SetRoleParentsResponse{
+		Role: mappers.RoleWithPermissionsToProto(role),
+	}, nil
+}
+
+// listRolesWithInheritance lists all the roles with their parents and the permissions inherited from them
+func listRolesWithInheritance() (models.RolesWithPermissions, error) {
+	roles, err := repositories.R().R().ListWithPermissions()
+	if err != nil {
+		return nil, err
+	}
+
+	hierarchy, err := repositories.R().R().ListHierarchy()
+	if err != nil {
+		return nil, err
+	}
+
+	return roles.ResolveInheritance(hierarchy), nil
+}
+
+// findRole returns the role matching the UUID from the list
+func findRole(roles models.RolesWithPermissions, roleID uuid.UUID) (models.RoleWithPermissions, bool) {
+	for _, role := range roles {
+		if role.Id == roleID {
+			return role, true
+		}
+	}
+	return models.RoleWithPermissions{}, false
+}
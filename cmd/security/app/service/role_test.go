@@ -41,7 +41,7 @@ func TestService_CreateRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.CreateRoleRequest{},
 			expected:        &securitypb.CreateRoleResponse{},
@@ -57,7 +57,7 @@ func TestService_CreateRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.CreateRoleRequest{
 				Name: "",
@@ -76,7 +76,7 @@ func TestService_CreateRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("some error"))
 				rr.EXPECT().GetWithPermissions(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.CreateRoleResponse{},
@@ -93,7 +93,7 @@ func TestService_CreateRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
 				rr.EXPECT().GetWithPermissions(gomock.Any()).Return(models.RoleWithPermissions{}, false, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.CreateRoleResponse{},
@@ -110,7 +110,7 @@ func TestService_CreateRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
 				rr.EXPECT().GetWithPermissions(gomock.Any()).Return(models.RoleWithPermissions{}, false, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.CreateRoleResponse{},
@@ -127,7 +127,7 @@ func TestService_CreateRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Create(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
 				rr.EXPECT().GetWithPermissions(gomock.Any()).Return(models.RoleWithPermissions{}, true, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: validRequest,
 			expected: &securitypb.CreateRoleResponse{
@@ -192,8 +192,8 @@ func TestService_GetRole(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
-				rr.EXPECT().GetWithPermissions(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				rr.EXPECT().ListWithPermissions().Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.GetRoleRequest{},
 			expected:        &securitypb.GetRoleResponse{},
@@ -208,8 +208,8 @@ func TestService_GetRole(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
-				rr.EXPECT().GetWithPermissions(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				rr.EXPECT().ListWithPermissions().Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.GetRoleRequest{
 				Id: "bad-uuid",
@@ -226,15 +226,15 @@ func TestService_GetRole(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
-				rr.EXPECT().GetWithPermissions(gomock.Any()).Return(models.RoleWithPermissions{}, false, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				rr.EXPECT().ListWithPermissions().Return(models.RolesWithPermissions{}, errors.New("some error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.GetRoleResponse{},
 			expectedErrCode: codes.Internal,
 		},
 		{
-			name: "fails to get role with permissions",
+			name: "fails to list role hierarchy",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
@@ -242,8 +242,26 @@ func TestService_GetRole(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
-				rr.EXPECT().GetWithPermissions(gomock.Any()).Return(models.RoleWithPermissions{}, false, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				rr.EXPECT().ListWithPermissions().Return(models.RolesWithPermissions{}, nil)
+				rr.EXPECT().ListHierarchy().Return(nil, errors.New("some error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.GetRoleResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "role not found",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListWithPermissions().Return(models.RolesWithPermissions{}, nil)
+				rr.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.GetRoleResponse{},
@@ -258,8 +276,11 @@ func TestService_GetRole(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
-				rr.EXPECT().GetWithPermissions(gomock.Any()).Return(models.RoleWithPermissions{}, true, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				rr.EXPECT().ListWithPermissions().Return(models.RolesWithPermissions{
+					{Role: models.Role{Id: uuid.MustParse(validRequest.Id)}},
+				}, nil)
+				rr.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.GetRoleResponse{},
@@ -325,7 +346,7 @@ func TestService_UpdateRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.UpdateRoleRequest{},
 			expected:        &securitypb.UpdateRoleResponse{},
@@ -341,7 +362,7 @@ func TestService_UpdateRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.UpdateRoleRequest{
 				Id: "bad-uuid",
@@ -359,7 +380,7 @@ func TestService_UpdateRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.UpdateRoleRequest{
 				Id:   uuid.New().String(),
@@ -379,7 +400,7 @@ func TestService_UpdateRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
 				rr.EXPECT().GetWithPermissions(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.UpdateRoleResponse{},
@@ -396,7 +417,7 @@ func TestService_UpdateRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().GetWithPermissions(gomock.Any()).Return(models.RoleWithPermissions{}, false, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.UpdateRoleResponse{},
@@ -413,7 +434,7 @@ func TestService_UpdateRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().GetWithPermissions(gomock.Any()).Return(models.RoleWithPermissions{}, false, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.UpdateRoleResponse{},
@@ -430,7 +451,7 @@ func TestService_UpdateRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().GetWithPermissions(gomock.Any()).Return(models.RoleWithPermissions{}, true, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: validRequest,
 			expected: &securitypb.UpdateRoleResponse{
@@ -496,7 +517,7 @@ func TestService_DeleteRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Delete(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.DeleteRoleRequest{},
 			expected:        &securitypb.DeleteRoleResponse{},
@@ -512,7 +533,7 @@ func TestService_DeleteRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Delete(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.DeleteRoleRequest{
 				Id: "bad-uuid",
@@ -530,7 +551,7 @@ func TestService_DeleteRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Delete(gomock.Any()).Return(errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.DeleteRoleResponse{},
@@ -546,7 +567,7 @@ func TestService_DeleteRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().Delete(gomock.Any()).Return(nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.DeleteRoleResponse{},
@@ -607,7 +628,7 @@ func TestService_ListRoles(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListWithPermissions().Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.ListRolesRequest{},
 			expected:        &securitypb.ListRolesResponse{},
@@ -623,7 +644,7 @@ func TestService_ListRoles(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListWithPermissions().Return(models.RolesWithPermissions{}, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.ListRolesRequest{},
 			expected:        &securitypb.ListRolesResponse{},
@@ -639,7 +660,8 @@ func TestService_ListRoles(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListWithPermissions().Return(models.RolesWithPermissions{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				rr.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.ListRolesRequest{},
 			expected: &securitypb.ListRolesResponse{
@@ -705,7 +727,7 @@ func TestService_ListRolePermissions(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListPermissionsByRoleId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.ListRolePermissionsRequest{},
 			expected:        &securitypb.ListRolePermissionsResponse{},
@@ -721,7 +743,7 @@ func TestService_ListRolePermissions(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListPermissionsByRoleId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.ListRolePermissionsRequest{
 				Id: "bad-uuid",
@@ -739,7 +761,7 @@ func TestService_ListRolePermissions(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListPermissionsByRoleId(gomock.Any()).Return(models.Permissions{}, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.ListRolePermissionsResponse{},
@@ -755,7 +777,7 @@ func TestService_ListRolePermissions(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListPermissionsByRoleId(gomock.Any()).Return(models.Permissions{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: validRequest,
 			expected: &securitypb.ListRolePermissionsResponse{
@@ -822,7 +844,7 @@ func TestService_SetRolePermissions(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetPermissionsByRoleId(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.SetRolePermissionsRequest{},
 			expected:        &securitypb.SetRolePermissionsResponse{},
@@ -838,7 +860,7 @@ func TestService_SetRolePermissions(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetPermissionsByRoleId(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.SetRolePermissionsRequest{
 				Id: "bad-uuid",
@@ -856,7 +878,7 @@ func TestService_SetRolePermissions(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetPermissionsByRoleId(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.SetRolePermissionsRequest{
 				Id:          uuid.New().String(),
@@ -876,7 +898,7 @@ func TestService_SetRolePermissions(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetPermissionsByRoleId(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
 				rr.EXPECT().ListPermissionsByRoleId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.SetRolePermissionsResponse{},
@@ -893,7 +915,7 @@ func TestService_SetRolePermissions(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetPermissionsByRoleId(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().ListPermissionsByRoleId(gomock.Any()).Return(models.Permissions{}, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.SetRolePermissionsResponse{},
@@ -910,7 +932,7 @@ func TestService_SetRolePermissions(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetPermissionsByRoleId(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().ListPermissionsByRoleId(gomock.Any()).Return(models.Permissions{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: validRequest,
 			expected: &securitypb.SetRolePermissionsResponse{
@@ -951,3 +973,181 @@ func TestService_SetRolePermissions(t *testing.T) {
 		})
 	}
 }
+
+func TestService_SetRoleParents(t *testing.T) {
+	service := &Service{}
+	roleID := uuid.New()
+	parentID := uuid.New()
+	validRequest := &securitypb.SetRoleParentsRequest{
+		Id:      roleID.String(),
+		Parents: []string{parentID.String()},
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *securitypb.SetRoleParentsRequest
+		expected        *securitypb.SetRoleParentsResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().SetParentsByRoleId(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         &securitypb.SetRoleParentsRequest{},
+			expected:        &securitypb.SetRoleParentsResponse{},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().SetParentsByRoleId(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request: &securitypb.SetRoleParentsRequest{
+				Id: "bad-uuid",
+			},
+			expected:        &securitypb.SetRoleParentsResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to parse parent ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().SetParentsByRoleId(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request: &securitypb.SetRoleParentsRequest{
+				Id:      roleID.String(),
+				Parents: []string{"bad-uuid"},
+			},
+			expected:        &securitypb.SetRoleParentsResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "parents create a cycle",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().SetParentsByRoleId(roleID, []uuid.UUID{parentID}).Return(models.ErrRoleHierarchyCycle)
+				rr.EXPECT().ListWithPermissions().Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.SetRoleParentsResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to set parents by role ID",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().SetParentsByRoleId(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+				rr.EXPECT().ListWithPermissions().Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.SetRoleParentsResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "role not found after update",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				rr.EXPECT().SetParentsByRoleId(gomock.Any(), gomock.Any()).Return(nil)
+				rr.EXPECT().ListWithPermissions().Return(models.RolesWithPermissions{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.SetRoleParentsResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "success",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().SetParentsByRoleId(roleID, []uuid.UUID{parentID}).Return(nil)
+				rr.EXPECT().ListWithPermissions().Return(models.RolesWithPermissions{
+					{Role: models.Role{Id: roleID}},
+					{Role: models.Role{Id: parentID}, Permissions: models.Permissions{{Id: uuid.New(), Value: "admin.roles.list"}}},
+				}, nil)
+				rr.EXPECT().ListHierarchy().Return(models.RoleHierarchy{roleID: {parentID}}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.SetRoleParentsResponse{},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.SetRoleParents(context.Background(), tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			if tt.expectedErrCode == codes.OK {
+				assert.Len(t, response.GetRole().GetParents(), 1)
+				assert.Len(t, response.GetRole().GetInheritedPermissions(), 1)
+			} else {
+				assert.Equal(t, tt.expected, response)
+			}
+		})
+	}
+}
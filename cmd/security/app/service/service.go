@@ -2,9 +2,22 @@ package service
 
 import (
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
 )
 
 // Service is the implementation of the SecurityService interface.
 type Service struct {
 	securitypb.UnimplementedSecurityServiceServer
+	identity   *grpcutil.IdentityAuthority
+	userClient userpb.UserServiceClient
+}
+
+// NewService creates a new Service instance
+// The user client is used to search the users, whose roles are then joined per page.
+func NewService(userClient userpb.UserServiceClient) *Service {
+	return &Service{
+		identity:   grpcutil.NewIdentityAuthorityFromConfig(),
+		userClient: userClient,
+	}
 }
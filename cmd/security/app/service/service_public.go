@@ -2,15 +2,20 @@ package service
 
 import (
 	"context"
-	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// maxCheckedPermissions is the maximum number of permissions evaluated by a single CheckPermissions call
+const maxCheckedPermissions = 100
+
 // PublicService is the implementation of the PublicSecurityService interface.
 type PublicService struct {
 	securitypb.UnimplementedPublicSecurityServiceServer
@@ -18,43 +23,45 @@ type PublicService struct {
 
 // CheckPermission implements the CheckPermission RPC method.
 func (s *PublicService) CheckPermission(ctx context.Context, req *securitypb.CheckPermissionRequest) (*securitypb.CheckPermissionResponse, error) {
-	// Retrieve the metadata
-	md, ok := metadata.FromIncomingContext(ctx)
+	// Retrieve the authenticated caller
+	principal, ok := grpcutil.PrincipalFromContext(ctx)
 	if !ok {
 		return &securitypb.CheckPermissionResponse{
 			HasPermission: false,
-		}, status.Error(codes.Unauthenticated, "Missing metadata")
-	}
-
-	// Check if the user ID is provided in the metadata
-	userIDs := md.Get("x-user-id")
-	if len(userIDs) == 0 {
-		return &securitypb.CheckPermissionResponse{
-			HasPermission: false,
-		}, status.Error(codes.Unauthenticated, "Missing user ID in metadata")
+		}, status.Error(codes.Unauthenticated, "Missing authenticated caller")
 	}
 
-	// If the user ID is provided in the request, we should check if it matches the user ID in the metadata
-	userID := userIDs[0]
-	if req.GetUserId() != "" && userID == req.GetUserId() {
+	// If the user ID is provided in the request, we should check if it matches the authenticated caller
+	userID := principal.UserID
+	if req.GetUserId() != "" && userID.String() == req.GetUserId() {
 		// User is performing request for himself : authorized
 		return &securitypb.CheckPermissionResponse{
 			HasPermission: true,
 		}, nil
 	}
 
-	// Retrieve the user roles with permissions from the repository
-	userRolesWithPermissions, err := repositories.R().R().ListWithPermissionsByUserId(uuid.MustParse(userID))
+	// Retrieve the user permissions, from the cache when available
+	permissions, err := listUserPermissions(userID)
 	if err != nil {
-		zap.L().Error("Cannot list a user roles with permissions", zap.String("uuid", userID), zap.Error(err))
+		zap.L().Error("Cannot list a user permissions", zap.String("uuid", userID.String()), zap.Error(err))
 		return &securitypb.CheckPermissionResponse{
 			HasPermission: false,
 		}, status.Error(codes.Internal, err.Error())
 	}
 
 	// Check if the user has the permission
-	if !userRolesWithPermissions.HasPermission(req.GetPermission()) {
+	if !permissions.HasPermission(req.GetPermission()) {
 		zap.L().Warn("Permission not found in context")
+		denied := models.AuditLog{
+			Action:  models.AuditActionPermissionDenied,
+			Outcome: models.AuditOutcomeDenied,
+			Details: models.AuditDetails{"permission": req.GetPermission()},
+		}
+		if req.GetUserId() != "" {
+			denied.TargetType = models.AuditTargetUser
+			denied.TargetID = req.GetUserId()
+		}
+		audit.Facade().Record(ctx, denied)
 		return &securitypb.CheckPermissionResponse{
 			HasPermission: false,
 		}, status.Error(codes.PermissionDenied, "Missing permission for action")
@@ -64,3 +71,178 @@ func (s *PublicService) CheckPermission(ctx context.Context, req *securitypb.Che
 		HasPermission: true,
 	}, nil
 }
+
+// CheckPermissions implements the CheckPermissions RPC method.
+// Evaluates every requested permission for the authenticated caller at once.
+func (s *PublicService) CheckPermissions(ctx context.Context, req *securitypb.CheckPermissionsRequest) (*securitypb.CheckPermissionsResponse, error) {
+	// Retrieve the authenticated caller
+	principal, ok := grpcutil.PrincipalFromContext(ctx)
+	if !ok {
+		return &securitypb.CheckPermissionsResponse{}, status.Error(codes.Unauthenticated, "Missing authenticated caller")
+	}
+
+	if len(req.GetPermissions()) > maxCheckedPermissions {
+		zap.L().Warn("Too many permissions to check", zap.Int("count", len(req.GetPermissions())))
+		return &securitypb.CheckPermissionsResponse{}, status.Error(codes.InvalidArgument, "permissions-limit-exceeded")
+	}
+
+	result := make(map[string]bool, len(req.GetPermissions()))
+
+	// If the user ID is provided in the request, we should check if it matches the authenticated caller
+	userID := principal.UserID
+	if req.GetUserId() != "" && userID.String() == req.GetUserId() {
+		// User is performing request for himself : authorized
+		for _, permission := range req.GetPermissions() {
+			result[permission] = true
+		}
+		return &securitypb.CheckPermissionsResponse{
+			Permissions: result,
+		}, nil
+	}
+
+	// Retrieve the user permissions, from the cache when available
+	permissions, err := listUserPermissions(userID)
+	if err != nil {
+		zap.L().Error("Cannot list a user permissions", zap.String("uuid", userID.String()), zap.Error(err))
+		return &securitypb.CheckPermissionsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Evaluate every permission
+	for _, permission := range req.GetPermissions() {
+		result[permission] = permissions.HasPermission(permission)
+	}
+
+	return &securitypb.CheckPermissionsResponse{
+		Permissions: result,
+	}, nil
+}
+
+// CheckResourceAccess implements the CheckResourceAccess RPC method.
+// Evaluates the resource access policy, combining the caller roles and permissions with the resource owner and attributes.
+func (s *PublicService) CheckResourceAccess(ctx context.Context, req *securitypb.CheckResourceAccessRequest) (*securitypb.CheckResourceAccessResponse, error) {
+	// Retrieve the authenticated caller
+	principal, ok := grpcutil.PrincipalFromContext(ctx)
+	if !ok {
+		return &securitypb.CheckResourceAccessResponse{
+			Allowed: false,
+		}, status.Error(codes.Unauthenticated, "Missing authenticated caller")
+	}
+
+	// Parse the owner ID from the request, if any
+	resource := security.Resource{
+		Type:       req.GetResourceType(),
+		Attributes: req.GetAttributes(),
+	}
+	if req.GetOwnerId() != "" {
+		ownerID, err := uuid.Parse(req.GetOwnerId())
+		if err != nil {
+			zap.L().Error("Invalid owner ID", zap.String("owner_id", req.GetOwnerId()), zap.Error(err))
+			return &securitypb.CheckResourceAccessResponse{
+				Allowed: false,
+			}, status.Error(codes.InvalidArgument, "Invalid owner ID")
+		}
+		resource.OwnerID = ownerID
+	}
+
+	// Retrieve the caller roles and permissions, from the cache when available
+	subject, err := userSubject(principal.UserID)
+	if err != nil {
+		zap.L().Error("Cannot list roles with permissions", zap.String("uuid", principal.UserID.String()), zap.Error(err))
+		return &securitypb.CheckResourceAccessResponse{
+			Allowed: false,
+		}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Evaluate the policy
+	allowed, rule := security.DefaultPolicy().Evaluate(subject, security.Action(req.GetAction()), resource)
+	if !allowed {
+		zap.L().Warn("Resource access denied",
+			zap.String("action", req.GetAction()),
+			zap.String("resource", req.GetResourceType()),
+			zap.String("rule", rule))
+		return &securitypb.CheckResourceAccessResponse{
+			Allowed: false,
+		}, status.Error(codes.PermissionDenied, "Missing permission for action")
+	}
+
+	return &securitypb.CheckResourceAccessResponse{
+		Allowed: true,
+	}, nil
+}
+
+// CheckImpersonation implements the CheckImpersonation RPC method.
+// The caller must be allowed to impersonate users, and the target must be another user that is not a superadmin.
+func (s *PublicService) CheckImpersonation(ctx context.Context, req *securitypb.CheckImpersonationRequest) (*securitypb.CheckImpersonationResponse, error) {
+	// Retrieve the authenticated caller
+	principal, ok := grpcutil.PrincipalFromContext(ctx)
+	if !ok {
+		return &securitypb.CheckImpersonationResponse{
+			Allowed: false,
+		}, status.Error(codes.Unauthenticated, "Missing authenticated caller")
+	}
+
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &securitypb.CheckImpersonationResponse{
+			Allowed: false,
+		}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Impersonating oneself is meaningless, and impersonations cannot be chained
+	if userID == principal.UserID {
+		return &securitypb.CheckImpersonationResponse{
+			Allowed: false,
+		}, status.Error(codes.InvalidArgument, "impersonation-self")
+	}
+	if principal.IsImpersonated() {
+		zap.L().Warn("Nested impersonation", zap.String("actor_id", principal.ActorID.UUID.String()))
+		return &securitypb.CheckImpersonationResponse{
+			Allowed: false,
+		}, status.Error(codes.PermissionDenied, "impersonation-nested")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.impersonate", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &securitypb.CheckImpersonationResponse{
+			Allowed: false,
+		}, err
+	}
+
+	// Administrators cannot be impersonated, that would grant administration permissions to the caller
+	permissions, err := listUserPermissions(userID)
+	if err != nil {
+		zap.L().Error("Cannot list a user permissions", zap.String("uuid", userID.String()), zap.Error(err))
+		return &securitypb.CheckImpersonationResponse{
+			Allowed: false,
+		}, status.Error(codes.Internal, err.Error())
+	}
+	if permissions.HasScopePermission(models.AdminScope) {
+		zap.L().Warn("Administrator impersonation", zap.String("uuid", userID.String()))
+		return &securitypb.CheckImpersonationResponse{
+			Allowed: false,
+		}, status.Error(codes.PermissionDenied, "impersonation-admin")
+	}
+
+	// Nor can users granted permissions the caller does not have
+	callerPermissions, err := listUserPermissions(principal.UserID)
+	if err != nil {
+		zap.L().Error("Cannot list a user permissions", zap.String("uuid", principal.UserID.String()), zap.Error(err))
+		return &securitypb.CheckImpersonationResponse{
+			Allowed: false,
+		}, status.Error(codes.Internal, err.Error())
+	}
+	if !callerPermissions.Covers(permissions) {
+		zap.L().Warn("Impersonation escalating permissions", zap.String("uuid", userID.String()))
+		return &securitypb.CheckImpersonationResponse{
+			Allowed: false,
+		}, status.Error(codes.PermissionDenied, "impersonation-escalation")
+	}
+
+	return &securitypb.CheckImpersonationResponse{
+		Allowed: true,
+	}, nil
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
 	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
 	"github.com/Zapharaos/fihub-backend/test/mocks"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -20,8 +22,8 @@ func TestPublicService_CheckPermission(t *testing.T) {
 	// Prepare data
 	service := &PublicService{}
 	userID := uuid.New()
-	validContext := metadata.NewIncomingContext(context.Background(), metadata.MD{
-		"x-user-id": {uuid.New().String()},
+	validContext := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{
+		UserID: uuid.New(),
 	})
 	validRequest := &securitypb.CheckPermissionRequest{
 		UserId:     userID.String(),
@@ -46,12 +48,12 @@ func TestPublicService_CheckPermission(t *testing.T) {
 		expectedErrCode codes.Code
 	}{
 		{
-			name: "missing metadata in context",
+			name: "missing principal in context",
 			mockSetup: func(ctrl *gomock.Controller) context.Context {
 				r := mocks.NewSecurityRoleRepository(ctrl)
 				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(r, nil))
-				// Create a new context without metadata
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				// Create a new context without principal
 				return context.Background()
 			},
 			request:         validRequest,
@@ -59,13 +61,15 @@ func TestPublicService_CheckPermission(t *testing.T) {
 			expectedErrCode: codes.Unauthenticated,
 		},
 		{
-			name: "missing user ID in metadata",
+			name: "raw user ID metadata is not trusted",
 			mockSetup: func(ctrl *gomock.Controller) context.Context {
 				r := mocks.NewSecurityRoleRepository(ctrl)
 				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(r, nil))
-				// Create a new context without userID in metadata
-				return metadata.NewIncomingContext(context.Background(), metadata.MD{})
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				// Create a new context with an unsigned userID in metadata
+				return metadata.NewIncomingContext(context.Background(), metadata.MD{
+					"x-user-id": {userID.String()},
+				})
 			},
 			request:         validRequest,
 			expected:        false,
@@ -76,7 +80,7 @@ func TestPublicService_CheckPermission(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) context.Context {
 				r := mocks.NewSecurityRoleRepository(ctrl)
 				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(nil, errors.New("error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(r, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
 				// Create a new context without userID in metadata
 				return validContext
 			},
@@ -89,7 +93,7 @@ func TestPublicService_CheckPermission(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) context.Context {
 				r := mocks.NewSecurityRoleRepository(ctrl)
 				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(models.RolesWithPermissions{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(r, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
 				// Create a new context without userID in metadata
 				return validContext
 			},
@@ -102,10 +106,10 @@ func TestPublicService_CheckPermission(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) context.Context {
 				r := mocks.NewSecurityRoleRepository(ctrl)
 				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(r, nil))
-				// Create a new context without userID in metadata
-				return metadata.NewIncomingContext(context.Background(), metadata.MD{
-					"x-user-id": {userID.String()},
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				// Create a new context with the requested user as principal
+				return grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{
+					UserID: userID,
 				})
 			},
 			request:         validRequest,
@@ -117,7 +121,9 @@ func TestPublicService_CheckPermission(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) context.Context {
 				r := mocks.NewSecurityRoleRepository(ctrl)
 				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(validResponse, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(r, nil))
+				r.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				r.EXPECT().List().Return(models.Roles{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
 				// Create a new context without userID in metadata
 				return validContext
 			},
@@ -154,3 +160,575 @@ func TestPublicService_CheckPermission(t *testing.T) {
 		})
 	}
 }
+
+func TestPublicService_CheckPermissions(t *testing.T) {
+	// Prepare data
+	service := &PublicService{}
+	userID := uuid.New()
+	validContext := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{
+		UserID: uuid.New(),
+	})
+	validRequest := &securitypb.CheckPermissionsRequest{
+		UserId:      userID.String(),
+		Permissions: []string{"example.permission", "other.permission"},
+	}
+	validResponse := []models.RoleWithPermissions{
+		{
+			Permissions: []models.Permission{
+				{
+					Value: "example.*",
+				},
+			},
+		},
+	}
+	tooManyPermissions := make([]string, maxCheckedPermissions+1)
+	for i := range tooManyPermissions {
+		tooManyPermissions[i] = "example.permission"
+	}
+
+	// Define the test cases
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller) context.Context
+		request         *securitypb.CheckPermissionsRequest
+		expected        map[string]bool
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "missing principal in context",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return context.Background()
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "too many permissions",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request: &securitypb.CheckPermissionsRequest{
+				Permissions: tooManyPermissions,
+			},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to list user permissions",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "successful self permissions check",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{
+					UserID: userID,
+				})
+			},
+			request: validRequest,
+			expected: map[string]bool{
+				"example.permission": true,
+				"other.permission":   true,
+			},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "successful permissions check",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(validResponse, nil).Times(1)
+				r.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				r.EXPECT().List().Return(models.Roles{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request: validRequest,
+			expected: map[string]bool{
+				"example.permission": true,
+				"other.permission":   false,
+			},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run the test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			ctx := tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.CheckPermissions(ctx, tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			if tt.expectedErrCode == codes.OK {
+				assert.Equal(t, tt.expected, response.GetPermissions())
+			} else {
+				assert.Empty(t, response.GetPermissions())
+			}
+		})
+	}
+}
+
+func TestPublicService_CheckResourceAccess(t *testing.T) {
+	// Prepare data
+	service := &PublicService{}
+	callerID := uuid.New()
+	validContext := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{
+		UserID: callerID,
+	})
+	supportRoles := models.RolesWithPermissions{
+		{Role: models.Role{Name: security.SupportRole}},
+	}
+	seniorSupport := models.Role{Id: uuid.New(), Name: "senior-support"}
+	support := models.Role{Id: uuid.New(), Name: security.SupportRole}
+
+	// Define the test cases
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller) context.Context
+		request         *securitypb.CheckResourceAccessRequest
+		expected        bool
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "missing principal in context",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return context.Background()
+			},
+			request: &securitypb.CheckResourceAccessRequest{
+				Action:       string(security.ActionRead),
+				ResourceType: security.ResourceTransaction,
+			},
+			expected:        false,
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "fails to parse owner ID from request",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request: &securitypb.CheckResourceAccessRequest{
+				Action:       string(security.ActionRead),
+				ResourceType: security.ResourceTransaction,
+				OwnerId:      "bad-uuid",
+			},
+			expected:        false,
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to list the caller roles",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(callerID).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request: &securitypb.CheckResourceAccessRequest{
+				Action:       string(security.ActionRead),
+				ResourceType: security.ResourceTransaction,
+				OwnerId:      uuid.New().String(),
+			},
+			expected:        false,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "caller does not own the resource",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(callerID).Return(models.RolesWithPermissions{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request: &securitypb.CheckResourceAccessRequest{
+				Action:       string(security.ActionRead),
+				ResourceType: security.ResourceTransaction,
+				OwnerId:      uuid.New().String(),
+			},
+			expected:        false,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "support cannot modify another user's resource",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(callerID).Return(supportRoles, nil)
+				r.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				r.EXPECT().List().Return(models.Roles{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request: &securitypb.CheckResourceAccessRequest{
+				Action:       string(security.ActionUpdate),
+				ResourceType: security.ResourceTransaction,
+				OwnerId:      uuid.New().String(),
+			},
+			expected:        false,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "support reads another user's resource",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(callerID).Return(supportRoles, nil)
+				r.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				r.EXPECT().List().Return(models.Roles{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request: &securitypb.CheckResourceAccessRequest{
+				Action:       string(security.ActionRead),
+				ResourceType: security.ResourceTransaction,
+				OwnerId:      uuid.New().String(),
+			},
+			expected:        true,
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "support reads another user's resource through an inherited role",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(callerID).Return(models.RolesWithPermissions{{Role: seniorSupport}}, nil)
+				r.EXPECT().ListHierarchy().Return(models.RoleHierarchy{seniorSupport.Id: {support.Id}}, nil)
+				r.EXPECT().List().Return(models.Roles{seniorSupport, support}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request: &securitypb.CheckResourceAccessRequest{
+				Action:       string(security.ActionRead),
+				ResourceType: security.ResourceTransaction,
+				OwnerId:      uuid.New().String(),
+			},
+			expected:        true,
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "support reads another user's resource with cached roles",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
+				c := mocks.NewSecurityPermissionCacheRepository(ctrl)
+				c.EXPECT().Get(callerID).Return(models.UserAccess{Roles: []string{security.SupportRole}}, true, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, c, nil))
+				return validContext
+			},
+			request: &securitypb.CheckResourceAccessRequest{
+				Action:       string(security.ActionRead),
+				ResourceType: security.ResourceTransaction,
+				OwnerId:      uuid.New().String(),
+			},
+			expected:        true,
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "caller owns the resource",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(callerID).Return(models.RolesWithPermissions{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request: &securitypb.CheckResourceAccessRequest{
+				Action:       string(security.ActionDelete),
+				ResourceType: security.ResourceTransaction,
+				OwnerId:      callerID.String(),
+			},
+			expected:        true,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run the test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			ctx := tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.CheckResourceAccess(ctx, tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			assert.Equal(t, tt.expected, response.GetAllowed())
+		})
+	}
+}
+
+func TestPublicService_CheckImpersonation(t *testing.T) {
+	// Prepare data
+	service := &PublicService{}
+	callerID := uuid.New()
+	targetID := uuid.New()
+	validContext := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{
+		UserID: callerID,
+	})
+	validRequest := &securitypb.CheckImpersonationRequest{
+		UserId: targetID.String(),
+	}
+
+	// Define the test cases
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller) context.Context
+		request         *securitypb.CheckImpersonationRequest
+		expected        bool
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "missing principal in context",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				return context.Background()
+			},
+			request:         validRequest,
+			expected:        false,
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "fails to parse user ID from request",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				return validContext
+			},
+			request: &securitypb.CheckImpersonationRequest{
+				UserId: "bad-uuid",
+			},
+			expected:        false,
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "caller impersonates itself",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				return validContext
+			},
+			request: &securitypb.CheckImpersonationRequest{
+				UserId: callerID.String(),
+			},
+			expected:        false,
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "caller is already impersonating",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				return grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{
+					UserID:  callerID,
+					ActorID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				})
+			},
+			request:         validRequest,
+			expected:        false,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request:         validRequest,
+			expected:        false,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to list the target permissions",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(targetID).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request:         validRequest,
+			expected:        false,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "target is a superadmin",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(targetID).Return(models.RolesWithPermissions{
+					{Permissions: models.Permissions{{Value: "*"}}},
+				}, nil)
+				r.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				r.EXPECT().List().Return(models.Roles{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request:         validRequest,
+			expected:        false,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "target is an administrator",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(targetID).Return(models.RolesWithPermissions{
+					{Permissions: models.Permissions{{Value: "front.*"}, {Value: "admin.users.list"}}},
+				}, nil)
+				r.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				r.EXPECT().List().Return(models.Roles{}, nil)
+				r.EXPECT().ListWithPermissionsByUserId(callerID).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request:         validRequest,
+			expected:        false,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to list the caller permissions",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(targetID).Return(models.RolesWithPermissions{}, nil)
+				r.EXPECT().ListWithPermissionsByUserId(callerID).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request:         validRequest,
+			expected:        false,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "target holds permissions the caller does not have",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(targetID).Return(models.RolesWithPermissions{
+					{Permissions: models.Permissions{{Value: "front.*"}}},
+				}, nil)
+				r.EXPECT().ListWithPermissionsByUserId(callerID).Return(models.RolesWithPermissions{
+					{Permissions: models.Permissions{{Value: "front.brokers.*"}, {Value: "admin.users.impersonate"}}},
+				}, nil)
+				r.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil).Times(2)
+				r.EXPECT().List().Return(models.Roles{}, nil).Times(2)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request:         validRequest,
+			expected:        false,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "succeeded",
+			mockSetup: func(ctrl *gomock.Controller) context.Context {
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				r := mocks.NewSecurityRoleRepository(ctrl)
+				r.EXPECT().ListWithPermissionsByUserId(targetID).Return(models.RolesWithPermissions{
+					{Permissions: models.Permissions{{Value: "front.*"}}},
+				}, nil)
+				r.EXPECT().ListWithPermissionsByUserId(callerID).Return(models.RolesWithPermissions{
+					{Permissions: models.Permissions{{Value: "front.*"}, {Value: "admin.users.impersonate"}}},
+				}, nil)
+				r.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil).Times(2)
+				r.EXPECT().List().Return(models.Roles{}, nil).Times(2)
+				repositories.ReplaceGlobals(repositories.NewRepository(r, nil, nil, nil))
+				return validContext
+			},
+			request:         validRequest,
+			expected:        true,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run the test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			ctx := tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.CheckImpersonation(ctx, tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			assert.Equal(t, tt.expected, response.GetAllowed())
+		})
+	}
+}
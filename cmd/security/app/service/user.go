@@ -4,13 +4,18 @@ import (
 	"context"
 	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
 	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/models"
 	"github.com/Zapharaos/fihub-backend/internal/security"
 	"github.com/Zapharaos/fihub-backend/internal/utils"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"strings"
 )
 
 // AddUsersToRole implements the AddUsersToRole RPC method.
@@ -43,6 +48,18 @@ func (s *Service) AddUsersToRole(ctx context.Context, req *securitypb.AddUsersTo
 		return &securitypb.AddUsersToRoleResponse{}, status.Error(codes.Internal, "Failed to add users to role")
 	}
 
+	// Invalidate the cached permissions
+	invalidateUsersPermissions(uuidUsers)
+
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionRoleUsersAdd,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetRole,
+		TargetID:   roleID.String(),
+		Details:    models.AuditDetails{"users": strings.Join(req.GetUserIds(), ",")},
+	})
+
 	// Retrieve users by role ID from database
 	users, err := repositories.R().R().ListUsersByRoleId(roleID)
 	if err != nil {
@@ -85,6 +102,18 @@ func (s *Service) RemoveUsersFromRole(ctx context.Context, req *securitypb.Remov
 		return &securitypb.RemoveUsersFromRoleResponse{}, status.Error(codes.Internal, "Failed to remove users from role")
 	}
 
+	// Invalidate the cached permissions
+	invalidateUsersPermissions(uuidUsers)
+
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionRoleUsersRemove,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetRole,
+		TargetID:   roleID.String(),
+		Details:    models.AuditDetails{"users": strings.Join(req.GetUserIds(), ",")},
+	})
+
 	// Retrieve users by role ID from database
 	users, err := repositories.R().R().ListUsersByRoleId(roleID)
 	if err != nil {
@@ -157,6 +186,18 @@ func (s *Service) SetRolesForUser(ctx context.Context, req *securitypb.SetRolesF
 		return &securitypb.SetRolesForUserResponse{}, status.Error(codes.Internal, "Failed to set user roles")
 	}
 
+	// Invalidate the cached permissions
+	invalidateUsersPermissions([]uuid.UUID{userID})
+
+	// Keep track of the change
+	audit.Facade().Record(ctx, models.AuditLog{
+		Action:     models.AuditActionUserRolesSet,
+		Outcome:    models.AuditOutcomeSuccess,
+		TargetType: models.AuditTargetUser,
+		TargetID:   userID.String(),
+		Details:    models.AuditDetails{"roles": strings.Join(req.GetRoleIds(), ",")},
+	})
+
 	// Get all roles with permissions for user from the database
 	roles, err := repositories.R().R().ListWithPermissionsByUserId(userID)
 	if err != nil {
@@ -170,14 +211,8 @@ func (s *Service) SetRolesForUser(ctx context.Context, req *securitypb.SetRolesF
 }
 
 // ListRolesForUser implements the ListRolesForUser RPC method.
+// Users can list their own roles, listing the roles of another user requires the permission.
 func (s *Service) ListRolesForUser(ctx context.Context, req *securitypb.ListRolesForUserRequest) (*securitypb.ListRolesForUserResponse, error) {
-	// Check user permissions for creating a role
-	err := security.Facade().CheckPermission(ctx, "admin.users.roles.list")
-	if err != nil {
-		zap.L().Error("CheckPermission", zap.Error(err))
-		return &securitypb.ListRolesForUserResponse{}, err
-	}
-
 	// Parse the user ID from the request
 	userID, err := uuid.Parse(req.GetUserId())
 	if err != nil {
@@ -186,6 +221,13 @@ func (s *Service) ListRolesForUser(ctx context.Context, req *securitypb.ListRole
 		return &securitypb.ListRolesForUserResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
 	}
 
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.roles.list", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &securitypb.ListRolesForUserResponse{}, err
+	}
+
 	// Get all roles for user from the database
 	roles, err := repositories.R().R().ListByUserId(userID)
 	if err != nil {
@@ -227,7 +269,102 @@ func (s *Service) ListRolesWithPermissionsForUser(ctx context.Context, req *secu
 	}, nil
 }
 
+// ListEffectivePermissionsForUser implements the ListEffectivePermissionsForUser RPC method.
+// Returns the permissions granted to the user, wildcards being expanded into the matching permissions.
+func (s *Service) ListEffectivePermissionsForUser(ctx context.Context, req *securitypb.ListEffectivePermissionsForUserRequest) (*securitypb.ListEffectivePermissionsForUserResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &securitypb.ListEffectivePermissionsForUserResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.roles.list", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &securitypb.ListEffectivePermissionsForUserResponse{}, err
+	}
+
+	// Validate the scope, if any
+	scope := req.GetScope()
+	if scope != "" && !models.CheckScope(scope) {
+		zap.L().Warn("Invalid scope", zap.String("scope", scope))
+		return &securitypb.ListEffectivePermissionsForUserResponse{}, status.Error(codes.InvalidArgument, models.ErrScopeInvalid.Error())
+	}
+
+	// Retrieve the user permissions, from the cache when available
+	granted, err := listUserPermissions(userID)
+	if err != nil {
+		zap.L().Error("Cannot list a user permissions", zap.String("uuid", userID.String()), zap.Error(err))
+		return &securitypb.ListEffectivePermissionsForUserResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Expand the granted permissions into the existing permissions
+	permissions, err := repositories.R().P().List()
+	if err != nil {
+		zap.L().Error("Cannot list permissions", zap.Error(err))
+		return &securitypb.ListEffectivePermissionsForUserResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	effective := make(models.Permissions, 0)
+	for _, permission := range permissions {
+		if scope != "" && !permission.HasScope(scope) {
+			continue
+		}
+		if granted.HasPermission(permission.Value) {
+			effective = append(effective, permission)
+		}
+	}
+
+	return &securitypb.ListEffectivePermissionsForUserResponse{
+		Permissions: mappers.PermissionsToProto(effective),
+	}, nil
+}
+
+// DeleteRolesForUser implements the DeleteRolesForUser RPC method.
+// Called by the user microservice once the deletion of an account is due, it removes every role assignment
+// and grant of the user and reports how many remain.
+func (s *Service) DeleteRolesForUser(ctx context.Context, req *securitypb.DeleteRolesForUserRequest) (*securitypb.DeleteRolesForUserResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &securitypb.DeleteRolesForUserResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.delete", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &securitypb.DeleteRolesForUserResponse{}, err
+	}
+
+	// Remove all the roles of the user
+	err = repositories.R().R().SetForUser(userID, nil)
+	if err != nil {
+		zap.L().Error("DeleteRolesForUser", zap.Error(err))
+		return &securitypb.DeleteRolesForUserResponse{}, status.Error(codes.Internal, "Failed to delete user roles")
+	}
+
+	// Invalidate the cached permissions
+	invalidateUsersPermissions([]uuid.UUID{userID})
+
+	// Report what is left, so that the caller can verify the deletion
+	remaining, err := repositories.R().R().ListGrantsByUserId(userID)
+	if err != nil {
+		zap.L().Error("Cannot list role grants", zap.Error(err))
+		return &securitypb.DeleteRolesForUserResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &securitypb.DeleteRolesForUserResponse{
+		Remaining: int64(len(remaining)),
+	}, nil
+}
+
 // ListUsersFull implements the ListUsersFull RPC method.
+// Returns a page of the users matching the filter, with their roles.
 func (s *Service) ListUsersFull(ctx context.Context, req *securitypb.ListUsersFullRequest) (*securitypb.ListUsersFullResponse, error) {
 	// Check user permissions for creating a role
 	err := security.Facade().CheckPermission(ctx, "admin.users.list")
@@ -236,28 +373,80 @@ func (s *Service) ListUsersFull(ctx context.Context, req *securitypb.ListUsersFu
 		return &securitypb.ListUsersFullResponse{}, err
 	}
 
-	// Retrieve users from database
-	userIds, err := repositories.R().R().ListUsers()
+	// Restrict the search to the holders of the role, which the user service does not know about
+	var holderIDs []string
+	if req.GetRoleId() != "" {
+		roleID, err := uuid.Parse(req.GetRoleId())
+		if err != nil {
+			zap.L().Warn("Role ID cannot be parsed", zap.Error(err))
+			return &securitypb.ListUsersFullResponse{}, status.Error(codes.InvalidArgument, models.ErrUserRoleInvalid.Error())
+		}
+		holders, err := repositories.R().R().ListActiveUsersByRoleId(roleID)
+		if err != nil {
+			zap.L().Error("ListActiveUsersByRoleId", zap.Error(err))
+			return &securitypb.ListUsersFullResponse{}, status.Error(codes.Internal, "Failed to list users")
+		}
+		// Without any holder, an empty restriction would match every user
+		if len(holders) == 0 {
+			return &securitypb.ListUsersFullResponse{}, nil
+		}
+		holderIDs = make([]string, len(holders))
+		for i, holder := range holders {
+			holderIDs[i] = holder.String()
+		}
+	}
+
+	// Search the page of users in the user service, on behalf of the caller
+	principal, ok := grpcutil.PrincipalFromContext(ctx)
+	if !ok {
+		return &securitypb.ListUsersFullResponse{}, status.Error(codes.Unauthenticated, "Missing caller identity")
+	}
+	outgoing, err := s.identity.AppendPrincipalToOutgoingContext(ctx, principal)
 	if err != nil {
-		zap.L().Error("ListUsers", zap.Error(err))
+		zap.L().Error("AppendPrincipalToOutgoingContext", zap.Error(err))
 		return &securitypb.ListUsersFullResponse{}, status.Error(codes.Internal, "Failed to list users")
 	}
+	response, err := s.userClient.ListUsers(outgoing, &userpb.ListUsersRequest{
+		Email:       req.GetEmail(),
+		Sort:        req.GetSort(),
+		Limit:       req.GetLimit(),
+		After:       req.GetAfter(),
+		CreatedFrom: req.GetCreatedFrom(),
+		CreatedTo:   req.GetCreatedTo(),
+		Status:      req.GetStatus(),
+		UserIds:     holderIDs,
+	})
+	if err != nil {
+		zap.L().Error("ListUsers", zap.Error(err))
+		return &securitypb.ListUsersFullResponse{}, err
+	}
+	page := response.GetUsers()
 
-	users := make([]*securitypb.UserWithRoles, 0, len(userIds))
-	for _, userID := range userIds {
-		roles, err := repositories.R().R().ListByUserId(uuid.MustParse(userID))
+	// Retrieve the roles of the whole page at once
+	userIDs := make([]uuid.UUID, len(page))
+	for i, user := range page {
+		userIDs[i], err = uuid.Parse(user.GetId())
 		if err != nil {
-			zap.L().Error("Cannot list roles", zap.Error(err))
-			return &securitypb.ListUsersFullResponse{}, status.Error(codes.Internal, err.Error())
+			zap.L().Error("User ID cannot be parsed", zap.Error(err))
+			return &securitypb.ListUsersFullResponse{}, status.Error(codes.Internal, "Failed to list users")
 		}
+	}
+	roles, err := repositories.R().R().ListByUserIds(userIDs)
+	if err != nil {
+		zap.L().Error("Cannot list roles", zap.Error(err))
+		return &securitypb.ListUsersFullResponse{}, status.Error(codes.Internal, err.Error())
+	}
 
+	users := make([]*securitypb.UserWithRoles, 0, len(page))
+	for i, user := range page {
 		users = append(users, &securitypb.UserWithRoles{
-			UserId: userID,
-			Roles:  mappers.RolesToProto(roles),
+			UserId: user.GetId(),
+			Roles:  mappers.RolesToProto(roles[userIDs[i]]),
 		})
 	}
 
 	return &securitypb.ListUsersFullResponse{
-		Users: users,
+		Users:      users,
+		NextCursor: response.GetNextCursor(),
 	}, nil
 }
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
 	"github.com/Zapharaos/fihub-backend/internal/models"
 	"github.com/Zapharaos/fihub-backend/internal/security"
 	"github.com/Zapharaos/fihub-backend/test/mocks"
@@ -14,6 +17,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"testing"
+	"time"
 )
 
 func TestService_AddUsersToRole(t *testing.T) {
@@ -41,7 +45,7 @@ func TestService_AddUsersToRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().AddToUsers(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.AddUsersToRoleRequest{},
 			expected:        &securitypb.AddUsersToRoleResponse{},
@@ -57,7 +61,7 @@ func TestService_AddUsersToRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().AddToUsers(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.AddUsersToRoleRequest{
 				RoleId: "bad-uuid",
@@ -75,7 +79,7 @@ func TestService_AddUsersToRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().AddToUsers(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.AddUsersToRoleRequest{
 				RoleId:  uuid.New().String(),
@@ -95,7 +99,7 @@ func TestService_AddUsersToRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().AddToUsers(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
 				rr.EXPECT().ListUsersByRoleId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.AddUsersToRoleResponse{},
@@ -112,7 +116,7 @@ func TestService_AddUsersToRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().AddToUsers(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().ListUsersByRoleId(gomock.Any()).Return(nil, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.AddUsersToRoleResponse{},
@@ -129,7 +133,7 @@ func TestService_AddUsersToRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().AddToUsers(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().ListUsersByRoleId(gomock.Any()).Return([]string{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.AddUsersToRoleResponse{UserIds: []string{}},
@@ -194,7 +198,7 @@ func TestService_RemoveUsersFromRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().RemoveFromUsers(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.RemoveUsersFromRoleRequest{},
 			expected:        &securitypb.RemoveUsersFromRoleResponse{},
@@ -210,7 +214,7 @@ func TestService_RemoveUsersFromRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().RemoveFromUsers(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.RemoveUsersFromRoleRequest{
 				RoleId: "bad-uuid",
@@ -228,7 +232,7 @@ func TestService_RemoveUsersFromRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().RemoveFromUsers(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.RemoveUsersFromRoleRequest{
 				RoleId:  uuid.New().String(),
@@ -248,7 +252,7 @@ func TestService_RemoveUsersFromRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().RemoveFromUsers(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
 				rr.EXPECT().ListUsersByRoleId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.RemoveUsersFromRoleResponse{},
@@ -265,7 +269,7 @@ func TestService_RemoveUsersFromRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().RemoveFromUsers(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().ListUsersByRoleId(gomock.Any()).Return(nil, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.RemoveUsersFromRoleResponse{},
@@ -282,7 +286,7 @@ func TestService_RemoveUsersFromRole(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().RemoveFromUsers(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().ListUsersByRoleId(gomock.Any()).Return([]string{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.RemoveUsersFromRoleResponse{UserIds: []string{}},
@@ -346,7 +350,7 @@ func TestService_ListUsersForRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListUsersByRoleId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.ListUsersForRoleRequest{},
 			expected:        &securitypb.ListUsersForRoleResponse{},
@@ -362,7 +366,7 @@ func TestService_ListUsersForRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListUsersByRoleId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.ListUsersForRoleRequest{
 				RoleId: "bad-uuid",
@@ -380,7 +384,7 @@ func TestService_ListUsersForRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListUsersByRoleId(gomock.Any()).Return(nil, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.ListUsersForRoleResponse{},
@@ -396,7 +400,7 @@ func TestService_ListUsersForRole(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListUsersByRoleId(gomock.Any()).Return([]string{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.ListUsersForRoleResponse{UserIds: []string{}},
@@ -461,7 +465,7 @@ func TestService_SetRolesForUser(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.SetRolesForUserRequest{},
 			expected:        &securitypb.SetRolesForUserResponse{},
@@ -477,7 +481,7 @@ func TestService_SetRolesForUser(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.SetRolesForUserRequest{
 				UserId: "bad-uuid",
@@ -495,7 +499,7 @@ func TestService_SetRolesForUser(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.SetRolesForUserRequest{
 				UserId:  uuid.New().String(),
@@ -515,7 +519,7 @@ func TestService_SetRolesForUser(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
 				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.SetRolesForUserResponse{},
@@ -532,7 +536,7 @@ func TestService_SetRolesForUser(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(nil, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.SetRolesForUserResponse{},
@@ -549,7 +553,7 @@ func TestService_SetRolesForUser(t *testing.T) {
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Return(nil)
 				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(models.RolesWithPermissions{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: validRequest,
 			expected: &securitypb.SetRolesForUserResponse{
@@ -615,9 +619,9 @@ func TestService_ListRolesForUser(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListByUserId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
-			request:         &securitypb.ListRolesForUserRequest{},
+			request:         validRequest,
 			expected:        &securitypb.ListRolesForUserResponse{},
 			expectedErrCode: codes.PermissionDenied,
 		},
@@ -626,12 +630,12 @@ func TestService_ListRolesForUser(t *testing.T) {
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
-				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListByUserId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.ListRolesForUserRequest{
 				UserId: "bad-uuid",
@@ -649,7 +653,7 @@ func TestService_ListRolesForUser(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListByUserId(gomock.Any()).Return(nil, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.ListRolesForUserResponse{},
@@ -665,7 +669,7 @@ func TestService_ListRolesForUser(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListByUserId(gomock.Any()).Return(models.Roles{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: validRequest,
 			expected: &securitypb.ListRolesForUserResponse{
@@ -731,7 +735,7 @@ func TestService_ListRolesWithPermissionsForUser(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         &securitypb.ListRolesWithPermissionsForUserRequest{},
 			expected:        &securitypb.ListRolesWithPermissionsForUserResponse{},
@@ -747,7 +751,7 @@ func TestService_ListRolesWithPermissionsForUser(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: &securitypb.ListRolesWithPermissionsForUserRequest{
 				UserId: "bad-uuid",
@@ -765,7 +769,7 @@ func TestService_ListRolesWithPermissionsForUser(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(nil, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request:         validRequest,
 			expected:        &securitypb.ListRolesWithPermissionsForUserResponse{},
@@ -781,7 +785,7 @@ func TestService_ListRolesWithPermissionsForUser(t *testing.T) {
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
 				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(models.RolesWithPermissions{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
 			request: validRequest,
 			expected: &securitypb.ListRolesWithPermissionsForUserResponse{
@@ -823,17 +827,52 @@ func TestService_ListRolesWithPermissionsForUser(t *testing.T) {
 	}
 }
 
-func TestService_ListUsersFull(t *testing.T) {
+func TestService_ListEffectivePermissionsForUser(t *testing.T) {
 	service := &Service{}
+	validRequest := &securitypb.ListEffectivePermissionsForUserRequest{
+		UserId: uuid.New().String(),
+		Scope:  string(models.FrontScope),
+	}
+	granted := models.RolesWithPermissions{
+		{
+			Permissions: models.Permissions{
+				{Value: "front.*"},
+				{Value: "admin.users.list"},
+			},
+		},
+	}
+	permissions := models.Permissions{
+		{Id: uuid.New(), Value: "front.brokers.list", Scope: models.FrontScope},
+		{Id: uuid.New(), Value: "front.transactions.list", Scope: models.FrontScope},
+		{Id: uuid.New(), Value: "admin.users.list", Scope: models.AdminScope},
+		{Id: uuid.New(), Value: "admin.users.delete", Scope: models.AdminScope},
+	}
 
 	// Define tests
 	tests := []struct {
 		name            string
 		mockSetup       func(ctrl *gomock.Controller)
-		request         *securitypb.ListUsersFullRequest
-		expected        *securitypb.ListUsersFullResponse
+		request         *securitypb.ListEffectivePermissionsForUserRequest
+		expected        []string
 		expectedErrCode codes.Code
 	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the repositories
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request: &securitypb.ListEffectivePermissionsForUserRequest{
+				UserId: "bad-uuid",
+			},
+			expectedErrCode: codes.InvalidArgument,
+		},
 		{
 			name: "does not have permission",
 			mockSetup: func(ctrl *gomock.Controller) {
@@ -841,17 +880,196 @@ func TestService_ListUsersFull(t *testing.T) {
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
 				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the repositories
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "invalid scope",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the repositories
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request: &securitypb.ListEffectivePermissionsForUserRequest{
+				UserId: validRequest.GetUserId(),
+				Scope:  "bad-scope",
+			},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to list user permissions",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the repositories
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(nil, errors.New("some error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "fails to list permissions",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the repositories
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(granted, nil)
+				rr.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				rr.EXPECT().List().Return(models.Roles{}, nil)
+				pr := mocks.NewSecurityPermissionRepository(ctrl)
+				pr.EXPECT().List().Return(nil, errors.New("some error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, pr, nil, nil))
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "expands the permissions of the requested scope",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the repositories
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(granted, nil)
+				rr.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				rr.EXPECT().List().Return(models.Roles{}, nil)
+				pr := mocks.NewSecurityPermissionRepository(ctrl)
+				pr.EXPECT().List().Return(permissions, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, pr, nil, nil))
+			},
+			request:         validRequest,
+			expected:        []string{"front.brokers.list", "front.transactions.list"},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "expands the permissions of every scope",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the repositories
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListWithPermissionsByUserId(gomock.Any()).Return(granted, nil)
+				rr.EXPECT().ListHierarchy().Return(models.RoleHierarchy{}, nil)
+				rr.EXPECT().List().Return(models.Roles{}, nil)
+				pr := mocks.NewSecurityPermissionRepository(ctrl)
+				pr.EXPECT().List().Return(permissions, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, pr, nil, nil))
+			},
+			request: &securitypb.ListEffectivePermissionsForUserRequest{
+				UserId: validRequest.GetUserId(),
+			},
+			expected:        []string{"front.brokers.list", "front.transactions.list", "admin.users.list"},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.ListEffectivePermissionsForUser(context.Background(), tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			values := make([]string, 0)
+			for _, permission := range response.GetPermissions() {
+				values = append(values, permission.GetValue())
+			}
+			if tt.expectedErrCode == codes.OK {
+				assert.Equal(t, tt.expected, values)
+			} else {
+				assert.Empty(t, values)
+			}
+		})
+	}
+}
+
+func TestService_DeleteRolesForUser(t *testing.T) {
+	service := &Service{}
+	validRequest := &securitypb.DeleteRolesForUserRequest{
+		UserId: uuid.New().String(),
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *securitypb.DeleteRolesForUserRequest
+		expected        *securitypb.DeleteRolesForUserResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
-				rr.EXPECT().ListUsers().Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
 			},
-			request:         &securitypb.ListUsersFullRequest{},
-			expected:        &securitypb.ListUsersFullResponse{},
+			request: &securitypb.DeleteRolesForUserRequest{
+				UserId: "bad-uuid",
+			},
+			expected:        &securitypb.DeleteRolesForUserResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.DeleteRolesForUserResponse{},
 			expectedErrCode: codes.PermissionDenied,
 		},
 		{
-			name: "fails to list users",
+			name: "fails to delete",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
@@ -859,26 +1077,194 @@ func TestService_ListUsersFull(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
-				rr.EXPECT().ListUsers().Return(nil, errors.New("some error"))
-				rr.EXPECT().ListByUserId(gomock.Any()).Times(0)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Return(errors.New("error"))
+				rr.EXPECT().ListGrantsByUserId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.DeleteRolesForUserResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "fails to count the remaining",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Return(nil)
+				rr.EXPECT().ListGrantsByUserId(gomock.Any()).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.DeleteRolesForUserResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().SetForUser(gomock.Any(), gomock.Any()).Return(nil)
+				rr.EXPECT().ListGrantsByUserId(gomock.Any()).Return(nil, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+			},
+			request:         validRequest,
+			expected:        &securitypb.DeleteRolesForUserResponse{Remaining: 0},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.DeleteRolesForUser(context.Background(), tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+}
+
+func TestService_ListUsersFull(t *testing.T) {
+	ctx := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{UserID: uuid.New()})
+	roleID := uuid.New()
+	users := []*userpb.User{
+		{Id: uuid.New().String(), Email: "jane@example.com"},
+	}
+	userID := uuid.MustParse(users[0].GetId())
+	roles := models.Roles{{Id: uuid.New(), Name: "admin"}}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller, uc *mocks.MockUserServiceClient)
+		request         *securitypb.ListUsersFullRequest
+		expected        *securitypb.ListUsersFullResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller, uc *mocks.MockUserServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user client
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
 			},
 			request:         &securitypb.ListUsersFullRequest{},
 			expected:        &securitypb.ListUsersFullResponse{},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails with an invalid role",
+			mockSetup: func(ctrl *gomock.Controller, uc *mocks.MockUserServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListActiveUsersByRoleId(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+				// Mock the user client
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			request:         &securitypb.ListUsersFullRequest{RoleId: "invalid"},
+			expected:        &securitypb.ListUsersFullResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to list the holders of the role",
+			mockSetup: func(ctrl *gomock.Controller, uc *mocks.MockUserServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListActiveUsersByRoleId(roleID).Return(nil, errors.New("some error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+				// Mock the user client
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			request:         &securitypb.ListUsersFullRequest{RoleId: roleID.String()},
+			expected:        &securitypb.ListUsersFullResponse{},
 			expectedErrCode: codes.Internal,
 		},
 		{
-			name: "fails to list user details",
-			mockSetup: func(ctrl *gomock.Controller) {
+			name: "no holder of the role",
+			mockSetup: func(ctrl *gomock.Controller, uc *mocks.MockUserServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListActiveUsersByRoleId(roleID).Return([]uuid.UUID{}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+				// Mock the user client
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			request:         &securitypb.ListUsersFullRequest{RoleId: roleID.String()},
+			expected:        &securitypb.ListUsersFullResponse{},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "fails to list users",
+			mockSetup: func(ctrl *gomock.Controller, uc *mocks.MockUserServiceClient) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
 				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
-				rr.EXPECT().ListUsers().Return([]string{uuid.New().String()}, nil)
-				rr.EXPECT().ListByUserId(gomock.Any()).Return(models.Roles{}, errors.New("some error"))
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				rr.EXPECT().ListByUserIds(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+				// Mock the user client
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, models.ErrUserSortInvalid.Error()))
+			},
+			request:         &securitypb.ListUsersFullRequest{Sort: "invalid"},
+			expected:        &securitypb.ListUsersFullResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to list the roles of the users",
+			mockSetup: func(ctrl *gomock.Controller, uc *mocks.MockUserServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the role repository
+				rr := mocks.NewSecurityRoleRepository(ctrl)
+				rr.EXPECT().ListByUserIds(gomock.Any()).Return(nil, errors.New("some error"))
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+				// Mock the user client
+				uc.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Return(&userpb.ListUsersResponse{Users: users}, nil)
 			},
 			request:         &securitypb.ListUsersFullRequest{},
 			expected:        &securitypb.ListUsersFullResponse{},
@@ -886,20 +1272,29 @@ func TestService_ListUsersFull(t *testing.T) {
 		},
 		{
 			name: "success",
-			mockSetup: func(ctrl *gomock.Controller) {
+			mockSetup: func(ctrl *gomock.Controller, uc *mocks.MockUserServiceClient) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
 				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the role repository
 				rr := mocks.NewSecurityRoleRepository(ctrl)
-				rr.EXPECT().ListUsers().Return([]string{uuid.New().String()}, nil)
-				rr.EXPECT().ListByUserId(gomock.Any()).Return(models.Roles{}, nil)
-				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil))
+				rr.EXPECT().ListActiveUsersByRoleId(roleID).Return([]uuid.UUID{userID}, nil)
+				rr.EXPECT().ListByUserIds([]uuid.UUID{userID}).Return(map[uuid.UUID]models.Roles{userID: roles}, nil)
+				repositories.ReplaceGlobals(repositories.NewRepository(rr, nil, nil, nil))
+				// Mock the user client
+				uc.EXPECT().ListUsers(gomock.Any(), &userpb.ListUsersRequest{
+					Sort:    models.UserSortEmailAsc,
+					Limit:   1,
+					UserIds: []string{userID.String()},
+				}).Return(&userpb.ListUsersResponse{Users: users, NextCursor: "next"}, nil)
 			},
-			request: &securitypb.ListUsersFullRequest{},
+			request: &securitypb.ListUsersFullRequest{Sort: models.UserSortEmailAsc, Limit: 1, RoleId: roleID.String()},
 			expected: &securitypb.ListUsersFullResponse{
-				Users: []*securitypb.UserWithRoles{},
+				Users: []*securitypb.UserWithRoles{
+					{UserId: userID.String(), Roles: mappers.RolesToProto(roles)},
+				},
+				NextCursor: "next",
 			},
 			expectedErrCode: codes.OK,
 		},
@@ -910,11 +1305,16 @@ func TestService_ListUsersFull(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Apply mocks
 			ctrl := gomock.NewController(t)
-			tt.mockSetup(ctrl)
+			uc := mocks.NewMockUserServiceClient(ctrl)
+			tt.mockSetup(ctrl, uc)
 			defer ctrl.Finish()
 
 			// Call service
-			response, err := service.ListUsersFull(context.Background(), tt.request)
+			service := &Service{
+				identity:   grpcutil.NewIdentityAuthority([]byte("test-key"), time.Minute),
+				userClient: uc,
+			}
+			response, err := service.ListUsersFull(ctx, tt.request)
 
 			// Handle errors
 			if err != nil && tt.expectedErrCode == codes.OK {
@@ -928,11 +1328,7 @@ func TestService_ListUsersFull(t *testing.T) {
 			}
 
 			// Handle response
-			if tt.expectedErrCode == codes.OK {
-				assert.NotNil(t, response)
-			} else {
-				assert.Equal(t, tt.expected, response)
-			}
+			assert.Equal(t, tt.expected, response)
 		})
 	}
 }
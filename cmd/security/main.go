@@ -4,12 +4,15 @@ import (
 	"github.com/Zapharaos/fihub-backend/cmd/security/app/repositories"
 	"github.com/Zapharaos/fihub-backend/cmd/security/app/service"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
 	"github.com/Zapharaos/fihub-backend/internal/app"
+	"github.com/Zapharaos/fihub-backend/internal/audit"
 	"github.com/Zapharaos/fihub-backend/internal/database"
 	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
 	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
 	"time"
 )
 
@@ -34,24 +37,51 @@ func main() {
 		return
 	}
 
+	// Setup gRPC clients
+	userConn := grpcutil.ConnectToClient("USER")
+	userClient := userpb.NewUserServiceClient(userConn)
+
 	// Register gRPC services
-	s := grpc.NewServer()
+	s := grpcutil.NewServer(serviceName)
 	publicService := &service.PublicService{}
 	securitypb.RegisterPublicSecurityServiceServer(s, publicService)
-	securitypb.RegisterSecurityServiceServer(s, &service.Service{})
+	securitypb.RegisterSecurityServiceServer(s, service.NewService(userClient))
+	auditService := &service.AuditService{}
+	securitypb.RegisterAuditServiceServer(s, auditService)
 	security.ReplaceGlobals(security.NewPublicSecurityFacade(publicService))
+	audit.ReplaceGlobals(audit.NewAuditFacade(auditService))
 
 	// Setup Database
+	// Redis is optional : it only holds the permission cache
+	if app.InitRedis() {
+		subscribePermissionCacheInvalidations()
+	}
 	if app.InitPostgres() {
-		setupPostgresRepositories()
+		setupRepositories()
+		syncPermissions()
 	}
 
 	// Start databases health monitoring
-	database.StartHealthMonitoring("Postgres", 30*time.Second, database.DB().Postgres(), func() {
+	healthMonitor := database.NewHealthMonitor(30 * time.Second)
+	healthMonitor.AddTarget("Postgres", database.DB().Postgres(), func() {
 		if app.InitPostgres() {
-			setupPostgresRepositories()
+			setupRepositories()
+			syncPermissions()
 		}
 	})
+	healthMonitor.AddTarget("Redis", database.DB().Redis(), func() {
+		if app.InitRedis() {
+			subscribePermissionCacheInvalidations()
+			setupRepositories()
+		}
+	})
+	healthMonitor.Start()
+
+	// Start removing the expired role grants and audit logs
+	stopRoleGrantsSweeper := database.StartSweeper(roleGrantsSweepInterval(), serverHealthStatusIsHealthy, service.SweepExpiredRoleGrants)
+	stopAuditLogsSweeper := database.StartSweeper(auditSweepInterval(), serverHealthStatusIsHealthy, func() {
+		service.SweepAuditLogs(auditRetention())
+	})
 
 	// Register gRPC health service
 	grpcutil.RegisterHealthServer(s, 30*time.Second, serviceName, serverHealthStatusIsHealthy)
@@ -62,14 +92,96 @@ func main() {
 
 	// Shutdown
 	zap.L().Info("Shutdown gRPC server", zap.String("service", serviceName))
+	stopRoleGrantsSweeper()
+	stopAuditLogsSweeper()
 	s.GracefulStop() // Stop server cleanly
 }
 
-// setupPostgresRepositories initializes the Postgres repositories for the microservice.
-func setupPostgresRepositories() {
+// setupRepositories initializes the repositories for the microservice.
+// The permission cache is only enabled when Redis is available.
+func setupRepositories() {
 	roleRepository := repositories.NewRolePostgresRepository(database.DB().Postgres().DB)
 	permissionRepository := repositories.NewPermissionPostgresRepository(database.DB().Postgres().DB)
-	repositories.ReplaceGlobals(repositories.NewRepository(roleRepository, permissionRepository))
+	auditRepository := repositories.NewAuditPostgresRepository(database.DB().Postgres().DB)
+	repositories.ReplaceGlobals(repositories.NewRepository(roleRepository, permissionRepository, permissionCacheRepository(), auditRepository))
+}
+
+// syncPermissions synchronises the permissions declared by the microservices into the database
+func syncPermissions() {
+	_, err := service.SyncPermissions(security.DeclaredPermissions())
+	if err != nil {
+		zap.L().Error("Cannot synchronise the declared permissions", zap.Error(err))
+	}
+}
+
+// permissionCacheRepository returns the Redis permission cache, or nil if Redis is unavailable
+func permissionCacheRepository() repositories.PermissionCacheRepository {
+	if database.DB().Redis().Client == nil {
+		return nil
+	}
+	return repositories.NewPermissionCacheRedisRepository(database.DB().Redis().Client, permissionCacheTTL())
+}
+
+// permissionCacheTTL returns the lifetime of the cached permissions
+func permissionCacheTTL() time.Duration {
+	ttl := viper.GetDuration("SECURITY_PERMISSIONS_CACHE_TTL")
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+	return ttl
+}
+
+// roleGrantsSweepInterval returns the delay between two sweeps of the expired role grants
+func roleGrantsSweepInterval() time.Duration {
+	interval := viper.GetDuration("SECURITY_ROLE_GRANTS_SWEEP_INTERVAL")
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return interval
+}
+
+// auditRetention returns how long the audit log entries are kept
+func auditRetention() time.Duration {
+	retention := viper.GetDuration("SECURITY_AUDIT_RETENTION")
+	if retention <= 0 {
+		retention = 365 * 24 * time.Hour
+	}
+	return retention
+}
+
+// auditSweepInterval returns the delay between two sweeps of the expired audit log entries
+func auditSweepInterval() time.Duration {
+	interval := viper.GetDuration("SECURITY_AUDIT_SWEEP_INTERVAL")
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return interval
+}
+
+// unsubscribePermissionCacheInvalidations stops the current invalidations subscription, if any
+var unsubscribePermissionCacheInvalidations func() error
+
+// subscribePermissionCacheInvalidations drops the in-process permission decisions whenever the cached permissions are invalidated,
+// including by other instances of the microservice. Any previous subscription is replaced.
+func subscribePermissionCacheInvalidations() {
+	cache := permissionCacheRepository()
+	if cache == nil {
+		return
+	}
+
+	if unsubscribePermissionCacheInvalidations != nil {
+		_ = unsubscribePermissionCacheInvalidations()
+		unsubscribePermissionCacheInvalidations = nil
+	}
+
+	unsubscribe, err := cache.Subscribe(func(userIDs []uuid.UUID) {
+		security.Facade().Invalidate(userIDs...)
+	})
+	if err != nil {
+		zap.L().Error("Subscribe to permission cache invalidations", zap.Error(err))
+		return
+	}
+	unsubscribePermissionCacheInvalidations = unsubscribe
 }
 
 // serverHealthStatusIsHealthy indicates whether the server is healthy.
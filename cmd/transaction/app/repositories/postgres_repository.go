@@ -195,3 +195,16 @@ func (r PostgresRepository) GetAll(userID uuid.UUID) ([]models.Transaction, erro
 
 	return utils.ScanAllStruct[models.Transaction](rows)
 }
+
+// DeleteAll use to delete every Transaction of a user
+func (r PostgresRepository) DeleteAll(userID uuid.UUID) error {
+	// Prepare query
+	query := `DELETE FROM transactions as t WHERE t.user_id = :user_id`
+	params := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	// Execute query
+	_, err := r.conn.NamedExec(query, params)
+	return err
+}
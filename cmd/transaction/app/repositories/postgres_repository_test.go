@@ -348,3 +348,43 @@ func TestPostgresRepository_GetAll(t *testing.T) {
 		})
 	}
 }
+
+// TestPostgresRepository_DeleteAll test the DeleteAll method
+func TestPostgresRepository_DeleteAll(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail transactions delete",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM transactions").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Delete transactions",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM transactions").WillReturnResult(sqlxmock.NewResult(1, 10))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().DeleteAll(uuid.New())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("DeleteAll() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ type Repository interface {
 	DeleteByBroker(transaction models.Transaction) error
 	Exists(transactionID uuid.UUID, userID uuid.UUID) (bool, error)
 	GetAll(userID uuid.UUID) ([]models.Transaction, error)
+	DeleteAll(userID uuid.UUID) error
 }
 
 var (
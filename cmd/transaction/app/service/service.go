@@ -6,6 +6,7 @@ import (
 	"github.com/Zapharaos/fihub-backend/gen/go/transactionpb"
 	"github.com/Zapharaos/fihub-backend/internal/mappers"
 	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -118,6 +119,18 @@ func (s *Service) GetTransaction(ctx context.Context, req *transactionpb.GetTran
 		}, status.Error(codes.NotFound, "Transaction not found")
 	}
 
+	// Verify that the user can access the transaction
+	err = security.Facade().CheckResourceAccess(ctx, security.ActionRead, security.Resource{
+		Type:    security.ResourceTransaction,
+		OwnerID: t.UserID,
+	})
+	if err != nil {
+		zap.L().Warn("CheckResourceAccess", zap.String("uuid", transactionID.String()), zap.Error(err))
+		return &transactionpb.GetTransactionResponse{
+			Transaction: nil,
+		}, err
+	}
+
 	// Return the transaction
 	return &transactionpb.GetTransactionResponse{
 		Transaction: mappers.TransactionToProto(t),
@@ -208,7 +221,7 @@ func (s *Service) UpdateTransaction(ctx context.Context, req *transactionpb.Upda
 		}, status.Error(codes.InvalidArgument, validationErr.Error())
 	}
 
-	// Verify that the transaction belongs to the user
+	// Retrieve the transaction to update
 	oldTransaction, ok, err := repositories.R().Get(transactionID)
 	if err != nil {
 		zap.L().Error("Cannot get transaction", zap.String("uuid", transactionID.String()), zap.Error(err))
@@ -222,11 +235,17 @@ func (s *Service) UpdateTransaction(ctx context.Context, req *transactionpb.Upda
 			Transaction: nil,
 		}, status.Error(codes.NotFound, "Transaction not found")
 	}
-	if oldTransaction.UserID != userID {
-		zap.L().Warn("Transaction does not belong to user", zap.String("uuid", transactionID.String()))
+
+	// Verify that the user can update the transaction
+	err = security.Facade().CheckResourceAccess(ctx, security.ActionUpdate, security.Resource{
+		Type:    security.ResourceTransaction,
+		OwnerID: oldTransaction.UserID,
+	})
+	if err != nil {
+		zap.L().Warn("CheckResourceAccess", zap.String("uuid", transactionID.String()), zap.Error(err))
 		return &transactionpb.UpdateTransactionResponse{
 			Transaction: nil,
-		}, status.Error(codes.PermissionDenied, "Transaction does not belong to user")
+		}, err
 	}
 
 	// Update the transaction
@@ -277,7 +296,7 @@ func (s *Service) DeleteTransaction(ctx context.Context, req *transactionpb.Dele
 		return &transactionpb.DeleteTransactionResponse{}, status.Error(codes.InvalidArgument, "Invalid transaction ID")
 	}
 
-	// Verify that the transaction belongs to the user
+	// Retrieve the transaction to delete
 	t, ok, err := repositories.R().Get(transactionID)
 	if err != nil {
 		zap.L().Error("Cannot get transaction", zap.String("uuid", transactionID.String()), zap.Error(err))
@@ -287,9 +306,15 @@ func (s *Service) DeleteTransaction(ctx context.Context, req *transactionpb.Dele
 		zap.L().Error("Transaction not found", zap.String("uuid", transactionID.String()))
 		return &transactionpb.DeleteTransactionResponse{}, status.Error(codes.NotFound, "Transaction not found")
 	}
-	if t.UserID != userID {
-		zap.L().Warn("Transaction does not belong to user", zap.String("uuid", transactionID.String()))
-		return &transactionpb.DeleteTransactionResponse{}, status.Error(codes.PermissionDenied, "Transaction does not belong to user")
+
+	// Verify that the user can delete the transaction
+	err = security.Facade().CheckResourceAccess(ctx, security.ActionDelete, security.Resource{
+		Type:    security.ResourceTransaction,
+		OwnerID: t.UserID,
+	})
+	if err != nil {
+		zap.L().Warn("CheckResourceAccess", zap.String("uuid", transactionID.String()), zap.Error(err))
+		return &transactionpb.DeleteTransactionResponse{}, err
 	}
 
 	// Remove transaction
@@ -336,3 +361,40 @@ func (s *Service) DeleteTransactionByBroker(ctx context.Context, req *transactio
 	// Return success response
 	return &transactionpb.DeleteTransactionByBrokerResponse{}, nil
 }
+
+// DeleteUserTransactions implements the DeleteUserTransactions RPC method.
+// Called by the user microservice once the deletion of an account is due, it reports how many transactions remain.
+func (s *Service) DeleteUserTransactions(ctx context.Context, req *transactionpb.DeleteUserTransactionsRequest) (*transactionpb.DeleteUserTransactionsResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &transactionpb.DeleteUserTransactionsResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.delete", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &transactionpb.DeleteUserTransactionsResponse{}, err
+	}
+
+	// Remove all the transactions of the user
+	err = repositories.R().DeleteAll(userID)
+	if err != nil {
+		zap.L().Error("Cannot remove user transactions", zap.String("uuid", userID.String()), zap.Error(err))
+		return &transactionpb.DeleteUserTransactionsResponse{}, status.Error(codes.Internal, "Failed to remove user transactions")
+	}
+
+	// Report what is left, so that the caller can verify the deletion
+	remaining, err := repositories.R().GetAll(userID)
+	if err != nil {
+		zap.L().Error("Cannot get transactions", zap.String("uuid", userID.String()), zap.Error(err))
+		return &transactionpb.DeleteUserTransactionsResponse{}, status.Error(codes.Internal, "Failed to get transactions")
+	}
+
+	return &transactionpb.DeleteUserTransactionsResponse{
+		Remaining: int64(len(remaining)),
+	}, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"github.com/Zapharaos/fihub-backend/cmd/transaction/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
 	"github.com/Zapharaos/fihub-backend/gen/go/transactionpb"
 	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
 	"github.com/Zapharaos/fihub-backend/test/mocks"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -251,9 +253,33 @@ func TestGetTransaction(t *testing.T) {
 			},
 			expectedErrCode: codes.NotFound,
 		},
+		{
+			name: "does not have access to the transaction",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				tr := mocks.NewTransactionsRepository(ctrl)
+				tr.EXPECT().Get(gomock.Any()).Return(models.Transaction{
+					ID:     transactionID,
+					UserID: uuid.New(),
+				}, true, nil)
+				repositories.ReplaceGlobals(tr)
+			},
+			request: request,
+			expected: &transactionpb.GetTransactionResponse{
+				Transaction: nil,
+			},
+			expectedErrCode: codes.PermissionDenied,
+		},
 		{
 			name: "succeeded",
 			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				tr := mocks.NewTransactionsRepository(ctrl)
 				tr.EXPECT().Get(gomock.Any()).Return(models.Transaction{
 					ID: transactionID,
@@ -511,8 +537,12 @@ func TestUpdateTransaction(t *testing.T) {
 			expectedErrCode: codes.NotFound,
 		},
 		{
-			name: "transaction user does not correspond to context user",
+			name: "does not have access to the transaction",
 			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				tr := mocks.NewTransactionsRepository(ctrl)
 				tr.EXPECT().Get(gomock.Any()).Return(models.Transaction{UserID: uuid.New()}, true, nil)
 				tr.EXPECT().Update(gomock.Any()).Times(0)
@@ -524,6 +554,10 @@ func TestUpdateTransaction(t *testing.T) {
 		{
 			name: "fails to update the transaction",
 			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				tr := mocks.NewTransactionsRepository(ctrl)
 				tr.EXPECT().Get(gomock.Any()).Return(models.Transaction{UserID: userID}, true, nil)
 				tr.EXPECT().Update(gomock.Any()).Return(errors.New("error"))
@@ -536,6 +570,10 @@ func TestUpdateTransaction(t *testing.T) {
 		{
 			name: "fails to retrieve the transaction after update",
 			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				tr := mocks.NewTransactionsRepository(ctrl)
 				tr.EXPECT().Get(gomock.Any()).Return(models.Transaction{UserID: userID}, true, nil)
 				tr.EXPECT().Update(gomock.Any()).Return(nil)
@@ -548,6 +586,10 @@ func TestUpdateTransaction(t *testing.T) {
 		{
 			name: "fails to find the transaction after update",
 			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				tr := mocks.NewTransactionsRepository(ctrl)
 				tr.EXPECT().Get(gomock.Any()).Return(models.Transaction{UserID: userID}, true, nil)
 				tr.EXPECT().Update(gomock.Any()).Return(nil)
@@ -560,6 +602,10 @@ func TestUpdateTransaction(t *testing.T) {
 		{
 			name: "succeeded",
 			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				tr := mocks.NewTransactionsRepository(ctrl)
 				tr.EXPECT().Get(gomock.Any()).Return(models.Transaction{
 					UserID: userID,
@@ -674,8 +720,12 @@ func TestDeleteTransaction(t *testing.T) {
 			expectedErrCode: codes.NotFound,
 		},
 		{
-			name: "transaction user does not correspond to context user",
+			name: "does not have access to the transaction",
 			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				tr := mocks.NewTransactionsRepository(ctrl)
 				tr.EXPECT().Get(gomock.Any()).Return(models.Transaction{UserID: uuid.New()}, true, nil)
 				tr.EXPECT().Delete(gomock.Any()).Times(0)
@@ -687,6 +737,10 @@ func TestDeleteTransaction(t *testing.T) {
 		{
 			name: "fails to delete the transaction",
 			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				tr := mocks.NewTransactionsRepository(ctrl)
 				tr.EXPECT().Get(gomock.Any()).Return(models.Transaction{UserID: userID}, true, nil)
 				tr.EXPECT().Delete(gomock.Any()).Return(errors.New("error"))
@@ -698,6 +752,10 @@ func TestDeleteTransaction(t *testing.T) {
 		{
 			name: "succeeded",
 			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				tr := mocks.NewTransactionsRepository(ctrl)
 				tr.EXPECT().Get(gomock.Any()).Return(models.Transaction{UserID: userID}, true, nil)
 				tr.EXPECT().Delete(gomock.Any()).Return(nil)
@@ -830,3 +888,133 @@ func TestDeleteTransactionByBroker(t *testing.T) {
 		})
 	}
 }
+
+// TestDeleteUserTransactions tests the DeleteUserTransactions service
+func TestDeleteUserTransactions(t *testing.T) {
+	service := &Service{}
+	validRequest := &transactionpb.DeleteUserTransactionsRequest{
+		UserId: uuid.New().String(),
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *transactionpb.DeleteUserTransactionsRequest
+		expected        *transactionpb.DeleteUserTransactionsResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the transaction repository
+				tr := mocks.NewTransactionsRepository(ctrl)
+				tr.EXPECT().DeleteAll(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(tr)
+			},
+			request: &transactionpb.DeleteUserTransactionsRequest{
+				UserId: "bad-uuid",
+			},
+			expected:        &transactionpb.DeleteUserTransactionsResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the transaction repository
+				tr := mocks.NewTransactionsRepository(ctrl)
+				tr.EXPECT().DeleteAll(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(tr)
+			},
+			request:         validRequest,
+			expected:        &transactionpb.DeleteUserTransactionsResponse{},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to delete",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the transaction repository
+				tr := mocks.NewTransactionsRepository(ctrl)
+				tr.EXPECT().DeleteAll(gomock.Any()).Return(errors.New("error"))
+				tr.EXPECT().GetAll(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(tr)
+			},
+			request:         validRequest,
+			expected:        &transactionpb.DeleteUserTransactionsResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "fails to count the remaining",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the transaction repository
+				tr := mocks.NewTransactionsRepository(ctrl)
+				tr.EXPECT().DeleteAll(gomock.Any()).Return(nil)
+				tr.EXPECT().GetAll(gomock.Any()).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(tr)
+			},
+			request:         validRequest,
+			expected:        &transactionpb.DeleteUserTransactionsResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the transaction repository
+				tr := mocks.NewTransactionsRepository(ctrl)
+				tr.EXPECT().DeleteAll(gomock.Any()).Return(nil)
+				tr.EXPECT().GetAll(gomock.Any()).Return(nil, nil)
+				repositories.ReplaceGlobals(tr)
+			},
+			request:         validRequest,
+			expected:        &transactionpb.DeleteUserTransactionsResponse{Remaining: 0},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.DeleteUserTransactions(context.Background(), tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Command translations checks the translation files against the Go sources.
+// For each language, it reports the keys referenced by the sources which are not translated,
+// and the translated keys which are no longer referenced. It exits with a non-zero status if any is found.
+//
+// Usage, from the root of the repository:
+//
+//	go run ./cmd/translations [-dir config/translations] [-src .]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"os"
+)
+
+func main() {
+	dir := flag.String("dir", translation.TranslationsDir, "directory of the translation files")
+	src := flag.String("src", ".", "root directory of the Go sources")
+	flag.Parse()
+
+	reports, err := translation.CheckKeys(*dir, *src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "check translations:", err)
+		os.Exit(2)
+	}
+	if len(reports) == 0 {
+		fmt.Fprintln(os.Stderr, "no translation file found in", *dir)
+		os.Exit(2)
+	}
+
+	valid := true
+	for _, report := range reports {
+		if report.IsValid() {
+			fmt.Printf("%s: ok\n", report.Language)
+			continue
+		}
+		valid = false
+		for _, key := range report.Missing {
+			fmt.Printf("%s: missing %s\n", report.Language, key)
+		}
+		for _, key := range report.Unused {
+			fmt.Printf("%s: unused %s\n", report.Language, key)
+		}
+	}
+
+	if !valid {
+		os.Exit(1)
+	}
+}
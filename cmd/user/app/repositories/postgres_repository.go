@@ -4,9 +4,12 @@ import (
 	"errors"
 	"github.com/Zapharaos/fihub-backend/internal/models"
 	"github.com/Zapharaos/fihub-backend/internal/utils"
+	"github.com/Zapharaos/fihub-backend/pkg/hasher"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+	"strings"
 	"time"
 )
 
@@ -31,7 +34,7 @@ func (r *PostgresRepository) Create(user models.UserWithPassword) (uuid.UUID, er
 	userID := uuid.New()
 
 	// Hash password before saving
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	hashedPassword, err := hasher.S().Hash(user.Password)
 	if err != nil {
 		return uuid.UUID{}, err
 	}
@@ -41,12 +44,13 @@ func (r *PostgresRepository) Create(user models.UserWithPassword) (uuid.UUID, er
 	updateTS := creationTS
 
 	// Prepare query
-	query := `INSERT INTO Users (ID, email, password, created_at, updated_at)
-				VALUES (:ID, :email, :password, :created_at, :updated_at)`
+	query := `INSERT INTO Users (ID, email, password, language, created_at, updated_at)
+				VALUES (:ID, :email, :password, :language, :created_at, :updated_at)`
 	params := map[string]interface{}{
 		"ID":         userID,
 		"email":      user.Email,
 		"password":   hashedPassword,
+		"language":   user.Language,
 		"created_at": creationTS,
 		"updated_at": updateTS,
 	}
@@ -64,7 +68,8 @@ func (r *PostgresRepository) Create(user models.UserWithPassword) (uuid.UUID, er
 func (r *PostgresRepository) Get(userID uuid.UUID) (models.User, bool, error) {
 
 	// Prepare query
-	query := `SELECT u.id, u.email, u.created_at, u.updated_at
+	query := `SELECT u.id, u.email, u.email_verified, u.created_at, u.updated_at, u.deletion_scheduled_at, u.disabled_at, u.password_reset_required, u.last_login_at,
+			         u.display_name, u.language, u.timezone, u.base_currency, u.number_format, u.date_format
 			  FROM Users as u
 			  WHERE u.ID = :ID`
 	params := map[string]interface{}{
@@ -85,7 +90,8 @@ func (r *PostgresRepository) Get(userID uuid.UUID) (models.User, bool, error) {
 func (r *PostgresRepository) GetByEmail(email string) (models.User, bool, error) {
 
 	// Prepare query
-	query := `SELECT u.id, u.email, u.created_at, u.updated_at
+	query := `SELECT u.id, u.email, u.email_verified, u.created_at, u.updated_at, u.deletion_scheduled_at, u.disabled_at, u.password_reset_required, u.last_login_at,
+			         u.display_name, u.language, u.timezone, u.base_currency, u.number_format, u.date_format
 			  FROM Users as u
 			  WHERE u.email = :email`
 	params := map[string]interface{}{
@@ -125,7 +131,8 @@ func (r *PostgresRepository) Exists(email string) (bool, error) {
 // Authenticate returns a User from the repository by its login and password
 func (r *PostgresRepository) Authenticate(email string, password string) (models.User, bool, error) {
 	// Prepare query
-	query := `SELECT id, email, created_at, updated_at, password 
+	query := `SELECT id, email, email_verified, created_at, updated_at, deletion_scheduled_at, disabled_at, password_reset_required, last_login_at, password,
+			         display_name, language, timezone, base_currency, number_format, date_format
 			  FROM Users as u
 			  WHERE u.email = :email`
 	params := map[string]interface{}{
@@ -148,12 +155,24 @@ func (r *PostgresRepository) Authenticate(email string, password string) (models
 		return models.User{}, false, errors.New("no User Found, invalid credentials")
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(userWithPassword.Password), []byte(password))
-	if err == nil {
-		return userWithPassword.User, true, nil
+	match, rehash, err := hasher.S().Verify(password, userWithPassword.Password)
+	if err != nil {
+		return models.User{}, false, err
+	}
+	if !match {
+		return models.User{}, false, errors.New("no User Found, invalid credentials")
 	}
 
-	return models.User{}, false, errors.New("no User Found, invalid credentials")
+	// Transparently upgrade the hash to the current algorithm and parameters
+	if rehash {
+		err = r.rehashPassword(userWithPassword.ID, password)
+		if err != nil {
+			// The user is authenticated anyway, the upgrade will be attempted again on next login
+			zap.L().Warn("Rehash password", zap.String("uuid", userWithPassword.ID.String()), zap.Error(err))
+		}
+	}
+
+	return userWithPassword.User, true, nil
 }
 
 // Update method used to update a User
@@ -161,12 +180,42 @@ func (r *PostgresRepository) Update(user models.User) error {
 
 	// Prepare query
 	query := `UPDATE Users as u
-			  SET email = :email, updated_at = :updated_at
+			  SET email = :email, email_verified = :email_verified, updated_at = :updated_at
 			  WHERE u.ID = :ID`
 	params := map[string]interface{}{
-		"ID":         user.ID,
-		"email":      user.Email,
-		"updated_at": time.Now().Truncate(1 * time.Millisecond).UTC(),
+		"ID":             user.ID,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"updated_at":     time.Now().Truncate(1 * time.Millisecond).UTC(),
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// UpdateProfile method used to update the profile of a User
+func (r *PostgresRepository) UpdateProfile(userID uuid.UUID, profile models.UserProfile) error {
+
+	// Prepare query
+	query := `UPDATE Users as u
+			  SET display_name = :display_name, language = :language, timezone = :timezone,
+			      base_currency = :base_currency, number_format = :number_format, date_format = :date_format,
+			      updated_at = :updated_at
+			  WHERE u.ID = :ID`
+	params := map[string]interface{}{
+		"ID":            userID,
+		"display_name":  profile.DisplayName,
+		"language":      profile.Language,
+		"timezone":      profile.Timezone,
+		"base_currency": profile.BaseCurrency,
+		"number_format": profile.NumberFormat,
+		"date_format":   profile.DateFormat,
+		"updated_at":    time.Now().Truncate(1 * time.Millisecond).UTC(),
 	}
 
 	// Execute query
@@ -182,14 +231,14 @@ func (r *PostgresRepository) Update(user models.User) error {
 func (r *PostgresRepository) UpdateWithPassword(user models.UserWithPassword) error {
 
 	// Hash password before saving
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	hashedPassword, err := hasher.S().Hash(user.Password)
 	if err != nil {
 		return err
 	}
 
-	// Prepare query
+	// Prepare query : setting a new password fulfills the forced reset
 	query := `UPDATE Users as u
-			  SET password = :password, updated_at = :updated_at
+			  SET password = :password, password_reset_required = false, updated_at = :updated_at
 			  WHERE u.ID = :ID`
 	params := map[string]interface{}{
 		"ID":         user.ID,
@@ -206,6 +255,36 @@ func (r *PostgresRepository) UpdateWithPassword(user models.UserWithPassword) er
 	return utils.CheckRowAffected(result, 1)
 }
 
+// rehashPassword hashes the password again and replaces the stored hash of a User
+func (r *PostgresRepository) rehashPassword(userID uuid.UUID, password string) error {
+	hashedPassword, err := hasher.S().Hash(password)
+	if err != nil {
+		return err
+	}
+	return r.updatePasswordHash(userID, hashedPassword)
+}
+
+// updatePasswordHash replaces the password hash of a User, without changing its update date
+func (r *PostgresRepository) updatePasswordHash(userID uuid.UUID, hashedPassword string) error {
+
+	// Prepare query
+	query := `UPDATE Users as u
+			  SET password = :password
+			  WHERE u.ID = :ID`
+	params := map[string]interface{}{
+		"ID":       userID,
+		"password": hashedPassword,
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
 // Delete method used to delete a User
 func (r *PostgresRepository) Delete(userID uuid.UUID) error {
 
@@ -225,14 +304,120 @@ func (r *PostgresRepository) Delete(userID uuid.UUID) error {
 	return utils.CheckRowAffected(result, 1)
 }
 
-// List method used to list all Users
-func (r *PostgresRepository) List() (models.Users, error) {
+// ScheduleDeletion method used to schedule the deletion of a User
+func (r *PostgresRepository) ScheduleDeletion(userID uuid.UUID, scheduledAt time.Time) error {
+
+	// Prepare query
+	query := `UPDATE Users as u
+			  SET deletion_scheduled_at = :deletion_scheduled_at, updated_at = :updated_at
+			  WHERE u.ID = :ID`
+	params := map[string]interface{}{
+		"ID":                    userID,
+		"deletion_scheduled_at": scheduledAt.Truncate(1 * time.Millisecond).UTC(),
+		"updated_at":            time.Now().Truncate(1 * time.Millisecond).UTC(),
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// CancelDeletion method used to cancel the scheduled deletion of a User
+func (r *PostgresRepository) CancelDeletion(userID uuid.UUID) error {
+
+	// Prepare query
+	query := `UPDATE Users as u
+			  SET deletion_scheduled_at = NULL, updated_at = :updated_at
+			  WHERE u.ID = :ID`
+	params := map[string]interface{}{
+		"ID":         userID,
+		"updated_at": time.Now().Truncate(1 * time.Millisecond).UTC(),
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// ListDueForDeletion method used to list the Users whose scheduled deletion is due at the given time
+func (r *PostgresRepository) ListDueForDeletion(at time.Time) (models.Users, error) {
 	// Prepare query
-	query := `SELECT u.id, u.email, u.created_at, u.updated_at
-			  FROM Users as u`
+	query := `SELECT u.id, u.email, u.email_verified, u.created_at, u.updated_at, u.deletion_scheduled_at, u.disabled_at, u.password_reset_required, u.last_login_at,
+			         u.display_name, u.language, u.timezone, u.base_currency, u.number_format, u.date_format
+			  FROM Users as u
+			  WHERE u.deletion_scheduled_at <= :at`
+	params := map[string]interface{}{
+		"at": at.UTC(),
+	}
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return utils.ScanAllStruct[models.User](rows)
+}
+
+// List method used to list a page of the Users matching the filter, with one more User than the limit of the filter
+func (r *PostgresRepository) List(filter models.UserFilter) (models.Users, error) {
+	filter = filter.Normalize()
+
+	// Only filter on the given criteria
+	conditions := make([]string, 0)
+	params := map[string]interface{}{
+		"limit": filter.Limit + 1,
+	}
+	if filter.Email != "" {
+		conditions = append(conditions, "lower(u.email) LIKE :email")
+		params["email"] = escapeLike(strings.ToLower(filter.Email)) + "%"
+	}
+	if len(filter.UserIDs) > 0 {
+		conditions = append(conditions, "u.id = ANY(:ids)")
+		params["ids"] = pq.Array(filter.UserIDs)
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "u.created_at >= :created_from")
+		params["created_from"] = *filter.CreatedFrom
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "u.created_at < :created_to")
+		params["created_to"] = *filter.CreatedTo
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, userStatusConditions[filter.Status])
+	}
+	if filter.After != nil {
+		conditions = append(conditions, userAfterConditions[filter.Sort])
+		params["after_created_at"] = filter.After.CreatedAt
+		params["after_email"] = filter.After.Email
+		params["after_id"] = filter.After.ID
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Prepare query : one more user than the limit tells whether a next page exists
+	query := `SELECT u.id, u.email, u.email_verified, u.created_at, u.updated_at, u.deletion_scheduled_at, u.disabled_at, u.password_reset_required, u.last_login_at,
+			         u.display_name, u.language, u.timezone, u.base_currency, u.number_format, u.date_format
+			  FROM Users as u
+			  ` + where + `
+			  ORDER BY ` + userOrderBy[filter.Sort] + `
+			  LIMIT :limit`
 
 	// Execute query
-	rows, err := r.conn.Queryx(query)
+	rows, err := r.conn.NamedQuery(query, params)
 	if err != nil {
 		return nil, err
 	}
@@ -240,3 +425,262 @@ func (r *PostgresRepository) List() (models.Users, error) {
 
 	return utils.ScanAllStruct[models.User](rows)
 }
+
+// userOrderBy maps the sorts of the users to their ORDER BY clause, the ID breaks the ties
+var userOrderBy = map[models.UserSort]string{
+	models.UserSortCreatedAtAsc:  "u.created_at ASC, u.id ASC",
+	models.UserSortCreatedAtDesc: "u.created_at DESC, u.id DESC",
+	models.UserSortEmailAsc:      "u.email ASC, u.id ASC",
+	models.UserSortEmailDesc:     "u.email DESC, u.id DESC",
+}
+
+// userAfterConditions maps the sorts of the users to the condition selecting the users after a cursor
+var userAfterConditions = map[models.UserSort]string{
+	models.UserSortCreatedAtAsc:  "(u.created_at, u.id) > (:after_created_at, :after_id)",
+	models.UserSortCreatedAtDesc: "(u.created_at, u.id) < (:after_created_at, :after_id)",
+	models.UserSortEmailAsc:      "(u.email, u.id) > (:after_email, :after_id)",
+	models.UserSortEmailDesc:     "(u.email, u.id) < (:after_email, :after_id)",
+}
+
+// userStatusConditions maps the statuses of the users to their condition
+var userStatusConditions = map[models.UserStatus]string{
+	models.UserStatusActive:          "u.disabled_at IS NULL AND u.email_verified AND u.deletion_scheduled_at IS NULL",
+	models.UserStatusUnverified:      "NOT u.email_verified",
+	models.UserStatusDisabled:        "u.disabled_at IS NOT NULL",
+	models.UserStatusPendingDeletion: "u.deletion_scheduled_at IS NOT NULL",
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(pattern string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(pattern)
+}
+
+// Disable method used to disable a User, who can no longer log in
+func (r *PostgresRepository) Disable(userID uuid.UUID, disabledAt time.Time) error {
+
+	// Prepare query
+	query := `UPDATE Users as u
+			  SET disabled_at = :disabled_at, updated_at = :updated_at
+			  WHERE u.ID = :ID`
+	params := map[string]interface{}{
+		"ID":          userID,
+		"disabled_at": disabledAt.Truncate(1 * time.Millisecond).UTC(),
+		"updated_at":  time.Now().Truncate(1 * time.Millisecond).UTC(),
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// Enable method used to enable a disabled User
+func (r *PostgresRepository) Enable(userID uuid.UUID) error {
+
+	// Prepare query
+	query := `UPDATE Users as u
+			  SET disabled_at = NULL, updated_at = :updated_at
+			  WHERE u.ID = :ID`
+	params := map[string]interface{}{
+		"ID":         userID,
+		"updated_at": time.Now().Truncate(1 * time.Millisecond).UTC(),
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// RequirePasswordReset method used to force a User to reset its password before logging in again
+func (r *PostgresRepository) RequirePasswordReset(userID uuid.UUID) error {
+
+	// Prepare query
+	query := `UPDATE Users as u
+			  SET password_reset_required = true, updated_at = :updated_at
+			  WHERE u.ID = :ID`
+	params := map[string]interface{}{
+		"ID":         userID,
+		"updated_at": time.Now().Truncate(1 * time.Millisecond).UTC(),
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// GetAvatar method used to retrieve the avatar of a User
+func (r *PostgresRepository) GetAvatar(userID uuid.UUID) (models.UserAvatar, bool, error) {
+	// Prepare query
+	query := `SELECT a.user_id, a.name, a.data, a.updated_at
+			  FROM user_avatar as a
+			  WHERE a.user_id = :user_id`
+	params := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return models.UserAvatar{}, false, err
+	}
+	defer rows.Close()
+
+	return utils.ScanFirstStruct[models.UserAvatar](rows)
+}
+
+// SetAvatar method used to create or replace the avatar of a User
+func (r *PostgresRepository) SetAvatar(avatar models.UserAvatar) error {
+	// Prepare query
+	query := `INSERT INTO user_avatar (user_id, name, data, updated_at)
+			  VALUES (:user_id, :name, :data, :updated_at)
+			  ON CONFLICT (user_id) DO UPDATE
+			  SET name = EXCLUDED.name, data = EXCLUDED.data, updated_at = EXCLUDED.updated_at`
+	params := map[string]interface{}{
+		"user_id":    avatar.UserID,
+		"name":       avatar.Name,
+		"data":       avatar.Data,
+		"updated_at": time.Now().Truncate(1 * time.Millisecond).UTC(),
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// DeleteAvatar method used to delete the avatar of a User
+func (r *PostgresRepository) DeleteAvatar(userID uuid.UUID) error {
+	// Prepare query
+	query := `DELETE FROM user_avatar as a
+			  WHERE a.user_id = :user_id`
+	params := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// RecordLogin method used to record a login attempt of a User, only keeping the last historySize successful attempts
+// and the last historySize failed ones, so that a burst of failures never pushes the known devices out of the history.
+// A successful attempt also becomes the last login of the User.
+func (r *PostgresRepository) RecordLogin(login models.UserLogin, historySize int) error {
+	tx, err := r.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	params := map[string]interface{}{
+		"id":           login.ID,
+		"user_id":      login.UserID,
+		"occurred_at":  login.OccurredAt.Truncate(1 * time.Microsecond).UTC(),
+		"ip_address":   login.IPAddress,
+		"user_agent":   login.UserAgent,
+		"method":       login.Method,
+		"success":      login.Success,
+		"reason":       login.Reason,
+		"history_size": historySize,
+	}
+
+	// Record the attempt
+	result, err := tx.NamedExec(`INSERT INTO user_logins (id, user_id, occurred_at, ip_address, user_agent, method, success, reason)
+			  VALUES (:id, :user_id, :occurred_at, :ip_address, :user_agent, :method, :success, :reason)`, params)
+	if err != nil {
+		return err
+	}
+	if err = utils.CheckRowAffected(result, 1); err != nil {
+		return err
+	}
+
+	// Keep track of the last login
+	if login.Success {
+		result, err = tx.NamedExec(`UPDATE Users as u
+			  SET last_login_at = :occurred_at
+			  WHERE u.ID = :user_id`, params)
+		if err != nil {
+			return err
+		}
+		if err = utils.CheckRowAffected(result, 1); err != nil {
+			return err
+		}
+	}
+
+	// Roll the history of the attempts with the same outcome
+	_, err = tx.NamedExec(`DELETE FROM user_logins as l
+			  WHERE l.user_id = :user_id AND l.success = :success AND l.id NOT IN (
+			      SELECT h.id FROM user_logins as h
+			      WHERE h.user_id = :user_id AND h.success = :success
+			      ORDER BY h.occurred_at DESC
+			      LIMIT :history_size
+			  )`, params)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListLogins method used to list the last login attempts of a User, the most recent first
+func (r *PostgresRepository) ListLogins(userID uuid.UUID, limit int) (models.UserLogins, error) {
+	// Prepare query
+	query := `SELECT l.id, l.user_id, l.occurred_at, l.ip_address, l.user_agent, l.method, l.success, l.reason
+			  FROM user_logins as l
+			  WHERE l.user_id = :user_id
+			  ORDER BY l.occurred_at DESC
+			  LIMIT :limit`
+	params := map[string]interface{}{
+		"user_id": userID,
+		"limit":   limit,
+	}
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return utils.ScanAllStruct[models.UserLogin](rows)
+}
+
+// HasLoggedInFrom method used to check if a User already logged in successfully from a device, identified by its user agent
+func (r *PostgresRepository) HasLoggedInFrom(userID uuid.UUID, userAgent string) (bool, error) {
+	// Prepare query
+	query := `SELECT 1
+			  FROM user_logins as l
+			  WHERE l.user_id = :user_id AND l.user_agent = :user_agent AND l.success
+			  LIMIT 1`
+	params := map[string]interface{}{
+		"user_id":    userID,
+		"user_agent": userAgent,
+	}
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	return rows.Next(), nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
 	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/pkg/hasher"
 	"github.com/Zapharaos/fihub-backend/test"
 	"github.com/google/uuid"
 	sqlxmock "github.com/zhashkevych/go-sqlxmock"
@@ -12,6 +13,14 @@ import (
 	"time"
 )
 
+// replaceHasher replaces the global hasher with a lightweight one, fast enough for tests
+func replaceHasher() func() {
+	return hasher.ReplaceGlobals(hasher.NewPasswordService(nil,
+		&hasher.Argon2idHasher{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+		&hasher.BcryptHasher{Cost: bcrypt.MinCost},
+	))
+}
+
 // TestUserPostgresRepository_Create test the RolePostgresRepository.Create method
 func TestUserPostgresRepository_Create(t *testing.T) {
 	var sqlxMock test.Sqlx
@@ -19,6 +28,7 @@ func TestUserPostgresRepository_Create(t *testing.T) {
 	defer sqlxMock.CleanTestSqlx()
 
 	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+	defer replaceHasher()()
 
 	tests := []struct {
 		name      string
@@ -84,8 +94,8 @@ func TestUserPostgresRepository_Get(t *testing.T) {
 			name:   "Retrieve user",
 			userID: uuid.New(),
 			mockSetup: func() {
-				rows := sqlxmock.NewRows([]string{"id", "email", "created_at", "updated_at"}).
-					AddRow(uuid.New(), "test@example.com", time.Now(), time.Now())
+				rows := sqlxmock.NewRows([]string{"id", "email", "email_verified", "created_at", "updated_at"}).
+					AddRow(uuid.New(), "test@example.com", true, time.Now(), time.Now())
 				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
 			},
 			expectErr:   false,
@@ -136,8 +146,8 @@ func TestUserPostgresRepository_GetByEmail(t *testing.T) {
 			name:  "Retrieve user by email",
 			email: "test@example.com",
 			mockSetup: func() {
-				rows := sqlxmock.NewRows([]string{"id", "email", "created_at", "updated_at"}).
-					AddRow(uuid.New(), "test@example.com", time.Now(), time.Now())
+				rows := sqlxmock.NewRows([]string{"id", "email", "email_verified", "created_at", "updated_at"}).
+					AddRow(uuid.New(), "test@example.com", true, time.Now(), time.Now())
 				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
 			},
 			expectErr:   false,
@@ -223,15 +233,26 @@ func TestUserPostgresRepository_Authenticate(t *testing.T) {
 	defer sqlxMock.CleanTestSqlx()
 
 	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+	defer replaceHasher()()
+
+	// Existing hashes
+	currentHash, _ := hasher.S().Hash("password")
+	legacyHash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	userRows := func(hash string) *sqlxmock.Rows {
+		return sqlxmock.NewRows([]string{"id", "email", "email_verified", "password", "created_at", "updated_at"}).
+			AddRow(uuid.New(), "", true, hash, time.Now(), time.Now())
+	}
 
 	tests := []struct {
 		name       string
+		password   string
 		mockSetup  func()
 		expectErr  bool
 		expectAuth bool
 	}{
 		{
-			name: "Fail user authentication",
+			name:     "Fail user authentication",
+			password: "password",
 			mockSetup: func() {
 				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
 			},
@@ -239,12 +260,48 @@ func TestUserPostgresRepository_Authenticate(t *testing.T) {
 			expectAuth: false,
 		},
 		{
-			name: "Authenticate user",
+			name:     "Fail with unsupported hash",
+			password: "password",
 			mockSetup: func() {
-				hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
-				rows := sqlxmock.NewRows([]string{"id", "email", "password", "created_at", "updated_at"}).
-					AddRow(uuid.New(), "", string(hashedPassword), time.Now(), time.Now())
-				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(userRows("plaintext"))
+			},
+			expectErr:  true,
+			expectAuth: false,
+		},
+		{
+			name:     "Fail with invalid password",
+			password: "wrong",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(userRows(currentHash))
+			},
+			expectErr:  true,
+			expectAuth: false,
+		},
+		{
+			name:     "Authenticate user",
+			password: "password",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(userRows(currentHash))
+			},
+			expectErr:  false,
+			expectAuth: true,
+		},
+		{
+			name:     "Authenticate user and upgrade the legacy hash",
+			password: "password",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(userRows(string(legacyHash)))
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr:  false,
+			expectAuth: true,
+		},
+		{
+			name:     "Authenticate user despite failing to upgrade the legacy hash",
+			password: "password",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(userRows(string(legacyHash)))
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnError(errors.New("error"))
 			},
 			expectErr:  false,
 			expectAuth: true,
@@ -254,13 +311,16 @@ func TestUserPostgresRepository_Authenticate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			_, auth, err := repositories.R().Authenticate("", "password")
+			_, auth, err := repositories.R().Authenticate("", tt.password)
 			if (err != nil) != tt.expectErr {
 				t.Errorf("Authenticate() error = %v, expectErr %v", err, tt.expectErr)
 			}
 			if auth != tt.expectAuth {
 				t.Errorf("Authenticate() users = %v, expectAuth %v", auth, tt.expectAuth)
 			}
+			if err := sqlxMock.Mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
 		})
 	}
 }
@@ -308,6 +368,49 @@ func TestUserPostgresRepository_Update(t *testing.T) {
 	}
 }
 
+// TestUserPostgresRepository_UpdateProfile tests the RolePostgresRepository.UpdateProfile method
+func TestUserPostgresRepository_UpdateProfile(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		profile   models.UserProfile
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:    "Fail profile update",
+			profile: models.UserProfile{DisplayName: "Jane"},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:    "Update profile",
+			profile: models.UserProfile{DisplayName: "Jane", Language: "fr"},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().UpdateProfile(uuid.New(), tt.profile)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("UpdateProfile() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
 // TestUserPostgresRepository_UpdateWithPassword tests the RolePostgresRepository.UpdateWithPassword method
 func TestUserPostgresRepository_UpdateWithPassword(t *testing.T) {
 	var sqlxMock test.Sqlx
@@ -315,6 +418,7 @@ func TestUserPostgresRepository_UpdateWithPassword(t *testing.T) {
 	defer sqlxMock.CleanTestSqlx()
 
 	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+	defer replaceHasher()()
 
 	tests := []struct {
 		name      string
@@ -394,6 +498,149 @@ func TestUserPostgresRepository_Delete(t *testing.T) {
 	}
 }
 
+// TestUserPostgresRepository_ScheduleDeletion tests the RolePostgresRepository.ScheduleDeletion method
+func TestUserPostgresRepository_ScheduleDeletion(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:   "Fail deletion scheduling",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:   "User not found",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			expectErr: true,
+		},
+		{
+			name:   "Schedule deletion",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().ScheduleDeletion(tt.userID, time.Now().Add(24*time.Hour))
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ScheduleDeletion() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestUserPostgresRepository_CancelDeletion tests the RolePostgresRepository.CancelDeletion method
+func TestUserPostgresRepository_CancelDeletion(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:   "Fail deletion cancellation",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:   "Cancel deletion",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().CancelDeletion(tt.userID)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("CancelDeletion() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestUserPostgresRepository_ListDueForDeletion tests the RolePostgresRepository.ListDueForDeletion method
+func TestUserPostgresRepository_ListDueForDeletion(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name         string
+		mockSetup    func()
+		expectErr    bool
+		expectLength int
+	}{
+		{
+			name: "Fail users retrieval",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
+			},
+			expectErr:    true,
+			expectLength: 0,
+		},
+		{
+			name: "Retrieve users",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows([]string{"id", "email", "email_verified", "created_at", "updated_at", "deletion_scheduled_at"}).
+					AddRow(uuid.New(), "test@example.com", true, time.Now(), time.Now(), time.Now())
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			expectErr:    false,
+			expectLength: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			users, err := repositories.R().ListDueForDeletion(time.Now())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ListDueForDeletion() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if len(users) != tt.expectLength {
+				t.Errorf("ListDueForDeletion() length = %v, expectLength %v", len(users), tt.expectLength)
+			}
+		})
+	}
+}
+
 // TestUserPostgresRepository_List test the RolePostgresRepository.List method
 func TestUserPostgresRepository_List(t *testing.T) {
 	var sqlxMock test.Sqlx
@@ -402,16 +649,19 @@ func TestUserPostgresRepository_List(t *testing.T) {
 
 	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
 
+	to := time.Now()
+	from := to.Add(-24 * time.Hour)
+
 	tests := []struct {
 		name         string
-		userID       uuid.UUID
+		filter       models.UserFilter
 		mockSetup    func()
 		expectErr    bool
 		expectLength int
 	}{
 		{
 			name:   "Fail user retrieval",
-			userID: uuid.New(),
+			filter: models.UserFilter{},
 			mockSetup: func() {
 				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
 			},
@@ -420,22 +670,42 @@ func TestUserPostgresRepository_List(t *testing.T) {
 		},
 		{
 			name:   "Retrieve user",
-			userID: uuid.New(),
+			filter: models.UserFilter{Email: "test_", Sort: models.UserSortEmailDesc},
 			mockSetup: func() {
-				rows := sqlxmock.NewRows([]string{"id", "email", "created_at", "updated_at"}).
-					AddRow(uuid.New(), "test@example.com", time.Now(), time.Now()).
-					AddRow(uuid.New(), "test@example.com", time.Now(), time.Now())
+				rows := sqlxmock.NewRows([]string{"id", "email", "email_verified", "created_at", "updated_at"}).
+					AddRow(uuid.New(), "test@example.com", true, time.Now(), time.Now()).
+					AddRow(uuid.New(), "test@example.com", true, time.Now(), time.Now())
 				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
 			},
 			expectErr:    false,
 			expectLength: 2,
 		},
+		{
+			name: "Retrieve user with every criteria",
+			filter: models.UserFilter{
+				Email:       "test",
+				UserIDs:     []uuid.UUID{uuid.New()},
+				CreatedFrom: &from,
+				CreatedTo:   &to,
+				Status:      models.UserStatusActive,
+				Sort:        models.UserSortCreatedAtDesc,
+				Limit:       1,
+				After:       &models.UserCursor{Sort: models.UserSortCreatedAtDesc, CreatedAt: &to, ID: uuid.New()},
+			},
+			mockSetup: func() {
+				rows := sqlxmock.NewRows([]string{"id", "email", "email_verified", "created_at", "updated_at"}).
+					AddRow(uuid.New(), "test@example.com", true, time.Now(), time.Now())
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			expectErr:    false,
+			expectLength: 1,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			users, err := repositories.R().List()
+			users, err := repositories.R().List(tt.filter)
 			if (err != nil) != tt.expectErr {
 				t.Errorf("List() error = %v, expectErr %v", err, tt.expectErr)
 				return
@@ -446,3 +716,482 @@ func TestUserPostgresRepository_List(t *testing.T) {
 		})
 	}
 }
+
+// TestUserPostgresRepository_Disable tests the RolePostgresRepository.Disable method
+func TestUserPostgresRepository_Disable(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:   "Fail user disabling",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:   "Disable user",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().Disable(tt.userID, time.Now())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Disable() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestUserPostgresRepository_Enable tests the RolePostgresRepository.Enable method
+func TestUserPostgresRepository_Enable(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:   "Fail user enabling",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:   "Enable user",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().Enable(tt.userID)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Enable() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestUserPostgresRepository_RequirePasswordReset tests the RolePostgresRepository.RequirePasswordReset method
+func TestUserPostgresRepository_RequirePasswordReset(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:   "Fail password reset requirement",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:   "Require password reset",
+			userID: uuid.New(),
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().RequirePasswordReset(tt.userID)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("RequirePasswordReset() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestUserPostgresRepository_GetAvatar tests the RolePostgresRepository.GetAvatar method
+func TestUserPostgresRepository_GetAvatar(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectErr   bool
+		expectFound bool
+	}{
+		{
+			name: "Fail avatar retrieval",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
+			},
+			expectErr:   true,
+			expectFound: false,
+		},
+		{
+			name: "Avatar not found",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows([]string{"user_id", "name", "data", "updated_at"})
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			expectErr:   false,
+			expectFound: false,
+		},
+		{
+			name: "Retrieve avatar",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows([]string{"user_id", "name", "data", "updated_at"}).
+					AddRow(uuid.New(), "avatar.png", []byte{1}, time.Now())
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			expectErr:   false,
+			expectFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			_, found, err := repositories.R().GetAvatar(uuid.New())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("GetAvatar() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if found != tt.expectFound {
+				t.Errorf("GetAvatar() found = %v, expectFound %v", found, tt.expectFound)
+			}
+		})
+	}
+}
+
+// TestUserPostgresRepository_SetAvatar tests the RolePostgresRepository.SetAvatar method
+func TestUserPostgresRepository_SetAvatar(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	avatar := models.UserAvatar{UserID: uuid.New(), Name: "avatar.png", Data: []byte{1}}
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail avatar upsert",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("INSERT INTO user_avatar").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Set avatar",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("INSERT INTO user_avatar").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().SetAvatar(avatar)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("SetAvatar() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestUserPostgresRepository_DeleteAvatar tests the RolePostgresRepository.DeleteAvatar method
+func TestUserPostgresRepository_DeleteAvatar(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail avatar deletion",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM user_avatar").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "No avatar deleted",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM user_avatar").WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Delete avatar",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM user_avatar").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().DeleteAvatar(uuid.New())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("DeleteAvatar() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestUserPostgresRepository_RecordLogin tests the RolePostgresRepository.RecordLogin method
+func TestUserPostgresRepository_RecordLogin(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	failedLogin := models.InitUserLogin(uuid.New(), "127.0.0.1", "Firefox", models.LoginMethodPassword)
+	failedLogin.Reason = "invalid-credentials"
+	successfulLogin := models.InitUserLogin(uuid.New(), "127.0.0.1", "Firefox", models.LoginMethodPassword)
+	successfulLogin.Success = true
+
+	tests := []struct {
+		name      string
+		login     models.UserLogin
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name:  "Fail to start the transaction",
+			login: failedLogin,
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin().WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name:  "Fail login recording",
+			login: failedLogin,
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("INSERT INTO user_logins").WillReturnError(errors.New("error"))
+				sqlxMock.Mock.ExpectRollback()
+			},
+			expectErr: true,
+		},
+		{
+			name:  "Fail last login update",
+			login: successfulLogin,
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("INSERT INTO user_logins").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnResult(sqlxmock.NewResult(0, 0))
+				sqlxMock.Mock.ExpectRollback()
+			},
+			expectErr: true,
+		},
+		{
+			name:  "Fail history rolling",
+			login: failedLogin,
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("INSERT INTO user_logins").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectExec("DELETE FROM user_logins").WillReturnError(errors.New("error"))
+				sqlxMock.Mock.ExpectRollback()
+			},
+			expectErr: true,
+		},
+		{
+			name:  "Record failed login",
+			login: failedLogin,
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("INSERT INTO user_logins").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectExec("DELETE FROM user_logins").WillReturnResult(sqlxmock.NewResult(0, 0))
+				sqlxMock.Mock.ExpectCommit()
+			},
+			expectErr: false,
+		},
+		{
+			name:  "Record successful login",
+			login: successfulLogin,
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectBegin()
+				sqlxMock.Mock.ExpectExec("INSERT INTO user_logins").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectExec("UPDATE Users").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectExec("DELETE FROM user_logins").WillReturnResult(sqlxmock.NewResult(1, 1))
+				sqlxMock.Mock.ExpectCommit()
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := repositories.R().RecordLogin(tt.login, 50)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("RecordLogin() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if err := sqlxMock.Mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("RecordLogin() unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+// TestUserPostgresRepository_ListLogins tests the RolePostgresRepository.ListLogins method
+func TestUserPostgresRepository_ListLogins(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name         string
+		mockSetup    func()
+		expectErr    bool
+		expectLength int
+	}{
+		{
+			name: "Fail logins retrieval",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
+			},
+			expectErr:    true,
+			expectLength: 0,
+		},
+		{
+			name: "Retrieve logins",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows([]string{"id", "user_id", "occurred_at", "ip_address", "user_agent", "method", "success", "reason"}).
+					AddRow(uuid.New(), uuid.New(), time.Now(), "127.0.0.1", "Firefox", models.LoginMethodPassword, true, "").
+					AddRow(uuid.New(), uuid.New(), time.Now(), "127.0.0.1", "Firefox", models.LoginMethodPassword, false, "invalid-credentials")
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			expectErr:    false,
+			expectLength: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			logins, err := repositories.R().ListLogins(uuid.New(), 20)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ListLogins() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if len(logins) != tt.expectLength {
+				t.Errorf("ListLogins() length = %v, expectLength %v", len(logins), tt.expectLength)
+			}
+		})
+	}
+}
+
+// TestUserPostgresRepository_HasLoggedInFrom tests the RolePostgresRepository.HasLoggedInFrom method
+func TestUserPostgresRepository_HasLoggedInFrom(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	repositories.ReplaceGlobals(repositories.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectErr   bool
+		expectFound bool
+	}{
+		{
+			name: "Fail logins retrieval",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnError(errors.New("error"))
+			},
+			expectErr:   true,
+			expectFound: false,
+		},
+		{
+			name: "New device",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(sqlxmock.NewRows([]string{"?column?"}))
+			},
+			expectErr:   false,
+			expectFound: false,
+		},
+		{
+			name: "Known device",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT").WillReturnRows(sqlxmock.NewRows([]string{"?column?"}).AddRow(1))
+			},
+			expectErr:   false,
+			expectFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			found, err := repositories.R().HasLoggedInFrom(uuid.New(), "Firefox")
+			if (err != nil) != tt.expectErr {
+				t.Errorf("HasLoggedInFrom() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if found != tt.expectFound {
+				t.Errorf("HasLoggedInFrom() found = %v, expectFound %v", found, tt.expectFound)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/Zapharaos/fihub-backend/internal/models"
 	"github.com/google/uuid"
 	"sync"
+	"time"
 )
 
 // Repository is a storage interface which can be implemented by multiple backend
@@ -17,8 +18,21 @@ type Repository interface {
 	Authenticate(email string, password string) (models.User, bool, error)
 	Update(user models.User) error
 	UpdateWithPassword(user models.UserWithPassword) error
+	UpdateProfile(userID uuid.UUID, profile models.UserProfile) error
 	Delete(userID uuid.UUID) error
-	List() (models.Users, error)
+	ScheduleDeletion(userID uuid.UUID, scheduledAt time.Time) error
+	CancelDeletion(userID uuid.UUID) error
+	ListDueForDeletion(at time.Time) (models.Users, error)
+	List(filter models.UserFilter) (models.Users, error)
+	Disable(userID uuid.UUID, disabledAt time.Time) error
+	Enable(userID uuid.UUID) error
+	RequirePasswordReset(userID uuid.UUID) error
+	RecordLogin(login models.UserLogin, historySize int) error
+	ListLogins(userID uuid.UUID, limit int) (models.UserLogins, error)
+	HasLoggedInFrom(userID uuid.UUID, userAgent string) (bool, error)
+	GetAvatar(userID uuid.UUID) (models.UserAvatar, bool, error)
+	SetAvatar(avatar models.UserAvatar) error
+	DeleteAvatar(userID uuid.UUID) error
 }
 
 var (
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// SetUserDisabled implements the SetUserDisabled RPC method.
+// Disabled users can no longer log in, until an administrator enables them again, and their sessions are revoked.
+func (s *Service) SetUserDisabled(ctx context.Context, req *userpb.SetUserDisabledRequest) (*userpb.SetUserDisabledResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetId()), zap.Error(err))
+		return &userpb.SetUserDisabledResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Users can not enable themselves again : the permission is required regardless of the user
+	err = security.Facade().CheckPermission(ctx, "admin.users.update")
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.SetUserDisabledResponse{}, err
+	}
+
+	// Get current user
+	user, found, err := repositories.R().Get(userID)
+	if err != nil {
+		zap.L().Error("Cannot get user", zap.String("uuid", userID.String()), zap.Error(err))
+		return &userpb.SetUserDisabledResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Warn("User not found", zap.String("uuid", userID.String()))
+		return &userpb.SetUserDisabledResponse{}, status.Error(codes.NotFound, "User not found")
+	}
+
+	// Only update the user if its state changes
+	if req.GetDisabled() && user.DisabledAt == nil {
+		disabledAt := time.Now()
+		err = repositories.R().Disable(userID, disabledAt)
+		if err != nil {
+			zap.L().Error("SetUserDisabled.Disable", zap.Error(err))
+			return &userpb.SetUserDisabledResponse{}, status.Error(codes.Internal, err.Error())
+		}
+		zap.L().Info("User disabled", zap.String("uuid", userID.String()))
+		user.DisabledAt = &disabledAt
+	} else if !req.GetDisabled() && user.DisabledAt != nil {
+		err = repositories.R().Enable(userID)
+		if err != nil {
+			zap.L().Error("SetUserDisabled.Enable", zap.Error(err))
+			return &userpb.SetUserDisabledResponse{}, status.Error(codes.Internal, err.Error())
+		}
+		zap.L().Info("User enabled", zap.String("uuid", userID.String()))
+		user.DisabledAt = nil
+	}
+
+	// Log the disabled user out of every device, also when it was already disabled in case a revocation failed
+	if req.GetDisabled() {
+		err = s.revokeUserSessions(ctx, userID)
+		if err != nil {
+			zap.L().Error("SetUserDisabled.revokeUserSessions", zap.Error(err))
+			return &userpb.SetUserDisabledResponse{}, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &userpb.SetUserDisabledResponse{
+		User: mappers.UserToProto(user),
+	}, nil
+}
+
+// ForcePasswordReset implements the ForcePasswordReset RPC method.
+// The user can no longer log in until the password is reset through the forgotten password process, and its sessions are revoked.
+func (s *Service) ForcePasswordReset(ctx context.Context, req *userpb.ForcePasswordResetRequest) (*userpb.ForcePasswordResetResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetId()), zap.Error(err))
+		return &userpb.ForcePasswordResetResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	err = security.Facade().CheckPermission(ctx, "admin.users.update")
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.ForcePasswordResetResponse{}, err
+	}
+
+	// Verify user existence
+	_, found, err := repositories.R().Get(userID)
+	if err != nil {
+		zap.L().Error("Cannot get user", zap.String("uuid", userID.String()), zap.Error(err))
+		return &userpb.ForcePasswordResetResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Warn("User not found", zap.String("uuid", userID.String()))
+		return &userpb.ForcePasswordResetResponse{}, status.Error(codes.NotFound, "User not found")
+	}
+
+	// Require the reset
+	err = repositories.R().RequirePasswordReset(userID)
+	if err != nil {
+		zap.L().Error("ForcePasswordReset.RequirePasswordReset", zap.Error(err))
+		return &userpb.ForcePasswordResetResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Log the user out of every device
+	err = s.revokeUserSessions(ctx, userID)
+	if err != nil {
+		zap.L().Error("ForcePasswordReset.revokeUserSessions", zap.Error(err))
+		return &userpb.ForcePasswordResetResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &userpb.ForcePasswordResetResponse{
+		Success: true,
+	}, nil
+}
+
+// revokeUserSessions deletes every session of the user, their tokens can no longer be used afterward.
+// The sessions are deleted on behalf of the user, the administrator may not be allowed to manage them.
+func (s *Service) revokeUserSessions(ctx context.Context, userID uuid.UUID) error {
+	ctx, err := s.identity.AppendIdentityToOutgoingContext(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.authClient.DeleteUserSessions(ctx, &authpb.DeleteUserSessionsRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if response.GetRemaining() > 0 {
+		return fmt.Errorf("%d sessions remaining", response.GetRemaining())
+	}
+	return nil
+}
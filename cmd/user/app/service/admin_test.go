@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+// TestSetUserDisabled tests the SetUserDisabled method
+func TestSetUserDisabled(t *testing.T) {
+	userID := uuid.New()
+	disabledAt := time.Now()
+
+	// Define tests
+	tests := []struct {
+		name             string
+		mockSetup        func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient)
+		request          *userpb.SetUserDisabledRequest
+		expectedErrCode  codes.Code
+		expectedDisabled bool
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &userpb.SetUserDisabledRequest{Id: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         &userpb.SetUserDisabledRequest{Id: userID.String(), Disabled: true},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to retrieve the user",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{}, false, errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         &userpb.SetUserDisabledRequest{Id: userID.String(), Disabled: true},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "user not found",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{}, false, nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         &userpb.SetUserDisabledRequest{Id: userID.String(), Disabled: true},
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "fails to disable the user",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{ID: userID}, true, nil)
+				ur.EXPECT().Disable(userID, gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         &userpb.SetUserDisabledRequest{Id: userID.String(), Disabled: true},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "fails to revoke the sessions of the disabled user",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{ID: userID}, true, nil)
+				ur.EXPECT().Disable(userID, gomock.Any()).Return(nil)
+				repositories.ReplaceGlobals(ur)
+				// Mock the auth service
+				ac.EXPECT().DeleteUserSessions(gomock.Any(), gomock.Any()).Return(&authpb.DeleteUserSessionsResponse{Remaining: 1}, nil)
+			},
+			request:         &userpb.SetUserDisabledRequest{Id: userID.String(), Disabled: true},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeds to disable the user",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{ID: userID}, true, nil)
+				ur.EXPECT().Disable(userID, gomock.Any()).Return(nil)
+				repositories.ReplaceGlobals(ur)
+				// Mock the auth service
+				ac.EXPECT().DeleteUserSessions(gomock.Any(), &authpb.DeleteUserSessionsRequest{UserId: userID.String()}).Return(&authpb.DeleteUserSessionsResponse{}, nil)
+			},
+			request:          &userpb.SetUserDisabledRequest{Id: userID.String(), Disabled: true},
+			expectedErrCode:  codes.OK,
+			expectedDisabled: true,
+		},
+		{
+			name: "succeeds without disabling an already disabled user",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{ID: userID, DisabledAt: &disabledAt}, true, nil)
+				ur.EXPECT().Disable(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+				// Mock the auth service
+				ac.EXPECT().DeleteUserSessions(gomock.Any(), &authpb.DeleteUserSessionsRequest{UserId: userID.String()}).Return(&authpb.DeleteUserSessionsResponse{}, nil)
+			},
+			request:          &userpb.SetUserDisabledRequest{Id: userID.String(), Disabled: true},
+			expectedErrCode:  codes.OK,
+			expectedDisabled: true,
+		},
+		{
+			name: "fails to enable the user",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{ID: userID, DisabledAt: &disabledAt}, true, nil)
+				ur.EXPECT().Enable(userID).Return(errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         &userpb.SetUserDisabledRequest{Id: userID.String(), Disabled: false},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeds to enable the user",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{ID: userID, DisabledAt: &disabledAt}, true, nil)
+				ur.EXPECT().Enable(userID).Return(nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:          &userpb.SetUserDisabledRequest{Id: userID.String(), Disabled: false},
+			expectedErrCode:  codes.OK,
+			expectedDisabled: false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			ac := mocks.NewMockAuthServiceClient(ctrl)
+			tt.mockSetup(ctrl, ac)
+			defer ctrl.Finish()
+			service := newTestService(nil, nil, nil, ac)
+
+			// Call service
+			response, err := service.SetUserDisabled(context.Background(), tt.request)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+
+			// Handle response
+			if tt.expectedErrCode == codes.OK {
+				assert.Equal(t, userID.String(), response.GetUser().GetId())
+				assert.Equal(t, tt.expectedDisabled, response.GetUser().GetDisabledAt() != nil)
+			} else {
+				assert.Equal(t, &userpb.SetUserDisabledResponse{}, response)
+			}
+		})
+	}
+}
+
+// TestForcePasswordReset tests the ForcePasswordReset method
+func TestForcePasswordReset(t *testing.T) {
+	userID := uuid.New()
+	validRequest := &userpb.ForcePasswordResetRequest{Id: userID.String()}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient)
+		request         *userpb.ForcePasswordResetRequest
+		expected        *userpb.ForcePasswordResetResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &userpb.ForcePasswordResetRequest{Id: "bad-uuid"},
+			expected:        &userpb.ForcePasswordResetResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().RequirePasswordReset(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.ForcePasswordResetResponse{},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "user not found",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{}, false, nil)
+				ur.EXPECT().RequirePasswordReset(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.ForcePasswordResetResponse{},
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "fails to require the password reset",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{ID: userID}, true, nil)
+				ur.EXPECT().RequirePasswordReset(userID).Return(errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.ForcePasswordResetResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "fails to revoke the sessions",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{ID: userID}, true, nil)
+				ur.EXPECT().RequirePasswordReset(userID).Return(nil)
+				repositories.ReplaceGlobals(ur)
+				// Mock the auth service
+				ac.EXPECT().DeleteUserSessions(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "error"))
+			},
+			request:         validRequest,
+			expected:        &userpb.ForcePasswordResetResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeds",
+			mockSetup: func(ctrl *gomock.Controller, ac *mocks.MockAuthServiceClient) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{ID: userID}, true, nil)
+				ur.EXPECT().RequirePasswordReset(userID).Return(nil)
+				repositories.ReplaceGlobals(ur)
+				// Mock the auth service
+				ac.EXPECT().DeleteUserSessions(gomock.Any(), &authpb.DeleteUserSessionsRequest{UserId: userID.String()}).Return(&authpb.DeleteUserSessionsResponse{}, nil)
+			},
+			request:         validRequest,
+			expected:        &userpb.ForcePasswordResetResponse{Success: true},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			ac := mocks.NewMockAuthServiceClient(ctrl)
+			tt.mockSetup(ctrl, ac)
+			defer ctrl.Finish()
+			service := newTestService(nil, nil, nil, ac)
+
+			// Call service
+			response, err := service.ForcePasswordReset(context.Background(), tt.request)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/brokerpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/transactionpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/pkg/email/templates"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+	"time"
+)
+
+// purgeTimeout is the maximum duration of the calls made to the other microservices to purge a user
+const purgeTimeout = 30 * time.Second
+
+// AccountDeletionPolicy defines how long a deleted account is kept before being permanently removed.
+// Logging in within the GracePeriod cancels the deletion.
+type AccountDeletionPolicy struct {
+	GracePeriod time.Duration
+}
+
+// NewAccountDeletionPolicyFromConfig creates a new AccountDeletionPolicy from the configuration
+func NewAccountDeletionPolicyFromConfig() AccountDeletionPolicy {
+	policy := AccountDeletionPolicy{
+		GracePeriod: viper.GetDuration("USER_DELETION_GRACE_PERIOD"),
+	}
+
+	if policy.GracePeriod <= 0 {
+		policy.GracePeriod = 30 * 24 * time.Hour
+	}
+
+	return policy
+}
+
+// PurgeDeletedUsers permanently removes the users whose grace period has ended.
+// The data held by the other microservices is deleted first, and the user is only removed once each of them
+// reported that nothing is left, so that an incomplete purge is attempted again on the next sweep.
+func (s *Service) PurgeDeletedUsers() {
+	users, err := repositories.R().ListDueForDeletion(time.Now())
+	if err != nil {
+		zap.L().Error("Cannot list users due for deletion", zap.Error(err))
+		return
+	}
+
+	for _, user := range users {
+		err = s.purgeUser(user)
+		if err != nil {
+			zap.L().Error("Cannot purge user", zap.String("uuid", user.ID.String()), zap.Error(err))
+			continue
+		}
+		zap.L().Info("User deleted",
+			zap.String("uuid", user.ID.String()),
+			zap.Timep("deletion_scheduled_at", user.DeletionScheduledAt))
+	}
+}
+
+// purgeUser deletes the data of the user across the microservices and the emails sent to it, then the user itself
+func (s *Service) purgeUser(user models.User) error {
+	userID := user.ID
+	ctx, cancel := context.WithTimeout(context.Background(), purgeTimeout)
+	defer cancel()
+
+	// The purge is performed on behalf of the user who requested the deletion
+	ctx, err := s.identity.AppendIdentityToOutgoingContext(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Transactions
+	transactions, err := s.transactionClient.DeleteUserTransactions(ctx, &transactionpb.DeleteUserTransactionsRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("delete transactions: %w", err)
+	}
+	if transactions.GetRemaining() > 0 {
+		return fmt.Errorf("delete transactions: %d remaining", transactions.GetRemaining())
+	}
+
+	// Brokers
+	brokers, err := s.brokerClient.DeleteUserBrokers(ctx, &brokerpb.DeleteUserBrokersRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("delete user brokers: %w", err)
+	}
+	if brokers.GetRemaining() > 0 {
+		return fmt.Errorf("delete user brokers: %d remaining", brokers.GetRemaining())
+	}
+
+	// Roles
+	roles, err := s.securityClient.DeleteRolesForUser(ctx, &securitypb.DeleteRolesForUserRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("delete roles: %w", err)
+	}
+	if roles.GetRemaining() > 0 {
+		return fmt.Errorf("delete roles: %d remaining", roles.GetRemaining())
+	}
+
+	// Sessions
+	sessions, err := s.authClient.DeleteUserSessions(ctx, &authpb.DeleteUserSessionsRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("delete sessions: %w", err)
+	}
+	if sessions.GetRemaining() > 0 {
+		return fmt.Errorf("delete sessions: %d remaining", sessions.GetRemaining())
+	}
+
+	// Emails, whether delivered or not
+	_, err = outbox.R().DeleteByRecipient(user.Email)
+	if err != nil {
+		return fmt.Errorf("delete emails: %w", err)
+	}
+
+	return repositories.R().Delete(userID)
+}
+
+// enqueueDeletionScheduledEmail confirms to the user that the account will be deleted, and how to cancel it
+func enqueueDeletionScheduledEmail(user models.User, scheduledAt time.Time, lang language.Tag) {
+	key := fmt.Sprintf("deletion-scheduled:%s:%d", user.ID, scheduledAt.Unix())
+	err := enqueueEmail(key, user.Email, lang, templates.DeletionScheduled, templates.DeletionScheduledData{
+		Date: scheduledAt,
+	})
+	if err != nil {
+		zap.L().Error("Failed to enqueue deletion scheduled email", zap.Error(err))
+	}
+}
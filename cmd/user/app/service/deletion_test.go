@@ -0,0 +1,208 @@
+package service
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/brokerpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/transactionpb"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+// TestNewAccountDeletionPolicyFromConfig tests the NewAccountDeletionPolicyFromConfig function
+func TestNewAccountDeletionPolicyFromConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		viper.Reset()
+		policy := NewAccountDeletionPolicyFromConfig()
+		assert.Equal(t, AccountDeletionPolicy{
+			GracePeriod: 30 * 24 * time.Hour,
+		}, policy)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		viper.Set("USER_DELETION_GRACE_PERIOD", "48h")
+		defer viper.Reset()
+
+		policy := NewAccountDeletionPolicyFromConfig()
+		assert.Equal(t, AccountDeletionPolicy{
+			GracePeriod: 48 * time.Hour,
+		}, policy)
+	})
+}
+
+// TestService_PurgeDeletedUsers tests the PurgeDeletedUsers method
+func TestService_PurgeDeletedUsers(t *testing.T) {
+	userID := uuid.New()
+	scheduledAt := time.Now().Add(-time.Hour)
+	dueUsers := models.Users{{ID: userID, Email: "jane@example.com", DeletionScheduledAt: &scheduledAt}}
+
+	tests := []struct {
+		name      string
+		mockSetup func(ctrl *gomock.Controller) *Service
+	}{
+		{
+			name: "Fails to list the users due for deletion",
+			mockSetup: func(ctrl *gomock.Controller) *Service {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListDueForDeletion(gomock.Any()).Return(nil, errors.New("error"))
+				ur.EXPECT().Delete(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+				return &Service{}
+			},
+		},
+		{
+			name: "Fails to delete the transactions",
+			mockSetup: func(ctrl *gomock.Controller) *Service {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListDueForDeletion(gomock.Any()).Return(dueUsers, nil)
+				ur.EXPECT().Delete(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+				tc := mocks.NewMockTransactionServiceClient(ctrl)
+				tc.EXPECT().DeleteUserTransactions(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				bc := mocks.NewMockBrokerServiceClient(ctrl)
+				bc.EXPECT().DeleteUserBrokers(gomock.Any(), gomock.Any()).Times(0)
+				return newTestService(bc, tc, nil, nil)
+			},
+		},
+		{
+			name: "Transactions remaining",
+			mockSetup: func(ctrl *gomock.Controller) *Service {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListDueForDeletion(gomock.Any()).Return(dueUsers, nil)
+				ur.EXPECT().Delete(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+				tc := mocks.NewMockTransactionServiceClient(ctrl)
+				tc.EXPECT().DeleteUserTransactions(gomock.Any(), gomock.Any()).Return(&transactionpb.DeleteUserTransactionsResponse{Remaining: 1}, nil)
+				bc := mocks.NewMockBrokerServiceClient(ctrl)
+				bc.EXPECT().DeleteUserBrokers(gomock.Any(), gomock.Any()).Times(0)
+				return newTestService(bc, tc, nil, nil)
+			},
+		},
+		{
+			name: "User brokers remaining",
+			mockSetup: func(ctrl *gomock.Controller) *Service {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListDueForDeletion(gomock.Any()).Return(dueUsers, nil)
+				ur.EXPECT().Delete(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+				tc := mocks.NewMockTransactionServiceClient(ctrl)
+				tc.EXPECT().DeleteUserTransactions(gomock.Any(), gomock.Any()).Return(&transactionpb.DeleteUserTransactionsResponse{}, nil)
+				bc := mocks.NewMockBrokerServiceClient(ctrl)
+				bc.EXPECT().DeleteUserBrokers(gomock.Any(), gomock.Any()).Return(&brokerpb.DeleteUserBrokersResponse{Remaining: 2}, nil)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().DeleteRolesForUser(gomock.Any(), gomock.Any()).Times(0)
+				return newTestService(bc, tc, sc, nil)
+			},
+		},
+		{
+			name: "Fails to delete the roles",
+			mockSetup: func(ctrl *gomock.Controller) *Service {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListDueForDeletion(gomock.Any()).Return(dueUsers, nil)
+				ur.EXPECT().Delete(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+				tc := mocks.NewMockTransactionServiceClient(ctrl)
+				tc.EXPECT().DeleteUserTransactions(gomock.Any(), gomock.Any()).Return(&transactionpb.DeleteUserTransactionsResponse{}, nil)
+				bc := mocks.NewMockBrokerServiceClient(ctrl)
+				bc.EXPECT().DeleteUserBrokers(gomock.Any(), gomock.Any()).Return(&brokerpb.DeleteUserBrokersResponse{}, nil)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().DeleteRolesForUser(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				return newTestService(bc, tc, sc, nil)
+			},
+		},
+		{
+			name: "Sessions remaining",
+			mockSetup: func(ctrl *gomock.Controller) *Service {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListDueForDeletion(gomock.Any()).Return(dueUsers, nil)
+				ur.EXPECT().Delete(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+				tc := mocks.NewMockTransactionServiceClient(ctrl)
+				tc.EXPECT().DeleteUserTransactions(gomock.Any(), gomock.Any()).Return(&transactionpb.DeleteUserTransactionsResponse{}, nil)
+				bc := mocks.NewMockBrokerServiceClient(ctrl)
+				bc.EXPECT().DeleteUserBrokers(gomock.Any(), gomock.Any()).Return(&brokerpb.DeleteUserBrokersResponse{}, nil)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().DeleteRolesForUser(gomock.Any(), gomock.Any()).Return(&securitypb.DeleteRolesForUserResponse{}, nil)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteUserSessions(gomock.Any(), gomock.Any()).Return(&authpb.DeleteUserSessionsResponse{Remaining: 1}, nil)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().DeleteByRecipient(gomock.Any()).Times(0)
+				outbox.ReplaceGlobals(or)
+				return newTestService(bc, tc, sc, ac)
+			},
+		},
+		{
+			name: "Fails to delete the emails",
+			mockSetup: func(ctrl *gomock.Controller) *Service {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListDueForDeletion(gomock.Any()).Return(dueUsers, nil)
+				ur.EXPECT().Delete(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+				tc := mocks.NewMockTransactionServiceClient(ctrl)
+				tc.EXPECT().DeleteUserTransactions(gomock.Any(), gomock.Any()).Return(&transactionpb.DeleteUserTransactionsResponse{}, nil)
+				bc := mocks.NewMockBrokerServiceClient(ctrl)
+				bc.EXPECT().DeleteUserBrokers(gomock.Any(), gomock.Any()).Return(&brokerpb.DeleteUserBrokersResponse{}, nil)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().DeleteRolesForUser(gomock.Any(), gomock.Any()).Return(&securitypb.DeleteRolesForUserResponse{}, nil)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteUserSessions(gomock.Any(), gomock.Any()).Return(&authpb.DeleteUserSessionsResponse{}, nil)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().DeleteByRecipient("jane@example.com").Return(int64(0), errors.New("error"))
+				outbox.ReplaceGlobals(or)
+				return newTestService(bc, tc, sc, ac)
+			},
+		},
+		{
+			name: "Deletes the user once every service completed",
+			mockSetup: func(ctrl *gomock.Controller) *Service {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListDueForDeletion(gomock.Any()).Return(dueUsers, nil)
+				ur.EXPECT().Delete(userID).Return(nil)
+				repositories.ReplaceGlobals(ur)
+				tc := mocks.NewMockTransactionServiceClient(ctrl)
+				tc.EXPECT().DeleteUserTransactions(gomock.Any(), &transactionpb.DeleteUserTransactionsRequest{UserId: userID.String()}).Return(&transactionpb.DeleteUserTransactionsResponse{}, nil)
+				bc := mocks.NewMockBrokerServiceClient(ctrl)
+				bc.EXPECT().DeleteUserBrokers(gomock.Any(), &brokerpb.DeleteUserBrokersRequest{UserId: userID.String()}).Return(&brokerpb.DeleteUserBrokersResponse{}, nil)
+				sc := mocks.NewMockSecurityServiceClient(ctrl)
+				sc.EXPECT().DeleteRolesForUser(gomock.Any(), &securitypb.DeleteRolesForUserRequest{UserId: userID.String()}).Return(&securitypb.DeleteRolesForUserResponse{}, nil)
+				ac := mocks.NewMockAuthServiceClient(ctrl)
+				ac.EXPECT().DeleteUserSessions(gomock.Any(), &authpb.DeleteUserSessionsRequest{UserId: userID.String()}).Return(&authpb.DeleteUserSessionsResponse{}, nil)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().DeleteByRecipient("jane@example.com").Return(int64(3), nil)
+				outbox.ReplaceGlobals(or)
+				return newTestService(bc, tc, sc, ac)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			service := tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			service.PurgeDeletedUsers()
+		})
+	}
+}
+
+// newTestService returns a Service calling the given clients on behalf of the users
+func newTestService(brokerClient brokerpb.BrokerServiceClient, transactionClient transactionpb.TransactionServiceClient, securityClient securitypb.SecurityServiceClient, authClient authpb.AuthServiceClient) *Service {
+	return &Service{
+		identity:          grpcutil.NewIdentityAuthority([]byte("test-key"), time.Minute),
+		brokerClient:      brokerClient,
+		transactionClient: transactionClient,
+		securityClient:    securityClient,
+		authClient:        authClient,
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/pkg/email/templates"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PreviewEmailTemplate implements the PreviewEmailTemplate RPC method.
+// The template is rendered with its sample data in the loaded language best matching the requested one.
+func (s *Service) PreviewEmailTemplate(ctx context.Context, req *userpb.PreviewEmailTemplateRequest) (*userpb.PreviewEmailTemplateResponse, error) {
+	// Check user permissions
+	err := security.Facade().CheckPermission(ctx, "admin.emails.preview")
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.PreviewEmailTemplateResponse{}, err
+	}
+
+	// Get the template
+	entry, found := templates.Lookup(req.GetName())
+	if !found {
+		zap.L().Warn("Email template not found", zap.String("name", req.GetName()))
+		return &userpb.PreviewEmailTemplateResponse{}, status.Error(codes.NotFound, "template not found")
+	}
+
+	// Parse the language from the request, or use the default one
+	langParam := req.GetLanguage()
+	if langParam == "" {
+		langParam = viper.GetString("DEFAULT_LANGUAGE")
+	}
+	lang, err := language.Parse(langParam)
+	if err != nil {
+		zap.L().Warn("Invalid language", zap.String("language", langParam), zap.Error(err))
+		return &userpb.PreviewEmailTemplateResponse{}, status.Error(codes.InvalidArgument, "language-invalid")
+	}
+
+	// Preview in the loaded language best matching the requested one
+	lang = translation.S().Match(lang)
+	loc, err := translation.S().Localizer(lang)
+	if err != nil {
+		zap.L().Error("Failed to get localizer", zap.String("language", lang.String()), zap.Error(err))
+		return &userpb.PreviewEmailTemplateResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Render the template with its sample data
+	mail, err := entry.Preview(loc)
+	if err != nil {
+		zap.L().Error("Render email template", zap.String("name", entry.Name()), zap.Error(err))
+		return &userpb.PreviewEmailTemplateResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &userpb.PreviewEmailTemplateResponse{
+		Name:             entry.Name(),
+		Language:         lang.String(),
+		Subject:          mail.Subject,
+		PlainTextContent: mail.PlainTextContent,
+		HtmlContent:      mail.HTMLContent,
+	}, nil
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// TestPreviewEmailTemplate tests the PreviewEmailTemplate method
+func TestPreviewEmailTemplate(t *testing.T) {
+	service := &Service{}
+	viper.Set("DEFAULT_LANGUAGE", "en")
+	defer viper.Set("DEFAULT_LANGUAGE", "")
+
+	// Define tests
+	tests := []struct {
+		name             string
+		mockSetup        func(ctrl *gomock.Controller)
+		request          *userpb.PreviewEmailTemplateRequest
+		expectedLanguage string
+		expectedErrCode  codes.Code
+	}{
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the translation service
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Times(0)
+				translation.ReplaceGlobals(tr)
+			},
+			request:         &userpb.PreviewEmailTemplateRequest{Name: "welcome"},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to find the template",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the translation service
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Times(0)
+				translation.ReplaceGlobals(tr)
+			},
+			request:         &userpb.PreviewEmailTemplateRequest{Name: "unknown"},
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "fails to parse the language",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the translation service
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Times(0)
+				translation.ReplaceGlobals(tr)
+			},
+			request:         &userpb.PreviewEmailTemplateRequest{Name: "welcome", Language: "not a language"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to get the localizer",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the translation service
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Match(language.German).Return(language.English)
+				tr.EXPECT().Localizer(language.English).Return(nil, errors.New("error"))
+				translation.ReplaceGlobals(tr)
+			},
+			request:         &userpb.PreviewEmailTemplateRequest{Name: "welcome", Language: "de"},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeds with the default language",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the translation service
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Match(language.English).Return(language.English)
+				tr.EXPECT().Localizer(language.English).Return(nil, nil)
+				tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("message").AnyTimes()
+				translation.ReplaceGlobals(tr)
+			},
+			request:          &userpb.PreviewEmailTemplateRequest{Name: "welcome"},
+			expectedLanguage: "en",
+			expectedErrCode:  codes.OK,
+		},
+		{
+			name: "succeeds with the best matching language",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the translation service
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Match(language.CanadianFrench).Return(language.French)
+				tr.EXPECT().Localizer(language.French).Return(nil, nil)
+				tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("message").AnyTimes()
+				translation.ReplaceGlobals(tr)
+			},
+			request:          &userpb.PreviewEmailTemplateRequest{Name: "weekly-summary", Language: "fr-CA"},
+			expectedLanguage: "fr",
+			expectedErrCode:  codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.PreviewEmailTemplate(context.Background(), tt.request)
+
+			// Handle errors and response
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Equal(t, tt.expectedLanguage, response.GetLanguage())
+			if tt.expectedErrCode == codes.OK {
+				assert.Equal(t, tt.request.GetName(), response.GetName())
+				assert.Equal(t, "message", response.GetSubject())
+				assert.NotEmpty(t, response.GetHtmlContent())
+			}
+		})
+	}
+}
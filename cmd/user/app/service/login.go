@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/pkg/email/templates"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LoginHistoryPolicy defines how many successful login attempts, and as many failed ones, are kept for each user,
+// the oldest ones being removed first.
+type LoginHistoryPolicy struct {
+	Size int
+}
+
+// NewLoginHistoryPolicyFromConfig creates a new LoginHistoryPolicy from the configuration
+func NewLoginHistoryPolicyFromConfig() LoginHistoryPolicy {
+	policy := LoginHistoryPolicy{
+		Size: viper.GetInt("USER_LOGIN_HISTORY_SIZE"),
+	}
+
+	if policy.Size <= 0 {
+		policy.Size = 50
+	}
+
+	return policy
+}
+
+// ListUserLogins implements the ListUserLogins RPC method.
+// The logins are sorted from the most recent one, and the limit is bounded by the size of the history.
+func (s *Service) ListUserLogins(ctx context.Context, req *userpb.ListUserLoginsRequest) (*userpb.ListUserLoginsResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &userpb.ListUserLoginsResponse{}, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.users.read", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.ListUserLoginsResponse{}, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > s.loginHistory.Size {
+		limit = s.loginHistory.Size
+	}
+
+	// List the logins
+	logins, err := repositories.R().ListLogins(userID, limit)
+	if err != nil {
+		zap.L().Error("List user logins", zap.String("uuid", userID.String()), zap.Error(err))
+		return &userpb.ListUserLoginsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &userpb.ListUserLoginsResponse{
+		Logins: mappers.UserLoginsToProto(logins),
+	}, nil
+}
+
+// recordLogin adds the login attempt described by the request to the history of the user.
+// A failed attempt holds the reason of the failure, an empty reason standing for a successful attempt.
+// The history is informative only : failing to record it is logged, without preventing the user to log in.
+func (s *Service) recordLogin(req *userpb.AuthenticateUserRequest, userID uuid.UUID, reason string) models.UserLogin {
+	login := models.InitUserLogin(userID, req.GetIpAddress(), req.GetUserAgent(), req.GetMethod())
+	login.Success = reason == ""
+	login.Reason = reason
+
+	err := repositories.R().RecordLogin(login, s.loginHistory.Size)
+	if err != nil {
+		zap.L().Error("Record user login", zap.String("uuid", userID.String()), zap.Error(err))
+	}
+	return login
+}
+
+// isNewDevice tells whether the user is logging in from a device never used before.
+// The very first login of a user, or a login from an unknown user agent, is not considered as such.
+func isNewDevice(user models.User, userAgent string) bool {
+	if user.LastLoginAt == nil || userAgent == "" {
+		return false
+	}
+
+	known, err := repositories.R().HasLoggedInFrom(user.ID, userAgent)
+	if err != nil {
+		zap.L().Error("Check user login device", zap.String("uuid", user.ID.String()), zap.Error(err))
+		return false
+	}
+	return !known
+}
+
+// enqueueNewLoginEmail warns the user about a successful login from a new device.
+// The email is delivered in the background, so that the login is not delayed by the transport.
+func enqueueNewLoginEmail(user models.User, login models.UserLogin) {
+	err := enqueueEmail("new-login:"+login.ID.String(), user.Email, user.EmailLanguage(""), templates.NewLogin, templates.NewLoginData{
+		Date:      login.OccurredAt,
+		Device:    login.UserAgent,
+		IPAddress: login.IPAddress,
+	})
+	if err != nil {
+		zap.L().Error("Failed to enqueue new login email", zap.Error(err))
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// TestNewLoginHistoryPolicyFromConfig tests the NewLoginHistoryPolicyFromConfig function
+func TestNewLoginHistoryPolicyFromConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		viper.Reset()
+		policy := NewLoginHistoryPolicyFromConfig()
+		assert.Equal(t, LoginHistoryPolicy{Size: 50}, policy)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		viper.Set("USER_LOGIN_HISTORY_SIZE", "10")
+		defer viper.Reset()
+
+		policy := NewLoginHistoryPolicyFromConfig()
+		assert.Equal(t, LoginHistoryPolicy{Size: 10}, policy)
+	})
+}
+
+// TestListUserLogins tests the ListUserLogins method
+func TestListUserLogins(t *testing.T) {
+	service := &Service{loginHistory: LoginHistoryPolicy{Size: 50}}
+	userID := uuid.New()
+	validRequest := &userpb.ListUserLoginsRequest{
+		UserId: userID.String(),
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *userpb.ListUserLoginsRequest
+		expectedLength  int
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &userpb.ListUserLoginsRequest{UserId: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListLogins(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to list the logins",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListLogins(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "bounds the limit to the size of the history",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListLogins(userID, 50).Return(models.UserLogins{}, nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         &userpb.ListUserLoginsRequest{UserId: userID.String(), Limit: 100},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().ListLogins(userID, 10).Return(models.UserLogins{
+					{ID: uuid.New(), UserID: userID, Success: true},
+					{ID: uuid.New(), UserID: userID, Reason: "invalid-credentials"},
+				}, nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         &userpb.ListUserLoginsRequest{UserId: userID.String(), Limit: 10},
+			expectedLength:  2,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.ListUserLogins(context.Background(), tt.request)
+
+			// Handle errors and response
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Len(t, response.GetLogins(), tt.expectedLength)
+		})
+	}
+}
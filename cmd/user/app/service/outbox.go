@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/pkg/email"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// OutboxPolicy defines how the emails enqueued in the outbox are delivered.
+// A failed delivery is attempted again after a delay doubling from BaseBackoff up to MaxBackoff,
+// and the email is dead once MaxAttempts deliveries failed.
+// Each sweep claims up to BatchSize emails, leased for Lease : an email still being sent after that is due again.
+// A dead email can be retried for DeadRetention, after which it is purged along with its contents.
+type OutboxPolicy struct {
+	BatchSize     int
+	MaxAttempts   int
+	BaseBackoff   time.Duration
+	MaxBackoff    time.Duration
+	Lease         time.Duration
+	DeadRetention time.Duration
+}
+
+// NewOutboxPolicyFromConfig creates a new OutboxPolicy from the configuration
+func NewOutboxPolicyFromConfig() OutboxPolicy {
+	policy := OutboxPolicy{
+		BatchSize:     viper.GetInt("EMAIL_OUTBOX_BATCH_SIZE"),
+		MaxAttempts:   viper.GetInt("EMAIL_OUTBOX_MAX_ATTEMPTS"),
+		BaseBackoff:   viper.GetDuration("EMAIL_OUTBOX_BACKOFF_BASE"),
+		MaxBackoff:    viper.GetDuration("EMAIL_OUTBOX_BACKOFF_MAX"),
+		Lease:         viper.GetDuration("EMAIL_OUTBOX_LEASE"),
+		DeadRetention: viper.GetDuration("EMAIL_OUTBOX_DEAD_RETENTION"),
+	}
+
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = 20
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 8
+	}
+	if policy.BaseBackoff <= 0 {
+		policy.BaseBackoff = 30 * time.Second
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = time.Hour
+	}
+	if policy.MaxBackoff < policy.BaseBackoff {
+		policy.MaxBackoff = policy.BaseBackoff
+	}
+	if policy.Lease <= 0 {
+		policy.Lease = 5 * time.Minute
+	}
+	if policy.DeadRetention <= 0 {
+		policy.DeadRetention = 7 * 24 * time.Hour
+	}
+
+	return policy
+}
+
+// Backoff returns the delay before attempting again the delivery of an email which failed the given attempts
+func (p OutboxPolicy) Backoff(attempts int) time.Duration {
+	backoff := p.BaseBackoff
+	for i := 1; i < attempts && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, p.MaxBackoff)
+}
+
+// DeliverOutboxEmails sends the emails of the outbox which are due.
+// A failed delivery is scheduled again according to the policy, until the email is dead.
+// The dead emails past their retention are purged, so that their contents are not kept forever.
+func (s *Service) DeliverOutboxEmails() {
+	purged, err := outbox.R().PurgeDead(time.Now().Add(-s.outbox.DeadRetention))
+	if err != nil {
+		zap.L().Error("Cannot purge dead outbox emails", zap.Error(err))
+	} else if purged > 0 {
+		zap.L().Info("Purged dead outbox emails", zap.Int64("count", purged))
+	}
+
+	emails, err := outbox.R().Claim(s.outbox.BatchSize, time.Now().Add(s.outbox.Lease))
+	if err != nil {
+		zap.L().Error("Cannot claim outbox emails", zap.Error(err))
+		return
+	}
+
+	for _, outboxEmail := range emails {
+		s.deliverOutboxEmail(outboxEmail)
+	}
+}
+
+// deliverOutboxEmail sends a claimed email and records the outcome of the attempt
+func (s *Service) deliverOutboxEmail(outboxEmail models.OutboxEmail) {
+	// An invalid email will never be delivered
+	if ok, err := outboxEmail.IsValid(); !ok {
+		zap.L().Error("Outbox email is not valid", zap.String("uuid", outboxEmail.ID.String()), zap.Error(err))
+		if err = outbox.R().MarkDead(outboxEmail.ID, err.Error()); err != nil {
+			zap.L().Error("Cannot mark outbox email as dead", zap.String("uuid", outboxEmail.ID.String()), zap.Error(err))
+		}
+		return
+	}
+
+	err := email.S().SendMessage(outboxEmail.Message())
+	if err == nil {
+		if err = outbox.R().MarkSent(outboxEmail.ID); err != nil {
+			zap.L().Error("Cannot mark outbox email as sent", zap.String("uuid", outboxEmail.ID.String()), zap.Error(err))
+		}
+		return
+	}
+
+	// Out of attempts
+	if outboxEmail.Attempts >= s.outbox.MaxAttempts {
+		zap.L().Error("Outbox email is dead",
+			zap.String("uuid", outboxEmail.ID.String()),
+			zap.Int("attempts", outboxEmail.Attempts),
+			zap.Error(err))
+		if err = outbox.R().MarkDead(outboxEmail.ID, err.Error()); err != nil {
+			zap.L().Error("Cannot mark outbox email as dead", zap.String("uuid", outboxEmail.ID.String()), zap.Error(err))
+		}
+		return
+	}
+
+	nextAttemptAt := time.Now().Add(s.outbox.Backoff(outboxEmail.Attempts))
+	zap.L().Warn("Outbox email delivery failed",
+		zap.String("uuid", outboxEmail.ID.String()),
+		zap.Int("attempts", outboxEmail.Attempts),
+		zap.Time("next_attempt_at", nextAttemptAt),
+		zap.Error(err))
+	if err = outbox.R().Reschedule(outboxEmail.ID, err.Error(), nextAttemptAt); err != nil {
+		zap.L().Error("Cannot reschedule outbox email", zap.String("uuid", outboxEmail.ID.String()), zap.Error(err))
+	}
+}
+
+// ListOutboxEmails implements the ListOutboxEmails RPC method.
+// The emails are sorted from the most recently enqueued one.
+func (s *Service) ListOutboxEmails(ctx context.Context, req *userpb.ListOutboxEmailsRequest) (*userpb.ListOutboxEmailsResponse, error) {
+	// Check user permissions
+	err := security.Facade().CheckPermission(ctx, "admin.emails.read")
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.ListOutboxEmailsResponse{}, err
+	}
+
+	// Construct the filter from the request
+	filter := models.OutboxEmailFilter{
+		Status:    req.GetStatus(),
+		Recipient: req.GetRecipient(),
+		Limit:     int(req.GetLimit()),
+		Offset:    int(req.GetOffset()),
+	}
+	if ok, err := filter.IsValid(); !ok {
+		zap.L().Warn("Outbox email filter is not valid", zap.Error(err))
+		return &userpb.ListOutboxEmailsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// List the emails
+	emails, err := outbox.R().List(filter.Normalize())
+	if err != nil {
+		zap.L().Error("List outbox emails", zap.Error(err))
+		return &userpb.ListOutboxEmailsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &userpb.ListOutboxEmailsResponse{
+		Emails: mappers.OutboxEmailsToProto(emails),
+	}, nil
+}
+
+// GetOutboxEmail implements the GetOutboxEmail RPC method.
+func (s *Service) GetOutboxEmail(ctx context.Context, req *userpb.GetOutboxEmailRequest) (*userpb.GetOutboxEmailResponse, error) {
+	// Parse the email ID from the request
+	emailID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		zap.L().Error("Invalid outbox email ID", zap.String("id", req.GetId()), zap.Error(err))
+		return &userpb.GetOutboxEmailResponse{}, status.Error(codes.InvalidArgument, "invalid email ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.emails.read")
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.GetOutboxEmailResponse{}, err
+	}
+
+	// Get the email
+	outboxEmail, found, err := outbox.R().Get(emailID)
+	if err != nil {
+		zap.L().Error("Get outbox email", zap.String("uuid", emailID.String()), zap.Error(err))
+		return &userpb.GetOutboxEmailResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Warn("Outbox email not found", zap.String("uuid", emailID.String()))
+		return &userpb.GetOutboxEmailResponse{}, status.Error(codes.NotFound, "email not found")
+	}
+
+	return &userpb.GetOutboxEmailResponse{
+		Email: mappers.OutboxEmailToProto(outboxEmail),
+	}, nil
+}
+
+// RetryOutboxEmail implements the RetryOutboxEmail RPC method.
+// Only dead emails can be retried : their delivery starts over, with a fresh count of attempts.
+func (s *Service) RetryOutboxEmail(ctx context.Context, req *userpb.RetryOutboxEmailRequest) (*userpb.RetryOutboxEmailResponse, error) {
+	// Parse the email ID from the request
+	emailID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		zap.L().Error("Invalid outbox email ID", zap.String("id", req.GetId()), zap.Error(err))
+		return &userpb.RetryOutboxEmailResponse{}, status.Error(codes.InvalidArgument, "invalid email ID")
+	}
+
+	// Check user permissions
+	err = security.Facade().CheckPermission(ctx, "admin.emails.retry")
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.RetryOutboxEmailResponse{}, err
+	}
+
+	// Retry the email
+	retried, err := outbox.R().Retry(emailID)
+	if err != nil {
+		zap.L().Error("Retry outbox email", zap.String("uuid", emailID.String()), zap.Error(err))
+		return &userpb.RetryOutboxEmailResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Get the email, whether retried or not
+	outboxEmail, found, err := outbox.R().Get(emailID)
+	if err != nil {
+		zap.L().Error("Get outbox email", zap.String("uuid", emailID.String()), zap.Error(err))
+		return &userpb.RetryOutboxEmailResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Warn("Outbox email not found", zap.String("uuid", emailID.String()))
+		return &userpb.RetryOutboxEmailResponse{}, status.Error(codes.NotFound, "email not found")
+	}
+	if !retried {
+		zap.L().Warn("Outbox email is not dead", zap.String("uuid", emailID.String()), zap.String("status", outboxEmail.Status))
+		return &userpb.RetryOutboxEmailResponse{}, status.Error(codes.FailedPrecondition, "email-not-dead")
+	}
+
+	zap.L().Info("Outbox email retried", zap.String("uuid", emailID.String()))
+	return &userpb.RetryOutboxEmailResponse{
+		Email: mappers.OutboxEmailToProto(outboxEmail),
+	}, nil
+}
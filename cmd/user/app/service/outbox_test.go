@@ -0,0 +1,550 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/pkg/email"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+// TestNewOutboxPolicyFromConfig tests the NewOutboxPolicyFromConfig function
+func TestNewOutboxPolicyFromConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		viper.Reset()
+		policy := NewOutboxPolicyFromConfig()
+		assert.Equal(t, OutboxPolicy{
+			BatchSize:     20,
+			MaxAttempts:   8,
+			BaseBackoff:   30 * time.Second,
+			MaxBackoff:    time.Hour,
+			Lease:         5 * time.Minute,
+			DeadRetention: 7 * 24 * time.Hour,
+		}, policy)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		viper.Set("EMAIL_OUTBOX_BATCH_SIZE", "5")
+		viper.Set("EMAIL_OUTBOX_MAX_ATTEMPTS", "3")
+		viper.Set("EMAIL_OUTBOX_BACKOFF_BASE", "1m")
+		viper.Set("EMAIL_OUTBOX_BACKOFF_MAX", "10m")
+		viper.Set("EMAIL_OUTBOX_LEASE", "2m")
+		viper.Set("EMAIL_OUTBOX_DEAD_RETENTION", "24h")
+		defer viper.Reset()
+
+		policy := NewOutboxPolicyFromConfig()
+		assert.Equal(t, OutboxPolicy{
+			BatchSize:     5,
+			MaxAttempts:   3,
+			BaseBackoff:   time.Minute,
+			MaxBackoff:    10 * time.Minute,
+			Lease:         2 * time.Minute,
+			DeadRetention: 24 * time.Hour,
+		}, policy)
+	})
+
+	t.Run("Maximum backoff below the base one", func(t *testing.T) {
+		viper.Set("EMAIL_OUTBOX_BACKOFF_BASE", "1m")
+		viper.Set("EMAIL_OUTBOX_BACKOFF_MAX", "10s")
+		defer viper.Reset()
+
+		policy := NewOutboxPolicyFromConfig()
+		assert.Equal(t, time.Minute, policy.MaxBackoff)
+	})
+}
+
+// TestOutboxPolicy_Backoff tests the Backoff method of the OutboxPolicy
+func TestOutboxPolicy_Backoff(t *testing.T) {
+	policy := OutboxPolicy{BaseBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+
+	assert.Equal(t, 30*time.Second, policy.Backoff(0))
+	assert.Equal(t, 30*time.Second, policy.Backoff(1))
+	assert.Equal(t, time.Minute, policy.Backoff(2))
+	assert.Equal(t, 2*time.Minute, policy.Backoff(3))
+	assert.Equal(t, 4*time.Minute, policy.Backoff(4))
+	assert.Equal(t, 5*time.Minute, policy.Backoff(5))
+	assert.Equal(t, 5*time.Minute, policy.Backoff(1000))
+}
+
+// TestDeliverOutboxEmails tests the DeliverOutboxEmails method
+func TestDeliverOutboxEmails(t *testing.T) {
+	service := &Service{outbox: OutboxPolicy{BatchSize: 10, MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour, Lease: time.Minute, DeadRetention: time.Hour}}
+	outboxEmail := models.InitOutboxEmail("key", email.NewMessage("jane@example.com", "Subject", "Plain", "<p>HTML</p>"))
+	outboxEmail.Status = models.OutboxStatusSending
+	outboxEmail.Attempts = 1
+
+	// Define tests
+	tests := []struct {
+		name      string
+		mockSetup func(ctrl *gomock.Controller)
+	}{
+		{
+			name: "purges the dead emails past their retention",
+			mockSetup: func(ctrl *gomock.Controller) {
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().PurgeDead(gomock.Cond(func(x any) bool {
+					age := time.Since(x.(time.Time))
+					return age >= time.Hour && age < time.Hour+time.Minute
+				})).Return(int64(2), nil)
+				or.EXPECT().Claim(10, gomock.Any()).Return(models.OutboxEmails{}, nil)
+				outbox.ReplaceGlobals(or)
+			},
+		},
+		{
+			name: "keeps on delivering when the dead emails can not be purged",
+			mockSetup: func(ctrl *gomock.Controller) {
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().PurgeDead(gomock.Any()).Return(int64(0), errors.New("error"))
+				or.EXPECT().Claim(10, gomock.Any()).Return(models.OutboxEmails{outboxEmail}, nil)
+				or.EXPECT().MarkSent(outboxEmail.ID).Return(nil)
+				outbox.ReplaceGlobals(or)
+				e := email.NewMockService(ctrl)
+				e.EXPECT().SendMessage(outboxEmail.Message()).Return(nil)
+				email.ReplaceGlobals(e)
+			},
+		},
+		{
+			name: "fails to claim the emails",
+			mockSetup: func(ctrl *gomock.Controller) {
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().PurgeDead(gomock.Any()).Return(int64(0), nil)
+				or.EXPECT().Claim(10, gomock.Any()).Return(nil, errors.New("error"))
+				outbox.ReplaceGlobals(or)
+				e := email.NewMockService(ctrl)
+				e.EXPECT().SendMessage(gomock.Any()).Times(0)
+				email.ReplaceGlobals(e)
+			},
+		},
+		{
+			name: "marks the invalid emails as dead",
+			mockSetup: func(ctrl *gomock.Controller) {
+				invalid := outboxEmail
+				invalid.To = []string{"not an email"}
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().PurgeDead(gomock.Any()).Return(int64(0), nil)
+				or.EXPECT().Claim(10, gomock.Any()).Return(models.OutboxEmails{invalid}, nil)
+				or.EXPECT().MarkDead(outboxEmail.ID, gomock.Any()).Return(nil)
+				outbox.ReplaceGlobals(or)
+				e := email.NewMockService(ctrl)
+				e.EXPECT().SendMessage(gomock.Any()).Times(0)
+				email.ReplaceGlobals(e)
+			},
+		},
+		{
+			name: "marks the delivered emails as sent",
+			mockSetup: func(ctrl *gomock.Controller) {
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().PurgeDead(gomock.Any()).Return(int64(0), nil)
+				or.EXPECT().Claim(10, gomock.Any()).Return(models.OutboxEmails{outboxEmail}, nil)
+				or.EXPECT().MarkSent(outboxEmail.ID).Return(nil)
+				outbox.ReplaceGlobals(or)
+				e := email.NewMockService(ctrl)
+				e.EXPECT().SendMessage(outboxEmail.Message()).Return(nil)
+				email.ReplaceGlobals(e)
+			},
+		},
+		{
+			name: "reschedules the failed emails with a backoff",
+			mockSetup: func(ctrl *gomock.Controller) {
+				failing := outboxEmail
+				failing.Attempts = 2
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().PurgeDead(gomock.Any()).Return(int64(0), nil)
+				or.EXPECT().Claim(10, gomock.Any()).Return(models.OutboxEmails{failing}, nil)
+				or.EXPECT().Reschedule(outboxEmail.ID, "error", gomock.Cond(func(x any) bool {
+					delay := time.Until(x.(time.Time))
+					return delay > time.Minute && delay <= 2*time.Minute
+				})).Return(nil)
+				outbox.ReplaceGlobals(or)
+				e := email.NewMockService(ctrl)
+				e.EXPECT().SendMessage(gomock.Any()).Return(errors.New("error"))
+				email.ReplaceGlobals(e)
+			},
+		},
+		{
+			name: "marks the emails out of attempts as dead",
+			mockSetup: func(ctrl *gomock.Controller) {
+				failing := outboxEmail
+				failing.Attempts = 3
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().PurgeDead(gomock.Any()).Return(int64(0), nil)
+				or.EXPECT().Claim(10, gomock.Any()).Return(models.OutboxEmails{failing}, nil)
+				or.EXPECT().Reschedule(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				or.EXPECT().MarkDead(outboxEmail.ID, "error").Return(nil)
+				outbox.ReplaceGlobals(or)
+				e := email.NewMockService(ctrl)
+				e.EXPECT().SendMessage(gomock.Any()).Return(errors.New("error"))
+				email.ReplaceGlobals(e)
+			},
+		},
+		{
+			name: "keeps on delivering when an outcome can not be recorded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				other := outboxEmail
+				other.ID = uuid.New()
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().PurgeDead(gomock.Any()).Return(int64(0), nil)
+				or.EXPECT().Claim(10, gomock.Any()).Return(models.OutboxEmails{outboxEmail, other}, nil)
+				or.EXPECT().MarkSent(outboxEmail.ID).Return(errors.New("error"))
+				or.EXPECT().MarkSent(other.ID).Return(nil)
+				outbox.ReplaceGlobals(or)
+				e := email.NewMockService(ctrl)
+				e.EXPECT().SendMessage(gomock.Any()).Return(nil).Times(2)
+				email.ReplaceGlobals(e)
+			},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			service.DeliverOutboxEmails()
+		})
+	}
+}
+
+// TestListOutboxEmails tests the ListOutboxEmails method
+func TestListOutboxEmails(t *testing.T) {
+	service := &Service{}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *userpb.ListOutboxEmailsRequest
+		expectedLength  int
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().List(gomock.Any()).Times(0)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         &userpb.ListOutboxEmailsRequest{},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to validate the filter",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().List(gomock.Any()).Times(0)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         &userpb.ListOutboxEmailsRequest{Status: "lost"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to list the emails",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().List(gomock.Any()).Return(nil, errors.New("error"))
+				outbox.ReplaceGlobals(or)
+			},
+			request:         &userpb.ListOutboxEmailsRequest{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().List(models.OutboxEmailFilter{
+					Status:    models.OutboxStatusDead,
+					Recipient: "jane@example.com",
+					Limit:     models.OutboxEmailsDefaultLimit,
+				}).Return(models.OutboxEmails{
+					{ID: uuid.New(), Status: models.OutboxStatusDead},
+				}, nil)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         &userpb.ListOutboxEmailsRequest{Status: models.OutboxStatusDead, Recipient: "jane@example.com"},
+			expectedLength:  1,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.ListOutboxEmails(context.Background(), tt.request)
+
+			// Handle errors and response
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Len(t, response.GetEmails(), tt.expectedLength)
+		})
+	}
+}
+
+// TestGetOutboxEmail tests the GetOutboxEmail method
+func TestGetOutboxEmail(t *testing.T) {
+	service := &Service{}
+	emailID := uuid.New()
+	validRequest := &userpb.GetOutboxEmailRequest{Id: emailID.String()}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *userpb.GetOutboxEmailRequest
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &userpb.GetOutboxEmailRequest{Id: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Get(gomock.Any()).Times(0)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to get the email",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Get(emailID).Return(models.OutboxEmail{}, false, errors.New("error"))
+				outbox.ReplaceGlobals(or)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "does not find the email",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Get(emailID).Return(models.OutboxEmail{}, false, nil)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Get(emailID).Return(models.OutboxEmail{ID: emailID, Status: models.OutboxStatusSent}, true, nil)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.GetOutboxEmail(context.Background(), tt.request)
+
+			// Handle errors and response
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			if tt.expectedErrCode == codes.OK {
+				assert.Equal(t, emailID.String(), response.GetEmail().GetId())
+			}
+		})
+	}
+}
+
+// TestRetryOutboxEmail tests the RetryOutboxEmail method
+func TestRetryOutboxEmail(t *testing.T) {
+	service := &Service{}
+	emailID := uuid.New()
+	validRequest := &userpb.RetryOutboxEmailRequest{Id: emailID.String()}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *userpb.RetryOutboxEmailRequest
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &userpb.RetryOutboxEmailRequest{Id: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Retry(gomock.Any()).Times(0)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to retry the email",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Retry(emailID).Return(false, errors.New("error"))
+				outbox.ReplaceGlobals(or)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "does not find the email",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Retry(emailID).Return(false, nil)
+				or.EXPECT().Get(emailID).Return(models.OutboxEmail{}, false, nil)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "does not retry an email which is not dead",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Retry(emailID).Return(false, nil)
+				or.EXPECT().Get(emailID).Return(models.OutboxEmail{ID: emailID, Status: models.OutboxStatusSent}, true, nil)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.FailedPrecondition,
+		},
+		{
+			name: "succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the outbox repository
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Retry(emailID).Return(true, nil)
+				or.EXPECT().Get(emailID).Return(models.OutboxEmail{ID: emailID, Status: models.OutboxStatusPending}, true, nil)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.RetryOutboxEmail(context.Background(), tt.request)
+
+			// Handle errors and response
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			if tt.expectedErrCode == codes.OK {
+				assert.Equal(t, models.OutboxStatusPending, response.GetEmail().GetStatus())
+			}
+		})
+	}
+}
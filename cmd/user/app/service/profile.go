@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateUserProfile implements the UpdateUserProfile RPC method.
+func (s *Service) UpdateUserProfile(ctx context.Context, req *userpb.UpdateUserProfileRequest) (*userpb.UpdateUserProfileResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetId()), zap.Error(err))
+		return &userpb.UpdateUserProfileResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	err = security.Facade().CheckPermission(ctx, "admin.users.update", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.UpdateUserProfileResponse{}, err
+	}
+
+	// Validate profile
+	profile := mappers.UserProfileFromProto(req.GetProfile())
+	if ok, err := profile.IsValid(); !ok {
+		zap.L().Warn("User profile is not valid", zap.Error(err))
+		return &userpb.UpdateUserProfileResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Store the language in its canonical form
+	if tag, ok := profile.LanguageTag(); ok {
+		profile.Language = tag.String()
+	}
+
+	// Update profile
+	err = repositories.R().UpdateProfile(userID, profile)
+	if err != nil {
+		zap.L().Error("UpdateUserProfile.UpdateProfile", zap.Error(err))
+		return &userpb.UpdateUserProfileResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Get user back from database
+	user, found, err := repositories.R().Get(userID)
+	if err != nil {
+		zap.L().Error("Cannot get user", zap.String("uuid", userID.String()), zap.Error(err))
+		return &userpb.UpdateUserProfileResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Error("User not found after update", zap.String("uuid", userID.String()))
+		return &userpb.UpdateUserProfileResponse{}, status.Error(codes.Internal, "User not found after update")
+	}
+
+	return &userpb.UpdateUserProfileResponse{
+		User: mappers.UserToProto(user),
+	}, nil
+}
+
+// SetUserAvatar implements the SetUserAvatar RPC method.
+func (s *Service) SetUserAvatar(ctx context.Context, req *userpb.SetUserAvatarRequest) (*userpb.SetUserAvatarResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &userpb.SetUserAvatarResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	err = security.Facade().CheckPermission(ctx, "admin.users.update", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.SetUserAvatarResponse{}, err
+	}
+
+	// Validate avatar
+	avatar := models.UserAvatar{
+		UserID: userID,
+		Name:   req.GetName(),
+		Data:   req.GetData(),
+	}
+	if ok, err := avatar.IsValid(); !ok {
+		zap.L().Warn("User avatar is not valid", zap.Error(err))
+		return &userpb.SetUserAvatarResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Create or replace the avatar
+	err = repositories.R().SetAvatar(avatar)
+	if err != nil {
+		zap.L().Error("SetUserAvatar.SetAvatar", zap.Error(err))
+		return &userpb.SetUserAvatarResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &userpb.SetUserAvatarResponse{
+		Success: true,
+	}, nil
+}
+
+// GetUserAvatar implements the GetUserAvatar RPC method.
+func (s *Service) GetUserAvatar(ctx context.Context, req *userpb.GetUserAvatarRequest) (*userpb.GetUserAvatarResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &userpb.GetUserAvatarResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	err = security.Facade().CheckPermission(ctx, "admin.users.read", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.GetUserAvatarResponse{}, err
+	}
+
+	// Get avatar
+	avatar, found, err := repositories.R().GetAvatar(userID)
+	if err != nil {
+		zap.L().Error("GetUserAvatar.GetAvatar", zap.Error(err))
+		return &userpb.GetUserAvatarResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Debug("User avatar not found", zap.String("uuid", userID.String()))
+		return &userpb.GetUserAvatarResponse{}, status.Error(codes.NotFound, "User avatar not found")
+	}
+
+	return &userpb.GetUserAvatarResponse{
+		Name: avatar.Name,
+		Data: avatar.Data,
+	}, nil
+}
+
+// DeleteUserAvatar implements the DeleteUserAvatar RPC method.
+func (s *Service) DeleteUserAvatar(ctx context.Context, req *userpb.DeleteUserAvatarRequest) (*userpb.DeleteUserAvatarResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &userpb.DeleteUserAvatarResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	err = security.Facade().CheckPermission(ctx, "admin.users.update", userID)
+	if err != nil {
+		zap.L().Error("CheckPermission", zap.Error(err))
+		return &userpb.DeleteUserAvatarResponse{}, err
+	}
+
+	// Verify avatar existence
+	_, found, err := repositories.R().GetAvatar(userID)
+	if err != nil {
+		zap.L().Error("DeleteUserAvatar.GetAvatar", zap.Error(err))
+		return &userpb.DeleteUserAvatarResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Warn("User avatar not found", zap.String("uuid", userID.String()))
+		return &userpb.DeleteUserAvatarResponse{}, status.Error(codes.NotFound, "User avatar not found")
+	}
+
+	// Delete avatar
+	err = repositories.R().DeleteAvatar(userID)
+	if err != nil {
+		zap.L().Error("DeleteUserAvatar.DeleteAvatar", zap.Error(err))
+		return &userpb.DeleteUserAvatarResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &userpb.DeleteUserAvatarResponse{
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,489 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// TestUpdateUserProfile tests the UpdateUserProfile method
+func TestUpdateUserProfile(t *testing.T) {
+	service := &Service{}
+	userID := uuid.New()
+	validRequest := &userpb.UpdateUserProfileRequest{
+		Id: userID.String(),
+		Profile: &userpb.UserProfile{
+			DisplayName: "Jane",
+			Language:    "FR-ca",
+			Timezone:    "Europe/Paris",
+		},
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *userpb.UpdateUserProfileRequest
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &userpb.UpdateUserProfileRequest{Id: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().UpdateProfile(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "invalid profile",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().UpdateProfile(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request: &userpb.UpdateUserProfileRequest{
+				Id:      userID.String(),
+				Profile: &userpb.UserProfile{Timezone: "Mars/Olympus"},
+			},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to update the profile",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().UpdateProfile(gomock.Any(), gomock.Any()).Return(errors.New("error"))
+				ur.EXPECT().Get(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "fails to retrieve the user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().UpdateProfile(gomock.Any(), gomock.Any()).Return(nil)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{}, false, errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository : the language is stored in its canonical form
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().UpdateProfile(userID, models.UserProfile{
+					DisplayName: "Jane",
+					Language:    "fr-CA",
+					Timezone:    "Europe/Paris",
+				}).Return(nil)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{ID: userID}, true, nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.UpdateUserProfile(context.Background(), tt.request)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+
+			// Handle response
+			if tt.expectedErrCode == codes.OK {
+				assert.Equal(t, userID.String(), response.GetUser().GetId())
+			} else {
+				assert.Equal(t, &userpb.UpdateUserProfileResponse{}, response)
+			}
+		})
+	}
+}
+
+// TestSetUserAvatar tests the SetUserAvatar method
+func TestSetUserAvatar(t *testing.T) {
+	service := &Service{}
+	validRequest := &userpb.SetUserAvatarRequest{
+		UserId: uuid.New().String(),
+		Name:   "avatar.png",
+		Data:   []byte{1},
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *userpb.SetUserAvatarRequest
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &userpb.SetUserAvatarRequest{UserId: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().SetAvatar(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "invalid avatar",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().SetAvatar(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request: &userpb.SetUserAvatarRequest{
+				UserId: validRequest.UserId,
+				Name:   "avatar.png",
+			},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to set the avatar",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().SetAvatar(gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().SetAvatar(gomock.Any()).Return(nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.SetUserAvatar(context.Background(), tt.request)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Equal(t, tt.expectedErrCode == codes.OK, response.GetSuccess())
+		})
+	}
+}
+
+// TestGetUserAvatar tests the GetUserAvatar method
+func TestGetUserAvatar(t *testing.T) {
+	service := &Service{}
+	validRequest := &userpb.GetUserAvatarRequest{
+		UserId: uuid.New().String(),
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *userpb.GetUserAvatarRequest
+		expected        *userpb.GetUserAvatarResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &userpb.GetUserAvatarRequest{UserId: "bad-uuid"},
+			expected:        &userpb.GetUserAvatarResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().GetAvatar(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.GetUserAvatarResponse{},
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "fails to retrieve the avatar",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().GetAvatar(gomock.Any()).Return(models.UserAvatar{}, false, errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.GetUserAvatarResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "no avatar",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().GetAvatar(gomock.Any()).Return(models.UserAvatar{}, false, nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.GetUserAvatarResponse{},
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "Succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().GetAvatar(gomock.Any()).Return(models.UserAvatar{Name: "avatar.png", Data: []byte{1}}, true, nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.GetUserAvatarResponse{Name: "avatar.png", Data: []byte{1}},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.GetUserAvatar(context.Background(), tt.request)
+
+			// Handle errors and response
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+}
+
+// TestDeleteUserAvatar tests the DeleteUserAvatar method
+func TestDeleteUserAvatar(t *testing.T) {
+	service := &Service{}
+	validRequest := &userpb.DeleteUserAvatarRequest{
+		UserId: uuid.New().String(),
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *userpb.DeleteUserAvatarRequest
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+			},
+			request:         &userpb.DeleteUserAvatarRequest{UserId: "bad-uuid"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "does not have permission",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: false}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().DeleteAvatar(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "no avatar",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().GetAvatar(gomock.Any()).Return(models.UserAvatar{}, false, nil)
+				ur.EXPECT().DeleteAvatar(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "fails to delete the avatar",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().GetAvatar(gomock.Any()).Return(models.UserAvatar{}, true, nil)
+				ur.EXPECT().DeleteAvatar(gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().GetAvatar(gomock.Any()).Return(models.UserAvatar{}, true, nil)
+				ur.EXPECT().DeleteAvatar(gomock.Any()).Return(nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.DeleteUserAvatar(context.Background(), tt.request)
+
+			// Handle errors
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Equal(t, tt.expectedErrCode == codes.OK, response.GetSuccess())
+		})
+	}
+}
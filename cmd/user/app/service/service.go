@@ -3,19 +3,54 @@ package service
 import (
 	"context"
 	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/brokerpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/transactionpb"
 	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
 	"github.com/Zapharaos/fihub-backend/internal/mappers"
 	"github.com/Zapharaos/fihub-backend/internal/models"
 	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/internal/verification"
+	"github.com/Zapharaos/fihub-backend/pkg/passwordpolicy"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"golang.org/x/text/language"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
 )
 
 // Service is the implementation of the UserService interface.
 type Service struct {
 	userpb.UnimplementedUserServiceServer
+	verification      EmailVerificationPolicy
+	deletion          AccountDeletionPolicy
+	loginHistory      LoginHistoryPolicy
+	outbox            OutboxPolicy
+	identity          *grpcutil.IdentityAuthority
+	brokerClient      brokerpb.BrokerServiceClient
+	transactionClient transactionpb.TransactionServiceClient
+	securityClient    securitypb.SecurityServiceClient
+	authClient        authpb.AuthServiceClient
+}
+
+// NewService creates a new Service instance
+// The clients are used to delete the data held by the other microservices once the deletion of a user is due.
+func NewService(brokerClient brokerpb.BrokerServiceClient, transactionClient transactionpb.TransactionServiceClient, securityClient securitypb.SecurityServiceClient, authClient authpb.AuthServiceClient) *Service {
+	return &Service{
+		verification:      NewEmailVerificationPolicyFromConfig(),
+		deletion:          NewAccountDeletionPolicyFromConfig(),
+		loginHistory:      NewLoginHistoryPolicyFromConfig(),
+		outbox:            NewOutboxPolicyFromConfig(),
+		identity:          grpcutil.NewIdentityAuthorityFromConfig(),
+		brokerClient:      brokerClient,
+		transactionClient: transactionClient,
+		securityClient:    securityClient,
+		authClient:        authClient,
+	}
 }
 
 // CreateUser implements the CreateUser RPC method.
@@ -41,8 +76,17 @@ func (s *Service) CreateUser(ctx context.Context, req *userpb.CreateUserRequest)
 		return &userpb.CreateUserResponse{}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	// Convert to UserWithPassword
+	// Check password against the policy
+	if err := passwordpolicy.S().Check(userInputCreate.Password, userInputCreate.Email); err != nil {
+		zap.L().Warn("Password does not comply with the policy", zap.Error(err))
+		return &userpb.CreateUserResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Convert to UserWithPassword, keeping the language of the registration as the preferred one
 	userWithPassword := userInputCreate.UserWithPassword
+	if lang, err := language.Parse(req.GetLanguage()); err == nil && lang != language.Und {
+		userWithPassword.Language = lang.String()
+	}
 
 	// Verify user existence
 	exists, err := repositories.R().Exists(userWithPassword.Email)
@@ -73,6 +117,12 @@ func (s *Service) CreateUser(ctx context.Context, req *userpb.CreateUserRequest)
 		return &userpb.CreateUserResponse{}, status.Error(codes.Internal, "User not found after creation")
 	}
 
+	// Send the email verification : the user can still request a new one if it fails
+	_, err = s.startEmailVerification(user.ID, user.Email, user.EmailLanguage(req.GetLanguage()))
+	if err != nil {
+		zap.L().Error("Start email verification", zap.String("uuid", userID.String()), zap.Error(err))
+	}
+
 	return &userpb.CreateUserResponse{
 		User: mappers.UserToProto(user),
 	}, nil
@@ -128,33 +178,44 @@ func (s *Service) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequest)
 	}
 
 	// Construct the user object
-	user := models.User{
+	input := models.User{
 		ID:    userID,
 		Email: req.GetEmail(),
 	}
 
 	// Validate user
-	if ok, err := user.IsValid(); !ok {
+	if ok, err := input.IsValid(); !ok {
 		zap.L().Warn("User is not valid", zap.Error(err))
 		return &userpb.UpdateUserResponse{}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	// Update user
-	err = repositories.R().Update(user)
-	if err != nil {
-		zap.L().Error("PutUser.Update", zap.Error(err))
-		return &userpb.UpdateUserResponse{}, status.Error(codes.Internal, err.Error())
-	}
-
-	// Get user back from database
+	// Get current user
 	user, found, err := repositories.R().Get(userID)
 	if err != nil {
 		zap.L().Error("Cannot get user", zap.String("uuid", userID.String()), zap.Error(err))
 		return &userpb.UpdateUserResponse{}, status.Error(codes.Internal, err.Error())
 	}
 	if !found {
-		zap.L().Error("User not found after update", zap.String("uuid", userID.String()))
-		return &userpb.UpdateUserResponse{}, status.Error(codes.Internal, "User not found after update")
+		zap.L().Warn("User not found", zap.String("uuid", userID.String()))
+		return &userpb.UpdateUserResponse{}, status.Error(codes.NotFound, "User not found")
+	}
+
+	// The email is only changed once the new address is verified
+	if input.Email != user.Email {
+		exists, err := repositories.R().Exists(input.Email)
+		if err != nil {
+			zap.L().Error("Check user exists", zap.Error(err))
+			return &userpb.UpdateUserResponse{}, status.Error(codes.Internal, err.Error())
+		}
+		if exists {
+			zap.L().Warn("User already exists", zap.String("email", input.Email))
+			return &userpb.UpdateUserResponse{}, status.Error(codes.AlreadyExists, "email-used")
+		}
+
+		_, err = s.startEmailVerification(userID, input.Email, user.EmailLanguage(req.GetLanguage()))
+		if err != nil {
+			return &userpb.UpdateUserResponse{}, err
+		}
 	}
 
 	return &userpb.UpdateUserResponse{
@@ -195,6 +256,23 @@ func (s *Service) UpdateUserPassword(ctx context.Context, req *userpb.UpdateUser
 		return &userpb.UpdateUserPasswordResponse{}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	// Get the user for the password policy
+	user, found, err := repositories.R().Get(userID)
+	if err != nil {
+		zap.L().Error("Cannot get user", zap.String("uuid", userID.String()), zap.Error(err))
+		return &userpb.UpdateUserPasswordResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Warn("User not found", zap.String("uuid", userID.String()))
+		return &userpb.UpdateUserPasswordResponse{}, status.Error(codes.NotFound, "User not found")
+	}
+
+	// Check password against the policy
+	if err := passwordpolicy.S().Check(userInputPassword.Password, user.Email); err != nil {
+		zap.L().Warn("Password does not comply with the policy", zap.Error(err))
+		return &userpb.UpdateUserPasswordResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Update password
 	err = repositories.R().UpdateWithPassword(userInputPassword.UserWithPassword)
 	if err != nil {
@@ -202,12 +280,17 @@ func (s *Service) UpdateUserPassword(ctx context.Context, req *userpb.UpdateUser
 		return &userpb.UpdateUserPasswordResponse{}, status.Error(codes.Internal, err.Error())
 	}
 
+	// Notify the user of the change
+	enqueuePasswordChangedEmail(user, time.Now())
+
 	return &userpb.UpdateUserPasswordResponse{
 		Success: true,
 	}, nil
 }
 
 // DeleteUser implements the DeleteUser RPC method.
+// The user is only scheduled for deletion : logging in within the grace period cancels it,
+// otherwise the user and its data are permanently removed by PurgeDeletedUsers.
 func (s *Service) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest) (*userpb.DeleteUserResponse, error) {
 	// Parse the user ID from the request
 	userID, err := uuid.Parse(req.GetId())
@@ -223,14 +306,39 @@ func (s *Service) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest)
 		return &userpb.DeleteUserResponse{}, err
 	}
 
-	err = repositories.R().Delete(userID)
+	// Get current user
+	user, found, err := repositories.R().Get(userID)
+	if err != nil {
+		zap.L().Error("Cannot get user", zap.String("uuid", userID.String()), zap.Error(err))
+		return &userpb.DeleteUserResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Warn("User not found", zap.String("uuid", userID.String()))
+		return &userpb.DeleteUserResponse{}, status.Error(codes.NotFound, "User not found")
+	}
+
+	// The deletion is already pending
+	if user.DeletionScheduledAt != nil {
+		return &userpb.DeleteUserResponse{
+			Success:     true,
+			ScheduledAt: timestamppb.New(*user.DeletionScheduledAt),
+		}, nil
+	}
+
+	// Schedule the deletion
+	scheduledAt := time.Now().Add(s.deletion.GracePeriod)
+	err = repositories.R().ScheduleDeletion(userID, scheduledAt)
 	if err != nil {
-		zap.L().Error("DeleteUser.Delete", zap.Error(err))
+		zap.L().Error("DeleteUser.ScheduleDeletion", zap.Error(err))
 		return &userpb.DeleteUserResponse{}, status.Error(codes.Internal, err.Error())
 	}
 
+	// Confirm the deletion by email
+	enqueueDeletionScheduledEmail(user, scheduledAt, user.EmailLanguage(req.GetLanguage()))
+
 	return &userpb.DeleteUserResponse{
-		Success: true,
+		Success:     true,
+		ScheduledAt: timestamppb.New(scheduledAt),
 	}, nil
 }
 
@@ -243,28 +351,210 @@ func (s *Service) ListUsers(ctx context.Context, req *userpb.ListUsersRequest) (
 		return &userpb.ListUsersResponse{}, err
 	}
 
+	// Construct the filter from the request
+	filter, err := mappers.UserFilterFromProto(req)
+	if err != nil {
+		zap.L().Warn("User filter cannot be parsed", zap.Error(err))
+		return &userpb.ListUsersResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if ok, err := filter.IsValid(); !ok {
+		zap.L().Warn("User filter is not valid", zap.Error(err))
+		return &userpb.ListUsersResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	filter = filter.Normalize()
+
 	// List users
-	users, err := repositories.R().List()
+	users, err := repositories.R().List(filter)
 	if err != nil {
 		zap.L().Error("GetUsers.List", zap.Error(err))
 		return &userpb.ListUsersResponse{}, status.Error(codes.Internal, err.Error())
 	}
+	users, next := filter.Page(users)
 
 	return &userpb.ListUsersResponse{
-		Users: mappers.UsersToProto(users),
+		Users:      mappers.UsersToProto(users),
+		NextCursor: next,
 	}, nil
 }
 
 // AuthenticateUser implements the AuthenticateUser RPC method.
+// Every attempt on an existing account is added to its login history, and the user is warned by email
+// when logging in successfully from a new device.
 func (s *Service) AuthenticateUser(ctx context.Context, req *userpb.AuthenticateUserRequest) (*userpb.AuthenticateUserResponse, error) {
 	// Try to authenticate the user
 	user, found, err := repositories.R().Authenticate(req.Email, req.Password)
 	if err != nil || !found {
 		zap.L().Error("AuthenticateUser", zap.Error(err))
-		return &userpb.AuthenticateUserResponse{}, status.Error(codes.InvalidArgument, err.Error())
+
+		// Distinguish unknown accounts from invalid credentials, so that callers only notify existing accounts
+		existing, exists, existsErr := repositories.R().GetByEmail(req.GetEmail())
+		if existsErr != nil {
+			zap.L().Error("Check user exists", zap.Error(existsErr))
+			return &userpb.AuthenticateUserResponse{}, status.Error(codes.Internal, existsErr.Error())
+		}
+		if !exists {
+			return &userpb.AuthenticateUserResponse{}, status.Error(codes.NotFound, "invalid-credentials")
+		}
+		s.recordLogin(req, existing.ID, "invalid-credentials")
+		return &userpb.AuthenticateUserResponse{}, status.Error(codes.InvalidArgument, "invalid-credentials")
+	}
+
+	// Disabled accounts can not log in
+	if user.DisabledAt != nil {
+		zap.L().Warn("User disabled", zap.String("uuid", user.ID.String()))
+		s.recordLogin(req, user.ID, "account-disabled")
+		return &userpb.AuthenticateUserResponse{}, status.Error(codes.PermissionDenied, "account-disabled")
+	}
+
+	// Unverified accounts can not log in
+	if !user.EmailVerified {
+		zap.L().Warn("Email not verified", zap.String("uuid", user.ID.String()))
+		s.recordLogin(req, user.ID, "email-unverified")
+		return &userpb.AuthenticateUserResponse{}, status.Error(codes.FailedPrecondition, "email-unverified")
+	}
+
+	// Accounts forced to reset their password can not log in until they do so
+	if user.PasswordResetRequired {
+		zap.L().Warn("Password reset required", zap.String("uuid", user.ID.String()))
+		s.recordLogin(req, user.ID, "password-reset-required")
+		return &userpb.AuthenticateUserResponse{}, status.Error(codes.FailedPrecondition, "password-reset-required")
+	}
+
+	// Logging in cancels the pending deletion
+	if user.DeletionScheduledAt != nil {
+		err = repositories.R().CancelDeletion(user.ID)
+		if err != nil {
+			zap.L().Error("Cancel user deletion", zap.String("uuid", user.ID.String()), zap.Error(err))
+			return &userpb.AuthenticateUserResponse{}, status.Error(codes.Internal, err.Error())
+		}
+		zap.L().Info("User deletion cancelled", zap.String("uuid", user.ID.String()))
+		user.DeletionScheduledAt = nil
+	}
+
+	// The device is checked against the history before the current login is added to it
+	newDevice := isNewDevice(user, req.GetUserAgent())
+	login := s.recordLogin(req, user.ID, "")
+	if newDevice {
+		enqueueNewLoginEmail(user, login)
 	}
+	user.LastLoginAt = &login.OccurredAt
 
 	return &userpb.AuthenticateUserResponse{
 		User: mappers.UserToProto(user),
 	}, nil
 }
+
+// VerifyEmail implements the VerifyEmail RPC method.
+// The token proves the ownership of the email address : it either verifies the user's current email,
+// or applies a pending email change, in which case the previous address is notified.
+func (s *Service) VerifyEmail(ctx context.Context, req *userpb.VerifyEmailRequest) (*userpb.VerifyEmailResponse, error) {
+	// Parse the user ID from the request
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		// Log the error and return an invalid response
+		zap.L().Error("Invalid user ID", zap.String("user_id", req.GetUserId()), zap.Error(err))
+		return &userpb.VerifyEmailResponse{}, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	// Retrieve the active verification
+	request, found, err := verification.R().Get(userID, req.GetToken())
+	if err != nil {
+		zap.L().Error("Get email verification", zap.Error(err))
+		return &userpb.VerifyEmailResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Warn("Email verification not found", zap.String("uuid", userID.String()))
+		return &userpb.VerifyEmailResponse{}, status.Error(codes.InvalidArgument, "token-invalid")
+	}
+
+	// Get current user
+	user, found, err := repositories.R().Get(userID)
+	if err != nil {
+		zap.L().Error("Cannot get user", zap.String("uuid", userID.String()), zap.Error(err))
+		return &userpb.VerifyEmailResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Warn("User not found", zap.String("uuid", userID.String()))
+		return &userpb.VerifyEmailResponse{}, status.Error(codes.NotFound, "User not found")
+	}
+
+	// The new address may have been taken since the change was requested
+	previousEmail := user.Email
+	if request.Email != previousEmail {
+		exists, err := repositories.R().Exists(request.Email)
+		if err != nil {
+			zap.L().Error("Check user exists", zap.Error(err))
+			return &userpb.VerifyEmailResponse{}, status.Error(codes.Internal, err.Error())
+		}
+		if exists {
+			zap.L().Warn("User already exists", zap.String("email", request.Email))
+			return &userpb.VerifyEmailResponse{}, status.Error(codes.AlreadyExists, "email-used")
+		}
+	}
+
+	// Update user
+	firstVerification := !user.EmailVerified
+	user.Email = request.Email
+	user.EmailVerified = true
+	err = repositories.R().Update(user)
+	if err != nil {
+		zap.L().Error("VerifyEmail.Update", zap.Error(err))
+		return &userpb.VerifyEmailResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Consume the verifications of the user
+	err = verification.R().DeleteForUser(userID)
+	if err != nil {
+		zap.L().Error("Delete email verifications", zap.Error(err))
+	}
+
+	// Welcome the user once the account is ready, or notify the previous address of the change
+	if firstVerification {
+		enqueueWelcomeEmail(user, user.EmailLanguage(req.GetLanguage()))
+	} else if previousEmail != user.Email {
+		enqueueEmailChangedEmail(request.ID, previousEmail, user.Email, user.EmailLanguage(req.GetLanguage()))
+	}
+
+	// Get user back from database
+	user, found, err = repositories.R().Get(userID)
+	if err != nil {
+		zap.L().Error("Cannot get user", zap.String("uuid", userID.String()), zap.Error(err))
+		return &userpb.VerifyEmailResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Error("User not found after update", zap.String("uuid", userID.String()))
+		return &userpb.VerifyEmailResponse{}, status.Error(codes.Internal, "User not found after update")
+	}
+
+	return &userpb.VerifyEmailResponse{
+		User: mappers.UserToProto(user),
+	}, nil
+}
+
+// ResendEmailVerification implements the ResendEmailVerification RPC method.
+// The caller is not authenticated : the same response is returned whether or not a verification is sent,
+// so that it cannot tell which email addresses belong to an account.
+func (s *Service) ResendEmailVerification(ctx context.Context, req *userpb.ResendEmailVerificationRequest) (*userpb.ResendEmailVerificationResponse, error) {
+	// Retrieve user
+	user, found, err := repositories.R().GetByEmail(req.GetEmail())
+	if err != nil {
+		zap.L().Error("Cannot get user", zap.String("email", req.GetEmail()), zap.Error(err))
+		return &userpb.ResendEmailVerificationResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		zap.L().Warn("User not found", zap.String("email", req.GetEmail()))
+		return &userpb.ResendEmailVerificationResponse{Success: true}, nil
+	}
+	if user.EmailVerified {
+		zap.L().Warn("Email already verified", zap.String("uuid", user.ID.String()))
+		return &userpb.ResendEmailVerificationResponse{Success: true}, nil
+	}
+
+	// Send a new verification, the resend limits are not disclosed either
+	_, err = s.startEmailVerification(user.ID, user.Email, user.EmailLanguage(req.GetLanguage()))
+	if err != nil && status.Code(err) != codes.ResourceExhausted {
+		return &userpb.ResendEmailVerificationResponse{}, err
+	}
+
+	return &userpb.ResendEmailVerificationResponse{Success: true}, nil
+}
@@ -6,21 +6,35 @@ import (
 	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
 	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/mappers"
 	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
 	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/internal/verification"
+	"github.com/Zapharaos/fihub-backend/pkg/passwordpolicy"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
 	"github.com/Zapharaos/fihub-backend/test/mocks"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
 
+// mockPasswordPolicy mocks the password policy check with the given result
+func mockPasswordPolicy(ctrl *gomock.Controller, err error) {
+	p := passwordpolicy.NewMockService(ctrl)
+	p.EXPECT().Check(gomock.Any(), gomock.Any()).Return(err)
+	passwordpolicy.ReplaceGlobals(p)
+}
+
 // TestCreateUser tests the CreateUser service
 func TestCreateUser(t *testing.T) {
-	service := &Service{}
+	service := NewService(nil, nil, nil, nil)
 	validRequest := &userpb.CreateUserRequest{
 		Email:        "email@example.com",
 		Password:     "password",
@@ -67,9 +81,24 @@ func TestCreateUser(t *testing.T) {
 			},
 			expectedErrCode: codes.InvalidArgument,
 		},
+		{
+			name: "password does not comply with the policy",
+			mockSetup: func(ctrl *gomock.Controller) {
+				mockPasswordPolicy(ctrl, passwordpolicy.ErrPasswordTooWeak)
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Exists(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request: validRequest,
+			expected: &userpb.CreateUserResponse{
+				User: nil,
+			},
+			expectedErrCode: codes.InvalidArgument,
+		},
 		{
 			name: "Fail to check existence",
 			mockSetup: func(ctrl *gomock.Controller) {
+				mockPasswordPolicy(ctrl, nil)
 				u := mocks.NewUserRepository(ctrl)
 				u.EXPECT().Exists(gomock.Any()).Return(false, errors.New("error"))
 				u.EXPECT().Create(gomock.Any()).Times(0)
@@ -84,6 +113,7 @@ func TestCreateUser(t *testing.T) {
 		{
 			name: "User already exists",
 			mockSetup: func(ctrl *gomock.Controller) {
+				mockPasswordPolicy(ctrl, nil)
 				u := mocks.NewUserRepository(ctrl)
 				u.EXPECT().Exists(gomock.Any()).Return(true, nil)
 				u.EXPECT().Create(gomock.Any()).Times(0)
@@ -98,6 +128,7 @@ func TestCreateUser(t *testing.T) {
 		{
 			name: "Fail at create",
 			mockSetup: func(ctrl *gomock.Controller) {
+				mockPasswordPolicy(ctrl, nil)
 				u := mocks.NewUserRepository(ctrl)
 				u.EXPECT().Exists(gomock.Any()).Return(false, nil)
 				u.EXPECT().Create(gomock.Any()).Return(uuid.Nil, errors.New("error"))
@@ -113,6 +144,7 @@ func TestCreateUser(t *testing.T) {
 		{
 			name: "Fails to retrieve the user",
 			mockSetup: func(ctrl *gomock.Controller) {
+				mockPasswordPolicy(ctrl, nil)
 				u := mocks.NewUserRepository(ctrl)
 				u.EXPECT().Exists(gomock.Any()).Return(false, nil)
 				u.EXPECT().Create(gomock.Any()).Return(uuid.New(), nil)
@@ -128,6 +160,7 @@ func TestCreateUser(t *testing.T) {
 		{
 			name: "Could not find the user",
 			mockSetup: func(ctrl *gomock.Controller) {
+				mockPasswordPolicy(ctrl, nil)
 				u := mocks.NewUserRepository(ctrl)
 				u.EXPECT().Exists(gomock.Any()).Return(false, nil)
 				u.EXPECT().Create(gomock.Any()).Return(uuid.New(), nil)
@@ -141,13 +174,35 @@ func TestCreateUser(t *testing.T) {
 			expectedErrCode: codes.Internal,
 		},
 		{
-			name: "Succeeded",
+			name: "Succeeds even if the verification email can not be sent",
 			mockSetup: func(ctrl *gomock.Controller) {
+				mockPasswordPolicy(ctrl, nil)
 				u := mocks.NewUserRepository(ctrl)
 				u.EXPECT().Exists(gomock.Any()).Return(false, nil)
 				u.EXPECT().Create(gomock.Any()).Return(uuid.New(), nil)
 				u.EXPECT().Get(gomock.Any()).Return(models.User{}, true, nil)
 				repositories.ReplaceGlobals(u)
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().GetLatest(gomock.Any()).Return(models.EmailVerification{}, false, errors.New("error"))
+				v.EXPECT().Create(gomock.Any()).Times(0)
+				verification.ReplaceGlobals(v)
+			},
+			request: validRequest,
+			expected: &userpb.CreateUserResponse{
+				User: &userpb.User{},
+			},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				mockPasswordPolicy(ctrl, nil)
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Exists(gomock.Any()).Return(false, nil)
+				u.EXPECT().Create(gomock.Any()).Return(uuid.New(), nil)
+				u.EXPECT().Get(gomock.Any()).Return(models.User{Email: validRequest.Email}, true, nil)
+				repositories.ReplaceGlobals(u)
+				mockEmailVerificationSent(ctrl, validRequest.Email)
 			},
 			request: validRequest,
 			expected: &userpb.CreateUserResponse{
@@ -332,7 +387,7 @@ func TestGetUser(t *testing.T) {
 
 // TestUpdateUser tests the UpdateUser service
 func TestUpdateUser(t *testing.T) {
-	service := &Service{}
+	service := NewService(nil, nil, nil, nil)
 	validRequest := &userpb.UpdateUserRequest{
 		Id:    uuid.New().String(),
 		Email: "email@example.com",
@@ -408,23 +463,23 @@ func TestUpdateUser(t *testing.T) {
 			expectedErrCode: codes.InvalidArgument,
 		},
 		{
-			name: "fails to update user",
+			name: "Fails to retrieve the user",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
 				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
-				ur := mocks.NewUserRepository(ctrl)
-				ur.EXPECT().Update(gomock.Any()).Return(errors.New("error"))
-				repositories.ReplaceGlobals(ur)
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Get(gomock.Any()).Return(models.User{}, false, errors.New("error"))
+				repositories.ReplaceGlobals(u)
 			},
 			request:         validRequest,
 			expected:        &userpb.UpdateUserResponse{},
 			expectedErrCode: codes.Internal,
 		},
 		{
-			name: "Fails to retrieve the user",
+			name: "Could not find the user",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
@@ -432,16 +487,15 @@ func TestUpdateUser(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				u := mocks.NewUserRepository(ctrl)
-				u.EXPECT().Update(gomock.Any()).Return(nil)
-				u.EXPECT().Get(gomock.Any()).Return(models.User{}, false, errors.New("error"))
+				u.EXPECT().Get(gomock.Any()).Return(models.User{}, false, nil)
 				repositories.ReplaceGlobals(u)
 			},
 			request:         validRequest,
 			expected:        &userpb.UpdateUserResponse{},
-			expectedErrCode: codes.Internal,
+			expectedErrCode: codes.NotFound,
 		},
 		{
-			name: "Could not find the user",
+			name: "Fails to check the new email existence",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
@@ -449,8 +503,8 @@ func TestUpdateUser(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				u := mocks.NewUserRepository(ctrl)
-				u.EXPECT().Update(gomock.Any()).Return(nil)
-				u.EXPECT().Get(gomock.Any()).Return(models.User{}, false, nil)
+				u.EXPECT().Get(gomock.Any()).Return(models.User{Email: "old@example.com"}, true, nil)
+				u.EXPECT().Exists(validRequest.Email).Return(false, errors.New("error"))
 				repositories.ReplaceGlobals(u)
 			},
 			request:         validRequest,
@@ -458,7 +512,7 @@ func TestUpdateUser(t *testing.T) {
 			expectedErrCode: codes.Internal,
 		},
 		{
-			name: "Succeeds",
+			name: "New email is already used",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
@@ -466,9 +520,70 @@ func TestUpdateUser(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				u := mocks.NewUserRepository(ctrl)
-				u.EXPECT().Update(gomock.Any()).Return(nil)
-				u.EXPECT().Get(gomock.Any()).Return(models.User{}, true, nil)
+				u.EXPECT().Get(gomock.Any()).Return(models.User{Email: "old@example.com"}, true, nil)
+				u.EXPECT().Exists(validRequest.Email).Return(true, nil)
+				repositories.ReplaceGlobals(u)
+			},
+			request:         validRequest,
+			expected:        &userpb.UpdateUserResponse{},
+			expectedErrCode: codes.AlreadyExists,
+		},
+		{
+			name: "Email change requested too soon",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Get(gomock.Any()).Return(models.User{Email: "old@example.com"}, true, nil)
+				u.EXPECT().Exists(validRequest.Email).Return(false, nil)
+				repositories.ReplaceGlobals(u)
+				// Mock the verification repository
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().GetLatest(gomock.Any()).Return(models.EmailVerification{CreatedAt: time.Now()}, true, nil)
+				v.EXPECT().Create(gomock.Any()).Times(0)
+				verification.ReplaceGlobals(v)
+			},
+			request:         validRequest,
+			expected:        &userpb.UpdateUserResponse{},
+			expectedErrCode: codes.ResourceExhausted,
+		},
+		{
+			name: "Succeeds without email change",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Get(gomock.Any()).Return(models.User{Email: validRequest.Email}, true, nil)
+				u.EXPECT().Exists(gomock.Any()).Times(0)
+				u.EXPECT().Update(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(u)
+			},
+			request: validRequest,
+			expected: &userpb.UpdateUserResponse{
+				User: &userpb.User{},
+			},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Succeeds and waits for the new email verification",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Get(gomock.Any()).Return(models.User{Email: "old@example.com"}, true, nil)
+				u.EXPECT().Exists(validRequest.Email).Return(false, nil)
+				u.EXPECT().Update(gomock.Any()).Times(0)
 				repositories.ReplaceGlobals(u)
+				mockEmailVerificationSent(ctrl, validRequest.Email)
 			},
 			request: validRequest,
 			expected: &userpb.UpdateUserResponse{
@@ -589,6 +704,58 @@ func TestUpdateUserPassword(t *testing.T) {
 			expected:        &userpb.UpdateUserPasswordResponse{},
 			expectedErrCode: codes.InvalidArgument,
 		},
+		{
+			name: "fails to retrieve the user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{}, false, errors.New("error"))
+				ur.EXPECT().UpdateWithPassword(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.UpdateUserPasswordResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "could not find the user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{}, false, nil)
+				ur.EXPECT().UpdateWithPassword(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.UpdateUserPasswordResponse{},
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "password does not comply with the policy",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{Email: "email@example.com"}, true, nil)
+				ur.EXPECT().UpdateWithPassword(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+				mockPasswordPolicy(ctrl, passwordpolicy.ErrPasswordSimilarEmail)
+			},
+			request:         validRequest,
+			expected:        &userpb.UpdateUserPasswordResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
 		{
 			name: "fails to update user password",
 			mockSetup: func(ctrl *gomock.Controller) {
@@ -598,8 +765,10 @@ func TestUpdateUserPassword(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{Email: "email@example.com"}, true, nil)
 				ur.EXPECT().UpdateWithPassword(gomock.Any()).Return(errors.New("bad-error"))
 				repositories.ReplaceGlobals(ur)
+				mockPasswordPolicy(ctrl, nil)
 			},
 			request:         validRequest,
 			expected:        &userpb.UpdateUserPasswordResponse{},
@@ -614,8 +783,20 @@ func TestUpdateUserPassword(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{Email: "email@example.com"}, true, nil)
 				ur.EXPECT().UpdateWithPassword(gomock.Any()).Return(nil)
 				repositories.ReplaceGlobals(ur)
+				mockPasswordPolicy(ctrl, nil)
+				// Mock the password changed email
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
+				tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("message").AnyTimes()
+				translation.ReplaceGlobals(tr)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Cond(func(outboxEmail models.OutboxEmail) bool {
+					return slices.Equal(outboxEmail.To, []string{"email@example.com"}) && strings.HasPrefix(outboxEmail.IdempotencyKey, "password-changed:")
+				})).Return(models.OutboxEmail{}, true, nil)
+				outbox.ReplaceGlobals(or)
 			},
 			request: validRequest,
 			expected: &userpb.UpdateUserPasswordResponse{
@@ -659,10 +840,15 @@ func TestUpdateUserPassword(t *testing.T) {
 
 // TestDeleteUser tests the DeleteUser service
 func TestDeleteUser(t *testing.T) {
-	service := &Service{}
+	service := &Service{
+		deletion: AccountDeletionPolicy{
+			GracePeriod: 24 * time.Hour,
+		},
+	}
 	validRequest := &userpb.DeleteUserRequest{
 		Id: uuid.New().String(),
 	}
+	scheduledAt := time.Now().Add(time.Hour)
 
 	// Define tests
 	tests := []struct {
@@ -707,13 +893,68 @@ func TestDeleteUser(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				ur := mocks.NewUserRepository(ctrl)
-				ur.EXPECT().Delete(gomock.Any()).Times(0)
+				ur.EXPECT().ScheduleDeletion(gomock.Any(), gomock.Any()).Times(0)
 				repositories.ReplaceGlobals(ur)
 			},
 			request:         validRequest,
 			expected:        &userpb.DeleteUserResponse{},
 			expectedErrCode: codes.PermissionDenied,
 		},
+		{
+			name: "fails to get user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{}, false, errors.New("some error"))
+				ur.EXPECT().ScheduleDeletion(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.DeleteUserResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "user not found",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{}, false, nil)
+				ur.EXPECT().ScheduleDeletion(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         validRequest,
+			expected:        &userpb.DeleteUserResponse{},
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "deletion already scheduled",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{Email: "email@example.com", DeletionScheduledAt: &scheduledAt}, true, nil)
+				ur.EXPECT().ScheduleDeletion(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+				// Mock the email service
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Any()).Times(0)
+				outbox.ReplaceGlobals(or)
+			},
+			request:         validRequest,
+			expected:        &userpb.DeleteUserResponse{},
+			expectedErrCode: codes.OK,
+		},
 		{
 			name: "fails to delete user",
 			mockSetup: func(ctrl *gomock.Controller) {
@@ -723,7 +964,8 @@ func TestDeleteUser(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				ur := mocks.NewUserRepository(ctrl)
-				ur.EXPECT().Delete(gomock.Any()).Return(errors.New("some error"))
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{Email: "email@example.com"}, true, nil)
+				ur.EXPECT().ScheduleDeletion(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
 				repositories.ReplaceGlobals(ur)
 			},
 			request:         validRequest,
@@ -739,8 +981,20 @@ func TestDeleteUser(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				ur := mocks.NewUserRepository(ctrl)
-				ur.EXPECT().Delete(gomock.Any()).Return(nil)
+				ur.EXPECT().Get(gomock.Any()).Return(models.User{Email: "email@example.com"}, true, nil)
+				ur.EXPECT().ScheduleDeletion(gomock.Any(), gomock.Any()).Return(nil)
+				ur.EXPECT().Delete(gomock.Any()).Times(0)
 				repositories.ReplaceGlobals(ur)
+				// Mock the confirmation email
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
+				tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("message").AnyTimes()
+				translation.ReplaceGlobals(tr)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Cond(func(outboxEmail models.OutboxEmail) bool {
+					return slices.Equal(outboxEmail.To, []string{"email@example.com"})
+				})).Return(models.OutboxEmail{}, true, nil)
+				outbox.ReplaceGlobals(or)
 			},
 			request:         validRequest,
 			expected:        &userpb.DeleteUserResponse{},
@@ -772,7 +1026,8 @@ func TestDeleteUser(t *testing.T) {
 
 			// Handle response
 			if tt.expectedErrCode == codes.OK {
-				assert.NotNil(t, response)
+				assert.True(t, response.GetSuccess())
+				assert.NotNil(t, response.GetScheduledAt())
 			} else {
 				assert.Equal(t, tt.expected, response)
 			}
@@ -783,7 +1038,12 @@ func TestDeleteUser(t *testing.T) {
 // TestListUsers tests the ListUsers service
 func TestListUsers(t *testing.T) {
 	service := &Service{}
-	validRequest := &userpb.ListUsersRequest{}
+	userID := uuid.New()
+	validRequest := &userpb.ListUsersRequest{Email: "jane", Sort: models.UserSortEmailAsc, Limit: 1, UserIds: []string{userID.String()}, Status: models.UserStatusActive}
+	users := models.Users{
+		{ID: uuid.New(), Email: "jane@example.com"},
+		{ID: uuid.New(), Email: "janet@example.com"},
+	}
 
 	// Define tests
 	tests := []struct {
@@ -803,7 +1063,7 @@ func TestListUsers(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				ur := mocks.NewUserRepository(ctrl)
-				ur.EXPECT().List().Times(0)
+				ur.EXPECT().List(gomock.Any()).Times(0)
 				repositories.ReplaceGlobals(ur)
 			},
 			permissionValue: false,
@@ -812,7 +1072,39 @@ func TestListUsers(t *testing.T) {
 			expectedErrCode: codes.PermissionDenied,
 		},
 		{
-			name: "fails to delete user",
+			name: "fails with an unparsable filter",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().List(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         &userpb.ListUsersRequest{After: "invalid"},
+			expected:        &userpb.ListUsersResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails with an invalid filter",
+			mockSetup: func(ctrl *gomock.Controller) {
+				// Mock the public security facade
+				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
+				publicSecurityClient.EXPECT().CheckPermission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil)
+				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+				// Mock the user repository
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().List(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request:         &userpb.ListUsersRequest{Sort: "password"},
+			expected:        &userpb.ListUsersResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "fails to list users",
 			mockSetup: func(ctrl *gomock.Controller) {
 				// Mock the public security facade
 				publicSecurityClient := mocks.NewMockPublicSecurityServiceClient(ctrl)
@@ -820,7 +1112,7 @@ func TestListUsers(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				ur := mocks.NewUserRepository(ctrl)
-				ur.EXPECT().List().Return(nil, errors.New("some error"))
+				ur.EXPECT().List(gomock.Any()).Return(nil, errors.New("some error"))
 				repositories.ReplaceGlobals(ur)
 			},
 			request:         validRequest,
@@ -836,12 +1128,19 @@ func TestListUsers(t *testing.T) {
 				security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
 				// Mock the user repository
 				ur := mocks.NewUserRepository(ctrl)
-				ur.EXPECT().List().Return(models.Users{}, nil)
+				ur.EXPECT().List(models.UserFilter{
+					Email:   "jane",
+					UserIDs: []uuid.UUID{userID},
+					Status:  models.UserStatusActive,
+					Sort:    models.UserSortEmailAsc,
+					Limit:   1,
+				}).Return(users, nil)
 				repositories.ReplaceGlobals(ur)
 			},
 			request: validRequest,
 			expected: &userpb.ListUsersResponse{
-				Users: []*userpb.User{},
+				Users:      mappers.UsersToProto(users[:1]),
+				NextCursor: models.NewUserCursor(models.UserSortEmailAsc, users[0]).Encode(),
 			},
 			expectedErrCode: codes.OK,
 		},
@@ -879,12 +1178,23 @@ func TestListUsers(t *testing.T) {
 	}
 }
 
+// failedLogin matches the failed login attempts recorded with the given reason
+func failedLogin(reason string) gomock.Matcher {
+	return gomock.Cond(func(login models.UserLogin) bool {
+		return !login.Success && login.Reason == reason
+	})
+}
+
 // TestAuthenticateUser tests the AuthenticateUser service
 func TestAuthenticateUser(t *testing.T) {
-	service := &Service{}
+	service := NewService(nil, nil, nil, nil)
+	scheduledAt := time.Now().Add(time.Hour)
+	lastLoginAt := time.Now().Add(-time.Hour)
 	validRequest := &userpb.AuthenticateUserRequest{
-		Email:    "email",
-		Password: "password",
+		Email:     "email",
+		Password:  "password",
+		IpAddress: "127.0.0.1",
+		UserAgent: "Mozilla/5.0",
 	}
 
 	// Define tests
@@ -895,11 +1205,42 @@ func TestAuthenticateUser(t *testing.T) {
 		expected        *userpb.AuthenticateUserResponse
 		expectedErrCode codes.Code
 	}{
+		{
+			name: "Fails to check user existence",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{}, false, errors.New("error"))
+				ur.EXPECT().GetByEmail(gomock.Any()).Return(models.User{}, false, errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request: validRequest,
+			expected: &userpb.AuthenticateUserResponse{
+				User: nil,
+			},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Fails to authenticate unknown user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{}, false, errors.New("error"))
+				ur.EXPECT().GetByEmail(gomock.Any()).Return(models.User{}, false, nil)
+				ur.EXPECT().RecordLogin(gomock.Any(), gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(ur)
+			},
+			request: validRequest,
+			expected: &userpb.AuthenticateUserResponse{
+				User: nil,
+			},
+			expectedErrCode: codes.NotFound,
+		},
 		{
 			name: "Fails to authenticate",
 			mockSetup: func(ctrl *gomock.Controller) {
 				ur := mocks.NewUserRepository(ctrl)
 				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{}, false, errors.New("error"))
+				ur.EXPECT().GetByEmail(gomock.Any()).Return(models.User{ID: uuid.New()}, true, nil)
+				ur.EXPECT().RecordLogin(failedLogin("invalid-credentials"), gomock.Any()).Return(nil)
 				repositories.ReplaceGlobals(ur)
 			},
 			request: validRequest,
@@ -909,36 +1250,209 @@ func TestAuthenticateUser(t *testing.T) {
 			expectedErrCode: codes.InvalidArgument,
 		},
 		{
-			name: "Succeeded",
+			name: "Account disabled",
 			mockSetup: func(ctrl *gomock.Controller) {
 				ur := mocks.NewUserRepository(ctrl)
 				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{
-					ID:        uuid.New(),
-					Email:     "email",
-					CreatedAt: time.Now(),
-					UpdatedAt: time.Now(),
+					ID:            uuid.New(),
+					Email:         "email",
+					EmailVerified: true,
+					DisabledAt:    &scheduledAt,
 				}, true, nil)
+				ur.EXPECT().RecordLogin(failedLogin("account-disabled"), gomock.Any()).Return(nil)
 				repositories.ReplaceGlobals(ur)
 			},
 			request: validRequest,
 			expected: &userpb.AuthenticateUserResponse{
-				User: &userpb.User{},
+				User: nil,
 			},
-			expectedErrCode: codes.OK,
+			expectedErrCode: codes.PermissionDenied,
 		},
-	}
-
-	// Run tests
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Apply mocks
-			ctrl := gomock.NewController(t)
-			tt.mockSetup(ctrl)
-			defer ctrl.Finish()
-
-			// Call service
-			response, err := service.AuthenticateUser(context.Background(), tt.request)
-
+		{
+			name: "Email not verified",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{
+					ID:            uuid.New(),
+					Email:         "email",
+					EmailVerified: false,
+				}, true, nil)
+				ur.EXPECT().RecordLogin(failedLogin("email-unverified"), gomock.Any()).Return(nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request: validRequest,
+			expected: &userpb.AuthenticateUserResponse{
+				User: nil,
+			},
+			expectedErrCode: codes.FailedPrecondition,
+		},
+		{
+			name: "Password reset required",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{
+					ID:                    uuid.New(),
+					Email:                 "email",
+					EmailVerified:         true,
+					PasswordResetRequired: true,
+				}, true, nil)
+				ur.EXPECT().RecordLogin(failedLogin("password-reset-required"), gomock.Any()).Return(nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request: validRequest,
+			expected: &userpb.AuthenticateUserResponse{
+				User: nil,
+			},
+			expectedErrCode: codes.FailedPrecondition,
+		},
+		{
+			name: "Fails to cancel the pending deletion",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{
+					ID:                  uuid.New(),
+					Email:               "email",
+					EmailVerified:       true,
+					DeletionScheduledAt: &scheduledAt,
+				}, true, nil)
+				ur.EXPECT().CancelDeletion(gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request: validRequest,
+			expected: &userpb.AuthenticateUserResponse{
+				User: nil,
+			},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Succeeded and cancels the pending deletion",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{
+					ID:                  uuid.New(),
+					Email:               "email",
+					EmailVerified:       true,
+					DeletionScheduledAt: &scheduledAt,
+				}, true, nil)
+				ur.EXPECT().CancelDeletion(gomock.Any()).Return(nil)
+				ur.EXPECT().RecordLogin(gomock.Any(), gomock.Any()).Return(nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request: validRequest,
+			expected: &userpb.AuthenticateUserResponse{
+				User: &userpb.User{},
+			},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Succeeded",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{
+					ID:            uuid.New(),
+					Email:         "email",
+					EmailVerified: true,
+					CreatedAt:     time.Now(),
+					UpdatedAt:     time.Now(),
+				}, true, nil)
+				ur.EXPECT().HasLoggedInFrom(gomock.Any(), gomock.Any()).Times(0)
+				ur.EXPECT().RecordLogin(gomock.Cond(func(login models.UserLogin) bool {
+					return login.Success && login.IPAddress == "127.0.0.1" && login.UserAgent == "Mozilla/5.0" &&
+						login.Method == models.LoginMethodPassword
+				}), 50).Return(nil)
+				repositories.ReplaceGlobals(ur)
+			},
+			request: validRequest,
+			expected: &userpb.AuthenticateUserResponse{
+				User: &userpb.User{},
+			},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Succeeded despite failing to record the login",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{
+					ID:            uuid.New(),
+					Email:         "email",
+					EmailVerified: true,
+				}, true, nil)
+				ur.EXPECT().RecordLogin(gomock.Any(), gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(ur)
+			},
+			request: validRequest,
+			expected: &userpb.AuthenticateUserResponse{
+				User: &userpb.User{},
+			},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Succeeded from a known device",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{
+					ID:            uuid.New(),
+					Email:         "email",
+					EmailVerified: true,
+					LastLoginAt:   &lastLoginAt,
+				}, true, nil)
+				ur.EXPECT().HasLoggedInFrom(gomock.Any(), "Mozilla/5.0").Return(true, nil)
+				ur.EXPECT().RecordLogin(gomock.Any(), gomock.Any()).Return(nil)
+				repositories.ReplaceGlobals(ur)
+				// No email is sent
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Any()).Times(0)
+				outbox.ReplaceGlobals(or)
+			},
+			request: validRequest,
+			expected: &userpb.AuthenticateUserResponse{
+				User: &userpb.User{},
+			},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Succeeded from a new device",
+			mockSetup: func(ctrl *gomock.Controller) {
+				ur := mocks.NewUserRepository(ctrl)
+				ur.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(models.User{
+					ID:            uuid.New(),
+					Email:         "email",
+					EmailVerified: true,
+					LastLoginAt:   &lastLoginAt,
+				}, true, nil)
+				ur.EXPECT().HasLoggedInFrom(gomock.Any(), "Mozilla/5.0").Return(false, nil)
+				ur.EXPECT().RecordLogin(gomock.Any(), gomock.Any()).Return(nil)
+				repositories.ReplaceGlobals(ur)
+				// Mock the new login email
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
+				tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("message").AnyTimes()
+				translation.ReplaceGlobals(tr)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Cond(func(outboxEmail models.OutboxEmail) bool {
+					return slices.Equal(outboxEmail.To, []string{"email"}) && strings.HasPrefix(outboxEmail.IdempotencyKey, "new-login:")
+				})).Return(models.OutboxEmail{}, true, nil)
+				outbox.ReplaceGlobals(or)
+			},
+			request: validRequest,
+			expected: &userpb.AuthenticateUserResponse{
+				User: &userpb.User{},
+			},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.AuthenticateUser(context.Background(), tt.request)
+
 			// Handle errors
 			if err != nil && tt.expectedErrCode == codes.OK {
 				assert.Fail(t, "unexpected error", err)
@@ -953,9 +1467,312 @@ func TestAuthenticateUser(t *testing.T) {
 			// Handle response
 			if tt.expectedErrCode == codes.OK {
 				assert.NotNil(t, response)
+				assert.Nil(t, response.GetUser().GetDeletionScheduledAt())
+				assert.NotNil(t, response.GetUser().GetLastLoginAt())
 			} else {
 				assert.Equal(t, tt.expected, response)
 			}
 		})
 	}
 }
+
+// TestVerifyEmail tests the VerifyEmail service
+func TestVerifyEmail(t *testing.T) {
+	service := NewService(nil, nil, nil, nil)
+	userID := uuid.New()
+	validRequest := &userpb.VerifyEmailRequest{
+		UserId: userID.String(),
+		Token:  "123456",
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *userpb.VerifyEmailRequest
+		expected        *userpb.VerifyEmailResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "fails to parse ID from request",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().Get(gomock.Any(), gomock.Any()).Times(0)
+				verification.ReplaceGlobals(v)
+			},
+			request: &userpb.VerifyEmailRequest{
+				UserId: "bad-uuid",
+			},
+			expected:        &userpb.VerifyEmailResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "Fails to retrieve the verification",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().Get(userID, validRequest.Token).Return(models.EmailVerification{}, false, errors.New("error"))
+				verification.ReplaceGlobals(v)
+			},
+			request:         validRequest,
+			expected:        &userpb.VerifyEmailResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Invalid token",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().Get(userID, validRequest.Token).Return(models.EmailVerification{}, false, nil)
+				verification.ReplaceGlobals(v)
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Get(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(u)
+			},
+			request:         validRequest,
+			expected:        &userpb.VerifyEmailResponse{},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "Could not find the user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().Get(userID, validRequest.Token).Return(models.EmailVerification{Email: "email@example.com"}, true, nil)
+				verification.ReplaceGlobals(v)
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Get(userID).Return(models.User{}, false, nil)
+				repositories.ReplaceGlobals(u)
+			},
+			request:         validRequest,
+			expected:        &userpb.VerifyEmailResponse{},
+			expectedErrCode: codes.NotFound,
+		},
+		{
+			name: "New email has been taken meanwhile",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().Get(userID, validRequest.Token).Return(models.EmailVerification{Email: "new@example.com"}, true, nil)
+				verification.ReplaceGlobals(v)
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Get(userID).Return(models.User{ID: userID, Email: "old@example.com"}, true, nil)
+				u.EXPECT().Exists("new@example.com").Return(true, nil)
+				u.EXPECT().Update(gomock.Any()).Times(0)
+				repositories.ReplaceGlobals(u)
+			},
+			request:         validRequest,
+			expected:        &userpb.VerifyEmailResponse{},
+			expectedErrCode: codes.AlreadyExists,
+		},
+		{
+			name: "Fails to update the user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().Get(userID, validRequest.Token).Return(models.EmailVerification{Email: "email@example.com"}, true, nil)
+				v.EXPECT().DeleteForUser(gomock.Any()).Times(0)
+				verification.ReplaceGlobals(v)
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Get(userID).Return(models.User{ID: userID, Email: "email@example.com"}, true, nil)
+				u.EXPECT().Update(gomock.Any()).Return(errors.New("error"))
+				repositories.ReplaceGlobals(u)
+			},
+			request:         validRequest,
+			expected:        &userpb.VerifyEmailResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Verifies the current email and welcomes the user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().Get(userID, validRequest.Token).Return(models.EmailVerification{Email: "email@example.com"}, true, nil)
+				v.EXPECT().DeleteForUser(userID).Return(nil)
+				verification.ReplaceGlobals(v)
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Get(userID).Return(models.User{ID: userID, Email: "email@example.com"}, true, nil).Times(2)
+				u.EXPECT().Exists(gomock.Any()).Times(0)
+				u.EXPECT().Update(models.User{ID: userID, Email: "email@example.com", EmailVerified: true}).Return(nil)
+				repositories.ReplaceGlobals(u)
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
+				tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("message").AnyTimes()
+				translation.ReplaceGlobals(tr)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Cond(func(outboxEmail models.OutboxEmail) bool {
+					return slices.Equal(outboxEmail.To, []string{"email@example.com"}) && outboxEmail.IdempotencyKey == "welcome:"+userID.String()
+				})).Return(models.OutboxEmail{}, true, nil)
+				outbox.ReplaceGlobals(or)
+			},
+			request: validRequest,
+			expected: &userpb.VerifyEmailResponse{
+				User: &userpb.User{},
+			},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Applies the email change and notifies the previous address",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().Get(userID, validRequest.Token).Return(models.EmailVerification{Email: "new@example.com"}, true, nil)
+				v.EXPECT().DeleteForUser(userID).Return(nil)
+				verification.ReplaceGlobals(v)
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().Get(userID).Return(models.User{ID: userID, Email: "old@example.com", EmailVerified: true}, true, nil)
+				u.EXPECT().Exists("new@example.com").Return(false, nil)
+				u.EXPECT().Update(models.User{ID: userID, Email: "new@example.com", EmailVerified: true}).Return(nil)
+				u.EXPECT().Get(userID).Return(models.User{ID: userID, Email: "new@example.com", EmailVerified: true}, true, nil)
+				repositories.ReplaceGlobals(u)
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
+				tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("message").AnyTimes()
+				translation.ReplaceGlobals(tr)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Cond(func(outboxEmail models.OutboxEmail) bool {
+					return slices.Equal(outboxEmail.To, []string{"old@example.com"})
+				})).Return(models.OutboxEmail{}, true, nil)
+				outbox.ReplaceGlobals(or)
+			},
+			request: validRequest,
+			expected: &userpb.VerifyEmailResponse{
+				User: &userpb.User{},
+			},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.VerifyEmail(context.Background(), tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			if tt.expectedErrCode == codes.OK {
+				assert.NotNil(t, response)
+			} else {
+				assert.Equal(t, tt.expected, response)
+			}
+		})
+	}
+}
+
+// TestResendEmailVerification tests the ResendEmailVerification service
+func TestResendEmailVerification(t *testing.T) {
+	service := NewService(nil, nil, nil, nil)
+	validRequest := &userpb.ResendEmailVerificationRequest{
+		Email: "email@example.com",
+	}
+
+	// Define tests
+	tests := []struct {
+		name            string
+		mockSetup       func(ctrl *gomock.Controller)
+		request         *userpb.ResendEmailVerificationRequest
+		expected        *userpb.ResendEmailVerificationResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "Fails to retrieve the user",
+			mockSetup: func(ctrl *gomock.Controller) {
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().GetByEmail(validRequest.Email).Return(models.User{}, false, errors.New("error"))
+				repositories.ReplaceGlobals(u)
+			},
+			request:         validRequest,
+			expected:        &userpb.ResendEmailVerificationResponse{},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Succeeds silently with an unknown email",
+			mockSetup: func(ctrl *gomock.Controller) {
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().GetByEmail(validRequest.Email).Return(models.User{}, false, nil)
+				repositories.ReplaceGlobals(u)
+			},
+			request:         validRequest,
+			expected:        &userpb.ResendEmailVerificationResponse{Success: true},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Succeeds silently with an already verified email",
+			mockSetup: func(ctrl *gomock.Controller) {
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().GetByEmail(validRequest.Email).Return(models.User{EmailVerified: true}, true, nil)
+				repositories.ReplaceGlobals(u)
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().Create(gomock.Any()).Times(0)
+				verification.ReplaceGlobals(v)
+			},
+			request:         validRequest,
+			expected:        &userpb.ResendEmailVerificationResponse{Success: true},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Succeeds silently once the resend limit is reached",
+			mockSetup: func(ctrl *gomock.Controller) {
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().GetByEmail(validRequest.Email).Return(models.User{ID: uuid.New(), Email: validRequest.Email}, true, nil)
+				repositories.ReplaceGlobals(u)
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().GetLatest(gomock.Any()).Return(models.EmailVerification{CreatedAt: time.Now().Add(-time.Hour)}, true, nil)
+				v.EXPECT().CountSince(gomock.Any(), gomock.Any()).Return(5, nil)
+				v.EXPECT().Create(gomock.Any()).Times(0)
+				verification.ReplaceGlobals(v)
+			},
+			request:         validRequest,
+			expected:        &userpb.ResendEmailVerificationResponse{Success: true},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				u := mocks.NewUserRepository(ctrl)
+				u.EXPECT().GetByEmail(validRequest.Email).Return(models.User{ID: uuid.New(), Email: validRequest.Email}, true, nil)
+				repositories.ReplaceGlobals(u)
+				mockEmailVerificationSent(ctrl, validRequest.Email)
+			},
+			request:         validRequest,
+			expected:        &userpb.ResendEmailVerificationResponse{Success: true},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			// Call service
+			response, err := service.ResendEmailVerification(context.Background(), tt.request)
+
+			// Handle errors
+			if err != nil && tt.expectedErrCode == codes.OK {
+				assert.Fail(t, "unexpected error", err)
+			} else if err != nil {
+				if s, ok := status.FromError(err); ok {
+					assert.Equal(t, tt.expectedErrCode, s.Code())
+				} else {
+					assert.Fail(t, "failed to get status from error")
+				}
+			}
+
+			// Handle response
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"fmt"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/internal/verification"
+	"github.com/Zapharaos/fihub-backend/pkg/email"
+	"github.com/Zapharaos/fihub-backend/pkg/email/templates"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"time"
+)
+
+// EmailVerificationPolicy defines how often verification emails can be sent to a user.
+// A new email can only be sent once ResendCooldown has elapsed since the previous one,
+// and at most ResendLimit emails can be sent within ResendWindow.
+type EmailVerificationPolicy struct {
+	ResendCooldown time.Duration
+	ResendLimit    int
+	ResendWindow   time.Duration
+}
+
+// NewEmailVerificationPolicyFromConfig creates a new EmailVerificationPolicy from the configuration
+func NewEmailVerificationPolicyFromConfig() EmailVerificationPolicy {
+	policy := EmailVerificationPolicy{
+		ResendCooldown: viper.GetDuration("EMAIL_VERIFICATION_RESEND_COOLDOWN"),
+		ResendLimit:    viper.GetInt("EMAIL_VERIFICATION_RESEND_LIMIT"),
+		ResendWindow:   viper.GetDuration("EMAIL_VERIFICATION_RESEND_WINDOW"),
+	}
+
+	if policy.ResendCooldown == 0 {
+		policy.ResendCooldown = 1 * time.Minute
+	}
+	if policy.ResendLimit == 0 {
+		policy.ResendLimit = 5
+	}
+	if policy.ResendWindow == 0 {
+		policy.ResendWindow = 24 * time.Hour
+	}
+
+	return policy
+}
+
+// startEmailVerification creates a new verification for the email address and sends its token to that address.
+// Any previous verification of the user is invalidated, so that only the latest token can be used.
+func (s *Service) startEmailVerification(userID uuid.UUID, emailAddress string, lang language.Tag) (models.EmailVerification, error) {
+	// Enforce the resend limits
+	if err := s.checkResendLimits(userID); err != nil {
+		return models.EmailVerification{}, err
+	}
+
+	// Invalidate previous verifications
+	err := verification.R().Invalidate(userID)
+	if err != nil {
+		zap.L().Error("Invalidate email verifications", zap.Error(err))
+		return models.EmailVerification{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Create and store verification
+	request, duration := models.InitEmailVerification(userID, emailAddress)
+	result, err := verification.R().Create(request)
+	if err != nil {
+		zap.L().Error("Create email verification", zap.Error(err))
+		return models.EmailVerification{}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Enqueue email
+	err = enqueueVerificationEmail(result, duration, lang)
+	if err != nil {
+		// Delete the verification since the email will not be sent
+		_ = verification.R().Delete(result.ID)
+
+		zap.L().Error("Failed to enqueue verification email", zap.Error(err))
+		return models.EmailVerification{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return result, nil
+}
+
+// checkResendLimits checks whether a new verification email can be sent to the user
+func (s *Service) checkResendLimits(userID uuid.UUID) error {
+	// Cooldown since the latest email
+	latest, found, err := verification.R().GetLatest(userID)
+	if err != nil {
+		zap.L().Error("Get latest email verification", zap.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	if found {
+		if wait := s.verification.ResendCooldown - time.Since(latest.CreatedAt); wait > 0 {
+			zap.L().Warn("Email verification resent too soon", zap.String("user_id", userID.String()))
+			return retryError("verification-cooldown", wait)
+		}
+	}
+
+	// Number of emails within the window
+	count, err := verification.R().CountSince(userID, time.Now().Add(-s.verification.ResendWindow))
+	if err != nil {
+		zap.L().Error("Count email verifications", zap.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	if count >= s.verification.ResendLimit {
+		zap.L().Warn("Email verification resend limit reached", zap.String("user_id", userID.String()))
+		return retryError("verification-limit", s.verification.ResendWindow)
+	}
+
+	return nil
+}
+
+// retryError returns a ResourceExhausted error, along with the delay before retrying
+func retryError(message string, retryAfter time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, message).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, message)
+	}
+	return st.Err()
+}
+
+// enqueueEmail renders the email of the catalog in the language and enqueues it in the outbox,
+// the key preventing the same email from being enqueued twice.
+// The outbox delivers the email in the background, retrying it when the transport fails.
+func enqueueEmail[T any](key, to string, lang language.Tag, definition *templates.Definition[T], data T) error {
+	mail, err := definition.Localize(lang, data)
+	if err != nil {
+		return err
+	}
+
+	message := email.NewMessage(to, mail.Subject, mail.PlainTextContent, mail.HTMLContent)
+	_, _, err = outbox.R().Enqueue(models.InitOutboxEmail(key, message))
+	return err
+}
+
+// enqueueWelcomeEmail welcomes the user whose account is ready, once the email address is verified for the first time
+func enqueueWelcomeEmail(user models.User, lang language.Tag) {
+	err := enqueueEmail("welcome:"+user.ID.String(), user.Email, lang, templates.Welcome, templates.WelcomeData{
+		Link: viper.GetString("APP_PUBLIC_URL"),
+	})
+	if err != nil {
+		zap.L().Error("Failed to enqueue welcome email", zap.Error(err))
+	}
+}
+
+// enqueueVerificationEmail sends the verification token to the email address being verified
+func enqueueVerificationEmail(request models.EmailVerification, duration time.Duration, lang language.Tag) error {
+	return enqueueEmail("email-verification:"+request.ID.String(), request.Email, lang, templates.EmailVerification, templates.EmailVerificationData{
+		Otp:      request.Token,
+		Duration: duration,
+	})
+}
+
+// enqueueEmailChangedEmail notifies the previous email address that the account email has been changed
+// by the given verification
+func enqueueEmailChangedEmail(verificationID uuid.UUID, previousEmail, newEmail string, lang language.Tag) {
+	err := enqueueEmail("email-changed:"+verificationID.String(), previousEmail, lang, templates.EmailChanged, templates.EmailChangedData{
+		Email: newEmail,
+	})
+	if err != nil {
+		zap.L().Error("Failed to enqueue email changed email", zap.Error(err))
+	}
+}
+
+// enqueuePasswordChangedEmail notifies the user that the password of the account has been changed
+func enqueuePasswordChangedEmail(user models.User, changedAt time.Time) {
+	key := fmt.Sprintf("password-changed:%s:%d", user.ID, changedAt.UnixMilli())
+	err := enqueueEmail(key, user.Email, user.EmailLanguage(""), templates.PasswordChanged, templates.PasswordChangedData{
+		Date: changedAt,
+	})
+	if err != nil {
+		zap.L().Error("Failed to enqueue password changed email", zap.Error(err))
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/internal/verification"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"testing"
+	"time"
+)
+
+// mockEmailVerificationSent mocks a verification successfully created and sent to the email address
+func mockEmailVerificationSent(ctrl *gomock.Controller, emailAddress string) {
+	v := mocks.NewVerificationRepository(ctrl)
+	v.EXPECT().GetLatest(gomock.Any()).Return(models.EmailVerification{}, false, nil)
+	v.EXPECT().CountSince(gomock.Any(), gomock.Any()).Return(0, nil)
+	v.EXPECT().Invalidate(gomock.Any()).Return(nil)
+	v.EXPECT().Create(gomock.Any()).DoAndReturn(func(request models.EmailVerification) (models.EmailVerification, error) {
+		return request, nil
+	})
+	verification.ReplaceGlobals(v)
+	tr := translation.NewMockService(ctrl)
+	tr.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
+	tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("message").AnyTimes()
+	translation.ReplaceGlobals(tr)
+	or := mocks.NewOutboxRepository(ctrl)
+	or.EXPECT().Enqueue(gomock.Cond(func(outboxEmail models.OutboxEmail) bool {
+		return slices.Equal(outboxEmail.To, []string{emailAddress})
+	})).Return(models.OutboxEmail{}, true, nil)
+	outbox.ReplaceGlobals(or)
+}
+
+// TestNewEmailVerificationPolicyFromConfig tests the NewEmailVerificationPolicyFromConfig function
+func TestNewEmailVerificationPolicyFromConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		viper.Reset()
+		policy := NewEmailVerificationPolicyFromConfig()
+		assert.Equal(t, EmailVerificationPolicy{
+			ResendCooldown: time.Minute,
+			ResendLimit:    5,
+			ResendWindow:   24 * time.Hour,
+		}, policy)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		viper.Set("EMAIL_VERIFICATION_RESEND_COOLDOWN", "2m")
+		viper.Set("EMAIL_VERIFICATION_RESEND_LIMIT", 3)
+		viper.Set("EMAIL_VERIFICATION_RESEND_WINDOW", "12h")
+		defer viper.Reset()
+
+		policy := NewEmailVerificationPolicyFromConfig()
+		assert.Equal(t, EmailVerificationPolicy{
+			ResendCooldown: 2 * time.Minute,
+			ResendLimit:    3,
+			ResendWindow:   12 * time.Hour,
+		}, policy)
+	})
+}
+
+// TestService_startEmailVerification tests the startEmailVerification method
+func TestService_startEmailVerification(t *testing.T) {
+	service := &Service{
+		verification: EmailVerificationPolicy{
+			ResendCooldown: time.Minute,
+			ResendLimit:    3,
+			ResendWindow:   time.Hour,
+		},
+	}
+	userID := uuid.New()
+
+	tests := []struct {
+		name               string
+		mockSetup          func(ctrl *gomock.Controller)
+		expectedErrCode    codes.Code
+		expectedRetryDelay time.Duration
+	}{
+		{
+			name: "Fails to retrieve the latest verification",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().GetLatest(userID).Return(models.EmailVerification{}, false, errors.New("error"))
+				verification.ReplaceGlobals(v)
+			},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Within the cooldown",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().GetLatest(userID).Return(models.EmailVerification{CreatedAt: time.Now().Add(-30 * time.Second)}, true, nil)
+				v.EXPECT().CountSince(gomock.Any(), gomock.Any()).Times(0)
+				verification.ReplaceGlobals(v)
+			},
+			expectedErrCode:    codes.ResourceExhausted,
+			expectedRetryDelay: 30 * time.Second,
+		},
+		{
+			name: "Limit reached",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().GetLatest(userID).Return(models.EmailVerification{CreatedAt: time.Now().Add(-10 * time.Minute)}, true, nil)
+				v.EXPECT().CountSince(userID, gomock.Any()).Return(3, nil)
+				v.EXPECT().Invalidate(gomock.Any()).Times(0)
+				verification.ReplaceGlobals(v)
+			},
+			expectedErrCode:    codes.ResourceExhausted,
+			expectedRetryDelay: time.Hour,
+		},
+		{
+			name: "Fails to send the email",
+			mockSetup: func(ctrl *gomock.Controller) {
+				v := mocks.NewVerificationRepository(ctrl)
+				v.EXPECT().GetLatest(userID).Return(models.EmailVerification{}, false, nil)
+				v.EXPECT().CountSince(userID, gomock.Any()).Return(0, nil)
+				v.EXPECT().Invalidate(userID).Return(nil)
+				v.EXPECT().Create(gomock.Any()).DoAndReturn(func(request models.EmailVerification) (models.EmailVerification, error) {
+					return request, nil
+				})
+				v.EXPECT().Delete(gomock.Any()).Return(nil)
+				verification.ReplaceGlobals(v)
+				tr := translation.NewMockService(ctrl)
+				tr.EXPECT().Localizer(gomock.Any()).Return(nil, nil)
+				tr.EXPECT().Message(gomock.Any(), gomock.Any()).Return("message").AnyTimes()
+				translation.ReplaceGlobals(tr)
+				or := mocks.NewOutboxRepository(ctrl)
+				or.EXPECT().Enqueue(gomock.Any()).Return(models.OutboxEmail{}, false, errors.New("error"))
+				outbox.ReplaceGlobals(or)
+			},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Succeeds",
+			mockSetup: func(ctrl *gomock.Controller) {
+				mockEmailVerificationSent(ctrl, "email@example.com")
+			},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			request, err := service.startEmailVerification(userID, "email@example.com", language.English)
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			if tt.expectedErrCode == codes.OK {
+				assert.Equal(t, userID, request.UserID)
+				assert.Equal(t, "email@example.com", request.Email)
+			}
+
+			if tt.expectedRetryDelay > 0 {
+				details := status.Convert(err).Details()
+				if assert.Len(t, details, 1) {
+					retryInfo := details[0].(*errdetails.RetryInfo)
+					assert.InDelta(t, tt.expectedRetryDelay.Seconds(), retryInfo.GetRetryDelay().AsDuration().Seconds(), 1)
+				}
+			}
+		})
+	}
+}
@@ -3,14 +3,24 @@ package main
 import (
 	"github.com/Zapharaos/fihub-backend/cmd/user/app/repositories"
 	"github.com/Zapharaos/fihub-backend/cmd/user/app/service"
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/gen/go/brokerpb"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/gen/go/transactionpb"
 	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
 	"github.com/Zapharaos/fihub-backend/internal/app"
 	"github.com/Zapharaos/fihub-backend/internal/database"
 	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
 	"github.com/Zapharaos/fihub-backend/internal/security"
+	"github.com/Zapharaos/fihub-backend/internal/verification"
+	"github.com/Zapharaos/fihub-backend/pkg/email"
+	"github.com/Zapharaos/fihub-backend/pkg/hasher"
+	"github.com/Zapharaos/fihub-backend/pkg/passwordpolicy"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
+	"golang.org/x/text/language"
 	"time"
 )
 
@@ -39,10 +49,31 @@ func main() {
 	securityConn := grpcutil.ConnectToClient("SECURITY")
 	publicSecurityClient := securitypb.NewPublicSecurityServiceClient(securityConn)
 	security.ReplaceGlobals(security.NewPublicSecurityFacadeWithGrpcClient(publicSecurityClient))
+	securityClient := securitypb.NewSecurityServiceClient(securityConn)
+	brokerConn := grpcutil.ConnectToClient("BROKER")
+	brokerClient := brokerpb.NewBrokerServiceClient(brokerConn)
+	transactionConn := grpcutil.ConnectToClient("TRANSACTION")
+	transactionClient := transactionpb.NewTransactionServiceClient(transactionConn)
+	authConn := grpcutil.ConnectToClient("AUTH")
+	authClient := authpb.NewAuthServiceClient(authConn)
+
+	// Setup Email
+	email.ReplaceGlobals(email.NewServiceFromConfig())
+
+	// Setup Password hashing
+	hasher.ReplaceGlobals(hasher.NewPasswordServiceFromConfig())
+
+	// Setup Password policy
+	passwordpolicy.ReplaceGlobals(passwordpolicy.NewPolicyServiceFromConfig())
+
+	// Setup Translations
+	defaultLang := language.MustParse(viper.GetString("DEFAULT_LANGUAGE"))
+	translation.ReplaceGlobals(translation.NewI18nService(defaultLang))
 
 	// Register gRPC service
-	s := grpc.NewServer()
-	userpb.RegisterUserServiceServer(s, &service.Service{})
+	s := grpcutil.NewServer(serviceName)
+	userService := service.NewService(brokerClient, transactionClient, securityClient, authClient)
+	userpb.RegisterUserServiceServer(s, userService)
 
 	// Setup Database
 	if app.InitPostgres() {
@@ -56,6 +87,12 @@ func main() {
 		}
 	})
 
+	// Start removing the users whose deletion is due
+	stopUserDeletionSweeper := database.StartSweeper(userDeletionSweepInterval(), serverHealthStatusIsHealthy, userService.PurgeDeletedUsers)
+
+	// Start delivering the emails enqueued in the outbox
+	stopOutboxWorker := database.StartSweeper(outboxPollInterval(), serverHealthStatusIsHealthy, userService.DeliverOutboxEmails)
+
 	// Register gRPC health service
 	grpcutil.RegisterHealthServer(s, 30*time.Second, serviceName, serverHealthStatusIsHealthy)
 
@@ -65,12 +102,34 @@ func main() {
 
 	// Shutdown
 	zap.L().Info("Shutdown gRPC server", zap.String("service", serviceName))
+	stopUserDeletionSweeper()
+	stopOutboxWorker()
 	s.GracefulStop() // Stop server cleanly
 }
 
 // setupPostgresRepositories initializes the Postgres repositories for the microservice.
 func setupPostgresRepositories() {
 	repositories.ReplaceGlobals(repositories.NewPostgresRepository(database.DB().Postgres().DB))
+	verification.ReplaceGlobals(verification.NewPostgresRepository(database.DB().Postgres().DB))
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(database.DB().Postgres().DB))
+}
+
+// userDeletionSweepInterval returns the delay between two sweeps of the users whose deletion is due
+func userDeletionSweepInterval() time.Duration {
+	interval := viper.GetDuration("USER_DELETION_SWEEP_INTERVAL")
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	return interval
+}
+
+// outboxPollInterval returns the delay between two deliveries of the emails due in the outbox
+func outboxPollInterval() time.Duration {
+	interval := viper.GetDuration("EMAIL_OUTBOX_POLL_INTERVAL")
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return interval
 }
 
 // serverHealthStatusIsHealthy indicates whether the server is healthy.
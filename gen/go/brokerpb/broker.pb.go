@@ -989,6 +989,94 @@ func (x *ListUserBrokersResponse) GetUserBrokers() []*BrokerUser {
 	return nil
 }
 
+type DeleteUserBrokersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserBrokersRequest) Reset() {
+	*x = DeleteUserBrokersRequest{}
+	mi := &file_broker_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserBrokersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserBrokersRequest) ProtoMessage() {}
+
+func (x *DeleteUserBrokersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_broker_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserBrokersRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserBrokersRequest) Descriptor() ([]byte, []int) {
+	return file_broker_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteUserBrokersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteUserBrokersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Remaining     int64                  `protobuf:"varint,1,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserBrokersResponse) Reset() {
+	*x = DeleteUserBrokersResponse{}
+	mi := &file_broker_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserBrokersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserBrokersResponse) ProtoMessage() {}
+
+func (x *DeleteUserBrokersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_broker_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserBrokersResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserBrokersResponse) Descriptor() ([]byte, []int) {
+	return file_broker_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteUserBrokersResponse) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
 type BrokerImage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1001,7 +1089,7 @@ type BrokerImage struct {
 
 func (x *BrokerImage) Reset() {
 	*x = BrokerImage{}
-	mi := &file_broker_proto_msgTypes[20]
+	mi := &file_broker_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1013,7 +1101,7 @@ func (x *BrokerImage) String() string {
 func (*BrokerImage) ProtoMessage() {}
 
 func (x *BrokerImage) ProtoReflect() protoreflect.Message {
-	mi := &file_broker_proto_msgTypes[20]
+	mi := &file_broker_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1026,7 +1114,7 @@ func (x *BrokerImage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BrokerImage.ProtoReflect.Descriptor instead.
 func (*BrokerImage) Descriptor() ([]byte, []int) {
-	return file_broker_proto_rawDescGZIP(), []int{20}
+	return file_broker_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *BrokerImage) GetId() string {
@@ -1068,7 +1156,7 @@ type CreateBrokerImageRequest struct {
 
 func (x *CreateBrokerImageRequest) Reset() {
 	*x = CreateBrokerImageRequest{}
-	mi := &file_broker_proto_msgTypes[21]
+	mi := &file_broker_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1080,7 +1168,7 @@ func (x *CreateBrokerImageRequest) String() string {
 func (*CreateBrokerImageRequest) ProtoMessage() {}
 
 func (x *CreateBrokerImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_broker_proto_msgTypes[21]
+	mi := &file_broker_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1093,7 +1181,7 @@ func (x *CreateBrokerImageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBrokerImageRequest.ProtoReflect.Descriptor instead.
 func (*CreateBrokerImageRequest) Descriptor() ([]byte, []int) {
-	return file_broker_proto_rawDescGZIP(), []int{21}
+	return file_broker_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CreateBrokerImageRequest) GetBrokerId() string {
@@ -1126,7 +1214,7 @@ type CreateBrokerImageResponse struct {
 
 func (x *CreateBrokerImageResponse) Reset() {
 	*x = CreateBrokerImageResponse{}
-	mi := &file_broker_proto_msgTypes[22]
+	mi := &file_broker_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1138,7 +1226,7 @@ func (x *CreateBrokerImageResponse) String() string {
 func (*CreateBrokerImageResponse) ProtoMessage() {}
 
 func (x *CreateBrokerImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_broker_proto_msgTypes[22]
+	mi := &file_broker_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1151,7 +1239,7 @@ func (x *CreateBrokerImageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBrokerImageResponse.ProtoReflect.Descriptor instead.
 func (*CreateBrokerImageResponse) Descriptor() ([]byte, []int) {
-	return file_broker_proto_rawDescGZIP(), []int{22}
+	return file_broker_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CreateBrokerImageResponse) GetImage() *BrokerImage {
@@ -1170,7 +1258,7 @@ type GetBrokerImageRequest struct {
 
 func (x *GetBrokerImageRequest) Reset() {
 	*x = GetBrokerImageRequest{}
-	mi := &file_broker_proto_msgTypes[23]
+	mi := &file_broker_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1182,7 +1270,7 @@ func (x *GetBrokerImageRequest) String() string {
 func (*GetBrokerImageRequest) ProtoMessage() {}
 
 func (x *GetBrokerImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_broker_proto_msgTypes[23]
+	mi := &file_broker_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1195,7 +1283,7 @@ func (x *GetBrokerImageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBrokerImageRequest.ProtoReflect.Descriptor instead.
 func (*GetBrokerImageRequest) Descriptor() ([]byte, []int) {
-	return file_broker_proto_rawDescGZIP(), []int{23}
+	return file_broker_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetBrokerImageRequest) GetImageId() string {
@@ -1215,7 +1303,7 @@ type GetBrokerImageResponse struct {
 
 func (x *GetBrokerImageResponse) Reset() {
 	*x = GetBrokerImageResponse{}
-	mi := &file_broker_proto_msgTypes[24]
+	mi := &file_broker_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1227,7 +1315,7 @@ func (x *GetBrokerImageResponse) String() string {
 func (*GetBrokerImageResponse) ProtoMessage() {}
 
 func (x *GetBrokerImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_broker_proto_msgTypes[24]
+	mi := &file_broker_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1240,7 +1328,7 @@ func (x *GetBrokerImageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBrokerImageResponse.ProtoReflect.Descriptor instead.
 func (*GetBrokerImageResponse) Descriptor() ([]byte, []int) {
-	return file_broker_proto_rawDescGZIP(), []int{24}
+	return file_broker_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetBrokerImageResponse) GetData() []byte {
@@ -1269,7 +1357,7 @@ type UpdateBrokerImageRequest struct {
 
 func (x *UpdateBrokerImageRequest) Reset() {
 	*x = UpdateBrokerImageRequest{}
-	mi := &file_broker_proto_msgTypes[25]
+	mi := &file_broker_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1281,7 +1369,7 @@ func (x *UpdateBrokerImageRequest) String() string {
 func (*UpdateBrokerImageRequest) ProtoMessage() {}
 
 func (x *UpdateBrokerImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_broker_proto_msgTypes[25]
+	mi := &file_broker_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1294,7 +1382,7 @@ func (x *UpdateBrokerImageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBrokerImageRequest.ProtoReflect.Descriptor instead.
 func (*UpdateBrokerImageRequest) Descriptor() ([]byte, []int) {
-	return file_broker_proto_rawDescGZIP(), []int{25}
+	return file_broker_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *UpdateBrokerImageRequest) GetImageId() string {
@@ -1334,7 +1422,7 @@ type UpdateBrokerImageResponse struct {
 
 func (x *UpdateBrokerImageResponse) Reset() {
 	*x = UpdateBrokerImageResponse{}
-	mi := &file_broker_proto_msgTypes[26]
+	mi := &file_broker_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1346,7 +1434,7 @@ func (x *UpdateBrokerImageResponse) String() string {
 func (*UpdateBrokerImageResponse) ProtoMessage() {}
 
 func (x *UpdateBrokerImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_broker_proto_msgTypes[26]
+	mi := &file_broker_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1359,7 +1447,7 @@ func (x *UpdateBrokerImageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBrokerImageResponse.ProtoReflect.Descriptor instead.
 func (*UpdateBrokerImageResponse) Descriptor() ([]byte, []int) {
-	return file_broker_proto_rawDescGZIP(), []int{26}
+	return file_broker_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *UpdateBrokerImageResponse) GetImage() *BrokerImage {
@@ -1379,7 +1467,7 @@ type DeleteBrokerImageRequest struct {
 
 func (x *DeleteBrokerImageRequest) Reset() {
 	*x = DeleteBrokerImageRequest{}
-	mi := &file_broker_proto_msgTypes[27]
+	mi := &file_broker_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1391,7 +1479,7 @@ func (x *DeleteBrokerImageRequest) String() string {
 func (*DeleteBrokerImageRequest) ProtoMessage() {}
 
 func (x *DeleteBrokerImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_broker_proto_msgTypes[27]
+	mi := &file_broker_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1404,7 +1492,7 @@ func (x *DeleteBrokerImageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBrokerImageRequest.ProtoReflect.Descriptor instead.
 func (*DeleteBrokerImageRequest) Descriptor() ([]byte, []int) {
-	return file_broker_proto_rawDescGZIP(), []int{27}
+	return file_broker_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *DeleteBrokerImageRequest) GetImageId() string {
@@ -1430,7 +1518,7 @@ type DeleteBrokerImageResponse struct {
 
 func (x *DeleteBrokerImageResponse) Reset() {
 	*x = DeleteBrokerImageResponse{}
-	mi := &file_broker_proto_msgTypes[28]
+	mi := &file_broker_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1442,7 +1530,7 @@ func (x *DeleteBrokerImageResponse) String() string {
 func (*DeleteBrokerImageResponse) ProtoMessage() {}
 
 func (x *DeleteBrokerImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_broker_proto_msgTypes[28]
+	mi := &file_broker_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1455,7 +1543,7 @@ func (x *DeleteBrokerImageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBrokerImageResponse.ProtoReflect.Descriptor instead.
 func (*DeleteBrokerImageResponse) Descriptor() ([]byte, []int) {
-	return file_broker_proto_rawDescGZIP(), []int{28}
+	return file_broker_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *DeleteBrokerImageResponse) GetSuccess() bool {
@@ -1522,7 +1610,11 @@ const file_broker_proto_rawDesc = "" +
 	"\x16ListUserBrokersRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"P\n" +
 	"\x17ListUserBrokersResponse\x125\n" +
-	"\fuser_brokers\x18\x01 \x03(\v2\x12.broker.BrokerUserR\vuserBrokers\"b\n" +
+	"\fuser_brokers\x18\x01 \x03(\v2\x12.broker.BrokerUserR\vuserBrokers\"3\n" +
+	"\x18DeleteUserBrokersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"9\n" +
+	"\x19DeleteUserBrokersResponse\x12\x1c\n" +
+	"\tremaining\x18\x01 \x01(\x03R\tremaining\"b\n" +
 	"\vBrokerImage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tbroker_id\x18\x02 \x01(\tR\bbrokerId\x12\x12\n" +
@@ -1550,7 +1642,7 @@ const file_broker_proto_rawDesc = "" +
 	"\bimage_id\x18\x01 \x01(\tR\aimageId\x12\x1b\n" +
 	"\tbroker_id\x18\x02 \x01(\tR\bbrokerId\"5\n" +
 	"\x19DeleteBrokerImageResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xa9\b\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\x83\t\n" +
 	"\rBrokerService\x12I\n" +
 	"\fCreateBroker\x12\x1b.broker.CreateBrokerRequest\x1a\x1c.broker.CreateBrokerResponse\x12@\n" +
 	"\tGetBroker\x12\x18.broker.GetBrokerRequest\x1a\x19.broker.GetBrokerResponse\x12I\n" +
@@ -1561,6 +1653,7 @@ const file_broker_proto_rawDesc = "" +
 	"\rGetBrokerUser\x12\x1c.broker.GetBrokerUserRequest\x1a\x1d.broker.GetBrokerUserResponse\x12U\n" +
 	"\x10DeleteBrokerUser\x12\x1f.broker.DeleteBrokerUserRequest\x1a .broker.DeleteBrokerUserResponse\x12R\n" +
 	"\x0fListUserBrokers\x12\x1e.broker.ListUserBrokersRequest\x1a\x1f.broker.ListUserBrokersResponse\x12X\n" +
+	"\x11DeleteUserBrokers\x12 .broker.DeleteUserBrokersRequest\x1a!.broker.DeleteUserBrokersResponse\x12X\n" +
 	"\x11CreateBrokerImage\x12 .broker.CreateBrokerImageRequest\x1a!.broker.CreateBrokerImageResponse\x12O\n" +
 	"\x0eGetBrokerImage\x12\x1d.broker.GetBrokerImageRequest\x1a\x1e.broker.GetBrokerImageResponse\x12X\n" +
 	"\x11UpdateBrokerImage\x12 .broker.UpdateBrokerImageRequest\x1a!.broker.UpdateBrokerImageResponse\x12X\n" +
@@ -1579,7 +1672,7 @@ func file_broker_proto_rawDescGZIP() []byte {
 	return file_broker_proto_rawDescData
 }
 
-var file_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_broker_proto_goTypes = []any{
 	(*Broker)(nil),                    // 0: broker.Broker
 	(*CreateBrokerRequest)(nil),       // 1: broker.CreateBrokerRequest
@@ -1601,15 +1694,17 @@ var file_broker_proto_goTypes = []any{
 	(*DeleteBrokerUserResponse)(nil),  // 17: broker.DeleteBrokerUserResponse
 	(*ListUserBrokersRequest)(nil),    // 18: broker.ListUserBrokersRequest
 	(*ListUserBrokersResponse)(nil),   // 19: broker.ListUserBrokersResponse
-	(*BrokerImage)(nil),               // 20: broker.BrokerImage
-	(*CreateBrokerImageRequest)(nil),  // 21: broker.CreateBrokerImageRequest
-	(*CreateBrokerImageResponse)(nil), // 22: broker.CreateBrokerImageResponse
-	(*GetBrokerImageRequest)(nil),     // 23: broker.GetBrokerImageRequest
-	(*GetBrokerImageResponse)(nil),    // 24: broker.GetBrokerImageResponse
-	(*UpdateBrokerImageRequest)(nil),  // 25: broker.UpdateBrokerImageRequest
-	(*UpdateBrokerImageResponse)(nil), // 26: broker.UpdateBrokerImageResponse
-	(*DeleteBrokerImageRequest)(nil),  // 27: broker.DeleteBrokerImageRequest
-	(*DeleteBrokerImageResponse)(nil), // 28: broker.DeleteBrokerImageResponse
+	(*DeleteUserBrokersRequest)(nil),  // 20: broker.DeleteUserBrokersRequest
+	(*DeleteUserBrokersResponse)(nil), // 21: broker.DeleteUserBrokersResponse
+	(*BrokerImage)(nil),               // 22: broker.BrokerImage
+	(*CreateBrokerImageRequest)(nil),  // 23: broker.CreateBrokerImageRequest
+	(*CreateBrokerImageResponse)(nil), // 24: broker.CreateBrokerImageResponse
+	(*GetBrokerImageRequest)(nil),     // 25: broker.GetBrokerImageRequest
+	(*GetBrokerImageResponse)(nil),    // 26: broker.GetBrokerImageResponse
+	(*UpdateBrokerImageRequest)(nil),  // 27: broker.UpdateBrokerImageRequest
+	(*UpdateBrokerImageResponse)(nil), // 28: broker.UpdateBrokerImageResponse
+	(*DeleteBrokerImageRequest)(nil),  // 29: broker.DeleteBrokerImageRequest
+	(*DeleteBrokerImageResponse)(nil), // 30: broker.DeleteBrokerImageResponse
 }
 var file_broker_proto_depIdxs = []int32{
 	0,  // 0: broker.CreateBrokerResponse.broker:type_name -> broker.Broker
@@ -1620,8 +1715,8 @@ var file_broker_proto_depIdxs = []int32{
 	11, // 5: broker.CreateBrokerUserResponse.user_brokers:type_name -> broker.BrokerUser
 	11, // 6: broker.GetBrokerUserResponse.broker_user:type_name -> broker.BrokerUser
 	11, // 7: broker.ListUserBrokersResponse.user_brokers:type_name -> broker.BrokerUser
-	20, // 8: broker.CreateBrokerImageResponse.image:type_name -> broker.BrokerImage
-	20, // 9: broker.UpdateBrokerImageResponse.image:type_name -> broker.BrokerImage
+	22, // 8: broker.CreateBrokerImageResponse.image:type_name -> broker.BrokerImage
+	22, // 9: broker.UpdateBrokerImageResponse.image:type_name -> broker.BrokerImage
 	1,  // 10: broker.BrokerService.CreateBroker:input_type -> broker.CreateBrokerRequest
 	3,  // 11: broker.BrokerService.GetBroker:input_type -> broker.GetBrokerRequest
 	5,  // 12: broker.BrokerService.UpdateBroker:input_type -> broker.UpdateBrokerRequest
@@ -1631,25 +1726,27 @@ var file_broker_proto_depIdxs = []int32{
 	14, // 16: broker.BrokerService.GetBrokerUser:input_type -> broker.GetBrokerUserRequest
 	16, // 17: broker.BrokerService.DeleteBrokerUser:input_type -> broker.DeleteBrokerUserRequest
 	18, // 18: broker.BrokerService.ListUserBrokers:input_type -> broker.ListUserBrokersRequest
-	21, // 19: broker.BrokerService.CreateBrokerImage:input_type -> broker.CreateBrokerImageRequest
-	23, // 20: broker.BrokerService.GetBrokerImage:input_type -> broker.GetBrokerImageRequest
-	25, // 21: broker.BrokerService.UpdateBrokerImage:input_type -> broker.UpdateBrokerImageRequest
-	27, // 22: broker.BrokerService.DeleteBrokerImage:input_type -> broker.DeleteBrokerImageRequest
-	2,  // 23: broker.BrokerService.CreateBroker:output_type -> broker.CreateBrokerResponse
-	4,  // 24: broker.BrokerService.GetBroker:output_type -> broker.GetBrokerResponse
-	6,  // 25: broker.BrokerService.UpdateBroker:output_type -> broker.UpdateBrokerResponse
-	8,  // 26: broker.BrokerService.DeleteBroker:output_type -> broker.DeleteBrokerResponse
-	10, // 27: broker.BrokerService.ListBrokers:output_type -> broker.ListBrokersResponse
-	13, // 28: broker.BrokerService.CreateBrokerUser:output_type -> broker.CreateBrokerUserResponse
-	15, // 29: broker.BrokerService.GetBrokerUser:output_type -> broker.GetBrokerUserResponse
-	17, // 30: broker.BrokerService.DeleteBrokerUser:output_type -> broker.DeleteBrokerUserResponse
-	19, // 31: broker.BrokerService.ListUserBrokers:output_type -> broker.ListUserBrokersResponse
-	22, // 32: broker.BrokerService.CreateBrokerImage:output_type -> broker.CreateBrokerImageResponse
-	24, // 33: broker.BrokerService.GetBrokerImage:output_type -> broker.GetBrokerImageResponse
-	26, // 34: broker.BrokerService.UpdateBrokerImage:output_type -> broker.UpdateBrokerImageResponse
-	28, // 35: broker.BrokerService.DeleteBrokerImage:output_type -> broker.DeleteBrokerImageResponse
-	23, // [23:36] is the sub-list for method output_type
-	10, // [10:23] is the sub-list for method input_type
+	20, // 19: broker.BrokerService.DeleteUserBrokers:input_type -> broker.DeleteUserBrokersRequest
+	23, // 20: broker.BrokerService.CreateBrokerImage:input_type -> broker.CreateBrokerImageRequest
+	25, // 21: broker.BrokerService.GetBrokerImage:input_type -> broker.GetBrokerImageRequest
+	27, // 22: broker.BrokerService.UpdateBrokerImage:input_type -> broker.UpdateBrokerImageRequest
+	29, // 23: broker.BrokerService.DeleteBrokerImage:input_type -> broker.DeleteBrokerImageRequest
+	2,  // 24: broker.BrokerService.CreateBroker:output_type -> broker.CreateBrokerResponse
+	4,  // 25: broker.BrokerService.GetBroker:output_type -> broker.GetBrokerResponse
+	6,  // 26: broker.BrokerService.UpdateBroker:output_type -> broker.UpdateBrokerResponse
+	8,  // 27: broker.BrokerService.DeleteBroker:output_type -> broker.DeleteBrokerResponse
+	10, // 28: broker.BrokerService.ListBrokers:output_type -> broker.ListBrokersResponse
+	13, // 29: broker.BrokerService.CreateBrokerUser:output_type -> broker.CreateBrokerUserResponse
+	15, // 30: broker.BrokerService.GetBrokerUser:output_type -> broker.GetBrokerUserResponse
+	17, // 31: broker.BrokerService.DeleteBrokerUser:output_type -> broker.DeleteBrokerUserResponse
+	19, // 32: broker.BrokerService.ListUserBrokers:output_type -> broker.ListUserBrokersResponse
+	21, // 33: broker.BrokerService.DeleteUserBrokers:output_type -> broker.DeleteUserBrokersResponse
+	24, // 34: broker.BrokerService.CreateBrokerImage:output_type -> broker.CreateBrokerImageResponse
+	26, // 35: broker.BrokerService.GetBrokerImage:output_type -> broker.GetBrokerImageResponse
+	28, // 36: broker.BrokerService.UpdateBrokerImage:output_type -> broker.UpdateBrokerImageResponse
+	30, // 37: broker.BrokerService.DeleteBrokerImage:output_type -> broker.DeleteBrokerImageResponse
+	24, // [24:38] is the sub-list for method output_type
+	10, // [10:24] is the sub-list for method input_type
 	10, // [10:10] is the sub-list for extension type_name
 	10, // [10:10] is the sub-list for extension extendee
 	0,  // [0:10] is the sub-list for field type_name
@@ -1666,7 +1763,7 @@ func file_broker_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_broker_proto_rawDesc), len(file_broker_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
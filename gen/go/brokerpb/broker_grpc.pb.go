@@ -28,6 +28,7 @@ const (
 	BrokerService_GetBrokerUser_FullMethodName     = "/broker.BrokerService/GetBrokerUser"
 	BrokerService_DeleteBrokerUser_FullMethodName  = "/broker.BrokerService/DeleteBrokerUser"
 	BrokerService_ListUserBrokers_FullMethodName   = "/broker.BrokerService/ListUserBrokers"
+	BrokerService_DeleteUserBrokers_FullMethodName = "/broker.BrokerService/DeleteUserBrokers"
 	BrokerService_CreateBrokerImage_FullMethodName = "/broker.BrokerService/CreateBrokerImage"
 	BrokerService_GetBrokerImage_FullMethodName    = "/broker.BrokerService/GetBrokerImage"
 	BrokerService_UpdateBrokerImage_FullMethodName = "/broker.BrokerService/UpdateBrokerImage"
@@ -49,6 +50,7 @@ type BrokerServiceClient interface {
 	GetBrokerUser(ctx context.Context, in *GetBrokerUserRequest, opts ...grpc.CallOption) (*GetBrokerUserResponse, error)
 	DeleteBrokerUser(ctx context.Context, in *DeleteBrokerUserRequest, opts ...grpc.CallOption) (*DeleteBrokerUserResponse, error)
 	ListUserBrokers(ctx context.Context, in *ListUserBrokersRequest, opts ...grpc.CallOption) (*ListUserBrokersResponse, error)
+	DeleteUserBrokers(ctx context.Context, in *DeleteUserBrokersRequest, opts ...grpc.CallOption) (*DeleteUserBrokersResponse, error)
 	// Image management
 	CreateBrokerImage(ctx context.Context, in *CreateBrokerImageRequest, opts ...grpc.CallOption) (*CreateBrokerImageResponse, error)
 	GetBrokerImage(ctx context.Context, in *GetBrokerImageRequest, opts ...grpc.CallOption) (*GetBrokerImageResponse, error)
@@ -154,6 +156,16 @@ func (c *brokerServiceClient) ListUserBrokers(ctx context.Context, in *ListUserB
 	return out, nil
 }
 
+func (c *brokerServiceClient) DeleteUserBrokers(ctx context.Context, in *DeleteUserBrokersRequest, opts ...grpc.CallOption) (*DeleteUserBrokersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserBrokersResponse)
+	err := c.cc.Invoke(ctx, BrokerService_DeleteUserBrokers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *brokerServiceClient) CreateBrokerImage(ctx context.Context, in *CreateBrokerImageRequest, opts ...grpc.CallOption) (*CreateBrokerImageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateBrokerImageResponse)
@@ -209,6 +221,7 @@ type BrokerServiceServer interface {
 	GetBrokerUser(context.Context, *GetBrokerUserRequest) (*GetBrokerUserResponse, error)
 	DeleteBrokerUser(context.Context, *DeleteBrokerUserRequest) (*DeleteBrokerUserResponse, error)
 	ListUserBrokers(context.Context, *ListUserBrokersRequest) (*ListUserBrokersResponse, error)
+	DeleteUserBrokers(context.Context, *DeleteUserBrokersRequest) (*DeleteUserBrokersResponse, error)
 	// Image management
 	CreateBrokerImage(context.Context, *CreateBrokerImageRequest) (*CreateBrokerImageResponse, error)
 	GetBrokerImage(context.Context, *GetBrokerImageRequest) (*GetBrokerImageResponse, error)
@@ -251,6 +264,9 @@ func (UnimplementedBrokerServiceServer) DeleteBrokerUser(context.Context, *Delet
 func (UnimplementedBrokerServiceServer) ListUserBrokers(context.Context, *ListUserBrokersRequest) (*ListUserBrokersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUserBrokers not implemented")
 }
+func (UnimplementedBrokerServiceServer) DeleteUserBrokers(context.Context, *DeleteUserBrokersRequest) (*DeleteUserBrokersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserBrokers not implemented")
+}
 func (UnimplementedBrokerServiceServer) CreateBrokerImage(context.Context, *CreateBrokerImageRequest) (*CreateBrokerImageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateBrokerImage not implemented")
 }
@@ -446,6 +462,24 @@ func _BrokerService_ListUserBrokers_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BrokerService_DeleteUserBrokers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserBrokersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServiceServer).DeleteUserBrokers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BrokerService_DeleteUserBrokers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServiceServer).DeleteUserBrokers(ctx, req.(*DeleteUserBrokersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BrokerService_CreateBrokerImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateBrokerImageRequest)
 	if err := dec(in); err != nil {
@@ -561,6 +595,10 @@ var BrokerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUserBrokers",
 			Handler:    _BrokerService_ListUserBrokers_Handler,
 		},
+		{
+			MethodName: "DeleteUserBrokers",
+			Handler:    _BrokerService_DeleteUserBrokers_Handler,
+		},
 		{
 			MethodName: "CreateBrokerImage",
 			Handler:    _BrokerService_CreateBrokerImage_Handler,
@@ -9,6 +9,7 @@ package securitypb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -614,11 +615,13 @@ func (x *Role) GetName() string {
 }
 
 type RoleWithPermissions struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Role          *Role                  `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
-	Permissions   []*Permission          `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Role                 *Role                  `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Permissions          []*Permission          `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Parents              []*Role                `protobuf:"bytes,3,rep,name=parents,proto3" json:"parents,omitempty"`
+	InheritedPermissions []*Permission          `protobuf:"bytes,4,rep,name=inherited_permissions,json=inheritedPermissions,proto3" json:"inherited_permissions,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *RoleWithPermissions) Reset() {
@@ -665,6 +668,20 @@ func (x *RoleWithPermissions) GetPermissions() []*Permission {
 	return nil
 }
 
+func (x *RoleWithPermissions) GetParents() []*Role {
+	if x != nil {
+		return x.Parents
+	}
+	return nil
+}
+
+func (x *RoleWithPermissions) GetInheritedPermissions() []*Permission {
+	if x != nil {
+		return x.InheritedPermissions
+	}
+	return nil
+}
+
 type CreateRoleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -1305,6 +1322,102 @@ func (x *SetRolePermissionsResponse) GetPermissions() []*Permission {
 	return nil
 }
 
+type SetRoleParentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Parents       []string               `protobuf:"bytes,2,rep,name=parents,proto3" json:"parents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRoleParentsRequest) Reset() {
+	*x = SetRoleParentsRequest{}
+	mi := &file_security_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRoleParentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoleParentsRequest) ProtoMessage() {}
+
+func (x *SetRoleParentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoleParentsRequest.ProtoReflect.Descriptor instead.
+func (*SetRoleParentsRequest) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetRoleParentsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetRoleParentsRequest) GetParents() []string {
+	if x != nil {
+		return x.Parents
+	}
+	return nil
+}
+
+type SetRoleParentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          *RoleWithPermissions   `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRoleParentsResponse) Reset() {
+	*x = SetRoleParentsResponse{}
+	mi := &file_security_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRoleParentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoleParentsResponse) ProtoMessage() {}
+
+func (x *SetRoleParentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoleParentsResponse.ProtoReflect.Descriptor instead.
+func (*SetRoleParentsResponse) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetRoleParentsResponse) GetRole() *RoleWithPermissions {
+	if x != nil {
+		return x.Role
+	}
+	return nil
+}
+
 type AddUsersToRoleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
@@ -1315,7 +1428,7 @@ type AddUsersToRoleRequest struct {
 
 func (x *AddUsersToRoleRequest) Reset() {
 	*x = AddUsersToRoleRequest{}
-	mi := &file_security_proto_msgTypes[27]
+	mi := &file_security_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1327,7 +1440,7 @@ func (x *AddUsersToRoleRequest) String() string {
 func (*AddUsersToRoleRequest) ProtoMessage() {}
 
 func (x *AddUsersToRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[27]
+	mi := &file_security_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1340,7 +1453,7 @@ func (x *AddUsersToRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddUsersToRoleRequest.ProtoReflect.Descriptor instead.
 func (*AddUsersToRoleRequest) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{27}
+	return file_security_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *AddUsersToRoleRequest) GetRoleId() string {
@@ -1366,7 +1479,7 @@ type AddUsersToRoleResponse struct {
 
 func (x *AddUsersToRoleResponse) Reset() {
 	*x = AddUsersToRoleResponse{}
-	mi := &file_security_proto_msgTypes[28]
+	mi := &file_security_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1378,7 +1491,7 @@ func (x *AddUsersToRoleResponse) String() string {
 func (*AddUsersToRoleResponse) ProtoMessage() {}
 
 func (x *AddUsersToRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[28]
+	mi := &file_security_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1391,7 +1504,7 @@ func (x *AddUsersToRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddUsersToRoleResponse.ProtoReflect.Descriptor instead.
 func (*AddUsersToRoleResponse) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{28}
+	return file_security_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *AddUsersToRoleResponse) GetUserIds() []string {
@@ -1411,7 +1524,7 @@ type RemoveUsersFromRoleRequest struct {
 
 func (x *RemoveUsersFromRoleRequest) Reset() {
 	*x = RemoveUsersFromRoleRequest{}
-	mi := &file_security_proto_msgTypes[29]
+	mi := &file_security_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1423,7 +1536,7 @@ func (x *RemoveUsersFromRoleRequest) String() string {
 func (*RemoveUsersFromRoleRequest) ProtoMessage() {}
 
 func (x *RemoveUsersFromRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[29]
+	mi := &file_security_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1436,7 +1549,7 @@ func (x *RemoveUsersFromRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveUsersFromRoleRequest.ProtoReflect.Descriptor instead.
 func (*RemoveUsersFromRoleRequest) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{29}
+	return file_security_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *RemoveUsersFromRoleRequest) GetRoleId() string {
@@ -1462,7 +1575,7 @@ type RemoveUsersFromRoleResponse struct {
 
 func (x *RemoveUsersFromRoleResponse) Reset() {
 	*x = RemoveUsersFromRoleResponse{}
-	mi := &file_security_proto_msgTypes[30]
+	mi := &file_security_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1474,7 +1587,7 @@ func (x *RemoveUsersFromRoleResponse) String() string {
 func (*RemoveUsersFromRoleResponse) ProtoMessage() {}
 
 func (x *RemoveUsersFromRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[30]
+	mi := &file_security_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1487,7 +1600,7 @@ func (x *RemoveUsersFromRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveUsersFromRoleResponse.ProtoReflect.Descriptor instead.
 func (*RemoveUsersFromRoleResponse) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{30}
+	return file_security_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *RemoveUsersFromRoleResponse) GetUserIds() []string {
@@ -1506,7 +1619,7 @@ type ListUsersForRoleRequest struct {
 
 func (x *ListUsersForRoleRequest) Reset() {
 	*x = ListUsersForRoleRequest{}
-	mi := &file_security_proto_msgTypes[31]
+	mi := &file_security_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1518,7 +1631,7 @@ func (x *ListUsersForRoleRequest) String() string {
 func (*ListUsersForRoleRequest) ProtoMessage() {}
 
 func (x *ListUsersForRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[31]
+	mi := &file_security_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1531,7 +1644,7 @@ func (x *ListUsersForRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersForRoleRequest.ProtoReflect.Descriptor instead.
 func (*ListUsersForRoleRequest) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{31}
+	return file_security_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ListUsersForRoleRequest) GetRoleId() string {
@@ -1550,7 +1663,7 @@ type ListUsersForRoleResponse struct {
 
 func (x *ListUsersForRoleResponse) Reset() {
 	*x = ListUsersForRoleResponse{}
-	mi := &file_security_proto_msgTypes[32]
+	mi := &file_security_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1562,7 +1675,7 @@ func (x *ListUsersForRoleResponse) String() string {
 func (*ListUsersForRoleResponse) ProtoMessage() {}
 
 func (x *ListUsersForRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[32]
+	mi := &file_security_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1575,7 +1688,7 @@ func (x *ListUsersForRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersForRoleResponse.ProtoReflect.Descriptor instead.
 func (*ListUsersForRoleResponse) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{32}
+	return file_security_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *ListUsersForRoleResponse) GetUserIds() []string {
@@ -1595,7 +1708,7 @@ type SetRolesForUserRequest struct {
 
 func (x *SetRolesForUserRequest) Reset() {
 	*x = SetRolesForUserRequest{}
-	mi := &file_security_proto_msgTypes[33]
+	mi := &file_security_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1607,7 +1720,7 @@ func (x *SetRolesForUserRequest) String() string {
 func (*SetRolesForUserRequest) ProtoMessage() {}
 
 func (x *SetRolesForUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[33]
+	mi := &file_security_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1620,7 +1733,7 @@ func (x *SetRolesForUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetRolesForUserRequest.ProtoReflect.Descriptor instead.
 func (*SetRolesForUserRequest) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{33}
+	return file_security_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *SetRolesForUserRequest) GetUserId() string {
@@ -1646,7 +1759,7 @@ type SetRolesForUserResponse struct {
 
 func (x *SetRolesForUserResponse) Reset() {
 	*x = SetRolesForUserResponse{}
-	mi := &file_security_proto_msgTypes[34]
+	mi := &file_security_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1658,7 +1771,7 @@ func (x *SetRolesForUserResponse) String() string {
 func (*SetRolesForUserResponse) ProtoMessage() {}
 
 func (x *SetRolesForUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[34]
+	mi := &file_security_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1671,7 +1784,7 @@ func (x *SetRolesForUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetRolesForUserResponse.ProtoReflect.Descriptor instead.
 func (*SetRolesForUserResponse) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{34}
+	return file_security_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *SetRolesForUserResponse) GetRoles() []*RoleWithPermissions {
@@ -1690,7 +1803,7 @@ type ListRolesForUserRequest struct {
 
 func (x *ListRolesForUserRequest) Reset() {
 	*x = ListRolesForUserRequest{}
-	mi := &file_security_proto_msgTypes[35]
+	mi := &file_security_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1702,7 +1815,7 @@ func (x *ListRolesForUserRequest) String() string {
 func (*ListRolesForUserRequest) ProtoMessage() {}
 
 func (x *ListRolesForUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[35]
+	mi := &file_security_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1715,7 +1828,7 @@ func (x *ListRolesForUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRolesForUserRequest.ProtoReflect.Descriptor instead.
 func (*ListRolesForUserRequest) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{35}
+	return file_security_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ListRolesForUserRequest) GetUserId() string {
@@ -1734,7 +1847,7 @@ type ListRolesForUserResponse struct {
 
 func (x *ListRolesForUserResponse) Reset() {
 	*x = ListRolesForUserResponse{}
-	mi := &file_security_proto_msgTypes[36]
+	mi := &file_security_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1746,7 +1859,7 @@ func (x *ListRolesForUserResponse) String() string {
 func (*ListRolesForUserResponse) ProtoMessage() {}
 
 func (x *ListRolesForUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[36]
+	mi := &file_security_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1759,7 +1872,7 @@ func (x *ListRolesForUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRolesForUserResponse.ProtoReflect.Descriptor instead.
 func (*ListRolesForUserResponse) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{36}
+	return file_security_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ListRolesForUserResponse) GetRoles() []*Role {
@@ -1778,7 +1891,7 @@ type ListRolesWithPermissionsForUserRequest struct {
 
 func (x *ListRolesWithPermissionsForUserRequest) Reset() {
 	*x = ListRolesWithPermissionsForUserRequest{}
-	mi := &file_security_proto_msgTypes[37]
+	mi := &file_security_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1790,7 +1903,7 @@ func (x *ListRolesWithPermissionsForUserRequest) String() string {
 func (*ListRolesWithPermissionsForUserRequest) ProtoMessage() {}
 
 func (x *ListRolesWithPermissionsForUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[37]
+	mi := &file_security_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1803,7 +1916,7 @@ func (x *ListRolesWithPermissionsForUserRequest) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use ListRolesWithPermissionsForUserRequest.ProtoReflect.Descriptor instead.
 func (*ListRolesWithPermissionsForUserRequest) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{37}
+	return file_security_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ListRolesWithPermissionsForUserRequest) GetUserId() string {
@@ -1822,7 +1935,7 @@ type ListRolesWithPermissionsForUserResponse struct {
 
 func (x *ListRolesWithPermissionsForUserResponse) Reset() {
 	*x = ListRolesWithPermissionsForUserResponse{}
-	mi := &file_security_proto_msgTypes[38]
+	mi := &file_security_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1834,7 +1947,7 @@ func (x *ListRolesWithPermissionsForUserResponse) String() string {
 func (*ListRolesWithPermissionsForUserResponse) ProtoMessage() {}
 
 func (x *ListRolesWithPermissionsForUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[38]
+	mi := &file_security_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1847,7 +1960,7 @@ func (x *ListRolesWithPermissionsForUserResponse) ProtoReflect() protoreflect.Me
 
 // Deprecated: Use ListRolesWithPermissionsForUserResponse.ProtoReflect.Descriptor instead.
 func (*ListRolesWithPermissionsForUserResponse) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{38}
+	return file_security_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ListRolesWithPermissionsForUserResponse) GetRoles() []*RoleWithPermissions {
@@ -1857,29 +1970,29 @@ func (x *ListRolesWithPermissionsForUserResponse) GetRoles() []*RoleWithPermissi
 	return nil
 }
 
-type UserWithRoles struct {
+type ListEffectivePermissionsForUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Roles         []*Role                `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserWithRoles) Reset() {
-	*x = UserWithRoles{}
-	mi := &file_security_proto_msgTypes[39]
+func (x *ListEffectivePermissionsForUserRequest) Reset() {
+	*x = ListEffectivePermissionsForUserRequest{}
+	mi := &file_security_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserWithRoles) String() string {
+func (x *ListEffectivePermissionsForUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserWithRoles) ProtoMessage() {}
+func (*ListEffectivePermissionsForUserRequest) ProtoMessage() {}
 
-func (x *UserWithRoles) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[39]
+func (x *ListEffectivePermissionsForUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1890,46 +2003,91 @@ func (x *UserWithRoles) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserWithRoles.ProtoReflect.Descriptor instead.
-func (*UserWithRoles) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use ListEffectivePermissionsForUserRequest.ProtoReflect.Descriptor instead.
+func (*ListEffectivePermissionsForUserRequest) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *UserWithRoles) GetUserId() string {
+func (x *ListEffectivePermissionsForUserRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *UserWithRoles) GetRoles() []*Role {
+func (x *ListEffectivePermissionsForUserRequest) GetScope() string {
 	if x != nil {
-		return x.Roles
+		return x.Scope
+	}
+	return ""
+}
+
+type ListEffectivePermissionsForUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Permissions   []*Permission          `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEffectivePermissionsForUserResponse) Reset() {
+	*x = ListEffectivePermissionsForUserResponse{}
+	mi := &file_security_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEffectivePermissionsForUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEffectivePermissionsForUserResponse) ProtoMessage() {}
+
+func (x *ListEffectivePermissionsForUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEffectivePermissionsForUserResponse.ProtoReflect.Descriptor instead.
+func (*ListEffectivePermissionsForUserResponse) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListEffectivePermissionsForUserResponse) GetPermissions() []*Permission {
+	if x != nil {
+		return x.Permissions
 	}
 	return nil
 }
 
-type ListUsersFullRequest struct {
+type DeleteRolesForUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUsersFullRequest) Reset() {
-	*x = ListUsersFullRequest{}
-	mi := &file_security_proto_msgTypes[40]
+func (x *DeleteRolesForUserRequest) Reset() {
+	*x = DeleteRolesForUserRequest{}
+	mi := &file_security_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUsersFullRequest) String() string {
+func (x *DeleteRolesForUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUsersFullRequest) ProtoMessage() {}
+func (*DeleteRolesForUserRequest) ProtoMessage() {}
 
-func (x *ListUsersFullRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[40]
+func (x *DeleteRolesForUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1940,33 +2098,40 @@ func (x *ListUsersFullRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUsersFullRequest.ProtoReflect.Descriptor instead.
-func (*ListUsersFullRequest) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use DeleteRolesForUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRolesForUserRequest) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{43}
 }
 
-type ListUsersFullResponse struct {
+func (x *DeleteRolesForUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteRolesForUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*UserWithRoles       `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Remaining     int64                  `protobuf:"varint,1,opt,name=remaining,proto3" json:"remaining,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUsersFullResponse) Reset() {
-	*x = ListUsersFullResponse{}
-	mi := &file_security_proto_msgTypes[41]
+func (x *DeleteRolesForUserResponse) Reset() {
+	*x = DeleteRolesForUserResponse{}
+	mi := &file_security_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUsersFullResponse) String() string {
+func (x *DeleteRolesForUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUsersFullResponse) ProtoMessage() {}
+func (*DeleteRolesForUserResponse) ProtoMessage() {}
 
-func (x *ListUsersFullResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_security_proto_msgTypes[41]
+func (x *DeleteRolesForUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1977,26 +2142,522 @@ func (x *ListUsersFullResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUsersFullResponse.ProtoReflect.Descriptor instead.
-func (*ListUsersFullResponse) Descriptor() ([]byte, []int) {
-	return file_security_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use DeleteRolesForUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRolesForUserResponse) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *ListUsersFullResponse) GetUsers() []*UserWithRoles {
+func (x *DeleteRolesForUserResponse) GetRemaining() int64 {
 	if x != nil {
-		return x.Users
+		return x.Remaining
 	}
-	return nil
+	return 0
 }
 
-var File_security_proto protoreflect.FileDescriptor
+type RoleGrant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoleId        string                 `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	ValidFrom     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	GrantedBy     string                 `protobuf:"bytes,6,opt,name=granted_by,json=grantedBy,proto3" json:"granted_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_security_proto_rawDesc = "" +
-	"\n" +
-	"\x0esecurity.proto\x12\bsecurity\"j\n" +
-	"\n" +
-	"Permission\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+func (x *RoleGrant) Reset() {
+	*x = RoleGrant{}
+	mi := &file_security_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleGrant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleGrant) ProtoMessage() {}
+
+func (x *RoleGrant) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleGrant.ProtoReflect.Descriptor instead.
+func (*RoleGrant) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RoleGrant) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RoleGrant) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *RoleGrant) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *RoleGrant) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *RoleGrant) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RoleGrant) GetGrantedBy() string {
+	if x != nil {
+		return x.GrantedBy
+	}
+	return ""
+}
+
+type GrantRoleToUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoleId        string                 `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	ValidFrom     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantRoleToUserRequest) Reset() {
+	*x = GrantRoleToUserRequest{}
+	mi := &file_security_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRoleToUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRoleToUserRequest) ProtoMessage() {}
+
+func (x *GrantRoleToUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRoleToUserRequest.ProtoReflect.Descriptor instead.
+func (*GrantRoleToUserRequest) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GrantRoleToUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GrantRoleToUserRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *GrantRoleToUserRequest) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *GrantRoleToUserRequest) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *GrantRoleToUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GrantRoleToUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grant         *RoleGrant             `protobuf:"bytes,1,opt,name=grant,proto3" json:"grant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantRoleToUserResponse) Reset() {
+	*x = GrantRoleToUserResponse{}
+	mi := &file_security_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRoleToUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRoleToUserResponse) ProtoMessage() {}
+
+func (x *GrantRoleToUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRoleToUserResponse.ProtoReflect.Descriptor instead.
+func (*GrantRoleToUserResponse) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GrantRoleToUserResponse) GetGrant() *RoleGrant {
+	if x != nil {
+		return x.Grant
+	}
+	return nil
+}
+
+type ListRoleGrantsForUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleGrantsForUserRequest) Reset() {
+	*x = ListRoleGrantsForUserRequest{}
+	mi := &file_security_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleGrantsForUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleGrantsForUserRequest) ProtoMessage() {}
+
+func (x *ListRoleGrantsForUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleGrantsForUserRequest.ProtoReflect.Descriptor instead.
+func (*ListRoleGrantsForUserRequest) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListRoleGrantsForUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListRoleGrantsForUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grants        []*RoleGrant           `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleGrantsForUserResponse) Reset() {
+	*x = ListRoleGrantsForUserResponse{}
+	mi := &file_security_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleGrantsForUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleGrantsForUserResponse) ProtoMessage() {}
+
+func (x *ListRoleGrantsForUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleGrantsForUserResponse.ProtoReflect.Descriptor instead.
+func (*ListRoleGrantsForUserResponse) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListRoleGrantsForUserResponse) GetGrants() []*RoleGrant {
+	if x != nil {
+		return x.Grants
+	}
+	return nil
+}
+
+type UserWithRoles struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Roles         []*Role                `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserWithRoles) Reset() {
+	*x = UserWithRoles{}
+	mi := &file_security_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserWithRoles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserWithRoles) ProtoMessage() {}
+
+func (x *UserWithRoles) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserWithRoles.ProtoReflect.Descriptor instead.
+func (*UserWithRoles) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *UserWithRoles) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserWithRoles) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type ListUsersFullRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Sort          string                 `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	After         string                 `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	RoleId        string                 `protobuf:"bytes,5,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersFullRequest) Reset() {
+	*x = ListUsersFullRequest{}
+	mi := &file_security_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersFullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersFullRequest) ProtoMessage() {}
+
+func (x *ListUsersFullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersFullRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersFullRequest) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListUsersFullRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ListUsersFullRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListUsersFullRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersFullRequest) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *ListUsersFullRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *ListUsersFullRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListUsersFullRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListUsersFullRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListUsersFullResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserWithRoles       `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersFullResponse) Reset() {
+	*x = ListUsersFullResponse{}
+	mi := &file_security_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersFullResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersFullResponse) ProtoMessage() {}
+
+func (x *ListUsersFullResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_security_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersFullResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersFullResponse) Descriptor() ([]byte, []int) {
+	return file_security_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListUsersFullResponse) GetUsers() []*UserWithRoles {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersFullResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+var File_security_proto protoreflect.FileDescriptor
+
+const file_security_proto_rawDesc = "" +
+	"\n" +
+	"\x0esecurity.proto\x12\bsecurity\x1a\x1fgoogle/protobuf/timestamp.proto\"j\n" +
+	"\n" +
+	"Permission\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value\x12\x14\n" +
 	"\x05scope\x18\x03 \x01(\tR\x05scope\x12 \n" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\"g\n" +
@@ -2032,10 +2693,12 @@ const file_security_proto_rawDesc = "" +
 	"\vpermissions\x18\x01 \x03(\v2\x14.security.PermissionR\vpermissions\"*\n" +
 	"\x04Role\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"q\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xe6\x01\n" +
 	"\x13RoleWithPermissions\x12\"\n" +
 	"\x04role\x18\x01 \x01(\v2\x0e.security.RoleR\x04role\x126\n" +
-	"\vpermissions\x18\x02 \x03(\v2\x14.security.PermissionR\vpermissions\"I\n" +
+	"\vpermissions\x18\x02 \x03(\v2\x14.security.PermissionR\vpermissions\x12(\n" +
+	"\aparents\x18\x03 \x03(\v2\x0e.security.RoleR\aparents\x12I\n" +
+	"\x15inherited_permissions\x18\x04 \x03(\v2\x14.security.PermissionR\x14inheritedPermissions\"I\n" +
 	"\x11CreateRoleRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vpermissions\x18\x02 \x03(\tR\vpermissions\"G\n" +
@@ -2066,7 +2729,12 @@ const file_security_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
 	"\vpermissions\x18\x02 \x03(\tR\vpermissions\"T\n" +
 	"\x1aSetRolePermissionsResponse\x126\n" +
-	"\vpermissions\x18\x01 \x03(\v2\x14.security.PermissionR\vpermissions\"K\n" +
+	"\vpermissions\x18\x01 \x03(\v2\x14.security.PermissionR\vpermissions\"A\n" +
+	"\x15SetRoleParentsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aparents\x18\x02 \x03(\tR\aparents\"K\n" +
+	"\x16SetRoleParentsResponse\x121\n" +
+	"\x04role\x18\x01 \x01(\v2\x1d.security.RoleWithPermissionsR\x04role\"K\n" +
 	"\x15AddUsersToRoleRequest\x12\x17\n" +
 	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12\x19\n" +
 	"\buser_ids\x18\x02 \x03(\tR\auserIds\"3\n" +
@@ -2093,13 +2761,57 @@ const file_security_proto_rawDesc = "" +
 	"&ListRolesWithPermissionsForUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"^\n" +
 	"'ListRolesWithPermissionsForUserResponse\x123\n" +
-	"\x05roles\x18\x01 \x03(\v2\x1d.security.RoleWithPermissionsR\x05roles\"N\n" +
+	"\x05roles\x18\x01 \x03(\v2\x1d.security.RoleWithPermissionsR\x05roles\"W\n" +
+	"&ListEffectivePermissionsForUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\"a\n" +
+	"'ListEffectivePermissionsForUserResponse\x126\n" +
+	"\vpermissions\x18\x01 \x03(\v2\x14.security.PermissionR\vpermissions\"4\n" +
+	"\x19DeleteRolesForUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\":\n" +
+	"\x1aDeleteRolesForUserResponse\x12\x1c\n" +
+	"\tremaining\x18\x01 \x01(\x03R\tremaining\"\xec\x01\n" +
+	"\tRoleGrant\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\arole_id\x18\x02 \x01(\tR\x06roleId\x129\n" +
+	"\n" +
+	"valid_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x12;\n" +
+	"\vvalid_until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"granted_by\x18\x06 \x01(\tR\tgrantedBy\"\xda\x01\n" +
+	"\x16GrantRoleToUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\arole_id\x18\x02 \x01(\tR\x06roleId\x129\n" +
+	"\n" +
+	"valid_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x12;\n" +
+	"\vvalid_until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"D\n" +
+	"\x17GrantRoleToUserResponse\x12)\n" +
+	"\x05grant\x18\x01 \x01(\v2\x13.security.RoleGrantR\x05grant\"7\n" +
+	"\x1cListRoleGrantsForUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"L\n" +
+	"\x1dListRoleGrantsForUserResponse\x12+\n" +
+	"\x06grants\x18\x01 \x03(\v2\x13.security.RoleGrantR\x06grants\"N\n" +
 	"\rUserWithRoles\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
-	"\x05roles\x18\x02 \x03(\v2\x0e.security.RoleR\x05roles\"\x16\n" +
-	"\x14ListUsersFullRequest\"F\n" +
+	"\x05roles\x18\x02 \x03(\v2\x0e.security.RoleR\x05roles\"\x97\x02\n" +
+	"\x14ListUsersFullRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05after\x18\x04 \x01(\tR\x05after\x12\x17\n" +
+	"\arole_id\x18\x05 \x01(\tR\x06roleId\x12=\n" +
+	"\fcreated_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\"g\n" +
 	"\x15ListUsersFullResponse\x12-\n" +
-	"\x05users\x18\x01 \x03(\v2\x17.security.UserWithRolesR\x05users2\x94\r\n" +
+	"\x05users\x18\x01 \x03(\v2\x17.security.UserWithRolesR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor2\x95\x11\n" +
 	"\x0fSecurityService\x12Y\n" +
 	"\x10CreatePermission\x12!.security.CreatePermissionRequest\x1a\".security.CreatePermissionResponse\x12P\n" +
 	"\rGetPermission\x12\x1e.security.GetPermissionRequest\x1a\x1f.security.GetPermissionResponse\x12Y\n" +
@@ -2116,12 +2828,17 @@ const file_security_proto_rawDesc = "" +
 	"\tListRoles\x12\x1a.security.ListRolesRequest\x1a\x1b.security.ListRolesResponse\x12b\n" +
 	"\x13ListRolePermissions\x12$.security.ListRolePermissionsRequest\x1a%.security.ListRolePermissionsResponse\x12_\n" +
 	"\x12SetRolePermissions\x12#.security.SetRolePermissionsRequest\x1a$.security.SetRolePermissionsResponse\x12S\n" +
+	"\x0eSetRoleParents\x12\x1f.security.SetRoleParentsRequest\x1a .security.SetRoleParentsResponse\x12S\n" +
 	"\x0eAddUsersToRole\x12\x1f.security.AddUsersToRoleRequest\x1a .security.AddUsersToRoleResponse\x12b\n" +
 	"\x13RemoveUsersFromRole\x12$.security.RemoveUsersFromRoleRequest\x1a%.security.RemoveUsersFromRoleResponse\x12Y\n" +
 	"\x10ListUsersForRole\x12!.security.ListUsersForRoleRequest\x1a\".security.ListUsersForRoleResponse\x12V\n" +
 	"\x0fSetRolesForUser\x12 .security.SetRolesForUserRequest\x1a!.security.SetRolesForUserResponse\x12Y\n" +
 	"\x10ListRolesForUser\x12!.security.ListRolesForUserRequest\x1a\".security.ListRolesForUserResponse\x12\x86\x01\n" +
-	"\x1fListRolesWithPermissionsForUser\x120.security.ListRolesWithPermissionsForUserRequest\x1a1.security.ListRolesWithPermissionsForUserResponse\x12P\n" +
+	"\x1fListRolesWithPermissionsForUser\x120.security.ListRolesWithPermissionsForUserRequest\x1a1.security.ListRolesWithPermissionsForUserResponse\x12\x86\x01\n" +
+	"\x1fListEffectivePermissionsForUser\x120.security.ListEffectivePermissionsForUserRequest\x1a1.security.ListEffectivePermissionsForUserResponse\x12_\n" +
+	"\x12DeleteRolesForUser\x12#.security.DeleteRolesForUserRequest\x1a$.security.DeleteRolesForUserResponse\x12V\n" +
+	"\x0fGrantRoleToUser\x12 .security.GrantRoleToUserRequest\x1a!.security.GrantRoleToUserResponse\x12h\n" +
+	"\x15ListRoleGrantsForUser\x12&.security.ListRoleGrantsForUserRequest\x1a'.security.ListRoleGrantsForUserResponse\x12P\n" +
 	"\rListUsersFull\x12\x1e.security.ListUsersFullRequest\x1a\x1f.security.ListUsersFullResponseB\x0eZ\f./securitypbb\x06proto3"
 
 var (
@@ -2136,7 +2853,7 @@ func file_security_proto_rawDescGZIP() []byte {
 	return file_security_proto_rawDescData
 }
 
-var file_security_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
+var file_security_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_security_proto_goTypes = []any{
 	(*Permission)(nil),                              // 0: security.Permission
 	(*CreatePermissionRequest)(nil),                 // 1: security.CreatePermissionRequest
@@ -2165,21 +2882,33 @@ var file_security_proto_goTypes = []any{
 	(*ListRolePermissionsResponse)(nil),             // 24: security.ListRolePermissionsResponse
 	(*SetRolePermissionsRequest)(nil),               // 25: security.SetRolePermissionsRequest
 	(*SetRolePermissionsResponse)(nil),              // 26: security.SetRolePermissionsResponse
-	(*AddUsersToRoleRequest)(nil),                   // 27: security.AddUsersToRoleRequest
-	(*AddUsersToRoleResponse)(nil),                  // 28: security.AddUsersToRoleResponse
-	(*RemoveUsersFromRoleRequest)(nil),              // 29: security.RemoveUsersFromRoleRequest
-	(*RemoveUsersFromRoleResponse)(nil),             // 30: security.RemoveUsersFromRoleResponse
-	(*ListUsersForRoleRequest)(nil),                 // 31: security.ListUsersForRoleRequest
-	(*ListUsersForRoleResponse)(nil),                // 32: security.ListUsersForRoleResponse
-	(*SetRolesForUserRequest)(nil),                  // 33: security.SetRolesForUserRequest
-	(*SetRolesForUserResponse)(nil),                 // 34: security.SetRolesForUserResponse
-	(*ListRolesForUserRequest)(nil),                 // 35: security.ListRolesForUserRequest
-	(*ListRolesForUserResponse)(nil),                // 36: security.ListRolesForUserResponse
-	(*ListRolesWithPermissionsForUserRequest)(nil),  // 37: security.ListRolesWithPermissionsForUserRequest
-	(*ListRolesWithPermissionsForUserResponse)(nil), // 38: security.ListRolesWithPermissionsForUserResponse
-	(*UserWithRoles)(nil),                           // 39: security.UserWithRoles
-	(*ListUsersFullRequest)(nil),                    // 40: security.ListUsersFullRequest
-	(*ListUsersFullResponse)(nil),                   // 41: security.ListUsersFullResponse
+	(*SetRoleParentsRequest)(nil),                   // 27: security.SetRoleParentsRequest
+	(*SetRoleParentsResponse)(nil),                  // 28: security.SetRoleParentsResponse
+	(*AddUsersToRoleRequest)(nil),                   // 29: security.AddUsersToRoleRequest
+	(*AddUsersToRoleResponse)(nil),                  // 30: security.AddUsersToRoleResponse
+	(*RemoveUsersFromRoleRequest)(nil),              // 31: security.RemoveUsersFromRoleRequest
+	(*RemoveUsersFromRoleResponse)(nil),             // 32: security.RemoveUsersFromRoleResponse
+	(*ListUsersForRoleRequest)(nil),                 // 33: security.ListUsersForRoleRequest
+	(*ListUsersForRoleResponse)(nil),                // 34: security.ListUsersForRoleResponse
+	(*SetRolesForUserRequest)(nil),                  // 35: security.SetRolesForUserRequest
+	(*SetRolesForUserResponse)(nil),                 // 36: security.SetRolesForUserResponse
+	(*ListRolesForUserRequest)(nil),                 // 37: security.ListRolesForUserRequest
+	(*ListRolesForUserResponse)(nil),                // 38: security.ListRolesForUserResponse
+	(*ListRolesWithPermissionsForUserRequest)(nil),  // 39: security.ListRolesWithPermissionsForUserRequest
+	(*ListRolesWithPermissionsForUserResponse)(nil), // 40: security.ListRolesWithPermissionsForUserResponse
+	(*ListEffectivePermissionsForUserRequest)(nil),  // 41: security.ListEffectivePermissionsForUserRequest
+	(*ListEffectivePermissionsForUserResponse)(nil), // 42: security.ListEffectivePermissionsForUserResponse
+	(*DeleteRolesForUserRequest)(nil),               // 43: security.DeleteRolesForUserRequest
+	(*DeleteRolesForUserResponse)(nil),              // 44: security.DeleteRolesForUserResponse
+	(*RoleGrant)(nil),                               // 45: security.RoleGrant
+	(*GrantRoleToUserRequest)(nil),                  // 46: security.GrantRoleToUserRequest
+	(*GrantRoleToUserResponse)(nil),                 // 47: security.GrantRoleToUserResponse
+	(*ListRoleGrantsForUserRequest)(nil),            // 48: security.ListRoleGrantsForUserRequest
+	(*ListRoleGrantsForUserResponse)(nil),           // 49: security.ListRoleGrantsForUserResponse
+	(*UserWithRoles)(nil),                           // 50: security.UserWithRoles
+	(*ListUsersFullRequest)(nil),                    // 51: security.ListUsersFullRequest
+	(*ListUsersFullResponse)(nil),                   // 52: security.ListUsersFullResponse
+	(*timestamppb.Timestamp)(nil),                   // 53: google.protobuf.Timestamp
 }
 var file_security_proto_depIdxs = []int32{
 	0,  // 0: security.CreatePermissionResponse.permission:type_name -> security.Permission
@@ -2188,60 +2917,82 @@ var file_security_proto_depIdxs = []int32{
 	0,  // 3: security.ListPermissionsResponse.permissions:type_name -> security.Permission
 	11, // 4: security.RoleWithPermissions.role:type_name -> security.Role
 	0,  // 5: security.RoleWithPermissions.permissions:type_name -> security.Permission
-	12, // 6: security.CreateRoleResponse.role:type_name -> security.RoleWithPermissions
-	12, // 7: security.GetRoleResponse.role:type_name -> security.RoleWithPermissions
-	12, // 8: security.UpdateRoleResponse.role:type_name -> security.RoleWithPermissions
-	12, // 9: security.ListRolesResponse.roles:type_name -> security.RoleWithPermissions
-	0,  // 10: security.ListRolePermissionsResponse.permissions:type_name -> security.Permission
-	0,  // 11: security.SetRolePermissionsResponse.permissions:type_name -> security.Permission
-	12, // 12: security.SetRolesForUserResponse.roles:type_name -> security.RoleWithPermissions
-	11, // 13: security.ListRolesForUserResponse.roles:type_name -> security.Role
-	12, // 14: security.ListRolesWithPermissionsForUserResponse.roles:type_name -> security.RoleWithPermissions
-	11, // 15: security.UserWithRoles.roles:type_name -> security.Role
-	39, // 16: security.ListUsersFullResponse.users:type_name -> security.UserWithRoles
-	1,  // 17: security.SecurityService.CreatePermission:input_type -> security.CreatePermissionRequest
-	3,  // 18: security.SecurityService.GetPermission:input_type -> security.GetPermissionRequest
-	5,  // 19: security.SecurityService.UpdatePermission:input_type -> security.UpdatePermissionRequest
-	7,  // 20: security.SecurityService.DeletePermission:input_type -> security.DeletePermissionRequest
-	9,  // 21: security.SecurityService.ListPermissions:input_type -> security.ListPermissionsRequest
-	13, // 22: security.SecurityService.CreateRole:input_type -> security.CreateRoleRequest
-	15, // 23: security.SecurityService.GetRole:input_type -> security.GetRoleRequest
-	17, // 24: security.SecurityService.UpdateRole:input_type -> security.UpdateRoleRequest
-	19, // 25: security.SecurityService.DeleteRole:input_type -> security.DeleteRoleRequest
-	21, // 26: security.SecurityService.ListRoles:input_type -> security.ListRolesRequest
-	23, // 27: security.SecurityService.ListRolePermissions:input_type -> security.ListRolePermissionsRequest
-	25, // 28: security.SecurityService.SetRolePermissions:input_type -> security.SetRolePermissionsRequest
-	27, // 29: security.SecurityService.AddUsersToRole:input_type -> security.AddUsersToRoleRequest
-	29, // 30: security.SecurityService.RemoveUsersFromRole:input_type -> security.RemoveUsersFromRoleRequest
-	31, // 31: security.SecurityService.ListUsersForRole:input_type -> security.ListUsersForRoleRequest
-	33, // 32: security.SecurityService.SetRolesForUser:input_type -> security.SetRolesForUserRequest
-	35, // 33: security.SecurityService.ListRolesForUser:input_type -> security.ListRolesForUserRequest
-	37, // 34: security.SecurityService.ListRolesWithPermissionsForUser:input_type -> security.ListRolesWithPermissionsForUserRequest
-	40, // 35: security.SecurityService.ListUsersFull:input_type -> security.ListUsersFullRequest
-	2,  // 36: security.SecurityService.CreatePermission:output_type -> security.CreatePermissionResponse
-	4,  // 37: security.SecurityService.GetPermission:output_type -> security.GetPermissionResponse
-	6,  // 38: security.SecurityService.UpdatePermission:output_type -> security.UpdatePermissionResponse
-	8,  // 39: security.SecurityService.DeletePermission:output_type -> security.DeletePermissionResponse
-	10, // 40: security.SecurityService.ListPermissions:output_type -> security.ListPermissionsResponse
-	14, // 41: security.SecurityService.CreateRole:output_type -> security.CreateRoleResponse
-	16, // 42: security.SecurityService.GetRole:output_type -> security.GetRoleResponse
-	18, // 43: security.SecurityService.UpdateRole:output_type -> security.UpdateRoleResponse
-	20, // 44: security.SecurityService.DeleteRole:output_type -> security.DeleteRoleResponse
-	22, // 45: security.SecurityService.ListRoles:output_type -> security.ListRolesResponse
-	24, // 46: security.SecurityService.ListRolePermissions:output_type -> security.ListRolePermissionsResponse
-	26, // 47: security.SecurityService.SetRolePermissions:output_type -> security.SetRolePermissionsResponse
-	28, // 48: security.SecurityService.AddUsersToRole:output_type -> security.AddUsersToRoleResponse
-	30, // 49: security.SecurityService.RemoveUsersFromRole:output_type -> security.RemoveUsersFromRoleResponse
-	32, // 50: security.SecurityService.ListUsersForRole:output_type -> security.ListUsersForRoleResponse
-	34, // 51: security.SecurityService.SetRolesForUser:output_type -> security.SetRolesForUserResponse
-	36, // 52: security.SecurityService.ListRolesForUser:output_type -> security.ListRolesForUserResponse
-	38, // 53: security.SecurityService.ListRolesWithPermissionsForUser:output_type -> security.ListRolesWithPermissionsForUserResponse
-	41, // 54: security.SecurityService.ListUsersFull:output_type -> security.ListUsersFullResponse
-	36, // [36:55] is the sub-list for method output_type
-	17, // [17:36] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	11, // 6: security.RoleWithPermissions.parents:type_name -> security.Role
+	0,  // 7: security.RoleWithPermissions.inherited_permissions:type_name -> security.Permission
+	12, // 8: security.CreateRoleResponse.role:type_name -> security.RoleWithPermissions
+	12, // 9: security.GetRoleResponse.role:type_name -> security.RoleWithPermissions
+	12, // 10: security.UpdateRoleResponse.role:type_name -> security.RoleWithPermissions
+	12, // 11: security.ListRolesResponse.roles:type_name -> security.RoleWithPermissions
+	0,  // 12: security.ListRolePermissionsResponse.permissions:type_name -> security.Permission
+	0,  // 13: security.SetRolePermissionsResponse.permissions:type_name -> security.Permission
+	12, // 14: security.SetRoleParentsResponse.role:type_name -> security.RoleWithPermissions
+	12, // 15: security.SetRolesForUserResponse.roles:type_name -> security.RoleWithPermissions
+	11, // 16: security.ListRolesForUserResponse.roles:type_name -> security.Role
+	12, // 17: security.ListRolesWithPermissionsForUserResponse.roles:type_name -> security.RoleWithPermissions
+	0,  // 18: security.ListEffectivePermissionsForUserResponse.permissions:type_name -> security.Permission
+	53, // 19: security.RoleGrant.valid_from:type_name -> google.protobuf.Timestamp
+	53, // 20: security.RoleGrant.valid_until:type_name -> google.protobuf.Timestamp
+	53, // 21: security.GrantRoleToUserRequest.valid_from:type_name -> google.protobuf.Timestamp
+	53, // 22: security.GrantRoleToUserRequest.valid_until:type_name -> google.protobuf.Timestamp
+	45, // 23: security.GrantRoleToUserResponse.grant:type_name -> security.RoleGrant
+	45, // 24: security.ListRoleGrantsForUserResponse.grants:type_name -> security.RoleGrant
+	11, // 25: security.UserWithRoles.roles:type_name -> security.Role
+	53, // 26: security.ListUsersFullRequest.created_from:type_name -> google.protobuf.Timestamp
+	53, // 27: security.ListUsersFullRequest.created_to:type_name -> google.protobuf.Timestamp
+	50, // 28: security.ListUsersFullResponse.users:type_name -> security.UserWithRoles
+	1,  // 29: security.SecurityService.CreatePermission:input_type -> security.CreatePermissionRequest
+	3,  // 30: security.SecurityService.GetPermission:input_type -> security.GetPermissionRequest
+	5,  // 31: security.SecurityService.UpdatePermission:input_type -> security.UpdatePermissionRequest
+	7,  // 32: security.SecurityService.DeletePermission:input_type -> security.DeletePermissionRequest
+	9,  // 33: security.SecurityService.ListPermissions:input_type -> security.ListPermissionsRequest
+	13, // 34: security.SecurityService.CreateRole:input_type -> security.CreateRoleRequest
+	15, // 35: security.SecurityService.GetRole:input_type -> security.GetRoleRequest
+	17, // 36: security.SecurityService.UpdateRole:input_type -> security.UpdateRoleRequest
+	19, // 37: security.SecurityService.DeleteRole:input_type -> security.DeleteRoleRequest
+	21, // 38: security.SecurityService.ListRoles:input_type -> security.ListRolesRequest
+	23, // 39: security.SecurityService.ListRolePermissions:input_type -> security.ListRolePermissionsRequest
+	25, // 40: security.SecurityService.SetRolePermissions:input_type -> security.SetRolePermissionsRequest
+	27, // 41: security.SecurityService.SetRoleParents:input_type -> security.SetRoleParentsRequest
+	29, // 42: security.SecurityService.AddUsersToRole:input_type -> security.AddUsersToRoleRequest
+	31, // 43: security.SecurityService.RemoveUsersFromRole:input_type -> security.RemoveUsersFromRoleRequest
+	33, // 44: security.SecurityService.ListUsersForRole:input_type -> security.ListUsersForRoleRequest
+	35, // 45: security.SecurityService.SetRolesForUser:input_type -> security.SetRolesForUserRequest
+	37, // 46: security.SecurityService.ListRolesForUser:input_type -> security.ListRolesForUserRequest
+	39, // 47: security.SecurityService.ListRolesWithPermissionsForUser:input_type -> security.ListRolesWithPermissionsForUserRequest
+	41, // 48: security.SecurityService.ListEffectivePermissionsForUser:input_type -> security.ListEffectivePermissionsForUserRequest
+	43, // 49: security.SecurityService.DeleteRolesForUser:input_type -> security.DeleteRolesForUserRequest
+	46, // 50: security.SecurityService.GrantRoleToUser:input_type -> security.GrantRoleToUserRequest
+	48, // 51: security.SecurityService.ListRoleGrantsForUser:input_type -> security.ListRoleGrantsForUserRequest
+	51, // 52: security.SecurityService.ListUsersFull:input_type -> security.ListUsersFullRequest
+	2,  // 53: security.SecurityService.CreatePermission:output_type -> security.CreatePermissionResponse
+	4,  // 54: security.SecurityService.GetPermission:output_type -> security.GetPermissionResponse
+	6,  // 55: security.SecurityService.UpdatePermission:output_type -> security.UpdatePermissionResponse
+	8,  // 56: security.SecurityService.DeletePermission:output_type -> security.DeletePermissionResponse
+	10, // 57: security.SecurityService.ListPermissions:output_type -> security.ListPermissionsResponse
+	14, // 58: security.SecurityService.CreateRole:output_type -> security.CreateRoleResponse
+	16, // 59: security.SecurityService.GetRole:output_type -> security.GetRoleResponse
+	18, // 60: security.SecurityService.UpdateRole:output_type -> security.UpdateRoleResponse
+	20, // 61: security.SecurityService.DeleteRole:output_type -> security.DeleteRoleResponse
+	22, // 62: security.SecurityService.ListRoles:output_type -> security.ListRolesResponse
+	24, // 63: security.SecurityService.ListRolePermissions:output_type -> security.ListRolePermissionsResponse
+	26, // 64: security.SecurityService.SetRolePermissions:output_type -> security.SetRolePermissionsResponse
+	28, // 65: security.SecurityService.SetRoleParents:output_type -> security.SetRoleParentsResponse
+	30, // 66: security.SecurityService.AddUsersToRole:output_type -> security.AddUsersToRoleResponse
+	32, // 67: security.SecurityService.RemoveUsersFromRole:output_type -> security.RemoveUsersFromRoleResponse
+	34, // 68: security.SecurityService.ListUsersForRole:output_type -> security.ListUsersForRoleResponse
+	36, // 69: security.SecurityService.SetRolesForUser:output_type -> security.SetRolesForUserResponse
+	38, // 70: security.SecurityService.ListRolesForUser:output_type -> security.ListRolesForUserResponse
+	40, // 71: security.SecurityService.ListRolesWithPermissionsForUser:output_type -> security.ListRolesWithPermissionsForUserResponse
+	42, // 72: security.SecurityService.ListEffectivePermissionsForUser:output_type -> security.ListEffectivePermissionsForUserResponse
+	44, // 73: security.SecurityService.DeleteRolesForUser:output_type -> security.DeleteRolesForUserResponse
+	47, // 74: security.SecurityService.GrantRoleToUser:output_type -> security.GrantRoleToUserResponse
+	49, // 75: security.SecurityService.ListRoleGrantsForUser:output_type -> security.ListRoleGrantsForUserResponse
+	52, // 76: security.SecurityService.ListUsersFull:output_type -> security.ListUsersFullResponse
+	53, // [53:77] is the sub-list for method output_type
+	29, // [29:53] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_security_proto_init() }
@@ -2255,7 +3006,7 @@ func file_security_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_security_proto_rawDesc), len(file_security_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   42,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -31,12 +31,17 @@ const (
 	SecurityService_ListRoles_FullMethodName                       = "/security.SecurityService/ListRoles"
 	SecurityService_ListRolePermissions_FullMethodName             = "/security.SecurityService/ListRolePermissions"
 	SecurityService_SetRolePermissions_FullMethodName              = "/security.SecurityService/SetRolePermissions"
+	SecurityService_SetRoleParents_FullMethodName                  = "/security.SecurityService/SetRoleParents"
 	SecurityService_AddUsersToRole_FullMethodName                  = "/security.SecurityService/AddUsersToRole"
 	SecurityService_RemoveUsersFromRole_FullMethodName             = "/security.SecurityService/RemoveUsersFromRole"
 	SecurityService_ListUsersForRole_FullMethodName                = "/security.SecurityService/ListUsersForRole"
 	SecurityService_SetRolesForUser_FullMethodName                 = "/security.SecurityService/SetRolesForUser"
 	SecurityService_ListRolesForUser_FullMethodName                = "/security.SecurityService/ListRolesForUser"
 	SecurityService_ListRolesWithPermissionsForUser_FullMethodName = "/security.SecurityService/ListRolesWithPermissionsForUser"
+	SecurityService_ListEffectivePermissionsForUser_FullMethodName = "/security.SecurityService/ListEffectivePermissionsForUser"
+	SecurityService_DeleteRolesForUser_FullMethodName              = "/security.SecurityService/DeleteRolesForUser"
+	SecurityService_GrantRoleToUser_FullMethodName                 = "/security.SecurityService/GrantRoleToUser"
+	SecurityService_ListRoleGrantsForUser_FullMethodName           = "/security.SecurityService/ListRoleGrantsForUser"
 	SecurityService_ListUsersFull_FullMethodName                   = "/security.SecurityService/ListUsersFull"
 )
 
@@ -59,6 +64,8 @@ type SecurityServiceClient interface {
 	// Role-Permission management
 	ListRolePermissions(ctx context.Context, in *ListRolePermissionsRequest, opts ...grpc.CallOption) (*ListRolePermissionsResponse, error)
 	SetRolePermissions(ctx context.Context, in *SetRolePermissionsRequest, opts ...grpc.CallOption) (*SetRolePermissionsResponse, error)
+	// Role-Parents management
+	SetRoleParents(ctx context.Context, in *SetRoleParentsRequest, opts ...grpc.CallOption) (*SetRoleParentsResponse, error)
 	// Role-Users management
 	AddUsersToRole(ctx context.Context, in *AddUsersToRoleRequest, opts ...grpc.CallOption) (*AddUsersToRoleResponse, error)
 	RemoveUsersFromRole(ctx context.Context, in *RemoveUsersFromRoleRequest, opts ...grpc.CallOption) (*RemoveUsersFromRoleResponse, error)
@@ -67,6 +74,11 @@ type SecurityServiceClient interface {
 	SetRolesForUser(ctx context.Context, in *SetRolesForUserRequest, opts ...grpc.CallOption) (*SetRolesForUserResponse, error)
 	ListRolesForUser(ctx context.Context, in *ListRolesForUserRequest, opts ...grpc.CallOption) (*ListRolesForUserResponse, error)
 	ListRolesWithPermissionsForUser(ctx context.Context, in *ListRolesWithPermissionsForUserRequest, opts ...grpc.CallOption) (*ListRolesWithPermissionsForUserResponse, error)
+	ListEffectivePermissionsForUser(ctx context.Context, in *ListEffectivePermissionsForUserRequest, opts ...grpc.CallOption) (*ListEffectivePermissionsForUserResponse, error)
+	DeleteRolesForUser(ctx context.Context, in *DeleteRolesForUserRequest, opts ...grpc.CallOption) (*DeleteRolesForUserResponse, error)
+	// User-Role grants management
+	GrantRoleToUser(ctx context.Context, in *GrantRoleToUserRequest, opts ...grpc.CallOption) (*GrantRoleToUserResponse, error)
+	ListRoleGrantsForUser(ctx context.Context, in *ListRoleGrantsForUserRequest, opts ...grpc.CallOption) (*ListRoleGrantsForUserResponse, error)
 	// Users-Roles management
 	ListUsersFull(ctx context.Context, in *ListUsersFullRequest, opts ...grpc.CallOption) (*ListUsersFullResponse, error)
 }
@@ -199,6 +211,16 @@ func (c *securityServiceClient) SetRolePermissions(ctx context.Context, in *SetR
 	return out, nil
 }
 
+func (c *securityServiceClient) SetRoleParents(ctx context.Context, in *SetRoleParentsRequest, opts ...grpc.CallOption) (*SetRoleParentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRoleParentsResponse)
+	err := c.cc.Invoke(ctx, SecurityService_SetRoleParents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *securityServiceClient) AddUsersToRole(ctx context.Context, in *AddUsersToRoleRequest, opts ...grpc.CallOption) (*AddUsersToRoleResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddUsersToRoleResponse)
@@ -259,6 +281,46 @@ func (c *securityServiceClient) ListRolesWithPermissionsForUser(ctx context.Cont
 	return out, nil
 }
 
+func (c *securityServiceClient) ListEffectivePermissionsForUser(ctx context.Context, in *ListEffectivePermissionsForUserRequest, opts ...grpc.CallOption) (*ListEffectivePermissionsForUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEffectivePermissionsForUserResponse)
+	err := c.cc.Invoke(ctx, SecurityService_ListEffectivePermissionsForUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *securityServiceClient) DeleteRolesForUser(ctx context.Context, in *DeleteRolesForUserRequest, opts ...grpc.CallOption) (*DeleteRolesForUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRolesForUserResponse)
+	err := c.cc.Invoke(ctx, SecurityService_DeleteRolesForUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *securityServiceClient) GrantRoleToUser(ctx context.Context, in *GrantRoleToUserRequest, opts ...grpc.CallOption) (*GrantRoleToUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantRoleToUserResponse)
+	err := c.cc.Invoke(ctx, SecurityService_GrantRoleToUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *securityServiceClient) ListRoleGrantsForUser(ctx context.Context, in *ListRoleGrantsForUserRequest, opts ...grpc.CallOption) (*ListRoleGrantsForUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoleGrantsForUserResponse)
+	err := c.cc.Invoke(ctx, SecurityService_ListRoleGrantsForUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *securityServiceClient) ListUsersFull(ctx context.Context, in *ListUsersFullRequest, opts ...grpc.CallOption) (*ListUsersFullResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListUsersFullResponse)
@@ -288,6 +350,8 @@ type SecurityServiceServer interface {
 	// Role-Permission management
 	ListRolePermissions(context.Context, *ListRolePermissionsRequest) (*ListRolePermissionsResponse, error)
 	SetRolePermissions(context.Context, *SetRolePermissionsRequest) (*SetRolePermissionsResponse, error)
+	// Role-Parents management
+	SetRoleParents(context.Context, *SetRoleParentsRequest) (*SetRoleParentsResponse, error)
 	// Role-Users management
 	AddUsersToRole(context.Context, *AddUsersToRoleRequest) (*AddUsersToRoleResponse, error)
 	RemoveUsersFromRole(context.Context, *RemoveUsersFromRoleRequest) (*RemoveUsersFromRoleResponse, error)
@@ -296,6 +360,11 @@ type SecurityServiceServer interface {
 	SetRolesForUser(context.Context, *SetRolesForUserRequest) (*SetRolesForUserResponse, error)
 	ListRolesForUser(context.Context, *ListRolesForUserRequest) (*ListRolesForUserResponse, error)
 	ListRolesWithPermissionsForUser(context.Context, *ListRolesWithPermissionsForUserRequest) (*ListRolesWithPermissionsForUserResponse, error)
+	ListEffectivePermissionsForUser(context.Context, *ListEffectivePermissionsForUserRequest) (*ListEffectivePermissionsForUserResponse, error)
+	DeleteRolesForUser(context.Context, *DeleteRolesForUserRequest) (*DeleteRolesForUserResponse, error)
+	// User-Role grants management
+	GrantRoleToUser(context.Context, *GrantRoleToUserRequest) (*GrantRoleToUserResponse, error)
+	ListRoleGrantsForUser(context.Context, *ListRoleGrantsForUserRequest) (*ListRoleGrantsForUserResponse, error)
 	// Users-Roles management
 	ListUsersFull(context.Context, *ListUsersFullRequest) (*ListUsersFullResponse, error)
 	mustEmbedUnimplementedSecurityServiceServer()
@@ -344,6 +413,9 @@ func (UnimplementedSecurityServiceServer) ListRolePermissions(context.Context, *
 func (UnimplementedSecurityServiceServer) SetRolePermissions(context.Context, *SetRolePermissionsRequest) (*SetRolePermissionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetRolePermissions not implemented")
 }
+func (UnimplementedSecurityServiceServer) SetRoleParents(context.Context, *SetRoleParentsRequest) (*SetRoleParentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoleParents not implemented")
+}
 func (UnimplementedSecurityServiceServer) AddUsersToRole(context.Context, *AddUsersToRoleRequest) (*AddUsersToRoleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddUsersToRole not implemented")
 }
@@ -362,6 +434,18 @@ func (UnimplementedSecurityServiceServer) ListRolesForUser(context.Context, *Lis
 func (UnimplementedSecurityServiceServer) ListRolesWithPermissionsForUser(context.Context, *ListRolesWithPermissionsForUserRequest) (*ListRolesWithPermissionsForUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRolesWithPermissionsForUser not implemented")
 }
+func (UnimplementedSecurityServiceServer) ListEffectivePermissionsForUser(context.Context, *ListEffectivePermissionsForUserRequest) (*ListEffectivePermissionsForUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEffectivePermissionsForUser not implemented")
+}
+func (UnimplementedSecurityServiceServer) DeleteRolesForUser(context.Context, *DeleteRolesForUserRequest) (*DeleteRolesForUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRolesForUser not implemented")
+}
+func (UnimplementedSecurityServiceServer) GrantRoleToUser(context.Context, *GrantRoleToUserRequest) (*GrantRoleToUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantRoleToUser not implemented")
+}
+func (UnimplementedSecurityServiceServer) ListRoleGrantsForUser(context.Context, *ListRoleGrantsForUserRequest) (*ListRoleGrantsForUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleGrantsForUser not implemented")
+}
 func (UnimplementedSecurityServiceServer) ListUsersFull(context.Context, *ListUsersFullRequest) (*ListUsersFullResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUsersFull not implemented")
 }
@@ -602,6 +686,24 @@ func _SecurityService_SetRolePermissions_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SecurityService_SetRoleParents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoleParentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityServiceServer).SetRoleParents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecurityService_SetRoleParents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityServiceServer).SetRoleParents(ctx, req.(*SetRoleParentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SecurityService_AddUsersToRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddUsersToRoleRequest)
 	if err := dec(in); err != nil {
@@ -710,6 +812,78 @@ func _SecurityService_ListRolesWithPermissionsForUser_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SecurityService_ListEffectivePermissionsForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEffectivePermissionsForUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityServiceServer).ListEffectivePermissionsForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecurityService_ListEffectivePermissionsForUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityServiceServer).ListEffectivePermissionsForUser(ctx, req.(*ListEffectivePermissionsForUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecurityService_DeleteRolesForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRolesForUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityServiceServer).DeleteRolesForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecurityService_DeleteRolesForUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityServiceServer).DeleteRolesForUser(ctx, req.(*DeleteRolesForUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecurityService_GrantRoleToUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantRoleToUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityServiceServer).GrantRoleToUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecurityService_GrantRoleToUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityServiceServer).GrantRoleToUser(ctx, req.(*GrantRoleToUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecurityService_ListRoleGrantsForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleGrantsForUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityServiceServer).ListRoleGrantsForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecurityService_ListRoleGrantsForUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityServiceServer).ListRoleGrantsForUser(ctx, req.(*ListRoleGrantsForUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SecurityService_ListUsersFull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListUsersFullRequest)
 	if err := dec(in); err != nil {
@@ -783,6 +957,10 @@ var SecurityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetRolePermissions",
 			Handler:    _SecurityService_SetRolePermissions_Handler,
 		},
+		{
+			MethodName: "SetRoleParents",
+			Handler:    _SecurityService_SetRoleParents_Handler,
+		},
 		{
 			MethodName: "AddUsersToRole",
 			Handler:    _SecurityService_AddUsersToRole_Handler,
@@ -807,6 +985,22 @@ var SecurityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListRolesWithPermissionsForUser",
 			Handler:    _SecurityService_ListRolesWithPermissionsForUser_Handler,
 		},
+		{
+			MethodName: "ListEffectivePermissionsForUser",
+			Handler:    _SecurityService_ListEffectivePermissionsForUser_Handler,
+		},
+		{
+			MethodName: "DeleteRolesForUser",
+			Handler:    _SecurityService_DeleteRolesForUser_Handler,
+		},
+		{
+			MethodName: "GrantRoleToUser",
+			Handler:    _SecurityService_GrantRoleToUser_Handler,
+		},
+		{
+			MethodName: "ListRoleGrantsForUser",
+			Handler:    _SecurityService_ListRoleGrantsForUser_Handler,
+		},
 		{
 			MethodName: "ListUsersFull",
 			Handler:    _SecurityService_ListUsersFull_Handler,
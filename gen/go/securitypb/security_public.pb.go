@@ -117,6 +117,302 @@ func (x *CheckPermissionResponse) GetHasPermission() bool {
 	return false
 }
 
+type CheckPermissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Permissions   []string               `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPermissionsRequest) Reset() {
+	*x = CheckPermissionsRequest{}
+	mi := &file_security_public_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPermissionsRequest) ProtoMessage() {}
+
+func (x *CheckPermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_security_public_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPermissionsRequest.ProtoReflect.Descriptor instead.
+func (*CheckPermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_security_public_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckPermissionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckPermissionsRequest) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type CheckPermissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Permissions   map[string]bool        `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPermissionsResponse) Reset() {
+	*x = CheckPermissionsResponse{}
+	mi := &file_security_public_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPermissionsResponse) ProtoMessage() {}
+
+func (x *CheckPermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_security_public_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPermissionsResponse.ProtoReflect.Descriptor instead.
+func (*CheckPermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_security_public_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckPermissionsResponse) GetPermissions() map[string]bool {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type CheckResourceAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	ResourceType  string                 `protobuf:"bytes,2,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResourceAccessRequest) Reset() {
+	*x = CheckResourceAccessRequest{}
+	mi := &file_security_public_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResourceAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResourceAccessRequest) ProtoMessage() {}
+
+func (x *CheckResourceAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_security_public_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResourceAccessRequest.ProtoReflect.Descriptor instead.
+func (*CheckResourceAccessRequest) Descriptor() ([]byte, []int) {
+	return file_security_public_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckResourceAccessRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *CheckResourceAccessRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *CheckResourceAccessRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *CheckResourceAccessRequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type CheckResourceAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResourceAccessResponse) Reset() {
+	*x = CheckResourceAccessResponse{}
+	mi := &file_security_public_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResourceAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResourceAccessResponse) ProtoMessage() {}
+
+func (x *CheckResourceAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_security_public_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResourceAccessResponse.ProtoReflect.Descriptor instead.
+func (*CheckResourceAccessResponse) Descriptor() ([]byte, []int) {
+	return file_security_public_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CheckResourceAccessResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+type CheckImpersonationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckImpersonationRequest) Reset() {
+	*x = CheckImpersonationRequest{}
+	mi := &file_security_public_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckImpersonationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckImpersonationRequest) ProtoMessage() {}
+
+func (x *CheckImpersonationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_security_public_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckImpersonationRequest.ProtoReflect.Descriptor instead.
+func (*CheckImpersonationRequest) Descriptor() ([]byte, []int) {
+	return file_security_public_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CheckImpersonationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CheckImpersonationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckImpersonationResponse) Reset() {
+	*x = CheckImpersonationResponse{}
+	mi := &file_security_public_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckImpersonationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckImpersonationResponse) ProtoMessage() {}
+
+func (x *CheckImpersonationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_security_public_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckImpersonationResponse.ProtoReflect.Descriptor instead.
+func (*CheckImpersonationResponse) Descriptor() ([]byte, []int) {
+	return file_security_public_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CheckImpersonationResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
 var File_security_public_proto protoreflect.FileDescriptor
 
 const file_security_public_proto_rawDesc = "" +
@@ -128,9 +424,36 @@ const file_security_public_proto_rawDesc = "" +
 	"permission\x18\x02 \x01(\tR\n" +
 	"permission\"@\n" +
 	"\x17CheckPermissionResponse\x12%\n" +
-	"\x0ehas_permission\x18\x01 \x01(\bR\rhasPermission2o\n" +
+	"\x0ehas_permission\x18\x01 \x01(\bR\rhasPermission\"T\n" +
+	"\x17CheckPermissionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12 \n" +
+	"\vpermissions\x18\x02 \x03(\tR\vpermissions\"\xb1\x01\n" +
+	"\x18CheckPermissionsResponse\x12U\n" +
+	"\vpermissions\x18\x01 \x03(\v23.security.CheckPermissionsResponse.PermissionsEntryR\vpermissions\x1a>\n" +
+	"\x10PermissionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"\x89\x02\n" +
+	"\x1aCheckResourceAccessRequest\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12#\n" +
+	"\rresource_type\x18\x02 \x01(\tR\fresourceType\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\tR\aownerId\x12T\n" +
+	"\n" +
+	"attributes\x18\x04 \x03(\v24.security.CheckResourceAccessRequest.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"7\n" +
+	"\x1bCheckResourceAccessResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\"4\n" +
+	"\x19CheckImpersonationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"6\n" +
+	"\x1aCheckImpersonationResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed2\x8f\x03\n" +
 	"\x15PublicSecurityService\x12V\n" +
-	"\x0fCheckPermission\x12 .security.CheckPermissionRequest\x1a!.security.CheckPermissionResponseB\x0eZ\f./securitypbb\x06proto3"
+	"\x0fCheckPermission\x12 .security.CheckPermissionRequest\x1a!.security.CheckPermissionResponse\x12Y\n" +
+	"\x10CheckPermissions\x12!.security.CheckPermissionsRequest\x1a\".security.CheckPermissionsResponse\x12b\n" +
+	"\x13CheckResourceAccess\x12$.security.CheckResourceAccessRequest\x1a%.security.CheckResourceAccessResponse\x12_\n" +
+	"\x12CheckImpersonation\x12#.security.CheckImpersonationRequest\x1a$.security.CheckImpersonationResponseB\x0eZ\f./securitypbb\x06proto3"
 
 var (
 	file_security_public_proto_rawDescOnce sync.Once
@@ -144,19 +467,35 @@ func file_security_public_proto_rawDescGZIP() []byte {
 	return file_security_public_proto_rawDescData
 }
 
-var file_security_public_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_security_public_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_security_public_proto_goTypes = []any{
-	(*CheckPermissionRequest)(nil),  // 0: security.CheckPermissionRequest
-	(*CheckPermissionResponse)(nil), // 1: security.CheckPermissionResponse
+	(*CheckPermissionRequest)(nil),      // 0: security.CheckPermissionRequest
+	(*CheckPermissionResponse)(nil),     // 1: security.CheckPermissionResponse
+	(*CheckPermissionsRequest)(nil),     // 2: security.CheckPermissionsRequest
+	(*CheckPermissionsResponse)(nil),    // 3: security.CheckPermissionsResponse
+	(*CheckResourceAccessRequest)(nil),  // 4: security.CheckResourceAccessRequest
+	(*CheckResourceAccessResponse)(nil), // 5: security.CheckResourceAccessResponse
+	(*CheckImpersonationRequest)(nil),   // 6: security.CheckImpersonationRequest
+	(*CheckImpersonationResponse)(nil),  // 7: security.CheckImpersonationResponse
+	nil,                                 // 8: security.CheckPermissionsResponse.PermissionsEntry
+	nil,                                 // 9: security.CheckResourceAccessRequest.AttributesEntry
 }
 var file_security_public_proto_depIdxs = []int32{
-	0, // 0: security.PublicSecurityService.CheckPermission:input_type -> security.CheckPermissionRequest
-	1, // 1: security.PublicSecurityService.CheckPermission:output_type -> security.CheckPermissionResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	8, // 0: security.CheckPermissionsResponse.permissions:type_name -> security.CheckPermissionsResponse.PermissionsEntry
+	9, // 1: security.CheckResourceAccessRequest.attributes:type_name -> security.CheckResourceAccessRequest.AttributesEntry
+	0, // 2: security.PublicSecurityService.CheckPermission:input_type -> security.CheckPermissionRequest
+	2, // 3: security.PublicSecurityService.CheckPermissions:input_type -> security.CheckPermissionsRequest
+	4, // 4: security.PublicSecurityService.CheckResourceAccess:input_type -> security.CheckResourceAccessRequest
+	6, // 5: security.PublicSecurityService.CheckImpersonation:input_type -> security.CheckImpersonationRequest
+	1, // 6: security.PublicSecurityService.CheckPermission:output_type -> security.CheckPermissionResponse
+	3, // 7: security.PublicSecurityService.CheckPermissions:output_type -> security.CheckPermissionsResponse
+	5, // 8: security.PublicSecurityService.CheckResourceAccess:output_type -> security.CheckResourceAccessResponse
+	7, // 9: security.PublicSecurityService.CheckImpersonation:output_type -> security.CheckImpersonationResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_security_public_proto_init() }
@@ -170,7 +509,7 @@ func file_security_public_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_security_public_proto_rawDesc), len(file_security_public_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
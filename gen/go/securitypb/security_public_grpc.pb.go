@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PublicSecurityService_CheckPermission_FullMethodName = "/security.PublicSecurityService/CheckPermission"
+	PublicSecurityService_CheckPermission_FullMethodName     = "/security.PublicSecurityService/CheckPermission"
+	PublicSecurityService_CheckPermissions_FullMethodName    = "/security.PublicSecurityService/CheckPermissions"
+	PublicSecurityService_CheckResourceAccess_FullMethodName = "/security.PublicSecurityService/CheckResourceAccess"
+	PublicSecurityService_CheckImpersonation_FullMethodName  = "/security.PublicSecurityService/CheckImpersonation"
 )
 
 // PublicSecurityServiceClient is the client API for PublicSecurityService service.
@@ -28,6 +31,9 @@ const (
 type PublicSecurityServiceClient interface {
 	// Public - Security management
 	CheckPermission(ctx context.Context, in *CheckPermissionRequest, opts ...grpc.CallOption) (*CheckPermissionResponse, error)
+	CheckPermissions(ctx context.Context, in *CheckPermissionsRequest, opts ...grpc.CallOption) (*CheckPermissionsResponse, error)
+	CheckResourceAccess(ctx context.Context, in *CheckResourceAccessRequest, opts ...grpc.CallOption) (*CheckResourceAccessResponse, error)
+	CheckImpersonation(ctx context.Context, in *CheckImpersonationRequest, opts ...grpc.CallOption) (*CheckImpersonationResponse, error)
 }
 
 type publicSecurityServiceClient struct {
@@ -48,12 +54,45 @@ func (c *publicSecurityServiceClient) CheckPermission(ctx context.Context, in *C
 	return out, nil
 }
 
+func (c *publicSecurityServiceClient) CheckPermissions(ctx context.Context, in *CheckPermissionsRequest, opts ...grpc.CallOption) (*CheckPermissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckPermissionsResponse)
+	err := c.cc.Invoke(ctx, PublicSecurityService_CheckPermissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicSecurityServiceClient) CheckResourceAccess(ctx context.Context, in *CheckResourceAccessRequest, opts ...grpc.CallOption) (*CheckResourceAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResourceAccessResponse)
+	err := c.cc.Invoke(ctx, PublicSecurityService_CheckResourceAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicSecurityServiceClient) CheckImpersonation(ctx context.Context, in *CheckImpersonationRequest, opts ...grpc.CallOption) (*CheckImpersonationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckImpersonationResponse)
+	err := c.cc.Invoke(ctx, PublicSecurityService_CheckImpersonation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PublicSecurityServiceServer is the server API for PublicSecurityService service.
 // All implementations must embed UnimplementedPublicSecurityServiceServer
 // for forward compatibility.
 type PublicSecurityServiceServer interface {
 	// Public - Security management
 	CheckPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error)
+	CheckPermissions(context.Context, *CheckPermissionsRequest) (*CheckPermissionsResponse, error)
+	CheckResourceAccess(context.Context, *CheckResourceAccessRequest) (*CheckResourceAccessResponse, error)
+	CheckImpersonation(context.Context, *CheckImpersonationRequest) (*CheckImpersonationResponse, error)
 	mustEmbedUnimplementedPublicSecurityServiceServer()
 }
 
@@ -67,6 +106,15 @@ type UnimplementedPublicSecurityServiceServer struct{}
 func (UnimplementedPublicSecurityServiceServer) CheckPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckPermission not implemented")
 }
+func (UnimplementedPublicSecurityServiceServer) CheckPermissions(context.Context, *CheckPermissionsRequest) (*CheckPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPermissions not implemented")
+}
+func (UnimplementedPublicSecurityServiceServer) CheckResourceAccess(context.Context, *CheckResourceAccessRequest) (*CheckResourceAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckResourceAccess not implemented")
+}
+func (UnimplementedPublicSecurityServiceServer) CheckImpersonation(context.Context, *CheckImpersonationRequest) (*CheckImpersonationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckImpersonation not implemented")
+}
 func (UnimplementedPublicSecurityServiceServer) mustEmbedUnimplementedPublicSecurityServiceServer() {}
 func (UnimplementedPublicSecurityServiceServer) testEmbeddedByValue()                               {}
 
@@ -106,6 +154,60 @@ func _PublicSecurityService_CheckPermission_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PublicSecurityService_CheckPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicSecurityServiceServer).CheckPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicSecurityService_CheckPermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicSecurityServiceServer).CheckPermissions(ctx, req.(*CheckPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicSecurityService_CheckResourceAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckResourceAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicSecurityServiceServer).CheckResourceAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicSecurityService_CheckResourceAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicSecurityServiceServer).CheckResourceAccess(ctx, req.(*CheckResourceAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicSecurityService_CheckImpersonation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckImpersonationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicSecurityServiceServer).CheckImpersonation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicSecurityService_CheckImpersonation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicSecurityServiceServer).CheckImpersonation(ctx, req.(*CheckImpersonationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PublicSecurityService_ServiceDesc is the grpc.ServiceDesc for PublicSecurityService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -117,6 +219,18 @@ var PublicSecurityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckPermission",
 			Handler:    _PublicSecurityService_CheckPermission_Handler,
 		},
+		{
+			MethodName: "CheckPermissions",
+			Handler:    _PublicSecurityService_CheckPermissions_Handler,
+		},
+		{
+			MethodName: "CheckResourceAccess",
+			Handler:    _PublicSecurityService_CheckResourceAccess_Handler,
+		},
+		{
+			MethodName: "CheckImpersonation",
+			Handler:    _PublicSecurityService_CheckImpersonation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "security_public.proto",
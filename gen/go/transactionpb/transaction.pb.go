@@ -732,6 +732,96 @@ func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
 	return nil
 }
 
+// Request message for deleting all the transactions of a user
+type DeleteUserTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserTransactionsRequest) Reset() {
+	*x = DeleteUserTransactionsRequest{}
+	mi := &file_transaction_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserTransactionsRequest) ProtoMessage() {}
+
+func (x *DeleteUserTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_transaction_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteUserTransactionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Response message for deleting all the transactions of a user
+type DeleteUserTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Remaining     int64                  `protobuf:"varint,1,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserTransactionsResponse) Reset() {
+	*x = DeleteUserTransactionsResponse{}
+	mi := &file_transaction_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserTransactionsResponse) ProtoMessage() {}
+
+func (x *DeleteUserTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_transaction_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteUserTransactionsResponse) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
 // Transaction message
 type Transaction struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -751,7 +841,7 @@ type Transaction struct {
 
 func (x *Transaction) Reset() {
 	*x = Transaction{}
-	mi := &file_transaction_proto_msgTypes[12]
+	mi := &file_transaction_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -763,7 +853,7 @@ func (x *Transaction) String() string {
 func (*Transaction) ProtoMessage() {}
 
 func (x *Transaction) ProtoReflect() protoreflect.Message {
-	mi := &file_transaction_proto_msgTypes[12]
+	mi := &file_transaction_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -776,7 +866,7 @@ func (x *Transaction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return file_transaction_proto_rawDescGZIP(), []int{12}
+	return file_transaction_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Transaction) GetId() string {
@@ -892,7 +982,11 @@ const file_transaction_proto_rawDesc = "" +
 	"\x17ListTransactionsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"X\n" +
 	"\x18ListTransactionsResponse\x12<\n" +
-	"\ftransactions\x18\x01 \x03(\v2\x18.transaction.TransactionR\ftransactions\"\xc5\x02\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x18.transaction.TransactionR\ftransactions\"8\n" +
+	"\x1dDeleteUserTransactionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\">\n" +
+	"\x1eDeleteUserTransactionsResponse\x12\x1c\n" +
+	"\tremaining\x18\x01 \x01(\x03R\tremaining\"\xc5\x02\n" +
 	"\vTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
@@ -909,14 +1003,15 @@ const file_transaction_proto_rawDesc = "" +
 	"\x0fTransactionType\x12 \n" +
 	"\x1cTRANSACTION_TYPE_UNSPECIFIED\x10\x00\x12\a\n" +
 	"\x03BUY\x10\x01\x12\b\n" +
-	"\x04SELL\x10\x022\xf8\x04\n" +
+	"\x04SELL\x10\x022\xeb\x05\n" +
 	"\x12TransactionService\x12b\n" +
 	"\x11CreateTransaction\x12%.transaction.CreateTransactionRequest\x1a&.transaction.CreateTransactionResponse\x12Y\n" +
 	"\x0eGetTransaction\x12\".transaction.GetTransactionRequest\x1a#.transaction.GetTransactionResponse\x12b\n" +
 	"\x11UpdateTransaction\x12%.transaction.UpdateTransactionRequest\x1a&.transaction.UpdateTransactionResponse\x12b\n" +
 	"\x11DeleteTransaction\x12%.transaction.DeleteTransactionRequest\x1a&.transaction.DeleteTransactionResponse\x12z\n" +
 	"\x19DeleteTransactionByBroker\x12-.transaction.DeleteTransactionByBrokerRequest\x1a..transaction.DeleteTransactionByBrokerResponse\x12_\n" +
-	"\x10ListTransactions\x12$.transaction.ListTransactionsRequest\x1a%.transaction.ListTransactionsResponseB\x11Z\x0f./transactionpbb\x06proto3"
+	"\x10ListTransactions\x12$.transaction.ListTransactionsRequest\x1a%.transaction.ListTransactionsResponse\x12q\n" +
+	"\x16DeleteUserTransactions\x12*.transaction.DeleteUserTransactionsRequest\x1a+.transaction.DeleteUserTransactionsResponseB\x11Z\x0f./transactionpbb\x06proto3"
 
 var (
 	file_transaction_proto_rawDescOnce sync.Once
@@ -931,7 +1026,7 @@ func file_transaction_proto_rawDescGZIP() []byte {
 }
 
 var file_transaction_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_transaction_proto_goTypes = []any{
 	(TransactionType)(0),                      // 0: transaction.TransactionType
 	(*CreateTransactionRequest)(nil),          // 1: transaction.CreateTransactionRequest
@@ -946,19 +1041,21 @@ var file_transaction_proto_goTypes = []any{
 	(*DeleteTransactionByBrokerResponse)(nil), // 10: transaction.DeleteTransactionByBrokerResponse
 	(*ListTransactionsRequest)(nil),           // 11: transaction.ListTransactionsRequest
 	(*ListTransactionsResponse)(nil),          // 12: transaction.ListTransactionsResponse
-	(*Transaction)(nil),                       // 13: transaction.Transaction
-	(*timestamppb.Timestamp)(nil),             // 14: google.protobuf.Timestamp
+	(*DeleteUserTransactionsRequest)(nil),     // 13: transaction.DeleteUserTransactionsRequest
+	(*DeleteUserTransactionsResponse)(nil),    // 14: transaction.DeleteUserTransactionsResponse
+	(*Transaction)(nil),                       // 15: transaction.Transaction
+	(*timestamppb.Timestamp)(nil),             // 16: google.protobuf.Timestamp
 }
 var file_transaction_proto_depIdxs = []int32{
-	14, // 0: transaction.CreateTransactionRequest.date:type_name -> google.protobuf.Timestamp
+	16, // 0: transaction.CreateTransactionRequest.date:type_name -> google.protobuf.Timestamp
 	0,  // 1: transaction.CreateTransactionRequest.transaction_type:type_name -> transaction.TransactionType
-	13, // 2: transaction.CreateTransactionResponse.transaction:type_name -> transaction.Transaction
-	13, // 3: transaction.GetTransactionResponse.transaction:type_name -> transaction.Transaction
-	14, // 4: transaction.UpdateTransactionRequest.date:type_name -> google.protobuf.Timestamp
+	15, // 2: transaction.CreateTransactionResponse.transaction:type_name -> transaction.Transaction
+	15, // 3: transaction.GetTransactionResponse.transaction:type_name -> transaction.Transaction
+	16, // 4: transaction.UpdateTransactionRequest.date:type_name -> google.protobuf.Timestamp
 	0,  // 5: transaction.UpdateTransactionRequest.transaction_type:type_name -> transaction.TransactionType
-	13, // 6: transaction.UpdateTransactionResponse.transaction:type_name -> transaction.Transaction
-	13, // 7: transaction.ListTransactionsResponse.transactions:type_name -> transaction.Transaction
-	14, // 8: transaction.Transaction.date:type_name -> google.protobuf.Timestamp
+	15, // 6: transaction.UpdateTransactionResponse.transaction:type_name -> transaction.Transaction
+	15, // 7: transaction.ListTransactionsResponse.transactions:type_name -> transaction.Transaction
+	16, // 8: transaction.Transaction.date:type_name -> google.protobuf.Timestamp
 	0,  // 9: transaction.Transaction.transaction_type:type_name -> transaction.TransactionType
 	1,  // 10: transaction.TransactionService.CreateTransaction:input_type -> transaction.CreateTransactionRequest
 	3,  // 11: transaction.TransactionService.GetTransaction:input_type -> transaction.GetTransactionRequest
@@ -966,14 +1063,16 @@ var file_transaction_proto_depIdxs = []int32{
 	7,  // 13: transaction.TransactionService.DeleteTransaction:input_type -> transaction.DeleteTransactionRequest
 	9,  // 14: transaction.TransactionService.DeleteTransactionByBroker:input_type -> transaction.DeleteTransactionByBrokerRequest
 	11, // 15: transaction.TransactionService.ListTransactions:input_type -> transaction.ListTransactionsRequest
-	2,  // 16: transaction.TransactionService.CreateTransaction:output_type -> transaction.CreateTransactionResponse
-	4,  // 17: transaction.TransactionService.GetTransaction:output_type -> transaction.GetTransactionResponse
-	6,  // 18: transaction.TransactionService.UpdateTransaction:output_type -> transaction.UpdateTransactionResponse
-	8,  // 19: transaction.TransactionService.DeleteTransaction:output_type -> transaction.DeleteTransactionResponse
-	10, // 20: transaction.TransactionService.DeleteTransactionByBroker:output_type -> transaction.DeleteTransactionByBrokerResponse
-	12, // 21: transaction.TransactionService.ListTransactions:output_type -> transaction.ListTransactionsResponse
-	16, // [16:22] is the sub-list for method output_type
-	10, // [10:16] is the sub-list for method input_type
+	13, // 16: transaction.TransactionService.DeleteUserTransactions:input_type -> transaction.DeleteUserTransactionsRequest
+	2,  // 17: transaction.TransactionService.CreateTransaction:output_type -> transaction.CreateTransactionResponse
+	4,  // 18: transaction.TransactionService.GetTransaction:output_type -> transaction.GetTransactionResponse
+	6,  // 19: transaction.TransactionService.UpdateTransaction:output_type -> transaction.UpdateTransactionResponse
+	8,  // 20: transaction.TransactionService.DeleteTransaction:output_type -> transaction.DeleteTransactionResponse
+	10, // 21: transaction.TransactionService.DeleteTransactionByBroker:output_type -> transaction.DeleteTransactionByBrokerResponse
+	12, // 22: transaction.TransactionService.ListTransactions:output_type -> transaction.ListTransactionsResponse
+	14, // 23: transaction.TransactionService.DeleteUserTransactions:output_type -> transaction.DeleteUserTransactionsResponse
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
 	10, // [10:10] is the sub-list for extension type_name
 	10, // [10:10] is the sub-list for extension extendee
 	0,  // [0:10] is the sub-list for field type_name
@@ -990,7 +1089,7 @@ func file_transaction_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transaction_proto_rawDesc), len(file_transaction_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   13,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
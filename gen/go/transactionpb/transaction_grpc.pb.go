@@ -25,6 +25,7 @@ const (
 	TransactionService_DeleteTransaction_FullMethodName         = "/transaction.TransactionService/DeleteTransaction"
 	TransactionService_DeleteTransactionByBroker_FullMethodName = "/transaction.TransactionService/DeleteTransactionByBroker"
 	TransactionService_ListTransactions_FullMethodName          = "/transaction.TransactionService/ListTransactions"
+	TransactionService_DeleteUserTransactions_FullMethodName    = "/transaction.TransactionService/DeleteUserTransactions"
 )
 
 // TransactionServiceClient is the client API for TransactionService service.
@@ -39,6 +40,7 @@ type TransactionServiceClient interface {
 	DeleteTransaction(ctx context.Context, in *DeleteTransactionRequest, opts ...grpc.CallOption) (*DeleteTransactionResponse, error)
 	DeleteTransactionByBroker(ctx context.Context, in *DeleteTransactionByBrokerRequest, opts ...grpc.CallOption) (*DeleteTransactionByBrokerResponse, error)
 	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	DeleteUserTransactions(ctx context.Context, in *DeleteUserTransactionsRequest, opts ...grpc.CallOption) (*DeleteUserTransactionsResponse, error)
 }
 
 type transactionServiceClient struct {
@@ -109,6 +111,16 @@ func (c *transactionServiceClient) ListTransactions(ctx context.Context, in *Lis
 	return out, nil
 }
 
+func (c *transactionServiceClient) DeleteUserTransactions(ctx context.Context, in *DeleteUserTransactionsRequest, opts ...grpc.CallOption) (*DeleteUserTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserTransactionsResponse)
+	err := c.cc.Invoke(ctx, TransactionService_DeleteUserTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransactionServiceServer is the server API for TransactionService service.
 // All implementations must embed UnimplementedTransactionServiceServer
 // for forward compatibility.
@@ -121,6 +133,7 @@ type TransactionServiceServer interface {
 	DeleteTransaction(context.Context, *DeleteTransactionRequest) (*DeleteTransactionResponse, error)
 	DeleteTransactionByBroker(context.Context, *DeleteTransactionByBrokerRequest) (*DeleteTransactionByBrokerResponse, error)
 	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	DeleteUserTransactions(context.Context, *DeleteUserTransactionsRequest) (*DeleteUserTransactionsResponse, error)
 	mustEmbedUnimplementedTransactionServiceServer()
 }
 
@@ -149,6 +162,9 @@ func (UnimplementedTransactionServiceServer) DeleteTransactionByBroker(context.C
 func (UnimplementedTransactionServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
 }
+func (UnimplementedTransactionServiceServer) DeleteUserTransactions(context.Context, *DeleteUserTransactionsRequest) (*DeleteUserTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserTransactions not implemented")
+}
 func (UnimplementedTransactionServiceServer) mustEmbedUnimplementedTransactionServiceServer() {}
 func (UnimplementedTransactionServiceServer) testEmbeddedByValue()                            {}
 
@@ -278,6 +294,24 @@ func _TransactionService_ListTransactions_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionService_DeleteUserTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).DeleteUserTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionService_DeleteUserTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).DeleteUserTransactions(ctx, req.(*DeleteUserTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransactionService_ServiceDesc is the grpc.ServiceDesc for TransactionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -309,6 +343,10 @@ var TransactionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTransactions",
 			Handler:    _TransactionService_ListTransactions_Handler,
 		},
+		{
+			MethodName: "DeleteUserTransactions",
+			Handler:    _TransactionService_DeleteUserTransactions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "transaction.proto",
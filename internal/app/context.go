@@ -5,4 +5,8 @@ type keyContext string
 const (
 	// ContextKeyUserID is used as key to add the user ID in the request context
 	ContextKeyUserID keyContext = "user"
+	// ContextKeySessionID is used as key to add the session ID in the request context
+	ContextKeySessionID keyContext = "session"
+	// ContextKeyActorID is used as key to add the impersonating administrator ID in the request context
+	ContextKeyActorID keyContext = "actor"
 )
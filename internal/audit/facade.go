@@ -0,0 +1,122 @@
+package audit
+
+import (
+	"context"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+// Writer defines the methods required by the facade
+type Writer interface {
+	WriteAuditLog(ctx context.Context, req *securitypb.WriteAuditLogRequest) (*securitypb.WriteAuditLogResponse, error)
+}
+
+// GrpcClientAdapter adapts the securitypb.AuditServiceClient to Writer
+type GrpcClientAdapter struct {
+	client securitypb.AuditServiceClient
+}
+
+// WriteAuditLog implements Writer for the gRPC client
+func (a *GrpcClientAdapter) WriteAuditLog(ctx context.Context, req *securitypb.WriteAuditLogRequest) (*securitypb.WriteAuditLogResponse, error) {
+	return a.client.WriteAuditLog(ctx, req)
+}
+
+// NewGrpcClientAdapter creates a new adapter for the gRPC client
+func NewGrpcClientAdapter(client securitypb.AuditServiceClient) *GrpcClientAdapter {
+	return &GrpcClientAdapter{
+		client: client,
+	}
+}
+
+// AuditFacade records the security relevant events into the audit log of the security microservice
+type AuditFacade struct {
+	writer Writer
+}
+
+// NewAuditFacade creates a new facade writing through the given Writer
+func NewAuditFacade(writer Writer) *AuditFacade {
+	return &AuditFacade{
+		writer: writer,
+	}
+}
+
+// NewAuditFacadeWithGrpcClient creates a new facade with a gRPC client
+func NewAuditFacadeWithGrpcClient(client securitypb.AuditServiceClient) *AuditFacade {
+	return NewAuditFacade(NewGrpcClientAdapter(client))
+}
+
+// Record appends the entry to the audit log.
+// The actor defaults to the authenticated caller, the request ID and IP address to the ones propagated with the context.
+// Failures are only logged : auditing must never break the audited operation.
+// Nothing is recorded when no facade is configured.
+func (f *AuditFacade) Record(ctx context.Context, entry models.AuditLog) {
+	if f == nil || f.writer == nil {
+		return
+	}
+
+	// Actions performed while impersonating are attributed to the real actor, on behalf of the impersonated user
+	if principal, ok := grpcutil.PrincipalFromContext(ctx); ok {
+		if !entry.ActorID.Valid {
+			entry.ActorID.UUID = principal.UserID
+			entry.ActorID.Valid = true
+		}
+		if principal.IsImpersonated() {
+			if entry.ActorID.UUID == principal.UserID {
+				entry.ActorID = principal.ActorID
+			}
+			details := make(models.AuditDetails, len(entry.Details)+1)
+			for k, v := range entry.Details {
+				details[k] = v
+			}
+			details[models.AuditDetailImpersonatedUser] = principal.UserID.String()
+			entry.Details = details
+		}
+	}
+
+	info := grpcutil.RequestInfoFromContext(ctx)
+	if entry.RequestID == "" {
+		entry.RequestID = info.RequestID
+	}
+	if entry.IPAddress == "" {
+		entry.IPAddress = info.IPAddress
+	}
+
+	// If any, propagate metadata from the incoming context to the outgoing context
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	req := &securitypb.WriteAuditLogRequest{
+		Action:     entry.Action,
+		Outcome:    entry.Outcome,
+		TargetType: entry.TargetType,
+		TargetId:   entry.TargetID,
+		RequestId:  entry.RequestID,
+		IpAddress:  entry.IPAddress,
+		Details:    entry.Details,
+	}
+	if entry.ActorID.Valid {
+		req.ActorId = entry.ActorID.UUID.String()
+	}
+
+	if _, err := f.writer.WriteAuditLog(ctx, req); err != nil {
+		zap.L().Error("AuditFacade.Record", zap.String("action", entry.Action), zap.Error(err))
+	}
+}
+
+// Global instance of the AuditFacade
+var _globalAuditFacade *AuditFacade
+
+// Facade returns the global AuditFacade instance
+func Facade() *AuditFacade {
+	return _globalAuditFacade
+}
+
+// ReplaceGlobals sets the global AuditFacade instance
+func ReplaceGlobals(facade *AuditFacade) {
+	_globalAuditFacade = facade
+}
@@ -0,0 +1,140 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/test/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+// TestAuditFacade_ReplaceGlobals tests the ReplaceGlobals function
+func TestAuditFacade_ReplaceGlobals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFacade := NewAuditFacade(mocks.NewMockAuditWriter(ctrl))
+	ReplaceGlobals(mockFacade)
+
+	assert.Equal(t, mockFacade, Facade(), "The global facade instance should be replaced correctly")
+}
+
+// TestAuditFacade_Record tests the AuditFacade.Record method
+func TestAuditFacade_Record(t *testing.T) {
+	callerID := uuid.New()
+	actorID := uuid.New()
+	info := grpcutil.RequestInfo{RequestID: "req-1", IPAddress: "203.0.113.7"}
+
+	// Prepare tests
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		entry     models.AuditLog
+		mockSetup func(ctrl *gomock.Controller) *AuditFacade
+	}{
+		{
+			name:  "No facade configured",
+			ctx:   context.Background(),
+			entry: models.AuditLog{Action: models.AuditActionLogin, Outcome: models.AuditOutcomeSuccess},
+			mockSetup: func(ctrl *gomock.Controller) *AuditFacade {
+				return nil
+			},
+		},
+		{
+			name: "Defaults to the caller and the request information",
+			ctx: func() context.Context {
+				outgoing, _ := metadata.FromOutgoingContext(grpcutil.AppendRequestInfoToOutgoingContext(context.Background(), info))
+				ctx := metadata.NewIncomingContext(context.Background(), outgoing)
+				return grpcutil.ContextWithPrincipal(ctx, grpcutil.Principal{UserID: callerID})
+			}(),
+			entry: models.AuditLog{Action: models.AuditActionRoleDelete, Outcome: models.AuditOutcomeSuccess, TargetType: models.AuditTargetRole, TargetID: "role"},
+			mockSetup: func(ctrl *gomock.Controller) *AuditFacade {
+				m := mocks.NewMockAuditWriter(ctrl)
+				m.EXPECT().WriteAuditLog(gomock.Any(), &securitypb.WriteAuditLogRequest{
+					Action:     models.AuditActionRoleDelete,
+					Outcome:    models.AuditOutcomeSuccess,
+					ActorId:    callerID.String(),
+					TargetType: models.AuditTargetRole,
+					TargetId:   "role",
+					RequestId:  info.RequestID,
+					IpAddress:  info.IPAddress,
+				}).Return(&securitypb.WriteAuditLogResponse{Id: uuid.New().String()}, nil)
+				return NewAuditFacade(m)
+			},
+		},
+		{
+			name: "Keeps the given actor and IP address",
+			ctx:  grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{UserID: callerID}),
+			entry: models.AuditLog{
+				Action:    models.AuditActionLogin,
+				Outcome:   models.AuditOutcomeSuccess,
+				ActorID:   uuid.NullUUID{UUID: actorID, Valid: true},
+				IPAddress: "198.51.100.1",
+			},
+			mockSetup: func(ctrl *gomock.Controller) *AuditFacade {
+				m := mocks.NewMockAuditWriter(ctrl)
+				m.EXPECT().WriteAuditLog(gomock.Any(), &securitypb.WriteAuditLogRequest{
+					Action:    models.AuditActionLogin,
+					Outcome:   models.AuditOutcomeSuccess,
+					ActorId:   actorID.String(),
+					IpAddress: "198.51.100.1",
+				}).Return(&securitypb.WriteAuditLogResponse{}, nil)
+				return NewAuditFacade(m)
+			},
+		},
+		{
+			name: "Attributes the impersonated actions to the real actor",
+			ctx: grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{
+				UserID:  callerID,
+				ActorID: uuid.NullUUID{UUID: actorID, Valid: true},
+			}),
+			entry: models.AuditLog{
+				Action:  models.AuditActionRoleDelete,
+				Outcome: models.AuditOutcomeSuccess,
+				Details: models.AuditDetails{"role": "role"},
+			},
+			mockSetup: func(ctrl *gomock.Controller) *AuditFacade {
+				m := mocks.NewMockAuditWriter(ctrl)
+				m.EXPECT().WriteAuditLog(gomock.Any(), &securitypb.WriteAuditLogRequest{
+					Action:  models.AuditActionRoleDelete,
+					Outcome: models.AuditOutcomeSuccess,
+					ActorId: actorID.String(),
+					Details: map[string]string{
+						"role":                             "role",
+						models.AuditDetailImpersonatedUser: callerID.String(),
+					},
+				}).Return(&securitypb.WriteAuditLogResponse{}, nil)
+				return NewAuditFacade(m)
+			},
+		},
+		{
+			name:  "Write failure is not propagated",
+			ctx:   context.Background(),
+			entry: models.AuditLog{Action: models.AuditActionLogin, Outcome: models.AuditOutcomeFailure},
+			mockSetup: func(ctrl *gomock.Controller) *AuditFacade {
+				c := mocks.NewMockAuditServiceClient(ctrl)
+				c.EXPECT().WriteAuditLog(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+				return NewAuditFacadeWithGrpcClient(c)
+			},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			facade := tt.mockSetup(ctrl)
+			assert.NotPanics(t, func() {
+				facade.Record(tt.ctx, tt.entry)
+			})
+		})
+	}
+}
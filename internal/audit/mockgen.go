@@ -0,0 +1,3 @@
+package audit
+
+//go:generate mockgen -source=facade.go -destination=../../test/mocks/audit_facade.go --package=mocks -mock_names=Writer=MockAuditWriter Writer
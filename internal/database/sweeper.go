@@ -0,0 +1,32 @@
+package database
+
+import (
+	"time"
+)
+
+// StartSweeper periodically calls sweep while isHealthy returns true.
+// The health is checked on every tick since the connections are replaced on recovery.
+// Returns a function to stop the sweeper.
+func StartSweeper(interval time.Duration, isHealthy func() bool, sweep func()) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan bool)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				if isHealthy() {
+					sweep()
+				}
+			}
+		}
+	}()
+
+	// Return function to stop the sweeper
+	return func() {
+		done <- true
+	}
+}
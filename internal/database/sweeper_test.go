@@ -0,0 +1,33 @@
+package database
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartSweeper(t *testing.T) {
+	checker := &MockHealthChecker{healthy: false}
+	var sweeps atomic.Int32
+
+	stop := StartSweeper(10*time.Millisecond, checker.IsHealthy, func() {
+		sweeps.Add(1)
+	})
+
+	// Nothing is swept while the checker is unhealthy
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(0), sweeps.Load())
+
+	// Sweeps once the checker is healthy
+	checker.SetHealth(true)
+	time.Sleep(50 * time.Millisecond)
+	assert.Greater(t, sweeps.Load(), int32(0))
+
+	// Nothing is swept once stopped
+	stop()
+	count := sweeps.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, count, sweeps.Load())
+}
@@ -0,0 +1,148 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Archive packs the export into a ZIP of JSON files, along with CSV copies of the tabular data
+func Archive(export models.UserExport) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+
+	files := []struct {
+		name  string
+		write func() ([]byte, error)
+	}{
+		{"profile.json", func() ([]byte, error) { return marshal(export.User) }},
+		{"brokers.json", func() ([]byte, error) { return marshal(export.Brokers) }},
+		{"transactions.json", func() ([]byte, error) { return marshal(export.Transactions) }},
+		{"transactions.csv", func() ([]byte, error) { return transactionsCSV(export.Transactions) }},
+		{"roles.json", func() ([]byte, error) { return marshal(export.Roles) }},
+		{"role_grants.json", func() ([]byte, error) { return marshal(export.RoleGrants) }},
+		{"audit_logs.json", func() ([]byte, error) { return marshal(export.AuditLogs) }},
+		{"audit_logs.csv", func() ([]byte, error) { return auditLogsCSV(export.AuditLogs) }},
+	}
+
+	for _, file := range files {
+		content, err := file.write()
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := w.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.GeneratedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err = f.Write(content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// marshal encodes the value as indented JSON, empty lists are encoded as [] rather than null
+func marshal(v interface{}) ([]byte, error) {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if string(content) == "null" {
+		return []byte("[]"), nil
+	}
+	return content, nil
+}
+
+// transactionsCSV encodes the transactions as CSV
+func transactionsCSV(transactions []models.Transaction) ([]byte, error) {
+	records := [][]string{
+		{"id", "date", "type", "asset", "broker_id", "broker_name", "quantity", "price", "price_unit", "fee"},
+	}
+	for _, t := range transactions {
+		records = append(records, []string{
+			t.ID.String(),
+			t.Date.Format(time.RFC3339),
+			string(t.Type),
+			t.Asset,
+			t.Broker.ID.String(),
+			t.Broker.Name,
+			formatFloat(t.Quantity),
+			formatFloat(t.Price),
+			formatFloat(t.PriceUnit),
+			formatFloat(t.Fee),
+		})
+	}
+	return writeCSV(records)
+}
+
+// auditLogsCSV encodes the audit log entries as CSV, details are flattened as sorted key=value pairs
+func auditLogsCSV(logs models.AuditLogs) ([]byte, error) {
+	records := [][]string{
+		{"id", "occurred_at", "action", "outcome", "actor_id", "target_type", "target_id", "ip_address", "details"},
+	}
+	for _, l := range logs {
+		actorID := ""
+		if l.ActorID.Valid {
+			actorID = l.ActorID.UUID.String()
+		}
+		records = append(records, []string{
+			l.ID.String(),
+			l.OccurredAt.Format(time.RFC3339),
+			l.Action,
+			l.Outcome,
+			actorID,
+			l.TargetType,
+			l.TargetID,
+			l.IPAddress,
+			formatDetails(l.Details),
+		})
+	}
+	return writeCSV(records)
+}
+
+// writeCSV encodes the records as CSV
+func writeCSV(records [][]string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatFloat formats the float without trailing zeros
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// formatDetails flattens the details into sorted key=value pairs separated by semicolons
+func formatDetails(details models.AuditDetails) string {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(';')
+		}
+		buf.WriteString(key + "=" + details[key])
+	}
+	return buf.String()
+}
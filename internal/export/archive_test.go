@@ -0,0 +1,62 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+// TestArchive tests the Archive function
+func TestArchive(t *testing.T) {
+	userID := uuid.New()
+	broker := models.Broker{ID: uuid.New(), Name: "broker"}
+	data := models.UserExport{
+		GeneratedAt: time.Now(),
+		User:        models.User{ID: userID, Email: "user@fihub.com"},
+		Brokers:     []models.BrokerUser{{UserID: userID, Broker: broker}},
+		Transactions: []models.Transaction{
+			{ID: uuid.New(), UserID: userID, Broker: broker, Type: models.BUY, Asset: "asset", Quantity: 1.5, Price: 10, PriceUnit: 6.67, Fee: 0.5},
+		},
+		AuditLogs: models.AuditLogs{
+			{ID: uuid.New(), Action: models.AuditActionLogin, Outcome: models.AuditOutcomeSuccess, Details: models.AuditDetails{"b": "2", "a": "1"}},
+		},
+	}
+
+	archive, err := Archive(data)
+	assert.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	assert.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		assert.NoError(t, err)
+		_ = rc.Close()
+		files[f.Name] = content
+	}
+
+	assert.Len(t, files, 8)
+	assert.Contains(t, string(files["profile.json"]), "user@fihub.com")
+	assert.Equal(t, "[]", string(files["roles.json"]))
+
+	transactions, err := csv.NewReader(bytes.NewReader(files["transactions.csv"])).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 2)
+	assert.Equal(t, "1.5", transactions[1][6])
+	assert.Equal(t, "broker", transactions[1][5])
+
+	logs, err := csv.NewReader(bytes.NewReader(files["audit_logs.csv"])).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assert.Equal(t, "", logs[1][4])
+	assert.Equal(t, "a=1;b=2", logs[1][8])
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	ErrLinkSigningKeyMissing = errors.New("export link signing key is missing")
+	ErrLinkInvalid           = errors.New("export-link-invalid")
+)
+
+// linkIssuer is the issuer set on every download link
+const linkIssuer = "fihub-export"
+
+// linkClaims are the claims of a download link token
+type linkClaims struct {
+	jwt.RegisteredClaims
+	UserID string `json:"uid"`
+}
+
+// LinkSigner signs and verifies the tokens of the export download links
+type LinkSigner struct {
+	signingKey []byte
+	ttl        time.Duration
+}
+
+// NewLinkSigner creates a new LinkSigner instance
+func NewLinkSigner(signingKey []byte, ttl time.Duration) *LinkSigner {
+	return &LinkSigner{
+		signingKey: signingKey,
+		ttl:        ttl,
+	}
+}
+
+// TTL returns how long the download links are valid
+func (s *LinkSigner) TTL() time.Duration {
+	return s.ttl
+}
+
+// Sign creates a download link for the archive of the user
+func (s *LinkSigner) Sign(exportID, userID uuid.UUID) (models.ExportLink, error) {
+	if len(s.signingKey) == 0 {
+		return models.ExportLink{}, ErrLinkSigningKeyMissing
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	claims := linkClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    linkIssuer,
+			Subject:   exportID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		UserID: userID.String(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.signingKey)
+	if err != nil {
+		return models.ExportLink{}, err
+	}
+
+	return models.ExportLink{
+		ID:        exportID,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Verify checks the token and returns the archive and user it was signed for
+func (s *LinkSigner) Verify(token string) (exportID, userID uuid.UUID, err error) {
+	if len(s.signingKey) == 0 {
+		return uuid.Nil, uuid.Nil, ErrLinkSigningKeyMissing
+	}
+
+	var claims linkClaims
+	_, err = jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		return s.signingKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(linkIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrLinkInvalid
+	}
+
+	exportID, err = uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrLinkInvalid
+	}
+	userID, err = uuid.Parse(claims.UserID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrLinkInvalid
+	}
+	return exportID, userID, nil
+}
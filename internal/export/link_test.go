@@ -0,0 +1,48 @@
+package export
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestLinkSigner_SignVerify tests the Sign and Verify methods of LinkSigner
+func TestLinkSigner_SignVerify(t *testing.T) {
+	exportID := uuid.New()
+	userID := uuid.New()
+	signer := NewLinkSigner([]byte("signing-key"), time.Hour)
+
+	t.Run("Success", func(t *testing.T) {
+		link, err := signer.Sign(exportID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, exportID, link.ID)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), link.ExpiresAt, time.Second)
+
+		verifiedExportID, verifiedUserID, err := signer.Verify(link.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, exportID, verifiedExportID)
+		assert.Equal(t, userID, verifiedUserID)
+	})
+
+	t.Run("Wrong signing key", func(t *testing.T) {
+		link, err := NewLinkSigner([]byte("other-key"), time.Hour).Sign(exportID, userID)
+		assert.NoError(t, err)
+
+		_, _, err = signer.Verify(link.Token)
+		assert.ErrorIs(t, err, ErrLinkInvalid)
+	})
+
+	t.Run("Expired link", func(t *testing.T) {
+		link, err := NewLinkSigner([]byte("signing-key"), -time.Hour).Sign(exportID, userID)
+		assert.NoError(t, err)
+
+		_, _, err = signer.Verify(link.Token)
+		assert.ErrorIs(t, err, ErrLinkInvalid)
+	})
+
+	t.Run("Missing signing key", func(t *testing.T) {
+		_, err := NewLinkSigner(nil, time.Hour).Sign(exportID, userID)
+		assert.ErrorIs(t, err, ErrLinkSigningKeyMissing)
+	})
+}
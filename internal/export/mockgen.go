@@ -0,0 +1,3 @@
+package export
+
+//go:generate mockgen -source=service.go -destination=../../test/mocks/export_service.go --package=mocks -mock_names=Service=MockExportService Service
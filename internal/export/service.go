@@ -0,0 +1,164 @@
+package export
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+var ErrTooManyExports = errors.New("too-many-exports")
+
+const (
+	defaultDirectory     = "data/exports"
+	defaultLinkTTL       = 24 * time.Hour
+	defaultMaxConcurrent = 2
+)
+
+// Service defines the interface for running the data exports and serving their archives
+type Service interface {
+	// Submit runs the export job in the background, returns ErrTooManyExports when every slot is busy
+	Submit(job func()) error
+	// Store keeps the archive of the user and returns a signed link to download it
+	Store(userID uuid.UUID, archive []byte) (models.ExportLink, error)
+	// Open verifies the link token and returns the user and the archive it points to
+	Open(token string) (uuid.UUID, []byte, error)
+	// Discard removes every archive of the user, their links can no longer be opened
+	Discard(userID uuid.UUID) error
+}
+
+type service struct {
+	storage Storage
+	signer  *LinkSigner
+	slots   chan struct{}
+}
+
+// NewService creates a new Service instance, running at most maxConcurrent jobs at once
+func NewService(storage Storage, signer *LinkSigner, maxConcurrent int) Service {
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrent
+	}
+	return &service{
+		storage: storage,
+		signer:  signer,
+		slots:   make(chan struct{}, maxConcurrent),
+	}
+}
+
+// NewServiceFromConfig creates a new Service instance using the EXPORT_* configuration.
+// Outside production, a development key signs the links when none is configured.
+func NewServiceFromConfig() (Service, error) {
+	directory := viper.GetString("EXPORT_DIRECTORY")
+	if directory == "" {
+		directory = defaultDirectory
+	}
+	storage, err := NewFileStorage(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	signingKey := viper.GetString("EXPORT_LINK_SIGNING_KEY")
+	if signingKey == "" {
+		if viper.GetString("APP_ENV") != "production" {
+			signingKey = "dev-export-link-signing-key"
+		} else {
+			zap.L().Error("EXPORT_LINK_SIGNING_KEY is not set, data exports will fail")
+		}
+	}
+
+	ttl := viper.GetDuration("EXPORT_LINK_DURATION")
+	if ttl <= 0 {
+		ttl = defaultLinkTTL
+	}
+
+	return NewService(storage, NewLinkSigner([]byte(signingKey), ttl), viper.GetInt("EXPORT_MAX_CONCURRENT_JOBS")), nil
+}
+
+// Submit runs the export job in the background
+func (s *service) Submit(job func()) error {
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		return ErrTooManyExports
+	}
+
+	go func() {
+		defer func() { <-s.slots }()
+		defer func() {
+			if r := recover(); r != nil {
+				zap.L().Error("Export job panicked", zap.Any("panic", r))
+			}
+		}()
+		job()
+	}()
+	return nil
+}
+
+// Store keeps the archive of the user and returns a signed link to download it.
+// Archives whose links have expired are purged along the way.
+func (s *service) Store(userID uuid.UUID, archive []byte) (models.ExportLink, error) {
+	if deleted, err := s.storage.DeleteOlderThan(time.Now().Add(-s.signer.TTL())); err != nil {
+		zap.L().Warn("Purge expired exports", zap.Error(err))
+	} else if deleted > 0 {
+		zap.L().Info("Purged expired exports", zap.Int("deleted", deleted))
+	}
+
+	exportID := uuid.New()
+	if err := s.storage.Save(userID, exportID, archive); err != nil {
+		return models.ExportLink{}, err
+	}
+	return s.signer.Sign(exportID, userID)
+}
+
+// Open verifies the link token and returns the user and the archive it points to
+func (s *service) Open(token string) (uuid.UUID, []byte, error) {
+	exportID, userID, err := s.signer.Verify(token)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	archive, err := s.storage.Load(userID, exportID)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	return userID, archive, nil
+}
+
+// Discard removes every archive of the user
+func (s *service) Discard(userID uuid.UUID) error {
+	deleted, err := s.storage.DeleteByUser(userID)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		zap.L().Info("Discarded user exports", zap.String("user_id", userID.String()), zap.Int("deleted", deleted))
+	}
+	return nil
+}
+
+var (
+	_globalServiceMu sync.RWMutex
+	_globalService   Service
+)
+
+// S is used to access the global service singleton
+func S() Service {
+	_globalServiceMu.RLock()
+	defer _globalServiceMu.RUnlock()
+
+	service := _globalService
+	return service
+}
+
+// ReplaceGlobals affect a new service to the global service singleton
+func ReplaceGlobals(service Service) func() {
+	_globalServiceMu.Lock()
+	defer _globalServiceMu.Unlock()
+
+	prev := _globalService
+	_globalService = service
+	return func() { ReplaceGlobals(prev) }
+}
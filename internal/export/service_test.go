@@ -0,0 +1,57 @@
+package export
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestService_StoreOpen tests the Store and Open methods of the service
+func TestService_StoreOpen(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	assert.NoError(t, err)
+	s := NewService(storage, NewLinkSigner([]byte("signing-key"), time.Hour), 1)
+
+	userID := uuid.New()
+	link, err := s.Store(userID, []byte("archive"))
+	assert.NoError(t, err)
+
+	openedUserID, archive, err := s.Open(link.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, openedUserID)
+	assert.Equal(t, []byte("archive"), archive)
+
+	_, _, err = s.Open("malformed")
+	assert.ErrorIs(t, err, ErrLinkInvalid)
+
+	// Discarded archives can no longer be opened
+	assert.NoError(t, s.Discard(userID))
+	_, _, err = s.Open(link.Token)
+	assert.ErrorIs(t, err, ErrArchiveNotFound)
+}
+
+// TestService_Submit tests the Submit method of the service
+func TestService_Submit(t *testing.T) {
+	s := NewService(nil, nil, 1)
+
+	// The only slot is taken until the job is released
+	release := make(chan struct{})
+	done := make(chan struct{})
+	assert.NoError(t, s.Submit(func() {
+		<-release
+		close(done)
+	}))
+	assert.ErrorIs(t, s.Submit(func() {}), ErrTooManyExports)
+
+	close(release)
+	<-done
+
+	// The slot is freed once the job completed, even if it panics
+	assert.Eventually(t, func() bool {
+		return s.Submit(func() { panic("export") }) == nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return s.Submit(func() {}) == nil
+	}, time.Second, 10*time.Millisecond)
+}
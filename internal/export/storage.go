@@ -0,0 +1,99 @@
+package export
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var ErrArchiveNotFound = errors.New("export-archive-not-found")
+
+// archiveExtension is the extension of the archives written on disk
+const archiveExtension = ".zip"
+
+// Storage is a storage interface for the export archives, which can be implemented by multiple backend
+// (file system, object storage, ...)
+type Storage interface {
+	Save(userID, id uuid.UUID, archive []byte) error
+	Load(userID, id uuid.UUID) ([]byte, error)
+	DeleteByUser(userID uuid.UUID) (int, error)
+	DeleteOlderThan(before time.Time) (int, error)
+}
+
+// FileStorage stores the export archives as files in a directory, named after their user
+type FileStorage struct {
+	directory string
+}
+
+// NewFileStorage creates a new FileStorage instance, creating the directory if needed
+func NewFileStorage(directory string) (*FileStorage, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStorage{directory: directory}, nil
+}
+
+// Save writes the archive of the user
+func (s *FileStorage) Save(userID, id uuid.UUID, archive []byte) error {
+	return os.WriteFile(s.path(userID, id), archive, 0o600)
+}
+
+// Load reads the archive of the user, returns ErrArchiveNotFound if it does not exist
+func (s *FileStorage) Load(userID, id uuid.UUID) ([]byte, error) {
+	archive, err := os.ReadFile(s.path(userID, id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrArchiveNotFound
+	}
+	return archive, err
+}
+
+// DeleteByUser removes every archive of the user and returns how many were removed
+func (s *FileStorage) DeleteByUser(userID uuid.UUID) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(s.directory, userID.String()+"_*"+archiveExtension))
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, path := range paths {
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// DeleteOlderThan removes the archives written before the given time and returns how many were removed
+func (s *FileStorage) DeleteOlderThan(before time.Time) (int, error) {
+	entries, err := os.ReadDir(s.directory)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), archiveExtension) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(before) {
+			if err = os.Remove(filepath.Join(s.directory, entry.Name())); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// path returns the location of the archive of the user
+func (s *FileStorage) path(userID, id uuid.UUID) string {
+	return filepath.Join(s.directory, userID.String()+"_"+id.String()+archiveExtension)
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestFileStorage tests the Save, Load, DeleteByUser and DeleteOlderThan methods of FileStorage
+func TestFileStorage(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	assert.NoError(t, err)
+	userID := uuid.New()
+
+	// Load a missing archive
+	_, err = storage.Load(userID, uuid.New())
+	assert.ErrorIs(t, err, ErrArchiveNotFound)
+
+	// Save then load an archive, only for its user
+	recent := uuid.New()
+	assert.NoError(t, storage.Save(userID, recent, []byte("recent")))
+	archive, err := storage.Load(userID, recent)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("recent"), archive)
+	_, err = storage.Load(uuid.New(), recent)
+	assert.ErrorIs(t, err, ErrArchiveNotFound)
+
+	// Only the outdated archives are deleted
+	outdated := uuid.New()
+	assert.NoError(t, storage.Save(userID, outdated, []byte("outdated")))
+	past := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(storage.path(userID, outdated), past, past))
+
+	deleted, err := storage.DeleteOlderThan(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	_, err = storage.Load(userID, outdated)
+	assert.ErrorIs(t, err, ErrArchiveNotFound)
+	_, err = storage.Load(userID, recent)
+	assert.NoError(t, err)
+
+	// Only the archives of the user are deleted
+	otherUserID := uuid.New()
+	other := uuid.New()
+	assert.NoError(t, storage.Save(otherUserID, other, []byte("other")))
+
+	deleted, err = storage.DeleteByUser(userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	_, err = storage.Load(userID, recent)
+	assert.ErrorIs(t, err, ErrArchiveNotFound)
+	_, err = storage.Load(otherUserID, other)
+	assert.NoError(t, err)
+}
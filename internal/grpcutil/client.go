@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"time"
@@ -19,9 +20,10 @@ func GetClientAddress(serviceName string) string {
 }
 
 // ConnectToClient creates a gRPC client connection based on service name and returns the connection
+// When GRPC_TLS_ENABLED is set, the connection uses mutual TLS.
 func ConnectToClient(serviceName string) *grpc.ClientConn {
 	address := GetClientAddress(serviceName)
-	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(clientTransportCredentials(serviceName)))
 	if err != nil {
 		zap.L().Fatal("Failed to connect to gRPC service", zap.String("service", serviceName), zap.Error(err))
 	}
@@ -41,3 +43,16 @@ func CheckClientHealth(conn *grpc.ClientConn, serviceName string) (*grpc_health_
 		Service: serviceName,
 	})
 }
+
+// clientTransportCredentials returns the transport credentials used to connect to a gRPC service
+func clientTransportCredentials(serviceName string) credentials.TransportCredentials {
+	if !TLSEnabled() {
+		return insecure.NewCredentials()
+	}
+
+	creds, err := NewClientCredentials(TLSConfigFromConfig(serviceName))
+	if err != nil {
+		zap.L().Fatal("Failed to load gRPC client TLS credentials", zap.String("service", serviceName), zap.Error(err))
+	}
+	return creds
+}
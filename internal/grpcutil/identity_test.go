@@ -0,0 +1,149 @@
+package grpcutil
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+// TestIdentityAuthority_SignVerify tests the Sign and Verify methods of IdentityAuthority
+func TestIdentityAuthority_SignVerify(t *testing.T) {
+	userID := uuid.New()
+	authority := NewIdentityAuthority([]byte("signing-key"), time.Minute)
+
+	t.Run("Success", func(t *testing.T) {
+		assertion, err := authority.Sign(userID)
+		assert.NoError(t, err)
+
+		principal, err := authority.Verify(assertion)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, principal.UserID)
+	})
+
+	t.Run("Success with actor", func(t *testing.T) {
+		actorID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+		assertion, err := authority.SignPrincipal(Principal{UserID: userID, ActorID: actorID})
+		assert.NoError(t, err)
+
+		principal, err := authority.Verify(assertion)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, principal.UserID)
+		assert.Equal(t, actorID, principal.ActorID)
+		assert.True(t, principal.IsImpersonated())
+	})
+
+	t.Run("Wrong signing key", func(t *testing.T) {
+		assertion, err := NewIdentityAuthority([]byte("other-key"), time.Minute).Sign(userID)
+		assert.NoError(t, err)
+
+		_, err = authority.Verify(assertion)
+		assert.ErrorIs(t, err, ErrIdentityAssertionInvalid)
+	})
+
+	t.Run("Expired assertion", func(t *testing.T) {
+		assertion, err := NewIdentityAuthority([]byte("signing-key"), -time.Minute).Sign(userID)
+		assert.NoError(t, err)
+
+		_, err = authority.Verify(assertion)
+		assert.ErrorIs(t, err, ErrIdentityAssertionInvalid)
+	})
+
+	t.Run("Malformed assertion", func(t *testing.T) {
+		_, err := authority.Verify("malformed")
+		assert.ErrorIs(t, err, ErrIdentityAssertionInvalid)
+	})
+
+	t.Run("Missing signing key", func(t *testing.T) {
+		empty := NewIdentityAuthority(nil, time.Minute)
+
+		_, err := empty.Sign(userID)
+		assert.ErrorIs(t, err, ErrIdentitySigningKeyMissing)
+
+		_, err = empty.Verify("assertion")
+		assert.ErrorIs(t, err, ErrIdentitySigningKeyMissing)
+	})
+}
+
+// TestIdentityAuthority_UnaryServerInterceptor tests the UnaryServerInterceptor method of IdentityAuthority
+func TestIdentityAuthority_UnaryServerInterceptor(t *testing.T) {
+	userID := uuid.New()
+	authority := NewIdentityAuthority([]byte("signing-key"), time.Minute)
+	validAssertion, _ := authority.Sign(userID)
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		expectPrincipal bool
+		expectedErrCode codes.Code
+	}{
+		{
+			name:            "no metadata",
+			ctx:             context.Background(),
+			expectPrincipal: false,
+			expectedErrCode: codes.OK,
+		},
+		{
+			name:            "no identity assertion",
+			ctx:             metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", userID.String())),
+			expectPrincipal: false,
+			expectedErrCode: codes.OK,
+		},
+		{
+			name:            "invalid identity assertion",
+			ctx:             metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdentityMetadataKey, "invalid")),
+			expectPrincipal: false,
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name:            "valid identity assertion",
+			ctx:             metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdentityMetadataKey, validAssertion)),
+			expectPrincipal: true,
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				principal, ok := PrincipalFromContext(ctx)
+				assert.Equal(t, tt.expectPrincipal, ok)
+				if tt.expectPrincipal {
+					assert.Equal(t, userID, principal.UserID)
+				}
+				return nil, nil
+			}
+
+			interceptor := authority.UnaryServerInterceptor()
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Equal(t, tt.expectedErrCode == codes.OK, called)
+		})
+	}
+}
+
+// TestIdentityAuthority_AppendIdentityToOutgoingContext tests the AppendIdentityToOutgoingContext method of IdentityAuthority
+func TestIdentityAuthority_AppendIdentityToOutgoingContext(t *testing.T) {
+	userID := uuid.New()
+	authority := NewIdentityAuthority([]byte("signing-key"), time.Minute)
+
+	ctx, err := authority.AppendIdentityToOutgoingContext(context.Background(), userID)
+	assert.NoError(t, err)
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	assert.True(t, ok)
+	assertions := md.Get(IdentityMetadataKey)
+	assert.Len(t, assertions, 1)
+
+	principal, err := authority.Verify(assertions[0])
+	assert.NoError(t, err)
+	assert.Equal(t, userID, principal.UserID)
+}
@@ -0,0 +1,52 @@
+package grpcutil
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// RequestIDMetadataKey is the gRPC metadata key carrying the ID of the originating HTTP request
+	RequestIDMetadataKey = "x-request-id"
+	// ClientIPMetadataKey is the gRPC metadata key carrying the IP address of the originating client
+	ClientIPMetadataKey = "x-client-ip"
+)
+
+// RequestInfo describes the HTTP request which originated a gRPC call
+type RequestInfo struct {
+	RequestID string
+	IPAddress string
+}
+
+// AppendRequestInfoToOutgoingContext attaches the request information to the outgoing metadata
+func AppendRequestInfoToOutgoingContext(ctx context.Context, info RequestInfo) context.Context {
+	kv := make([]string, 0, 4)
+	if info.RequestID != "" {
+		kv = append(kv, RequestIDMetadataKey, info.RequestID)
+	}
+	if info.IPAddress != "" {
+		kv = append(kv, ClientIPMetadataKey, info.IPAddress)
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// RequestInfoFromContext retrieves the request information from the incoming metadata,
+// or from the outgoing metadata when the context has not been received through gRPC (i.e. in the gateway).
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || (len(md.Get(RequestIDMetadataKey)) == 0 && len(md.Get(ClientIPMetadataKey)) == 0) {
+		md, _ = metadata.FromOutgoingContext(ctx)
+	}
+
+	var info RequestInfo
+	if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+		info.RequestID = values[0]
+	}
+	if values := md.Get(ClientIPMetadataKey); len(values) > 0 {
+		info.IPAddress = values[0]
+	}
+	return info
+}
@@ -0,0 +1,36 @@
+package grpcutil
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+// TestRequestInfo tests the AppendRequestInfoToOutgoingContext and RequestInfoFromContext functions
+func TestRequestInfo(t *testing.T) {
+	info := RequestInfo{RequestID: "host/abc-000001", IPAddress: "203.0.113.7"}
+
+	t.Run("Outgoing context", func(t *testing.T) {
+		ctx := AppendRequestInfoToOutgoingContext(context.Background(), info)
+		assert.Equal(t, info, RequestInfoFromContext(ctx))
+	})
+
+	t.Run("Incoming context", func(t *testing.T) {
+		outgoing, _ := metadata.FromOutgoingContext(AppendRequestInfoToOutgoingContext(context.Background(), info))
+		ctx := metadata.NewIncomingContext(context.Background(), outgoing)
+		assert.Equal(t, info, RequestInfoFromContext(ctx))
+	})
+
+	t.Run("Incoming context without request information", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdentityMetadataKey, "assertion"))
+		assert.Equal(t, RequestInfo{}, RequestInfoFromContext(ctx))
+	})
+
+	t.Run("Empty request information", func(t *testing.T) {
+		ctx := AppendRequestInfoToOutgoingContext(context.Background(), RequestInfo{})
+		_, ok := metadata.FromOutgoingContext(ctx)
+		assert.False(t, ok)
+		assert.Equal(t, RequestInfo{}, RequestInfoFromContext(ctx))
+	})
+}
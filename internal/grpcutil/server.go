@@ -25,6 +25,27 @@ func SetupServer(serviceName string) (net.Listener, error) {
 	return lis, nil
 }
 
+// NewServer creates a new gRPC server for the specified service, authenticating incoming requests through
+// their identity assertion. When GRPC_TLS_ENABLED is set, the server only accepts mutual TLS connections.
+func NewServer(serviceName string, opts ...grpc.ServerOption) *grpc.Server {
+	identity := NewIdentityAuthorityFromConfig()
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(identity.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(identity.StreamServerInterceptor()),
+	}, opts...)
+
+	// Mutual TLS
+	if TLSEnabled() {
+		creds, err := NewServerCredentials(TLSConfigFromConfig(serviceName))
+		if err != nil {
+			zap.L().Fatal("Failed to load gRPC server TLS credentials", zap.String("service", serviceName), zap.Error(err))
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	return grpc.NewServer(opts...)
+}
+
 // StartServer starts the gRPC server and listens for incoming connections
 func StartServer(s *grpc.Server, lis net.Listener, serviceName string) {
 	go func() {
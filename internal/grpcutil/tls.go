@@ -0,0 +1,276 @@
+package grpcutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrTLSCANoCertificate     = errors.New("no certificate found in CA file")
+	ErrTLSPeerNoCertificate   = errors.New("peer did not present a certificate")
+	ErrTLSPeerIdentityDenied  = errors.New("peer certificate identity is not allowed")
+	ErrTLSConfigurationAbsent = errors.New("tls cert, key and CA files must all be specified")
+)
+
+// TLSConfig holds the files and identities used to set up mutual TLS on gRPC links
+type TLSConfig struct {
+	CertFile          string
+	KeyFile           string
+	CAFile            string
+	AllowedIdentities []string
+}
+
+// IsValid checks if every file of the TLSConfig is specified
+func (c TLSConfig) IsValid() (bool, error) {
+	if c.CertFile == "" || c.KeyFile == "" || c.CAFile == "" {
+		return false, ErrTLSConfigurationAbsent
+	}
+	return true, nil
+}
+
+// TLSEnabled indicates whether mutual TLS is enabled on gRPC links
+func TLSEnabled() bool {
+	return viper.GetBool("GRPC_TLS_ENABLED")
+}
+
+// TLSConfigFromConfig builds the TLSConfig for the specified service from the configuration.
+// Allowed client identities are read from the <SERVICE>_MICROSERVICE_TLS_ALLOWED_CLIENTS comma separated list.
+func TLSConfigFromConfig(serviceName string) TLSConfig {
+	allowed := make([]string, 0)
+	for _, identity := range strings.Split(viper.GetString(fmt.Sprintf("%s_MICROSERVICE_TLS_ALLOWED_CLIENTS", serviceName)), ",") {
+		if identity = strings.TrimSpace(identity); identity != "" {
+			allowed = append(allowed, identity)
+		}
+	}
+
+	return TLSConfig{
+		CertFile:          viper.GetString("GRPC_TLS_FILE_CRT"),
+		KeyFile:           viper.GetString("GRPC_TLS_FILE_KEY"),
+		CAFile:            viper.GetString("GRPC_TLS_FILE_CA"),
+		AllowedIdentities: allowed,
+	}
+}
+
+// NewServerCredentials creates the server side mutual TLS credentials.
+// Client certificates must be signed by the CA and, if any, match one of the allowed identities.
+func NewServerCredentials(config TLSConfig) (credentials.TransportCredentials, error) {
+	store, err := newCertificateStore(config)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool, err := store.get()
+			if err != nil {
+				return nil, err
+			}
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{cert},
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				ClientCAs:    pool,
+				VerifyConnection: func(cs tls.ConnectionState) error {
+					return verifyPeerIdentity(cs, config.AllowedIdentities)
+				},
+			}, nil
+		},
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// NewClientCredentials creates the client side mutual TLS credentials.
+// Server certificates must be signed by the CA and valid for the dialed host.
+func NewClientCredentials(config TLSConfig) (credentials.TransportCredentials, error) {
+	store, err := newCertificateStore(config)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _, err := store.get()
+			if err != nil {
+				return nil, err
+			}
+			return &cert, nil
+		},
+		// The server certificate is verified against the latest CA pool in VerifyConnection,
+		// which allows the CA file to be reloaded without recreating the connection.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			_, pool, err := store.get()
+			if err != nil {
+				return err
+			}
+			return verifyServerCertificate(cs, pool)
+		},
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// verifyServerCertificate verifies the server certificate chain against the CA pool and the dialed host
+func verifyServerCertificate(cs tls.ConnectionState, pool *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return ErrTLSPeerNoCertificate
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	return err
+}
+
+// verifyPeerIdentity checks that the peer certificate matches one of the allowed identities.
+// The common name and the DNS and URI subject alternative names are considered as identities.
+// No restriction is applied when the allow-list is empty.
+func verifyPeerIdentity(cs tls.ConnectionState, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return ErrTLSPeerNoCertificate
+	}
+
+	for _, identity := range certificateIdentities(cs.PeerCertificates[0]) {
+		for _, a := range allowed {
+			if identity == a {
+				return nil
+			}
+		}
+	}
+	return ErrTLSPeerIdentityDenied
+}
+
+// certificateIdentities lists the identities carried by a certificate
+func certificateIdentities(cert *x509.Certificate) []string {
+	identities := make([]string, 0, 1+len(cert.DNSNames)+len(cert.URIs))
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	identities = append(identities, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	return identities
+}
+
+// certificateStore keeps the key pair and CA pool in memory and reloads them whenever their files change
+type certificateStore struct {
+	mu      sync.Mutex
+	config  TLSConfig
+	cert    tls.Certificate
+	pool    *x509.CertPool
+	modTime map[string]time.Time
+}
+
+// newCertificateStore creates a new certificateStore and loads the files a first time
+func newCertificateStore(config TLSConfig) (*certificateStore, error) {
+	if ok, err := config.IsValid(); !ok {
+		return nil, err
+	}
+
+	store := &certificateStore{
+		config: config,
+	}
+	if _, _, err := store.get(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// get returns the current key pair and CA pool, reloading them if any of their files changed.
+// If a reload fails, the previously loaded key pair and CA pool are kept.
+func (s *certificateStore) get() (tls.Certificate, *x509.CertPool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed, err := s.changed()
+	if err == nil && changed {
+		err = s.reload()
+	}
+	if err != nil {
+		if s.pool == nil {
+			return tls.Certificate{}, nil, err
+		}
+		zap.L().Warn("Failed to reload TLS certificates, keeping previous ones", zap.Error(err))
+	}
+
+	return s.cert, s.pool, nil
+}
+
+// reload loads the key pair and CA pool from their files
+func (s *certificateStore) reload() error {
+	// Retrieve the modification times first, so that a change during the load triggers another reload
+	modTime := make(map[string]time.Time)
+	for _, file := range s.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTime[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.config.CertFile, s.config.KeyFile)
+	if err != nil {
+		return err
+	}
+
+	caPEM, err := os.ReadFile(s.config.CAFile)
+	if err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return ErrTLSCANoCertificate
+	}
+
+	s.cert = cert
+	s.pool = pool
+	s.modTime = modTime
+	zap.L().Info("Loaded TLS certificates", zap.String("cert", s.config.CertFile), zap.String("ca", s.config.CAFile))
+	return nil
+}
+
+// changed indicates whether any file was modified since it was last loaded
+func (s *certificateStore) changed() (bool, error) {
+	if s.pool == nil {
+		return true, nil
+	}
+	for _, file := range s.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, err
+		}
+		if !info.ModTime().Equal(s.modTime[file]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// files lists the files watched by the store
+func (s *certificateStore) files() []string {
+	return []string{s.config.CertFile, s.config.KeyFile, s.config.CAFile}
+}
@@ -0,0 +1,231 @@
+package grpcutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCA is a self-signed certificate authority generated at test time
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+// newTestCA generates a new self-signed certificate authority
+func newTestCA(t *testing.T) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "fihub-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue generates a certificate signed by the CA for the given common name, and writes the files in dir
+func (ca testCA) issue(t *testing.T, dir, commonName string) TLSConfig {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	config := TLSConfig{
+		CertFile: filepath.Join(dir, commonName+".crt"),
+		KeyFile:  filepath.Join(dir, commonName+".key"),
+		CAFile:   filepath.Join(dir, commonName+".ca.crt"),
+	}
+	writeTestFile(t, config.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	writeTestFile(t, config.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+	writeTestFile(t, config.CAFile, ca.pem)
+	return config
+}
+
+// testFileRevision is incremented on every test file write to produce distinct modification times
+var testFileRevision int64
+
+// writeTestFile writes a file and bumps its modification time so that reloads are detected
+func writeTestFile(t *testing.T, path string, content []byte) {
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	testFileRevision++
+	modTime := time.Now().Add(time.Duration(testFileRevision) * time.Second)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// startTestServer starts a gRPC server serving the health service with the given credentials
+func startTestServer(t *testing.T, creds credentials.TransportCredentials) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := grpc.NewServer(grpc.Creds(creds))
+	grpc_health_v1.RegisterHealthServer(s, health.NewServer())
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	return fmt.Sprintf("localhost:%d", lis.Addr().(*net.TCPAddr).Port)
+}
+
+// checkTestServer calls the health service of the server using the given credentials
+func checkTestServer(t *testing.T, address string, creds credentials.TransportCredentials) error {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+// TestMutualTLS tests the mutual TLS credentials between a client and a server
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	serverConfig := ca.issue(t, dir, "security")
+	serverConfig.AllowedIdentities = []string{"api"}
+
+	serverCreds, err := NewServerCredentials(serverConfig)
+	require.NoError(t, err)
+	address := startTestServer(t, serverCreds)
+
+	t.Run("Allowed client", func(t *testing.T) {
+		clientCreds, err := NewClientCredentials(ca.issue(t, dir, "api"))
+		require.NoError(t, err)
+		assert.NoError(t, checkTestServer(t, address, clientCreds))
+	})
+
+	t.Run("Client identity not allowed", func(t *testing.T) {
+		clientCreds, err := NewClientCredentials(ca.issue(t, dir, "intruder"))
+		require.NoError(t, err)
+		assert.Error(t, checkTestServer(t, address, clientCreds))
+	})
+
+	t.Run("Client signed by another CA", func(t *testing.T) {
+		otherConfig := newTestCA(t).issue(t, t.TempDir(), "api")
+		otherConfig.CAFile = serverConfig.CAFile
+		clientCreds, err := NewClientCredentials(otherConfig)
+		require.NoError(t, err)
+		assert.Error(t, checkTestServer(t, address, clientCreds))
+	})
+
+	t.Run("Client without certificate", func(t *testing.T) {
+		assert.Error(t, checkTestServer(t, address, insecure.NewCredentials()))
+	})
+}
+
+// TestMutualTLS_Reload tests that certificates are reloaded when their files change
+func TestMutualTLS_Reload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	serverConfig := ca.issue(t, dir, "security")
+	serverConfig.AllowedIdentities = []string{"api"}
+
+	serverCreds, err := NewServerCredentials(serverConfig)
+	require.NoError(t, err)
+	address := startTestServer(t, serverCreds)
+
+	// The client starts with an identity which is not allowed
+	clientDir := t.TempDir()
+	clientConfig := ca.issue(t, clientDir, "intruder")
+	clientCreds, err := NewClientCredentials(clientConfig)
+	require.NoError(t, err)
+	assert.Error(t, checkTestServer(t, address, clientCreds))
+
+	// Replace the client certificate files with an allowed identity
+	allowedConfig := ca.issue(t, clientDir, "api")
+	for src, dst := range map[string]string{
+		allowedConfig.CertFile: clientConfig.CertFile,
+		allowedConfig.KeyFile:  clientConfig.KeyFile,
+	} {
+		content, err := os.ReadFile(src)
+		require.NoError(t, err)
+		writeTestFile(t, dst, content)
+	}
+	assert.NoError(t, checkTestServer(t, address, clientCreds))
+
+	// Rotate the server CA : the client no longer trusts the server
+	rotatedConfig := newTestCA(t).issue(t, t.TempDir(), "security")
+	for src, dst := range map[string]string{
+		rotatedConfig.CertFile: serverConfig.CertFile,
+		rotatedConfig.KeyFile:  serverConfig.KeyFile,
+		rotatedConfig.CAFile:   serverConfig.CAFile,
+	} {
+		content, err := os.ReadFile(src)
+		require.NoError(t, err)
+		writeTestFile(t, dst, content)
+	}
+	assert.Error(t, checkTestServer(t, address, clientCreds))
+}
+
+// TestTLSConfigFromConfig tests the TLSConfigFromConfig function
+func TestTLSConfigFromConfig(t *testing.T) {
+	viper.Set("GRPC_TLS_FILE_CRT", "certs/grpc.crt")
+	viper.Set("GRPC_TLS_FILE_KEY", "certs/grpc.key")
+	viper.Set("GRPC_TLS_FILE_CA", "certs/ca.crt")
+	viper.Set("TEST_MICROSERVICE_TLS_ALLOWED_CLIENTS", "api, user,,auth ")
+
+	config := TLSConfigFromConfig("TEST")
+	assert.Equal(t, TLSConfig{
+		CertFile:          "certs/grpc.crt",
+		KeyFile:           "certs/grpc.key",
+		CAFile:            "certs/ca.crt",
+		AllowedIdentities: []string{"api", "user", "auth"},
+	}, config)
+
+	ok, err := config.IsValid()
+	assert.True(t, ok)
+	assert.NoError(t, err)
+
+	ok, err = TLSConfig{}.IsValid()
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, ErrTLSConfigurationAbsent)
+}
@@ -0,0 +1,69 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuditLogToProto converts a models.AuditLog to a securitypb.AuditLog
+func AuditLogToProto(log models.AuditLog) *securitypb.AuditLog {
+	protoLog := &securitypb.AuditLog{
+		Id:         log.ID.String(),
+		OccurredAt: timestamppb.New(log.OccurredAt),
+		Action:     log.Action,
+		Outcome:    log.Outcome,
+		TargetType: log.TargetType,
+		TargetId:   log.TargetID,
+		RequestId:  log.RequestID,
+		IpAddress:  log.IPAddress,
+		Details:    log.Details,
+	}
+	if log.ActorID.Valid {
+		protoLog.ActorId = log.ActorID.UUID.String()
+	}
+	return protoLog
+}
+
+// AuditLogFromProto converts a securitypb.AuditLog to a models.AuditLog
+func AuditLogFromProto(log *securitypb.AuditLog) models.AuditLog {
+	actorID, err := uuid.Parse(log.GetActorId())
+	if err != nil {
+		actorID = uuid.Nil
+	}
+
+	return models.AuditLog{
+		ID:         uuid.MustParse(log.GetId()),
+		OccurredAt: log.GetOccurredAt().AsTime(),
+		Action:     log.GetAction(),
+		Outcome:    log.GetOutcome(),
+		ActorID: uuid.NullUUID{
+			UUID:  actorID,
+			Valid: actorID != uuid.Nil,
+		},
+		TargetType: log.GetTargetType(),
+		TargetID:   log.GetTargetId(),
+		RequestID:  log.GetRequestId(),
+		IPAddress:  log.GetIpAddress(),
+		Details:    log.GetDetails(),
+	}
+}
+
+// AuditLogsToProto converts a models.AuditLogs to a slice of securitypb.AuditLog
+func AuditLogsToProto(logs models.AuditLogs) []*securitypb.AuditLog {
+	protoLogs := make([]*securitypb.AuditLog, len(logs))
+	for i, log := range logs {
+		protoLogs[i] = AuditLogToProto(log)
+	}
+	return protoLogs
+}
+
+// AuditLogsFromProto converts a slice of securitypb.AuditLog to a models.AuditLogs
+func AuditLogsFromProto(logs []*securitypb.AuditLog) models.AuditLogs {
+	modelLogs := make(models.AuditLogs, len(logs))
+	for i, log := range logs {
+		modelLogs[i] = AuditLogFromProto(log)
+	}
+	return modelLogs
+}
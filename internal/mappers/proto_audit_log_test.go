@@ -0,0 +1,89 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+// Test_AuditLogToProto tests the AuditLogToProto function
+func Test_AuditLogToProto(t *testing.T) {
+	log := models.AuditLog{
+		ID:         uuid.New(),
+		OccurredAt: time.Date(2025, 5, 9, 9, 0, 0, 0, time.UTC),
+		Action:     models.AuditActionRoleDelete,
+		Outcome:    models.AuditOutcomeSuccess,
+		ActorID:    uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TargetType: models.AuditTargetRole,
+		TargetID:   uuid.New().String(),
+		RequestID:  "req-1",
+		IPAddress:  "127.0.0.1",
+		Details:    models.AuditDetails{"name": "admin"},
+	}
+
+	result := AuditLogToProto(log)
+
+	assert.Equal(t, log.ID.String(), result.GetId())
+	assert.Equal(t, log.OccurredAt, result.GetOccurredAt().AsTime())
+	assert.Equal(t, log.Action, result.GetAction())
+	assert.Equal(t, log.Outcome, result.GetOutcome())
+	assert.Equal(t, log.ActorID.UUID.String(), result.GetActorId())
+	assert.Equal(t, log.TargetType, result.GetTargetType())
+	assert.Equal(t, log.TargetID, result.GetTargetId())
+	assert.Equal(t, log.RequestID, result.GetRequestId())
+	assert.Equal(t, log.IPAddress, result.GetIpAddress())
+	assert.Equal(t, map[string]string(log.Details), result.GetDetails())
+
+	// Anonymous actor
+	result = AuditLogToProto(models.AuditLog{ID: uuid.New(), Action: models.AuditActionLogin})
+
+	assert.Empty(t, result.GetActorId())
+}
+
+// Test_AuditLogFromProto tests the AuditLogFromProto function
+func Test_AuditLogFromProto(t *testing.T) {
+	occurredAt := time.Date(2025, 5, 9, 9, 0, 0, 0, time.UTC)
+	log := &securitypb.AuditLog{
+		Id:         uuid.New().String(),
+		OccurredAt: timestamppb.New(occurredAt),
+		Action:     models.AuditActionLogin,
+		Outcome:    models.AuditOutcomeFailure,
+		ActorId:    uuid.New().String(),
+		IpAddress:  "127.0.0.1",
+		Details:    map[string]string{"reason": "invalid-credentials"},
+	}
+
+	result := AuditLogFromProto(log)
+
+	assert.Equal(t, log.GetId(), result.ID.String())
+	assert.Equal(t, occurredAt, result.OccurredAt)
+	assert.Equal(t, log.GetAction(), result.Action)
+	assert.Equal(t, log.GetOutcome(), result.Outcome)
+	assert.True(t, result.ActorID.Valid)
+	assert.Equal(t, log.GetActorId(), result.ActorID.UUID.String())
+	assert.Equal(t, log.GetIpAddress(), result.IPAddress)
+	assert.Equal(t, models.AuditDetails(log.GetDetails()), result.Details)
+
+	// Anonymous actor
+	log.ActorId = ""
+	result = AuditLogFromProto(log)
+
+	assert.False(t, result.ActorID.Valid)
+}
+
+// Test_AuditLogsToProto tests the AuditLogsToProto and AuditLogsFromProto functions
+func Test_AuditLogsToProto(t *testing.T) {
+	logs := models.AuditLogs{
+		{ID: uuid.New(), OccurredAt: time.Date(2025, 5, 9, 9, 0, 0, 0, time.UTC), Action: models.AuditActionLogin, Details: models.AuditDetails{}},
+		{ID: uuid.New(), OccurredAt: time.Date(2025, 5, 9, 10, 0, 0, 0, time.UTC), Action: models.AuditActionRoleCreate, Details: models.AuditDetails{}},
+	}
+
+	result := AuditLogsToProto(logs)
+
+	assert.Len(t, result, len(logs))
+	assert.Equal(t, logs, AuditLogsFromProto(result))
+}
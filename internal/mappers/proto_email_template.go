@@ -0,0 +1,17 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+)
+
+// EmailTemplatePreviewFromProto converts a userpb.PreviewEmailTemplateResponse to a models.EmailTemplatePreview
+func EmailTemplatePreviewFromProto(preview *userpb.PreviewEmailTemplateResponse) models.EmailTemplatePreview {
+	return models.EmailTemplatePreview{
+		Name:             preview.GetName(),
+		Language:         preview.GetLanguage(),
+		Subject:          preview.GetSubject(),
+		PlainTextContent: preview.GetPlainTextContent(),
+		HTMLContent:      preview.GetHtmlContent(),
+	}
+}
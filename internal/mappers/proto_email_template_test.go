@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Test_EmailTemplatePreviewFromProto tests the EmailTemplatePreviewFromProto function
+func Test_EmailTemplatePreviewFromProto(t *testing.T) {
+	preview := &userpb.PreviewEmailTemplateResponse{
+		Name:             "welcome",
+		Language:         "fr",
+		Subject:          "Bienvenue sur Fihub",
+		PlainTextContent: "Votre compte Fihub est prêt.",
+		HtmlContent:      "<h1>Bonjour !</h1>",
+	}
+
+	result := EmailTemplatePreviewFromProto(preview)
+
+	assert.Equal(t, "welcome", result.Name)
+	assert.Equal(t, "fr", result.Language)
+	assert.Equal(t, "Bienvenue sur Fihub", result.Subject)
+	assert.Equal(t, "Votre compte Fihub est prêt.", result.PlainTextContent)
+	assert.Equal(t, "<h1>Bonjour !</h1>", result.HTMLContent)
+}
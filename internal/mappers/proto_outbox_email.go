@@ -0,0 +1,72 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OutboxEmailToProto converts a models.OutboxEmail to a userpb.OutboxEmail
+// The contents of the email are left out on purpose, since they may hold secrets.
+func OutboxEmailToProto(email models.OutboxEmail) *userpb.OutboxEmail {
+	protoEmail := &userpb.OutboxEmail{
+		Id:             email.ID.String(),
+		IdempotencyKey: email.IdempotencyKey,
+		To:             email.To,
+		Cc:             email.Cc,
+		ReplyTo:        email.ReplyTo,
+		Subject:        email.Subject,
+		Status:         email.Status,
+		Attempts:       int32(email.Attempts),
+		LastError:      email.LastError,
+		NextAttemptAt:  timestamppb.New(email.NextAttemptAt),
+		CreatedAt:      timestamppb.New(email.CreatedAt),
+		UpdatedAt:      timestamppb.New(email.UpdatedAt),
+	}
+	if email.SentAt != nil {
+		protoEmail.SentAt = timestamppb.New(*email.SentAt)
+	}
+	return protoEmail
+}
+
+// OutboxEmailFromProto converts a userpb.OutboxEmail to a models.OutboxEmail
+func OutboxEmailFromProto(email *userpb.OutboxEmail) models.OutboxEmail {
+	result := models.OutboxEmail{
+		ID:             uuid.MustParse(email.GetId()),
+		IdempotencyKey: email.GetIdempotencyKey(),
+		To:             email.GetTo(),
+		Cc:             email.GetCc(),
+		ReplyTo:        email.GetReplyTo(),
+		Subject:        email.GetSubject(),
+		Status:         email.GetStatus(),
+		Attempts:       int(email.GetAttempts()),
+		LastError:      email.GetLastError(),
+		NextAttemptAt:  email.GetNextAttemptAt().AsTime(),
+		CreatedAt:      email.GetCreatedAt().AsTime(),
+		UpdatedAt:      email.GetUpdatedAt().AsTime(),
+	}
+	if email.GetSentAt() != nil {
+		sentAt := email.GetSentAt().AsTime()
+		result.SentAt = &sentAt
+	}
+	return result
+}
+
+// OutboxEmailsToProto converts a slice of models.OutboxEmail to a slice of userpb.OutboxEmail
+func OutboxEmailsToProto(emails models.OutboxEmails) []*userpb.OutboxEmail {
+	protoEmails := make([]*userpb.OutboxEmail, len(emails))
+	for i, email := range emails {
+		protoEmails[i] = OutboxEmailToProto(email)
+	}
+	return protoEmails
+}
+
+// OutboxEmailsFromProto converts a slice of userpb.OutboxEmail to a slice of models.OutboxEmail
+func OutboxEmailsFromProto(emails []*userpb.OutboxEmail) models.OutboxEmails {
+	modelEmails := make(models.OutboxEmails, len(emails))
+	for i, email := range emails {
+		modelEmails[i] = OutboxEmailFromProto(email)
+	}
+	return modelEmails
+}
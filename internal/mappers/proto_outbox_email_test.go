@@ -0,0 +1,91 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+// Test_OutboxEmailToProto tests the OutboxEmailToProto function
+func Test_OutboxEmailToProto(t *testing.T) {
+	testDate := time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC)
+	email := models.OutboxEmail{
+		ID:               uuid.New(),
+		IdempotencyKey:   "password-reset:1",
+		To:               []string{"jane@example.com"},
+		Cc:               []string{"copy@example.com"},
+		ReplyTo:          "support@example.com",
+		Subject:          "Subject",
+		PlainTextContent: "Your code is 123456",
+		Status:           models.OutboxStatusSent,
+		Attempts:         2,
+		LastError:        "timeout",
+		NextAttemptAt:    testDate,
+		CreatedAt:        testDate,
+		UpdatedAt:        testDate,
+		SentAt:           &testDate,
+	}
+
+	result := OutboxEmailToProto(email)
+
+	assert.Equal(t, email.ID.String(), result.Id)
+	assert.Equal(t, "password-reset:1", result.IdempotencyKey)
+	assert.Equal(t, []string{"jane@example.com"}, result.To)
+	assert.Equal(t, []string{"copy@example.com"}, result.Cc)
+	assert.Equal(t, "support@example.com", result.ReplyTo)
+	assert.Equal(t, "Subject", result.Subject)
+	assert.Equal(t, models.OutboxStatusSent, result.Status)
+	assert.Equal(t, int32(2), result.Attempts)
+	assert.Equal(t, "timeout", result.LastError)
+	assert.Equal(t, testDate, result.NextAttemptAt.AsTime())
+	assert.Equal(t, testDate, result.SentAt.AsTime())
+
+	// Not sent yet
+	email.SentAt = nil
+	assert.Nil(t, OutboxEmailToProto(email).SentAt)
+}
+
+// Test_OutboxEmailFromProto tests the OutboxEmailFromProto function
+func Test_OutboxEmailFromProto(t *testing.T) {
+	email := models.OutboxEmail{
+		ID:             uuid.New(),
+		IdempotencyKey: "password-reset:1",
+		To:             []string{"jane@example.com"},
+		Cc:             []string{},
+		Subject:        "Subject",
+		Status:         models.OutboxStatusDead,
+		Attempts:       5,
+		LastError:      "timeout",
+		NextAttemptAt:  time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC),
+		CreatedAt:      time.Date(2023, 5, 15, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:      time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, email, OutboxEmailFromProto(OutboxEmailToProto(email)))
+
+	// Sent
+	sentAt := time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC)
+	result := OutboxEmailFromProto(&userpb.OutboxEmail{Id: email.ID.String(), SentAt: timestamppb.New(sentAt)})
+	assert.Equal(t, sentAt, *result.SentAt)
+}
+
+// Test_OutboxEmailsToProto tests the OutboxEmailsToProto and OutboxEmailsFromProto functions
+func Test_OutboxEmailsToProto(t *testing.T) {
+	emails := models.OutboxEmails{
+		{ID: uuid.New(), To: []string{"jane@example.com"}, Status: models.OutboxStatusPending},
+		{ID: uuid.New(), To: []string{"john@example.com"}, Status: models.OutboxStatusSent},
+	}
+
+	result := OutboxEmailsToProto(emails)
+	assert.Len(t, result, 2)
+	assert.Equal(t, emails[1].ID.String(), result[1].Id)
+
+	back := OutboxEmailsFromProto(result)
+	assert.Len(t, back, 2)
+	assert.Equal(t, emails[0].ID, back[0].ID)
+	assert.Equal(t, models.OutboxStatusSent, back[1].Status)
+}
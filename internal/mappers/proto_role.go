@@ -43,16 +43,20 @@ func RolesFromProto(roles []*securitypb.Role) models.Roles {
 // RoleWithPermissionsToProto converts a models.RoleWithPermissions to a securitypb.RoleWithPermissions
 func RoleWithPermissionsToProto(role models.RoleWithPermissions) *securitypb.RoleWithPermissions {
 	return &securitypb.RoleWithPermissions{
-		Role:        RoleToProto(role.Role),
-		Permissions: PermissionsToProto(role.Permissions),
+		Role:                 RoleToProto(role.Role),
+		Permissions:          PermissionsToProto(role.Permissions),
+		Parents:              RolesToProto(role.Parents),
+		InheritedPermissions: PermissionsToProto(role.InheritedPermissions),
 	}
 }
 
 // RoleWithPermissionsFromProto converts a securitypb.RoleWithPermissions to a models.RoleWithPermissions
 func RoleWithPermissionsFromProto(role *securitypb.RoleWithPermissions) models.RoleWithPermissions {
 	return models.RoleWithPermissions{
-		Role:        RoleFromProto(role.GetRole()),
-		Permissions: PermissionsFromProto(role.GetPermissions()),
+		Role:                 RoleFromProto(role.GetRole()),
+		Permissions:          PermissionsFromProto(role.GetPermissions()),
+		Parents:              RolesFromProto(role.GetParents()),
+		InheritedPermissions: PermissionsFromProto(role.GetInheritedPermissions()),
 	}
 }
 
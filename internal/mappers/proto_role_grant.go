@@ -0,0 +1,67 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RoleGrantToProto converts a models.RoleGrant to a securitypb.RoleGrant
+func RoleGrantToProto(grant models.RoleGrant) *securitypb.RoleGrant {
+	protoGrant := &securitypb.RoleGrant{
+		UserId:    grant.UserID.String(),
+		RoleId:    grant.RoleID.String(),
+		ValidFrom: timestamppb.New(grant.ValidFrom),
+		Reason:    grant.Reason,
+	}
+	if grant.ValidUntil != nil {
+		protoGrant.ValidUntil = timestamppb.New(*grant.ValidUntil)
+	}
+	if grant.GrantedBy.Valid {
+		protoGrant.GrantedBy = grant.GrantedBy.UUID.String()
+	}
+	return protoGrant
+}
+
+// RoleGrantFromProto converts a securitypb.RoleGrant to a models.RoleGrant
+func RoleGrantFromProto(grant *securitypb.RoleGrant) models.RoleGrant {
+	grantedBy, err := uuid.Parse(grant.GetGrantedBy())
+	if err != nil {
+		grantedBy = uuid.Nil
+	}
+
+	result := models.RoleGrant{
+		UserID:    uuid.MustParse(grant.GetUserId()),
+		RoleID:    uuid.MustParse(grant.GetRoleId()),
+		ValidFrom: grant.GetValidFrom().AsTime(),
+		Reason:    grant.GetReason(),
+		GrantedBy: uuid.NullUUID{
+			UUID:  grantedBy,
+			Valid: grantedBy != uuid.Nil,
+		},
+	}
+	if grant.GetValidUntil() != nil {
+		validUntil := grant.GetValidUntil().AsTime()
+		result.ValidUntil = &validUntil
+	}
+	return result
+}
+
+// RoleGrantsToProto converts a models.RoleGrants to a slice of securitypb.RoleGrant
+func RoleGrantsToProto(grants models.RoleGrants) []*securitypb.RoleGrant {
+	protoGrants := make([]*securitypb.RoleGrant, len(grants))
+	for i, grant := range grants {
+		protoGrants[i] = RoleGrantToProto(grant)
+	}
+	return protoGrants
+}
+
+// RoleGrantsFromProto converts a slice of securitypb.RoleGrant to a models.RoleGrants
+func RoleGrantsFromProto(grants []*securitypb.RoleGrant) models.RoleGrants {
+	modelGrants := make(models.RoleGrants, len(grants))
+	for i, grant := range grants {
+		modelGrants[i] = RoleGrantFromProto(grant)
+	}
+	return modelGrants
+}
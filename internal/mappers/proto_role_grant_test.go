@@ -0,0 +1,93 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+// Test_RoleGrantToProto tests the RoleGrantToProto function
+func Test_RoleGrantToProto(t *testing.T) {
+	validFrom := time.Date(2025, 5, 8, 9, 0, 0, 0, time.UTC)
+	validUntil := validFrom.Add(48 * time.Hour)
+	grant := models.RoleGrant{
+		UserID:     uuid.New(),
+		RoleID:     uuid.New(),
+		ValidFrom:  validFrom,
+		ValidUntil: &validUntil,
+		Reason:     "support ticket",
+		GrantedBy:  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+	}
+
+	result := RoleGrantToProto(grant)
+
+	assert.Equal(t, grant.UserID.String(), result.GetUserId())
+	assert.Equal(t, grant.RoleID.String(), result.GetRoleId())
+	assert.Equal(t, validFrom, result.GetValidFrom().AsTime())
+	assert.Equal(t, validUntil, result.GetValidUntil().AsTime())
+	assert.Equal(t, grant.Reason, result.GetReason())
+	assert.Equal(t, grant.GrantedBy.UUID.String(), result.GetGrantedBy())
+
+	// Permanent grant, without granting user
+	result = RoleGrantToProto(models.RoleGrant{UserID: uuid.New(), RoleID: uuid.New(), ValidFrom: validFrom})
+
+	assert.Nil(t, result.GetValidUntil())
+	assert.Empty(t, result.GetGrantedBy())
+}
+
+// Test_RoleGrantFromProto tests the RoleGrantFromProto function
+func Test_RoleGrantFromProto(t *testing.T) {
+	validFrom := time.Date(2025, 5, 8, 9, 0, 0, 0, time.UTC)
+	protoGrant := &securitypb.RoleGrant{
+		UserId:     uuid.New().String(),
+		RoleId:     uuid.New().String(),
+		ValidFrom:  timestamppb.New(validFrom),
+		ValidUntil: timestamppb.New(validFrom.Add(48 * time.Hour)),
+		Reason:     "support ticket",
+		GrantedBy:  uuid.New().String(),
+	}
+
+	result := RoleGrantFromProto(protoGrant)
+
+	assert.Equal(t, protoGrant.GetUserId(), result.UserID.String())
+	assert.Equal(t, protoGrant.GetRoleId(), result.RoleID.String())
+	assert.Equal(t, validFrom, result.ValidFrom)
+	assert.Equal(t, validFrom.Add(48*time.Hour), *result.ValidUntil)
+	assert.Equal(t, "support ticket", result.Reason)
+	assert.True(t, result.GrantedBy.Valid)
+	assert.Equal(t, protoGrant.GetGrantedBy(), result.GrantedBy.UUID.String())
+
+	// Permanent grant, without granting user
+	result = RoleGrantFromProto(&securitypb.RoleGrant{
+		UserId:    uuid.New().String(),
+		RoleId:    uuid.New().String(),
+		ValidFrom: timestamppb.New(validFrom),
+	})
+
+	assert.Nil(t, result.ValidUntil)
+	assert.False(t, result.GrantedBy.Valid)
+}
+
+// Test_RoleGrantsToProto tests the RoleGrantsToProto function
+func Test_RoleGrantsToProto(t *testing.T) {
+	grants := models.RoleGrants{{UserID: uuid.New(), RoleID: uuid.New()}}
+
+	result := RoleGrantsToProto(grants)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, grants[0].RoleID.String(), result[0].GetRoleId())
+}
+
+// Test_RoleGrantsFromProto tests the RoleGrantsFromProto function
+func Test_RoleGrantsFromProto(t *testing.T) {
+	protoGrants := []*securitypb.RoleGrant{{UserId: uuid.New().String(), RoleId: uuid.New().String()}}
+
+	result := RoleGrantsFromProto(protoGrants)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, protoGrants[0].GetRoleId(), result[0].RoleID.String())
+}
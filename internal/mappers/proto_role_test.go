@@ -115,6 +115,9 @@ func Test_RoleWithPermissionsToProto(t *testing.T) {
 		},
 	}
 
+	role.Parents = models.Roles{{Id: uuid.New(), Name: "support"}}
+	role.InheritedPermissions = models.Permissions{{Id: uuid.New(), Value: "front.*"}}
+
 	// Convert to proto
 	protoRole := RoleWithPermissionsToProto(role)
 
@@ -123,6 +126,10 @@ func Test_RoleWithPermissionsToProto(t *testing.T) {
 	assert.Equal(t, "admin", protoRole.Role.Name)
 	assert.Equal(t, 1, len(protoRole.Permissions))
 	assert.Equal(t, "read", protoRole.Permissions[0].Value)
+	assert.Equal(t, 1, len(protoRole.Parents))
+	assert.Equal(t, "support", protoRole.Parents[0].Name)
+	assert.Equal(t, 1, len(protoRole.InheritedPermissions))
+	assert.Equal(t, "front.*", protoRole.InheritedPermissions[0].Value)
 }
 
 // Test_RoleWithPermissionsFromProto tests the RoleWithPermissionsFromProto function
@@ -144,6 +151,9 @@ func Test_RoleWithPermissionsFromProto(t *testing.T) {
 		},
 	}
 
+	protoRole.Parents = []*securitypb.Role{{Id: uuid.New().String(), Name: "support"}}
+	protoRole.InheritedPermissions = []*securitypb.Permission{{Id: uuid.New().String(), Value: "front.*"}}
+
 	// Convert to model
 	role := RoleWithPermissionsFromProto(protoRole)
 
@@ -152,6 +162,10 @@ func Test_RoleWithPermissionsFromProto(t *testing.T) {
 	assert.Equal(t, "admin", role.Role.Name)
 	assert.Equal(t, 1, len(role.Permissions))
 	assert.Equal(t, "read", role.Permissions[0].Value)
+	assert.Equal(t, 1, len(role.Parents))
+	assert.Equal(t, "support", role.Parents[0].Name)
+	assert.Equal(t, 1, len(role.InheritedPermissions))
+	assert.Equal(t, "front.*", role.InheritedPermissions[0].Value)
 }
 
 // Test_RolesWithPermissionsToProto tests the RolesWithPermissionsToProto function
@@ -0,0 +1,52 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SessionToProto converts a models.Session to an authpb.Session
+func SessionToProto(session models.Session) *authpb.Session {
+	return &authpb.Session{
+		Id:         session.ID.String(),
+		UserId:     session.UserID.String(),
+		UserAgent:  session.UserAgent,
+		IpAddress:  session.IPAddress,
+		CreatedAt:  timestamppb.New(session.CreatedAt),
+		LastSeenAt: timestamppb.New(session.LastSeenAt),
+		ExpiresAt:  timestamppb.New(session.ExpiresAt),
+	}
+}
+
+// SessionFromProto converts an authpb.Session to a models.Session
+func SessionFromProto(session *authpb.Session) models.Session {
+	return models.Session{
+		ID:         uuid.MustParse(session.GetId()),
+		UserID:     uuid.MustParse(session.GetUserId()),
+		UserAgent:  session.GetUserAgent(),
+		IPAddress:  session.GetIpAddress(),
+		CreatedAt:  session.GetCreatedAt().AsTime(),
+		LastSeenAt: session.GetLastSeenAt().AsTime(),
+		ExpiresAt:  session.GetExpiresAt().AsTime(),
+	}
+}
+
+// SessionsToProto converts a slice of models.Session to a slice of authpb.Session
+func SessionsToProto(sessions []models.Session) []*authpb.Session {
+	protoSessions := make([]*authpb.Session, len(sessions))
+	for i, session := range sessions {
+		protoSessions[i] = SessionToProto(session)
+	}
+	return protoSessions
+}
+
+// SessionsFromProto converts a slice of authpb.Session to a slice of models.Session
+func SessionsFromProto(sessions []*authpb.Session) []models.Session {
+	modelSessions := make([]models.Session, len(sessions))
+	for i, session := range sessions {
+		modelSessions[i] = SessionFromProto(session)
+	}
+	return modelSessions
+}
@@ -0,0 +1,79 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/authpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+// Test_SessionToProto tests the SessionToProto function
+func Test_SessionToProto(t *testing.T) {
+	session := models.Session{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		UserAgent:  "Mozilla/5.0",
+		IPAddress:  "127.0.0.1",
+		CreatedAt:  time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC),
+		LastSeenAt: time.Date(2023, 5, 15, 11, 30, 0, 0, time.UTC),
+		ExpiresAt:  time.Date(2023, 5, 15, 22, 30, 0, 0, time.UTC),
+	}
+
+	result := SessionToProto(session)
+
+	assert.Equal(t, session.ID.String(), result.Id)
+	assert.Equal(t, session.UserID.String(), result.UserId)
+	assert.Equal(t, session.UserAgent, result.UserAgent)
+	assert.Equal(t, session.IPAddress, result.IpAddress)
+	assert.Equal(t, session.CreatedAt, result.CreatedAt.AsTime())
+	assert.Equal(t, session.LastSeenAt, result.LastSeenAt.AsTime())
+	assert.Equal(t, session.ExpiresAt, result.ExpiresAt.AsTime())
+}
+
+// Test_SessionFromProto tests the SessionFromProto function
+func Test_SessionFromProto(t *testing.T) {
+	testDate := time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC)
+	protoSession := &authpb.Session{
+		Id:         uuid.New().String(),
+		UserId:     uuid.New().String(),
+		UserAgent:  "Mozilla/5.0",
+		IpAddress:  "127.0.0.1",
+		CreatedAt:  timestamppb.New(testDate),
+		LastSeenAt: timestamppb.New(testDate.Add(time.Hour)),
+		ExpiresAt:  timestamppb.New(testDate.Add(12 * time.Hour)),
+	}
+
+	result := SessionFromProto(protoSession)
+
+	assert.Equal(t, protoSession.Id, result.ID.String())
+	assert.Equal(t, protoSession.UserId, result.UserID.String())
+	assert.Equal(t, "Mozilla/5.0", result.UserAgent)
+	assert.Equal(t, "127.0.0.1", result.IPAddress)
+	assert.Equal(t, testDate, result.CreatedAt)
+	assert.Equal(t, testDate.Add(time.Hour), result.LastSeenAt)
+	assert.Equal(t, testDate.Add(12*time.Hour), result.ExpiresAt)
+	assert.False(t, result.Current)
+}
+
+// Test_SessionsToProto tests the SessionsToProto function
+func Test_SessionsToProto(t *testing.T) {
+	sessions := []models.Session{{ID: uuid.New(), UserID: uuid.New()}}
+
+	result := SessionsToProto(sessions)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, sessions[0].ID.String(), result[0].Id)
+}
+
+// Test_SessionsFromProto tests the SessionsFromProto function
+func Test_SessionsFromProto(t *testing.T) {
+	protoSessions := []*authpb.Session{{Id: uuid.New().String(), UserId: uuid.New().String()}}
+
+	result := SessionsFromProto(protoSessions)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, protoSessions[0].Id, result[0].ID.String())
+}
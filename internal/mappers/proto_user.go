@@ -9,21 +9,74 @@ import (
 
 // UserToProto converts a models.User to a userpb.User
 func UserToProto(user models.User) *userpb.User {
-	return &userpb.User{
-		Id:        user.ID.String(),
-		Email:     user.Email,
-		CreatedAt: timestamppb.New(user.CreatedAt),
-		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	protoUser := &userpb.User{
+		Id:                    user.ID.String(),
+		Email:                 user.Email,
+		EmailVerified:         user.EmailVerified,
+		CreatedAt:             timestamppb.New(user.CreatedAt),
+		UpdatedAt:             timestamppb.New(user.UpdatedAt),
+		Profile:               UserProfileToProto(user.UserProfile),
+		PasswordResetRequired: user.PasswordResetRequired,
 	}
+	if user.DeletionScheduledAt != nil {
+		protoUser.DeletionScheduledAt = timestamppb.New(*user.DeletionScheduledAt)
+	}
+	if user.DisabledAt != nil {
+		protoUser.DisabledAt = timestamppb.New(*user.DisabledAt)
+	}
+	if user.LastLoginAt != nil {
+		protoUser.LastLoginAt = timestamppb.New(*user.LastLoginAt)
+	}
+	return protoUser
 }
 
 // UserFromProto converts a userpb.User to a models.User
 func UserFromProto(user *userpb.User) models.User {
-	return models.User{
-		ID:        uuid.MustParse(user.GetId()),
-		Email:     user.GetEmail(),
-		CreatedAt: user.GetCreatedAt().AsTime(),
-		UpdatedAt: user.GetUpdatedAt().AsTime(),
+	result := models.User{
+		ID:                    uuid.MustParse(user.GetId()),
+		Email:                 user.GetEmail(),
+		EmailVerified:         user.GetEmailVerified(),
+		CreatedAt:             user.GetCreatedAt().AsTime(),
+		UpdatedAt:             user.GetUpdatedAt().AsTime(),
+		UserProfile:           UserProfileFromProto(user.GetProfile()),
+		PasswordResetRequired: user.GetPasswordResetRequired(),
+	}
+	if user.GetDeletionScheduledAt() != nil {
+		deletionScheduledAt := user.GetDeletionScheduledAt().AsTime()
+		result.DeletionScheduledAt = &deletionScheduledAt
+	}
+	if user.GetDisabledAt() != nil {
+		disabledAt := user.GetDisabledAt().AsTime()
+		result.DisabledAt = &disabledAt
+	}
+	if user.GetLastLoginAt() != nil {
+		lastLoginAt := user.GetLastLoginAt().AsTime()
+		result.LastLoginAt = &lastLoginAt
+	}
+	return result
+}
+
+// UserProfileToProto converts a models.UserProfile to a userpb.UserProfile
+func UserProfileToProto(profile models.UserProfile) *userpb.UserProfile {
+	return &userpb.UserProfile{
+		DisplayName:  profile.DisplayName,
+		Language:     profile.Language,
+		Timezone:     profile.Timezone,
+		BaseCurrency: profile.BaseCurrency,
+		NumberFormat: profile.NumberFormat,
+		DateFormat:   profile.DateFormat,
+	}
+}
+
+// UserProfileFromProto converts a userpb.UserProfile to a models.UserProfile
+func UserProfileFromProto(profile *userpb.UserProfile) models.UserProfile {
+	return models.UserProfile{
+		DisplayName:  profile.GetDisplayName(),
+		Language:     profile.GetLanguage(),
+		Timezone:     profile.GetTimezone(),
+		BaseCurrency: profile.GetBaseCurrency(),
+		NumberFormat: profile.GetNumberFormat(),
+		DateFormat:   profile.GetDateFormat(),
 	}
 }
 
@@ -0,0 +1,41 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// UserFilterFromProto converts a userpb.ListUsersRequest to a models.UserFilter.
+// An error is returned when a user ID or the cursor cannot be parsed, the filter still has to be validated.
+func UserFilterFromProto(req *userpb.ListUsersRequest) (models.UserFilter, error) {
+	filter := models.UserFilter{
+		Email:  req.GetEmail(),
+		Status: req.GetStatus(),
+		Sort:   req.GetSort(),
+		Limit:  int(req.GetLimit()),
+	}
+	for _, id := range req.GetUserIds() {
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			return models.UserFilter{}, models.ErrUserIDInvalid
+		}
+		filter.UserIDs = append(filter.UserIDs, userID)
+	}
+	if req.GetCreatedFrom() != nil {
+		from := req.GetCreatedFrom().AsTime()
+		filter.CreatedFrom = &from
+	}
+	if req.GetCreatedTo() != nil {
+		to := req.GetCreatedTo().AsTime()
+		filter.CreatedTo = &to
+	}
+	if req.GetAfter() != "" {
+		cursor, err := models.ParseUserCursor(req.GetAfter())
+		if err != nil {
+			return models.UserFilter{}, err
+		}
+		filter.After = &cursor
+	}
+	return filter, nil
+}
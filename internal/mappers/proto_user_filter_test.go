@@ -0,0 +1,54 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+// TestUserFilterFromProto tests the UserFilterFromProto function
+func TestUserFilterFromProto(t *testing.T) {
+	userID := uuid.New()
+	to := time.Now().UTC()
+	from := to.Add(-time.Hour)
+	cursor := models.UserCursor{Sort: models.UserSortEmailAsc, Email: "jane@example.com", ID: uuid.New()}
+
+	filter, err := UserFilterFromProto(&userpb.ListUsersRequest{
+		Email:       "jane",
+		Sort:        models.UserSortEmailAsc,
+		Limit:       10,
+		After:       cursor.Encode(),
+		UserIds:     []string{userID.String()},
+		CreatedFrom: timestamppb.New(from),
+		CreatedTo:   timestamppb.New(to),
+		Status:      models.UserStatusActive,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, models.UserFilter{
+		Email:       "jane",
+		UserIDs:     []uuid.UUID{userID},
+		CreatedFrom: &from,
+		CreatedTo:   &to,
+		Status:      models.UserStatusActive,
+		Sort:        models.UserSortEmailAsc,
+		Limit:       10,
+		After:       &cursor,
+	}, filter)
+
+	// Empty request
+	filter, err = UserFilterFromProto(&userpb.ListUsersRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, models.UserFilter{}, filter)
+
+	// Invalid user ID
+	_, err = UserFilterFromProto(&userpb.ListUsersRequest{UserIds: []string{"invalid"}})
+	assert.Equal(t, models.ErrUserIDInvalid, err)
+
+	// Invalid cursor
+	_, err = UserFilterFromProto(&userpb.ListUsersRequest{After: "invalid"})
+	assert.Equal(t, models.ErrUserCursorInvalid, err)
+}
@@ -0,0 +1,54 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UserLoginToProto converts a models.UserLogin to a userpb.UserLogin
+func UserLoginToProto(login models.UserLogin) *userpb.UserLogin {
+	return &userpb.UserLogin{
+		Id:         login.ID.String(),
+		UserId:     login.UserID.String(),
+		OccurredAt: timestamppb.New(login.OccurredAt),
+		IpAddress:  login.IPAddress,
+		UserAgent:  login.UserAgent,
+		Method:     login.Method,
+		Success:    login.Success,
+		Reason:     login.Reason,
+	}
+}
+
+// UserLoginFromProto converts a userpb.UserLogin to a models.UserLogin
+func UserLoginFromProto(login *userpb.UserLogin) models.UserLogin {
+	return models.UserLogin{
+		ID:         uuid.MustParse(login.GetId()),
+		UserID:     uuid.MustParse(login.GetUserId()),
+		OccurredAt: login.GetOccurredAt().AsTime(),
+		IPAddress:  login.GetIpAddress(),
+		UserAgent:  login.GetUserAgent(),
+		Method:     login.GetMethod(),
+		Success:    login.GetSuccess(),
+		Reason:     login.GetReason(),
+	}
+}
+
+// UserLoginsToProto converts a slice of models.UserLogin to a slice of userpb.UserLogin
+func UserLoginsToProto(logins models.UserLogins) []*userpb.UserLogin {
+	protoLogins := make([]*userpb.UserLogin, len(logins))
+	for i, login := range logins {
+		protoLogins[i] = UserLoginToProto(login)
+	}
+	return protoLogins
+}
+
+// UserLoginsFromProto converts a slice of userpb.UserLogin to a slice of models.UserLogin
+func UserLoginsFromProto(logins []*userpb.UserLogin) models.UserLogins {
+	modelLogins := make(models.UserLogins, len(logins))
+	for i, login := range logins {
+		modelLogins[i] = UserLoginFromProto(login)
+	}
+	return modelLogins
+}
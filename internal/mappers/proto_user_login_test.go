@@ -0,0 +1,81 @@
+package mappers
+
+import (
+	"github.com/Zapharaos/fihub-backend/gen/go/userpb"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+// Test_UserLoginToProto tests the UserLoginToProto function
+func Test_UserLoginToProto(t *testing.T) {
+	login := models.UserLogin{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		OccurredAt: time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC),
+		IPAddress:  "127.0.0.1",
+		UserAgent:  "Mozilla/5.0",
+		Method:     models.LoginMethodPassword,
+		Success:    false,
+		Reason:     "invalid-credentials",
+	}
+
+	result := UserLoginToProto(login)
+
+	assert.Equal(t, login.ID.String(), result.Id)
+	assert.Equal(t, login.UserID.String(), result.UserId)
+	assert.Equal(t, login.OccurredAt, result.OccurredAt.AsTime())
+	assert.Equal(t, "127.0.0.1", result.IpAddress)
+	assert.Equal(t, "Mozilla/5.0", result.UserAgent)
+	assert.Equal(t, models.LoginMethodPassword, result.Method)
+	assert.False(t, result.Success)
+	assert.Equal(t, "invalid-credentials", result.Reason)
+}
+
+// Test_UserLoginFromProto tests the UserLoginFromProto function
+func Test_UserLoginFromProto(t *testing.T) {
+	testDate := time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC)
+	protoLogin := &userpb.UserLogin{
+		Id:         uuid.New().String(),
+		UserId:     uuid.New().String(),
+		OccurredAt: timestamppb.New(testDate),
+		IpAddress:  "127.0.0.1",
+		UserAgent:  "Mozilla/5.0",
+		Method:     models.LoginMethodPassword,
+		Success:    true,
+	}
+
+	result := UserLoginFromProto(protoLogin)
+
+	assert.Equal(t, protoLogin.Id, result.ID.String())
+	assert.Equal(t, protoLogin.UserId, result.UserID.String())
+	assert.Equal(t, testDate, result.OccurredAt)
+	assert.Equal(t, "127.0.0.1", result.IPAddress)
+	assert.Equal(t, "Mozilla/5.0", result.UserAgent)
+	assert.Equal(t, models.LoginMethodPassword, result.Method)
+	assert.True(t, result.Success)
+	assert.Empty(t, result.Reason)
+}
+
+// Test_UserLoginsToProto tests the UserLoginsToProto function
+func Test_UserLoginsToProto(t *testing.T) {
+	logins := models.UserLogins{{ID: uuid.New(), UserID: uuid.New()}}
+
+	result := UserLoginsToProto(logins)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, logins[0].ID.String(), result[0].Id)
+}
+
+// Test_UserLoginsFromProto tests the UserLoginsFromProto function
+func Test_UserLoginsFromProto(t *testing.T) {
+	protoLogins := []*userpb.UserLogin{{Id: uuid.New().String(), UserId: uuid.New().String()}}
+
+	result := UserLoginsFromProto(protoLogins)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, protoLogins[0].Id, result[0].ID.String())
+}
@@ -16,16 +16,29 @@ func Test_UserToProto(t *testing.T) {
 	testDate := time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC)
 
 	user := models.User{
-		ID:        userId,
-		Email:     "email@example.com",
-		CreatedAt: testDate,
+		ID:                    userId,
+		Email:                 "email@example.com",
+		EmailVerified:         true,
+		CreatedAt:             testDate,
+		DeletionScheduledAt:   &testDate,
+		DisabledAt:            &testDate,
+		LastLoginAt:           &testDate,
+		PasswordResetRequired: true,
+		UserProfile:           models.UserProfile{DisplayName: "Jane", Language: "fr"},
 	}
 
 	result := UserToProto(user)
 
 	assert.Equal(t, userId.String(), result.Id)
 	assert.Equal(t, "email@example.com", result.Email)
+	assert.True(t, result.EmailVerified)
 	assert.Equal(t, testDate.Unix(), result.CreatedAt.AsTime().Unix())
+	assert.Equal(t, testDate.Unix(), result.DeletionScheduledAt.AsTime().Unix())
+	assert.Equal(t, testDate.Unix(), result.DisabledAt.AsTime().Unix())
+	assert.Equal(t, testDate.Unix(), result.LastLoginAt.AsTime().Unix())
+	assert.True(t, result.PasswordResetRequired)
+	assert.Equal(t, "Jane", result.Profile.DisplayName)
+	assert.Equal(t, "fr", result.Profile.Language)
 }
 
 // Test_UserFromProto tests the UserFromProto function
@@ -34,16 +47,88 @@ func Test_UserFromProto(t *testing.T) {
 	testDate := time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC)
 
 	protoUser := &userpb.User{
-		Id:        userId.String(),
-		Email:     "email@example.com",
-		CreatedAt: timestamppb.New(testDate),
+		Id:            userId.String(),
+		Email:         "email@example.com",
+		EmailVerified: true,
+		CreatedAt:     timestamppb.New(testDate),
+		Profile:       &userpb.UserProfile{DisplayName: "Jane", Language: "fr"},
 	}
 
 	result := UserFromProto(protoUser)
 
 	assert.Equal(t, userId, result.ID)
 	assert.Equal(t, "email@example.com", result.Email)
+	assert.True(t, result.EmailVerified)
 	assert.Equal(t, testDate.Unix(), result.CreatedAt.Unix())
+	assert.Nil(t, result.DeletionScheduledAt)
+	assert.Nil(t, result.DisabledAt)
+	assert.Nil(t, result.LastLoginAt)
+	assert.False(t, result.PasswordResetRequired)
+	assert.Equal(t, models.UserProfile{DisplayName: "Jane", Language: "fr"}, result.UserProfile)
+
+	// Pending deletion
+	protoUser.DeletionScheduledAt = timestamppb.New(testDate)
+	result = UserFromProto(protoUser)
+	assert.Equal(t, testDate.Unix(), result.DeletionScheduledAt.Unix())
+
+	// Disabled and forced to reset the password
+	protoUser.DisabledAt = timestamppb.New(testDate)
+	protoUser.PasswordResetRequired = true
+	result = UserFromProto(protoUser)
+	assert.Equal(t, testDate.Unix(), result.DisabledAt.Unix())
+	assert.True(t, result.PasswordResetRequired)
+
+	// Logged in
+	protoUser.LastLoginAt = timestamppb.New(testDate)
+	result = UserFromProto(protoUser)
+	assert.Equal(t, testDate.Unix(), result.LastLoginAt.Unix())
+}
+
+// Test_UserProfileToProto tests the UserProfileToProto function
+func Test_UserProfileToProto(t *testing.T) {
+	profile := models.UserProfile{
+		DisplayName:  "Jane",
+		Language:     "fr",
+		Timezone:     "Europe/Paris",
+		BaseCurrency: "EUR",
+		NumberFormat: "1 234,56",
+		DateFormat:   "DD/MM/YYYY",
+	}
+
+	result := UserProfileToProto(profile)
+
+	assert.Equal(t, "Jane", result.DisplayName)
+	assert.Equal(t, "fr", result.Language)
+	assert.Equal(t, "Europe/Paris", result.Timezone)
+	assert.Equal(t, "EUR", result.BaseCurrency)
+	assert.Equal(t, "1 234,56", result.NumberFormat)
+	assert.Equal(t, "DD/MM/YYYY", result.DateFormat)
+}
+
+// Test_UserProfileFromProto tests the UserProfileFromProto function
+func Test_UserProfileFromProto(t *testing.T) {
+	protoProfile := &userpb.UserProfile{
+		DisplayName:  "Jane",
+		Language:     "fr",
+		Timezone:     "Europe/Paris",
+		BaseCurrency: "EUR",
+		NumberFormat: "1 234,56",
+		DateFormat:   "DD/MM/YYYY",
+	}
+
+	result := UserProfileFromProto(protoProfile)
+
+	assert.Equal(t, models.UserProfile{
+		DisplayName:  "Jane",
+		Language:     "fr",
+		Timezone:     "Europe/Paris",
+		BaseCurrency: "EUR",
+		NumberFormat: "1 234,56",
+		DateFormat:   "DD/MM/YYYY",
+	}, result)
+
+	// Missing profile
+	assert.Equal(t, models.UserProfile{}, UserProfileFromProto(nil))
 }
 
 // Test_UsersToProto tests the UsersToProto function
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	ErrAuditActionRequired = errors.New("audit-action-required")
+	ErrAuditOutcomeInvalid = errors.New("audit-outcome-invalid")
+	ErrAuditWindowInvalid  = errors.New("audit-window-invalid")
+)
+
+const (
+	AuditLogsDefaultLimit = 50
+	AuditLogsMaxLimit     = 500
+)
+
+type AuditAction = string
+
+// Actions recorded in the audit log
+const (
+	AuditActionLogin                = "auth.login"
+	AuditActionImpersonate          = "auth.impersonate"
+	AuditActionPasswordResetRequest = "auth.password.reset.request"
+	AuditActionPasswordReset        = "auth.password.reset"
+	AuditActionPermissionDenied     = "security.permission.denied"
+	AuditActionPermissionCreate     = "security.permission.create"
+	AuditActionPermissionUpdate     = "security.permission.update"
+	AuditActionPermissionDelete     = "security.permission.delete"
+	AuditActionRoleCreate           = "security.role.create"
+	AuditActionRoleUpdate           = "security.role.update"
+	AuditActionRoleDelete           = "security.role.delete"
+	AuditActionRolePermissionsSet   = "security.role.permissions.set"
+	AuditActionRoleParentsSet       = "security.role.parents.set"
+	AuditActionRoleUsersAdd         = "security.role.users.add"
+	AuditActionRoleUsersRemove      = "security.role.users.remove"
+	AuditActionUserRolesSet         = "security.user.roles.set"
+	AuditActionUserRoleGrant        = "security.user.roles.grant"
+	AuditActionUserExport           = "user.export"
+	AuditActionUserExportDownload   = "user.export.download"
+)
+
+type AuditOutcome = string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+	AuditOutcomeDenied  AuditOutcome = "denied"
+)
+
+var validAuditOutcomes = []AuditOutcome{AuditOutcomeSuccess, AuditOutcomeFailure, AuditOutcomeDenied}
+
+// Types of the targets recorded in the audit log
+const (
+	AuditTargetUser       = "user"
+	AuditTargetRole       = "role"
+	AuditTargetPermission = "permission"
+)
+
+// AuditDetailImpersonatedUser is the detail set on the entries recorded while an actor impersonates a user
+const AuditDetailImpersonatedUser = "impersonated_user_id"
+
+// AuditDetails holds the free-form context of an audit log entry, stored as JSON
+type AuditDetails map[string]string
+
+// Value implements driver.Valuer
+func (d AuditDetails) Value() (driver.Value, error) {
+	if d == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(d)
+}
+
+// Scan implements sql.Scanner
+func (d *AuditDetails) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*d = AuditDetails{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into AuditDetails", src)
+	}
+	return json.Unmarshal(data, d)
+}
+
+// AuditLog represents a security relevant event : who (actor) did what (action) to which target, and how it ended
+type AuditLog struct {
+	ID         uuid.UUID     `json:"id" db:"id"`
+	OccurredAt time.Time     `json:"occurred_at" db:"occurred_at"`
+	Action     AuditAction   `json:"action" db:"action"`
+	Outcome    AuditOutcome  `json:"outcome" db:"outcome"`
+	ActorID    uuid.NullUUID `json:"actor_id" db:"actor_id" swaggertype:"string"`
+	TargetType string        `json:"target_type" db:"target_type"`
+	TargetID   string        `json:"target_id" db:"target_id"`
+	RequestID  string        `json:"request_id" db:"request_id"`
+	IPAddress  string        `json:"ip_address" db:"ip_address"`
+	Details    AuditDetails  `json:"details" db:"details"`
+}
+
+// AuditLogs represents a list of AuditLog
+type AuditLogs []AuditLog
+
+// IsValid checks if an AuditLog is valid and has no missing mandatory fields
+func (l AuditLog) IsValid() (bool, error) {
+	if l.Action == "" {
+		return false, ErrAuditActionRequired
+	}
+	if !CheckAuditOutcome(l.Outcome) {
+		return false, ErrAuditOutcomeInvalid
+	}
+	return true, nil
+}
+
+// CheckAuditOutcome checks if the outcome is valid
+func CheckAuditOutcome(outcome AuditOutcome) bool {
+	for _, o := range validAuditOutcomes {
+		if o == outcome {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditLogFilter narrows the search of audit log entries, empty fields are ignored
+type AuditLogFilter struct {
+	Action     AuditAction
+	Outcome    AuditOutcome
+	ActorID    uuid.NullUUID
+	TargetType string
+	TargetID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// IsValid checks if an AuditLogFilter is valid
+func (f AuditLogFilter) IsValid() (bool, error) {
+	if f.Outcome != "" && !CheckAuditOutcome(f.Outcome) {
+		return false, ErrAuditOutcomeInvalid
+	}
+	if f.From != nil && f.To != nil && f.To.Before(*f.From) {
+		return false, ErrAuditWindowInvalid
+	}
+	return true, nil
+}
+
+// Normalize bounds the pagination of the filter
+func (f AuditLogFilter) Normalize() AuditLogFilter {
+	if f.Limit <= 0 {
+		f.Limit = AuditLogsDefaultLimit
+	}
+	if f.Limit > AuditLogsMaxLimit {
+		f.Limit = AuditLogsMaxLimit
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+	return f
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestAuditLog_IsValid tests the IsValid method of the AuditLog struct
+func TestAuditLog_IsValid(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name     string
+		log      AuditLog
+		expected bool
+		err      error
+	}{
+		{
+			name:     "valid",
+			log:      AuditLog{Action: AuditActionLogin, Outcome: AuditOutcomeSuccess},
+			expected: true,
+			err:      nil,
+		},
+		{
+			name:     "missing action",
+			log:      AuditLog{Outcome: AuditOutcomeSuccess},
+			expected: false,
+			err:      ErrAuditActionRequired,
+		},
+		{
+			name:     "invalid outcome",
+			log:      AuditLog{Action: AuditActionLogin, Outcome: "unknown"},
+			expected: false,
+			err:      ErrAuditOutcomeInvalid,
+		},
+	}
+
+	// Run test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := tt.log.IsValid()
+			assert.Equal(t, tt.expected, valid)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+// TestAuditLogFilter_IsValid tests the IsValid and Normalize methods of the AuditLogFilter struct
+func TestAuditLogFilter_IsValid(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+
+	valid, err := AuditLogFilter{}.IsValid()
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = AuditLogFilter{Outcome: "unknown"}.IsValid()
+	assert.False(t, valid)
+	assert.Equal(t, ErrAuditOutcomeInvalid, err)
+
+	valid, err = AuditLogFilter{From: &now, To: &before}.IsValid()
+	assert.False(t, valid)
+	assert.Equal(t, ErrAuditWindowInvalid, err)
+
+	assert.Equal(t, AuditLogsDefaultLimit, AuditLogFilter{}.Normalize().Limit)
+	assert.Equal(t, AuditLogsMaxLimit, AuditLogFilter{Limit: AuditLogsMaxLimit + 1}.Normalize().Limit)
+	assert.Equal(t, 0, AuditLogFilter{Offset: -1}.Normalize().Offset)
+}
+
+// TestAuditDetails_Scan tests the Value and Scan methods of the AuditDetails type
+func TestAuditDetails_Scan(t *testing.T) {
+	details := AuditDetails{"permission": "admin.roles.read"}
+	value, err := details.Value()
+	assert.NoError(t, err)
+
+	var scanned AuditDetails
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, details, scanned)
+
+	assert.NoError(t, scanned.Scan(nil))
+	assert.Equal(t, AuditDetails{}, scanned)
+
+	assert.Error(t, scanned.Scan(42))
+
+	value, err = AuditDetails(nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("{}"), value)
+}
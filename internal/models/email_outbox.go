@@ -0,0 +1,165 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Zapharaos/fihub-backend/pkg/email"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"slices"
+	"time"
+)
+
+var (
+	ErrOutboxIdempotencyKeyRequired = errors.New("idempotency-key-required")
+	ErrOutboxStatusInvalid          = errors.New("status-invalid")
+)
+
+const (
+	OutboxEmailsDefaultLimit = 50
+	OutboxEmailsMaxLimit     = 500
+)
+
+type OutboxStatus = string
+
+// Statuses of the emails in the outbox : pending emails are waiting for their next attempt, sending emails are
+// being delivered by a worker, sent emails were accepted by the transport and dead emails exhausted their attempts.
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSending = "sending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusDead    = "dead"
+)
+
+// OutboxStatuses lists the supported statuses of the emails in the outbox
+var OutboxStatuses = []OutboxStatus{OutboxStatusPending, OutboxStatusSending, OutboxStatusSent, OutboxStatusDead}
+
+// OutboxAttachments holds the attachments of an email in the outbox, stored as JSON
+type OutboxAttachments []email.Attachment
+
+// Value implements driver.Valuer
+func (a OutboxAttachments) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner
+func (a *OutboxAttachments) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*a = OutboxAttachments{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into OutboxAttachments", src)
+	}
+	return json.Unmarshal(data, a)
+}
+
+// OutboxEmail represents an email waiting in the outbox to be delivered, or the record of its delivery.
+// The IdempotencyKey identifies the email among the enqueued ones, enqueueing the same key twice keeps the first email.
+// The contents are never exposed, and are dropped once the email is sent since they may hold secrets (e.g. an OTP).
+type OutboxEmail struct {
+	ID               uuid.UUID         `json:"id" db:"id"`
+	IdempotencyKey   string            `json:"idempotency_key" db:"idempotency_key"`
+	To               pq.StringArray    `json:"to" db:"to_addresses" swaggertype:"array,string"`
+	Cc               pq.StringArray    `json:"cc" db:"cc_addresses" swaggertype:"array,string"`
+	ReplyTo          string            `json:"reply_to" db:"reply_to"`
+	Subject          string            `json:"subject" db:"subject"`
+	PlainTextContent string            `json:"-" db:"plain_text_content"`
+	HTMLContent      string            `json:"-" db:"html_content"`
+	Attachments      OutboxAttachments `json:"-" db:"attachments"`
+	Status           OutboxStatus      `json:"status" db:"status"`
+	Attempts         int               `json:"attempts" db:"attempts"`
+	LastError        string            `json:"last_error" db:"last_error"`
+	NextAttemptAt    time.Time         `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at" db:"updated_at"`
+	SentAt           *time.Time        `json:"sent_at" db:"sent_at"`
+}
+
+// OutboxEmails represents a list of OutboxEmail
+type OutboxEmails []OutboxEmail
+
+// InitOutboxEmail creates a new pending OutboxEmail for the message, due right away
+func InitOutboxEmail(idempotencyKey string, message email.Message) OutboxEmail {
+	now := time.Now()
+	return OutboxEmail{
+		ID:               uuid.New(),
+		IdempotencyKey:   idempotencyKey,
+		To:               message.To,
+		Cc:               message.Cc,
+		ReplyTo:          message.ReplyTo,
+		Subject:          message.Subject,
+		PlainTextContent: message.PlainTextContent,
+		HTMLContent:      message.HTMLContent,
+		Attachments:      message.Attachments,
+		Status:           OutboxStatusPending,
+		NextAttemptAt:    now,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// IsValid checks if an OutboxEmail is valid and has no missing mandatory fields
+func (e OutboxEmail) IsValid() (bool, error) {
+	if e.IdempotencyKey == "" {
+		return false, ErrOutboxIdempotencyKeyRequired
+	}
+	if err := e.Message().Validate(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Message returns the email.Message to deliver
+func (e OutboxEmail) Message() email.Message {
+	return email.Message{
+		To:               e.To,
+		Cc:               e.Cc,
+		ReplyTo:          e.ReplyTo,
+		Subject:          e.Subject,
+		PlainTextContent: e.PlainTextContent,
+		HTMLContent:      e.HTMLContent,
+		Attachments:      e.Attachments,
+	}
+}
+
+// OutboxEmailFilter narrows the search of emails in the outbox, empty fields are ignored
+// Recipient matches the emails sent to the address, regardless of the case.
+type OutboxEmailFilter struct {
+	Status    OutboxStatus
+	Recipient string
+	Limit     int
+	Offset    int
+}
+
+// IsValid checks if an OutboxEmailFilter is valid
+func (f OutboxEmailFilter) IsValid() (bool, error) {
+	if f.Status != "" && !slices.Contains(OutboxStatuses, f.Status) {
+		return false, ErrOutboxStatusInvalid
+	}
+	return true, nil
+}
+
+// Normalize bounds the pagination of the filter
+func (f OutboxEmailFilter) Normalize() OutboxEmailFilter {
+	if f.Limit <= 0 {
+		f.Limit = OutboxEmailsDefaultLimit
+	}
+	if f.Limit > OutboxEmailsMaxLimit {
+		f.Limit = OutboxEmailsMaxLimit
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+	return f
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"github.com/Zapharaos/fihub-backend/pkg/email"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// TestInitOutboxEmail tests the InitOutboxEmail function and the Message method of the OutboxEmail struct
+func TestInitOutboxEmail(t *testing.T) {
+	message := email.NewMessage("jane@example.com", "Subject", "Plain", "<p>HTML</p>")
+	message.Cc = []string{"copy@example.com"}
+	message.Attachments = []email.Attachment{{Filename: "data.json", Content: []byte("{}")}}
+
+	outboxEmail := InitOutboxEmail("key", message)
+
+	assert.Equal(t, "key", outboxEmail.IdempotencyKey)
+	assert.Equal(t, OutboxStatusPending, outboxEmail.Status)
+	assert.Zero(t, outboxEmail.Attempts)
+	assert.Equal(t, outboxEmail.CreatedAt, outboxEmail.NextAttemptAt)
+	assert.Nil(t, outboxEmail.SentAt)
+	assert.Equal(t, message, outboxEmail.Message())
+}
+
+// TestOutboxEmail_IsValid tests the IsValid method of the OutboxEmail struct
+func TestOutboxEmail_IsValid(t *testing.T) {
+	message := email.NewMessage("jane@example.com", "Subject", "Plain", "")
+
+	valid, err := InitOutboxEmail("key", message).IsValid()
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = InitOutboxEmail("", message).IsValid()
+	assert.False(t, valid)
+	assert.Equal(t, ErrOutboxIdempotencyKeyRequired, err)
+
+	valid, err = InitOutboxEmail("key", email.Message{Subject: "Subject"}).IsValid()
+	assert.False(t, valid)
+	assert.ErrorIs(t, err, email.ErrNoRecipient)
+}
+
+// TestOutboxAttachments tests the Value and Scan methods of the OutboxAttachments type
+func TestOutboxAttachments(t *testing.T) {
+	attachments := OutboxAttachments{{Filename: "data.json", ContentType: "application/json", Content: []byte("{}")}}
+
+	value, err := attachments.Value()
+	assert.NoError(t, err)
+
+	var scanned OutboxAttachments
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, attachments, scanned)
+
+	value, err = OutboxAttachments(nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("[]"), value)
+
+	assert.NoError(t, scanned.Scan(nil))
+	assert.Empty(t, scanned)
+
+	assert.Error(t, scanned.Scan(42))
+}
+
+// TestOutboxEmailFilter_IsValid tests the IsValid and Normalize methods of the OutboxEmailFilter struct
+func TestOutboxEmailFilter_IsValid(t *testing.T) {
+	valid, err := OutboxEmailFilter{}.IsValid()
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = OutboxEmailFilter{Status: OutboxStatusDead, Recipient: "jane@example.com"}.IsValid()
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = OutboxEmailFilter{Status: "lost"}.IsValid()
+	assert.False(t, valid)
+	assert.Equal(t, ErrOutboxStatusInvalid, err)
+
+	assert.Equal(t, OutboxEmailsDefaultLimit, OutboxEmailFilter{}.Normalize().Limit)
+	assert.Equal(t, OutboxEmailsMaxLimit, OutboxEmailFilter{Limit: OutboxEmailsMaxLimit + 1}.Normalize().Limit)
+	assert.Zero(t, OutboxEmailFilter{Offset: -1}.Normalize().Offset)
+}
@@ -0,0 +1,10 @@
+package models
+
+// EmailTemplatePreview represents an email template rendered with its sample data in a language
+type EmailTemplatePreview struct {
+	Name             string `json:"name"`
+	Language         string `json:"language"`
+	Subject          string `json:"subject"`
+	PlainTextContent string `json:"plain_text_content"`
+	HTMLContent      string `json:"html_content"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/utils"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"time"
+)
+
+// EmailVerificationInput represents the input used to verify an email address
+type EmailVerificationInput struct {
+	UserID uuid.UUID `json:"user_id"`
+	Token  string    `json:"token"`
+}
+
+// EmailVerificationInputResend represents the input used to resend an email verification
+type EmailVerificationInputResend struct {
+	Email string `json:"email"`
+}
+
+// EmailVerification represents a pending verification of an email address.
+// The email differs from the user's current one when the verification confirms an email change.
+type EmailVerification struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InitEmailVerification creates a new EmailVerification for the user and the email address to verify
+func InitEmailVerification(userID uuid.UUID, email string) (EmailVerification, time.Duration) {
+	duration := viper.GetDuration("EMAIL_VERIFICATION_DURATION")
+	if duration == 0 {
+		duration = time.Hour
+	}
+	length := viper.GetInt("EMAIL_VERIFICATION_TOKEN_LENGTH")
+	if length == 0 {
+		length = 6
+	}
+	return EmailVerification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Email:     email,
+		Token:     utils.RandDigitString(length),
+		ExpiresAt: time.Now().Add(duration),
+		CreatedAt: time.Now(),
+	}, duration
+}
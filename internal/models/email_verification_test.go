@@ -0,0 +1,28 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestInitEmailVerification tests the InitEmailVerification function
+func TestInitEmailVerification(t *testing.T) {
+	// Mock the viper configuration
+	viper.Set("EMAIL_VERIFICATION_DURATION", 30*time.Minute)
+	viper.Set("EMAIL_VERIFICATION_TOKEN_LENGTH", 8)
+	defer viper.Reset()
+
+	userID := uuid.New()
+	verification, duration := InitEmailVerification(userID, "new@example.com")
+
+	assert.NotEqual(t, uuid.Nil, verification.ID)
+	assert.Equal(t, userID, verification.UserID)
+	assert.Equal(t, "new@example.com", verification.Email)
+	assert.Len(t, verification.Token, 8)
+	assert.Equal(t, 30*time.Minute, duration)
+	assert.WithinDuration(t, time.Now().Add(duration), verification.ExpiresAt, time.Second)
+	assert.WithinDuration(t, time.Now(), verification.CreatedAt, time.Second)
+}
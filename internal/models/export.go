@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// UserExport gathers a copy of the personal data held by the services on a user
+type UserExport struct {
+	GeneratedAt  time.Time     `json:"generated_at"`
+	User         User          `json:"user"`
+	Brokers      []BrokerUser  `json:"brokers"`
+	Transactions []Transaction `json:"transactions"`
+	Roles        Roles         `json:"roles"`
+	RoleGrants   RoleGrants    `json:"role_grants"`
+	AuditLogs    AuditLogs     `json:"audit_logs"`
+}
+
+// ExportLink represents a signed and expiring link to download an export archive
+type ExportLink struct {
+	ID        uuid.UUID `json:"id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ImpersonationRequest represents the justification given by an administrator to impersonate a user
+type ImpersonationRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ImpersonationToken represents a short-lived token to act as a user on behalf of an administrator
+type ImpersonationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
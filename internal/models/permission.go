@@ -27,10 +27,11 @@ type Scope = string
 
 const (
 	AdminScope Scope = "admin"
+	FrontScope Scope = "front"
 	AllScope   Scope = "all"
 )
 
-var validScopes = []Scope{AdminScope, AllScope}
+var validScopes = []Scope{AdminScope, FrontScope, AllScope}
 
 // IsValid checks if a permission is valid and has no missing mandatory fields
 func (p Permission) IsValid() (bool, error) {
@@ -77,6 +78,61 @@ func (p Permission) Match(permission string) bool {
 	return re.MatchString(permission)
 }
 
+// HasPermission returns true if any of the permissions matches the given permission.
+// Wildcards (*) in permissions are supported.
+func (p Permissions) HasPermission(permission string) bool {
+	for _, perm := range p {
+		if perm.Match(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers returns true if every one of the given permissions, wildcards included, is granted by the permissions
+func (p Permissions) Covers(permissions Permissions) bool {
+	for _, perm := range permissions {
+		if !p.HasPermission(perm.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasScopePermission returns true if any of the permissions grants a permission of the scope, wildcards included
+func (p Permissions) HasScopePermission(scope Scope) bool {
+	for _, perm := range p {
+		if strings.HasPrefix(perm.Value, scope+".") || perm.Match(scope+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetValues returns the list of values for the permissions
+func (p Permissions) GetValues() []string {
+	values := make([]string, 0, len(p))
+	for _, perm := range p {
+		values = append(values, perm.Value)
+	}
+	return values
+}
+
+// UserAccess holds the names of the roles of a user and the values of its effective permissions
+type UserAccess struct {
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// PermissionsFromValues creates permissions holding only their values
+func PermissionsFromValues(values []string) Permissions {
+	permissions := make(Permissions, 0, len(values))
+	for _, value := range values {
+		permissions = append(permissions, Permission{Value: value})
+	}
+	return permissions
+}
+
 // GetUUIDs returns the list of UUIDs for the permissions
 func (p Permissions) GetUUIDs() []uuid.UUID {
 	uuids := make([]uuid.UUID, 0)
@@ -148,6 +148,45 @@ func TestPermission_Match(t *testing.T) {
 	}
 }
 
+// TestPermissions_HasPermission tests the HasPermission method
+func TestPermissions_HasPermission(t *testing.T) {
+	perms := PermissionsFromValues([]string{"admin.users.read", "admin.roles.*"})
+
+	assert.True(t, perms.HasPermission("admin.users.read"))
+	assert.True(t, perms.HasPermission("admin.roles.update"))
+	assert.False(t, perms.HasPermission("admin.users.update"))
+	assert.False(t, Permissions{}.HasPermission("admin.users.read"))
+}
+
+// TestPermissions_Covers tests the Covers method
+func TestPermissions_Covers(t *testing.T) {
+	perms := PermissionsFromValues([]string{"front.*", "admin.users.read"})
+
+	assert.True(t, perms.Covers(PermissionsFromValues([]string{"front.brokers", "front.*", "admin.users.read"})))
+	assert.True(t, perms.Covers(Permissions{}))
+	assert.False(t, perms.Covers(PermissionsFromValues([]string{"admin.users.*"})))
+	assert.False(t, perms.Covers(PermissionsFromValues([]string{"*"})))
+	assert.True(t, PermissionsFromValues([]string{"*"}).Covers(PermissionsFromValues([]string{"*"})))
+}
+
+// TestPermissions_HasScopePermission tests the HasScopePermission method
+func TestPermissions_HasScopePermission(t *testing.T) {
+	assert.True(t, PermissionsFromValues([]string{"admin.users.read"}).HasScopePermission(AdminScope))
+	assert.True(t, PermissionsFromValues([]string{"admin.*"}).HasScopePermission(AdminScope))
+	assert.True(t, PermissionsFromValues([]string{"*"}).HasScopePermission(AdminScope))
+	assert.False(t, PermissionsFromValues([]string{"front.*", "administration"}).HasScopePermission(AdminScope))
+	assert.False(t, Permissions{}.HasScopePermission(AdminScope))
+}
+
+// TestPermissions_GetValues tests the GetValues method and the PermissionsFromValues function
+func TestPermissions_GetValues(t *testing.T) {
+	values := []string{"admin.users.read", "admin.roles.*"}
+
+	assert.Equal(t, values, PermissionsFromValues(values).GetValues())
+	assert.Equal(t, []string{}, Permissions{}.GetValues())
+	assert.Equal(t, Permissions{}, PermissionsFromValues(nil))
+}
+
 // TestPermissions_GetUUIDs tests the GetUUIDs method of the Permissions struct
 func TestPermissions_GetUUIDs(t *testing.T) {
 	// Define valid values
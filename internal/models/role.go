@@ -6,8 +6,9 @@ import (
 )
 
 var (
-	ErrNameRequired = errors.New("name-required")
-	ErrNameInvalid  = errors.New("name-invalid")
+	ErrNameRequired       = errors.New("name-required")
+	ErrNameInvalid        = errors.New("name-invalid")
+	ErrRoleHierarchyCycle = errors.New("role-hierarchy-cycle")
 )
 
 const (
@@ -25,9 +26,12 @@ type Role struct {
 type Roles []Role
 
 // RoleWithPermissions represents a Role with its permissions.Permissions
+// The inherited permissions are granted through the parent roles, recursively.
 type RoleWithPermissions struct {
 	Role
-	Permissions Permissions `json:"permissions"`
+	Parents              Roles       `json:"parents"`
+	Permissions          Permissions `json:"permissions"`
+	InheritedPermissions Permissions `json:"inherited_permissions"`
 }
 
 // RolesWithPermissions represents a list of RoleWithPermissions
@@ -36,6 +40,9 @@ type RolesWithPermissions []RoleWithPermissions
 // RolePermissionsInput represents a list of Permission UUIDs at input
 type RolePermissionsInput []uuid.UUID
 
+// RoleHierarchy maps a Role UUID to the UUIDs of its parent roles
+type RoleHierarchy map[uuid.UUID][]uuid.UUID
+
 // IsValid checks if a Role is valid
 func (r Role) IsValid() (bool, error) {
 	if r.Name == "" {
@@ -56,12 +63,91 @@ func (r RolesWithPermissions) GetUUIDs() []uuid.UUID {
 	return uuids
 }
 
-// HasPermission returns true if the User has the given permission.
+// HasPermission returns true if the User has the given permission, directly or through inheritance.
 // Wildcards (*) in permissions are supported.
 func (r RolesWithPermissions) HasPermission(permission string) bool {
+	return r.GetPermissions().HasPermission(permission)
+}
+
+// GetPermissions returns the permissions granted by the roles, inherited ones included, without duplicates
+func (r RolesWithPermissions) GetPermissions() Permissions {
+	permissions := make(Permissions, 0)
+	seen := make(map[string]bool)
 	for _, role := range r {
+		for _, p := range append(role.Permissions, role.InheritedPermissions...) {
+			if p.Value == "" || seen[p.Value] {
+				continue
+			}
+			seen[p.Value] = true
+			permissions = append(permissions, p)
+		}
+	}
+	return permissions
+}
+
+// ResolveInheritance returns the roles with their parents and the permissions inherited from their ancestors
+func (r RolesWithPermissions) ResolveInheritance(hierarchy RoleHierarchy) RolesWithPermissions {
+	byId := make(map[uuid.UUID]RoleWithPermissions, len(r))
+	for _, role := range r {
+		byId[role.Id] = role
+	}
+
+	resolved := make(RolesWithPermissions, len(r))
+	for i, role := range r {
+		role.Parents = make(Roles, 0)
+		for _, parentUUID := range hierarchy[role.Id] {
+			if parent, ok := byId[parentUUID]; ok {
+				role.Parents = append(role.Parents, parent.Role)
+			}
+		}
+
+		// Inherit the permissions of every ancestor, without duplicating the direct ones
+		role.InheritedPermissions = make(Permissions, 0)
+		seen := make(map[uuid.UUID]bool)
 		for _, p := range role.Permissions {
-			if p.Match(permission) {
+			seen[p.Id] = true
+		}
+		for _, ancestorUUID := range hierarchy.Ancestors(role.Id) {
+			for _, p := range byId[ancestorUUID].Permissions {
+				if seen[p.Id] {
+					continue
+				}
+				seen[p.Id] = true
+				role.InheritedPermissions = append(role.InheritedPermissions, p)
+			}
+		}
+		resolved[i] = role
+	}
+	return resolved
+}
+
+// Ancestors returns the UUIDs of the parent roles of a role, recursively.
+// The traversal stops on cycles, hence a role never is its own ancestor.
+func (h RoleHierarchy) Ancestors(roleUUID uuid.UUID) []uuid.UUID {
+	ancestors := make([]uuid.UUID, 0)
+	visited := map[uuid.UUID]bool{roleUUID: true}
+	queue := append([]uuid.UUID{}, h[roleUUID]...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		ancestors = append(ancestors, current)
+		queue = append(queue, h[current]...)
+	}
+	return ancestors
+}
+
+// CreatesCycle checks if giving the parents to the role would create a cycle in the hierarchy
+func (h RoleHierarchy) CreatesCycle(roleUUID uuid.UUID, parentUUIDs []uuid.UUID) bool {
+	for _, parentUUID := range parentUUIDs {
+		if parentUUID == roleUUID {
+			return true
+		}
+		for _, ancestorUUID := range h.Ancestors(parentUUID) {
+			if ancestorUUID == roleUUID {
 				return true
 			}
 		}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	ErrRoleGrantWindowInvalid = errors.New("role-grant-window-invalid")
+	ErrRoleGrantReasonInvalid = errors.New("role-grant-reason-invalid")
+)
+
+const (
+	RoleGrantReasonMaxLength = 255
+)
+
+// RoleGrant represents the assignment of a Role to a User.
+// The grant is only effective between ValidFrom and ValidUntil, and never expires without ValidUntil.
+type RoleGrant struct {
+	UserID     uuid.UUID     `json:"user_id" db:"user_id"`
+	RoleID     uuid.UUID     `json:"role_id" db:"role_id"`
+	ValidFrom  time.Time     `json:"valid_from" db:"valid_from"`
+	ValidUntil *time.Time    `json:"valid_until,omitempty" db:"valid_until"`
+	Reason     string        `json:"reason" db:"reason"`
+	GrantedBy  uuid.NullUUID `json:"granted_by" db:"granted_by" swaggertype:"string"`
+}
+
+// RoleGrants represents a list of RoleGrant
+type RoleGrants []RoleGrant
+
+// IsValid checks if a RoleGrant is valid
+func (g RoleGrant) IsValid() (bool, error) {
+	if g.ValidUntil != nil && !g.ValidUntil.After(g.ValidFrom) {
+		return false, ErrRoleGrantWindowInvalid
+	}
+	if len(g.Reason) > RoleGrantReasonMaxLength {
+		return false, ErrRoleGrantReasonInvalid
+	}
+	return true, nil
+}
+
+// IsActive checks if the grant is effective at the given time
+func (g RoleGrant) IsActive(at time.Time) bool {
+	return !at.Before(g.ValidFrom) && !g.IsExpired(at)
+}
+
+// IsExpired checks if the grant has ended at the given time
+func (g RoleGrant) IsExpired(at time.Time) bool {
+	return g.ValidUntil != nil && !at.Before(*g.ValidUntil)
+}
+
+// NextBoundary returns the earliest date after the given time at which one of the grants starts or ends.
+// Returns the zero time when none of the grants changes afterward.
+func (gs RoleGrants) NextBoundary(at time.Time) time.Time {
+	var next time.Time
+	for _, grant := range gs {
+		boundaries := []time.Time{grant.ValidFrom}
+		if grant.ValidUntil != nil {
+			boundaries = append(boundaries, *grant.ValidUntil)
+		}
+		for _, boundary := range boundaries {
+			if boundary.After(at) && (next.IsZero() || boundary.Before(next)) {
+				next = boundary
+			}
+		}
+	}
+	return next
+}
+
+// GetUserUUIDs returns the UUIDs of the users of the grants, without duplicates
+func (gs RoleGrants) GetUserUUIDs() []uuid.UUID {
+	uuids := make([]uuid.UUID, 0, len(gs))
+	seen := make(map[uuid.UUID]bool)
+	for _, grant := range gs {
+		if seen[grant.UserID] {
+			continue
+		}
+		seen[grant.UserID] = true
+		uuids = append(uuids, grant.UserID)
+	}
+	return uuids
+}
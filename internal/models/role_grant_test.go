@@ -0,0 +1,111 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRoleGrant_IsValid tests the IsValid method of the RoleGrant struct
+func TestRoleGrant_IsValid(t *testing.T) {
+	now := time.Now()
+	later := now.Add(48 * time.Hour)
+
+	// Define test cases
+	tests := []struct {
+		name     string
+		grant    RoleGrant
+		expected bool
+		err      error
+	}{
+		{
+			name:     "permanent grant",
+			grant:    RoleGrant{ValidFrom: now},
+			expected: true,
+			err:      nil,
+		},
+		{
+			name:     "time-bound grant",
+			grant:    RoleGrant{ValidFrom: now, ValidUntil: &later, Reason: "support ticket"},
+			expected: true,
+			err:      nil,
+		},
+		{
+			name:     "grant ends before it starts",
+			grant:    RoleGrant{ValidFrom: later, ValidUntil: &now},
+			expected: false,
+			err:      ErrRoleGrantWindowInvalid,
+		},
+		{
+			name:     "grant ends when it starts",
+			grant:    RoleGrant{ValidFrom: now, ValidUntil: &now},
+			expected: false,
+			err:      ErrRoleGrantWindowInvalid,
+		},
+		{
+			name:     "reason too long",
+			grant:    RoleGrant{ValidFrom: now, Reason: strings.Repeat("a", RoleGrantReasonMaxLength+1)},
+			expected: false,
+			err:      ErrRoleGrantReasonInvalid,
+		},
+	}
+
+	// Run test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := tt.grant.IsValid()
+			assert.Equal(t, tt.expected, valid)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+// TestRoleGrant_IsActive tests the IsActive and IsExpired methods of the RoleGrant struct
+func TestRoleGrant_IsActive(t *testing.T) {
+	now := time.Now()
+	until := now.Add(48 * time.Hour)
+	grant := RoleGrant{ValidFrom: now, ValidUntil: &until}
+
+	assert.False(t, grant.IsActive(now.Add(-time.Minute)))
+	assert.True(t, grant.IsActive(now))
+	assert.True(t, grant.IsActive(until.Add(-time.Minute)))
+	assert.False(t, grant.IsActive(until))
+	assert.False(t, grant.IsExpired(now))
+	assert.True(t, grant.IsExpired(until))
+
+	permanent := RoleGrant{ValidFrom: now}
+	assert.True(t, permanent.IsActive(now.Add(24*365*time.Hour)))
+	assert.False(t, permanent.IsExpired(now.Add(24*365*time.Hour)))
+}
+
+// TestRoleGrants_NextBoundary tests the NextBoundary method of the RoleGrants type
+func TestRoleGrants_NextBoundary(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	soon := now.Add(time.Hour)
+	later := now.Add(48 * time.Hour)
+
+	assert.True(t, RoleGrants{}.NextBoundary(now).IsZero())
+	assert.True(t, RoleGrants{{ValidFrom: past}}.NextBoundary(now).IsZero())
+	assert.Equal(t, later, RoleGrants{{ValidFrom: past, ValidUntil: &later}}.NextBoundary(now))
+	assert.Equal(t, soon, RoleGrants{
+		{ValidFrom: past, ValidUntil: &later},
+		{ValidFrom: soon},
+	}.NextBoundary(now))
+}
+
+// TestRoleGrants_GetUserUUIDs tests the GetUserUUIDs method of the RoleGrants type
+func TestRoleGrants_GetUserUUIDs(t *testing.T) {
+	userA := uuid.New()
+	userB := uuid.New()
+	grants := RoleGrants{
+		{UserID: userA, RoleID: uuid.New()},
+		{UserID: userB, RoleID: uuid.New()},
+		{UserID: userA, RoleID: uuid.New()},
+	}
+
+	assert.Equal(t, []uuid.UUID{userA, userB}, grants.GetUserUUIDs())
+	assert.Empty(t, RoleGrants{}.GetUserUUIDs())
+}
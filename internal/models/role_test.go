@@ -114,6 +114,14 @@ func TestRolesWithPermissions_HasPermission(t *testing.T) {
 			permission: "admin.Users.read",
 			expected:   true,
 		},
+		{
+			name: "User has inherited permission",
+			roles: RolesWithPermissions{
+				{InheritedPermissions: Permissions{{Value: "admin.Users.read"}}},
+			},
+			permission: "admin.Users.read",
+			expected:   true,
+		},
 	}
 
 	// Run tests
@@ -125,6 +133,159 @@ func TestRolesWithPermissions_HasPermission(t *testing.T) {
 	}
 }
 
+// TestRolesWithPermissions_GetPermissions tests the GetPermissions method
+func TestRolesWithPermissions_GetPermissions(t *testing.T) {
+	roles := RolesWithPermissions{
+		{Permissions: Permissions{{Value: "admin.users.read"}, {Value: "admin.roles.*"}}},
+		{Permissions: Permissions{{Value: "admin.users.read"}, {Value: ""}}},
+	}
+
+	assert.Equal(t, []string{"admin.users.read", "admin.roles.*"}, roles.GetPermissions().GetValues())
+	assert.Equal(t, Permissions{}, RolesWithPermissions{}.GetPermissions())
+
+	// Inherited permissions are included
+	roles = RolesWithPermissions{
+		{
+			Permissions:          Permissions{{Value: "admin.users.read"}},
+			InheritedPermissions: Permissions{{Value: "admin.users.read"}, {Value: "front.*"}},
+		},
+	}
+	assert.Equal(t, []string{"admin.users.read", "front.*"}, roles.GetPermissions().GetValues())
+}
+
+// TestRolesWithPermissions_ResolveInheritance tests the ResolveInheritance method
+func TestRolesWithPermissions_ResolveInheritance(t *testing.T) {
+	read := Permission{Id: uuid.New(), Value: "admin.users.read"}
+	update := Permission{Id: uuid.New(), Value: "admin.users.update"}
+	front := Permission{Id: uuid.New(), Value: "front.*"}
+
+	support := RoleWithPermissions{Role: Role{Id: uuid.New(), Name: "support"}, Permissions: Permissions{read, front}}
+	moderator := RoleWithPermissions{Role: Role{Id: uuid.New(), Name: "moderator"}, Permissions: Permissions{update}}
+	admin := RoleWithPermissions{Role: Role{Id: uuid.New(), Name: "admin"}, Permissions: Permissions{read}}
+
+	hierarchy := RoleHierarchy{
+		admin.Id:     {moderator.Id},
+		moderator.Id: {support.Id},
+	}
+
+	resolved := RolesWithPermissions{support, moderator, admin}.ResolveInheritance(hierarchy)
+
+	// Support has no parent
+	assert.Equal(t, Roles{}, resolved[0].Parents)
+	assert.Equal(t, Permissions{}, resolved[0].InheritedPermissions)
+
+	// Moderator inherits from support
+	assert.Equal(t, Roles{support.Role}, resolved[1].Parents)
+	assert.Equal(t, Permissions{read, front}, resolved[1].InheritedPermissions)
+
+	// Admin inherits from moderator and support, without duplicating its direct permissions
+	assert.Equal(t, Roles{moderator.Role}, resolved[2].Parents)
+	assert.Equal(t, Permissions{read}, resolved[2].Permissions)
+	assert.Equal(t, Permissions{update, front}, resolved[2].InheritedPermissions)
+}
+
+// TestRoleHierarchy_Ancestors tests the Ancestors method
+func TestRoleHierarchy_Ancestors(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	// Define test cases
+	tests := []struct {
+		name      string
+		hierarchy RoleHierarchy
+		role      uuid.UUID
+		expected  []uuid.UUID
+	}{
+		{
+			name:      "No parent",
+			hierarchy: RoleHierarchy{},
+			role:      a,
+			expected:  []uuid.UUID{},
+		},
+		{
+			name:      "Chain of parents",
+			hierarchy: RoleHierarchy{a: {b}, b: {c}},
+			role:      a,
+			expected:  []uuid.UUID{b, c},
+		},
+		{
+			name:      "Diamond is traversed once",
+			hierarchy: RoleHierarchy{a: {b, c}, b: {d}, c: {d}},
+			role:      a,
+			expected:  []uuid.UUID{b, c, d},
+		},
+		{
+			name:      "Cycle stops the traversal",
+			hierarchy: RoleHierarchy{a: {b}, b: {a}},
+			role:      a,
+			expected:  []uuid.UUID{b},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.hierarchy.Ancestors(tt.role))
+		})
+	}
+}
+
+// TestRoleHierarchy_CreatesCycle tests the CreatesCycle method
+func TestRoleHierarchy_CreatesCycle(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+
+	// Define test cases
+	tests := []struct {
+		name      string
+		hierarchy RoleHierarchy
+		role      uuid.UUID
+		parents   []uuid.UUID
+		expected  bool
+	}{
+		{
+			name:      "No parent",
+			hierarchy: RoleHierarchy{},
+			role:      a,
+			parents:   nil,
+			expected:  false,
+		},
+		{
+			name:      "Role is its own parent",
+			hierarchy: RoleHierarchy{},
+			role:      a,
+			parents:   []uuid.UUID{a},
+			expected:  true,
+		},
+		{
+			name:      "Parent is a descendant",
+			hierarchy: RoleHierarchy{c: {b}, b: {a}},
+			role:      a,
+			parents:   []uuid.UUID{c},
+			expected:  true,
+		},
+		{
+			name:      "Shared ancestor is not a cycle",
+			hierarchy: RoleHierarchy{b: {c}},
+			role:      a,
+			parents:   []uuid.UUID{b, c},
+			expected:  false,
+		},
+		{
+			name:      "Replacing the parents of a role",
+			hierarchy: RoleHierarchy{a: {b}, b: {c}},
+			role:      b,
+			parents:   []uuid.UUID{c},
+			expected:  false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.hierarchy.CreatesCycle(tt.role, tt.parents))
+		})
+	}
+}
+
 // createRolesWithPermission creates a RolesWithPermissions instance with a single permission
 func createRolesWithPermission(permission string) RolesWithPermissions {
 	return RolesWithPermissions{
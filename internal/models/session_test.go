@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestInitSession tests the InitSession function
+func TestInitSession(t *testing.T) {
+	userID := uuid.New()
+	session := InitSession(userID, "Mozilla/5.0", "127.0.0.1", time.Hour)
+
+	assert.NotEqual(t, uuid.Nil, session.ID)
+	assert.Equal(t, userID, session.UserID)
+	assert.Equal(t, "Mozilla/5.0", session.UserAgent)
+	assert.Equal(t, "127.0.0.1", session.IPAddress)
+	assert.WithinDuration(t, time.Now(), session.CreatedAt, time.Second)
+	assert.Equal(t, session.CreatedAt, session.LastSeenAt)
+	assert.Equal(t, session.CreatedAt.Add(time.Hour), session.ExpiresAt)
+	assert.False(t, session.Current)
+}
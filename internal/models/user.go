@@ -41,11 +41,22 @@ type UserWithPassword struct {
 }
 
 // User represents a User entity in the system
+// DeletionScheduledAt is set while the account is pending deletion, until which logging in cancels the deletion.
+// DisabledAt is set while the account is disabled by an administrator, PasswordResetRequired while a password reset is forced :
+// in both cases the user can not log in.
+// LastLoginAt is the time of the last successful login, if any (see UserLogin struct).
+// UserProfile holds the preferences of the user (see UserProfile struct).
 type User struct {
-	ID        uuid.UUID `json:"ID" db:"id"`
-	Email     string    `json:"email" db:"email"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID                    uuid.UUID  `json:"ID" db:"id"`
+	Email                 string     `json:"email" db:"email"`
+	EmailVerified         bool       `json:"email_verified" db:"email_verified"`
+	CreatedAt             time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at" db:"updated_at"`
+	DeletionScheduledAt   *time.Time `json:"deletion_scheduled_at,omitempty" db:"deletion_scheduled_at"`
+	DisabledAt            *time.Time `json:"disabled_at,omitempty" db:"disabled_at"`
+	PasswordResetRequired bool       `json:"password_reset_required" db:"password_reset_required"`
+	LastLoginAt           *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
+	UserProfile
 }
 
 type Users []User
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+var (
+	ErrUserSortInvalid    = errors.New("sort-invalid")
+	ErrUserStatusInvalid  = errors.New("status-invalid")
+	ErrUserCreatedInvalid = errors.New("created-invalid")
+	ErrUserCursorInvalid  = errors.New("cursor-invalid")
+	ErrUserRoleInvalid    = errors.New("role-invalid")
+	ErrUserIDInvalid      = errors.New("user-id-invalid")
+)
+
+const (
+	UsersDefaultLimit = 50
+	UsersMaxLimit     = 500
+)
+
+type UserSort = string
+
+// Sorts of the users, the "-" prefix stands for the descending order
+const (
+	UserSortCreatedAtAsc  = "created_at"
+	UserSortCreatedAtDesc = "-created_at"
+	UserSortEmailAsc      = "email"
+	UserSortEmailDesc     = "-email"
+)
+
+// UserSorts lists the supported sorts of the users
+var UserSorts = []UserSort{UserSortCreatedAtAsc, UserSortCreatedAtDesc, UserSortEmailAsc, UserSortEmailDesc}
+
+type UserStatus = string
+
+// Statuses of the users : an active account is enabled, verified and not pending deletion,
+// the other statuses match the accounts in the given state, whatever their other attributes.
+const (
+	UserStatusActive          = "active"
+	UserStatusUnverified      = "unverified"
+	UserStatusDisabled        = "disabled"
+	UserStatusPendingDeletion = "pending_deletion"
+)
+
+// UserStatuses lists the supported statuses of the users
+var UserStatuses = []UserStatus{UserStatusActive, UserStatusUnverified, UserStatusDisabled, UserStatusPendingDeletion}
+
+// UserFilter narrows the search of users, empty fields are ignored
+// Email matches the beginning of the email addresses, regardless of the case.
+// UserIDs restricts the search to the given users, the role held by the users being resolved by the security service.
+// CreatedFrom is inclusive and CreatedTo is exclusive.
+// After is the cursor of the last user of the previous page, the next page starting right after it.
+type UserFilter struct {
+	Email       string
+	UserIDs     []uuid.UUID
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Status      UserStatus
+	Sort        UserSort
+	Limit       int
+	After       *UserCursor
+}
+
+// IsValid checks if a UserFilter is valid
+func (f UserFilter) IsValid() (bool, error) {
+	if f.Sort != "" && !slices.Contains(UserSorts, f.Sort) {
+		return false, ErrUserSortInvalid
+	}
+	if f.Status != "" && !slices.Contains(UserStatuses, f.Status) {
+		return false, ErrUserStatusInvalid
+	}
+	if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedTo.Before(*f.CreatedFrom) {
+		return false, ErrUserCreatedInvalid
+	}
+	// A cursor only makes sense along the sort it was issued for
+	if f.After != nil && f.After.Sort != f.Normalize().Sort {
+		return false, ErrUserCursorInvalid
+	}
+	return true, nil
+}
+
+// Normalize bounds the pagination of the filter and defaults the sort to the creation date
+func (f UserFilter) Normalize() UserFilter {
+	if f.Sort == "" {
+		f.Sort = UserSortCreatedAtAsc
+	}
+	if f.Limit <= 0 {
+		f.Limit = UsersDefaultLimit
+	}
+	if f.Limit > UsersMaxLimit {
+		f.Limit = UsersMaxLimit
+	}
+	return f
+}
+
+// Page trims the users retrieved for a normalized filter, one more than the limit being retrieved to
+// tell whether a next page exists, and returns the cursor of that next page (empty on the last page).
+func (f UserFilter) Page(users Users) (Users, string) {
+	if len(users) <= f.Limit {
+		return users, ""
+	}
+	users = users[:f.Limit]
+	return users, NewUserCursor(f.Sort, users[len(users)-1]).Encode()
+}
+
+// UserCursor is the position of a user along a sort, used for keyset pagination.
+// Only the key of the sort is kept besides the ID, which breaks the ties.
+type UserCursor struct {
+	Sort      UserSort   `json:"s"`
+	CreatedAt *time.Time `json:"c,omitempty"`
+	Email     string     `json:"e,omitempty"`
+	ID        uuid.UUID  `json:"i"`
+}
+
+// NewUserCursor returns the cursor of a user along a sort
+func NewUserCursor(sort UserSort, user User) UserCursor {
+	cursor := UserCursor{
+		Sort: sort,
+		ID:   user.ID,
+	}
+	switch sort {
+	case UserSortEmailAsc, UserSortEmailDesc:
+		cursor.Email = user.Email
+	default:
+		createdAt := user.CreatedAt
+		cursor.CreatedAt = &createdAt
+	}
+	return cursor
+}
+
+// ParseUserCursor decodes a cursor returned by Encode
+func ParseUserCursor(value string) (UserCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return UserCursor{}, ErrUserCursorInvalid
+	}
+	var cursor UserCursor
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return UserCursor{}, ErrUserCursorInvalid
+	}
+	if !slices.Contains(UserSorts, cursor.Sort) || cursor.ID == uuid.Nil {
+		return UserCursor{}, ErrUserCursorInvalid
+	}
+	if (cursor.Sort == UserSortCreatedAtAsc || cursor.Sort == UserSortCreatedAtDesc) && cursor.CreatedAt == nil {
+		return UserCursor{}, ErrUserCursorInvalid
+	}
+	return cursor, nil
+}
+
+// Encode returns the opaque representation of the cursor, safe to use in URLs
+func (c UserCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestUserFilter_IsValid tests the IsValid and Normalize methods of the UserFilter struct
+func TestUserFilter_IsValid(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	valid, err := UserFilter{}.IsValid()
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = UserFilter{
+		Email:       "jane",
+		UserIDs:     []uuid.UUID{uuid.New()},
+		CreatedFrom: &earlier,
+		CreatedTo:   &now,
+		Status:      UserStatusDisabled,
+		Sort:        UserSortEmailDesc,
+		After:       &UserCursor{Sort: UserSortEmailDesc, Email: "jane@example.com", ID: uuid.New()},
+	}.IsValid()
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	valid, err = UserFilter{Sort: "password"}.IsValid()
+	assert.False(t, valid)
+	assert.Equal(t, ErrUserSortInvalid, err)
+
+	valid, err = UserFilter{Status: "banned"}.IsValid()
+	assert.False(t, valid)
+	assert.Equal(t, ErrUserStatusInvalid, err)
+
+	valid, err = UserFilter{CreatedFrom: &now, CreatedTo: &earlier}.IsValid()
+	assert.False(t, valid)
+	assert.Equal(t, ErrUserCreatedInvalid, err)
+
+	valid, err = UserFilter{After: &UserCursor{Sort: UserSortEmailAsc, ID: uuid.New()}}.IsValid()
+	assert.False(t, valid)
+	assert.Equal(t, ErrUserCursorInvalid, err)
+
+	assert.Equal(t, UserSortCreatedAtAsc, UserFilter{}.Normalize().Sort)
+	assert.Equal(t, UserSortEmailDesc, UserFilter{Sort: UserSortEmailDesc}.Normalize().Sort)
+	assert.Equal(t, UsersDefaultLimit, UserFilter{}.Normalize().Limit)
+	assert.Equal(t, UsersMaxLimit, UserFilter{Limit: UsersMaxLimit + 1}.Normalize().Limit)
+}
+
+// TestUserFilter_Page tests the Page method of the UserFilter struct
+func TestUserFilter_Page(t *testing.T) {
+	users := Users{
+		{ID: uuid.New(), Email: "a@example.com"},
+		{ID: uuid.New(), Email: "b@example.com"},
+		{ID: uuid.New(), Email: "c@example.com"},
+	}
+	filter := UserFilter{Sort: UserSortEmailAsc, Limit: 2}
+
+	// More users than the limit : trimmed, with the cursor of the last kept user
+	page, next := filter.Page(users)
+	assert.Equal(t, users[:2], page)
+	cursor, err := ParseUserCursor(next)
+	assert.NoError(t, err)
+	assert.Equal(t, UserCursor{Sort: UserSortEmailAsc, Email: "b@example.com", ID: users[1].ID}, cursor)
+
+	// Last page
+	page, next = filter.Page(users[:2])
+	assert.Equal(t, users[:2], page)
+	assert.Empty(t, next)
+}
+
+// TestUserCursor tests the encoding and decoding of the UserCursor struct
+func TestUserCursor(t *testing.T) {
+	user := User{ID: uuid.New(), Email: "jane@example.com", CreatedAt: time.Now().UTC().Truncate(time.Microsecond)}
+
+	// Round trip along the creation date
+	cursor, err := ParseUserCursor(NewUserCursor(UserSortCreatedAtDesc, user).Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, UserSortCreatedAtDesc, cursor.Sort)
+	assert.Equal(t, user.ID, cursor.ID)
+	assert.True(t, user.CreatedAt.Equal(*cursor.CreatedAt))
+	assert.Empty(t, cursor.Email)
+
+	// Round trip along the email
+	cursor, err = ParseUserCursor(NewUserCursor(UserSortEmailAsc, user).Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, UserCursor{Sort: UserSortEmailAsc, Email: user.Email, ID: user.ID}, cursor)
+
+	// Invalid cursors
+	for _, value := range []string{
+		"not base64 !",
+		"bm90IGpzb24", // not json
+		UserCursor{Sort: "password", ID: user.ID}.Encode(),           // unknown sort
+		UserCursor{Sort: UserSortEmailAsc}.Encode(),                  // missing ID
+		UserCursor{Sort: UserSortCreatedAtAsc, ID: user.ID}.Encode(), // missing creation date
+	} {
+		_, err = ParseUserCursor(value)
+		assert.Equal(t, ErrUserCursorInvalid, err, value)
+	}
+}
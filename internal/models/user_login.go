@@ -0,0 +1,43 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type LoginMethod = string
+
+// Methods used to log in
+const (
+	LoginMethodPassword = "password"
+)
+
+// UserLogin represents a login attempt on the account of a user, successful or not.
+// Reason is only set on failures, with the error sent back to the user (e.g. "invalid-credentials").
+type UserLogin struct {
+	ID         uuid.UUID   `json:"id" db:"id"`
+	UserID     uuid.UUID   `json:"user_id" db:"user_id"`
+	OccurredAt time.Time   `json:"occurred_at" db:"occurred_at"`
+	IPAddress  string      `json:"ip_address" db:"ip_address"`
+	UserAgent  string      `json:"user_agent" db:"user_agent"`
+	Method     LoginMethod `json:"method" db:"method"`
+	Success    bool        `json:"success" db:"success"`
+	Reason     string      `json:"reason,omitempty" db:"reason"`
+}
+
+type UserLogins []UserLogin
+
+// InitUserLogin creates a new UserLogin for the user, occurring now
+func InitUserLogin(userID uuid.UUID, ipAddress, userAgent string, method LoginMethod) UserLogin {
+	if method == "" {
+		method = LoginMethodPassword
+	}
+	return UserLogin{
+		ID:         uuid.New(),
+		UserID:     userID,
+		OccurredAt: time.Now(),
+		IPAddress:  ipAddress,
+		UserAgent:  userAgent,
+		Method:     method,
+	}
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"slices"
+	"time"
+	// Embed the time zone database, so that the timezones are validated regardless of the host
+	_ "time/tzdata"
+)
+
+var (
+	ErrDisplayNameInvalid  = errors.New("display-name-invalid")
+	ErrLanguageInvalid     = errors.New("language-invalid")
+	ErrTimezoneInvalid     = errors.New("timezone-invalid")
+	ErrBaseCurrencyInvalid = errors.New("base-currency-invalid")
+	ErrNumberFormatInvalid = errors.New("number-format-invalid")
+	ErrDateFormatInvalid   = errors.New("date-format-invalid")
+	errAvatarNameInvalid   = errors.New("name-invalid")
+	errAvatarDataRequired  = errors.New("data-required")
+	errAvatarDataTooLarge  = errors.New("data-too-large")
+)
+
+const (
+	DisplayNameMaxLength = 64
+	AvatarNameMaxLength  = 255
+	// AvatarMaxSize keeps the avatars well below the default gRPC message size limit
+	AvatarMaxSize = 1 << 20 // 1 MB
+)
+
+// NumberFormats lists the supported number formats, as the rendering of 1234.56
+var NumberFormats = []string{"1,234.56", "1.234,56", "1 234,56", "1'234.56"}
+
+// DateFormats lists the supported date formats
+var DateFormats = []string{"YYYY-MM-DD", "DD/MM/YYYY", "MM/DD/YYYY", "DD.MM.YYYY"}
+
+// UserProfile represents the preferences of a User
+// Every field is optional : an empty value lets the clients derive it from the language.
+type UserProfile struct {
+	DisplayName  string `json:"display_name" db:"display_name"`
+	Language     string `json:"language" db:"language"`
+	Timezone     string `json:"timezone" db:"timezone"`
+	BaseCurrency string `json:"base_currency" db:"base_currency"`
+	NumberFormat string `json:"number_format" db:"number_format"`
+	DateFormat   string `json:"date_format" db:"date_format"`
+}
+
+// IsValid checks if a UserProfile is valid
+// * DisplayName must not be longer than 64 characters
+// * Language must be a BCP 47 language tag
+// * Timezone must be an IANA time zone
+// * BaseCurrency must be an ISO 4217 currency code
+// * NumberFormat and DateFormat must be supported formats
+func (p UserProfile) IsValid() (bool, error) {
+	if len([]rune(p.DisplayName)) > DisplayNameMaxLength {
+		return false, ErrDisplayNameInvalid
+	}
+	if p.Language != "" {
+		if _, err := language.Parse(p.Language); err != nil {
+			return false, ErrLanguageInvalid
+		}
+	}
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return false, ErrTimezoneInvalid
+		}
+	}
+	if p.BaseCurrency != "" {
+		if _, err := currency.ParseISO(p.BaseCurrency); err != nil {
+			return false, ErrBaseCurrencyInvalid
+		}
+	}
+	if p.NumberFormat != "" && !slices.Contains(NumberFormats, p.NumberFormat) {
+		return false, ErrNumberFormatInvalid
+	}
+	if p.DateFormat != "" && !slices.Contains(DateFormats, p.DateFormat) {
+		return false, ErrDateFormatInvalid
+	}
+	return true, nil
+}
+
+// LanguageTag returns the preferred language of the user, if any
+func (p UserProfile) LanguageTag() (language.Tag, bool) {
+	if p.Language == "" {
+		return language.Und, false
+	}
+	tag, err := language.Parse(p.Language)
+	if err != nil {
+		return language.Und, false
+	}
+	return tag, true
+}
+
+// EmailLanguage returns the language of the emails sent to the user :
+// the requested one, otherwise the language stored in the profile of the user.
+// language.Und is returned when neither is set, for which the localizer falls back to the default language.
+func (p UserProfile) EmailLanguage(requested string) language.Tag {
+	if requested != "" {
+		if lang, err := language.Parse(requested); err == nil && lang != language.Und {
+			return lang
+		}
+	}
+	lang, _ := p.LanguageTag()
+	return lang
+}
+
+// UserAvatar represents the avatar image of a User
+type UserAvatar struct {
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Name      string    `json:"name" db:"name"`
+	Data      []byte    `json:"-" db:"data"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IsValid checks if a UserAvatar is valid
+func (a UserAvatar) IsValid() (bool, error) {
+	if a.Name == "" || len(a.Name) > AvatarNameMaxLength {
+		return false, errAvatarNameInvalid
+	}
+	if len(a.Data) == 0 {
+		return false, errAvatarDataRequired
+	}
+	if len(a.Data) > AvatarMaxSize {
+		return false, errAvatarDataTooLarge
+	}
+	return true, nil
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"strings"
+	"testing"
+)
+
+// TestUserProfile_IsValid tests the IsValid method of the UserProfile struct
+func TestUserProfile_IsValid(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name     string      // Test case name
+		profile  UserProfile // UserProfile instance to test
+		expected bool        // Expected result
+		err      error       // Expected error
+	}{
+		{
+			name:     "empty UserProfile",
+			profile:  UserProfile{},
+			expected: true,
+			err:      nil,
+		},
+		{
+			name: "valid UserProfile",
+			profile: UserProfile{
+				DisplayName:  "Jane",
+				Language:     "fr-CA",
+				Timezone:     "Europe/Paris",
+				BaseCurrency: "EUR",
+				NumberFormat: "1 234,56",
+				DateFormat:   "DD/MM/YYYY",
+			},
+			expected: true,
+			err:      nil,
+		},
+		{
+			name:     "display name too long",
+			profile:  UserProfile{DisplayName: strings.Repeat("a", DisplayNameMaxLength+1)},
+			expected: false,
+			err:      ErrDisplayNameInvalid,
+		},
+		{
+			name:     "invalid language",
+			profile:  UserProfile{Language: "not a language"},
+			expected: false,
+			err:      ErrLanguageInvalid,
+		},
+		{
+			name:     "invalid timezone",
+			profile:  UserProfile{Timezone: "Mars/Olympus"},
+			expected: false,
+			err:      ErrTimezoneInvalid,
+		},
+		{
+			name:     "invalid base currency",
+			profile:  UserProfile{BaseCurrency: "ABC"},
+			expected: false,
+			err:      ErrBaseCurrencyInvalid,
+		},
+		{
+			name:     "invalid number format",
+			profile:  UserProfile{NumberFormat: "1234.56"},
+			expected: false,
+			err:      ErrNumberFormatInvalid,
+		},
+		{
+			name:     "invalid date format",
+			profile:  UserProfile{DateFormat: "YYYY/DD/MM"},
+			expected: false,
+			err:      ErrDateFormatInvalid,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.profile.IsValid()
+			assert.Equal(t, tt.expected, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+// TestUserProfile_LanguageTag tests the LanguageTag method of the UserProfile struct
+func TestUserProfile_LanguageTag(t *testing.T) {
+	tag, ok := UserProfile{Language: "fr"}.LanguageTag()
+	assert.True(t, ok)
+	assert.Equal(t, language.French, tag)
+
+	_, ok = UserProfile{}.LanguageTag()
+	assert.False(t, ok)
+
+	_, ok = UserProfile{Language: "not a language"}.LanguageTag()
+	assert.False(t, ok)
+}
+
+// TestUserProfile_EmailLanguage tests the EmailLanguage method of the UserProfile struct
+func TestUserProfile_EmailLanguage(t *testing.T) {
+	profile := UserProfile{Language: "fr"}
+	assert.Equal(t, language.English, profile.EmailLanguage("en"))
+	assert.Equal(t, language.French, profile.EmailLanguage(""))
+	assert.Equal(t, language.French, profile.EmailLanguage("und"))
+	assert.Equal(t, language.French, profile.EmailLanguage("not a language"))
+	assert.Equal(t, language.Und, UserProfile{}.EmailLanguage(""))
+}
+
+// TestUserAvatar_IsValid tests the IsValid method of the UserAvatar struct
+func TestUserAvatar_IsValid(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name     string     // Test case name
+		avatar   UserAvatar // UserAvatar instance to test
+		expected bool       // Expected result
+		err      error      // Expected error
+	}{
+		{
+			name:     "valid UserAvatar",
+			avatar:   UserAvatar{UserID: uuid.New(), Name: "avatar.png", Data: []byte{1}},
+			expected: true,
+			err:      nil,
+		},
+		{
+			name:     "missing name",
+			avatar:   UserAvatar{UserID: uuid.New(), Data: []byte{1}},
+			expected: false,
+			err:      errAvatarNameInvalid,
+		},
+		{
+			name:     "name too long",
+			avatar:   UserAvatar{UserID: uuid.New(), Name: strings.Repeat("a", AvatarNameMaxLength+1), Data: []byte{1}},
+			expected: false,
+			err:      errAvatarNameInvalid,
+		},
+		{
+			name:     "missing data",
+			avatar:   UserAvatar{UserID: uuid.New(), Name: "avatar.png"},
+			expected: false,
+			err:      errAvatarDataRequired,
+		},
+		{
+			name:     "data too large",
+			avatar:   UserAvatar{UserID: uuid.New(), Name: "avatar.png", Data: make([]byte, AvatarMaxSize+1)},
+			expected: false,
+			err:      errAvatarDataTooLarge,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.avatar.IsValid()
+			assert.Equal(t, tt.expected, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+package outbox
+
+//go:generate mockgen -source=repository.go -destination=../../test/mocks/outbox_repository.go --package=mocks -mock_names=Repository=OutboxRepository Repository
@@ -0,0 +1,316 @@
+package outbox
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strings"
+	"time"
+)
+
+// outboxColumns are the columns retrieved for an OutboxEmail
+const outboxColumns = `o.id, o.idempotency_key, o.to_addresses, o.cc_addresses, o.reply_to, o.subject,
+			  o.plain_text_content, o.html_content, o.attachments, o.status, o.attempts, o.last_error,
+			  o.next_attempt_at, o.created_at, o.updated_at, o.sent_at`
+
+// PostgresRepository is a repository containing the OutboxEmail definition based on a PSQL database and
+// implementing the repository interface
+type PostgresRepository struct {
+	conn *sqlx.DB
+}
+
+// NewPostgresRepository returns a new instance of PostgresRepository
+func NewPostgresRepository(dbClient *sqlx.DB) Repository {
+	r := PostgresRepository{
+		conn: dbClient,
+	}
+	var repo Repository = &r
+	return repo
+}
+
+// Enqueue adds an OutboxEmail to the outbox, unless an email with the same idempotency key was already enqueued.
+// Returns the email stored in the outbox, and whether it was enqueued by this call.
+func (r *PostgresRepository) Enqueue(email models.OutboxEmail) (models.OutboxEmail, bool, error) {
+	// Prepare query
+	query := `INSERT INTO email_outbox as o (id, idempotency_key, to_addresses, cc_addresses, reply_to, subject,
+			  	plain_text_content, html_content, attachments, next_attempt_at)
+			  VALUES (:id, :idempotency_key, :to_addresses, :cc_addresses, :reply_to, :subject,
+			  	:plain_text_content, :html_content, :attachments, :next_attempt_at)
+			  ON CONFLICT (idempotency_key) DO NOTHING
+			  RETURNING ` + outboxColumns
+	params := map[string]interface{}{
+		"id":                 email.ID,
+		"idempotency_key":    email.IdempotencyKey,
+		"to_addresses":       addresses(email.To),
+		"cc_addresses":       addresses(email.Cc),
+		"reply_to":           email.ReplyTo,
+		"subject":            email.Subject,
+		"plain_text_content": email.PlainTextContent,
+		"html_content":       email.HTMLContent,
+		"attachments":        email.Attachments,
+		"next_attempt_at":    email.NextAttemptAt,
+	}
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return models.OutboxEmail{}, false, err
+	}
+	defer rows.Close()
+
+	// Scan result
+	result, enqueued, err := utils.ScanFirstStruct[models.OutboxEmail](rows)
+	if err != nil || enqueued {
+		return result, enqueued, err
+	}
+
+	// Already enqueued : retrieve the email holding the idempotency key
+	result, _, err = r.getByIdempotencyKey(email.IdempotencyKey)
+	return result, false, err
+}
+
+// addresses returns the addresses to store, a nil list being stored as an empty array rather than NULL
+// since the address columns are not nullable and their default does not apply to an explicit NULL
+func addresses(list pq.StringArray) pq.StringArray {
+	if list == nil {
+		return pq.StringArray{}
+	}
+	return list
+}
+
+// getByIdempotencyKey retrieves the OutboxEmail holding the idempotency key
+func (r *PostgresRepository) getByIdempotencyKey(idempotencyKey string) (models.OutboxEmail, bool, error) {
+	// Prepare query
+	query := `SELECT ` + outboxColumns + `
+			  FROM email_outbox as o
+			  WHERE o.idempotency_key = :idempotency_key`
+	params := map[string]interface{}{
+		"idempotency_key": idempotencyKey,
+	}
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return models.OutboxEmail{}, false, err
+	}
+	defer rows.Close()
+
+	return utils.ScanFirstStruct[models.OutboxEmail](rows)
+}
+
+// Get retrieves an OutboxEmail
+func (r *PostgresRepository) Get(id uuid.UUID) (models.OutboxEmail, bool, error) {
+	// Prepare query
+	query := `SELECT ` + outboxColumns + `
+			  FROM email_outbox as o
+			  WHERE o.id = :id`
+	params := map[string]interface{}{
+		"id": id,
+	}
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return models.OutboxEmail{}, false, err
+	}
+	defer rows.Close()
+
+	return utils.ScanFirstStruct[models.OutboxEmail](rows)
+}
+
+// List retrieves the OutboxEmail matching the filter, from the most recently enqueued one
+func (r *PostgresRepository) List(filter models.OutboxEmailFilter) (models.OutboxEmails, error) {
+	filter = filter.Normalize()
+
+	// Only filter on the given criteria
+	conditions := make([]string, 0)
+	params := map[string]interface{}{
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "o.status = :status")
+		params["status"] = filter.Status
+	}
+	if filter.Recipient != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM unnest(o.to_addresses || o.cc_addresses) as r WHERE lower(r) = lower(:recipient))")
+		params["recipient"] = filter.Recipient
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Prepare query
+	query := `SELECT ` + outboxColumns + `
+			  FROM email_outbox as o
+			  ` + where + `
+			  ORDER BY o.created_at DESC, o.id
+			  LIMIT :limit OFFSET :offset`
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return utils.ScanAllStruct[models.OutboxEmail](rows)
+}
+
+// Claim reserves up to limit OutboxEmail due for delivery, counting a new attempt for each of them.
+// The claimed emails are leased until leaseUntil : an email still being sent by then, e.g. because the worker
+// stopped, is due again. Concurrent workers never claim the same email.
+func (r *PostgresRepository) Claim(limit int, leaseUntil time.Time) (models.OutboxEmails, error) {
+	// Prepare query
+	query := `UPDATE email_outbox as o
+			  SET status = 'sending', attempts = o.attempts + 1, next_attempt_at = :lease_until, updated_at = NOW()
+			  WHERE o.id IN (
+			  	SELECT d.id
+			  	FROM email_outbox as d
+			  	WHERE d.status IN ('pending', 'sending') AND d.next_attempt_at <= NOW()
+			  	ORDER BY d.next_attempt_at
+			  	LIMIT :limit
+			  	FOR UPDATE SKIP LOCKED
+			  )
+			  RETURNING ` + outboxColumns
+	params := map[string]interface{}{
+		"limit":       limit,
+		"lease_until": leaseUntil,
+	}
+
+	// Execute query
+	rows, err := r.conn.NamedQuery(query, params)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return utils.ScanAllStruct[models.OutboxEmail](rows)
+}
+
+// MarkSent records the delivery of an OutboxEmail, dropping its contents
+func (r *PostgresRepository) MarkSent(id uuid.UUID) error {
+	// Prepare query
+	query := `UPDATE email_outbox as o
+			  SET status = 'sent', last_error = '', sent_at = NOW(), updated_at = NOW(),
+			  	plain_text_content = '', html_content = '', attachments = '[]'
+			  WHERE o.id = :id`
+	params := map[string]interface{}{
+		"id": id,
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// Reschedule records a failed delivery of an OutboxEmail, to be attempted again at nextAttemptAt
+func (r *PostgresRepository) Reschedule(id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	// Prepare query
+	query := `UPDATE email_outbox as o
+			  SET status = 'pending', last_error = :last_error, next_attempt_at = :next_attempt_at, updated_at = NOW()
+			  WHERE o.id = :id`
+	params := map[string]interface{}{
+		"id":              id,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// MarkDead records the last failed delivery of an OutboxEmail, which will not be attempted again unless retried
+func (r *PostgresRepository) MarkDead(id uuid.UUID, lastError string) error {
+	// Prepare query
+	query := `UPDATE email_outbox as o
+			  SET status = 'dead', last_error = :last_error, updated_at = NOW()
+			  WHERE o.id = :id`
+	params := map[string]interface{}{
+		"id":         id,
+		"last_error": lastError,
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// Retry makes a dead OutboxEmail due again, with a fresh count of attempts.
+// Returns false if the email is not dead, or was already purged.
+func (r *PostgresRepository) Retry(id uuid.UUID) (bool, error) {
+	// Prepare query
+	query := `UPDATE email_outbox as o
+			  SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+			  WHERE o.id = :id AND o.status = 'dead'`
+	params := map[string]interface{}{
+		"id": id,
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+// PurgeDead removes the dead OutboxEmail which failed for the last time before the given time, along with
+// their contents, and returns how many were removed
+func (r *PostgresRepository) PurgeDead(before time.Time) (int64, error) {
+	// Prepare query
+	query := `DELETE FROM email_outbox as o
+			  WHERE o.status = 'dead' AND o.updated_at < :before`
+	params := map[string]interface{}{
+		"before": before,
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// DeleteByRecipient removes every OutboxEmail sent to the address, whatever its status, and returns how many were removed
+func (r *PostgresRepository) DeleteByRecipient(address string) (int64, error) {
+	// Prepare query
+	query := `DELETE FROM email_outbox as o
+			  WHERE :address = ANY(o.to_addresses) OR :address = ANY(o.cc_addresses)`
+	params := map[string]interface{}{
+		"address": address,
+	}
+
+	// Execute query
+	result, err := r.conn.NamedExec(query, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
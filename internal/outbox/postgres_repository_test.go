@@ -0,0 +1,439 @@
+package outbox_test
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/outbox"
+	"github.com/Zapharaos/fihub-backend/test"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+	"testing"
+	"time"
+)
+
+// outboxColumns are the columns returned when retrieving an OutboxEmail
+var outboxColumns = []string{"id", "idempotency_key", "to_addresses", "cc_addresses", "reply_to", "subject",
+	"plain_text_content", "html_content", "attachments", "status", "attempts", "last_error",
+	"next_attempt_at", "created_at", "updated_at", "sent_at"}
+
+// outboxRows returns the rows of an OutboxEmail with the given status
+func outboxRows(status models.OutboxStatus) *sqlxmock.Rows {
+	return sqlxmock.NewRows(outboxColumns).
+		AddRow(uuid.New(), "key", "{jane@example.com}", "{}", "", "Subject", "Plain", "<p>HTML</p>", "[]",
+			status, 1, "", time.Now(), time.Now(), time.Now(), nil)
+}
+
+// TestPostgresRepository_Enqueue test the Enqueue method
+func TestPostgresRepository_Enqueue(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectErr      bool
+		expectEnqueued bool
+	}{
+		{
+			name: "Fail to enqueue",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("INSERT INTO email_outbox").WillReturnError(errors.New("error"))
+			},
+			expectErr:      true,
+			expectEnqueued: false,
+		},
+		{
+			name: "Enqueue",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("INSERT INTO email_outbox").WillReturnRows(outboxRows(models.OutboxStatusPending))
+			},
+			expectErr:      false,
+			expectEnqueued: true,
+		},
+		{
+			name: "Enqueue without carbon copy",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("INSERT INTO email_outbox").
+					WithArgs(sqlxmock.AnyArg(), "key", "{}", "{}", sqlxmock.AnyArg(), sqlxmock.AnyArg(),
+						sqlxmock.AnyArg(), sqlxmock.AnyArg(), sqlxmock.AnyArg(), sqlxmock.AnyArg()).
+					WillReturnRows(outboxRows(models.OutboxStatusPending))
+			},
+			expectErr:      false,
+			expectEnqueued: true,
+		},
+		{
+			name: "Fail to get the email already enqueued",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("INSERT INTO email_outbox").WillReturnRows(sqlxmock.NewRows(outboxColumns))
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_outbox").WillReturnError(errors.New("error"))
+			},
+			expectErr:      true,
+			expectEnqueued: false,
+		},
+		{
+			name: "Already enqueued",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("INSERT INTO email_outbox").WillReturnRows(sqlxmock.NewRows(outboxColumns))
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_outbox").WillReturnRows(outboxRows(models.OutboxStatusSent))
+			},
+			expectErr:      false,
+			expectEnqueued: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			result, enqueued, err := outbox.R().Enqueue(models.OutboxEmail{IdempotencyKey: "key"})
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Enqueue() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if enqueued != tt.expectEnqueued {
+				t.Errorf("Enqueue() enqueued = %v, expectEnqueued %v", enqueued, tt.expectEnqueued)
+			}
+			if !tt.expectErr {
+				assert.Equal(t, "key", result.IdempotencyKey)
+				assert.Equal(t, []string{"jane@example.com"}, []string(result.To))
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_Get test the Get method
+func TestPostgresRepository_Get(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectErr   bool
+		expectFound bool
+	}{
+		{
+			name: "Fail to get email",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_outbox").WillReturnError(errors.New("error"))
+			},
+			expectErr:   true,
+			expectFound: false,
+		},
+		{
+			name: "Email not found",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_outbox").WillReturnRows(sqlxmock.NewRows(outboxColumns))
+			},
+			expectErr:   false,
+			expectFound: false,
+		},
+		{
+			name: "Get email",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_outbox").WillReturnRows(outboxRows(models.OutboxStatusPending))
+			},
+			expectErr:   false,
+			expectFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			_, found, err := outbox.R().Get(uuid.New())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Get() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if found != tt.expectFound {
+				t.Errorf("Get() found = %v, expectFound %v", found, tt.expectFound)
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_List test the List method
+func TestPostgresRepository_List(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name         string
+		filter       models.OutboxEmailFilter
+		mockSetup    func()
+		expectErr    bool
+		expectLength int
+	}{
+		{
+			name: "Fail to list emails",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_outbox").WillReturnError(errors.New("error"))
+			},
+			expectErr:    true,
+			expectLength: 0,
+		},
+		{
+			name: "List emails",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_outbox as o ORDER BY").WillReturnRows(outboxRows(models.OutboxStatusPending))
+			},
+			expectErr:    false,
+			expectLength: 1,
+		},
+		{
+			name:   "List emails matching the filter",
+			filter: models.OutboxEmailFilter{Status: models.OutboxStatusDead, Recipient: "jane@example.com"},
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("WHERE o.status = (.+) AND EXISTS").WillReturnRows(outboxRows(models.OutboxStatusDead))
+			},
+			expectErr:    false,
+			expectLength: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			emails, err := outbox.R().List(tt.filter)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("List() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if len(emails) != tt.expectLength {
+				t.Errorf("List() length = %v, expectLength %v", len(emails), tt.expectLength)
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_Claim test the Claim method
+func TestPostgresRepository_Claim(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name         string
+		mockSetup    func()
+		expectErr    bool
+		expectLength int
+	}{
+		{
+			name: "Fail to claim emails",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("UPDATE email_outbox").WillReturnError(errors.New("error"))
+			},
+			expectErr:    true,
+			expectLength: 0,
+		},
+		{
+			name: "Claim emails",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("UPDATE email_outbox (.+) FOR UPDATE SKIP LOCKED").WillReturnRows(outboxRows(models.OutboxStatusSending))
+			},
+			expectErr:    false,
+			expectLength: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			emails, err := outbox.R().Claim(10, time.Now().Add(time.Minute))
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Claim() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if len(emails) != tt.expectLength {
+				t.Errorf("Claim() length = %v, expectLength %v", len(emails), tt.expectLength)
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_Updates test the MarkSent, Reschedule and MarkDead methods
+func TestPostgresRepository_Updates(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(sqlxMock.DB))
+
+	updates := map[string]func() error{
+		"MarkSent": func() error {
+			return outbox.R().MarkSent(uuid.New())
+		},
+		"Reschedule": func() error {
+			return outbox.R().Reschedule(uuid.New(), "error", time.Now().Add(time.Minute))
+		},
+		"MarkDead": func() error {
+			return outbox.R().MarkDead(uuid.New(), "error")
+		},
+	}
+
+	for name, update := range updates {
+		t.Run(name, func(t *testing.T) {
+			// Failure
+			sqlxMock.Mock.ExpectExec("UPDATE email_outbox").WillReturnError(errors.New("error"))
+			assert.Error(t, update())
+
+			// Email not found
+			sqlxMock.Mock.ExpectExec("UPDATE email_outbox").WillReturnResult(sqlxmock.NewResult(0, 0))
+			assert.Error(t, update())
+
+			// Success
+			sqlxMock.Mock.ExpectExec("UPDATE email_outbox").WillReturnResult(sqlxmock.NewResult(0, 1))
+			assert.NoError(t, update())
+		})
+	}
+}
+
+// TestPostgresRepository_Retry test the Retry method
+func TestPostgresRepository_Retry(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectErr     bool
+		expectRetried bool
+	}{
+		{
+			name: "Fail to retry email",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE email_outbox").WillReturnError(errors.New("error"))
+			},
+			expectErr:     true,
+			expectRetried: false,
+		},
+		{
+			name: "Email not dead",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE email_outbox").WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			expectErr:     false,
+			expectRetried: false,
+		},
+		{
+			name: "Retry email",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE email_outbox").WillReturnResult(sqlxmock.NewResult(0, 1))
+			},
+			expectErr:     false,
+			expectRetried: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			retried, err := outbox.R().Retry(uuid.New())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Retry() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if retried != tt.expectRetried {
+				t.Errorf("Retry() retried = %v, expectRetried %v", retried, tt.expectRetried)
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_PurgeDead test the PurgeDead method
+func TestPostgresRepository_PurgeDead(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name         string
+		mockSetup    func()
+		expectErr    bool
+		expectPurged int64
+	}{
+		{
+			name: "Fail to purge emails",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM email_outbox").WillReturnError(errors.New("error"))
+			},
+			expectErr:    true,
+			expectPurged: 0,
+		},
+		{
+			name: "Purge emails",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM email_outbox").WillReturnResult(sqlxmock.NewResult(0, 3))
+			},
+			expectErr:    false,
+			expectPurged: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			purged, err := outbox.R().PurgeDead(time.Now())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("PurgeDead() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.Equal(t, tt.expectPurged, purged)
+		})
+	}
+}
+
+// TestPostgresRepository_DeleteByRecipient test the DeleteByRecipient method
+func TestPostgresRepository_DeleteByRecipient(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	outbox.ReplaceGlobals(outbox.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectErr     bool
+		expectDeleted int64
+	}{
+		{
+			name: "Fail to delete emails",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM email_outbox").WillReturnError(errors.New("error"))
+			},
+			expectErr:     true,
+			expectDeleted: 0,
+		},
+		{
+			name: "Delete emails",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM email_outbox").WillReturnResult(sqlxmock.NewResult(0, 2))
+			},
+			expectErr:     false,
+			expectDeleted: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			deleted, err := outbox.R().DeleteByRecipient("jane@example.com")
+			if (err != nil) != tt.expectErr {
+				t.Errorf("DeleteByRecipient() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			assert.Equal(t, tt.expectDeleted, deleted)
+		})
+	}
+}
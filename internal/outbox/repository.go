@@ -0,0 +1,48 @@
+package outbox
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Repository is a storage interface which can be implemented by multiple backend
+// (in-memory map, sql database, in-memory cache, file system, ...)
+// It allows to enqueue OutboxEmail and to keep track of their delivery
+type Repository interface {
+	Enqueue(email models.OutboxEmail) (models.OutboxEmail, bool, error)
+	Get(id uuid.UUID) (models.OutboxEmail, bool, error)
+	List(filter models.OutboxEmailFilter) (models.OutboxEmails, error)
+	Claim(limit int, leaseUntil time.Time) (models.OutboxEmails, error)
+	MarkSent(id uuid.UUID) error
+	Reschedule(id uuid.UUID, lastError string, nextAttemptAt time.Time) error
+	MarkDead(id uuid.UUID, lastError string) error
+	Retry(id uuid.UUID) (bool, error)
+	PurgeDead(before time.Time) (int64, error)
+	DeleteByRecipient(address string) (int64, error)
+}
+
+var (
+	_globalRepositoryMu sync.RWMutex
+	_globalRepository   Repository
+)
+
+// R is used to access the global repository singleton
+func R() Repository {
+	_globalRepositoryMu.RLock()
+	defer _globalRepositoryMu.RUnlock()
+
+	repository := _globalRepository
+	return repository
+}
+
+// ReplaceGlobals affect a new repository to the global repository singleton
+func ReplaceGlobals(repository Repository) func() {
+	_globalRepositoryMu.Lock()
+	defer _globalRepositoryMu.Unlock()
+
+	prev := _globalRepository
+	_globalRepository = repository
+	return func() { ReplaceGlobals(prev) }
+}
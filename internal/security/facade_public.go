@@ -3,16 +3,29 @@ package security
 import (
 	"context"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
 	"github.com/google/uuid"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultDecisionCacheTTL is the lifetime of the cached permission decisions, unless configured otherwise
+	defaultDecisionCacheTTL = 5 * time.Second
+	// decisionCacheMaxEntries is the number of cached decisions above which expired decisions are swept
+	decisionCacheMaxEntries = 10000
 )
 
 // PermissionChecker defines the methods required by the facade
 type PermissionChecker interface {
 	CheckPermission(ctx context.Context, req *securitypb.CheckPermissionRequest) (*securitypb.CheckPermissionResponse, error)
+	CheckResourceAccess(ctx context.Context, req *securitypb.CheckResourceAccessRequest) (*securitypb.CheckResourceAccessResponse, error)
+	CheckImpersonation(ctx context.Context, req *securitypb.CheckImpersonationRequest) (*securitypb.CheckImpersonationResponse, error)
 }
 
 // GrpcClientAdapter adapts the securitypb.PublicSecurityServiceClient to PermissionChecker
@@ -25,6 +38,16 @@ func (a *GrpcClientAdapter) CheckPermission(ctx context.Context, req *securitypb
 	return a.client.CheckPermission(ctx, req)
 }
 
+// CheckResourceAccess implements PermissionChecker for the gRPC client
+func (a *GrpcClientAdapter) CheckResourceAccess(ctx context.Context, req *securitypb.CheckResourceAccessRequest) (*securitypb.CheckResourceAccessResponse, error) {
+	return a.client.CheckResourceAccess(ctx, req)
+}
+
+// CheckImpersonation implements PermissionChecker for the gRPC client
+func (a *GrpcClientAdapter) CheckImpersonation(ctx context.Context, req *securitypb.CheckImpersonationRequest) (*securitypb.CheckImpersonationResponse, error) {
+	return a.client.CheckImpersonation(ctx, req)
+}
+
 // NewGrpcClientAdapter creates a new adapter for the gRPC client
 func NewGrpcClientAdapter(client securitypb.PublicSecurityServiceClient) *GrpcClientAdapter {
 	return &GrpcClientAdapter{
@@ -32,25 +55,83 @@ func NewGrpcClientAdapter(client securitypb.PublicSecurityServiceClient) *GrpcCl
 	}
 }
 
+// decisionKey identifies a permission decision : the caller, the permission and the targeted user, if any
+type decisionKey struct {
+	caller     uuid.UUID
+	permission string
+	target     uuid.UUID
+}
+
+// decision is a cached permission decision
+type decision struct {
+	allowed   bool
+	expiresAt time.Time
+}
+
+// PublicSecurityFacade checks permissions against the security microservice.
+// Decisions are cached in-process for a short time, per authenticated caller, to avoid a round trip on every call.
+// Only the security microservice invalidates its cache when permissions change : in the other services,
+// a revoked permission can still be granted until the cached decision expires, that is for at most the ttl.
 type PublicSecurityFacade struct {
 	service PermissionChecker
+
+	ttl         time.Duration
+	decisionsMu sync.Mutex
+	decisions   map[decisionKey]decision
 }
 
 func NewPublicSecurityFacade(service PermissionChecker) *PublicSecurityFacade {
 	return &PublicSecurityFacade{
-		service: service,
+		service:   service,
+		ttl:       decisionCacheTTLFromConfig(),
+		decisions: make(map[decisionKey]decision),
 	}
 }
 
 // NewPublicSecurityFacadeWithGrpcClient creates a new facade with a gRPC client
 func NewPublicSecurityFacadeWithGrpcClient(client securitypb.PublicSecurityServiceClient) *PublicSecurityFacade {
-	return &PublicSecurityFacade{
-		service: NewGrpcClientAdapter(client),
+	return NewPublicSecurityFacade(NewGrpcClientAdapter(client))
+}
+
+// decisionCacheTTLFromConfig returns the lifetime of the cached permission decisions.
+// A zero duration disables the cache.
+func decisionCacheTTLFromConfig() time.Duration {
+	if !viper.IsSet("SECURITY_DECISION_CACHE_TTL") {
+		return defaultDecisionCacheTTL
 	}
+	return viper.GetDuration("SECURITY_DECISION_CACHE_TTL")
 }
 
 // CheckPermission wraps the CheckPermission call
 func (s *PublicSecurityFacade) CheckPermission(ctx context.Context, permission string, userIDs ...uuid.UUID) error {
+	// Decisions can only be cached for an authenticated caller
+	principal, cacheable := grpcutil.PrincipalFromContext(ctx)
+	cacheable = cacheable && s.ttl > 0
+	key := decisionKey{caller: principal.UserID, permission: permission}
+	if len(userIDs) > 0 {
+		key.target = userIDs[0]
+	}
+
+	if cacheable {
+		if allowed, found := s.getDecision(key); found {
+			if !allowed {
+				return status.Error(codes.PermissionDenied, "Permission denied")
+			}
+			return nil
+		}
+	}
+
+	err := s.checkPermission(ctx, permission, userIDs...)
+
+	// Only cache definitive decisions
+	if cacheable && (err == nil || status.Code(err) == codes.PermissionDenied) {
+		s.setDecision(key, err == nil)
+	}
+	return err
+}
+
+// checkPermission performs the CheckPermission call
+func (s *PublicSecurityFacade) checkPermission(ctx context.Context, permission string, userIDs ...uuid.UUID) error {
 	// If any, propagate metadata from the incoming context to the outgoing context
 	md, ok := metadata.FromIncomingContext(ctx)
 	if ok {
@@ -80,6 +161,126 @@ func (s *PublicSecurityFacade) CheckPermission(ctx context.Context, permission s
 	return nil
 }
 
+// CheckResourceAccess wraps the CheckResourceAccess call.
+// Decisions depend on the resource attributes, hence they are not cached.
+func (s *PublicSecurityFacade) CheckResourceAccess(ctx context.Context, action Action, resource Resource) error {
+	// If any, propagate metadata from the incoming context to the outgoing context
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	// Setup the request
+	req := &securitypb.CheckResourceAccessRequest{
+		Action:       string(action),
+		ResourceType: resource.Type,
+		Attributes:   resource.Attributes,
+	}
+
+	if resource.OwnerID != uuid.Nil {
+		req.OwnerId = resource.OwnerID.String()
+	}
+
+	response, err := s.service.CheckResourceAccess(ctx, req)
+	if err != nil {
+		zap.L().Error("PublicSecurityFacade.CheckResourceAccess", zap.Error(err))
+		return err
+	}
+
+	if !response.GetAllowed() {
+		zap.L().Error("PublicSecurityFacade.ResourceAccessDenied", zap.String("action", string(action)), zap.String("resource", resource.Type))
+		return status.Error(codes.PermissionDenied, "Permission denied")
+	}
+
+	return nil
+}
+
+// CheckImpersonation wraps the CheckImpersonation call.
+// Decisions depend on the target user permissions, hence they are not cached.
+func (s *PublicSecurityFacade) CheckImpersonation(ctx context.Context, userID uuid.UUID) error {
+	// If any, propagate metadata from the incoming context to the outgoing context
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	response, err := s.service.CheckImpersonation(ctx, &securitypb.CheckImpersonationRequest{
+		UserId: userID.String(),
+	})
+	if err != nil {
+		zap.L().Error("PublicSecurityFacade.CheckImpersonation", zap.Error(err))
+		return err
+	}
+
+	if !response.GetAllowed() {
+		zap.L().Error("PublicSecurityFacade.ImpersonationDenied", zap.String("user_id", userID.String()))
+		return status.Error(codes.PermissionDenied, "Permission denied")
+	}
+
+	return nil
+}
+
+// getDecision returns the cached decision, if it has not expired
+func (s *PublicSecurityFacade) getDecision(key decisionKey) (bool, bool) {
+	s.decisionsMu.Lock()
+	defer s.decisionsMu.Unlock()
+
+	d, found := s.decisions[key]
+	if !found {
+		return false, false
+	}
+	if time.Now().After(d.expiresAt) {
+		delete(s.decisions, key)
+		return false, false
+	}
+	return d.allowed, true
+}
+
+// setDecision caches the decision until the TTL expires
+func (s *PublicSecurityFacade) setDecision(key decisionKey, allowed bool) {
+	s.decisionsMu.Lock()
+	defer s.decisionsMu.Unlock()
+
+	now := time.Now()
+	if len(s.decisions) >= decisionCacheMaxEntries {
+		for k, d := range s.decisions {
+			if now.After(d.expiresAt) {
+				delete(s.decisions, k)
+			}
+		}
+		// Every decision is still valid : start over rather than growing indefinitely
+		if len(s.decisions) >= decisionCacheMaxEntries {
+			s.decisions = make(map[decisionKey]decision)
+		}
+	}
+
+	s.decisions[key] = decision{
+		allowed:   allowed,
+		expiresAt: now.Add(s.ttl),
+	}
+}
+
+// Invalidate drops the cached decisions of the callers, or every cached decision if none is given
+func (s *PublicSecurityFacade) Invalidate(userIDs ...uuid.UUID) {
+	s.decisionsMu.Lock()
+	defer s.decisionsMu.Unlock()
+
+	if len(userIDs) == 0 {
+		s.decisions = make(map[decisionKey]decision)
+		return
+	}
+
+	callers := make(map[uuid.UUID]bool, len(userIDs))
+	for _, userID := range userIDs {
+		callers[userID] = true
+	}
+	for k := range s.decisions {
+		if callers[k.caller] {
+			delete(s.decisions, k)
+		}
+	}
+}
+
 // Global instance of the PublicSecurityFacade
 var _globalPublicSecurityFacade *PublicSecurityFacade
 
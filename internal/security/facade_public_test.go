@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"github.com/Zapharaos/fihub-backend/gen/go/securitypb"
+	"github.com/Zapharaos/fihub-backend/internal/grpcutil"
 	"github.com/Zapharaos/fihub-backend/test/mocks"
 	"github.com/google/uuid"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"testing"
+	"time"
 )
 
 // TestPublicSecurityFacade_ReplaceGlobals tests the ReplaceGlobals function
@@ -88,6 +93,131 @@ func TestPublicSecurityFacade_CheckPermission(t *testing.T) {
 	}
 }
 
+// TestPublicSecurityFacade_CheckResourceAccess tests the PublicSecurityFacade.CheckResourceAccess method
+func TestPublicSecurityFacade_CheckResourceAccess(t *testing.T) {
+	facade := NewPublicSecurityFacade(nil)
+	// Using incoming context here as we are testing the facade
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+	ownerID := uuid.New()
+
+	// Prepare tests
+	tests := []struct {
+		name        string
+		mockSetup   func(ctrl *gomock.Controller)
+		resource    Resource
+		expectError bool
+	}{
+		{
+			name: "Failure - CheckResourceAccess Error",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockPermissionChecker(ctrl)
+				m.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any()).Return((*securitypb.CheckResourceAccessResponse)(nil), errors.New("internal error"))
+				facade = NewPublicSecurityFacade(m)
+			},
+			resource:    Resource{Type: ResourceTransaction},
+			expectError: true,
+		},
+		{
+			name: "Failure - Not Allowed",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockPermissionChecker(ctrl)
+				m.EXPECT().CheckResourceAccess(gomock.Any(), gomock.Any()).Return(&securitypb.CheckResourceAccessResponse{Allowed: false}, nil)
+				facade = NewPublicSecurityFacade(m)
+			},
+			resource:    Resource{Type: ResourceTransaction},
+			expectError: true,
+		},
+		{
+			name: "Success - Allowed",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockPermissionChecker(ctrl)
+				m.EXPECT().CheckResourceAccess(gomock.Any(), &securitypb.CheckResourceAccessRequest{
+					Action:       string(ActionRead),
+					ResourceType: ResourceTransaction,
+					OwnerId:      ownerID.String(),
+					Attributes:   map[string]string{"key": "value"},
+				}).Return(&securitypb.CheckResourceAccessResponse{Allowed: true}, nil)
+				facade = NewPublicSecurityFacade(m)
+			},
+			resource: Resource{
+				Type:       ResourceTransaction,
+				OwnerID:    ownerID,
+				Attributes: map[string]string{"key": "value"},
+			},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			err := facade.CheckResourceAccess(ctx, ActionRead, tt.resource)
+			assert.Equal(t, tt.expectError, err != nil)
+		})
+	}
+}
+
+// TestPublicSecurityFacade_CheckImpersonation tests the CheckImpersonation method of the PublicSecurityFacade
+func TestPublicSecurityFacade_CheckImpersonation(t *testing.T) {
+	facade := NewPublicSecurityFacade(nil)
+	// Using incoming context here as we are testing the facade
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+	userID := uuid.New()
+
+	// Prepare tests
+	tests := []struct {
+		name        string
+		mockSetup   func(ctrl *gomock.Controller)
+		expectError bool
+	}{
+		{
+			name: "Failure - CheckImpersonation Error",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockPermissionChecker(ctrl)
+				m.EXPECT().CheckImpersonation(gomock.Any(), gomock.Any()).Return((*securitypb.CheckImpersonationResponse)(nil), errors.New("internal error"))
+				facade = NewPublicSecurityFacade(m)
+			},
+			expectError: true,
+		},
+		{
+			name: "Failure - Not Allowed",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockPermissionChecker(ctrl)
+				m.EXPECT().CheckImpersonation(gomock.Any(), gomock.Any()).Return(&securitypb.CheckImpersonationResponse{Allowed: false}, nil)
+				facade = NewPublicSecurityFacade(m)
+			},
+			expectError: true,
+		},
+		{
+			name: "Success - Allowed",
+			mockSetup: func(ctrl *gomock.Controller) {
+				m := mocks.NewMockPermissionChecker(ctrl)
+				m.EXPECT().CheckImpersonation(gomock.Any(), &securitypb.CheckImpersonationRequest{
+					UserId: userID.String(),
+				}).Return(&securitypb.CheckImpersonationResponse{Allowed: true}, nil)
+				facade = NewPublicSecurityFacade(m)
+			},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Apply mocks
+			ctrl := gomock.NewController(t)
+			tt.mockSetup(ctrl)
+			defer ctrl.Finish()
+
+			err := facade.CheckImpersonation(ctx, userID)
+			assert.Equal(t, tt.expectError, err != nil)
+		})
+	}
+}
+
 // TestPublicSecurityFacade_GrpcClientAdapter tests the GrpcClientAdapter methods
 func TestPublicSecurityFacade_GrpcClientAdapter(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -106,3 +236,92 @@ func TestPublicSecurityFacade_GrpcClientAdapter(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.True(t, resp.GetHasPermission())
 }
+
+// TestPublicSecurityFacade_DecisionCache tests that the decisions are cached per authenticated caller
+func TestPublicSecurityFacade_DecisionCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	caller := uuid.New()
+	other := uuid.New()
+	target := uuid.New()
+	ctx := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{UserID: caller})
+	otherCtx := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{UserID: other})
+
+	m := mocks.NewMockPermissionChecker(ctrl)
+	facade := NewPublicSecurityFacade(m)
+
+	// Granted decisions are cached
+	m.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil).Times(1)
+	assert.NoError(t, facade.CheckPermission(ctx, "admin.users.read"))
+	assert.NoError(t, facade.CheckPermission(ctx, "admin.users.read"))
+
+	// Denied decisions are cached, per target
+	m.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.PermissionDenied, "denied")).Times(1)
+	assert.Error(t, facade.CheckPermission(ctx, "admin.users.read", target))
+	err := facade.CheckPermission(ctx, "admin.users.read", target)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Errors are not cached
+	m.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(nil, errors.New("error")).Times(2)
+	assert.Error(t, facade.CheckPermission(ctx, "admin.users.delete"))
+	assert.Error(t, facade.CheckPermission(ctx, "admin.users.delete"))
+
+	// Decisions are cached per caller
+	m.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil).Times(1)
+	assert.NoError(t, facade.CheckPermission(otherCtx, "admin.users.read"))
+
+	// Invalidating the caller drops its decisions only
+	facade.Invalidate(caller)
+	m.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil).Times(1)
+	assert.NoError(t, facade.CheckPermission(ctx, "admin.users.read"))
+	assert.NoError(t, facade.CheckPermission(otherCtx, "admin.users.read"))
+
+	// Invalidating everything drops every decision
+	facade.Invalidate()
+	m.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil).Times(2)
+	assert.NoError(t, facade.CheckPermission(ctx, "admin.users.read"))
+	assert.NoError(t, facade.CheckPermission(otherCtx, "admin.users.read"))
+
+	// Decisions expire
+	facade.ttl = time.Millisecond
+	facade.Invalidate()
+	m.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil).Times(2)
+	assert.NoError(t, facade.CheckPermission(ctx, "admin.users.read"))
+	time.Sleep(2 * time.Millisecond)
+	assert.NoError(t, facade.CheckPermission(ctx, "admin.users.read"))
+}
+
+// TestPublicSecurityFacade_DecisionCacheDisabled tests that no decision is cached without caller or TTL
+func TestPublicSecurityFacade_DecisionCacheDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Without authenticated caller
+	m := mocks.NewMockPermissionChecker(ctrl)
+	m.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil).Times(2)
+	facade := NewPublicSecurityFacade(m)
+	assert.NoError(t, facade.CheckPermission(context.Background(), "admin.users.read"))
+	assert.NoError(t, facade.CheckPermission(context.Background(), "admin.users.read"))
+
+	// Without TTL
+	viper.Set("SECURITY_DECISION_CACHE_TTL", "0s")
+	defer viper.Reset()
+	ctx := grpcutil.ContextWithPrincipal(context.Background(), grpcutil.Principal{UserID: uuid.New()})
+	m.EXPECT().CheckPermission(gomock.Any(), gomock.Any()).Return(&securitypb.CheckPermissionResponse{HasPermission: true}, nil).Times(2)
+	facade = NewPublicSecurityFacade(m)
+	assert.NoError(t, facade.CheckPermission(ctx, "admin.users.read"))
+	assert.NoError(t, facade.CheckPermission(ctx, "admin.users.read"))
+}
+
+// TestPublicSecurityFacade_DecisionCacheMaxEntries tests that the cached decisions do not grow indefinitely
+func TestPublicSecurityFacade_DecisionCacheMaxEntries(t *testing.T) {
+	facade := NewPublicSecurityFacade(nil)
+	for i := 0; i < decisionCacheMaxEntries; i++ {
+		facade.setDecision(decisionKey{caller: uuid.New()}, true)
+	}
+	assert.Len(t, facade.decisions, decisionCacheMaxEntries)
+
+	facade.setDecision(decisionKey{caller: uuid.New()}, true)
+	assert.Len(t, facade.decisions, 1)
+}
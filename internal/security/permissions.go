@@ -0,0 +1,107 @@
+package security
+
+import "github.com/Zapharaos/fihub-backend/internal/models"
+
+// Permissions declared by each microservice for the checks it performs.
+// The security microservice synchronises them into its database at startup,
+// hence a permission must be declared here before being checked anywhere.
+
+// SuperadminPermissions grant everything through the wildcard
+var SuperadminPermissions = models.Permissions{
+	{Value: "*", Scope: models.AllScope, Description: "Superadmin wildcard"},
+}
+
+// FrontPermissions control the views of the web application
+var FrontPermissions = models.Permissions{
+	{Value: "front.admin.overview", Scope: models.FrontScope, Description: "Admin overview view"},
+	{Value: "front.admin.roles", Scope: models.FrontScope, Description: "Admin roles view"},
+	{Value: "front.admin.users", Scope: models.FrontScope, Description: "Admin users view"},
+	{Value: "front.admin.brokers", Scope: models.FrontScope, Description: "Admin brokers view"},
+}
+
+// AuthPermissions are checked by the auth microservice
+var AuthPermissions = models.Permissions{
+	{Value: "admin.users.unlock", Scope: models.AdminScope, Description: "Unlock user login"},
+	{Value: "admin.users.sessions.list", Scope: models.AdminScope, Description: "List user sessions"},
+	{Value: "admin.users.sessions.delete", Scope: models.AdminScope, Description: "Terminate user sessions"},
+}
+
+// BrokerPermissions are checked by the broker microservice
+var BrokerPermissions = models.Permissions{
+	{Value: "admin.brokers.create", Scope: models.AdminScope, Description: "Create broker"},
+	{Value: "admin.brokers.update", Scope: models.AdminScope, Description: "Update broker"},
+	{Value: "admin.brokers.delete", Scope: models.AdminScope, Description: "Delete broker"},
+	{Value: "admin.users.brokers.get", Scope: models.AdminScope, Description: "Read user broker"},
+	{Value: "admin.users.brokers.delete", Scope: models.AdminScope, Description: "Delete user broker"},
+	{Value: "admin.users.brokers.list", Scope: models.AdminScope, Description: "List user brokers"},
+}
+
+// SecurityPermissions are checked by the security microservice
+var SecurityPermissions = models.Permissions{
+	{Value: "admin.permissions.create", Scope: models.AdminScope, Description: "Create permission"},
+	{Value: "admin.permissions.read", Scope: models.AdminScope, Description: "Read permission"},
+	{Value: "admin.permissions.update", Scope: models.AdminScope, Description: "Update permission"},
+	{Value: "admin.permissions.delete", Scope: models.AdminScope, Description: "Delete permission"},
+	{Value: "admin.permissions.list", Scope: models.AdminScope, Description: "List permissions"},
+	{Value: "admin.roles.create", Scope: models.AdminScope, Description: "Create role"},
+	{Value: "admin.roles.read", Scope: models.AdminScope, Description: "Read role"},
+	{Value: "admin.roles.update", Scope: models.AdminScope, Description: "Update role"},
+	{Value: "admin.roles.delete", Scope: models.AdminScope, Description: "Delete role"},
+	{Value: "admin.roles.list", Scope: models.AdminScope, Description: "List roles"},
+	{Value: "admin.roles.permissions.list", Scope: models.AdminScope, Description: "List role permission"},
+	{Value: "admin.roles.permissions.update", Scope: models.AdminScope, Description: "Update role permission"},
+	{Value: "admin.roles.users.list", Scope: models.AdminScope, Description: "List role users"},
+	{Value: "admin.roles.users.update", Scope: models.AdminScope, Description: "Add users to role"},
+	{Value: "admin.roles.users.delete", Scope: models.AdminScope, Description: "Remove users from role"},
+	{Value: "admin.users.roles.list", Scope: models.AdminScope, Description: "List user role"},
+	{Value: "admin.users.roles.update", Scope: models.AdminScope, Description: "Update user role"},
+	{Value: "admin.audit.list", Scope: models.AdminScope, Description: "List audit logs"},
+	{Value: "admin.users.impersonate", Scope: models.AdminScope, Description: "Impersonate user"},
+}
+
+// UserPermissions are checked by the user microservice
+var UserPermissions = models.Permissions{
+	{Value: "admin.users.read", Scope: models.AdminScope, Description: "Read user"},
+	{Value: "admin.users.update", Scope: models.AdminScope, Description: "Update user"},
+	{Value: "admin.users.delete", Scope: models.AdminScope, Description: "Delete user"},
+	{Value: "admin.users.list", Scope: models.AdminScope, Description: "List users"},
+	{Value: "admin.emails.read", Scope: models.AdminScope, Description: "Read email delivery status"},
+	{Value: "admin.emails.retry", Scope: models.AdminScope, Description: "Retry email delivery"},
+	{Value: "admin.emails.preview", Scope: models.AdminScope, Description: "Preview email templates"},
+}
+
+// DeclaredPermissions returns the permissions declared by every microservice.
+// A permission checked by several microservices is only returned once.
+func DeclaredPermissions() models.Permissions {
+	declarations := []models.Permissions{
+		SuperadminPermissions,
+		FrontPermissions,
+		AuthPermissions,
+		BrokerPermissions,
+		SecurityPermissions,
+		UserPermissions,
+	}
+
+	permissions := make(models.Permissions, 0)
+	seen := make(map[string]bool)
+	for _, declaration := range declarations {
+		for _, p := range declaration {
+			if seen[p.Value] {
+				continue
+			}
+			seen[p.Value] = true
+			permissions = append(permissions, p)
+		}
+	}
+	return permissions
+}
+
+// IsDeclaredPermission checks if the permission value is declared by a microservice
+func IsDeclaredPermission(value string) bool {
+	for _, p := range DeclaredPermissions() {
+		if p.Value == value {
+			return true
+		}
+	}
+	return false
+}
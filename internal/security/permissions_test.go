@@ -0,0 +1,126 @@
+package security
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestDeclaredPermissions tests that the declared permissions are valid and declared consistently
+func TestDeclaredPermissions(t *testing.T) {
+	declarations := []struct {
+		name        string
+		permissions models.Permissions
+	}{
+		{name: "superadmin", permissions: SuperadminPermissions},
+		{name: "front", permissions: FrontPermissions},
+		{name: "auth", permissions: AuthPermissions},
+		{name: "broker", permissions: BrokerPermissions},
+		{name: "security", permissions: SecurityPermissions},
+		{name: "user", permissions: UserPermissions},
+	}
+
+	declared := make(map[string]models.Permission)
+	for _, declaration := range declarations {
+		for _, p := range declaration.permissions {
+			ok, err := p.IsValid()
+			assert.True(t, ok, "%s: %s is not valid: %v", declaration.name, p.Value, err)
+
+			// A permission declared by several microservices must be declared the same way
+			if previous, found := declared[p.Value]; found {
+				assert.Equal(t, previous, p, "%s: %s is declared differently", declaration.name, p.Value)
+			}
+			declared[p.Value] = p
+		}
+	}
+
+	assert.Len(t, DeclaredPermissions(), len(declared))
+	assert.True(t, IsDeclaredPermission("admin.users.read"))
+	assert.False(t, IsDeclaredPermission("admin.unknown"))
+}
+
+// TestCheckedPermissionsAreDeclared fails when the code checks a permission which is not declared.
+// It looks for the string literals given to CheckPermission calls and to the Permission of the policy rules.
+func TestCheckedPermissionsAreDeclared(t *testing.T) {
+	roots := []string{"../../cmd", "../../internal"}
+	fset := token.NewFileSet()
+	checked := 0
+
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+
+			file, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				return err
+			}
+
+			ast.Inspect(file, func(node ast.Node) bool {
+				for _, value := range checkedPermissionLiterals(node) {
+					checked++
+					permission, err := strconv.Unquote(value.Value)
+					if err != nil {
+						t.Errorf("%s: cannot read permission %s", fset.Position(value.Pos()), value.Value)
+						continue
+					}
+					if !IsDeclaredPermission(permission) {
+						t.Errorf("%s: permission %q is not declared in internal/security/permissions.go", fset.Position(value.Pos()), permission)
+					}
+				}
+				return true
+			})
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+
+	// Make sure the scan actually found the permission checks
+	assert.NotZero(t, checked)
+}
+
+// checkedPermissionLiterals returns the permission string literals checked by the node, if any
+func checkedPermissionLiterals(node ast.Node) []*ast.BasicLit {
+	switch n := node.(type) {
+	case *ast.CallExpr:
+		// security.Facade().CheckPermission(ctx, "permission", ...)
+		selector, ok := n.Fun.(*ast.SelectorExpr)
+		if !ok || selector.Sel.Name != "CheckPermission" || len(n.Args) < 2 {
+			return nil
+		}
+		if literal, ok := n.Args[1].(*ast.BasicLit); ok && literal.Kind == token.STRING {
+			return []*ast.BasicLit{literal}
+		}
+	case *ast.CompositeLit:
+		// Rule{Permission: "permission"}
+		ident, ok := n.Type.(*ast.Ident)
+		if !ok || ident.Name != "Rule" {
+			return nil
+		}
+		for _, elt := range n.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok || key.Name != "Permission" {
+				continue
+			}
+			if literal, ok := kv.Value.(*ast.BasicLit); ok && literal.Kind == token.STRING {
+				return []*ast.BasicLit{literal}
+			}
+		}
+	}
+	return nil
+}
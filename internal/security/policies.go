@@ -0,0 +1,50 @@
+package security
+
+// Resource types protected by the default policy
+const (
+	ResourceTransaction = "transaction"
+	ResourceBrokerUser  = "broker_user"
+)
+
+// SupportRole is the name of the role given to the support team
+const SupportRole = "support"
+
+// defaultPolicy holds the rules evaluated by the security microservice
+var defaultPolicy = NewPolicy(
+	// Users manage their own resources
+	Rule{
+		Name:      "owner-full-access",
+		Effect:    EffectAllow,
+		Resources: []string{ResourceTransaction, ResourceBrokerUser},
+		Actions:   []Action{Wildcard},
+		Owner:     true,
+	},
+	// Support can read but not modify another user's resources
+	Rule{
+		Name:      "support-read-only",
+		Effect:    EffectAllow,
+		Resources: []string{ResourceTransaction, ResourceBrokerUser},
+		Actions:   []Action{ActionRead},
+		Roles:     []string{SupportRole},
+	},
+	// Administrators manage the brokers of other users through their permissions
+	Rule{
+		Name:       "admin-broker-user-read",
+		Effect:     EffectAllow,
+		Resources:  []string{ResourceBrokerUser},
+		Actions:    []Action{ActionRead},
+		Permission: "admin.users.brokers.get",
+	},
+	Rule{
+		Name:       "admin-broker-user-delete",
+		Effect:     EffectAllow,
+		Resources:  []string{ResourceBrokerUser},
+		Actions:    []Action{ActionDelete},
+		Permission: "admin.users.brokers.delete",
+	},
+)
+
+// DefaultPolicy returns the resource access policy enforced by the security microservice
+func DefaultPolicy() *Policy {
+	return defaultPolicy
+}
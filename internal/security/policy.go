@@ -0,0 +1,178 @@
+package security
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// Action is an operation performed on a resource
+type Action string
+
+const (
+	ActionRead   Action = "read"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Effect is the outcome of a matching rule
+type Effect string
+
+const (
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
+)
+
+// Wildcard matches any resource type or action in a rule
+const Wildcard = "*"
+
+// Subject is the user attempting to perform an action
+type Subject struct {
+	ID          uuid.UUID
+	Roles       []string
+	Permissions models.Permissions
+}
+
+// SubjectFromRoles builds the subject from the roles granted to the user.
+// The roles inherited from the hierarchy are added after the granted ones, named after the roles of all.
+func SubjectFromRoles(userID uuid.UUID, roles models.RolesWithPermissions, hierarchy models.RoleHierarchy, all models.Roles) Subject {
+	byId := make(map[uuid.UUID]string, len(all))
+	for _, role := range all {
+		byId[role.Id] = role.Name
+	}
+
+	names := make([]string, 0, len(roles))
+	seen := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		if !seen[role.Name] {
+			seen[role.Name] = true
+			names = append(names, role.Name)
+		}
+	}
+	for _, role := range roles {
+		for _, ancestorUUID := range hierarchy.Ancestors(role.Id) {
+			name, ok := byId[ancestorUUID]
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return Subject{
+		ID:          userID,
+		Roles:       names,
+		Permissions: roles.GetPermissions(),
+	}
+}
+
+// HasRole checks if the subject has been granted the role
+func (s Subject) HasRole(role string) bool {
+	for _, r := range s.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Resource is the target of an action
+type Resource struct {
+	Type       string
+	OwnerID    uuid.UUID
+	Attributes map[string]string
+}
+
+// Rule grants or denies actions on a resource type.
+// Every condition set on the rule must hold for the rule to match.
+type Rule struct {
+	Name      string
+	Effect    Effect
+	Resources []string
+	Actions   []Action
+
+	// Roles requires the subject to have one of the roles
+	Roles []string
+	// Permission requires the subject to be granted the permission
+	Permission string
+	// Owner requires the subject to own the resource
+	Owner bool
+	// Attributes requires the resource to carry every attribute with the same value
+	Attributes map[string]string
+}
+
+// Matches checks if the rule applies to the subject performing the action on the resource
+func (r Rule) Matches(subject Subject, action Action, resource Resource) bool {
+	if !matchesAny(r.Resources, resource.Type) || !matchesAny(r.Actions, action) {
+		return false
+	}
+
+	if len(r.Roles) > 0 {
+		hasRole := false
+		for _, role := range r.Roles {
+			if subject.HasRole(role) {
+				hasRole = true
+				break
+			}
+		}
+		if !hasRole {
+			return false
+		}
+	}
+
+	if r.Permission != "" && !subject.Permissions.HasPermission(r.Permission) {
+		return false
+	}
+
+	if r.Owner && (resource.OwnerID == uuid.Nil || resource.OwnerID != subject.ID) {
+		return false
+	}
+
+	for key, value := range r.Attributes {
+		if resource.Attributes[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesAny checks if the value is listed, or if the list contains the wildcard
+func matchesAny[T ~string](values []T, value T) bool {
+	for _, v := range values {
+		if v == value || v == Wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy is a set of rules evaluated together.
+// Any matching deny rule wins over the allow rules, and nothing is allowed unless a rule says so.
+type Policy struct {
+	rules []Rule
+}
+
+// NewPolicy creates a new Policy from the rules
+func NewPolicy(rules ...Rule) *Policy {
+	return &Policy{
+		rules: rules,
+	}
+}
+
+// Evaluate checks if the subject is allowed to perform the action on the resource.
+// Returns the name of the rule that decided, if any.
+func (p *Policy) Evaluate(subject Subject, action Action, resource Resource) (bool, string) {
+	allowedBy := ""
+	for _, rule := range p.rules {
+		if !rule.Matches(subject, action, resource) {
+			continue
+		}
+		if rule.Effect == EffectDeny {
+			return false, rule.Name
+		}
+		if allowedBy == "" {
+			allowedBy = rule.Name
+		}
+	}
+	return allowedBy != "", allowedBy
+}
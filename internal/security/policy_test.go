@@ -0,0 +1,309 @@
+package security
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// TestSubjectFromRoles tests the SubjectFromRoles function
+func TestSubjectFromRoles(t *testing.T) {
+	userID := uuid.New()
+	support := models.Role{Id: uuid.New(), Name: "support"}
+	admin := models.Role{Id: uuid.New(), Name: "admin"}
+	staff := models.Role{Id: uuid.New(), Name: "staff"}
+	employee := models.Role{Id: uuid.New(), Name: "employee"}
+	superadmin := models.Role{Id: uuid.New(), Name: "superadmin"}
+	roles := models.RolesWithPermissions{
+		{
+			Role:        support,
+			Permissions: models.Permissions{{Value: "front.*"}},
+		},
+		{
+			Role:        admin,
+			Permissions: models.Permissions{{Value: "admin.users.list"}},
+		},
+	}
+	hierarchy := models.RoleHierarchy{
+		support.Id:    {staff.Id},
+		admin.Id:      {staff.Id},
+		staff.Id:      {employee.Id},
+		superadmin.Id: {admin.Id},
+	}
+	all := models.Roles{support, admin, staff, employee, superadmin}
+
+	subject := SubjectFromRoles(userID, roles, hierarchy, all)
+
+	assert.Equal(t, userID, subject.ID)
+	assert.Equal(t, []string{"support", "admin", "staff", "employee"}, subject.Roles)
+	assert.True(t, subject.HasRole("support"))
+	assert.True(t, subject.HasRole("employee"))
+	assert.False(t, subject.HasRole("superadmin"))
+	assert.True(t, subject.Permissions.HasPermission("front.brokers"))
+	assert.True(t, subject.Permissions.HasPermission("admin.users.list"))
+}
+
+// TestRule_Matches tests the Matches function
+func TestRule_Matches(t *testing.T) {
+	ownerID := uuid.New()
+	owner := Subject{ID: ownerID}
+	support := Subject{ID: uuid.New(), Roles: []string{"support"}}
+	admin := Subject{ID: uuid.New(), Permissions: models.Permissions{{Value: "admin.*"}}}
+	resource := Resource{
+		Type:       "transaction",
+		OwnerID:    ownerID,
+		Attributes: map[string]string{"status": "locked"},
+	}
+
+	// Define the test cases
+	tests := []struct {
+		name     string
+		rule     Rule
+		subject  Subject
+		action   Action
+		resource Resource
+		expected bool
+	}{
+		{
+			name:     "resource type does not match",
+			rule:     Rule{Resources: []string{"broker_user"}, Actions: []Action{ActionRead}},
+			subject:  owner,
+			action:   ActionRead,
+			resource: resource,
+			expected: false,
+		},
+		{
+			name:     "action does not match",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}},
+			subject:  owner,
+			action:   ActionUpdate,
+			resource: resource,
+			expected: false,
+		},
+		{
+			name:     "wildcards match any resource type and action",
+			rule:     Rule{Resources: []string{Wildcard}, Actions: []Action{Wildcard}},
+			subject:  owner,
+			action:   ActionDelete,
+			resource: resource,
+			expected: true,
+		},
+		{
+			name:     "subject is missing the role",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}, Roles: []string{"support"}},
+			subject:  owner,
+			action:   ActionRead,
+			resource: resource,
+			expected: false,
+		},
+		{
+			name:     "subject has one of the roles",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}, Roles: []string{"auditor", "support"}},
+			subject:  support,
+			action:   ActionRead,
+			resource: resource,
+			expected: true,
+		},
+		{
+			name:     "subject is missing the permission",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}, Permission: "admin.transactions.read"},
+			subject:  support,
+			action:   ActionRead,
+			resource: resource,
+			expected: false,
+		},
+		{
+			name:     "subject is granted the permission through a wildcard",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}, Permission: "admin.transactions.read"},
+			subject:  admin,
+			action:   ActionRead,
+			resource: resource,
+			expected: true,
+		},
+		{
+			name:     "subject does not own the resource",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}, Owner: true},
+			subject:  support,
+			action:   ActionRead,
+			resource: resource,
+			expected: false,
+		},
+		{
+			name:     "resource without owner is owned by no one",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}, Owner: true},
+			subject:  Subject{},
+			action:   ActionRead,
+			resource: Resource{Type: "transaction"},
+			expected: false,
+		},
+		{
+			name:     "subject owns the resource",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}, Owner: true},
+			subject:  owner,
+			action:   ActionRead,
+			resource: resource,
+			expected: true,
+		},
+		{
+			name:     "resource attribute does not match",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}, Attributes: map[string]string{"status": "open"}},
+			subject:  owner,
+			action:   ActionRead,
+			resource: resource,
+			expected: false,
+		},
+		{
+			name:     "resource is missing the attribute",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}, Attributes: map[string]string{"status": "locked"}},
+			subject:  owner,
+			action:   ActionRead,
+			resource: Resource{Type: "transaction", OwnerID: ownerID},
+			expected: false,
+		},
+		{
+			name:     "every condition holds",
+			rule:     Rule{Resources: []string{"transaction"}, Actions: []Action{ActionRead}, Owner: true, Attributes: map[string]string{"status": "locked"}},
+			subject:  owner,
+			action:   ActionRead,
+			resource: resource,
+			expected: true,
+		},
+	}
+
+	// Run the test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule.Matches(tt.subject, tt.action, tt.resource))
+		})
+	}
+}
+
+// TestPolicy_Evaluate tests the Evaluate function
+func TestPolicy_Evaluate(t *testing.T) {
+	ownerID := uuid.New()
+	policy := NewPolicy(
+		Rule{
+			Name:      "owner",
+			Effect:    EffectAllow,
+			Resources: []string{"transaction"},
+			Actions:   []Action{Wildcard},
+			Owner:     true,
+		},
+		Rule{
+			Name:       "locked",
+			Effect:     EffectDeny,
+			Resources:  []string{"transaction"},
+			Actions:    []Action{ActionUpdate, ActionDelete},
+			Attributes: map[string]string{"status": "locked"},
+		},
+		Rule{
+			Name:      "support",
+			Effect:    EffectAllow,
+			Resources: []string{"transaction"},
+			Actions:   []Action{ActionRead},
+			Roles:     []string{"support"},
+		},
+	)
+
+	// Define the test cases
+	tests := []struct {
+		name         string
+		subject      Subject
+		action       Action
+		resource     Resource
+		expected     bool
+		expectedRule string
+	}{
+		{
+			name:         "no rule matches",
+			subject:      Subject{ID: uuid.New()},
+			action:       ActionRead,
+			resource:     Resource{Type: "transaction", OwnerID: ownerID},
+			expected:     false,
+			expectedRule: "",
+		},
+		{
+			name:         "first allow rule decides",
+			subject:      Subject{ID: ownerID, Roles: []string{"support"}},
+			action:       ActionRead,
+			resource:     Resource{Type: "transaction", OwnerID: ownerID},
+			expected:     true,
+			expectedRule: "owner",
+		},
+		{
+			name:         "deny rule wins over allow rules",
+			subject:      Subject{ID: ownerID},
+			action:       ActionUpdate,
+			resource:     Resource{Type: "transaction", OwnerID: ownerID, Attributes: map[string]string{"status": "locked"}},
+			expected:     false,
+			expectedRule: "locked",
+		},
+		{
+			name:         "deny rule does not apply to other actions",
+			subject:      Subject{ID: ownerID},
+			action:       ActionRead,
+			resource:     Resource{Type: "transaction", OwnerID: ownerID, Attributes: map[string]string{"status": "locked"}},
+			expected:     true,
+			expectedRule: "owner",
+		},
+	}
+
+	// Run the test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, rule := policy.Evaluate(tt.subject, tt.action, tt.resource)
+			assert.Equal(t, tt.expected, allowed)
+			assert.Equal(t, tt.expectedRule, rule)
+		})
+	}
+}
+
+// TestDefaultPolicy tests the rules of the default policy
+func TestDefaultPolicy(t *testing.T) {
+	ownerID := uuid.New()
+	owner := Subject{ID: ownerID}
+	stranger := Subject{ID: uuid.New()}
+	support := Subject{ID: uuid.New(), Roles: []string{SupportRole}}
+	brokersAdmin := Subject{ID: uuid.New(), Permissions: models.Permissions{
+		{Value: "admin.users.brokers.get"},
+		{Value: "admin.users.brokers.delete"},
+	}}
+	superadmin := Subject{ID: uuid.New(), Permissions: models.Permissions{{Value: "*"}}}
+	transaction := Resource{Type: ResourceTransaction, OwnerID: ownerID}
+	brokerUser := Resource{Type: ResourceBrokerUser, OwnerID: ownerID}
+
+	// Define the test cases
+	tests := []struct {
+		name     string
+		subject  Subject
+		action   Action
+		resource Resource
+		expected bool
+	}{
+		{name: "owner reads his transaction", subject: owner, action: ActionRead, resource: transaction, expected: true},
+		{name: "owner updates his transaction", subject: owner, action: ActionUpdate, resource: transaction, expected: true},
+		{name: "owner deletes his transaction", subject: owner, action: ActionDelete, resource: transaction, expected: true},
+		{name: "owner deletes his broker", subject: owner, action: ActionDelete, resource: brokerUser, expected: true},
+		{name: "stranger reads a transaction", subject: stranger, action: ActionRead, resource: transaction, expected: false},
+		{name: "stranger updates a transaction", subject: stranger, action: ActionUpdate, resource: transaction, expected: false},
+		{name: "support reads a transaction", subject: support, action: ActionRead, resource: transaction, expected: true},
+		{name: "support updates a transaction", subject: support, action: ActionUpdate, resource: transaction, expected: false},
+		{name: "support deletes a transaction", subject: support, action: ActionDelete, resource: transaction, expected: false},
+		{name: "support reads a broker", subject: support, action: ActionRead, resource: brokerUser, expected: true},
+		{name: "support deletes a broker", subject: support, action: ActionDelete, resource: brokerUser, expected: false},
+		{name: "admin reads a broker", subject: brokersAdmin, action: ActionRead, resource: brokerUser, expected: true},
+		{name: "admin deletes a broker", subject: brokersAdmin, action: ActionDelete, resource: brokerUser, expected: true},
+		{name: "admin reads a transaction", subject: brokersAdmin, action: ActionRead, resource: transaction, expected: false},
+		{name: "superadmin deletes a broker", subject: superadmin, action: ActionDelete, resource: brokerUser, expected: true},
+		{name: "unknown resource type", subject: owner, action: ActionRead, resource: Resource{Type: "unknown", OwnerID: ownerID}, expected: false},
+	}
+
+	// Run the test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, _ := DefaultPolicy().Evaluate(tt.subject, tt.action, tt.resource)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+package verification
+
+//go:generate mockgen -source=repository.go -destination=../../test/mocks/verification_repository.go --package=mocks -mock_names=Repository=VerificationRepository Repository
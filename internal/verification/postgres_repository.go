@@ -0,0 +1,177 @@
+package verification
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+// PostgresRepository is a repository containing the EmailVerification definition based on a PSQL database and
+// implementing the repository interface
+type PostgresRepository struct {
+	conn *sqlx.DB
+}
+
+// NewPostgresRepository returns a new instance of PostgresRepository
+func NewPostgresRepository(dbClient *sqlx.DB) Repository {
+	r := PostgresRepository{
+		conn: dbClient,
+	}
+	var repo Repository = &r
+	return repo
+}
+
+// Create method used to create an EmailVerification
+func (p PostgresRepository) Create(verification models.EmailVerification) (models.EmailVerification, error) {
+	// Prepare query
+	query := `INSERT INTO email_verification_tokens (user_id, email, token, expires_at)
+				VALUES (:user_id, :email, :token, :expires_at)
+				RETURNING id, user_id, email, token, expires_at, created_at`
+	params := map[string]interface{}{
+		"user_id":    verification.UserID,
+		"email":      verification.Email,
+		"token":      verification.Token,
+		"expires_at": verification.ExpiresAt,
+	}
+
+	// Execute query
+	rows, err := p.conn.NamedQuery(query, params)
+	if err != nil {
+		return models.EmailVerification{}, err
+	}
+	defer rows.Close()
+
+	// Scan result
+	result, _, err := utils.ScanFirst(rows, p.Scan)
+
+	return result, err
+}
+
+// Get retrieves the active EmailVerification of a user matching the token
+func (p PostgresRepository) Get(userID uuid.UUID, token string) (models.EmailVerification, bool, error) {
+	// Prepare query
+	query := `SELECT id, user_id, email, token, expires_at, created_at
+			  FROM email_verification_tokens as v
+			  WHERE v.user_id = :user_id AND v.token = :token AND v.expires_at > NOW()
+			  LIMIT 1`
+	params := map[string]interface{}{
+		"user_id": userID,
+		"token":   token,
+	}
+
+	// Execute query
+	rows, err := p.conn.NamedQuery(query, params)
+	if err != nil {
+		return models.EmailVerification{}, false, err
+	}
+	defer rows.Close()
+
+	return utils.ScanFirst(rows, p.Scan)
+}
+
+// GetLatest retrieves the most recently created EmailVerification of a user, whether active or not
+func (p PostgresRepository) GetLatest(userID uuid.UUID) (models.EmailVerification, bool, error) {
+	// Prepare query
+	query := `SELECT id, user_id, email, token, expires_at, created_at
+			  FROM email_verification_tokens as v
+			  WHERE v.user_id = :user_id
+			  ORDER BY v.created_at DESC
+			  LIMIT 1`
+	params := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	// Execute query
+	rows, err := p.conn.NamedQuery(query, params)
+	if err != nil {
+		return models.EmailVerification{}, false, err
+	}
+	defer rows.Close()
+
+	return utils.ScanFirst(rows, p.Scan)
+}
+
+// CountSince counts the EmailVerification created for a user since the given time
+func (p PostgresRepository) CountSince(userID uuid.UUID, since time.Time) (int, error) {
+	// Prepare query
+	query := `SELECT COUNT(*)
+			  FROM email_verification_tokens as v
+			  WHERE v.user_id = $1 AND v.created_at > $2`
+
+	// Execute query
+	var count int
+	err := p.conn.Get(&count, query, userID, since)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Invalidate expires every active EmailVerification of a user.
+// Expired verifications are kept so that they still count towards the resend limits.
+func (p PostgresRepository) Invalidate(userID uuid.UUID) error {
+	// Prepare query
+	query := `UPDATE email_verification_tokens as v
+			  SET expires_at = NOW()
+			  WHERE v.user_id = :user_id AND v.expires_at > NOW()`
+	params := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	// Execute query
+	_, err := p.conn.NamedExec(query, params)
+	return err
+}
+
+// Delete method used to delete an EmailVerification
+func (p PostgresRepository) Delete(verificationID uuid.UUID) error {
+	// Prepare query
+	query := `DELETE FROM email_verification_tokens as v
+			  WHERE v.id = :id`
+	params := map[string]interface{}{
+		"id": verificationID,
+	}
+
+	// Execute query
+	result, err := p.conn.NamedExec(query, params)
+	if err != nil {
+		return err
+	}
+
+	return utils.CheckRowAffected(result, 1)
+}
+
+// DeleteForUser method used to delete every EmailVerification of a user
+func (p PostgresRepository) DeleteForUser(userID uuid.UUID) error {
+	// Prepare query
+	query := `DELETE FROM email_verification_tokens as v
+			  WHERE v.user_id = :user_id`
+	params := map[string]interface{}{
+		"user_id": userID,
+	}
+
+	// Execute query
+	_, err := p.conn.NamedExec(query, params)
+	return err
+}
+
+// Scan scans the current row of the rows into an EmailVerification
+func (p PostgresRepository) Scan(rows *sqlx.Rows) (models.EmailVerification, error) {
+	var verification models.EmailVerification
+	err := rows.Scan(
+		&verification.ID,
+		&verification.UserID,
+		&verification.Email,
+		&verification.Token,
+		&verification.ExpiresAt,
+		&verification.CreatedAt,
+	)
+	if err != nil {
+		return models.EmailVerification{}, err
+	}
+
+	return verification, nil
+}
@@ -0,0 +1,328 @@
+package verification_test
+
+import (
+	"errors"
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/Zapharaos/fihub-backend/internal/verification"
+	"github.com/Zapharaos/fihub-backend/test"
+	"github.com/google/uuid"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+	"testing"
+	"time"
+)
+
+// verificationColumns are the columns returned when retrieving an EmailVerification
+var verificationColumns = []string{"id", "user_id", "email", "token", "expires_at", "created_at"}
+
+// TestPostgresRepository_Create test the Create method
+func TestPostgresRepository_Create(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	verification.ReplaceGlobals(verification.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail verification creation",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("INSERT INTO email_verification_tokens").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Create verification",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows(verificationColumns).
+					AddRow(uuid.New(), uuid.New(), "email@example.com", "token", time.Now().Add(1*time.Hour), time.Now())
+				sqlxMock.Mock.ExpectQuery("INSERT INTO email_verification_tokens").WillReturnRows(rows)
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			_, err := verification.R().Create(models.EmailVerification{})
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Create() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_Get test the Get method
+func TestPostgresRepository_Get(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	verification.ReplaceGlobals(verification.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectErr   bool
+		expectFound bool
+	}{
+		{
+			name: "Fail to get verification",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_verification_tokens").WillReturnError(errors.New("error"))
+			},
+			expectErr:   true,
+			expectFound: false,
+		},
+		{
+			name: "Verification not found",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_verification_tokens").WillReturnRows(sqlxmock.NewRows(verificationColumns))
+			},
+			expectErr:   false,
+			expectFound: false,
+		},
+		{
+			name: "Get verification",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows(verificationColumns).
+					AddRow(uuid.New(), uuid.New(), "email@example.com", "token", time.Now().Add(1*time.Hour), time.Now())
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_verification_tokens").WillReturnRows(rows)
+			},
+			expectErr:   false,
+			expectFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			_, found, err := verification.R().Get(uuid.New(), "token")
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Get() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if found != tt.expectFound {
+				t.Errorf("Get() found = %v, expectFound %v", found, tt.expectFound)
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_GetLatest test the GetLatest method
+func TestPostgresRepository_GetLatest(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	verification.ReplaceGlobals(verification.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectErr   bool
+		expectFound bool
+	}{
+		{
+			name: "Fail to get latest verification",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_verification_tokens").WillReturnError(errors.New("error"))
+			},
+			expectErr:   true,
+			expectFound: false,
+		},
+		{
+			name: "Get latest verification",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows(verificationColumns).
+					AddRow(uuid.New(), uuid.New(), "email@example.com", "token", time.Now(), time.Now())
+				sqlxMock.Mock.ExpectQuery("SELECT (.+) FROM email_verification_tokens").WillReturnRows(rows)
+			},
+			expectErr:   false,
+			expectFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			_, found, err := verification.R().GetLatest(uuid.New())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("GetLatest() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if found != tt.expectFound {
+				t.Errorf("GetLatest() found = %v, expectFound %v", found, tt.expectFound)
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_CountSince test the CountSince method
+func TestPostgresRepository_CountSince(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	verification.ReplaceGlobals(verification.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectErr     bool
+		expectedCount int
+	}{
+		{
+			name: "Fail to count verifications",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectQuery("SELECT COUNT(.+) FROM email_verification_tokens").WillReturnError(errors.New("error"))
+			},
+			expectErr:     true,
+			expectedCount: 0,
+		},
+		{
+			name: "Count verifications",
+			mockSetup: func() {
+				rows := sqlxmock.NewRows([]string{"count"}).AddRow(3)
+				sqlxMock.Mock.ExpectQuery("SELECT COUNT(.+) FROM email_verification_tokens").WillReturnRows(rows)
+			},
+			expectErr:     false,
+			expectedCount: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			count, err := verification.R().CountSince(uuid.New(), time.Now().Add(-time.Hour))
+			if (err != nil) != tt.expectErr {
+				t.Errorf("CountSince() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if count != tt.expectedCount {
+				t.Errorf("CountSince() count = %v, expectedCount %v", count, tt.expectedCount)
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_Invalidate test the Invalidate method
+func TestPostgresRepository_Invalidate(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	verification.ReplaceGlobals(verification.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail to invalidate verifications",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE email_verification_tokens").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Invalidate verifications",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("UPDATE email_verification_tokens").WillReturnResult(sqlxmock.NewResult(0, 2))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := verification.R().Invalidate(uuid.New())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Invalidate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_Delete test the Delete method
+func TestPostgresRepository_Delete(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	verification.ReplaceGlobals(verification.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail verification delete",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM email_verification_tokens").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Delete verification",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM email_verification_tokens").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := verification.R().Delete(uuid.New())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Delete() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+// TestPostgresRepository_DeleteForUser test the DeleteForUser method
+func TestPostgresRepository_DeleteForUser(t *testing.T) {
+	var sqlxMock test.Sqlx
+	sqlxMock.CreateFullTestSqlx(t)
+	defer sqlxMock.CleanTestSqlx()
+
+	verification.ReplaceGlobals(verification.NewPostgresRepository(sqlxMock.DB))
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		expectErr bool
+	}{
+		{
+			name: "Fail user verifications delete",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM email_verification_tokens").WillReturnError(errors.New("error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "Delete user verifications",
+			mockSetup: func() {
+				sqlxMock.Mock.ExpectExec("DELETE FROM email_verification_tokens").WillReturnResult(sqlxmock.NewResult(0, 3))
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := verification.R().DeleteForUser(uuid.New())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("DeleteForUser() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package verification
+
+import (
+	"github.com/Zapharaos/fihub-backend/internal/models"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Repository is a storage interface which can be implemented by multiple backend
+// (in-memory map, sql database, in-memory cache, file system, ...)
+// It allows standard CRUD operation on EmailVerification
+type Repository interface {
+	Create(verification models.EmailVerification) (models.EmailVerification, error)
+	Get(userID uuid.UUID, token string) (models.EmailVerification, bool, error)
+	GetLatest(userID uuid.UUID) (models.EmailVerification, bool, error)
+	CountSince(userID uuid.UUID, since time.Time) (int, error)
+	Invalidate(userID uuid.UUID) error
+	Delete(verificationID uuid.UUID) error
+	DeleteForUser(userID uuid.UUID) error
+}
+
+var (
+	_globalRepositoryMu sync.RWMutex
+	_globalRepository   Repository
+)
+
+// R is used to access the global repository singleton
+func R() Repository {
+	_globalRepositoryMu.RLock()
+	defer _globalRepositoryMu.RUnlock()
+
+	repository := _globalRepository
+	return repository
+}
+
+// ReplaceGlobals affect a new repository to the global repository singleton
+func ReplaceGlobals(repository Repository) func() {
+	_globalRepositoryMu.Lock()
+	defer _globalRepositoryMu.Unlock()
+
+	prev := _globalRepository
+	_globalRepository = repository
+	return func() { ReplaceGlobals(prev) }
+}
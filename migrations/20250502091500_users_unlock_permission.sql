@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+INSERT INTO permissions (id, value, scope, description)
+VALUES ('5e0f7c1a-3d4b-4f6e-9a2c-8b1d0e7f6a53', 'admin.users.unlock', 'admin', 'Unlock user login');
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DELETE FROM permissions
+WHERE value = 'admin.users.unlock';
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE "users" ADD COLUMN "email_verified" boolean NOT NULL DEFAULT FALSE;
+
+-- Existing accounts were usable before verification was introduced
+UPDATE "users" SET "email_verified" = TRUE;
+
+CREATE TABLE email_verification_tokens
+(
+    id         uuid PRIMARY KEY NOT NULL DEFAULT (gen_random_uuid()),
+    user_id    uuid             NOT NULL,
+    email      varchar(100)     NOT NULL,
+    token      VARCHAR(255)     NOT NULL,
+    expires_at timestamptz      NOT NULL,
+    created_at timestamptz      NOT NULL DEFAULT (NOW()),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE email_verification_tokens;
+
+ALTER TABLE "users" DROP COLUMN "email_verified";
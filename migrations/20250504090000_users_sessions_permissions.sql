@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+INSERT INTO permissions (id, value, scope, description)
+VALUES ('9b3e6d2f-1c7a-4e58-b0d4-6f2a8c5e1b97', 'admin.users.sessions.list', 'admin', 'List user sessions'),
+       ('2d8f4a6c-7e1b-4c93-a5f0-3b9d6e2c8a14', 'admin.users.sessions.delete', 'admin', 'Terminate user sessions');
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DELETE FROM permissions
+WHERE value IN ('admin.users.sessions.list', 'admin.users.sessions.delete');
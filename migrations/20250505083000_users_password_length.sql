@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- PHC encoded Argon2id hashes are longer than bcrypt ones
+ALTER TABLE users
+    ALTER COLUMN password TYPE varchar(255);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE users
+    ALTER COLUMN password TYPE varchar(100);
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+INSERT INTO roles (id, name)
+VALUES ('5f8c2e1a-9b3d-4a67-8e0f-1d7c4b2a9e63', 'support');
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DELETE FROM roles
+WHERE name = 'support';
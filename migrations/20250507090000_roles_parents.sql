@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE "role_parents"
+(
+    "role_id"   uuid NOT NULL,
+    "parent_id" uuid NOT NULL,
+    PRIMARY KEY ("role_id", "parent_id"),
+    FOREIGN KEY ("role_id") REFERENCES "roles" (id) ON DELETE CASCADE,
+    FOREIGN KEY ("parent_id") REFERENCES "roles" (id) ON DELETE CASCADE,
+    CHECK ("role_id" <> "parent_id")
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE "role_parents";
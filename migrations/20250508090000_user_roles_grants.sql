@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Role assignments can be limited in time, and keep track of who granted them and why
+ALTER TABLE "user_roles"
+    ADD COLUMN "valid_from"  timestamptz NOT NULL DEFAULT (NOW()),
+    ADD COLUMN "valid_until" timestamptz NULL,
+    ADD COLUMN "reason"      varchar(255) NOT NULL DEFAULT '',
+    ADD COLUMN "granted_by"  uuid NULL,
+    ADD CHECK ("valid_until" IS NULL OR "valid_until" > "valid_from");
+
+CREATE INDEX "user_roles_valid_until_idx" ON "user_roles" ("valid_until") WHERE "valid_until" IS NOT NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP INDEX "user_roles_valid_until_idx";
+
+ALTER TABLE "user_roles"
+    DROP COLUMN "granted_by",
+    DROP COLUMN "reason",
+    DROP COLUMN "valid_until",
+    DROP COLUMN "valid_from";
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Append-only record of the authentication and authorization events
+CREATE TABLE "audit_logs"
+(
+    "id"          uuid PRIMARY KEY,
+    "occurred_at" timestamptz  NOT NULL DEFAULT (NOW()),
+    "action"      varchar(100) NOT NULL,
+    "outcome"     varchar(20)  NOT NULL,
+    "actor_id"    uuid         NULL,
+    "target_type" varchar(50)  NOT NULL DEFAULT '',
+    "target_id"   varchar(255) NOT NULL DEFAULT '',
+    "request_id"  varchar(255) NOT NULL DEFAULT '',
+    "ip_address"  varchar(64)  NOT NULL DEFAULT '',
+    "details"     jsonb        NOT NULL DEFAULT '{}'
+);
+
+-- Entries outlive the users they mention, hence no foreign key on actor_id
+CREATE INDEX "audit_logs_occurred_at_idx" ON "audit_logs" ("occurred_at");
+CREATE INDEX "audit_logs_actor_id_idx" ON "audit_logs" ("actor_id");
+CREATE INDEX "audit_logs_target_idx" ON "audit_logs" ("target_type", "target_id");
+
+-- Entries can only be removed by the retention sweeper, never altered
+-- +goose StatementBegin
+CREATE FUNCTION "audit_logs_reject_update"() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit log entries cannot be updated';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER "audit_logs_no_update"
+    BEFORE UPDATE
+    ON "audit_logs"
+    FOR EACH ROW
+EXECUTE FUNCTION "audit_logs_reject_update"();
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TRIGGER "audit_logs_no_update" ON "audit_logs";
+DROP FUNCTION "audit_logs_reject_update"();
+DROP TABLE "audit_logs";
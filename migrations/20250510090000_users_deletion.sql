@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Deleted accounts are kept during a grace period before being permanently removed
+ALTER TABLE "users" ADD COLUMN "deletion_scheduled_at" timestamptz NULL;
+
+CREATE INDEX "users_deletion_scheduled_at_idx" ON "users" ("deletion_scheduled_at") WHERE "deletion_scheduled_at" IS NOT NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP INDEX "users_deletion_scheduled_at_idx";
+
+ALTER TABLE "users" DROP COLUMN "deletion_scheduled_at";
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Profile preferences : empty values let the clients derive them from the language
+ALTER TABLE "users" ADD COLUMN "display_name" varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "language" varchar(35) NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "timezone" varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "base_currency" varchar(3) NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "number_format" varchar(16) NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "date_format" varchar(16) NOT NULL DEFAULT '';
+
+CREATE TABLE "user_avatar"
+(
+    "user_id"    uuid PRIMARY KEY NOT NULL,
+    "name"       varchar(255)     NOT NULL,
+    "data"       bytea            NOT NULL,
+    "updated_at" timestamptz      NOT NULL,
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE "user_avatar";
+
+ALTER TABLE "users" DROP COLUMN "date_format";
+ALTER TABLE "users" DROP COLUMN "number_format";
+ALTER TABLE "users" DROP COLUMN "base_currency";
+ALTER TABLE "users" DROP COLUMN "timezone";
+ALTER TABLE "users" DROP COLUMN "language";
+ALTER TABLE "users" DROP COLUMN "display_name";
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Disabled accounts can not log in until enabled again
+ALTER TABLE "users" ADD COLUMN "disabled_at" timestamptz;
+
+-- Accounts forced to reset their password can not log in until they do so
+ALTER TABLE "users" ADD COLUMN "password_reset_required" boolean NOT NULL DEFAULT false;
+
+-- Searching users by email prefix
+CREATE INDEX "users_email_lower_idx" ON "users" (lower("email") varchar_pattern_ops);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP INDEX "users_email_lower_idx";
+
+ALTER TABLE "users" DROP COLUMN "password_reset_required";
+ALTER TABLE "users" DROP COLUMN "disabled_at";
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Paginating users along their creation date, the ID breaking the ties
+CREATE INDEX "users_created_at_id_idx" ON "users" ("created_at", "id");
+
+-- Paginating users along their email, the ID breaking the ties
+CREATE INDEX "users_email_id_idx" ON "users" ("email", "id");
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP INDEX "users_email_id_idx";
+DROP INDEX "users_created_at_id_idx";
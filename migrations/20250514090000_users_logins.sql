@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Time of the last successful login
+ALTER TABLE "users" ADD COLUMN "last_login_at" timestamptz;
+
+-- Rolling history of the login attempts on each account
+CREATE TABLE "user_logins"
+(
+    "id"          uuid PRIMARY KEY NOT NULL DEFAULT (gen_random_uuid()),
+    "user_id"     uuid             NOT NULL,
+    "occurred_at" timestamptz      NOT NULL DEFAULT (NOW()),
+    "ip_address"  varchar(45)      NOT NULL DEFAULT '',
+    "user_agent"  varchar(512)     NOT NULL DEFAULT '',
+    "method"      varchar(32)      NOT NULL,
+    "success"     boolean          NOT NULL,
+    "reason"      varchar(64)      NOT NULL DEFAULT '',
+    FOREIGN KEY ("user_id") REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX "user_logins_user_id_occurred_at_idx" ON "user_logins" ("user_id", "occurred_at" DESC);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE "user_logins";
+
+ALTER TABLE "users" DROP COLUMN "last_login_at";
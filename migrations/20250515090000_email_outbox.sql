@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Emails waiting to be delivered, along with the record of their delivery
+CREATE TABLE "email_outbox"
+(
+    "id"                 uuid PRIMARY KEY NOT NULL DEFAULT (gen_random_uuid()),
+    "idempotency_key"    varchar(255)     NOT NULL UNIQUE,
+    "to_addresses"       text[]           NOT NULL,
+    "cc_addresses"       text[]           NOT NULL DEFAULT '{}',
+    "reply_to"           varchar(255)     NOT NULL DEFAULT '',
+    "subject"            varchar(998)     NOT NULL DEFAULT '',
+    "plain_text_content" text             NOT NULL DEFAULT '',
+    "html_content"       text             NOT NULL DEFAULT '',
+    "attachments"        jsonb            NOT NULL DEFAULT '[]',
+    "status"             varchar(20)      NOT NULL DEFAULT 'pending',
+    "attempts"           integer          NOT NULL DEFAULT 0,
+    "last_error"         text             NOT NULL DEFAULT '',
+    "next_attempt_at"    timestamptz      NOT NULL DEFAULT (NOW()),
+    "created_at"         timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"         timestamptz      NOT NULL DEFAULT (NOW()),
+    "sent_at"            timestamptz
+);
+
+-- Claiming the emails due for delivery
+CREATE INDEX "email_outbox_due_idx" ON "email_outbox" ("next_attempt_at") WHERE "status" IN ('pending', 'sending');
+CREATE INDEX "email_outbox_status_created_at_idx" ON "email_outbox" ("status", "created_at" DESC);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE "email_outbox";
@@ -1,7 +1,9 @@
 // Package email provides functionality for sending emails.
 //
-// This package defines an interface for email services, allowing for different implementations.
-// The default implementation uses the SendGrid service to send emails.
+// This package defines an interface for email services, allowing for different transports :
+//   - SendGrid (default), through its web API
+//   - SMTP, in plain text, with STARTTLS or over implicit TLS, with optional authentication
+//   - Mailbox, writing the emails as .eml files in a directory, for development and tests
 //
 // In your main.go or application initialization file, you can initialize the email service like this:
 //
@@ -12,8 +14,8 @@
 //	)
 //
 //	func main() {
-//	    // Initialize the email service
-//	    emailService := email.NewSendgridService()
+//	    // Initialize the email service, using the transport selected by EMAIL_TRANSPORT
+//	    emailService := email.NewServiceFromConfig()
 //
 //	    // Replace the global email service instance
 //	    email.ReplaceGlobals(emailService)
@@ -23,5 +25,11 @@
 //
 //	err := service.Send("recipient@example.com", "Subject", "Plain text content", "HTML content")
 //
-// For more information, see the documentation for the SendGrid library.
+// To send an email with carbon copies, a reply-to address or attachments:
+//
+//	message := email.NewMessage("recipient@example.com", "Subject", "Plain text content", "HTML content")
+//	message.Cc = []string{"copy@example.com"}
+//	message.ReplyTo = "support@example.com"
+//	message.Attachments = []email.Attachment{{Filename: "data.json", ContentType: "application/json", Content: data}}
+//	err := service.SendMessage(message)
 package email
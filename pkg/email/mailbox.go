@@ -0,0 +1,74 @@
+package email
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MailboxService implements the Service interface by writing the emails as .eml files in a directory.
+// Meant for development and tests : the files can be opened with any email client.
+type MailboxService struct {
+	dir  string
+	from mail.Address
+}
+
+// NewMailboxServiceFromConfig returns a new instance of MailboxService based on the configuration
+func NewMailboxServiceFromConfig() Service {
+	s := MailboxService{
+		dir:  viper.GetString("EMAIL_MAILBOX_DIR"),
+		from: senderFromConfig(),
+	}
+
+	if s.dir == "" {
+		s.dir = "mailbox"
+	}
+
+	var service Service = &s
+	return service
+}
+
+// Send writes an email in the mailbox
+func (s *MailboxService) Send(emailTo, subject, plainTextContent, htmlContent string) error {
+	return s.SendMessage(NewMessage(emailTo, subject, plainTextContent, htmlContent))
+}
+
+// SendMessage writes a message in the mailbox.
+// The files are named after the date of the message, so that they are listed in the order they were sent.
+func (s *MailboxService) SendMessage(message Message) error {
+	if err := message.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	data, err := buildMIME(s.from, message, now)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(s.dir, 0o755)
+	if err != nil {
+		zap.L().Error("Mailbox email send", zap.Error(err))
+		return err
+	}
+
+	random := make([]byte, 4)
+	_, _ = rand.Read(random)
+	name := now.UTC().Format("20060102T150405.000000000Z") + "-" + hex.EncodeToString(random) + ".eml"
+	path := filepath.Join(s.dir, name)
+
+	// The emails hold secrets such as one-time passwords
+	err = os.WriteFile(path, data, 0o600)
+	if err != nil {
+		zap.L().Error("Mailbox email send", zap.Error(err))
+		return err
+	}
+
+	zap.L().Info("Email written", zap.Strings("to", message.To), zap.String("subject", message.Subject), zap.String("path", path))
+	return nil
+}
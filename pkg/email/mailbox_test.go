@@ -0,0 +1,64 @@
+package email
+
+import (
+	"bytes"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNewMailboxServiceFromConfig tests the NewMailboxServiceFromConfig function
+func TestNewMailboxServiceFromConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		viper.Reset()
+		service := NewMailboxServiceFromConfig().(*MailboxService)
+		assert.Equal(t, "mailbox", service.dir)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		viper.Set("EMAIL_MAILBOX_DIR", "/tmp/fihub-mailbox")
+		defer viper.Reset()
+
+		service := NewMailboxServiceFromConfig().(*MailboxService)
+		assert.Equal(t, "/tmp/fihub-mailbox", service.dir)
+	})
+}
+
+// TestMailboxService_SendMessage tests the SendMessage method of MailboxService
+func TestMailboxService_SendMessage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mailbox")
+	service := &MailboxService{
+		dir:  dir,
+		from: mail.Address{Name: "Fihub", Address: "sender@example.com"},
+	}
+
+	// Writes one file per email, creating the directory
+	require.NoError(t, service.Send("first@example.com", "First", "Plain", ""))
+	require.NoError(t, service.Send("second@example.com", "Second", "Plain", ""))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	// Files are listed in the order the emails were sent
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "<first@example.com>", msg.Header.Get("To"))
+	assert.Equal(t, "First", msg.Header.Get("Subject"))
+
+	info, err := os.Stat(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// Invalid messages are not written
+	err = service.SendMessage(Message{Subject: "No recipient"})
+	assert.ErrorIs(t, err, ErrNoRecipient)
+	files, _ = filepath.Glob(filepath.Join(dir, "*.eml"))
+	assert.Len(t, files, 2)
+}
@@ -0,0 +1,65 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoRecipient    = errors.New("email has no recipient")
+	ErrInvalidAddress = errors.New("invalid email address")
+)
+
+// Attachment is a file attached to a Message.
+// ContentType defaults to "application/octet-stream" when empty.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Message is an email, sent to the To and Cc recipients.
+// Replies are sent to ReplyTo if set, otherwise to the sender.
+// Either content can be empty, but at least one of them should be set.
+type Message struct {
+	To               []string
+	Cc               []string
+	ReplyTo          string
+	Subject          string
+	PlainTextContent string
+	HTMLContent      string
+	Attachments      []Attachment
+}
+
+// NewMessage returns a new Message sent to a single recipient, without attachments
+func NewMessage(emailTo, subject, plainTextContent, htmlContent string) Message {
+	return Message{
+		To:               []string{emailTo},
+		Subject:          subject,
+		PlainTextContent: plainTextContent,
+		HTMLContent:      htmlContent,
+	}
+}
+
+// Recipients returns the addresses the message is delivered to
+func (m Message) Recipients() []string {
+	recipients := make([]string, 0, len(m.To)+len(m.Cc))
+	recipients = append(recipients, m.To...)
+	return append(recipients, m.Cc...)
+}
+
+// Validate checks that the message has at least one recipient and that all the addresses are valid
+func (m Message) Validate() error {
+	if len(m.To) == 0 {
+		return ErrNoRecipient
+	}
+	for _, address := range m.Recipients() {
+		if !IsValid(address) {
+			return fmt.Errorf("%w: %q", ErrInvalidAddress, address)
+		}
+	}
+	if m.ReplyTo != "" && !IsValid(m.ReplyTo) {
+		return fmt.Errorf("%w: %q", ErrInvalidAddress, m.ReplyTo)
+	}
+	return nil
+}
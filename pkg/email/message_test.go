@@ -0,0 +1,74 @@
+package email
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// TestNewMessage tests the NewMessage function
+func TestNewMessage(t *testing.T) {
+	message := NewMessage("to@example.com", "Subject", "Plain", "<p>HTML</p>")
+
+	assert.Equal(t, Message{
+		To:               []string{"to@example.com"},
+		Subject:          "Subject",
+		PlainTextContent: "Plain",
+		HTMLContent:      "<p>HTML</p>",
+	}, message)
+}
+
+// TestMessage_Recipients tests the Recipients method of Message
+func TestMessage_Recipients(t *testing.T) {
+	message := Message{
+		To: []string{"a@example.com", "b@example.com"},
+		Cc: []string{"c@example.com"},
+	}
+
+	assert.Equal(t, []string{"a@example.com", "b@example.com", "c@example.com"}, message.Recipients())
+}
+
+// TestMessage_Validate tests the Validate method of Message
+func TestMessage_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  Message
+		expected error
+	}{
+		{
+			name:     "valid",
+			message:  Message{To: []string{"to@example.com"}, Cc: []string{"cc@example.com"}, ReplyTo: "reply@example.com"},
+			expected: nil,
+		},
+		{
+			name:     "no recipient",
+			message:  Message{Cc: []string{"cc@example.com"}},
+			expected: ErrNoRecipient,
+		},
+		{
+			name:     "invalid recipient",
+			message:  Message{To: []string{"to@example.com\r\nBcc: spy@example.com"}},
+			expected: ErrInvalidAddress,
+		},
+		{
+			name:     "invalid carbon copy",
+			message:  Message{To: []string{"to@example.com"}, Cc: []string{"cc"}},
+			expected: ErrInvalidAddress,
+		},
+		{
+			name:     "invalid reply-to",
+			message:  Message{To: []string{"to@example.com"}, ReplyTo: "Reply <reply@example.com>"},
+			expected: ErrInvalidAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.message.Validate()
+			if tt.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expected)
+			}
+		})
+	}
+}
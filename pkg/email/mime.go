@@ -0,0 +1,175 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// base64LineLength is the maximum length of the lines of base64 encoded content (RFC 2045)
+const base64LineLength = 76
+
+// headerOrder is the order in which the header fields are written
+var headerOrder = []string{"From", "To", "Cc", "Reply-To", "Subject", "Date", "Message-Id", "MIME-Version",
+	"Content-Type", "Content-Transfer-Encoding", "Content-Disposition"}
+
+// mimePart is a MIME entity : the header describing its content, and the function writing that content
+type mimePart struct {
+	header textproto.MIMEHeader
+	write  func(w io.Writer) error
+}
+
+// buildMIME returns the RFC 5322 representation of the message sent by from at the given date.
+// Both contents are sent as alternatives, and wrapped along with the attachments when there are any.
+func buildMIME(from mail.Address, message Message, date time.Time) ([]byte, error) {
+	body := contentPart(message)
+	if len(message.Attachments) > 0 {
+		parts := []mimePart{body}
+		for _, attachment := range message.Attachments {
+			parts = append(parts, attachmentPart(attachment))
+		}
+		body = multipartPart("multipart/mixed", parts)
+	}
+
+	header := body.header
+	header.Set("From", from.String())
+	header.Set("To", formatAddresses(message.To))
+	if len(message.Cc) > 0 {
+		header.Set("Cc", formatAddresses(message.Cc))
+	}
+	if message.ReplyTo != "" {
+		header.Set("Reply-To", formatAddresses([]string{message.ReplyTo}))
+	}
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", message.Subject))
+	header.Set("Date", date.Format(time.RFC1123Z))
+	header.Set("Message-Id", messageID(from))
+	header.Set("MIME-Version", "1.0")
+
+	var buf bytes.Buffer
+	for _, key := range headerOrder {
+		for _, value := range header.Values(key) {
+			fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+		}
+	}
+	buf.WriteString("\r\n")
+	if err := body.write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// contentPart returns the contents of the message, as alternatives when both are set
+func contentPart(message Message) mimePart {
+	switch {
+	case message.HTMLContent == "":
+		return textPart("text/plain", message.PlainTextContent)
+	case message.PlainTextContent == "":
+		return textPart("text/html", message.HTMLContent)
+	default:
+		return multipartPart("multipart/alternative", []mimePart{
+			textPart("text/plain", message.PlainTextContent),
+			textPart("text/html", message.HTMLContent),
+		})
+	}
+}
+
+// textPart returns a text encoded as quoted-printable
+func textPart(contentType, content string) mimePart {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", contentType+"; charset=utf-8")
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return mimePart{
+		header: header,
+		write: func(w io.Writer) error {
+			qp := quotedprintable.NewWriter(w)
+			if _, err := qp.Write([]byte(content)); err != nil {
+				return err
+			}
+			return qp.Close()
+		},
+	}
+}
+
+// attachmentPart returns an attachment encoded as base64, wrapped in lines of base64LineLength characters
+func attachmentPart(attachment Attachment) mimePart {
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": attachment.Filename,
+	}))
+	return mimePart{
+		header: header,
+		write: func(w io.Writer) error {
+			encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+			for len(encoded) > 0 {
+				n := min(base64LineLength, len(encoded))
+				if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+					return err
+				}
+				encoded = encoded[n:]
+			}
+			return nil
+		},
+	}
+}
+
+// multipartPart returns the parts enclosed in a multipart entity of the given type
+func multipartPart(contentType string, parts []mimePart) mimePart {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"boundary": boundary}))
+	return mimePart{
+		header: header,
+		write: func(w io.Writer) error {
+			writer := multipart.NewWriter(w)
+			if err := writer.SetBoundary(boundary); err != nil {
+				return err
+			}
+			for _, part := range parts {
+				pw, err := writer.CreatePart(part.header)
+				if err != nil {
+					return err
+				}
+				if err = part.write(pw); err != nil {
+					return err
+				}
+			}
+			return writer.Close()
+		},
+	}
+}
+
+// formatAddresses returns the addresses as a header field value
+func formatAddresses(addresses []string) string {
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		formatted[i] = (&mail.Address{Address: address}).String()
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// messageID returns a new unique Message-Id, in the domain of the sender
+func messageID(from mail.Address) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from.Address, "@"); at >= 0 {
+		domain = from.Address[at+1:]
+	}
+	random := make([]byte, 16)
+	_, _ = rand.Read(random)
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(random), domain)
+}
@@ -0,0 +1,133 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parseMIME parses an email built by buildMIME
+func parseMIME(t *testing.T, data []byte) (*mail.Message, string, map[string]string) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	return msg, mediaType, params
+}
+
+// readPart returns the decoded content of a part
+func readPart(t *testing.T, part *multipart.Part) string {
+	var reader io.Reader = part
+	if part.Header.Get("Content-Transfer-Encoding") == "base64" {
+		reader = base64.NewDecoder(base64.StdEncoding, part)
+	}
+	// The quoted-printable parts are decoded by the multipart reader
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(content)
+}
+
+// Test_buildMIME tests the buildMIME function
+func Test_buildMIME(t *testing.T) {
+	from := mail.Address{Name: "Fihub", Address: "sender@example.com"}
+	date := time.Date(2025, 5, 15, 10, 30, 0, 0, time.UTC)
+
+	t.Run("Plain text only", func(t *testing.T) {
+		data, err := buildMIME(from, NewMessage("to@example.com", "Héllo", "Line with é", ""), date)
+		require.NoError(t, err)
+
+		msg, mediaType, params := parseMIME(t, data)
+		assert.Equal(t, `"Fihub" <sender@example.com>`, msg.Header.Get("From"))
+		assert.Equal(t, "<to@example.com>", msg.Header.Get("To"))
+		assert.Empty(t, msg.Header.Get("Cc"))
+		assert.Empty(t, msg.Header.Get("Reply-To"))
+		subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Héllo", subject)
+		sentAt, err := msg.Header.Date()
+		assert.NoError(t, err)
+		assert.True(t, date.Equal(sentAt))
+		assert.True(t, strings.HasSuffix(msg.Header.Get("Message-Id"), "@example.com>"))
+		assert.Equal(t, "text/plain", mediaType)
+		assert.Equal(t, "utf-8", params["charset"])
+
+		body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+		assert.NoError(t, err)
+		assert.Equal(t, "Line with é", string(body))
+	})
+
+	t.Run("Alternatives with carbon copies and reply-to", func(t *testing.T) {
+		message := NewMessage("to@example.com", "Subject", "Plain", "<p>HTML</p>")
+		message.Cc = []string{"cc1@example.com", "cc2@example.com"}
+		message.ReplyTo = "support@example.com"
+		data, err := buildMIME(from, message, date)
+		require.NoError(t, err)
+
+		msg, mediaType, params := parseMIME(t, data)
+		assert.Equal(t, "<cc1@example.com>, <cc2@example.com>", msg.Header.Get("Cc"))
+		assert.Equal(t, "<support@example.com>", msg.Header.Get("Reply-To"))
+		assert.Equal(t, "multipart/alternative", mediaType)
+
+		reader := multipart.NewReader(msg.Body, params["boundary"])
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain; charset=utf-8", part.Header.Get("Content-Type"))
+		assert.Equal(t, "Plain", readPart(t, part))
+		part, err = reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "text/html; charset=utf-8", part.Header.Get("Content-Type"))
+		assert.Equal(t, "<p>HTML</p>", readPart(t, part))
+		_, err = reader.NextPart()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("Attachments", func(t *testing.T) {
+		content := bytes.Repeat([]byte("fihub"), 100)
+		message := NewMessage("to@example.com", "Subject", "Plain", "<p>HTML</p>")
+		message.Attachments = []Attachment{
+			{Filename: "export.zip", ContentType: "application/zip", Content: content},
+			{Filename: "notes données.txt", Content: []byte("notes")},
+		}
+		data, err := buildMIME(from, message, date)
+		require.NoError(t, err)
+
+		// Lines are wrapped
+		for _, line := range strings.Split(string(data), "\r\n") {
+			assert.LessOrEqual(t, len(line), 998)
+		}
+
+		msg, mediaType, params := parseMIME(t, data)
+		assert.Equal(t, "multipart/mixed", mediaType)
+		reader := multipart.NewReader(msg.Body, params["boundary"])
+
+		// Contents
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		contentType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		assert.NoError(t, err)
+		assert.Equal(t, "multipart/alternative", contentType)
+
+		// Attachments
+		part, err = reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "application/zip", part.Header.Get("Content-Type"))
+		assert.Equal(t, "export.zip", part.FileName())
+		assert.Equal(t, string(content), readPart(t, part))
+		part, err = reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "application/octet-stream", part.Header.Get("Content-Type"))
+		assert.Equal(t, "notes données.txt", part.FileName())
+		assert.Equal(t, "notes", readPart(t, part))
+		_, err = reader.NextPart()
+		assert.Equal(t, io.EOF, err)
+	})
+}
@@ -2,11 +2,14 @@ package email
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"github.com/sendgrid/rest"
 	"github.com/sendgrid/sendgrid-go"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
-	"os"
+	"net/http"
 )
 
 // SendgridClient is an interface for sending emails
@@ -23,14 +26,13 @@ type SendgridService struct {
 
 // NewSendgridService returns a new instance of SendgridService
 func NewSendgridService() Service {
-	// Get SendGrid API key and sender info from environment variables
-	apiKey := os.Getenv("SENDGRID_API_KEY")
-	senderName := os.Getenv("SENDGRID_SENDER_NAME")
-	senderEmail := os.Getenv("SENDGRID_SENDER_EMAIL")
+	// Get SendGrid API key and sender info from the configuration
+	apiKey := viper.GetString("SENDGRID_API_KEY")
+	sender := senderFromConfig()
 
 	s := SendgridService{
 		client: sendgrid.NewSendClient(apiKey),
-		from:   mail.NewEmail(senderName, senderEmail),
+		from:   mail.NewEmail(sender.Name, sender.Address),
 	}
 	var service Service = &s
 	return service
@@ -38,17 +40,61 @@ func NewSendgridService() Service {
 
 // Send sends an email using SendGrid
 func (s *SendgridService) Send(emailTo, subject, plainTextContent, htmlContent string) error {
+	return s.SendMessage(NewMessage(emailTo, subject, plainTextContent, htmlContent))
+}
+
+// SendMessage sends a message using SendGrid
+func (s *SendgridService) SendMessage(message Message) error {
+	if err := message.Validate(); err != nil {
+		return err
+	}
+
 	// Email props
-	to := mail.NewEmail(emailTo, emailTo)
-	message := mail.NewSingleEmail(s.from, subject, to, plainTextContent, htmlContent)
+	email := mail.NewV3Mail()
+	email.SetFrom(s.from)
+	email.Subject = message.Subject
+	personalization := mail.NewPersonalization()
+	for _, to := range message.To {
+		personalization.AddTos(mail.NewEmail(to, to))
+	}
+	for _, cc := range message.Cc {
+		personalization.AddCCs(mail.NewEmail(cc, cc))
+	}
+	email.AddPersonalizations(personalization)
+	if message.ReplyTo != "" {
+		email.SetReplyTo(mail.NewEmail(message.ReplyTo, message.ReplyTo))
+	}
+
+	// Contents, the plain text one being expected first
+	if message.PlainTextContent != "" {
+		email.AddContent(mail.NewContent("text/plain", message.PlainTextContent))
+	}
+	if message.HTMLContent != "" {
+		email.AddContent(mail.NewContent("text/html", message.HTMLContent))
+	}
+
+	// Attachments
+	for _, attachment := range message.Attachments {
+		a := mail.NewAttachment()
+		a.SetFilename(attachment.Filename)
+		a.SetContent(base64.StdEncoding.EncodeToString(attachment.Content))
+		if attachment.ContentType != "" {
+			a.SetType(attachment.ContentType)
+		}
+		a.SetDisposition("attachment")
+		email.AddAttachment(a)
+	}
 
 	// Send email
-	_, err := s.client.Send(message)
+	response, err := s.client.Send(email)
+	if err == nil && response != nil && response.StatusCode >= http.StatusBadRequest {
+		err = fmt.Errorf("sendgrid responded with status %d: %s", response.StatusCode, response.Body)
+	}
 	if err != nil {
 		zap.L().Error("Sendgrid email send", zap.Error(err))
 		return err
 	}
 
-	zap.L().Info("Email sent", zap.String("to", emailTo), zap.String("subject", subject))
+	zap.L().Info("Email sent", zap.Strings("to", message.To), zap.String("subject", message.Subject))
 	return nil
 }
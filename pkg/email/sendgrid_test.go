@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"github.com/sendgrid/rest"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
-	"os"
+	"net/http"
 	"testing"
 )
 
@@ -25,21 +26,30 @@ func (m MockSendgridClient) SendWithContext(ctx context.Context, email *mail.SGM
 
 // TestNewSendgridService tests the NewSendgridService function
 func TestNewSendgridService(t *testing.T) {
-	os.Setenv("SENDGRID_API_KEY", "test_api_key")
-	os.Setenv("SENDGRID_SENDER_NAME", "test_sender_name")
-	os.Setenv("SENDGRID_SENDER_EMAIL", "test_sender_email")
+	viper.Set("SENDGRID_API_KEY", "test_api_key")
+	viper.Set("SENDGRID_SENDER_NAME", "test_sender_name")
+	viper.Set("SENDGRID_SENDER_EMAIL", "sender@example.com")
+	defer viper.Reset()
 
 	service := NewSendgridService()
 
 	assert.NotNil(t, service)
 	assert.IsType(t, &SendgridService{}, service)
+	assert.Equal(t, "test_sender_name", service.(*SendgridService).from.Name)
+	assert.Equal(t, "sender@example.com", service.(*SendgridService).from.Address)
+
+	// The generic sender settings take precedence
+	viper.Set("EMAIL_SENDER_EMAIL", "noreply@example.com")
+	service = NewSendgridService()
+	assert.Equal(t, "noreply@example.com", service.(*SendgridService).from.Address)
 }
 
 // TestSendgridService_Send tests the Send method of SendgridService
 func TestSendgridService_Send(t *testing.T) {
-	os.Setenv("SENDGRID_API_KEY", "test_api_key")
-	os.Setenv("SENDGRID_SENDER_NAME", "test_sender_name")
-	os.Setenv("SENDGRID_SENDER_EMAIL", "test_sender_email")
+	viper.Set("SENDGRID_API_KEY", "test_api_key")
+	viper.Set("SENDGRID_SENDER_NAME", "test_sender_name")
+	viper.Set("SENDGRID_SENDER_EMAIL", "sender@example.com")
+	defer viper.Reset()
 
 	service := NewSendgridService()
 
@@ -65,4 +75,71 @@ func TestSendgridService_Send(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, "failed to send email", err.Error())
 	})
+
+	// Test case where SendGrid rejects the email
+	t.Run("Rejected", func(t *testing.T) {
+		service.(*SendgridService).client = MockSendgridClient{
+			SendResponse: &rest.Response{StatusCode: http.StatusUnauthorized, Body: "unauthorized"},
+		}
+
+		err := service.Send("test@example.com", "Test Subject", "Test Plain Text", "Test HTML Content")
+		assert.Error(t, err)
+	})
+
+	// Test case where the recipient is invalid
+	t.Run("Invalid recipient", func(t *testing.T) {
+		service.(*SendgridService).client = MockSendgridClient{
+			SendResponse: &rest.Response{},
+		}
+
+		err := service.Send("not an email", "Test Subject", "Test Plain Text", "Test HTML Content")
+		assert.ErrorIs(t, err, ErrInvalidAddress)
+	})
+}
+
+// TestSendgridService_SendMessage tests the SendMessage method of SendgridService
+func TestSendgridService_SendMessage(t *testing.T) {
+	client := &capturingSendgridClient{}
+	service := &SendgridService{
+		client: client,
+		from:   mail.NewEmail("Fihub", "sender@example.com"),
+	}
+
+	err := service.SendMessage(Message{
+		To:               []string{"to@example.com"},
+		Cc:               []string{"cc@example.com"},
+		ReplyTo:          "support@example.com",
+		Subject:          "Test Subject",
+		PlainTextContent: "Test Plain Text",
+		Attachments: []Attachment{
+			{Filename: "data.json", ContentType: "application/json", Content: []byte("{}")},
+		},
+	})
+	assert.NoError(t, err)
+
+	sent := client.sent
+	assert.Equal(t, "sender@example.com", sent.From.Address)
+	assert.Equal(t, "Test Subject", sent.Subject)
+	assert.Equal(t, "to@example.com", sent.Personalizations[0].To[0].Address)
+	assert.Equal(t, "cc@example.com", sent.Personalizations[0].CC[0].Address)
+	assert.Equal(t, "support@example.com", sent.ReplyTo.Address)
+	assert.Len(t, sent.Content, 1)
+	assert.Equal(t, "text/plain", sent.Content[0].Type)
+	assert.Equal(t, "data.json", sent.Attachments[0].Filename)
+	assert.Equal(t, "application/json", sent.Attachments[0].Type)
+	assert.Equal(t, "e30=", sent.Attachments[0].Content)
+}
+
+// capturingSendgridClient keeps the last email sent
+type capturingSendgridClient struct {
+	sent *mail.SGMailV3
+}
+
+func (c *capturingSendgridClient) Send(email *mail.SGMailV3) (*rest.Response, error) {
+	c.sent = email
+	return &rest.Response{StatusCode: http.StatusAccepted}, nil
+}
+
+func (c *capturingSendgridClient) SendWithContext(ctx context.Context, email *mail.SGMailV3) (*rest.Response, error) {
+	return c.Send(email)
 }
@@ -1,12 +1,52 @@
 package email
 
 import (
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"net/mail"
 	"sync"
 )
 
 // Service defines the interface for handling emails
 type Service interface {
 	Send(emailTo, subject, plainTextContent, htmlContent string) error
+	SendMessage(message Message) error
+}
+
+// Transports used to deliver the emails
+const (
+	TransportSendgrid = "sendgrid"
+	TransportSMTP     = "smtp"
+	TransportMailbox  = "mailbox"
+)
+
+// NewServiceFromConfig returns a new instance of the Service delivering the emails through the EMAIL_TRANSPORT
+func NewServiceFromConfig() Service {
+	switch viper.GetString("EMAIL_TRANSPORT") {
+	case "", TransportSendgrid:
+		return NewSendgridService()
+	case TransportSMTP:
+		return NewSMTPServiceFromConfig()
+	case TransportMailbox:
+		return NewMailboxServiceFromConfig()
+	default:
+		zap.L().Warn("Unknown email transport, using sendgrid", zap.String("transport", viper.GetString("EMAIL_TRANSPORT")))
+		return NewSendgridService()
+	}
+}
+
+// senderFromConfig returns the sender of the emails.
+// The SendGrid specific settings are still read when the generic ones are not set.
+func senderFromConfig() mail.Address {
+	name := viper.GetString("EMAIL_SENDER_NAME")
+	if name == "" {
+		name = viper.GetString("SENDGRID_SENDER_NAME")
+	}
+	address := viper.GetString("EMAIL_SENDER_EMAIL")
+	if address == "" {
+		address = viper.GetString("SENDGRID_SENDER_EMAIL")
+	}
+	return mail.Address{Name: name, Address: address}
 }
 
 var (
@@ -52,3 +52,17 @@ func (mr *MockServiceMockRecorder) Send(emailTo, subject, plainTextContent, html
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockService)(nil).Send), emailTo, subject, plainTextContent, htmlContent)
 }
+
+// SendMessage mocks base method.
+func (m *MockService) SendMessage(message Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockServiceMockRecorder) SendMessage(message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockService)(nil).SendMessage), message)
+}
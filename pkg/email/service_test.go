@@ -1,6 +1,7 @@
 package email
 
 import (
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
@@ -41,3 +42,25 @@ func TestEmailS(t *testing.T) {
 	service := S()
 	assert.Equal(t, m, service)
 }
+
+// TestNewServiceFromConfig tests the NewServiceFromConfig function
+// It verifies that the transport is selected from the configuration, SendGrid being the default.
+func TestNewServiceFromConfig(t *testing.T) {
+	defer viper.Reset()
+
+	tests := []struct {
+		transport string
+		expected  Service
+	}{
+		{transport: "", expected: &SendgridService{}},
+		{transport: TransportSendgrid, expected: &SendgridService{}},
+		{transport: TransportSMTP, expected: &SMTPService{}},
+		{transport: TransportMailbox, expected: &MailboxService{}},
+		{transport: "pigeon", expected: &SendgridService{}},
+	}
+
+	for _, tt := range tests {
+		viper.Set("EMAIL_TRANSPORT", tt.transport)
+		assert.IsType(t, tt.expected, NewServiceFromConfig(), tt.transport)
+	}
+}
@@ -0,0 +1,172 @@
+package email
+
+import (
+	"crypto/tls"
+	"fmt"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// Securities of the connection to the SMTP server
+const (
+	// SMTPSecurityNone sends the emails in plain text, for local relays only
+	SMTPSecurityNone = "none"
+	// SMTPSecurityStartTLS upgrades the connection to TLS with the STARTTLS command, which is required
+	SMTPSecurityStartTLS = "starttls"
+	// SMTPSecurityTLS establishes the connection over TLS right away (implicit TLS)
+	SMTPSecurityTLS = "tls"
+)
+
+// SMTPService implements the Service interface using an SMTP server
+type SMTPService struct {
+	host      string
+	port      int
+	username  string
+	password  string
+	security  string
+	timeout   time.Duration
+	tlsConfig *tls.Config
+	from      mail.Address
+}
+
+// NewSMTPServiceFromConfig returns a new instance of SMTPService based on the configuration.
+// The port defaults to the one expected by the security : 25 in plain text, 587 with STARTTLS and 465 with TLS.
+func NewSMTPServiceFromConfig() Service {
+	s := SMTPService{
+		host:     viper.GetString("SMTP_HOST"),
+		port:     viper.GetInt("SMTP_PORT"),
+		username: viper.GetString("SMTP_USERNAME"),
+		password: viper.GetString("SMTP_PASSWORD"),
+		security: viper.GetString("SMTP_SECURITY"),
+		timeout:  viper.GetDuration("SMTP_TIMEOUT"),
+		from:     senderFromConfig(),
+	}
+
+	if s.host == "" {
+		s.host = "localhost"
+	}
+	switch s.security {
+	case SMTPSecurityNone, SMTPSecurityStartTLS, SMTPSecurityTLS:
+	default:
+		if s.security != "" {
+			zap.L().Warn("Unknown SMTP security, using starttls", zap.String("security", s.security))
+		}
+		s.security = SMTPSecurityStartTLS
+	}
+	if s.port == 0 {
+		s.port = map[string]int{SMTPSecurityNone: 25, SMTPSecurityStartTLS: 587, SMTPSecurityTLS: 465}[s.security]
+	}
+	if s.timeout <= 0 {
+		s.timeout = 10 * time.Second
+	}
+	s.tlsConfig = &tls.Config{
+		ServerName: s.host,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	var service Service = &s
+	return service
+}
+
+// Send sends an email using the SMTP server
+func (s *SMTPService) Send(emailTo, subject, plainTextContent, htmlContent string) error {
+	return s.SendMessage(NewMessage(emailTo, subject, plainTextContent, htmlContent))
+}
+
+// SendMessage sends a message using the SMTP server
+func (s *SMTPService) SendMessage(message Message) error {
+	if err := message.Validate(); err != nil {
+		return err
+	}
+
+	data, err := buildMIME(s.from, message, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = s.deliver(message.Recipients(), data)
+	if err != nil {
+		zap.L().Error("SMTP email send", zap.Error(err))
+		return err
+	}
+
+	zap.L().Info("Email sent", zap.Strings("to", message.To), zap.String("subject", message.Subject))
+	return nil
+}
+
+// deliver runs the SMTP transaction sending the data to the recipients
+func (s *SMTPService) deliver(recipients []string, data []byte) error {
+	client, err := s.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if s.security == SMTPSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server %s does not support STARTTLS", s.host)
+		}
+		if err = client.StartTLS(s.tlsConfig); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+
+	if s.username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err = client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("smtp mail: %w", err)
+	}
+	for _, recipient := range recipients {
+		if err = client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("smtp rcpt %s: %w", recipient, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err = w.Write(data); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// dial connects to the SMTP server, over TLS with the implicit TLS security.
+// The whole transaction must complete within the timeout.
+func (s *SMTPService) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	dialer := &net.Dialer{Timeout: s.timeout}
+
+	var conn net.Conn
+	var err error
+	if s.security == SMTPSecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, s.tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("smtp dial %s: %w", addr, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(s.timeout))
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("smtp greeting: %w", err)
+	}
+	return client, nil
+}
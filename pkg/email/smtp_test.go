@@ -0,0 +1,269 @@
+package email
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testSMTPServer is a minimal SMTP server recording the transactions it receives
+type testSMTPServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	startTLS  bool
+
+	mu         sync.Mutex
+	auth       string
+	from       string
+	recipients []string
+	data       string
+	tls        bool
+}
+
+// newTLSConfig returns a server TLS configuration using a self-signed certificate generated at test time,
+// along with a client TLS configuration trusting it
+func newTLSConfig(t *testing.T) (*tls.Config, *tls.Config) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	server := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	client := &tls.Config{ServerName: "127.0.0.1", RootCAs: pool}
+	return server, client
+}
+
+// startTestSMTPServer starts a server, over TLS when implicitTLS is set, offering STARTTLS when startTLS is set
+func startTestSMTPServer(t *testing.T, tlsConfig *tls.Config, implicitTLS, startTLS bool) *testSMTPServer {
+	var listener net.Listener
+	var err error
+	if implicitTLS {
+		listener, err = tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	} else {
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	s := &testSMTPServer{listener: listener, tlsConfig: tlsConfig, startTLS: startTLS, tls: implicitTLS}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+// port returns the port the server listens on
+func (s *testSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// serve runs an SMTP session on the connection
+func (s *testSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP test")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0])
+
+		s.mu.Lock()
+		switch verb {
+		case "EHLO":
+			if s.startTLS && !s.tls {
+				reply("250-localhost")
+				reply("250-STARTTLS")
+			} else {
+				reply("250-localhost")
+			}
+			reply("250 AUTH PLAIN")
+		case "STARTTLS":
+			reply("220 Ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err = tlsConn.Handshake(); err != nil {
+				s.mu.Unlock()
+				return
+			}
+			conn = tlsConn
+			reader = bufio.NewReader(conn)
+			s.tls = true
+		case "AUTH":
+			s.auth = strings.TrimPrefix(command, "AUTH PLAIN ")
+			reply("235 Authentication succeeded")
+		case "MAIL":
+			s.from = command
+			reply("250 OK")
+		case "RCPT":
+			s.recipients = append(s.recipients, command)
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil || dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.data = data.String()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+		s.mu.Unlock()
+	}
+}
+
+// TestNewSMTPServiceFromConfig tests the NewSMTPServiceFromConfig function
+func TestNewSMTPServiceFromConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		viper.Reset()
+		service := NewSMTPServiceFromConfig().(*SMTPService)
+		assert.Equal(t, "localhost", service.host)
+		assert.Equal(t, 587, service.port)
+		assert.Equal(t, SMTPSecurityStartTLS, service.security)
+		assert.Equal(t, 10*time.Second, service.timeout)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		viper.Set("SMTP_HOST", "smtp.example.com")
+		viper.Set("SMTP_SECURITY", SMTPSecurityTLS)
+		viper.Set("SMTP_USERNAME", "user")
+		viper.Set("SMTP_PASSWORD", "secret")
+		viper.Set("SMTP_TIMEOUT", "5s")
+		viper.Set("EMAIL_SENDER_NAME", "Fihub")
+		viper.Set("EMAIL_SENDER_EMAIL", "sender@example.com")
+		defer viper.Reset()
+
+		service := NewSMTPServiceFromConfig().(*SMTPService)
+		assert.Equal(t, "smtp.example.com", service.host)
+		assert.Equal(t, 465, service.port)
+		assert.Equal(t, SMTPSecurityTLS, service.security)
+		assert.Equal(t, "user", service.username)
+		assert.Equal(t, "secret", service.password)
+		assert.Equal(t, 5*time.Second, service.timeout)
+		assert.Equal(t, "smtp.example.com", service.tlsConfig.ServerName)
+		assert.Equal(t, mail.Address{Name: "Fihub", Address: "sender@example.com"}, service.from)
+	})
+}
+
+// TestSMTPService_SendMessage tests the SendMessage method of SMTPService
+func TestSMTPService_SendMessage(t *testing.T) {
+	serverTLS, clientTLS := newTLSConfig(t)
+	message := NewMessage("to@example.com", "Test Subject", "Test Plain Text", "Test HTML Content")
+	message.Cc = []string{"cc@example.com"}
+
+	tests := []struct {
+		name        string
+		security    string
+		implicitTLS bool
+		startTLS    bool
+		username    string
+		expectErr   bool
+	}{
+		{name: "Plain text", security: SMTPSecurityNone},
+		{name: "Plain text with auth on a local server", security: SMTPSecurityNone, username: "user"},
+		{name: "STARTTLS with auth", security: SMTPSecurityStartTLS, startTLS: true, username: "user"},
+		{name: "STARTTLS not supported", security: SMTPSecurityStartTLS, expectErr: true},
+		{name: "Implicit TLS with auth", security: SMTPSecurityTLS, implicitTLS: true, username: "user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startTestSMTPServer(t, serverTLS, tt.implicitTLS, tt.startTLS)
+			service := &SMTPService{
+				host:      "127.0.0.1",
+				port:      server.port(),
+				username:  tt.username,
+				password:  "secret",
+				security:  tt.security,
+				timeout:   5 * time.Second,
+				tlsConfig: clientTLS,
+				from:      mail.Address{Name: "Fihub", Address: "sender@example.com"},
+			}
+
+			err := service.SendMessage(message)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			assert.Equal(t, tt.security != SMTPSecurityNone, server.tls)
+			assert.Equal(t, "MAIL FROM:<sender@example.com>", strings.SplitN(server.from, " BODY", 2)[0])
+			assert.Equal(t, []string{"RCPT TO:<to@example.com>", "RCPT TO:<cc@example.com>"}, server.recipients)
+			assert.Contains(t, server.data, "Subject: Test Subject\r\n")
+			assert.Contains(t, server.data, "Test HTML Content")
+			if tt.username != "" {
+				assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("\x00user\x00secret")), server.auth)
+			} else {
+				assert.Empty(t, server.auth)
+			}
+		})
+	}
+
+	t.Run("Invalid recipient", func(t *testing.T) {
+		service := &SMTPService{host: "127.0.0.1", port: 1, security: SMTPSecurityNone, timeout: time.Second}
+		err := service.Send("invalid", "Subject", "Plain", "")
+		assert.ErrorIs(t, err, ErrInvalidAddress)
+	})
+
+	t.Run("Server unreachable", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := listener.Addr().(*net.TCPAddr).Port
+		_ = listener.Close()
+
+		service := &SMTPService{host: "127.0.0.1", port: port, security: SMTPSecurityNone, timeout: time.Second}
+		err = service.Send("to@example.com", "Subject", "Plain", "")
+		assert.Error(t, err)
+	})
+}
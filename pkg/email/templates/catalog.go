@@ -0,0 +1,152 @@
+package templates
+
+import (
+	"fmt"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+	"slices"
+	"time"
+)
+
+// Email represents an email of the catalog rendered in a language
+type Email struct {
+	Subject          string
+	PlainTextContent string
+	HTMLContent      string
+}
+
+// Translate returns the message in the language being rendered
+type Translate func(message *translation.Message) string
+
+// Entry represents a template of the catalog, regardless of the type of its data
+type Entry interface {
+	Name() string
+	Keys() []string
+	Preview(localizer interface{}) (Email, error)
+	MissingKeys(localizer interface{}) []string
+}
+
+// Definition represents a template of the catalog along with its typed data.
+// It declares every translation key it references : rendering a key which is not declared fails.
+type Definition[T any] struct {
+	name   string
+	keys   []string
+	sample T
+	build  func(translate Translate, data T) (subject, plainTextContent string, htmlContent Template)
+}
+
+// layoutKeys are the translation keys shared by every template of the catalog
+var layoutKeys = []string{"EmailGreeting", "EmailFooterHelp", "EmailFooterCopyrights"}
+
+// Name returns the name of the template
+func (d *Definition[T]) Name() string {
+	return d.name
+}
+
+// Keys returns the sorted translation keys referenced by the template, including the layout ones
+func (d *Definition[T]) Keys() []string {
+	keys := append(slices.Clone(layoutKeys), d.keys...)
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// Render renders the subject, plain text and HTML contents of the template in the language of the localizer.
+// On error, the email is returned without its HTML content, so that it can still be sent as plain text.
+func (d *Definition[T]) Render(localizer interface{}, data T) (Email, error) {
+	// Translate the declared keys only
+	keys := d.Keys()
+	var undeclared []string
+	translate := func(message *translation.Message) string {
+		if _, found := slices.BinarySearch(keys, message.ID); !found {
+			undeclared = append(undeclared, message.ID)
+		}
+		return translation.S().Message(localizer, message)
+	}
+
+	// Prepare email contents and layout labels
+	subject, plainTextContent, htmlContentTemplate := d.build(translate, data)
+	labels := LayoutLabels{
+		Help: translate(&translation.Message{ID: "EmailFooterHelp"}),
+		Copyrights: translate(&translation.Message{
+			ID: "EmailFooterCopyrights",
+			Data: map[string]interface{}{
+				"Year": time.Now().Year(),
+			},
+		}),
+	}
+	email := Email{
+		Subject:          subject,
+		PlainTextContent: plainTextContent,
+	}
+	if len(undeclared) > 0 {
+		return email, fmt.Errorf("template %q references undeclared translation keys %v", d.name, undeclared)
+	}
+
+	// Render email html content
+	htmlContent, err := htmlContentTemplate.Build(labels)
+	if err != nil {
+		return email, err
+	}
+	email.HTMLContent = htmlContent
+
+	return email, nil
+}
+
+// Localize renders the template in the loaded language best matching the requested one, ready to be sent.
+// When the HTML content cannot be rendered, the plain text content is used instead.
+func (d *Definition[T]) Localize(lang language.Tag, data T) (Email, error) {
+	loc, err := translation.S().Localizer(lang)
+	if err != nil {
+		return Email{}, err
+	}
+
+	mail, err := d.Render(loc, data)
+	if err != nil {
+		zap.L().Error("Render email content", zap.String("template", d.name), zap.Error(err))
+		mail.HTMLContent = mail.PlainTextContent
+	}
+	return mail, nil
+}
+
+// Preview renders the template with its sample data in the language of the localizer
+func (d *Definition[T]) Preview(localizer interface{}) (Email, error) {
+	return d.Render(localizer, d.sample)
+}
+
+// MissingKeys returns the translation keys of the template which are not translated in the language of the localizer
+func (d *Definition[T]) MissingKeys(localizer interface{}) []string {
+	var missing []string
+	for _, key := range d.Keys() {
+		if translation.S().Message(localizer, &translation.Message{ID: key}) == "" {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// Catalog returns every template of the catalog, sorted by name
+func Catalog() []Entry {
+	return []Entry{
+		AccountLocked,
+		DeletionScheduled,
+		EmailChanged,
+		EmailVerification,
+		ExportReady,
+		NewLogin,
+		PasswordChanged,
+		PasswordReset,
+		WeeklySummary,
+		Welcome,
+	}
+}
+
+// Lookup returns the template of the catalog with the given name
+func Lookup(name string) (Entry, bool) {
+	for _, entry := range Catalog() {
+		if entry.Name() == name {
+			return entry, true
+		}
+	}
+	return nil, false
+}
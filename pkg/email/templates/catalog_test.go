@@ -0,0 +1,146 @@
+package templates
+
+import (
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"os"
+	"slices"
+	"testing"
+)
+
+// useTranslations replaces the global translation service with the translation files of the repository
+func useTranslations(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+
+	// Translation files are loaded relatively to the root of the repository
+	assert.NoError(t, os.Chdir("../../.."))
+	restore := translation.ReplaceGlobals(translation.NewI18nService(language.English))
+	t.Cleanup(func() {
+		restore()
+		assert.NoError(t, os.Chdir(wd))
+	})
+}
+
+// TestCatalog tests that the templates of the catalog are uniquely named and can be looked up
+func TestCatalog(t *testing.T) {
+	catalog := Catalog()
+
+	names := make([]string, 0, len(catalog))
+	for _, entry := range catalog {
+		names = append(names, entry.Name())
+	}
+	assert.True(t, slices.IsSorted(names))
+	assert.Len(t, slices.Compact(slices.Clone(names)), len(names))
+
+	for _, name := range names {
+		entry, found := Lookup(name)
+		assert.True(t, found)
+		assert.Equal(t, name, entry.Name())
+	}
+
+	_, found := Lookup("unknown")
+	assert.False(t, found)
+}
+
+// TestCatalogTranslations tests that every template of the catalog renders in every loaded language.
+// It fails whenever a template references a translation key missing from one of the translation files.
+func TestCatalogTranslations(t *testing.T) {
+	useTranslations(t)
+
+	for _, lang := range translation.S().Languages() {
+		loc, err := translation.S().Localizer(lang)
+		assert.NoError(t, err)
+
+		for _, entry := range Catalog() {
+			t.Run(lang.String()+"/"+entry.Name(), func(t *testing.T) {
+				assert.Empty(t, entry.MissingKeys(loc))
+
+				email, err := entry.Preview(loc)
+				assert.NoError(t, err)
+				assert.NotEmpty(t, email.Subject)
+				assert.NotEmpty(t, email.PlainTextContent)
+				assert.NotEmpty(t, email.HTMLContent)
+				assert.NotContains(t, email.PlainTextContent, "<no value>")
+				assert.NotContains(t, email.HTMLContent, "<no value>")
+			})
+		}
+	}
+}
+
+// TestDefinition_MissingKeys tests that the keys missing from the translation files are reported
+func TestDefinition_MissingKeys(t *testing.T) {
+	useTranslations(t)
+
+	definition := &Definition[NoticeData]{
+		name: "missing",
+		keys: []string{"EmailDoesNotExist", "EmailNewLoginTitle"},
+	}
+
+	for _, lang := range translation.S().Languages() {
+		loc, err := translation.S().Localizer(lang)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"EmailDoesNotExist"}, definition.MissingKeys(loc))
+	}
+}
+
+// TestDefinition_Render tests that rendering a key which is not declared by the template fails
+func TestDefinition_Render(t *testing.T) {
+	useTranslations(t)
+	loc, err := translation.S().Localizer(language.English)
+	assert.NoError(t, err)
+
+	t.Run("Undeclared key", func(t *testing.T) {
+		definition := &Definition[NoticeData]{
+			name: "undeclared",
+			keys: []string{"EmailNewLoginTitle"},
+			build: func(translate Translate, data NoticeData) (string, string, Template) {
+				return translate(&translation.Message{ID: "EmailNewLoginTitle"}), translate(&translation.Message{ID: "EmailNewLoginAdvice"}), NewNoticeTemplate(data)
+			},
+		}
+		_, err := definition.Render(loc, NoticeData{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Declared keys", func(t *testing.T) {
+		email, err := NewLogin.Render(loc, NewLogin.sample)
+		assert.NoError(t, err)
+		assert.Equal(t, "New login to your Fihub account", email.Subject)
+		assert.Contains(t, email.PlainTextContent, "203.0.113.42")
+		assert.Contains(t, email.HTMLContent, "Copyright ©")
+	})
+	t.Run("Plural forms", func(t *testing.T) {
+		french, err := translation.S().Localizer(language.CanadianFrench)
+		assert.NoError(t, err)
+
+		data := WeeklySummary.sample
+		data.Transactions = 0
+		email, err := WeeklySummary.Render(french, data)
+		assert.NoError(t, err)
+		assert.Contains(t, email.PlainTextContent, "0 transaction a été enregistrée")
+
+		email, err = WeeklySummary.Render(loc, data)
+		assert.NoError(t, err)
+		assert.Contains(t, email.PlainTextContent, "0 transactions were recorded")
+
+		data.Transactions = 1
+		email, err = WeeklySummary.Render(loc, data)
+		assert.NoError(t, err)
+		assert.Contains(t, email.PlainTextContent, "1 transaction was recorded")
+	})
+}
+
+// TestDefinition_Localize tests that the template is rendered in the loaded language best matching the requested one
+func TestDefinition_Localize(t *testing.T) {
+	useTranslations(t)
+
+	email, err := NewLogin.Localize(language.CanadianFrench, NewLogin.sample)
+	assert.NoError(t, err)
+	assert.Equal(t, "Nouvelle connexion à votre compte Fihub", email.Subject)
+	assert.NotEmpty(t, email.HTMLContent)
+
+	email, err = NewLogin.Localize(language.German, NewLogin.sample)
+	assert.NoError(t, err)
+	assert.Equal(t, "New login to your Fihub account", email.Subject)
+}
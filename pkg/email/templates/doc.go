@@ -27,5 +27,16 @@
 //	    // Handle error
 //	}
 //
+// The emails sent by the services are defined in the catalog. Each template of the catalog declares
+// its typed data and the translation keys it references, and renders the subject along with the
+// plain text and HTML contents in the language of a localizer:
+//
+//	email, err := templates.AccountLocked.Render(localizer, templates.AccountLockedData{
+//	    Duration: 15 * time.Minute,
+//	})
+//	if err != nil {
+//	    // Handle error, email.PlainTextContent can still be sent
+//	}
+//
 // For more information, see the documentation for the text/template and html/template packages.
 package templates
@@ -0,0 +1,331 @@
+package templates
+
+import (
+	"fmt"
+	"github.com/Zapharaos/fihub-backend/pkg/translation"
+	"math"
+	"time"
+)
+
+// WelcomeData contains the data for the welcome email
+type WelcomeData struct {
+	Link string // Link to the application
+}
+
+// Welcome welcomes the user once the account is created
+var Welcome = &Definition[WelcomeData]{
+	name: "welcome",
+	keys: []string{"EmailWelcomeAdvice", "EmailWelcomeContent", "EmailWelcomeLinkLabel", "EmailWelcomePlainTextContent", "EmailWelcomeTitle"},
+	sample: WelcomeData{
+		Link: "https://fihub.com",
+	},
+	build: func(translate Translate, data WelcomeData) (string, string, Template) {
+		return translate(&translation.Message{ID: "EmailWelcomeTitle"}),
+			translate(&translation.Message{
+				ID: "EmailWelcomePlainTextContent",
+				Data: map[string]interface{}{
+					"Link": data.Link,
+				},
+			}),
+			NewLinkTemplate(LinkData{
+				Greeting:    translate(&translation.Message{ID: "EmailGreeting"}),
+				MainContent: translate(&translation.Message{ID: "EmailWelcomeContent"}),
+				Link:        data.Link,
+				LinkLabel:   translate(&translation.Message{ID: "EmailWelcomeLinkLabel"}),
+				Secondary:   translate(&translation.Message{ID: "EmailWelcomeAdvice"}),
+			})
+	},
+}
+
+// EmailVerificationData contains the data for the email verification email
+type EmailVerificationData struct {
+	Otp      string        // Verification code
+	Duration time.Duration // Validity of the code
+}
+
+// EmailVerification sends the code verifying that an email address belongs to the user
+var EmailVerification = &Definition[EmailVerificationData]{
+	name: "email-verification",
+	keys: []string{"EmailOtpDoNotShare", "EmailVerificationContent", "EmailVerificationPlainTextContent", "EmailVerificationTitle"},
+	sample: EmailVerificationData{
+		Otp:      "123456",
+		Duration: 15 * time.Minute,
+	},
+	build: func(translate Translate, data EmailVerificationData) (string, string, Template) {
+		return translate(&translation.Message{ID: "EmailVerificationTitle"}),
+			translate(&translation.Message{
+				ID: "EmailVerificationPlainTextContent",
+				Data: map[string]interface{}{
+					"Otp":      data.Otp,
+					"Duration": minutes(data.Duration),
+				},
+			}),
+			NewOtpTemplate(OtpData{
+				OTP:      data.Otp,
+				Greeting: translate(&translation.Message{ID: "EmailGreeting"}),
+				MainContent: translate(&translation.Message{
+					ID: "EmailVerificationContent",
+					Data: map[string]interface{}{
+						"Duration": minutes(data.Duration),
+					},
+				}),
+				DoNotShare: translate(&translation.Message{ID: "EmailOtpDoNotShare"}),
+			})
+	},
+}
+
+// PasswordResetData contains the data for the password reset email
+type PasswordResetData struct {
+	Otp      string        // Reset code
+	Duration time.Duration // Validity of the code
+}
+
+// PasswordReset sends the code allowing the user to set a new password
+var PasswordReset = &Definition[PasswordResetData]{
+	name: "password-reset",
+	keys: []string{"EmailOtpContentForgotPassword", "EmailOtpDoNotShare", "EmailOtpPlainTextContent", "EmailOtpTitle"},
+	sample: PasswordResetData{
+		Otp:      "123456",
+		Duration: 15 * time.Minute,
+	},
+	build: func(translate Translate, data PasswordResetData) (string, string, Template) {
+		return translate(&translation.Message{ID: "EmailOtpTitle"}),
+			translate(&translation.Message{
+				ID: "EmailOtpPlainTextContent",
+				Data: map[string]interface{}{
+					"Otp": data.Otp,
+				},
+			}),
+			NewOtpTemplate(OtpData{
+				OTP:      data.Otp,
+				Greeting: translate(&translation.Message{ID: "EmailGreeting"}),
+				MainContent: translate(&translation.Message{
+					ID: "EmailOtpContentForgotPassword",
+					Data: map[string]interface{}{
+						"Duration": minutes(data.Duration),
+					},
+				}),
+				DoNotShare: translate(&translation.Message{ID: "EmailOtpDoNotShare"}),
+			})
+	},
+}
+
+// PasswordChangedData contains the data for the password changed email
+type PasswordChangedData struct {
+	Date time.Time // When the password was changed
+}
+
+// PasswordChanged notifies the user that the password of the account has been changed
+var PasswordChanged = &Definition[PasswordChangedData]{
+	name: "password-changed",
+	keys: []string{"EmailPasswordChangedAdvice", "EmailPasswordChangedContent", "EmailPasswordChangedPlainTextContent", "EmailPasswordChangedTitle"},
+	sample: PasswordChangedData{
+		Date: time.Date(2025, time.May, 12, 9, 30, 0, 0, time.UTC),
+	},
+	build: func(translate Translate, data PasswordChangedData) (string, string, Template) {
+		values := map[string]interface{}{
+			"Date": data.Date.UTC().Format(time.DateTime),
+		}
+		return translate(&translation.Message{ID: "EmailPasswordChangedTitle"}),
+			translate(&translation.Message{ID: "EmailPasswordChangedPlainTextContent", Data: values}),
+			NewNoticeTemplate(NoticeData{
+				Greeting:    translate(&translation.Message{ID: "EmailGreeting"}),
+				MainContent: translate(&translation.Message{ID: "EmailPasswordChangedContent", Data: values}),
+				Secondary:   translate(&translation.Message{ID: "EmailPasswordChangedAdvice"}),
+			})
+	},
+}
+
+// EmailChangedData contains the data for the email changed email
+type EmailChangedData struct {
+	Email string // New email address of the account
+}
+
+// EmailChanged notifies the previous email address that the account email has been changed
+var EmailChanged = &Definition[EmailChangedData]{
+	name: "email-changed",
+	keys: []string{"EmailChangedAdvice", "EmailChangedContent", "EmailChangedPlainTextContent", "EmailChangedTitle"},
+	sample: EmailChangedData{
+		Email: "jane.doe@example.com",
+	},
+	build: func(translate Translate, data EmailChangedData) (string, string, Template) {
+		values := map[string]interface{}{
+			"Email": data.Email,
+		}
+		return translate(&translation.Message{ID: "EmailChangedTitle"}),
+			translate(&translation.Message{ID: "EmailChangedPlainTextContent", Data: values}),
+			NewNoticeTemplate(NoticeData{
+				Greeting:    translate(&translation.Message{ID: "EmailGreeting"}),
+				MainContent: translate(&translation.Message{ID: "EmailChangedContent", Data: values}),
+				Secondary:   translate(&translation.Message{ID: "EmailChangedAdvice"}),
+			})
+	},
+}
+
+// NewLoginData contains the data for the new login email
+type NewLoginData struct {
+	Date      time.Time // When the login occurred
+	Device    string    // User agent of the device
+	IPAddress string    // IP address of the device
+}
+
+// NewLogin warns the user about a successful login from a new device
+var NewLogin = &Definition[NewLoginData]{
+	name: "new-login",
+	keys: []string{"EmailNewLoginAdvice", "EmailNewLoginContent", "EmailNewLoginPlainTextContent", "EmailNewLoginTitle"},
+	sample: NewLoginData{
+		Date:      time.Date(2025, time.May, 12, 9, 30, 0, 0, time.UTC),
+		Device:    "Mozilla/5.0 (X11; Linux x86_64) Firefox/138.0",
+		IPAddress: "203.0.113.42",
+	},
+	build: func(translate Translate, data NewLoginData) (string, string, Template) {
+		values := map[string]interface{}{
+			"Date":      data.Date.UTC().Format(time.DateTime),
+			"Device":    data.Device,
+			"IPAddress": data.IPAddress,
+		}
+		return translate(&translation.Message{ID: "EmailNewLoginTitle"}),
+			translate(&translation.Message{ID: "EmailNewLoginPlainTextContent", Data: values}),
+			NewNoticeTemplate(NoticeData{
+				Greeting:    translate(&translation.Message{ID: "EmailGreeting"}),
+				MainContent: translate(&translation.Message{ID: "EmailNewLoginContent", Data: values}),
+				Secondary:   translate(&translation.Message{ID: "EmailNewLoginAdvice"}),
+			})
+	},
+}
+
+// AccountLockedData contains the data for the account locked email
+type AccountLockedData struct {
+	Duration time.Duration // How long signing in is disabled
+}
+
+// AccountLocked notifies the user that the account has been locked after too many failed logins
+var AccountLocked = &Definition[AccountLockedData]{
+	name: "account-locked",
+	keys: []string{"EmailAccountLockedAdvice", "EmailAccountLockedContent", "EmailAccountLockedPlainTextContent", "EmailAccountLockedTitle"},
+	sample: AccountLockedData{
+		Duration: 15 * time.Minute,
+	},
+	build: func(translate Translate, data AccountLockedData) (string, string, Template) {
+		values := map[string]interface{}{
+			"Duration": minutes(data.Duration),
+		}
+		return translate(&translation.Message{ID: "EmailAccountLockedTitle"}),
+			translate(&translation.Message{ID: "EmailAccountLockedPlainTextContent", Data: values}),
+			NewNoticeTemplate(NoticeData{
+				Greeting:    translate(&translation.Message{ID: "EmailGreeting"}),
+				MainContent: translate(&translation.Message{ID: "EmailAccountLockedContent", Data: values}),
+				Secondary:   translate(&translation.Message{ID: "EmailAccountLockedAdvice"}),
+			})
+	},
+}
+
+// ExportReadyData contains the data for the export ready email
+type ExportReadyData struct {
+	Link     string        // Download link of the export
+	Duration time.Duration // Validity of the link
+}
+
+// ExportReady sends the download link of the data export to the user
+var ExportReady = &Definition[ExportReadyData]{
+	name: "export-ready",
+	keys: []string{"EmailExportReadyAdvice", "EmailExportReadyContent", "EmailExportReadyLinkLabel", "EmailExportReadyPlainTextContent", "EmailExportReadyTitle"},
+	sample: ExportReadyData{
+		Link:     "https://fihub.com/api/v1/export/sample-token",
+		Duration: 24 * time.Hour,
+	},
+	build: func(translate Translate, data ExportReadyData) (string, string, Template) {
+		return translate(&translation.Message{ID: "EmailExportReadyTitle"}),
+			translate(&translation.Message{
+				ID: "EmailExportReadyPlainTextContent",
+				Data: map[string]interface{}{
+					"Link":     data.Link,
+					"Duration": hours(data.Duration),
+				},
+			}),
+			NewLinkTemplate(LinkData{
+				Greeting: translate(&translation.Message{ID: "EmailGreeting"}),
+				MainContent: translate(&translation.Message{
+					ID: "EmailExportReadyContent",
+					Data: map[string]interface{}{
+						"Duration": hours(data.Duration),
+					},
+				}),
+				Link:      data.Link,
+				LinkLabel: translate(&translation.Message{ID: "EmailExportReadyLinkLabel"}),
+				Secondary: translate(&translation.Message{ID: "EmailExportReadyAdvice"}),
+			})
+	},
+}
+
+// DeletionScheduledData contains the data for the deletion scheduled email
+type DeletionScheduledData struct {
+	Date time.Time // When the account will be deleted
+}
+
+// DeletionScheduled confirms to the user that the account will be deleted, and how to cancel it
+var DeletionScheduled = &Definition[DeletionScheduledData]{
+	name: "deletion-scheduled",
+	keys: []string{"EmailDeletionScheduledAdvice", "EmailDeletionScheduledContent", "EmailDeletionScheduledPlainTextContent", "EmailDeletionScheduledTitle"},
+	sample: DeletionScheduledData{
+		Date: time.Date(2025, time.June, 11, 0, 0, 0, 0, time.UTC),
+	},
+	build: func(translate Translate, data DeletionScheduledData) (string, string, Template) {
+		values := map[string]interface{}{
+			"Date": data.Date.UTC().Format(time.DateOnly),
+		}
+		return translate(&translation.Message{ID: "EmailDeletionScheduledTitle"}),
+			translate(&translation.Message{ID: "EmailDeletionScheduledPlainTextContent", Data: values}),
+			NewNoticeTemplate(NoticeData{
+				Greeting:    translate(&translation.Message{ID: "EmailGreeting"}),
+				MainContent: translate(&translation.Message{ID: "EmailDeletionScheduledContent", Data: values}),
+				Secondary:   translate(&translation.Message{ID: "EmailDeletionScheduledAdvice"}),
+			})
+	},
+}
+
+// WeeklySummaryData contains the data for the weekly summary email
+type WeeklySummaryData struct {
+	From         time.Time // First day of the week
+	To           time.Time // Last day of the week
+	Transactions int       // Number of transactions recorded during the week
+	Link         string    // Link to the dashboard
+}
+
+// WeeklySummary sums up the activity of the account over the past week
+var WeeklySummary = &Definition[WeeklySummaryData]{
+	name: "weekly-summary",
+	keys: []string{"EmailWeeklySummaryAdvice", "EmailWeeklySummaryContent", "EmailWeeklySummaryLinkLabel", "EmailWeeklySummaryPlainTextContent", "EmailWeeklySummaryTitle"},
+	sample: WeeklySummaryData{
+		From:         time.Date(2025, time.May, 5, 0, 0, 0, 0, time.UTC),
+		To:           time.Date(2025, time.May, 11, 0, 0, 0, 0, time.UTC),
+		Transactions: 12,
+		Link:         "https://fihub.com",
+	},
+	build: func(translate Translate, data WeeklySummaryData) (string, string, Template) {
+		values := map[string]interface{}{
+			"From": data.From.UTC().Format(time.DateOnly),
+			"To":   data.To.UTC().Format(time.DateOnly),
+			"Link": data.Link,
+		}
+		return translate(&translation.Message{ID: "EmailWeeklySummaryTitle"}),
+			translate(&translation.Message{ID: "EmailWeeklySummaryPlainTextContent", Data: values, PluralCount: data.Transactions}),
+			NewLinkTemplate(LinkData{
+				Greeting:    translate(&translation.Message{ID: "EmailGreeting"}),
+				MainContent: translate(&translation.Message{ID: "EmailWeeklySummaryContent", Data: values, PluralCount: data.Transactions}),
+				Link:        data.Link,
+				LinkLabel:   translate(&translation.Message{ID: "EmailWeeklySummaryLinkLabel"}),
+				Secondary:   translate(&translation.Message{ID: "EmailWeeklySummaryAdvice"}),
+			})
+	},
+}
+
+// minutes returns the duration as a number of minutes, rounded up
+func minutes(d time.Duration) string {
+	return fmt.Sprintf("%d", int(math.Ceil(d.Minutes())))
+}
+
+// hours returns the duration as a number of hours, rounded up
+func hours(d time.Duration) string {
+	return fmt.Sprintf("%d", int(math.Ceil(d.Hours())))
+}
@@ -0,0 +1,35 @@
+package templates
+
+// LinkData contains the data for the link template
+type LinkData struct {
+	Greeting    string
+	MainContent string
+	Link        string
+	LinkLabel   string
+	Secondary   string
+}
+
+// NewLinkTemplate creates a new link template, used to send the user a link to follow
+func NewLinkTemplate(data LinkData) Template {
+	// Prepare link template
+	return Template{
+		Name:       "link",
+		ContentRaw: linkHtml,
+		Data:       data,
+	}
+}
+
+const linkHtml = `
+<h1>
+	{{.Greeting}}
+</h1>
+<p>
+	{{.MainContent}}
+</p>
+<a class="button" href="{{.Link}}">
+	{{.LinkLabel}}
+</a>
+<p class="secondary">
+	{{.Secondary}}
+</p>
+`
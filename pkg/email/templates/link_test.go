@@ -0,0 +1,23 @@
+package templates
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// TestNewLinkTemplate tests the NewLinkTemplate function
+func TestNewLinkTemplate(t *testing.T) {
+	data := LinkData{
+		Greeting:    "Hello",
+		MainContent: "Your export is ready",
+		Link:        "https://fihub.com/api/v1/export/token",
+		LinkLabel:   "Download",
+		Secondary:   "This link expires in 24 hours.",
+	}
+
+	template := NewLinkTemplate(data)
+
+	assert.Equal(t, "link", template.Name)
+	assert.Equal(t, linkHtml, template.ContentRaw)
+	assert.Equal(t, data, template.Data)
+}
@@ -0,0 +1,30 @@
+package templates
+
+// NoticeData contains the data for the notice template
+type NoticeData struct {
+	Greeting    string
+	MainContent string
+	Secondary   string
+}
+
+// NewNoticeTemplate creates a new notice template, used to inform the user about an event on their account
+func NewNoticeTemplate(data NoticeData) Template {
+	// Prepare notice template
+	return Template{
+		Name:       "notice",
+		ContentRaw: noticeHtml,
+		Data:       data,
+	}
+}
+
+const noticeHtml = `
+<h1>
+	{{.Greeting}}
+</h1>
+<p>
+	{{.MainContent}}
+</p>
+<p class="secondary">
+	{{.Secondary}}
+</p>
+`
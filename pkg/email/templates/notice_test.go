@@ -0,0 +1,21 @@
+package templates
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// TestNewNoticeTemplate tests the NewNoticeTemplate function
+func TestNewNoticeTemplate(t *testing.T) {
+	data := NoticeData{
+		Greeting:    "Hello",
+		MainContent: "Something happened on your account",
+		Secondary:   "Contact us if this was not you.",
+	}
+
+	template := NewNoticeTemplate(data)
+
+	assert.Equal(t, "notice", template.Name)
+	assert.Equal(t, noticeHtml, template.ContentRaw)
+	assert.Equal(t, data, template.Data)
+}
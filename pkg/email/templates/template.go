@@ -177,6 +177,16 @@ const layoutCss = `
         color: #8183f4;
         text-align: center;
       }
+      .content a.button {
+        display: inline-block;
+        margin-top: 2rem;
+        padding: 12px 32px;
+        border-radius: 30px;
+        background: #8183f4;
+        color: #ffffff;
+        font-weight: 600;
+        text-decoration: none;
+      }
       .help {
         max-width: 400px;
         margin: 0 auto;
@@ -0,0 +1,139 @@
+package hasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+const (
+	// Argon2idID is the PHC identifier of the Argon2id algorithm
+	Argon2idID = "argon2id"
+)
+
+var (
+	// ErrInvalidArgon2idHash is returned when an encoded hash does not follow the Argon2id PHC format
+	ErrInvalidArgon2idHash = errors.New("invalid argon2id hash")
+)
+
+// Argon2idHasher implements the Hasher interface using Argon2id.
+// Hashes are encoded in the PHC string format : $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+type Argon2idHasher struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// NewArgon2idHasherFromConfig returns a new instance of Argon2idHasher based on the configuration
+func NewArgon2idHasherFromConfig() *Argon2idHasher {
+	h := Argon2idHasher{
+		Memory:      viper.GetUint32("PASSWORD_ARGON2ID_MEMORY"),
+		Iterations:  viper.GetUint32("PASSWORD_ARGON2ID_ITERATIONS"),
+		Parallelism: uint8(viper.GetUint("PASSWORD_ARGON2ID_PARALLELISM")),
+		SaltLength:  viper.GetUint32("PASSWORD_ARGON2ID_SALT_LENGTH"),
+		KeyLength:   viper.GetUint32("PASSWORD_ARGON2ID_KEY_LENGTH"),
+	}
+
+	if h.Memory == 0 {
+		h.Memory = 64 * 1024
+	}
+	if h.Iterations == 0 {
+		h.Iterations = 3
+	}
+	if h.Parallelism == 0 {
+		h.Parallelism = 2
+	}
+	if h.SaltLength == 0 {
+		h.SaltLength = 16
+	}
+	if h.KeyLength == 0 {
+		h.KeyLength = 32
+	}
+
+	return &h
+}
+
+// Hash returns the PHC encoded Argon2id hash of the password, using a random salt
+func (h *Argon2idHasher) Hash(password []byte) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey(password, salt, h.Iterations, h.Memory, h.Parallelism, h.KeyLength)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		Argon2idID, argon2.Version, h.Memory, h.Iterations, h.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify checks whether the password matches the encoded hash, using the parameters of the hash
+func (h *Argon2idHasher) Verify(password []byte, encoded string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey(password, salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// Supports checks whether the encoded hash is an Argon2id hash
+func (h *Argon2idHasher) Supports(encoded string) bool {
+	return strings.HasPrefix(encoded, "$"+Argon2idID+"$")
+}
+
+// NeedsRehash checks whether the encoded hash was produced with other parameters than the configured ones
+func (h *Argon2idHasher) NeedsRehash(encoded string) bool {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return params.Memory != h.Memory ||
+		params.Iterations != h.Iterations ||
+		params.Parallelism != h.Parallelism ||
+		uint32(len(salt)) != h.SaltLength ||
+		uint32(len(key)) != h.KeyLength
+}
+
+// decodeArgon2id extracts the parameters, the salt and the key of a PHC encoded Argon2id hash
+func decodeArgon2id(encoded string) (Argon2idHasher, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != Argon2idID {
+		return Argon2idHasher{}, nil, nil, ErrInvalidArgon2idHash
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return Argon2idHasher{}, nil, nil, ErrInvalidArgon2idHash
+	}
+
+	var params Argon2idHasher
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return Argon2idHasher{}, nil, nil, ErrInvalidArgon2idHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2idHasher{}, nil, nil, ErrInvalidArgon2idHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Argon2idHasher{}, nil, nil, ErrInvalidArgon2idHash
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,117 @@
+package hasher
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// newTestArgon2idHasher returns a lightweight Argon2idHasher, fast enough for tests
+func newTestArgon2idHasher() *Argon2idHasher {
+	return &Argon2idHasher{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+}
+
+// TestNewArgon2idHasherFromConfig tests the NewArgon2idHasherFromConfig function
+func TestNewArgon2idHasherFromConfig(t *testing.T) {
+	// Defaults
+	h := NewArgon2idHasherFromConfig()
+	assert.Equal(t, &Argon2idHasher{Memory: 64 * 1024, Iterations: 3, Parallelism: 2, SaltLength: 16, KeyLength: 32}, h)
+
+	// Configured values
+	viper.Set("PASSWORD_ARGON2ID_MEMORY", 1024)
+	viper.Set("PASSWORD_ARGON2ID_ITERATIONS", 1)
+	viper.Set("PASSWORD_ARGON2ID_PARALLELISM", 1)
+	viper.Set("PASSWORD_ARGON2ID_SALT_LENGTH", 8)
+	viper.Set("PASSWORD_ARGON2ID_KEY_LENGTH", 16)
+	defer viper.Reset()
+
+	h = NewArgon2idHasherFromConfig()
+	assert.Equal(t, &Argon2idHasher{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 8, KeyLength: 16}, h)
+}
+
+// TestArgon2idHasher_Hash tests the Argon2idHasher.Hash method
+func TestArgon2idHasher_Hash(t *testing.T) {
+	h := newTestArgon2idHasher()
+
+	encoded, err := h.Hash([]byte("password"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$"))
+	assert.True(t, h.Supports(encoded))
+	assert.False(t, h.NeedsRehash(encoded))
+
+	// Salts are random
+	other, err := h.Hash([]byte("password"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, encoded, other)
+}
+
+// TestArgon2idHasher_Verify tests the Argon2idHasher.Verify method
+func TestArgon2idHasher_Verify(t *testing.T) {
+	h := newTestArgon2idHasher()
+	encoded, _ := h.Hash([]byte("password"))
+
+	tests := []struct {
+		name        string
+		password    string
+		encoded     string
+		expectMatch bool
+		expectErr   bool
+	}{
+		{
+			name:        "matches",
+			password:    "password",
+			encoded:     encoded,
+			expectMatch: true,
+		},
+		{
+			name:        "does not match",
+			password:    "wrong",
+			encoded:     encoded,
+			expectMatch: false,
+		},
+		{
+			name:      "fails with invalid format",
+			password:  "password",
+			encoded:   "$argon2id$v=19$invalid",
+			expectErr: true,
+		},
+		{
+			name:      "fails with unknown version",
+			password:  "password",
+			encoded:   strings.Replace(encoded, "v=19", "v=16", 1),
+			expectErr: true,
+		},
+		{
+			name:      "fails with invalid parameters",
+			password:  "password",
+			encoded:   strings.Replace(encoded, "m=1024", "m=x", 1),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := h.Verify([]byte(tt.password), tt.encoded)
+			assert.Equal(t, tt.expectErr, err != nil)
+			assert.Equal(t, tt.expectMatch, match)
+		})
+	}
+}
+
+// TestArgon2idHasher_NeedsRehash tests the Argon2idHasher.NeedsRehash method
+func TestArgon2idHasher_NeedsRehash(t *testing.T) {
+	h := newTestArgon2idHasher()
+	encoded, _ := h.Hash([]byte("password"))
+
+	// Same parameters
+	assert.False(t, h.NeedsRehash(encoded))
+
+	// Stronger parameters were configured since
+	stronger := newTestArgon2idHasher()
+	stronger.Iterations = 2
+	assert.True(t, stronger.NeedsRehash(encoded))
+
+	// Invalid hash
+	assert.True(t, h.NeedsRehash("invalid"))
+}
@@ -0,0 +1,66 @@
+package hasher
+
+import (
+	"errors"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+const (
+	// BcryptID is the identifier of the bcrypt algorithm
+	BcryptID = "bcrypt"
+)
+
+// BcryptHasher implements the Hasher interface using bcrypt.
+// Hashes are encoded in the modular crypt format used by bcrypt : $2a$<cost>$<salt and key>
+type BcryptHasher struct {
+	Cost int
+}
+
+// NewBcryptHasherFromConfig returns a new instance of BcryptHasher based on the configuration
+func NewBcryptHasherFromConfig() *BcryptHasher {
+	h := BcryptHasher{
+		Cost: viper.GetInt("PASSWORD_BCRYPT_COST"),
+	}
+
+	if h.Cost == 0 {
+		h.Cost = bcrypt.DefaultCost
+	}
+
+	return &h
+}
+
+// Hash returns the bcrypt hash of the password
+func (h *BcryptHasher) Hash(password []byte) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword(password, h.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify checks whether the password matches the bcrypt hash
+func (h *BcryptHasher) Verify(password []byte, encoded string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), password)
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Supports checks whether the encoded hash is a bcrypt hash
+func (h *BcryptHasher) Supports(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+// NeedsRehash checks whether the bcrypt hash was produced with another cost than the configured one
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != h.Cost
+}
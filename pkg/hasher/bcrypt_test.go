@@ -0,0 +1,43 @@
+package hasher
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+// TestNewBcryptHasherFromConfig tests the NewBcryptHasherFromConfig function
+func TestNewBcryptHasherFromConfig(t *testing.T) {
+	// Defaults
+	assert.Equal(t, bcrypt.DefaultCost, NewBcryptHasherFromConfig().Cost)
+
+	// Configured values
+	viper.Set("PASSWORD_BCRYPT_COST", 12)
+	defer viper.Reset()
+	assert.Equal(t, 12, NewBcryptHasherFromConfig().Cost)
+}
+
+// TestBcryptHasher tests the BcryptHasher methods
+func TestBcryptHasher(t *testing.T) {
+	h := &BcryptHasher{Cost: bcrypt.MinCost}
+
+	encoded, err := h.Hash([]byte("password"))
+	assert.NoError(t, err)
+	assert.True(t, h.Supports(encoded))
+	assert.False(t, h.Supports("$argon2id$v=19$m=1024,t=1,p=1$salt$key"))
+
+	// Verify
+	match, err := h.Verify([]byte("password"), encoded)
+	assert.NoError(t, err)
+	assert.True(t, match)
+	match, err = h.Verify([]byte("wrong"), encoded)
+	assert.NoError(t, err)
+	assert.False(t, match)
+	_, err = h.Verify([]byte("password"), "$2a$invalid")
+	assert.Error(t, err)
+
+	// Rehash
+	assert.False(t, h.NeedsRehash(encoded))
+	assert.True(t, (&BcryptHasher{Cost: bcrypt.MinCost + 1}).NeedsRehash(encoded))
+}
@@ -0,0 +1,30 @@
+// Package hasher provides functionality for hashing and verifying passwords.
+//
+// This package defines an interface for password hashing services, allowing for different implementations.
+// The default implementation encodes the hashes in the PHC string format using Argon2id, while still
+// verifying the hashes produced by the other supported algorithms (bcrypt), so that they can be upgraded
+// transparently. An optional server-side pepper can be mixed into the passwords before hashing.
+//
+// In your main.go or application initialization file, you can initialize the hasher service like this:
+//
+//	package main
+//
+//	import (
+//	    "github.com/Zapharaos/fihub-backend/pkg/hasher"
+//	)
+//
+//	func main() {
+//	    // Initialize the hasher service
+//	    hasherService := hasher.NewPasswordServiceFromConfig()
+//
+//	    // Replace the global hasher service instance
+//	    hasher.ReplaceGlobals(hasherService)
+//	}
+//
+// To hash and verify a password:
+//
+//	encoded, err := hasher.S().Hash("password")
+//	match, rehash, err := hasher.S().Verify("password", encoded)
+//
+// When rehash is true, the password should be hashed again and the stored hash replaced.
+package hasher
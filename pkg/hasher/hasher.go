@@ -0,0 +1,120 @@
+package hasher
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrUnsupportedHash is returned when no hasher can verify an encoded hash
+	ErrUnsupportedHash = errors.New("unsupported hash format")
+)
+
+// Hasher defines the interface of a password hashing algorithm
+type Hasher interface {
+	// Hash returns the encoded hash of the password
+	Hash(password []byte) (string, error)
+	// Verify checks whether the password matches the encoded hash
+	Verify(password []byte, encoded string) (bool, error)
+	// Supports checks whether the encoded hash was produced by the algorithm
+	Supports(encoded string) bool
+	// NeedsRehash checks whether the encoded hash was produced with outdated parameters
+	NeedsRehash(encoded string) bool
+}
+
+// PasswordService implements the Service interface.
+// New hashes are produced by the current hasher, while the legacy hashers are only used for verification.
+type PasswordService struct {
+	pepper  []byte
+	current Hasher
+	legacy  []Hasher
+}
+
+// NewPasswordService returns a new instance of PasswordService
+func NewPasswordService(pepper []byte, current Hasher, legacy ...Hasher) Service {
+	s := PasswordService{
+		pepper:  pepper,
+		current: current,
+		legacy:  legacy,
+	}
+	var service Service = &s
+	return service
+}
+
+// NewPasswordServiceFromConfig returns a new instance of PasswordService based on the configuration.
+// Both Argon2id and bcrypt hashes can be verified, PASSWORD_HASHER selects the one used for new hashes.
+func NewPasswordServiceFromConfig() Service {
+	argon2id := NewArgon2idHasherFromConfig()
+	bcrypt := NewBcryptHasherFromConfig()
+
+	var pepper []byte
+	if value := viper.GetString("PASSWORD_PEPPER"); value != "" {
+		pepper = []byte(value)
+	}
+
+	switch viper.GetString("PASSWORD_HASHER") {
+	case "", Argon2idID:
+		return NewPasswordService(pepper, argon2id, bcrypt)
+	case BcryptID:
+		return NewPasswordService(pepper, bcrypt, argon2id)
+	default:
+		zap.L().Warn("Unknown password hasher, using argon2id", zap.String("hasher", viper.GetString("PASSWORD_HASHER")))
+		return NewPasswordService(pepper, argon2id, bcrypt)
+	}
+}
+
+// Hash returns the encoded hash of the peppered password, using the current hasher
+func (s *PasswordService) Hash(password string) (string, error) {
+	return s.current.Hash(s.peppered(password))
+}
+
+// Verify checks whether the password matches the encoded hash.
+// Rehash indicates that the hash should be replaced, because it was produced by a legacy hasher,
+// with outdated parameters, or before the pepper was configured.
+func (s *PasswordService) Verify(password, encoded string) (bool, bool, error) {
+	h := s.hasherFor(encoded)
+	if h == nil {
+		return false, false, ErrUnsupportedHash
+	}
+	rehash := h != s.current || h.NeedsRehash(encoded)
+
+	match, err := h.Verify(s.peppered(password), encoded)
+	if err != nil || match {
+		return match, match && rehash, err
+	}
+
+	// Hashes produced before the pepper was configured
+	if s.pepper == nil {
+		return false, false, nil
+	}
+	match, err = h.Verify([]byte(password), encoded)
+	return match, match, err
+}
+
+// hasherFor returns the hasher which produced the encoded hash, nil if none of them did
+func (s *PasswordService) hasherFor(encoded string) Hasher {
+	if s.current.Supports(encoded) {
+		return s.current
+	}
+	for _, h := range s.legacy {
+		if h.Supports(encoded) {
+			return h
+		}
+	}
+	return nil
+}
+
+// peppered mixes the pepper into the password using HMAC-SHA256.
+// The result is base64 encoded to stay within the bcrypt input length limit.
+func (s *PasswordService) peppered(password string) []byte {
+	if s.pepper == nil {
+		return []byte(password)
+	}
+	mac := hmac.New(sha256.New, s.pepper)
+	mac.Write([]byte(password))
+	return []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,160 @@
+package hasher
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+// TestNewPasswordServiceFromConfig tests the NewPasswordServiceFromConfig function
+func TestNewPasswordServiceFromConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		hasher        string
+		pepper        string
+		expectCurrent string
+	}{
+		{name: "defaults to argon2id", hasher: "", expectCurrent: Argon2idID},
+		{name: "argon2id", hasher: "argon2id", expectCurrent: Argon2idID},
+		{name: "bcrypt", hasher: "bcrypt", expectCurrent: BcryptID},
+		{name: "unknown hasher falls back to argon2id", hasher: "md5", expectCurrent: Argon2idID},
+		{name: "with pepper", hasher: "argon2id", pepper: "pepper", expectCurrent: Argon2idID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("PASSWORD_HASHER", tt.hasher)
+			viper.Set("PASSWORD_PEPPER", tt.pepper)
+			defer viper.Reset()
+
+			s := NewPasswordServiceFromConfig().(*PasswordService)
+			switch tt.expectCurrent {
+			case Argon2idID:
+				assert.IsType(t, &Argon2idHasher{}, s.current)
+				assert.IsType(t, &BcryptHasher{}, s.legacy[0])
+			case BcryptID:
+				assert.IsType(t, &BcryptHasher{}, s.current)
+				assert.IsType(t, &Argon2idHasher{}, s.legacy[0])
+			}
+			if tt.pepper == "" {
+				assert.Nil(t, s.pepper)
+			} else {
+				assert.Equal(t, []byte(tt.pepper), s.pepper)
+			}
+		})
+	}
+}
+
+// TestPasswordService_Verify tests the PasswordService.Hash and PasswordService.Verify methods
+func TestPasswordService_Verify(t *testing.T) {
+	argon2id := newTestArgon2idHasher()
+	bcryptHasher := &BcryptHasher{Cost: bcrypt.MinCost}
+	service := NewPasswordService(nil, argon2id, bcryptHasher)
+	peppered := NewPasswordService([]byte("pepper"), argon2id, bcryptHasher)
+
+	// Existing hashes
+	current, _ := service.Hash("password")
+	currentPeppered, _ := peppered.Hash("password")
+	legacy, _ := bcryptHasher.Hash([]byte("password"))
+	outdated, _ := (&Argon2idHasher{Memory: 1024, Iterations: 2, Parallelism: 1, SaltLength: 16, KeyLength: 32}).Hash([]byte("password"))
+
+	tests := []struct {
+		name         string
+		service      Service
+		password     string
+		encoded      string
+		expectMatch  bool
+		expectRehash bool
+		expectErr    bool
+	}{
+		{
+			name:         "matches the current hash",
+			service:      service,
+			password:     "password",
+			encoded:      current,
+			expectMatch:  true,
+			expectRehash: false,
+		},
+		{
+			name:         "does not match the current hash",
+			service:      service,
+			password:     "wrong",
+			encoded:      current,
+			expectMatch:  false,
+			expectRehash: false,
+		},
+		{
+			name:         "matches a legacy hash and asks for a rehash",
+			service:      service,
+			password:     "password",
+			encoded:      legacy,
+			expectMatch:  true,
+			expectRehash: true,
+		},
+		{
+			name:         "does not match a legacy hash",
+			service:      service,
+			password:     "wrong",
+			encoded:      legacy,
+			expectMatch:  false,
+			expectRehash: false,
+		},
+		{
+			name:         "matches an outdated hash and asks for a rehash",
+			service:      service,
+			password:     "password",
+			encoded:      outdated,
+			expectMatch:  true,
+			expectRehash: true,
+		},
+		{
+			name:         "matches a peppered hash",
+			service:      peppered,
+			password:     "password",
+			encoded:      currentPeppered,
+			expectMatch:  true,
+			expectRehash: false,
+		},
+		{
+			name:         "peppered hash does not match without the pepper",
+			service:      service,
+			password:     "password",
+			encoded:      currentPeppered,
+			expectMatch:  false,
+			expectRehash: false,
+		},
+		{
+			name:         "matches a hash produced before the pepper and asks for a rehash",
+			service:      peppered,
+			password:     "password",
+			encoded:      legacy,
+			expectMatch:  true,
+			expectRehash: true,
+		},
+		{
+			name:         "does not match a hash produced before the pepper",
+			service:      peppered,
+			password:     "wrong",
+			encoded:      current,
+			expectMatch:  false,
+			expectRehash: false,
+		},
+		{
+			name:      "fails with an unsupported hash",
+			service:   service,
+			password:  "password",
+			encoded:   "plaintext",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, rehash, err := tt.service.Verify(tt.password, tt.encoded)
+			assert.Equal(t, tt.expectErr, err != nil)
+			assert.Equal(t, tt.expectMatch, match)
+			assert.Equal(t, tt.expectRehash, rehash)
+		})
+	}
+}
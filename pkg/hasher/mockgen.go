@@ -0,0 +1,3 @@
+package hasher
+
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=hasher -mock_names=Service=MockService Service
@@ -0,0 +1,35 @@
+package hasher
+
+import (
+	"sync"
+)
+
+// Service defines the interface for hashing passwords
+type Service interface {
+	Hash(password string) (string, error)
+	Verify(password, encoded string) (match bool, rehash bool, err error)
+}
+
+var (
+	_globalServiceMu sync.RWMutex
+	_globalService   Service
+)
+
+// S is used to access the global service singleton
+func S() Service {
+	_globalServiceMu.RLock()
+	defer _globalServiceMu.RUnlock()
+
+	service := _globalService
+	return service
+}
+
+// ReplaceGlobals affect a new service to the global service singleton
+func ReplaceGlobals(service Service) func() {
+	_globalServiceMu.Lock()
+	defer _globalServiceMu.Unlock()
+
+	prev := _globalService
+	_globalService = service
+	return func() { ReplaceGlobals(prev) }
+}
@@ -0,0 +1,83 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// breachedPrefixLength is the length of the hash prefix the breached hashes are indexed by
+const breachedPrefixLength = 5
+
+// BreachedList is a local list of breached password SHA-1 hashes, indexed by hash prefix
+type BreachedList struct {
+	hashes map[string]map[string]struct{}
+}
+
+// NewBreachedList returns a new empty BreachedList
+func NewBreachedList() *BreachedList {
+	return &BreachedList{
+		hashes: make(map[string]map[string]struct{}),
+	}
+}
+
+// LoadBreachedList loads the breached list from a file.
+// Each line holds an hex SHA-1 hash, optionally followed by ":<count>". Empty lines and lines starting with "#" are ignored.
+func LoadBreachedList(path string) (*BreachedList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	list := NewBreachedList()
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		hash, _, _ := strings.Cut(text, ":")
+		if err := list.AddHash(hash); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// AddHash adds an hex SHA-1 hash to the list
+func (l *BreachedList) AddHash(hash string) error {
+	hash = strings.ToUpper(strings.TrimSpace(hash))
+	if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha1.Size*2 {
+		return fmt.Errorf("invalid SHA-1 hash %q", hash)
+	}
+
+	prefix, suffix := hash[:breachedPrefixLength], hash[breachedPrefixLength:]
+	if l.hashes[prefix] == nil {
+		l.hashes[prefix] = make(map[string]struct{})
+	}
+	l.hashes[prefix][suffix] = struct{}{}
+	return nil
+}
+
+// Suffixes returns the suffixes of the breached hashes starting with the prefix
+func (l *BreachedList) Suffixes(prefix string) map[string]struct{} {
+	return l.hashes[strings.ToUpper(prefix)]
+}
+
+// Contains checks whether the password is in the list.
+// Only the hash prefix is used to look up the candidates, which are then compared to the hash suffix.
+func (l *BreachedList) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	_, found := l.Suffixes(hash[:breachedPrefixLength])[hash[breachedPrefixLength:]]
+	return found
+}
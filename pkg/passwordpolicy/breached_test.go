@@ -0,0 +1,68 @@
+package passwordpolicy
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// sha1Hex returns the uppercase hex SHA-1 hash of the password
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// TestLoadBreachedList tests the LoadBreachedList function
+func TestLoadBreachedList(t *testing.T) {
+	dir := t.TempDir()
+
+	// Valid file
+	valid := filepath.Join(dir, "valid.txt")
+	content := "# comment\n\n" + sha1Hex("password") + ":3861493\n" + strings.ToLower(sha1Hex("123456")) + "\n"
+	assert.NoError(t, os.WriteFile(valid, []byte(content), 0600))
+
+	list, err := LoadBreachedList(valid)
+	assert.NoError(t, err)
+	assert.True(t, list.Contains("password"))
+	assert.True(t, list.Contains("123456"))
+	assert.False(t, list.Contains("Password"))
+
+	// Invalid hash
+	invalid := filepath.Join(dir, "invalid.txt")
+	assert.NoError(t, os.WriteFile(invalid, []byte(sha1Hex("password")+"\nnot-a-hash\n"), 0600))
+
+	_, err = LoadBreachedList(invalid)
+	assert.ErrorContains(t, err, "line 2")
+
+	// Missing file
+	_, err = LoadBreachedList(filepath.Join(dir, "missing.txt"))
+	assert.Error(t, err)
+}
+
+// TestBreachedList_AddHash tests the BreachedList.AddHash method
+func TestBreachedList_AddHash(t *testing.T) {
+	list := NewBreachedList()
+	hash := sha1Hex("password")
+
+	assert.NoError(t, list.AddHash(hash))
+	assert.Error(t, list.AddHash("ZZ"+hash[2:]))
+	assert.Error(t, list.AddHash(hash[:10]))
+
+	// Hashes are indexed by prefix
+	suffixes := list.Suffixes(strings.ToLower(hash[:breachedPrefixLength]))
+	assert.Len(t, suffixes, 1)
+	assert.Contains(t, suffixes, hash[breachedPrefixLength:])
+	assert.Empty(t, list.Suffixes("00000"))
+}
+
+// TestBreachedList_LoadSampleFile tests that the sample breached list shipped with the configuration can be loaded
+func TestBreachedList_LoadSampleFile(t *testing.T) {
+	list, err := LoadBreachedList("../../config/passwords/breached-sha1.txt")
+	assert.NoError(t, err)
+	assert.True(t, list.Contains("P@ssw0rd"))
+	assert.False(t, list.Contains("Zk7#pQ9vLm2x"))
+}
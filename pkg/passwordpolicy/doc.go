@@ -0,0 +1,30 @@
+// Package passwordpolicy provides functionality for checking the strength of passwords.
+//
+// This package defines an interface for password policy services, allowing for different implementations.
+// The default implementation enforces a configurable policy : required character classes, a minimum
+// strength score estimated zxcvbn-style, the absence of similarity with the user's email address, and
+// the absence from a local list of breached password hashes.
+//
+// The breached list follows the "Have I Been Pwned" format : one uppercase hex SHA-1 hash per line,
+// optionally followed by ":<count>". Hashes are indexed by their 5 characters prefix, k-anonymity style.
+//
+// In your main.go or application initialization file, you can initialize the password policy service like this:
+//
+//	package main
+//
+//	import (
+//	    "github.com/Zapharaos/fihub-backend/pkg/passwordpolicy"
+//	)
+//
+//	func main() {
+//	    // Initialize the password policy service
+//	    policyService := passwordpolicy.NewPolicyServiceFromConfig()
+//
+//	    // Replace the global password policy service instance
+//	    passwordpolicy.ReplaceGlobals(policyService)
+//	}
+//
+// To check a password, along with the user specific inputs it must not be similar to:
+//
+//	err := passwordpolicy.S().Check("password", "user@example.com")
+package passwordpolicy
@@ -0,0 +1,3 @@
+package passwordpolicy
+
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=passwordpolicy -mock_names=Service=MockService Service
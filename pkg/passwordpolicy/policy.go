@@ -0,0 +1,167 @@
+package passwordpolicy
+
+import (
+	"errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrPasswordMissingLowercase = errors.New("password-missing-lowercase")
+	ErrPasswordMissingUppercase = errors.New("password-missing-uppercase")
+	ErrPasswordMissingDigit     = errors.New("password-missing-digit")
+	ErrPasswordMissingSymbol    = errors.New("password-missing-symbol")
+	ErrPasswordTooWeak          = errors.New("password-too-weak")
+	ErrPasswordSimilarEmail     = errors.New("password-similar-email")
+	ErrPasswordBreached         = errors.New("password-breached")
+)
+
+// Policy defines the rules a password must comply with
+type Policy struct {
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	MinScore         int // Between 0 (any password) and 4 (very unguessable passwords only)
+	CheckEmail       bool
+}
+
+// PolicyService implements the Service interface
+type PolicyService struct {
+	policy   Policy
+	breached *BreachedList
+}
+
+// NewPolicyService returns a new instance of PolicyService.
+// The breached list is optional, no breached password check is done without it.
+func NewPolicyService(policy Policy, breached *BreachedList) Service {
+	s := PolicyService{
+		policy:   policy,
+		breached: breached,
+	}
+	var service Service = &s
+	return service
+}
+
+// NewPolicyServiceFromConfig returns a new instance of PolicyService based on the configuration.
+// The breached password check is disabled if the breached list can not be loaded.
+func NewPolicyServiceFromConfig() Service {
+	policy := Policy{
+		RequireLowercase: viper.GetBool("PASSWORD_POLICY_REQUIRE_LOWERCASE"),
+		RequireUppercase: viper.GetBool("PASSWORD_POLICY_REQUIRE_UPPERCASE"),
+		RequireDigit:     viper.GetBool("PASSWORD_POLICY_REQUIRE_DIGIT"),
+		RequireSymbol:    viper.GetBool("PASSWORD_POLICY_REQUIRE_SYMBOL"),
+		MinScore:         viper.GetInt("PASSWORD_POLICY_MIN_SCORE"),
+		CheckEmail:       viper.GetBool("PASSWORD_POLICY_CHECK_EMAIL"),
+	}
+
+	if !viper.IsSet("PASSWORD_POLICY_MIN_SCORE") {
+		policy.MinScore = 3
+	}
+	if !viper.IsSet("PASSWORD_POLICY_CHECK_EMAIL") {
+		policy.CheckEmail = true
+	}
+
+	var breached *BreachedList
+	if path := viper.GetString("PASSWORD_POLICY_BREACHED_FILE"); path != "" {
+		list, err := LoadBreachedList(path)
+		if err != nil {
+			zap.L().Error("Load breached passwords, check disabled", zap.String("path", path), zap.Error(err))
+		} else {
+			breached = list
+		}
+	}
+
+	return NewPolicyService(policy, breached)
+}
+
+// Check checks whether the password complies with the policy.
+// The email is the one of the user the password belongs to, the password must not be similar to it.
+func (s *PolicyService) Check(password string, email string) error {
+	// Character classes
+	if err := s.checkClasses(password); err != nil {
+		return err
+	}
+
+	// Similarity with the email address
+	inputs := emailInputs(email)
+	if s.policy.CheckEmail && isSimilar(password, inputs) {
+		return ErrPasswordSimilarEmail
+	}
+
+	// Strength
+	if EstimateScore(password, inputs) < s.policy.MinScore {
+		return ErrPasswordTooWeak
+	}
+
+	// Breached passwords
+	if s.breached != nil && s.breached.Contains(password) {
+		return ErrPasswordBreached
+	}
+
+	return nil
+}
+
+// checkClasses checks whether the password contains the required character classes
+func (s *PolicyService) checkClasses(password string) error {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	switch {
+	case s.policy.RequireLowercase && !lower:
+		return ErrPasswordMissingLowercase
+	case s.policy.RequireUppercase && !upper:
+		return ErrPasswordMissingUppercase
+	case s.policy.RequireDigit && !digit:
+		return ErrPasswordMissingDigit
+	case s.policy.RequireSymbol && !symbol:
+		return ErrPasswordMissingSymbol
+	}
+	return nil
+}
+
+// emailInputs splits an email address into the words the password must not be built from
+func emailInputs(email string) []string {
+	email = strings.ToLower(email)
+	if email == "" {
+		return nil
+	}
+
+	inputs := []string{email}
+	local, domain, _ := strings.Cut(email, "@")
+	inputs = append(inputs, local)
+	inputs = append(inputs, strings.FieldsFunc(local, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})...)
+	if name, _, found := strings.Cut(domain, "."); found {
+		inputs = append(inputs, name)
+	}
+	return inputs
+}
+
+// isSimilar checks whether the password contains one of the inputs, or is contained by one of them
+func isSimilar(password string, inputs []string) bool {
+	password = strings.ToLower(password)
+	for _, input := range inputs {
+		if len(input) < minWordLength {
+			continue
+		}
+		if strings.Contains(password, input) || strings.Contains(input, password) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package passwordpolicy
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNewPolicyServiceFromConfig tests the NewPolicyServiceFromConfig function
+func TestNewPolicyServiceFromConfig(t *testing.T) {
+	// Defaults
+	s := NewPolicyServiceFromConfig().(*PolicyService)
+	assert.Equal(t, Policy{MinScore: 3, CheckEmail: true}, s.policy)
+	assert.Nil(t, s.breached)
+
+	// Configured values
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\n"), 0600))
+	viper.Set("PASSWORD_POLICY_REQUIRE_LOWERCASE", true)
+	viper.Set("PASSWORD_POLICY_REQUIRE_UPPERCASE", true)
+	viper.Set("PASSWORD_POLICY_REQUIRE_DIGIT", true)
+	viper.Set("PASSWORD_POLICY_REQUIRE_SYMBOL", true)
+	viper.Set("PASSWORD_POLICY_MIN_SCORE", 4)
+	viper.Set("PASSWORD_POLICY_CHECK_EMAIL", false)
+	viper.Set("PASSWORD_POLICY_BREACHED_FILE", path)
+	defer viper.Reset()
+
+	s = NewPolicyServiceFromConfig().(*PolicyService)
+	assert.Equal(t, Policy{
+		RequireLowercase: true,
+		RequireUppercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		MinScore:         4,
+		CheckEmail:       false,
+	}, s.policy)
+	assert.True(t, s.breached.Contains("password"))
+
+	// Breached file can not be loaded
+	viper.Set("PASSWORD_POLICY_BREACHED_FILE", filepath.Join(t.TempDir(), "missing.txt"))
+	s = NewPolicyServiceFromConfig().(*PolicyService)
+	assert.Nil(t, s.breached)
+}
+
+// TestPolicyService_Check tests the PolicyService.Check method
+func TestPolicyService_Check(t *testing.T) {
+	breached := NewBreachedList()
+	assert.NoError(t, breached.AddHash(sha1Hex("Zk7#pQ9vLm2x")))
+
+	strict := Policy{
+		RequireLowercase: true,
+		RequireUppercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		MinScore:         3,
+		CheckEmail:       true,
+	}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		password string
+		email    string
+		expected error
+	}{
+		{"missing lowercase", strict, "ZK7#PQ9VLM", "user@example.com", ErrPasswordMissingLowercase},
+		{"missing uppercase", strict, "zk7#pq9vlm", "user@example.com", ErrPasswordMissingUppercase},
+		{"missing digit", strict, "Zkq#pQwvLm", "user@example.com", ErrPasswordMissingDigit},
+		{"missing symbol", strict, "Zk7xpQ9vLm", "user@example.com", ErrPasswordMissingSymbol},
+		{"similar to the email", strict, "Johnny#2025x", "johnny.walker@example.com", ErrPasswordSimilarEmail},
+		{"similar to the email is allowed", Policy{}, "johnny", "johnny@example.com", nil},
+		{"too weak", strict, "P@ssw0rd1", "user@example.com", ErrPasswordTooWeak},
+		{"breached", strict, "Zk7#pQ9vLm2x", "user@example.com", ErrPasswordBreached},
+		{"valid", strict, "Zk7#pQ9vLm3y", "user@example.com", nil},
+		{"valid without requirements", Policy{}, "password", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPolicyService(tt.policy, breached)
+			assert.Equal(t, tt.expected, s.Check(tt.password, tt.email))
+		})
+	}
+}
+
+// TestEmailInputs tests the emailInputs function
+func TestEmailInputs(t *testing.T) {
+	assert.Nil(t, emailInputs(""))
+	assert.Equal(t,
+		[]string{"john.doe-42@example.com", "john.doe-42", "john", "doe", "example"},
+		emailInputs("John.Doe-42@Example.com"))
+}
+
+// TestIsSimilar tests the isSimilar function
+func TestIsSimilar(t *testing.T) {
+	inputs := emailInputs("john.doe@example.com")
+	assert.True(t, isSimilar("JohnDoe!2025", inputs))
+	assert.True(t, isSimilar("Example", inputs))
+	assert.False(t, isSimilar("Zk7#pQ9vLm2x", inputs))
+	assert.False(t, isSimilar("Zk7#pQ9vLm2x", nil))
+}
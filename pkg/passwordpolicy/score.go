@@ -0,0 +1,126 @@
+package passwordpolicy
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	// minWordLength is the minimum length of a dictionary or user input word to be matched
+	minWordLength = 3
+	// wordBits is the entropy of a matched word, as if guessed among the most common words
+	wordBits = 8
+	// patternBits is the entropy of a character continuing a repeat, a sequence or a keyboard pattern
+	patternBits = 1
+)
+
+// bruteforceBits is the entropy of any other character, zxcvbn's bruteforce cardinality of 10
+var bruteforceBits = math.Log2(10)
+
+// commonWords are common passwords and words, matched case-insensitively and after undoing leetspeak
+var commonWords = []string{
+	"password", "passwort", "motdepasse", "qwerty", "azerty", "letmein", "welcome", "admin", "login",
+	"dragon", "monkey", "master", "shadow", "sunshine", "princess", "football", "baseball", "soccer",
+	"superman", "batman", "iloveyou", "love", "trustno", "secret", "hello", "freedom", "whatever",
+	"starwars", "pokemon", "michael", "jordan", "jennifer", "hunter", "killer", "charlie", "summer",
+	"winter", "spring", "autumn", "flower", "cheese", "computer", "internet", "access", "changeme",
+	"default", "test", "user", "guest", "root", "abc", "fihub", "finance", "money", "bonjour", "soleil",
+}
+
+// leetSubstitutions maps common leetspeak characters to the letter they stand for
+var leetSubstitutions = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g', '@': 'a', '$': 's', '!': 'i',
+}
+
+// keyboardRows are the keyboard rows used to detect adjacent keys patterns
+var keyboardRows = []string{
+	"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./",
+	"azertyuiop^$", "qsdfghjklmù*", "wxcvbn,;:!",
+}
+
+// EstimateScore estimates the strength of the password, zxcvbn-style, between 0 (too guessable) and 4 (very unguessable).
+// The inputs are user specific words (such as the email address) that are considered as easily guessed.
+func EstimateScore(password string, inputs []string) int {
+	guesses := math.Log10(2) * estimateEntropy(password, inputs)
+	switch {
+	case guesses < 3:
+		return 0
+	case guesses < 6:
+		return 1
+	case guesses < 8:
+		return 2
+	case guesses < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// estimateEntropy estimates the entropy of the password in bits.
+// Words, repeats, sequences and keyboard patterns barely add entropy, other characters are bruteforced.
+func estimateEntropy(password string, inputs []string) float64 {
+	runes := []rune(strings.ToLower(password))
+	words := append(append([]string{}, commonWords...), inputs...)
+
+	var entropy float64
+	for i := 0; i < len(runes); {
+		// Dictionary or user input word
+		if n := matchWord(runes[i:], words); n > 0 {
+			entropy += wordBits
+			i += n
+			continue
+		}
+
+		// Pattern continuing the previous character
+		if i > 0 && isPattern(runes[i-1], runes[i]) {
+			entropy += patternBits
+			i++
+			continue
+		}
+
+		entropy += bruteforceBits
+		i++
+	}
+	return entropy
+}
+
+// matchWord returns the length of the longest word the runes start with, or 0
+func matchWord(runes []rune, words []string) int {
+	unleeted := make([]rune, len(runes))
+	for i, r := range runes {
+		if sub, ok := leetSubstitutions[r]; ok {
+			unleeted[i] = sub
+		} else {
+			unleeted[i] = r
+		}
+	}
+
+	longest := 0
+	for _, word := range words {
+		word = strings.ToLower(word)
+		length := len([]rune(word))
+		if length < minWordLength || length <= longest || length > len(runes) {
+			continue
+		}
+		if string(runes[:length]) == word || string(unleeted[:length]) == word {
+			longest = length
+		}
+	}
+	return longest
+}
+
+// isPattern checks whether the current character repeats, follows in sequence or is adjacent on keyboard to the previous one
+func isPattern(prev, cur rune) bool {
+	if prev == cur || cur-prev == 1 || prev-cur == 1 {
+		return true
+	}
+	for _, row := range keyboardRows {
+		keys := []rune(row)
+		for i := 0; i < len(keys)-1; i++ {
+			if (keys[i] == prev && keys[i+1] == cur) || (keys[i] == cur && keys[i+1] == prev) {
+				return true
+			}
+		}
+	}
+	return false
+}
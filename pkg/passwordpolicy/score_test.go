@@ -0,0 +1,52 @@
+package passwordpolicy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// TestEstimateScore tests the EstimateScore function
+func TestEstimateScore(t *testing.T) {
+	tests := []struct {
+		password string
+		inputs   []string
+		expected int
+	}{
+		{"", nil, 0},
+		{"password", nil, 0},
+		{"P@ssw0rd1", nil, 1},
+		{"qwerty123", nil, 1},
+		{"aaaaaaaaaaaa", nil, 1},
+		{"kq8zmw3p", nil, 3},
+		{"johndoe2024", nil, 4},
+		{"johndoe2024", emailInputs("john.doe@example.com"), 3},
+		{"kq8zmw3pvt7r", nil, 4},
+		{"correct horse battery staple", nil, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			assert.Equal(t, tt.expected, EstimateScore(tt.password, tt.inputs))
+		})
+	}
+}
+
+// TestMatchWord tests the matchWord function
+func TestMatchWord(t *testing.T) {
+	words := []string{"pass", "password", "ab"}
+	assert.Equal(t, 8, matchWord([]rune("password123"), words))
+	assert.Equal(t, 8, matchWord([]rune("p@55w0rd"), words))
+	assert.Equal(t, 4, matchWord([]rune("passw"), words))
+	assert.Equal(t, 0, matchWord([]rune("abc"), words))
+}
+
+// TestIsPattern tests the isPattern function
+func TestIsPattern(t *testing.T) {
+	assert.True(t, isPattern('a', 'a'))
+	assert.True(t, isPattern('a', 'b'))
+	assert.True(t, isPattern('3', '2'))
+	assert.True(t, isPattern('q', 'w'))
+	assert.True(t, isPattern('l', 'k'))
+	assert.False(t, isPattern('a', 'm'))
+	assert.False(t, isPattern('1', '9'))
+}
@@ -0,0 +1,134 @@
+package translation
+
+import (
+	"github.com/BurntSushi/toml"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"golang.org/x/text/language"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// KeysReport lists the translation keys of a language which are missing or unused
+type KeysReport struct {
+	Language language.Tag
+	Missing  []string // keys referenced by the sources but not translated in the language
+	Unused   []string // keys translated in the language but not referenced by the sources
+}
+
+// IsValid returns true if the language has neither missing nor unused keys
+func (r KeysReport) IsValid() bool {
+	return len(r.Missing) == 0 && len(r.Unused) == 0
+}
+
+// CheckKeys compares the keys of every translation file of dir with the keys referenced by the Go sources of root.
+// A key is referenced when a string literal of a source file, tests and generated code aside, is equal to it.
+// Reports are sorted by language.
+func CheckKeys(dir, root string) ([]KeysReport, error) {
+	// Load the keys of every language
+	files, err := filepath.Glob(filepath.Join(dir, "active.*.toml"))
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[language.Tag][]string, len(files))
+	var all []string
+	for _, file := range files {
+		buf, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		messageFile, err := i18n.ParseMessageFileBytes(buf, file, map[string]i18n.UnmarshalFunc{"toml": toml.Unmarshal})
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range messageFile.Messages {
+			keys[messageFile.Tag] = append(keys[messageFile.Tag], message.ID)
+		}
+		all = append(all, keys[messageFile.Tag]...)
+	}
+
+	// Keep the keys referenced by the sources
+	literals, err := stringLiterals(root)
+	if err != nil {
+		return nil, err
+	}
+	var referenced []string
+	for _, key := range all {
+		if _, found := literals[key]; found {
+			referenced = append(referenced, key)
+		}
+	}
+	slices.Sort(referenced)
+	referenced = slices.Compact(referenced)
+
+	// Compare the keys of each language with the referenced ones
+	reports := make([]KeysReport, 0, len(keys))
+	for lang, langKeys := range keys {
+		report := KeysReport{Language: lang}
+		for _, key := range referenced {
+			if !slices.Contains(langKeys, key) {
+				report.Missing = append(report.Missing, key)
+			}
+		}
+		for _, key := range langKeys {
+			if _, found := slices.BinarySearch(referenced, key); !found {
+				report.Unused = append(report.Unused, key)
+			}
+		}
+		slices.Sort(report.Unused)
+		reports = append(reports, report)
+	}
+	slices.SortFunc(reports, func(a, b KeysReport) int {
+		return strings.Compare(a.Language.String(), b.Language.String())
+	})
+
+	return reports, nil
+}
+
+// stringLiterals returns the string literals of the Go sources of root, tests and generated code aside
+func stringLiterals(root string) (map[string]struct{}, error) {
+	literals := make(map[string]struct{})
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip hidden, tooling, vendored and generated directories
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "gen") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			literal, ok := node.(*ast.BasicLit)
+			if !ok || literal.Kind != token.STRING {
+				return true
+			}
+			if value, err := strconv.Unquote(literal.Value); err == nil {
+				literals[value] = struct{}{}
+			}
+			return true
+		})
+		return nil
+	})
+
+	return literals, err
+}
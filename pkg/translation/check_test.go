@@ -0,0 +1,55 @@
+package translation
+
+import (
+	"github.com/Zapharaos/fihub-backend/test"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"os"
+	"testing"
+)
+
+// TestCheckKeys tests the report of the missing and unused keys of each language.
+func TestCheckKeys(t *testing.T) {
+	// Setup test suite with translation files and sources
+	ts := test.TestSuite{}
+	_ = ts.CreateConfigTranslationsFullTestSuite(t)
+	defer ts.CleanTestSuite(t)
+	writeTranslationFile(t, "active.fr.toml", `
+		[stale]
+		other = "Périmé"
+	`)
+	writeTranslationFile(t, "active.de.toml", `
+		hello = "Hallo, {{.name}}!"
+	`)
+	assert.NoError(t, os.WriteFile("main.go", []byte(`package main
+
+func main() {
+	println("hello")
+}
+`), 0644))
+
+	// Keys referenced by tests or generated code are not used
+	assert.NoError(t, os.WriteFile("main_test.go", []byte("package main\n\nvar _ = \"stale\"\n"), 0644))
+	assert.NoError(t, os.MkdirAll("gen", os.ModePerm))
+	assert.NoError(t, os.WriteFile("gen/gen.go", []byte("package gen\n\nvar _ = \"stale\"\n"), 0644))
+
+	reports, err := CheckKeys(TranslationsDir, ".")
+	assert.NoError(t, err)
+	assert.Equal(t, []KeysReport{
+		{Language: language.German},
+		{Language: language.English},
+		{Language: language.French, Missing: []string{"hello"}, Unused: []string{"stale"}},
+	}, reports)
+	assert.True(t, reports[0].IsValid())
+	assert.False(t, reports[2].IsValid())
+}
+
+// TestCheckKeys_Repository tests that the translation files of the repository have neither missing nor unused keys.
+func TestCheckKeys_Repository(t *testing.T) {
+	reports, err := CheckKeys("../../"+TranslationsDir, "../..")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, reports)
+	for _, report := range reports {
+		assert.True(t, report.IsValid(), "%s: missing %v, unused %v", report.Language, report.Missing, report.Unused)
+	}
+}
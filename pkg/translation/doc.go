@@ -21,10 +21,24 @@
 //	    translation.ReplaceGlobals(translationService)
 //	}
 //
+// Every "active.<language>.toml" file of config/translations is loaded. The default language must have one.
+//
 // To get a localized message:
 //
 //	localizer, _ := translation.S().Localizer(language.French)
 //	message := translation.S().Message(localizer, &translation.Message{ID: "HelloWorld"})
 //
+// A language which is not loaded falls back to its parents, then to the default language:
+// the localizer of language.CanadianFrench is the French one when only "active.fr.toml" exists.
+// Match negotiates the loaded language from several preferences, such as the ones of an Accept-Language header:
+//
+//	tags, _, _ := language.ParseAcceptLanguage("fr-CA, en;q=0.8")
+//	lang := translation.S().Match(tags...)
+//
+// With a PluralCount, the plural form (zero, one, two, few, many, other) is selected according to the CLDR rules
+// of the language, and the count is available to the message as {{.PluralCount}}:
+//
+//	message := translation.S().Message(localizer, &translation.Message{ID: "Transactions", PluralCount: 2})
+//
 // For more information, see the documentation for the go-i18n library.
 package translation
@@ -6,15 +6,24 @@ import (
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"go.uber.org/zap"
 	"golang.org/x/text/language"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
 )
 
+// TranslationsDir is the directory holding the translation files, one "active.<language>.toml" file per language
+const TranslationsDir = "config/translations"
+
 // I18nService implements the Service interface using i18n
 type I18nService struct {
 	bundle     *i18n.Bundle
+	languages  []language.Tag
 	localizers map[language.Tag]*i18n.Localizer
 }
 
-// NewI18nService returns a new instance of I18nService
+// NewI18nService returns a new instance of I18nService, loading every translation file of TranslationsDir.
+// It panics if no translation file is found for the default language.
 func NewI18nService(defaultLang language.Tag) Service {
 
 	// Create a new bundle
@@ -22,34 +31,78 @@ func NewI18nService(defaultLang language.Tag) Service {
 	bundle.RegisterUnmarshalFunc("toml", toml.Unmarshal)
 
 	// Load the translations
-	bundle.MustLoadMessageFile("config/translations/active.en.toml")
-	bundle.MustLoadMessageFile("config/translations/active.fr.toml")
+	files, err := filepath.Glob(filepath.Join(TranslationsDir, "active.*.toml"))
+	if err != nil {
+		panic(err)
+	}
+	var languages []language.Tag
+	for _, file := range files {
+		messageFile, err := bundle.LoadMessageFile(file)
+		if err != nil {
+			panic(err)
+		}
+		languages = append(languages, messageFile.Tag)
+	}
+	if !slices.Contains(languages, defaultLang) {
+		panic(fmt.Errorf("translation file for the default language %q not found in %s", defaultLang, TranslationsDir))
+	}
+
+	// Keep track of the languages, starting with the default one
+	languages = slices.DeleteFunc(languages, func(lang language.Tag) bool {
+		return lang == defaultLang
+	})
+	slices.SortFunc(languages, func(a, b language.Tag) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	languages = append([]language.Tag{defaultLang}, slices.Compact(languages)...)
 
 	// Create localizers for each language
-	localizers := map[language.Tag]*i18n.Localizer{
-		defaultLang:     i18n.NewLocalizer(bundle, defaultLang.String()),
-		language.French: i18n.NewLocalizer(bundle, language.French.String()),
+	localizers := make(map[language.Tag]*i18n.Localizer, len(languages))
+	for _, lang := range languages {
+		localizers[lang] = i18n.NewLocalizer(bundle, lang.String())
 	}
 
 	// Create the service
 	s := I18nService{
 		bundle:     bundle,
+		languages:  languages,
 		localizers: localizers,
 	}
 	var service Service = &s
 	return service
 }
 
-// Localizer returns the requested localizer and an error if any
+// Localizer returns the localizer of the loaded language best matching the requested one, and an error if any
 func (t *I18nService) Localizer(language language.Tag) (interface{}, error) {
-	localizer, found := t.localizers[language]
+	matched := t.Match(language)
+	localizer, found := t.localizers[matched]
 	if !found {
-		return nil, fmt.Errorf("localizer %q not found", language)
+		return nil, fmt.Errorf("localizer %q not found", matched)
 	}
 	return localizer, nil
 }
 
-// Message returns a localized message for the given localizer and message
+// Match returns the loaded language best matching the requested ones, given in order of preference.
+// Each requested language falls back to its parents (e.g. fr-CA to fr), and ultimately to the default language.
+func (t *I18nService) Match(languages ...language.Tag) language.Tag {
+	for _, requested := range languages {
+		for lang := requested; lang != language.Und; lang = lang.Parent() {
+			if _, found := t.localizers[lang]; found {
+				return lang
+			}
+		}
+	}
+	return t.languages[0]
+}
+
+// Languages returns the loaded languages, starting with the default one
+func (t *I18nService) Languages() []language.Tag {
+	return append([]language.Tag(nil), t.languages...)
+}
+
+// Message returns a localized message for the given localizer and message.
+// With a PluralCount, the plural form of the language is selected following the CLDR rules,
+// and the count is available to the message as {{.PluralCount}}.
 func (t *I18nService) Message(localizer interface{}, message *Message) string {
 	// Verify that the localizer is of the correct type
 	loc, ok := localizer.(*i18n.Localizer)
@@ -64,6 +117,15 @@ func (t *I18nService) Message(localizer interface{}, message *Message) string {
 		PluralCount:  message.PluralCount,
 	}
 
+	// Expose the count along with the data
+	if data, ok := message.Data.(map[string]interface{}); ok && message.PluralCount != nil {
+		if _, found := data["PluralCount"]; !found {
+			withCount := maps.Clone(data)
+			withCount["PluralCount"] = message.PluralCount
+			localizeConfig.TemplateData = withCount
+		}
+	}
+
 	// Localize the message
 	result, err := loc.Localize(localizeConfig)
 	if err != nil {
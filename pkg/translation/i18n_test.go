@@ -4,6 +4,8 @@ import (
 	"github.com/Zapharaos/fihub-backend/test"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -28,6 +30,36 @@ func TestNewI18nService(t *testing.T) {
 		service := NewI18nService(defaultLang)
 		assert.NotNil(t, service)
 	})
+
+	t.Run("Without the default language translation file", func(t *testing.T) {
+		// Setup test suite with translation files
+		ts := test.TestSuite{}
+		_ = ts.CreateConfigTranslationsFullTestSuite(t)
+		defer ts.CleanTestSuite(t)
+
+		// Expect a panic when the default language has no translation file
+		assert.Panics(t, func() {
+			NewI18nService(language.German)
+		})
+	})
+
+	t.Run("Discovers the translation files", func(t *testing.T) {
+		// Setup test suite with translation files
+		ts := test.TestSuite{}
+		_ = ts.CreateConfigTranslationsFullTestSuite(t)
+		defer ts.CleanTestSuite(t)
+		writeTranslationFile(t, "active.de.toml", `hello = "Hallo, {{.name}}!"`)
+
+		// Expect the additional language to be loaded
+		service := NewI18nService(defaultLang)
+		assert.Equal(t, []language.Tag{defaultLang, language.German, language.French}, service.Languages())
+	})
+}
+
+// writeTranslationFile writes a translation file within the translations directory of the test suite
+func writeTranslationFile(t *testing.T, name, content string) {
+	err := os.WriteFile(filepath.Join(TranslationsDir, name), []byte(content), 0644)
+	assert.NoError(t, err)
 }
 
 // TestI18nService_Localizer tests the retrieval of localizers from I18nService.
@@ -48,9 +80,67 @@ func TestI18nService_Localizer(t *testing.T) {
 	})
 
 	t.Run("Retrieve non-existing language localizer", func(t *testing.T) {
-		// Expect an error when trying to retrieve a localizer for a non-existing language
-		_, err := service.Localizer(language.Spanish)
-		assert.Error(t, err)
+		// Expect the default language localizer for a non-existing language
+		localizer, err := service.Localizer(language.Spanish)
+		assert.NoError(t, err)
+		defaultLocalizer, _ := service.Localizer(defaultLang)
+		assert.Same(t, defaultLocalizer, localizer)
+	})
+
+	t.Run("Retrieve regional language localizer", func(t *testing.T) {
+		// Expect the localizer of the parent language for a regional variant
+		localizer, err := service.Localizer(language.CanadianFrench)
+		assert.NoError(t, err)
+		frenchLocalizer, _ := service.Localizer(language.French)
+		assert.Same(t, frenchLocalizer, localizer)
+	})
+}
+
+// TestI18nService_Match tests the negotiation of the languages by I18nService.
+func TestI18nService_Match(t *testing.T) {
+	// Setup test suite with translation files
+	ts := test.TestSuite{}
+	_ = ts.CreateConfigTranslationsFullTestSuite(t)
+	defer ts.CleanTestSuite(t)
+
+	// Create a new I18nService instance
+	service := NewI18nService(defaultLang)
+
+	tests := []struct {
+		name      string
+		languages []language.Tag
+		expected  language.Tag
+	}{
+		{name: "No language", expected: defaultLang},
+		{name: "Loaded language", languages: []language.Tag{language.French}, expected: language.French},
+		{name: "Regional language", languages: []language.Tag{language.CanadianFrench}, expected: language.French},
+		{name: "Nested regional language", languages: []language.Tag{language.BritishEnglish}, expected: language.English},
+		{name: "Language not loaded", languages: []language.Tag{language.German}, expected: defaultLang},
+		{name: "First loaded preference", languages: []language.Tag{language.German, language.CanadianFrench, language.English}, expected: language.French},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, service.Match(tt.languages...))
+		})
+	}
+}
+
+// TestI18nService_Languages tests the retrieval of the languages from I18nService.
+func TestI18nService_Languages(t *testing.T) {
+	// Setup test suite with translation files
+	ts := test.TestSuite{}
+	_ = ts.CreateConfigTranslationsFullTestSuite(t)
+	defer ts.CleanTestSuite(t)
+
+	t.Run("Default language first", func(t *testing.T) {
+		service := NewI18nService(defaultLang)
+		assert.Equal(t, []language.Tag{defaultLang, language.French}, service.Languages())
+	})
+
+	t.Run("French as default language", func(t *testing.T) {
+		service := NewI18nService(language.French)
+		assert.Equal(t, []language.Tag{language.French, defaultLang}, service.Languages())
 	})
 }
 
@@ -88,3 +178,54 @@ func TestI18nService_Message(t *testing.T) {
 		assert.Equal(t, "", result)
 	})
 }
+
+// TestI18nService_MessagePlural tests the selection of the plural forms following the CLDR rules.
+func TestI18nService_MessagePlural(t *testing.T) {
+	// Setup test suite with plural translations
+	ts := test.TestSuite{}
+	_ = ts.CreateConfigTranslationsFullTestSuite(t)
+	defer ts.CleanTestSuite(t)
+	writeTranslationFile(t, "active.fr.toml", `
+		[apples]
+		one = "{{.PluralCount}} pomme pour {{.name}}"
+		many = "{{.PluralCount}} de pommes pour {{.name}}"
+		other = "{{.PluralCount}} pommes pour {{.name}}"
+	`)
+	writeTranslationFile(t, "active.pl.toml", `
+		[apples]
+		one = "{{.PluralCount}} jabłko"
+		few = "{{.PluralCount}} jabłka"
+		many = "{{.PluralCount}} jabłek"
+		other = "{{.PluralCount}} jabłka"
+	`)
+
+	service := NewI18nService(defaultLang)
+	french, _ := service.Localizer(language.French)
+	polish, _ := service.Localizer(language.Polish)
+
+	tests := []struct {
+		name      string
+		localizer interface{}
+		count     int
+		expected  string
+	}{
+		{name: "French zero is singular", localizer: french, count: 0, expected: "0 pomme pour Jane"},
+		{name: "French one", localizer: french, count: 1, expected: "1 pomme pour Jane"},
+		{name: "French other", localizer: french, count: 2, expected: "2 pommes pour Jane"},
+		{name: "French many", localizer: french, count: 1000000, expected: "1000000 de pommes pour Jane"},
+		{name: "Polish one", localizer: polish, count: 1, expected: "1 jabłko"},
+		{name: "Polish few", localizer: polish, count: 3, expected: "3 jabłka"},
+		{name: "Polish many", localizer: polish, count: 5, expected: "5 jabłek"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := service.Message(tt.localizer, &Message{
+				ID:          "apples",
+				Data:        map[string]interface{}{"name": "Jane"},
+				PluralCount: tt.count,
+			})
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 type Service interface {
 	Localizer(language language.Tag) (interface{}, error)
 	Message(localizer interface{}, message *Message) string
+	Languages() []language.Tag
+	Match(languages ...language.Tag) language.Tag
 }
 
 // Message represents a message to be translated
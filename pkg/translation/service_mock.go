@@ -40,6 +40,20 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Languages mocks base method.
+func (m *MockService) Languages() []language.Tag {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Languages")
+	ret0, _ := ret[0].([]language.Tag)
+	return ret0
+}
+
+// Languages indicates an expected call of Languages.
+func (mr *MockServiceMockRecorder) Languages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Languages", reflect.TypeOf((*MockService)(nil).Languages))
+}
+
 // Localizer mocks base method.
 func (m *MockService) Localizer(language language.Tag) (any, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +69,24 @@ func (mr *MockServiceMockRecorder) Localizer(language any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Localizer", reflect.TypeOf((*MockService)(nil).Localizer), language)
 }
 
+// Match mocks base method.
+func (m *MockService) Match(languages ...language.Tag) language.Tag {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range languages {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Match", varargs...)
+	ret0, _ := ret[0].(language.Tag)
+	return ret0
+}
+
+// Match indicates an expected call of Match.
+func (mr *MockServiceMockRecorder) Match(languages ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Match", reflect.TypeOf((*MockService)(nil).Match), languages...)
+}
+
 // Message mocks base method.
 func (m *MockService) Message(localizer any, message *Message) string {
 	m.ctrl.T.Helper()
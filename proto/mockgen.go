@@ -5,5 +5,6 @@ package proto
 //go:generate mockgen -source=../gen/go/authpb/auth_grpc.pb.go -destination=../test/mocks/auth_client.go -package=mocks AuthServiceClient
 //go:generate mockgen -source=../gen/go/securitypb/security_grpc.pb.go -destination=../test/mocks/security_client.go -package=mocks SecurityServiceClient
 //go:generate mockgen -source=../gen/go/securitypb/security_public_grpc.pb.go -destination=../test/mocks/security_client_public.go -package=mocks PublicSecurityServiceClient
+//go:generate mockgen -source=../gen/go/securitypb/audit_grpc.pb.go -destination=../test/mocks/audit_client.go -package=mocks AuditServiceClient
 //go:generate mockgen -source=../gen/go/transactionpb/transaction_grpc.pb.go -destination=../test/mocks/transaction_client.go -package=mocks TransactionServiceClient
 //go:generate mockgen -source=../gen/go/brokerpb/broker_grpc.pb.go -destination=../test/mocks/broker_client.go -package=mocks BrokerServiceClient
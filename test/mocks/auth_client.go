@@ -42,6 +42,46 @@ func (m *MockAuthServiceClient) EXPECT() *MockAuthServiceClientMockRecorder {
 	return m.recorder
 }
 
+// DeleteSession mocks base method.
+func (m *MockAuthServiceClient) DeleteSession(ctx context.Context, in *authpb.DeleteSessionRequest, opts ...grpc.CallOption) (*authpb.DeleteSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteSession", varargs...)
+	ret0, _ := ret[0].(*authpb.DeleteSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockAuthServiceClientMockRecorder) DeleteSession(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockAuthServiceClient)(nil).DeleteSession), varargs...)
+}
+
+// DeleteUserSessions mocks base method.
+func (m *MockAuthServiceClient) DeleteUserSessions(ctx context.Context, in *authpb.DeleteUserSessionsRequest, opts ...grpc.CallOption) (*authpb.DeleteUserSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteUserSessions", varargs...)
+	ret0, _ := ret[0].(*authpb.DeleteUserSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserSessions indicates an expected call of DeleteUserSessions.
+func (mr *MockAuthServiceClientMockRecorder) DeleteUserSessions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockAuthServiceClient)(nil).DeleteUserSessions), varargs...)
+}
+
 // ExtractUserID mocks base method.
 func (m *MockAuthServiceClient) ExtractUserID(ctx context.Context, in *authpb.ExtractUserIDRequest, opts ...grpc.CallOption) (*authpb.ExtractUserIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +122,66 @@ func (mr *MockAuthServiceClientMockRecorder) GenerateToken(ctx, in any, opts ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthServiceClient)(nil).GenerateToken), varargs...)
 }
 
+// ImpersonateUser mocks base method.
+func (m *MockAuthServiceClient) ImpersonateUser(ctx context.Context, in *authpb.ImpersonateUserRequest, opts ...grpc.CallOption) (*authpb.ImpersonateUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImpersonateUser", varargs...)
+	ret0, _ := ret[0].(*authpb.ImpersonateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImpersonateUser indicates an expected call of ImpersonateUser.
+func (mr *MockAuthServiceClientMockRecorder) ImpersonateUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImpersonateUser", reflect.TypeOf((*MockAuthServiceClient)(nil).ImpersonateUser), varargs...)
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthServiceClient) ListSessions(ctx context.Context, in *authpb.ListSessionsRequest, opts ...grpc.CallOption) (*authpb.ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSessions", varargs...)
+	ret0, _ := ret[0].(*authpb.ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthServiceClientMockRecorder) ListSessions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthServiceClient)(nil).ListSessions), varargs...)
+}
+
+// UnlockUser mocks base method.
+func (m *MockAuthServiceClient) UnlockUser(ctx context.Context, in *authpb.UnlockUserRequest, opts ...grpc.CallOption) (*authpb.UnlockUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnlockUser", varargs...)
+	ret0, _ := ret[0].(*authpb.UnlockUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockAuthServiceClientMockRecorder) UnlockUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockAuthServiceClient)(nil).UnlockUser), varargs...)
+}
+
 // ValidateToken mocks base method.
 func (m *MockAuthServiceClient) ValidateToken(ctx context.Context, in *authpb.ValidateTokenRequest, opts ...grpc.CallOption) (*authpb.ValidateTokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +226,36 @@ func (m *MockAuthServiceServer) EXPECT() *MockAuthServiceServerMockRecorder {
 	return m.recorder
 }
 
+// DeleteSession mocks base method.
+func (m *MockAuthServiceServer) DeleteSession(arg0 context.Context, arg1 *authpb.DeleteSessionRequest) (*authpb.DeleteSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1)
+	ret0, _ := ret[0].(*authpb.DeleteSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockAuthServiceServerMockRecorder) DeleteSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockAuthServiceServer)(nil).DeleteSession), arg0, arg1)
+}
+
+// DeleteUserSessions mocks base method.
+func (m *MockAuthServiceServer) DeleteUserSessions(arg0 context.Context, arg1 *authpb.DeleteUserSessionsRequest) (*authpb.DeleteUserSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSessions", arg0, arg1)
+	ret0, _ := ret[0].(*authpb.DeleteUserSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserSessions indicates an expected call of DeleteUserSessions.
+func (mr *MockAuthServiceServerMockRecorder) DeleteUserSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockAuthServiceServer)(nil).DeleteUserSessions), arg0, arg1)
+}
+
 // ExtractUserID mocks base method.
 func (m *MockAuthServiceServer) ExtractUserID(arg0 context.Context, arg1 *authpb.ExtractUserIDRequest) (*authpb.ExtractUserIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +286,51 @@ func (mr *MockAuthServiceServerMockRecorder) GenerateToken(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthServiceServer)(nil).GenerateToken), arg0, arg1)
 }
 
+// ImpersonateUser mocks base method.
+func (m *MockAuthServiceServer) ImpersonateUser(arg0 context.Context, arg1 *authpb.ImpersonateUserRequest) (*authpb.ImpersonateUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImpersonateUser", arg0, arg1)
+	ret0, _ := ret[0].(*authpb.ImpersonateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImpersonateUser indicates an expected call of ImpersonateUser.
+func (mr *MockAuthServiceServerMockRecorder) ImpersonateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImpersonateUser", reflect.TypeOf((*MockAuthServiceServer)(nil).ImpersonateUser), arg0, arg1)
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthServiceServer) ListSessions(arg0 context.Context, arg1 *authpb.ListSessionsRequest) (*authpb.ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", arg0, arg1)
+	ret0, _ := ret[0].(*authpb.ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthServiceServerMockRecorder) ListSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthServiceServer)(nil).ListSessions), arg0, arg1)
+}
+
+// UnlockUser mocks base method.
+func (m *MockAuthServiceServer) UnlockUser(arg0 context.Context, arg1 *authpb.UnlockUserRequest) (*authpb.UnlockUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", arg0, arg1)
+	ret0, _ := ret[0].(*authpb.UnlockUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockAuthServiceServerMockRecorder) UnlockUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockAuthServiceServer)(nil).UnlockUser), arg0, arg1)
+}
+
 // ValidateToken mocks base method.
 func (m *MockAuthServiceServer) ValidateToken(arg0 context.Context, arg1 *authpb.ValidateTokenRequest) (*authpb.ValidateTokenResponse, error) {
 	m.ctrl.T.Helper()
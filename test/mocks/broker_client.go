@@ -162,6 +162,26 @@ func (mr *MockBrokerServiceClientMockRecorder) DeleteBrokerUser(ctx, in any, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBrokerUser", reflect.TypeOf((*MockBrokerServiceClient)(nil).DeleteBrokerUser), varargs...)
 }
 
+// DeleteUserBrokers mocks base method.
+func (m *MockBrokerServiceClient) DeleteUserBrokers(ctx context.Context, in *brokerpb.DeleteUserBrokersRequest, opts ...grpc.CallOption) (*brokerpb.DeleteUserBrokersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteUserBrokers", varargs...)
+	ret0, _ := ret[0].(*brokerpb.DeleteUserBrokersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserBrokers indicates an expected call of DeleteUserBrokers.
+func (mr *MockBrokerServiceClientMockRecorder) DeleteUserBrokers(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserBrokers", reflect.TypeOf((*MockBrokerServiceClient)(nil).DeleteUserBrokers), varargs...)
+}
+
 // GetBroker mocks base method.
 func (m *MockBrokerServiceClient) GetBroker(ctx context.Context, in *brokerpb.GetBrokerRequest, opts ...grpc.CallOption) (*brokerpb.GetBrokerResponse, error) {
 	m.ctrl.T.Helper()
@@ -416,6 +436,21 @@ func (mr *MockBrokerServiceServerMockRecorder) DeleteBrokerUser(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBrokerUser", reflect.TypeOf((*MockBrokerServiceServer)(nil).DeleteBrokerUser), arg0, arg1)
 }
 
+// DeleteUserBrokers mocks base method.
+func (m *MockBrokerServiceServer) DeleteUserBrokers(arg0 context.Context, arg1 *brokerpb.DeleteUserBrokersRequest) (*brokerpb.DeleteUserBrokersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserBrokers", arg0, arg1)
+	ret0, _ := ret[0].(*brokerpb.DeleteUserBrokersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserBrokers indicates an expected call of DeleteUserBrokers.
+func (mr *MockBrokerServiceServerMockRecorder) DeleteUserBrokers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserBrokers", reflect.TypeOf((*MockBrokerServiceServer)(nil).DeleteUserBrokers), arg0, arg1)
+}
+
 // GetBroker mocks base method.
 func (m *MockBrokerServiceServer) GetBroker(arg0 context.Context, arg1 *brokerpb.GetBrokerRequest) (*brokerpb.GetBrokerResponse, error) {
 	m.ctrl.T.Helper()
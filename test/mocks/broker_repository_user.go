@@ -69,6 +69,20 @@ func (mr *BrokerUserRepositoryMockRecorder) Delete(userBroker any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*BrokerUserRepository)(nil).Delete), userBroker)
 }
 
+// DeleteAll mocks base method.
+func (m *BrokerUserRepository) DeleteAll(userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *BrokerUserRepositoryMockRecorder) DeleteAll(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*BrokerUserRepository)(nil).DeleteAll), userID)
+}
+
 // Exists mocks base method.
 func (m *BrokerUserRepository) Exists(userBroker models.BrokerUser) (bool, error) {
 	m.ctrl.T.Helper()
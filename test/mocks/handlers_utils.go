@@ -42,6 +42,35 @@ func (m *MockApiUtils) EXPECT() *MockApiUtilsMockRecorder {
 	return m.recorder
 }
 
+// GetClientIP mocks base method.
+func (m *MockApiUtils) GetClientIP(r *http.Request) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientIP", r)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetClientIP indicates an expected call of GetClientIP.
+func (mr *MockApiUtilsMockRecorder) GetClientIP(r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientIP", reflect.TypeOf((*MockApiUtils)(nil).GetClientIP), r)
+}
+
+// GetSessionIDFromContext mocks base method.
+func (m *MockApiUtils) GetSessionIDFromContext(r *http.Request) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionIDFromContext", r)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetSessionIDFromContext indicates an expected call of GetSessionIDFromContext.
+func (mr *MockApiUtilsMockRecorder) GetSessionIDFromContext(r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionIDFromContext", reflect.TypeOf((*MockApiUtils)(nil).GetSessionIDFromContext), r)
+}
+
 // GetUserIDFromContext mocks base method.
 func (m *MockApiUtils) GetUserIDFromContext(r *http.Request) (string, bool) {
 	m.ctrl.T.Helper()
@@ -142,6 +142,26 @@ func (mr *MockSecurityServiceClientMockRecorder) DeleteRole(ctx, in any, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockSecurityServiceClient)(nil).DeleteRole), varargs...)
 }
 
+// DeleteRolesForUser mocks base method.
+func (m *MockSecurityServiceClient) DeleteRolesForUser(ctx context.Context, in *securitypb.DeleteRolesForUserRequest, opts ...grpc.CallOption) (*securitypb.DeleteRolesForUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteRolesForUser", varargs...)
+	ret0, _ := ret[0].(*securitypb.DeleteRolesForUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRolesForUser indicates an expected call of DeleteRolesForUser.
+func (mr *MockSecurityServiceClientMockRecorder) DeleteRolesForUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRolesForUser", reflect.TypeOf((*MockSecurityServiceClient)(nil).DeleteRolesForUser), varargs...)
+}
+
 // GetPermission mocks base method.
 func (m *MockSecurityServiceClient) GetPermission(ctx context.Context, in *securitypb.GetPermissionRequest, opts ...grpc.CallOption) (*securitypb.GetPermissionResponse, error) {
 	m.ctrl.T.Helper()
@@ -182,6 +202,46 @@ func (mr *MockSecurityServiceClientMockRecorder) GetRole(ctx, in any, opts ...an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockSecurityServiceClient)(nil).GetRole), varargs...)
 }
 
+// GrantRoleToUser mocks base method.
+func (m *MockSecurityServiceClient) GrantRoleToUser(ctx context.Context, in *securitypb.GrantRoleToUserRequest, opts ...grpc.CallOption) (*securitypb.GrantRoleToUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GrantRoleToUser", varargs...)
+	ret0, _ := ret[0].(*securitypb.GrantRoleToUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRoleToUser indicates an expected call of GrantRoleToUser.
+func (mr *MockSecurityServiceClientMockRecorder) GrantRoleToUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRoleToUser", reflect.TypeOf((*MockSecurityServiceClient)(nil).GrantRoleToUser), varargs...)
+}
+
+// ListEffectivePermissionsForUser mocks base method.
+func (m *MockSecurityServiceClient) ListEffectivePermissionsForUser(ctx context.Context, in *securitypb.ListEffectivePermissionsForUserRequest, opts ...grpc.CallOption) (*securitypb.ListEffectivePermissionsForUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEffectivePermissionsForUser", varargs...)
+	ret0, _ := ret[0].(*securitypb.ListEffectivePermissionsForUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEffectivePermissionsForUser indicates an expected call of ListEffectivePermissionsForUser.
+func (mr *MockSecurityServiceClientMockRecorder) ListEffectivePermissionsForUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEffectivePermissionsForUser", reflect.TypeOf((*MockSecurityServiceClient)(nil).ListEffectivePermissionsForUser), varargs...)
+}
+
 // ListPermissions mocks base method.
 func (m *MockSecurityServiceClient) ListPermissions(ctx context.Context, in *securitypb.ListPermissionsRequest, opts ...grpc.CallOption) (*securitypb.ListPermissionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -202,6 +262,26 @@ func (mr *MockSecurityServiceClientMockRecorder) ListPermissions(ctx, in any, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockSecurityServiceClient)(nil).ListPermissions), varargs...)
 }
 
+// ListRoleGrantsForUser mocks base method.
+func (m *MockSecurityServiceClient) ListRoleGrantsForUser(ctx context.Context, in *securitypb.ListRoleGrantsForUserRequest, opts ...grpc.CallOption) (*securitypb.ListRoleGrantsForUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRoleGrantsForUser", varargs...)
+	ret0, _ := ret[0].(*securitypb.ListRoleGrantsForUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoleGrantsForUser indicates an expected call of ListRoleGrantsForUser.
+func (mr *MockSecurityServiceClientMockRecorder) ListRoleGrantsForUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoleGrantsForUser", reflect.TypeOf((*MockSecurityServiceClient)(nil).ListRoleGrantsForUser), varargs...)
+}
+
 // ListRolePermissions mocks base method.
 func (m *MockSecurityServiceClient) ListRolePermissions(ctx context.Context, in *securitypb.ListRolePermissionsRequest, opts ...grpc.CallOption) (*securitypb.ListRolePermissionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -342,6 +422,26 @@ func (mr *MockSecurityServiceClientMockRecorder) RemoveUsersFromRole(ctx, in any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUsersFromRole", reflect.TypeOf((*MockSecurityServiceClient)(nil).RemoveUsersFromRole), varargs...)
 }
 
+// SetRoleParents mocks base method.
+func (m *MockSecurityServiceClient) SetRoleParents(ctx context.Context, in *securitypb.SetRoleParentsRequest, opts ...grpc.CallOption) (*securitypb.SetRoleParentsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetRoleParents", varargs...)
+	ret0, _ := ret[0].(*securitypb.SetRoleParentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRoleParents indicates an expected call of SetRoleParents.
+func (mr *MockSecurityServiceClientMockRecorder) SetRoleParents(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoleParents", reflect.TypeOf((*MockSecurityServiceClient)(nil).SetRoleParents), varargs...)
+}
+
 // SetRolePermissions mocks base method.
 func (m *MockSecurityServiceClient) SetRolePermissions(ctx context.Context, in *securitypb.SetRolePermissionsRequest, opts ...grpc.CallOption) (*securitypb.SetRolePermissionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -521,6 +621,21 @@ func (mr *MockSecurityServiceServerMockRecorder) DeleteRole(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockSecurityServiceServer)(nil).DeleteRole), arg0, arg1)
 }
 
+// DeleteRolesForUser mocks base method.
+func (m *MockSecurityServiceServer) DeleteRolesForUser(arg0 context.Context, arg1 *securitypb.DeleteRolesForUserRequest) (*securitypb.DeleteRolesForUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRolesForUser", arg0, arg1)
+	ret0, _ := ret[0].(*securitypb.DeleteRolesForUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRolesForUser indicates an expected call of DeleteRolesForUser.
+func (mr *MockSecurityServiceServerMockRecorder) DeleteRolesForUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRolesForUser", reflect.TypeOf((*MockSecurityServiceServer)(nil).DeleteRolesForUser), arg0, arg1)
+}
+
 // GetPermission mocks base method.
 func (m *MockSecurityServiceServer) GetPermission(arg0 context.Context, arg1 *securitypb.GetPermissionRequest) (*securitypb.GetPermissionResponse, error) {
 	m.ctrl.T.Helper()
@@ -551,6 +666,36 @@ func (mr *MockSecurityServiceServerMockRecorder) GetRole(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockSecurityServiceServer)(nil).GetRole), arg0, arg1)
 }
 
+// GrantRoleToUser mocks base method.
+func (m *MockSecurityServiceServer) GrantRoleToUser(arg0 context.Context, arg1 *securitypb.GrantRoleToUserRequest) (*securitypb.GrantRoleToUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRoleToUser", arg0, arg1)
+	ret0, _ := ret[0].(*securitypb.GrantRoleToUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRoleToUser indicates an expected call of GrantRoleToUser.
+func (mr *MockSecurityServiceServerMockRecorder) GrantRoleToUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRoleToUser", reflect.TypeOf((*MockSecurityServiceServer)(nil).GrantRoleToUser), arg0, arg1)
+}
+
+// ListEffectivePermissionsForUser mocks base method.
+func (m *MockSecurityServiceServer) ListEffectivePermissionsForUser(arg0 context.Context, arg1 *securitypb.ListEffectivePermissionsForUserRequest) (*securitypb.ListEffectivePermissionsForUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEffectivePermissionsForUser", arg0, arg1)
+	ret0, _ := ret[0].(*securitypb.ListEffectivePermissionsForUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEffectivePermissionsForUser indicates an expected call of ListEffectivePermissionsForUser.
+func (mr *MockSecurityServiceServerMockRecorder) ListEffectivePermissionsForUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEffectivePermissionsForUser", reflect.TypeOf((*MockSecurityServiceServer)(nil).ListEffectivePermissionsForUser), arg0, arg1)
+}
+
 // ListPermissions mocks base method.
 func (m *MockSecurityServiceServer) ListPermissions(arg0 context.Context, arg1 *securitypb.ListPermissionsRequest) (*securitypb.ListPermissionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -566,6 +711,21 @@ func (mr *MockSecurityServiceServerMockRecorder) ListPermissions(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockSecurityServiceServer)(nil).ListPermissions), arg0, arg1)
 }
 
+// ListRoleGrantsForUser mocks base method.
+func (m *MockSecurityServiceServer) ListRoleGrantsForUser(arg0 context.Context, arg1 *securitypb.ListRoleGrantsForUserRequest) (*securitypb.ListRoleGrantsForUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoleGrantsForUser", arg0, arg1)
+	ret0, _ := ret[0].(*securitypb.ListRoleGrantsForUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoleGrantsForUser indicates an expected call of ListRoleGrantsForUser.
+func (mr *MockSecurityServiceServerMockRecorder) ListRoleGrantsForUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoleGrantsForUser", reflect.TypeOf((*MockSecurityServiceServer)(nil).ListRoleGrantsForUser), arg0, arg1)
+}
+
 // ListRolePermissions mocks base method.
 func (m *MockSecurityServiceServer) ListRolePermissions(arg0 context.Context, arg1 *securitypb.ListRolePermissionsRequest) (*securitypb.ListRolePermissionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -671,6 +831,21 @@ func (mr *MockSecurityServiceServerMockRecorder) RemoveUsersFromRole(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUsersFromRole", reflect.TypeOf((*MockSecurityServiceServer)(nil).RemoveUsersFromRole), arg0, arg1)
 }
 
+// SetRoleParents mocks base method.
+func (m *MockSecurityServiceServer) SetRoleParents(arg0 context.Context, arg1 *securitypb.SetRoleParentsRequest) (*securitypb.SetRoleParentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRoleParents", arg0, arg1)
+	ret0, _ := ret[0].(*securitypb.SetRoleParentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRoleParents indicates an expected call of SetRoleParents.
+func (mr *MockSecurityServiceServerMockRecorder) SetRoleParents(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoleParents", reflect.TypeOf((*MockSecurityServiceServer)(nil).SetRoleParents), arg0, arg1)
+}
+
 // SetRolePermissions mocks base method.
 func (m *MockSecurityServiceServer) SetRolePermissions(arg0 context.Context, arg1 *securitypb.SetRolePermissionsRequest) (*securitypb.SetRolePermissionsResponse, error) {
 	m.ctrl.T.Helper()
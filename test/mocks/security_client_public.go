@@ -42,6 +42,26 @@ func (m *MockPublicSecurityServiceClient) EXPECT() *MockPublicSecurityServiceCli
 	return m.recorder
 }
 
+// CheckImpersonation mocks base method.
+func (m *MockPublicSecurityServiceClient) CheckImpersonation(ctx context.Context, in *securitypb.CheckImpersonationRequest, opts ...grpc.CallOption) (*securitypb.CheckImpersonationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckImpersonation", varargs...)
+	ret0, _ := ret[0].(*securitypb.CheckImpersonationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckImpersonation indicates an expected call of CheckImpersonation.
+func (mr *MockPublicSecurityServiceClientMockRecorder) CheckImpersonation(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckImpersonation", reflect.TypeOf((*MockPublicSecurityServiceClient)(nil).CheckImpersonation), varargs...)
+}
+
 // CheckPermission mocks base method.
 func (m *MockPublicSecurityServiceClient) CheckPermission(ctx context.Context, in *securitypb.CheckPermissionRequest, opts ...grpc.CallOption) (*securitypb.CheckPermissionResponse, error) {
 	m.ctrl.T.Helper()
@@ -62,6 +82,46 @@ func (mr *MockPublicSecurityServiceClientMockRecorder) CheckPermission(ctx, in a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPermission", reflect.TypeOf((*MockPublicSecurityServiceClient)(nil).CheckPermission), varargs...)
 }
 
+// CheckPermissions mocks base method.
+func (m *MockPublicSecurityServiceClient) CheckPermissions(ctx context.Context, in *securitypb.CheckPermissionsRequest, opts ...grpc.CallOption) (*securitypb.CheckPermissionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckPermissions", varargs...)
+	ret0, _ := ret[0].(*securitypb.CheckPermissionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPermissions indicates an expected call of CheckPermissions.
+func (mr *MockPublicSecurityServiceClientMockRecorder) CheckPermissions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPermissions", reflect.TypeOf((*MockPublicSecurityServiceClient)(nil).CheckPermissions), varargs...)
+}
+
+// CheckResourceAccess mocks base method.
+func (m *MockPublicSecurityServiceClient) CheckResourceAccess(ctx context.Context, in *securitypb.CheckResourceAccessRequest, opts ...grpc.CallOption) (*securitypb.CheckResourceAccessResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckResourceAccess", varargs...)
+	ret0, _ := ret[0].(*securitypb.CheckResourceAccessResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckResourceAccess indicates an expected call of CheckResourceAccess.
+func (mr *MockPublicSecurityServiceClientMockRecorder) CheckResourceAccess(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckResourceAccess", reflect.TypeOf((*MockPublicSecurityServiceClient)(nil).CheckResourceAccess), varargs...)
+}
+
 // MockPublicSecurityServiceServer is a mock of PublicSecurityServiceServer interface.
 type MockPublicSecurityServiceServer struct {
 	ctrl     *gomock.Controller
@@ -86,6 +146,21 @@ func (m *MockPublicSecurityServiceServer) EXPECT() *MockPublicSecurityServiceSer
 	return m.recorder
 }
 
+// CheckImpersonation mocks base method.
+func (m *MockPublicSecurityServiceServer) CheckImpersonation(arg0 context.Context, arg1 *securitypb.CheckImpersonationRequest) (*securitypb.CheckImpersonationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckImpersonation", arg0, arg1)
+	ret0, _ := ret[0].(*securitypb.CheckImpersonationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckImpersonation indicates an expected call of CheckImpersonation.
+func (mr *MockPublicSecurityServiceServerMockRecorder) CheckImpersonation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckImpersonation", reflect.TypeOf((*MockPublicSecurityServiceServer)(nil).CheckImpersonation), arg0, arg1)
+}
+
 // CheckPermission mocks base method.
 func (m *MockPublicSecurityServiceServer) CheckPermission(arg0 context.Context, arg1 *securitypb.CheckPermissionRequest) (*securitypb.CheckPermissionResponse, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +176,36 @@ func (mr *MockPublicSecurityServiceServerMockRecorder) CheckPermission(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPermission", reflect.TypeOf((*MockPublicSecurityServiceServer)(nil).CheckPermission), arg0, arg1)
 }
 
+// CheckPermissions mocks base method.
+func (m *MockPublicSecurityServiceServer) CheckPermissions(arg0 context.Context, arg1 *securitypb.CheckPermissionsRequest) (*securitypb.CheckPermissionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPermissions", arg0, arg1)
+	ret0, _ := ret[0].(*securitypb.CheckPermissionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPermissions indicates an expected call of CheckPermissions.
+func (mr *MockPublicSecurityServiceServerMockRecorder) CheckPermissions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPermissions", reflect.TypeOf((*MockPublicSecurityServiceServer)(nil).CheckPermissions), arg0, arg1)
+}
+
+// CheckResourceAccess mocks base method.
+func (m *MockPublicSecurityServiceServer) CheckResourceAccess(arg0 context.Context, arg1 *securitypb.CheckResourceAccessRequest) (*securitypb.CheckResourceAccessResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckResourceAccess", arg0, arg1)
+	ret0, _ := ret[0].(*securitypb.CheckResourceAccessResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckResourceAccess indicates an expected call of CheckResourceAccess.
+func (mr *MockPublicSecurityServiceServerMockRecorder) CheckResourceAccess(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckResourceAccess", reflect.TypeOf((*MockPublicSecurityServiceServer)(nil).CheckResourceAccess), arg0, arg1)
+}
+
 // mustEmbedUnimplementedPublicSecurityServiceServer mocks base method.
 func (m *MockPublicSecurityServiceServer) mustEmbedUnimplementedPublicSecurityServiceServer() {
 	m.ctrl.T.Helper()
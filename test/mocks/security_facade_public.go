@@ -41,6 +41,21 @@ func (m *MockPermissionChecker) EXPECT() *MockPermissionCheckerMockRecorder {
 	return m.recorder
 }
 
+// CheckImpersonation mocks base method.
+func (m *MockPermissionChecker) CheckImpersonation(ctx context.Context, req *securitypb.CheckImpersonationRequest) (*securitypb.CheckImpersonationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckImpersonation", ctx, req)
+	ret0, _ := ret[0].(*securitypb.CheckImpersonationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckImpersonation indicates an expected call of CheckImpersonation.
+func (mr *MockPermissionCheckerMockRecorder) CheckImpersonation(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckImpersonation", reflect.TypeOf((*MockPermissionChecker)(nil).CheckImpersonation), ctx, req)
+}
+
 // CheckPermission mocks base method.
 func (m *MockPermissionChecker) CheckPermission(ctx context.Context, req *securitypb.CheckPermissionRequest) (*securitypb.CheckPermissionResponse, error) {
 	m.ctrl.T.Helper()
@@ -55,3 +70,18 @@ func (mr *MockPermissionCheckerMockRecorder) CheckPermission(ctx, req any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPermission", reflect.TypeOf((*MockPermissionChecker)(nil).CheckPermission), ctx, req)
 }
+
+// CheckResourceAccess mocks base method.
+func (m *MockPermissionChecker) CheckResourceAccess(ctx context.Context, req *securitypb.CheckResourceAccessRequest) (*securitypb.CheckResourceAccessResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckResourceAccess", ctx, req)
+	ret0, _ := ret[0].(*securitypb.CheckResourceAccessResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckResourceAccess indicates an expected call of CheckResourceAccess.
+func (mr *MockPermissionCheckerMockRecorder) CheckResourceAccess(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckResourceAccess", reflect.TypeOf((*MockPermissionChecker)(nil).CheckResourceAccess), ctx, req)
+}
@@ -84,6 +84,21 @@ func (mr *SecurityRoleRepositoryMockRecorder) Delete(uuid any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*SecurityRoleRepository)(nil).Delete), uuid)
 }
 
+// DeleteExpiredGrants mocks base method.
+func (m *SecurityRoleRepository) DeleteExpiredGrants() (models.RoleGrants, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredGrants")
+	ret0, _ := ret[0].(models.RoleGrants)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredGrants indicates an expected call of DeleteExpiredGrants.
+func (mr *SecurityRoleRepositoryMockRecorder) DeleteExpiredGrants() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredGrants", reflect.TypeOf((*SecurityRoleRepository)(nil).DeleteExpiredGrants))
+}
+
 // Get mocks base method.
 func (m *SecurityRoleRepository) Get(uuid uuid.UUID) (models.Role, bool, error) {
 	m.ctrl.T.Helper()
@@ -132,6 +147,20 @@ func (mr *SecurityRoleRepositoryMockRecorder) GetWithPermissions(uuid any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithPermissions", reflect.TypeOf((*SecurityRoleRepository)(nil).GetWithPermissions), uuid)
 }
 
+// GrantToUser mocks base method.
+func (m *SecurityRoleRepository) GrantToUser(grant models.RoleGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantToUser", grant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantToUser indicates an expected call of GrantToUser.
+func (mr *SecurityRoleRepositoryMockRecorder) GrantToUser(grant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantToUser", reflect.TypeOf((*SecurityRoleRepository)(nil).GrantToUser), grant)
+}
+
 // List mocks base method.
 func (m *SecurityRoleRepository) List() (models.Roles, error) {
 	m.ctrl.T.Helper()
@@ -162,6 +191,66 @@ func (mr *SecurityRoleRepositoryMockRecorder) ListByUserId(userUUID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserId", reflect.TypeOf((*SecurityRoleRepository)(nil).ListByUserId), userUUID)
 }
 
+// ListActiveUsersByRoleId mocks base method.
+func (m *SecurityRoleRepository) ListActiveUsersByRoleId(roleUUID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveUsersByRoleId", roleUUID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveUsersByRoleId indicates an expected call of ListActiveUsersByRoleId.
+func (mr *SecurityRoleRepositoryMockRecorder) ListActiveUsersByRoleId(roleUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveUsersByRoleId", reflect.TypeOf((*SecurityRoleRepository)(nil).ListActiveUsersByRoleId), roleUUID)
+}
+
+// ListByUserIds mocks base method.
+func (m *SecurityRoleRepository) ListByUserIds(userUUIDs []uuid.UUID) (map[uuid.UUID]models.Roles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserIds", userUUIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]models.Roles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserIds indicates an expected call of ListByUserIds.
+func (mr *SecurityRoleRepositoryMockRecorder) ListByUserIds(userUUIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserIds", reflect.TypeOf((*SecurityRoleRepository)(nil).ListByUserIds), userUUIDs)
+}
+
+// ListGrantsByUserId mocks base method.
+func (m *SecurityRoleRepository) ListGrantsByUserId(userUUID uuid.UUID) (models.RoleGrants, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGrantsByUserId", userUUID)
+	ret0, _ := ret[0].(models.RoleGrants)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGrantsByUserId indicates an expected call of ListGrantsByUserId.
+func (mr *SecurityRoleRepositoryMockRecorder) ListGrantsByUserId(userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGrantsByUserId", reflect.TypeOf((*SecurityRoleRepository)(nil).ListGrantsByUserId), userUUID)
+}
+
+// ListHierarchy mocks base method.
+func (m *SecurityRoleRepository) ListHierarchy() (models.RoleHierarchy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHierarchy")
+	ret0, _ := ret[0].(models.RoleHierarchy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHierarchy indicates an expected call of ListHierarchy.
+func (mr *SecurityRoleRepositoryMockRecorder) ListHierarchy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHierarchy", reflect.TypeOf((*SecurityRoleRepository)(nil).ListHierarchy))
+}
+
 // ListPermissionsByRoleId mocks base method.
 func (m *SecurityRoleRepository) ListPermissionsByRoleId(roleUUID uuid.UUID) (models.Permissions, error) {
 	m.ctrl.T.Helper()
@@ -192,21 +281,6 @@ func (mr *SecurityRoleRepositoryMockRecorder) ListPermissionsByUserId(userUUID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissionsByUserId", reflect.TypeOf((*SecurityRoleRepository)(nil).ListPermissionsByUserId), userUUID)
 }
 
-// ListUsers mocks base method.
-func (m *SecurityRoleRepository) ListUsers() ([]string, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers")
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// ListUsers indicates an expected call of ListUsers.
-func (mr *SecurityRoleRepositoryMockRecorder) ListUsers() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*SecurityRoleRepository)(nil).ListUsers))
-}
-
 // ListUsersByRoleId mocks base method.
 func (m *SecurityRoleRepository) ListUsersByRoleId(roleUUID uuid.UUID) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -280,6 +354,20 @@ func (mr *SecurityRoleRepositoryMockRecorder) SetForUser(userUUID, roleUUIDs any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetForUser", reflect.TypeOf((*SecurityRoleRepository)(nil).SetForUser), userUUID, roleUUIDs)
 }
 
+// SetParentsByRoleId mocks base method.
+func (m *SecurityRoleRepository) SetParentsByRoleId(roleUUID uuid.UUID, parentUUIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetParentsByRoleId", roleUUID, parentUUIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetParentsByRoleId indicates an expected call of SetParentsByRoleId.
+func (mr *SecurityRoleRepositoryMockRecorder) SetParentsByRoleId(roleUUID, parentUUIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParentsByRoleId", reflect.TypeOf((*SecurityRoleRepository)(nil).SetParentsByRoleId), roleUUID, parentUUIDs)
+}
+
 // SetPermissionsByRoleId mocks base method.
 func (m *SecurityRoleRepository) SetPermissionsByRoleId(roleUUID uuid.UUID, permissionUUIDs []uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -102,6 +102,26 @@ func (mr *MockTransactionServiceClientMockRecorder) DeleteTransactionByBroker(ct
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransactionByBroker", reflect.TypeOf((*MockTransactionServiceClient)(nil).DeleteTransactionByBroker), varargs...)
 }
 
+// DeleteUserTransactions mocks base method.
+func (m *MockTransactionServiceClient) DeleteUserTransactions(ctx context.Context, in *transactionpb.DeleteUserTransactionsRequest, opts ...grpc.CallOption) (*transactionpb.DeleteUserTransactionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteUserTransactions", varargs...)
+	ret0, _ := ret[0].(*transactionpb.DeleteUserTransactionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserTransactions indicates an expected call of DeleteUserTransactions.
+func (mr *MockTransactionServiceClientMockRecorder) DeleteUserTransactions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTransactions", reflect.TypeOf((*MockTransactionServiceClient)(nil).DeleteUserTransactions), varargs...)
+}
+
 // GetTransaction mocks base method.
 func (m *MockTransactionServiceClient) GetTransaction(ctx context.Context, in *transactionpb.GetTransactionRequest, opts ...grpc.CallOption) (*transactionpb.GetTransactionResponse, error) {
 	m.ctrl.T.Helper()
@@ -231,6 +251,21 @@ func (mr *MockTransactionServiceServerMockRecorder) DeleteTransactionByBroker(ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransactionByBroker", reflect.TypeOf((*MockTransactionServiceServer)(nil).DeleteTransactionByBroker), arg0, arg1)
 }
 
+// DeleteUserTransactions mocks base method.
+func (m *MockTransactionServiceServer) DeleteUserTransactions(arg0 context.Context, arg1 *transactionpb.DeleteUserTransactionsRequest) (*transactionpb.DeleteUserTransactionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTransactions", arg0, arg1)
+	ret0, _ := ret[0].(*transactionpb.DeleteUserTransactionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserTransactions indicates an expected call of DeleteUserTransactions.
+func (mr *MockTransactionServiceServerMockRecorder) DeleteUserTransactions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTransactions", reflect.TypeOf((*MockTransactionServiceServer)(nil).DeleteUserTransactions), arg0, arg1)
+}
+
 // GetTransaction mocks base method.
 func (m *MockTransactionServiceServer) GetTransaction(arg0 context.Context, arg1 *transactionpb.GetTransactionRequest) (*transactionpb.GetTransactionResponse, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +70,20 @@ func (mr *TransactionsRepositoryMockRecorder) Delete(transaction any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*TransactionsRepository)(nil).Delete), transaction)
 }
 
+// DeleteAll mocks base method.
+func (m *TransactionsRepository) DeleteAll(userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *TransactionsRepositoryMockRecorder) DeleteAll(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*TransactionsRepository)(nil).DeleteAll), userID)
+}
+
 // DeleteByBroker mocks base method.
 func (m *TransactionsRepository) DeleteByBroker(transaction models.Transaction) error {
 	m.ctrl.T.Helper()
@@ -102,6 +102,66 @@ func (mr *MockUserServiceClientMockRecorder) DeleteUser(ctx, in any, opts ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServiceClient)(nil).DeleteUser), varargs...)
 }
 
+// DeleteUserAvatar mocks base method.
+func (m *MockUserServiceClient) DeleteUserAvatar(ctx context.Context, in *userpb.DeleteUserAvatarRequest, opts ...grpc.CallOption) (*userpb.DeleteUserAvatarResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteUserAvatar", varargs...)
+	ret0, _ := ret[0].(*userpb.DeleteUserAvatarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserAvatar indicates an expected call of DeleteUserAvatar.
+func (mr *MockUserServiceClientMockRecorder) DeleteUserAvatar(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserAvatar", reflect.TypeOf((*MockUserServiceClient)(nil).DeleteUserAvatar), varargs...)
+}
+
+// ForcePasswordReset mocks base method.
+func (m *MockUserServiceClient) ForcePasswordReset(ctx context.Context, in *userpb.ForcePasswordResetRequest, opts ...grpc.CallOption) (*userpb.ForcePasswordResetResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ForcePasswordReset", varargs...)
+	ret0, _ := ret[0].(*userpb.ForcePasswordResetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForcePasswordReset indicates an expected call of ForcePasswordReset.
+func (mr *MockUserServiceClientMockRecorder) ForcePasswordReset(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePasswordReset", reflect.TypeOf((*MockUserServiceClient)(nil).ForcePasswordReset), varargs...)
+}
+
+// GetOutboxEmail mocks base method.
+func (m *MockUserServiceClient) GetOutboxEmail(ctx context.Context, in *userpb.GetOutboxEmailRequest, opts ...grpc.CallOption) (*userpb.GetOutboxEmailResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOutboxEmail", varargs...)
+	ret0, _ := ret[0].(*userpb.GetOutboxEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxEmail indicates an expected call of GetOutboxEmail.
+func (mr *MockUserServiceClientMockRecorder) GetOutboxEmail(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxEmail", reflect.TypeOf((*MockUserServiceClient)(nil).GetOutboxEmail), varargs...)
+}
+
 // GetUser mocks base method.
 func (m *MockUserServiceClient) GetUser(ctx context.Context, in *userpb.GetUserRequest, opts ...grpc.CallOption) (*userpb.GetUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +182,66 @@ func (mr *MockUserServiceClientMockRecorder) GetUser(ctx, in any, opts ...any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserServiceClient)(nil).GetUser), varargs...)
 }
 
+// GetUserAvatar mocks base method.
+func (m *MockUserServiceClient) GetUserAvatar(ctx context.Context, in *userpb.GetUserAvatarRequest, opts ...grpc.CallOption) (*userpb.GetUserAvatarResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUserAvatar", varargs...)
+	ret0, _ := ret[0].(*userpb.GetUserAvatarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAvatar indicates an expected call of GetUserAvatar.
+func (mr *MockUserServiceClientMockRecorder) GetUserAvatar(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAvatar", reflect.TypeOf((*MockUserServiceClient)(nil).GetUserAvatar), varargs...)
+}
+
+// ListOutboxEmails mocks base method.
+func (m *MockUserServiceClient) ListOutboxEmails(ctx context.Context, in *userpb.ListOutboxEmailsRequest, opts ...grpc.CallOption) (*userpb.ListOutboxEmailsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListOutboxEmails", varargs...)
+	ret0, _ := ret[0].(*userpb.ListOutboxEmailsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOutboxEmails indicates an expected call of ListOutboxEmails.
+func (mr *MockUserServiceClientMockRecorder) ListOutboxEmails(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutboxEmails", reflect.TypeOf((*MockUserServiceClient)(nil).ListOutboxEmails), varargs...)
+}
+
+// ListUserLogins mocks base method.
+func (m *MockUserServiceClient) ListUserLogins(ctx context.Context, in *userpb.ListUserLoginsRequest, opts ...grpc.CallOption) (*userpb.ListUserLoginsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUserLogins", varargs...)
+	ret0, _ := ret[0].(*userpb.ListUserLoginsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLogins indicates an expected call of ListUserLogins.
+func (mr *MockUserServiceClientMockRecorder) ListUserLogins(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLogins", reflect.TypeOf((*MockUserServiceClient)(nil).ListUserLogins), varargs...)
+}
+
 // ListUsers mocks base method.
 func (m *MockUserServiceClient) ListUsers(ctx context.Context, in *userpb.ListUsersRequest, opts ...grpc.CallOption) (*userpb.ListUsersResponse, error) {
 	m.ctrl.T.Helper()
@@ -142,6 +262,106 @@ func (mr *MockUserServiceClientMockRecorder) ListUsers(ctx, in any, opts ...any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserServiceClient)(nil).ListUsers), varargs...)
 }
 
+// PreviewEmailTemplate mocks base method.
+func (m *MockUserServiceClient) PreviewEmailTemplate(ctx context.Context, in *userpb.PreviewEmailTemplateRequest, opts ...grpc.CallOption) (*userpb.PreviewEmailTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PreviewEmailTemplate", varargs...)
+	ret0, _ := ret[0].(*userpb.PreviewEmailTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewEmailTemplate indicates an expected call of PreviewEmailTemplate.
+func (mr *MockUserServiceClientMockRecorder) PreviewEmailTemplate(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewEmailTemplate", reflect.TypeOf((*MockUserServiceClient)(nil).PreviewEmailTemplate), varargs...)
+}
+
+// ResendEmailVerification mocks base method.
+func (m *MockUserServiceClient) ResendEmailVerification(ctx context.Context, in *userpb.ResendEmailVerificationRequest, opts ...grpc.CallOption) (*userpb.ResendEmailVerificationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResendEmailVerification", varargs...)
+	ret0, _ := ret[0].(*userpb.ResendEmailVerificationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResendEmailVerification indicates an expected call of ResendEmailVerification.
+func (mr *MockUserServiceClientMockRecorder) ResendEmailVerification(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendEmailVerification", reflect.TypeOf((*MockUserServiceClient)(nil).ResendEmailVerification), varargs...)
+}
+
+// RetryOutboxEmail mocks base method.
+func (m *MockUserServiceClient) RetryOutboxEmail(ctx context.Context, in *userpb.RetryOutboxEmailRequest, opts ...grpc.CallOption) (*userpb.RetryOutboxEmailResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RetryOutboxEmail", varargs...)
+	ret0, _ := ret[0].(*userpb.RetryOutboxEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryOutboxEmail indicates an expected call of RetryOutboxEmail.
+func (mr *MockUserServiceClientMockRecorder) RetryOutboxEmail(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryOutboxEmail", reflect.TypeOf((*MockUserServiceClient)(nil).RetryOutboxEmail), varargs...)
+}
+
+// SetUserAvatar mocks base method.
+func (m *MockUserServiceClient) SetUserAvatar(ctx context.Context, in *userpb.SetUserAvatarRequest, opts ...grpc.CallOption) (*userpb.SetUserAvatarResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetUserAvatar", varargs...)
+	ret0, _ := ret[0].(*userpb.SetUserAvatarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserAvatar indicates an expected call of SetUserAvatar.
+func (mr *MockUserServiceClientMockRecorder) SetUserAvatar(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAvatar", reflect.TypeOf((*MockUserServiceClient)(nil).SetUserAvatar), varargs...)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockUserServiceClient) SetUserDisabled(ctx context.Context, in *userpb.SetUserDisabledRequest, opts ...grpc.CallOption) (*userpb.SetUserDisabledResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetUserDisabled", varargs...)
+	ret0, _ := ret[0].(*userpb.SetUserDisabledResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockUserServiceClientMockRecorder) SetUserDisabled(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockUserServiceClient)(nil).SetUserDisabled), varargs...)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserServiceClient) UpdateUser(ctx context.Context, in *userpb.UpdateUserRequest, opts ...grpc.CallOption) (*userpb.UpdateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -182,6 +402,46 @@ func (mr *MockUserServiceClientMockRecorder) UpdateUserPassword(ctx, in any, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockUserServiceClient)(nil).UpdateUserPassword), varargs...)
 }
 
+// UpdateUserProfile mocks base method.
+func (m *MockUserServiceClient) UpdateUserProfile(ctx context.Context, in *userpb.UpdateUserProfileRequest, opts ...grpc.CallOption) (*userpb.UpdateUserProfileResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateUserProfile", varargs...)
+	ret0, _ := ret[0].(*userpb.UpdateUserProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockUserServiceClientMockRecorder) UpdateUserProfile(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserServiceClient)(nil).UpdateUserProfile), varargs...)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockUserServiceClient) VerifyEmail(ctx context.Context, in *userpb.VerifyEmailRequest, opts ...grpc.CallOption) (*userpb.VerifyEmailResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyEmail", varargs...)
+	ret0, _ := ret[0].(*userpb.VerifyEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserServiceClientMockRecorder) VerifyEmail(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserServiceClient)(nil).VerifyEmail), varargs...)
+}
+
 // MockUserServiceServer is a mock of UserServiceServer interface.
 type MockUserServiceServer struct {
 	ctrl     *gomock.Controller
@@ -251,6 +511,51 @@ func (mr *MockUserServiceServerMockRecorder) DeleteUser(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServiceServer)(nil).DeleteUser), arg0, arg1)
 }
 
+// DeleteUserAvatar mocks base method.
+func (m *MockUserServiceServer) DeleteUserAvatar(arg0 context.Context, arg1 *userpb.DeleteUserAvatarRequest) (*userpb.DeleteUserAvatarResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserAvatar", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.DeleteUserAvatarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserAvatar indicates an expected call of DeleteUserAvatar.
+func (mr *MockUserServiceServerMockRecorder) DeleteUserAvatar(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserAvatar", reflect.TypeOf((*MockUserServiceServer)(nil).DeleteUserAvatar), arg0, arg1)
+}
+
+// ForcePasswordReset mocks base method.
+func (m *MockUserServiceServer) ForcePasswordReset(arg0 context.Context, arg1 *userpb.ForcePasswordResetRequest) (*userpb.ForcePasswordResetResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForcePasswordReset", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.ForcePasswordResetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForcePasswordReset indicates an expected call of ForcePasswordReset.
+func (mr *MockUserServiceServerMockRecorder) ForcePasswordReset(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePasswordReset", reflect.TypeOf((*MockUserServiceServer)(nil).ForcePasswordReset), arg0, arg1)
+}
+
+// GetOutboxEmail mocks base method.
+func (m *MockUserServiceServer) GetOutboxEmail(arg0 context.Context, arg1 *userpb.GetOutboxEmailRequest) (*userpb.GetOutboxEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxEmail", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.GetOutboxEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxEmail indicates an expected call of GetOutboxEmail.
+func (mr *MockUserServiceServerMockRecorder) GetOutboxEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxEmail", reflect.TypeOf((*MockUserServiceServer)(nil).GetOutboxEmail), arg0, arg1)
+}
+
 // GetUser mocks base method.
 func (m *MockUserServiceServer) GetUser(arg0 context.Context, arg1 *userpb.GetUserRequest) (*userpb.GetUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -266,6 +571,51 @@ func (mr *MockUserServiceServerMockRecorder) GetUser(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserServiceServer)(nil).GetUser), arg0, arg1)
 }
 
+// GetUserAvatar mocks base method.
+func (m *MockUserServiceServer) GetUserAvatar(arg0 context.Context, arg1 *userpb.GetUserAvatarRequest) (*userpb.GetUserAvatarResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAvatar", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.GetUserAvatarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAvatar indicates an expected call of GetUserAvatar.
+func (mr *MockUserServiceServerMockRecorder) GetUserAvatar(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAvatar", reflect.TypeOf((*MockUserServiceServer)(nil).GetUserAvatar), arg0, arg1)
+}
+
+// ListOutboxEmails mocks base method.
+func (m *MockUserServiceServer) ListOutboxEmails(arg0 context.Context, arg1 *userpb.ListOutboxEmailsRequest) (*userpb.ListOutboxEmailsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOutboxEmails", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.ListOutboxEmailsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOutboxEmails indicates an expected call of ListOutboxEmails.
+func (mr *MockUserServiceServerMockRecorder) ListOutboxEmails(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutboxEmails", reflect.TypeOf((*MockUserServiceServer)(nil).ListOutboxEmails), arg0, arg1)
+}
+
+// ListUserLogins mocks base method.
+func (m *MockUserServiceServer) ListUserLogins(arg0 context.Context, arg1 *userpb.ListUserLoginsRequest) (*userpb.ListUserLoginsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLogins", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.ListUserLoginsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLogins indicates an expected call of ListUserLogins.
+func (mr *MockUserServiceServerMockRecorder) ListUserLogins(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLogins", reflect.TypeOf((*MockUserServiceServer)(nil).ListUserLogins), arg0, arg1)
+}
+
 // ListUsers mocks base method.
 func (m *MockUserServiceServer) ListUsers(arg0 context.Context, arg1 *userpb.ListUsersRequest) (*userpb.ListUsersResponse, error) {
 	m.ctrl.T.Helper()
@@ -281,6 +631,81 @@ func (mr *MockUserServiceServerMockRecorder) ListUsers(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserServiceServer)(nil).ListUsers), arg0, arg1)
 }
 
+// PreviewEmailTemplate mocks base method.
+func (m *MockUserServiceServer) PreviewEmailTemplate(arg0 context.Context, arg1 *userpb.PreviewEmailTemplateRequest) (*userpb.PreviewEmailTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewEmailTemplate", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.PreviewEmailTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewEmailTemplate indicates an expected call of PreviewEmailTemplate.
+func (mr *MockUserServiceServerMockRecorder) PreviewEmailTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewEmailTemplate", reflect.TypeOf((*MockUserServiceServer)(nil).PreviewEmailTemplate), arg0, arg1)
+}
+
+// ResendEmailVerification mocks base method.
+func (m *MockUserServiceServer) ResendEmailVerification(arg0 context.Context, arg1 *userpb.ResendEmailVerificationRequest) (*userpb.ResendEmailVerificationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendEmailVerification", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.ResendEmailVerificationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResendEmailVerification indicates an expected call of ResendEmailVerification.
+func (mr *MockUserServiceServerMockRecorder) ResendEmailVerification(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendEmailVerification", reflect.TypeOf((*MockUserServiceServer)(nil).ResendEmailVerification), arg0, arg1)
+}
+
+// RetryOutboxEmail mocks base method.
+func (m *MockUserServiceServer) RetryOutboxEmail(arg0 context.Context, arg1 *userpb.RetryOutboxEmailRequest) (*userpb.RetryOutboxEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryOutboxEmail", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.RetryOutboxEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryOutboxEmail indicates an expected call of RetryOutboxEmail.
+func (mr *MockUserServiceServerMockRecorder) RetryOutboxEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryOutboxEmail", reflect.TypeOf((*MockUserServiceServer)(nil).RetryOutboxEmail), arg0, arg1)
+}
+
+// SetUserAvatar mocks base method.
+func (m *MockUserServiceServer) SetUserAvatar(arg0 context.Context, arg1 *userpb.SetUserAvatarRequest) (*userpb.SetUserAvatarResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserAvatar", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.SetUserAvatarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserAvatar indicates an expected call of SetUserAvatar.
+func (mr *MockUserServiceServerMockRecorder) SetUserAvatar(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAvatar", reflect.TypeOf((*MockUserServiceServer)(nil).SetUserAvatar), arg0, arg1)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockUserServiceServer) SetUserDisabled(arg0 context.Context, arg1 *userpb.SetUserDisabledRequest) (*userpb.SetUserDisabledResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDisabled", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.SetUserDisabledResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockUserServiceServerMockRecorder) SetUserDisabled(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockUserServiceServer)(nil).SetUserDisabled), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserServiceServer) UpdateUser(arg0 context.Context, arg1 *userpb.UpdateUserRequest) (*userpb.UpdateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -311,6 +736,36 @@ func (mr *MockUserServiceServerMockRecorder) UpdateUserPassword(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockUserServiceServer)(nil).UpdateUserPassword), arg0, arg1)
 }
 
+// UpdateUserProfile mocks base method.
+func (m *MockUserServiceServer) UpdateUserProfile(arg0 context.Context, arg1 *userpb.UpdateUserProfileRequest) (*userpb.UpdateUserProfileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.UpdateUserProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockUserServiceServerMockRecorder) UpdateUserProfile(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserServiceServer)(nil).UpdateUserProfile), arg0, arg1)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockUserServiceServer) VerifyEmail(arg0 context.Context, arg1 *userpb.VerifyEmailRequest) (*userpb.VerifyEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.VerifyEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserServiceServerMockRecorder) VerifyEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserServiceServer)(nil).VerifyEmail), arg0, arg1)
+}
+
 // mustEmbedUnimplementedUserServiceServer mocks base method.
 func (m *MockUserServiceServer) mustEmbedUnimplementedUserServiceServer() {
 	m.ctrl.T.Helper()
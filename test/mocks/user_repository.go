@@ -11,6 +11,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	models "github.com/Zapharaos/fihub-backend/internal/models"
 	uuid "github.com/google/uuid"
@@ -57,6 +58,20 @@ func (mr *UserRepositoryMockRecorder) Authenticate(email, password any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*UserRepository)(nil).Authenticate), email, password)
 }
 
+// CancelDeletion mocks base method.
+func (m *UserRepository) CancelDeletion(userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelDeletion", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelDeletion indicates an expected call of CancelDeletion.
+func (mr *UserRepositoryMockRecorder) CancelDeletion(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDeletion", reflect.TypeOf((*UserRepository)(nil).CancelDeletion), userID)
+}
+
 // Create mocks base method.
 func (m *UserRepository) Create(user models.UserWithPassword) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +101,48 @@ func (mr *UserRepositoryMockRecorder) Delete(userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*UserRepository)(nil).Delete), userID)
 }
 
+// DeleteAvatar mocks base method.
+func (m *UserRepository) DeleteAvatar(userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvatar", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAvatar indicates an expected call of DeleteAvatar.
+func (mr *UserRepositoryMockRecorder) DeleteAvatar(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatar", reflect.TypeOf((*UserRepository)(nil).DeleteAvatar), userID)
+}
+
+// Disable mocks base method.
+func (m *UserRepository) Disable(userID uuid.UUID, disabledAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", userID, disabledAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *UserRepositoryMockRecorder) Disable(userID, disabledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*UserRepository)(nil).Disable), userID, disabledAt)
+}
+
+// Enable mocks base method.
+func (m *UserRepository) Enable(userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *UserRepositoryMockRecorder) Enable(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*UserRepository)(nil).Enable), userID)
+}
+
 // Exists mocks base method.
 func (m *UserRepository) Exists(email string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -117,6 +174,22 @@ func (mr *UserRepositoryMockRecorder) Get(userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*UserRepository)(nil).Get), userID)
 }
 
+// GetAvatar mocks base method.
+func (m *UserRepository) GetAvatar(userID uuid.UUID) (models.UserAvatar, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatar", userID)
+	ret0, _ := ret[0].(models.UserAvatar)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAvatar indicates an expected call of GetAvatar.
+func (mr *UserRepositoryMockRecorder) GetAvatar(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatar", reflect.TypeOf((*UserRepository)(nil).GetAvatar), userID)
+}
+
 // GetByEmail mocks base method.
 func (m *UserRepository) GetByEmail(email string) (models.User, bool, error) {
 	m.ctrl.T.Helper()
@@ -133,19 +206,120 @@ func (mr *UserRepositoryMockRecorder) GetByEmail(email any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*UserRepository)(nil).GetByEmail), email)
 }
 
+// HasLoggedInFrom mocks base method.
+func (m *UserRepository) HasLoggedInFrom(userID uuid.UUID, userAgent string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasLoggedInFrom", userID, userAgent)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasLoggedInFrom indicates an expected call of HasLoggedInFrom.
+func (mr *UserRepositoryMockRecorder) HasLoggedInFrom(userID, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLoggedInFrom", reflect.TypeOf((*UserRepository)(nil).HasLoggedInFrom), userID, userAgent)
+}
+
 // List mocks base method.
-func (m *UserRepository) List() (models.Users, error) {
+func (m *UserRepository) List(filter models.UserFilter) (models.Users, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List")
+	ret := m.ctrl.Call(m, "List", filter)
 	ret0, _ := ret[0].(models.Users)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *UserRepositoryMockRecorder) List() *gomock.Call {
+func (mr *UserRepositoryMockRecorder) List(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*UserRepository)(nil).List))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*UserRepository)(nil).List), filter)
+}
+
+// ListDueForDeletion mocks base method.
+func (m *UserRepository) ListDueForDeletion(at time.Time) (models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueForDeletion", at)
+	ret0, _ := ret[0].(models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueForDeletion indicates an expected call of ListDueForDeletion.
+func (mr *UserRepositoryMockRecorder) ListDueForDeletion(at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueForDeletion", reflect.TypeOf((*UserRepository)(nil).ListDueForDeletion), at)
+}
+
+// ListLogins mocks base method.
+func (m *UserRepository) ListLogins(userID uuid.UUID, limit int) (models.UserLogins, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogins", userID, limit)
+	ret0, _ := ret[0].(models.UserLogins)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogins indicates an expected call of ListLogins.
+func (mr *UserRepositoryMockRecorder) ListLogins(userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogins", reflect.TypeOf((*UserRepository)(nil).ListLogins), userID, limit)
+}
+
+// RecordLogin mocks base method.
+func (m *UserRepository) RecordLogin(login models.UserLogin, historySize int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLogin", login, historySize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordLogin indicates an expected call of RecordLogin.
+func (mr *UserRepositoryMockRecorder) RecordLogin(login, historySize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLogin", reflect.TypeOf((*UserRepository)(nil).RecordLogin), login, historySize)
+}
+
+// RequirePasswordReset mocks base method.
+func (m *UserRepository) RequirePasswordReset(userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequirePasswordReset", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequirePasswordReset indicates an expected call of RequirePasswordReset.
+func (mr *UserRepositoryMockRecorder) RequirePasswordReset(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequirePasswordReset", reflect.TypeOf((*UserRepository)(nil).RequirePasswordReset), userID)
+}
+
+// ScheduleDeletion mocks base method.
+func (m *UserRepository) ScheduleDeletion(userID uuid.UUID, scheduledAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleDeletion", userID, scheduledAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleDeletion indicates an expected call of ScheduleDeletion.
+func (mr *UserRepositoryMockRecorder) ScheduleDeletion(userID, scheduledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleDeletion", reflect.TypeOf((*UserRepository)(nil).ScheduleDeletion), userID, scheduledAt)
+}
+
+// SetAvatar mocks base method.
+func (m *UserRepository) SetAvatar(avatar models.UserAvatar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvatar", avatar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAvatar indicates an expected call of SetAvatar.
+func (mr *UserRepositoryMockRecorder) SetAvatar(avatar any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvatar", reflect.TypeOf((*UserRepository)(nil).SetAvatar), avatar)
 }
 
 // Update mocks base method.
@@ -162,6 +336,20 @@ func (mr *UserRepositoryMockRecorder) Update(user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*UserRepository)(nil).Update), user)
 }
 
+// UpdateProfile mocks base method.
+func (m *UserRepository) UpdateProfile(userID uuid.UUID, profile models.UserProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", userID, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *UserRepositoryMockRecorder) UpdateProfile(userID, profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*UserRepository)(nil).UpdateProfile), userID, profile)
+}
+
 // UpdateWithPassword mocks base method.
 func (m *UserRepository) UpdateWithPassword(user models.UserWithPassword) error {
 	m.ctrl.T.Helper()